@@ -12,11 +12,11 @@ import (
 
 func main() {
 	browser := chrome.New(
-		&chrome.Flags{
+		chrome.WithFlags(&chrome.Flags{
 			"addr":                     "0.0.0.0",
 			"remote-debugging-address": "0.0.0.0",
 			"remote-debugging-port":    9222,
-		}, "", "", "", "",
+		}),
 	)
 
 	tab, err := browser.NewTab("https://www.google.com")
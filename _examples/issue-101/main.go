@@ -20,11 +20,11 @@ func main() {
 
 	// Create a chrome instance
 	browser := chrome.New(
-		&chrome.Flags{
+		chrome.WithFlags(&chrome.Flags{
 			"addr":                     "0.0.0.0",
 			"remote-debugging-address": "0.0.0.0",
 			"remote-debugging-port":    9222,
-		}, "", "", "", "",
+		}),
 	)
 
 	// Open a tab
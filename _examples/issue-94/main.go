@@ -56,11 +56,11 @@ var flags = &chrome.Flags{
 func main() {
 	// Create a chrome instance
 	browser := chrome.New(
-		&chrome.Flags{
+		chrome.WithFlags(&chrome.Flags{
 			"addr":                     "0.0.0.0",
 			"remote-debugging-address": "0.0.0.0",
 			"remote-debugging-port":    9222,
-		}, "", "", "", "",
+		}),
 	)
 
 	defer func() {
@@ -37,11 +37,11 @@ func main() {
 
 	// Create a chrome instance
 	browser := chrome.New(
-		&chrome.Flags{
+		chrome.WithFlags(&chrome.Flags{
 			"addr":                     "0.0.0.0",
 			"remote-debugging-address": "0.0.0.0",
 			"remote-debugging-port":    9222,
-		}, "", "", "", "",
+		}),
 	)
 
 	// Open a tab and navigate to the URL you want to screenshot.
@@ -0,0 +1,665 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/*
+docURL is the base URL of the protocol documentation linked from the
+generated doc comments.
+*/
+const docURL = "https://chromedevtools.github.io/devtools-protocol/tot/"
+
+/*
+header marks the generated files.
+*/
+const header = "// Code generated by protogen. DO NOT EDIT.\n\n"
+
+/*
+generator generates the protocol packages and socket methods of a set of
+protocol domains.
+*/
+type generator struct {
+	// importBase is the import path of the directory holding the packages.
+	importBase string
+
+	// domains are the domains by name, order is the order they were defined.
+	domains map[string]*domain
+	order   []*domain
+
+	// shared counts the domains that use each package name. Packages with a
+	// shared name are imported with an alias.
+	shared map[string]int
+
+	// imports records whether a package may import another. An import that
+	// would create a cycle is refused and the referenced types are duplicated
+	// in the importing package instead, as page.LoaderID duplicates
+	// network.LoaderID.
+	imports map[string]map[string]bool
+
+	errs []string
+}
+
+/*
+newGenerator returns a generator for the domains of the specified protocols.
+*/
+func newGenerator(importBase string, protocols ...*protocol) *generator {
+	gen := &generator{
+		importBase: strings.TrimSuffix(importBase, "/"),
+		domains:    map[string]*domain{},
+		shared:     map[string]int{},
+		imports:    map[string]map[string]bool{},
+	}
+	for _, proto := range protocols {
+		for _, dom := range proto.Domains {
+			if _, ok := gen.domains[dom.Domain]; ok {
+				continue
+			}
+			gen.domains[dom.Domain] = dom
+			gen.order = append(gen.order, dom)
+			gen.shared[packageName(dom.Domain)]++
+		}
+	}
+	return gen
+}
+
+/*
+canImport returns whether the package of a domain may import the package of
+another domain. The first reference between two domains decides: the import
+is allowed unless the other package already reaches the importing package.
+*/
+func (gen *generator) canImport(from, to string) bool {
+	if allowed, ok := gen.imports[from][to]; ok {
+		return allowed
+	}
+	allowed := !gen.reaches(to, from, map[string]bool{})
+	if nil == gen.imports[from] {
+		gen.imports[from] = map[string]bool{}
+	}
+	gen.imports[from][to] = allowed
+	return allowed
+}
+
+/*
+reaches returns whether a package imports another, directly or indirectly.
+*/
+func (gen *generator) reaches(from, to string, seen map[string]bool) bool {
+	if from == to {
+		return true
+	}
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for next, allowed := range gen.imports[from] {
+		if allowed && gen.reaches(next, to, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+alias returns the name a domain's package is imported as.
+*/
+func (gen *generator) alias(name string) string {
+	if gen.shared[packageName(name)] > 1 {
+		return strings.Replace(packagePath(name), "/", "", -1)
+	}
+	return packageName(name)
+}
+
+/*
+lookup returns a domain's type definition.
+*/
+func (gen *generator) lookup(domainName, id string) *typeDef {
+	if dom, ok := gen.domains[domainName]; ok {
+		for _, def := range dom.Types {
+			if id == def.ID {
+				return def
+			}
+		}
+	}
+	return nil
+}
+
+/*
+Generate renders the files of every domain, keyed by their path relative to
+the output directory. Every domain is rendered even when only some are written
+so the import decisions don't depend on the selection.
+*/
+func (gen *generator) Generate() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, dom := range gen.order {
+		pkg := newPackage(gen, dom)
+		for name, src := range pkg.render() {
+			files[name] = src
+		}
+	}
+	if len(gen.errs) > 0 {
+		return files, fmt.Errorf("%s", strings.Join(gen.errs, "\n"))
+	}
+	return files, nil
+}
+
+/*
+Write generates the files of the selected domains, or all domains if none are
+selected, into the output directory. It returns the paths that were written.
+*/
+func (gen *generator) Write(out string, only []string) ([]string, error) {
+	files, err := gen.Generate()
+	if nil != err {
+		return nil, err
+	}
+
+	selected := map[string]bool{}
+	for _, name := range only {
+		if _, ok := gen.domains[name]; !ok {
+			return nil, fmt.Errorf("unknown domain '%s'", name)
+		}
+		selected[packagePath(name)+"/"] = true
+		selected["socket/"+socketFile(name)] = true
+	}
+
+	written := []string{}
+	for name, src := range files {
+		if len(selected) > 0 && !selected[name] && !selected[path.Dir(name)+"/"] {
+			continue
+		}
+		dest := filepath.Join(out, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); nil != err {
+			return written, err
+		}
+		if err := ioutil.WriteFile(dest, src, 0644); nil != err {
+			return written, err
+		}
+		written = append(written, dest)
+	}
+	sort.Strings(written)
+	return written, nil
+}
+
+/*
+file is a generated source file.
+*/
+type file struct {
+	imports map[string]string
+	body    bytes.Buffer
+}
+
+/*
+printf writes formatted source to the file.
+*/
+func (f *file) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&f.body, format, args...)
+}
+
+/*
+comment writes a doc comment block. Paragraphs are separated by empty strings.
+*/
+func (f *file) comment(paragraphs ...string) {
+	f.printf("/*\n")
+	for i, paragraph := range paragraphs {
+		if i > 0 {
+			f.printf("\n")
+		}
+		for _, line := range wrap(paragraph, 80) {
+			f.printf("%s\n", line)
+		}
+	}
+	f.printf("*/\n")
+}
+
+/*
+source returns the formatted source of the file.
+*/
+func (f *file) source(doc, pkg string) ([]byte, error) {
+	src := &bytes.Buffer{}
+	src.WriteString(header)
+	src.WriteString(doc)
+	fmt.Fprintf(src, "package %s\n\n", pkg)
+	if len(f.imports) > 0 {
+		paths := []string{}
+		for importPath := range f.imports {
+			paths = append(paths, importPath)
+		}
+		sort.Strings(paths)
+		src.WriteString("import (\n")
+		for _, importPath := range paths {
+			if alias := f.imports[importPath]; "" != alias {
+				fmt.Fprintf(src, "\t%s %q\n", alias, importPath)
+			} else {
+				fmt.Fprintf(src, "\t%q\n", importPath)
+			}
+		}
+		src.WriteString(")\n\n")
+	}
+	src.Write(f.body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+/*
+duplicate is a type of another domain that is defined again in a package
+because importing it would create a cycle.
+*/
+type duplicate struct {
+	domain string
+	def    *typeDef
+	name   string
+}
+
+/*
+pkg generates the files of a single domain.
+*/
+type pkg struct {
+	gen    *generator
+	domain *domain
+
+	types    *file
+	commands *file
+	events   *file
+
+	// duplicates are the local names of duplicated types, keyed by
+	// Domain.Type, queue holds the duplicates that haven't been rendered.
+	duplicates map[string]string
+	queue      []duplicate
+}
+
+/*
+newPackage returns the generator of a domain's files.
+*/
+func newPackage(gen *generator, dom *domain) *pkg {
+	return &pkg{
+		gen:        gen,
+		domain:     dom,
+		types:      &file{imports: map[string]string{}},
+		commands:   &file{imports: map[string]string{}},
+		events:     &file{imports: map[string]string{}},
+		duplicates: map[string]string{},
+	}
+}
+
+/*
+render returns the domain's package files and its socket file.
+*/
+func (p *pkg) render() map[string][]byte {
+	name := p.domain.Domain
+	for _, def := range p.domain.Types {
+		p.typeDef(p.types, name, def, exported(def.ID), "")
+	}
+	for _, cmd := range p.domain.Commands {
+		p.command(cmd)
+	}
+	for _, evt := range p.domain.Events {
+		p.event(evt)
+	}
+	for len(p.queue) > 0 {
+		dup := p.queue[0]
+		p.queue = p.queue[1:]
+		p.typeDef(p.types, dup.domain, dup.def, dup.name, fmt.Sprintf(
+			"This is a duplicate of %s.%s to avoid an invalid import cycle.",
+			p.gen.alias(dup.domain), exported(dup.def.ID),
+		))
+	}
+
+	dir := packagePath(name)
+	files := map[string][]byte{}
+	doc := fmt.Sprintf("/*\nPackage %s provides type definitions for use with the Chrome %s protocol\n\n%s%s/\n*/\n",
+		packageName(name), name, docURL, name)
+	p.emit(files, dir+"/cdtp.go", p.types, doc, packageName(name))
+	if len(p.domain.Commands) > 0 {
+		p.emit(files, dir+"/command.go", p.commands, "", packageName(name))
+	}
+	if len(p.domain.Events) > 0 {
+		p.emit(files, dir+"/event.go", p.events, "", packageName(name))
+	}
+	p.emit(files, "socket/"+socketFile(name), p.socket(), "", "socket")
+	return files
+}
+
+/*
+emit formats a file, recording an error if it isn't valid Go.
+*/
+func (p *pkg) emit(files map[string][]byte, name string, f *file, doc, pkgName string) {
+	src, err := f.source(doc, pkgName)
+	if nil != err {
+		p.gen.errs = append(p.gen.errs, fmt.Sprintf("%s: %s", name, err))
+		return
+	}
+	files[name] = src
+}
+
+/*
+resolve returns the Go type of a reference to a type. Unqualified references
+are relative to the scope domain. References to struct types are pointers.
+*/
+func (p *pkg) resolve(f *file, scope, ref string) string {
+	domainName, id := scope, ref
+	if i := strings.Index(ref, "."); i >= 0 {
+		domainName, id = ref[:i], ref[i+1:]
+	}
+	def := p.gen.lookup(domainName, id)
+	if nil == def {
+		p.gen.errs = append(p.gen.errs, fmt.Sprintf("%s: unknown type '%s.%s'", p.domain.Domain, domainName, id))
+		return "interface{}"
+	}
+
+	pointer := ""
+	if "object" == def.Type && len(def.Properties) > 0 {
+		pointer = "*"
+	}
+	if domainName == p.domain.Domain {
+		return pointer + exported(id)
+	}
+	if p.gen.canImport(p.domain.Domain, domainName) {
+		alias := p.gen.alias(domainName)
+		if alias == packageName(domainName) {
+			f.imports[p.gen.importBase+"/"+packagePath(domainName)] = ""
+		} else {
+			f.imports[p.gen.importBase+"/"+packagePath(domainName)] = alias
+		}
+		return pointer + alias + "." + exported(id)
+	}
+	return pointer + p.duplicate(domainName, def)
+}
+
+/*
+duplicate returns the local name of a duplicated type, queueing it to be
+rendered the first time it's referenced.
+*/
+func (p *pkg) duplicate(domainName string, def *typeDef) string {
+	key := domainName + "." + def.ID
+	if name, ok := p.duplicates[key]; ok {
+		return name
+	}
+	name := exported(def.ID)
+	if nil != p.gen.lookup(p.domain.Domain, def.ID) || p.duplicated(name) {
+		name = exported(domainName) + name
+	}
+	p.duplicates[key] = name
+	p.queue = append(p.queue, duplicate{domain: domainName, def: def, name: name})
+	return name
+}
+
+/*
+duplicated returns whether a local name is used by a duplicated type.
+*/
+func (p *pkg) duplicated(name string) bool {
+	for _, local := range p.duplicates {
+		if name == local {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+goType returns the Go type of a property.
+*/
+func (p *pkg) goType(f *file, scope string, prop *property) string {
+	if "" != prop.Ref {
+		return p.resolve(f, scope, prop.Ref)
+	}
+	return p.baseType(f, scope, prop.Type, prop.Items)
+}
+
+/*
+baseType returns the Go type of a protocol primitive.
+*/
+func (p *pkg) baseType(f *file, scope, typ string, items *property) string {
+	switch typ {
+	case "string", "binary":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if nil == items {
+			return "[]interface{}"
+		}
+		return "[]" + p.goType(f, scope, items)
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+/*
+annotate returns a description with the optional, experimental, deprecated and
+allowed value notes used in the hand-written packages.
+*/
+func annotate(description string, optional, experimental, deprecated bool, enum []string) string {
+	text := sentence(description)
+	if optional {
+		text = strings.TrimSpace("Optional. " + text)
+	}
+	if experimental {
+		text = strings.TrimSpace(text + " EXPERIMENTAL.")
+	}
+	if deprecated {
+		text = strings.TrimSpace(text + " DEPRECATED.")
+	}
+	if len(enum) > 0 {
+		values := make([]string, len(enum))
+		for i, value := range enum {
+			values[i] = fmt.Sprintf("%q", value)
+		}
+		text = strings.TrimSpace(text + " Allowed values: " + strings.Join(values, ", ") + ".")
+	}
+	return text
+}
+
+/*
+fields writes the fields of a struct.
+*/
+func (p *pkg) fields(f *file, scope string, props []*property) {
+	for i, prop := range props {
+		if i > 0 {
+			f.printf("\n")
+		}
+		doc := annotate(prop.Description, prop.Optional, prop.Experimental, prop.Deprecated, prop.Enum)
+		for _, line := range wrap(doc, 76) {
+			f.printf("\t// %s\n", line)
+		}
+		tag := prop.Name
+		if prop.Optional {
+			tag += ",omitempty"
+		}
+		f.printf("\t%s %s `json:\"%s\"`\n", exported(prop.Name), p.goType(f, scope, prop), tag)
+	}
+}
+
+/*
+errField writes the Err field of results and events.
+*/
+func errField(f *file, fields int, doc string) {
+	if fields > 0 {
+		f.printf("\n")
+	}
+	f.printf("\t// %s\n\tErr error `json:\"-\"`\n", doc)
+}
+
+/*
+typeDef writes a type definition.
+*/
+func (p *pkg) typeDef(f *file, scope string, def *typeDef, name, note string) {
+	doc := fmt.Sprintf("%s represents the %s.%s type.", name, scope, def.ID)
+	if "" != note {
+		doc += " " + note
+	}
+	doc += " " + annotate(def.Description, false, def.Experimental, def.Deprecated, def.Enum)
+	f.comment(doc, fmt.Sprintf("%s%s/#type-%s", docURL, scope, def.ID))
+
+	switch {
+	case "object" == def.Type && len(def.Properties) > 0:
+		f.printf("type %s struct {\n", name)
+		p.fields(f, scope, def.Properties)
+		f.printf("}\n\n")
+	default:
+		f.printf("type %s %s\n\n", name, p.baseType(f, scope, def.Type, def.Items))
+	}
+}
+
+/*
+command writes the params and result structs of a method.
+*/
+func (p *pkg) command(cmd *command) {
+	f := p.commands
+	method := p.domain.Domain + "." + cmd.Name
+	link := fmt.Sprintf("%s%s/#method-%s", docURL, p.domain.Domain, cmd.Name)
+	name := exported(cmd.Name)
+
+	if len(cmd.Parameters) > 0 {
+		f.comment(fmt.Sprintf("%sParams represents %s parameters.", name, method), link)
+		f.printf("type %sParams struct {\n", name)
+		p.fields(f, p.domain.Domain, cmd.Parameters)
+		f.printf("}\n\n")
+	}
+
+	f.comment(fmt.Sprintf("%sResult represents the result of calls to %s.", name, method), link)
+	f.printf("type %sResult struct {\n", name)
+	p.fields(f, p.domain.Domain, cmd.Returns)
+	errField(f, len(cmd.Returns), "Error information related to executing this method")
+	f.printf("}\n\n")
+}
+
+/*
+event writes the struct of an event.
+*/
+func (p *pkg) event(evt *event) {
+	f := p.events
+	name := exported(evt.Name)
+	f.comment(
+		fmt.Sprintf("%sEvent represents %s.%s event data.", name, p.domain.Domain, evt.Name),
+		fmt.Sprintf("%s%s/#event-%s", docURL, p.domain.Domain, evt.Name),
+	)
+	f.printf("type %sEvent struct {\n", name)
+	p.fields(f, p.domain.Domain, evt.Parameters)
+	errField(f, len(evt.Parameters), "Error information related to this event")
+	f.printf("}\n\n")
+}
+
+/*
+commandDoc returns the first sentence of a method's doc comment.
+*/
+func commandDoc(name, method string, cmd *command) string {
+	doc := fmt.Sprintf("%s sends %s.", name, method)
+	if "" != strings.TrimSpace(cmd.Description) {
+		doc = name + " " + lowerFirst(sentence(cmd.Description))
+	}
+	return annotate(doc, false, cmd.Experimental, cmd.Deprecated, nil)
+}
+
+/*
+eventDoc returns the first sentences of an event handler's doc comment.
+*/
+func eventDoc(name, method string, evt *event) string {
+	doc := fmt.Sprintf("%s adds a handler to the %s event.", name, method)
+	description := sentence(evt.Description)
+	switch {
+	case strings.HasPrefix(description, "Fired "):
+		doc += " " + method + " fires " + strings.TrimPrefix(description, "Fired ")
+	case strings.HasPrefix(description, "Issued "):
+		doc += " " + method + " is issued " + strings.TrimPrefix(description, "Issued ")
+	case "" != description:
+		doc += " " + description
+	}
+	return annotate(doc, false, evt.Experimental, evt.Deprecated, nil)
+}
+
+/*
+socket returns the socket package file with the domain's protocol methods and
+event handlers.
+*/
+func (p *pkg) socket() *file {
+	f := &file{imports: map[string]string{}}
+	name := p.domain.Domain
+	qual := packageName(name)
+	protocol := name + "Protocol"
+	f.imports[p.gen.importBase+"/"+packagePath(name)] = ""
+
+	doc := fmt.Sprintf("%s provides a namespace for the Chrome %s protocol methods.", protocol, name)
+	if "" != strings.TrimSpace(p.domain.Description) {
+		doc += " " + sentence(p.domain.Description)
+	}
+	f.comment(annotate(doc, false, p.domain.Experimental, p.domain.Deprecated, nil), docURL+name+"/")
+	f.printf("type %s struct {\n\tSocket Socketer\n}\n\n", protocol)
+
+	for _, cmd := range p.domain.Commands {
+		method := name + "." + cmd.Name
+		ident := exported(cmd.Name)
+		f.comment(commandDoc(ident, method, cmd), fmt.Sprintf("%s%s/#method-%s", docURL, name, cmd.Name))
+		if len(cmd.Parameters) > 0 {
+			f.printf("func (protocol *%s) %s(\n\tparams *%s.%sParams,\n) <-chan *%s.%sResult {\n", protocol, ident, qual, ident, qual, ident)
+		} else {
+			f.printf("func (protocol *%s) %s() <-chan *%s.%sResult {\n", protocol, ident, qual, ident)
+		}
+		params := "nil"
+		if len(cmd.Parameters) > 0 {
+			params = "params"
+		}
+		f.printf("\tresultChan := make(chan *%s.%sResult)\n", qual, ident)
+		f.printf("\tcommand := NewCommand(protocol.Socket, %q, %s)\n", method, params)
+		f.printf("\tresult := &%s.%sResult{}\n\n", qual, ident)
+		f.printf("\tgo func() {\n")
+		f.printf("\t\tresponse := <-protocol.Socket.SendCommand(command)\n")
+		f.printf("\t\tif nil != response.Error && 0 != response.Error.Code {\n")
+		f.printf("\t\t\tresult.Err = response.Err()\n")
+		if len(cmd.Returns) > 0 {
+			f.imports["encoding/json"] = ""
+			f.printf("\t\t} else {\n")
+			f.printf("\t\t\tresult.Err = json.Unmarshal(response.Result, &result)\n")
+		}
+		f.printf("\t\t}\n")
+		f.printf("\t\tresultChan <- result\n")
+		f.printf("\t\tclose(resultChan)\n")
+		f.printf("\t}()\n\n")
+		f.printf("\treturn resultChan\n}\n\n")
+	}
+
+	for _, evt := range p.domain.Events {
+		f.imports["encoding/json"] = ""
+		method := name + "." + evt.Name
+		ident := exported(evt.Name)
+		typ := fmt.Sprintf("%s.%sEvent", qual, ident)
+		f.comment(eventDoc("On"+ident, method, evt), fmt.Sprintf("%s%s/#event-%s", docURL, name, evt.Name))
+		f.printf("func (protocol *%s) On%s(\n\tcallback func(event *%s),\n) *Subscription {\n", protocol, ident, typ)
+		f.printf("\thandler := NewEventHandler(\n\t\t%q,\n\t\tfunc(response *Response) {\n", method)
+		f.printf("\t\t\tevent := &%s{}\n", typ)
+		f.printf("\t\t\tjson.Unmarshal([]byte(response.Params), event)\n")
+		f.printf("\t\t\tif nil != response.Error && 0 != response.Error.Code {\n")
+		f.printf("\t\t\t\tevent.Err = response.Error\n")
+		f.printf("\t\t\t}\n")
+		f.printf("\t\t\tcallback(event)\n")
+		f.printf("\t\t},\n\t)\n")
+		f.printf("\treturn protocol.Socket.AddEventHandler(handler)\n}\n\n")
+
+		f.comment(fmt.Sprintf(
+			"%sChan returns a channel of %s events, as an alternative to On%s. The returned function removes the event handler and closes the channel. Event handlers run concurrently, so events are not guaranteed to arrive in order.",
+			ident, method, ident,
+		))
+		f.printf("func (protocol *%s) %sChan(\n\tbuffer int,\n) (<-chan *%s, func()) {\n", protocol, ident, typ)
+		f.printf("\teventCh := make(chan *%s, buffer)\n", typ)
+		f.printf("\tstream := newEventStream()\n")
+		f.printf("\tsub := protocol.On%s(func(event *%s) {\n", ident, typ)
+		f.printf("\t\t// Pooled events are reused once the handler returns, the channel\n")
+		f.printf("\t\t// receives a copy.\n")
+		f.printf("\t\te := *event\n")
+		f.printf("\t\tstream.send(func(done <-chan struct{}) {\n")
+		f.printf("\t\t\tselect {\n\t\t\tcase eventCh <- &e:\n\t\t\tcase <-done:\n\t\t\t}\n")
+		f.printf("\t\t})\n\t})\n")
+		f.printf("\treturn eventCh, stream.cancel(sub, func() { close(eventCh) })\n}\n\n")
+	}
+	return f
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	for name, expected := range map[string]string{
+		"backendNodeId":  "BackendNodeID",
+		"getFullAXTree":  "GetFullAXTree",
+		"documentURL":    "DocumentURL",
+		"nodeIds":        "NodeIDs",
+		"DOMSnapshot":    "DOMSnapshot",
+		"usedJSHeapSize": "UsedJSHeapSize",
+		"eof":            "EOF",
+		"x509Cert":       "X509Cert",
+	} {
+		if result := exported(name); expected != result {
+			t.Errorf("Expected '%s' for '%s', got '%s'", expected, name, result)
+		}
+	}
+	for name, expected := range map[string]string{
+		"DOMSnapshot":      "dom/snapshot",
+		"IndexedDB":        "indexed/db",
+		"HeapProfiler":     "heap/profiler",
+		"IO":               "io",
+		"ApplicationCache": "application/cache",
+	} {
+		if result := packagePath(name); expected != result {
+			t.Errorf("Expected '%s' for '%s', got '%s'", expected, name, result)
+		}
+	}
+	if "cdtp.dom.snapshot.go" != socketFile("DOMSnapshot") {
+		t.Errorf("Expected cdtp.dom.snapshot.go, got '%s'", socketFile("DOMSnapshot"))
+	}
+}
+
+func newTestGenerator(t *testing.T) *generator {
+	proto, err := loadProtocol("testdata/protocol.json")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	return newGenerator("github.com/mkenney/go-chrome/tot", proto)
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := newTestGenerator(t).Generate()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	for name, snippets := range map[string][]string{
+		"network/cdtp.go": {
+			"package network",
+			"type LoaderID string",
+			`Allowed values: "Document", "Script".`,
+			"URL string `json:\"url\"`",
+			"Headers map[string]interface{} `json:\"headers\"`",
+			"// Optional. HTTP POST request data.\n\tPostData string `json:\"postData,omitempty\"`",
+		},
+		"network/command.go": {
+			`"github.com/mkenney/go-chrome/tot/page"`,
+			"type EnableResult struct {\n\t// Error information related to executing this method\n\tErr error `json:\"-\"`\n}",
+			"FrameID page.FrameID `json:\"frameId\"`",
+			"LoaderIDs []LoaderID `json:\"loaderIds,omitempty\"`",
+			"Request *Request `json:\"request\"`",
+		},
+		"network/event.go": {
+			"RequestWillBeSentEvent represents Network.requestWillBeSent event data.",
+			"Type ResourceType `json:\"type,omitempty\"`",
+		},
+		"page/cdtp.go": {
+			"LoaderID represents the Network.LoaderId type. This is a duplicate of\nnetwork.LoaderID to avoid an invalid import cycle.",
+			"LoaderID LoaderID `json:\"loaderId\"`",
+		},
+		"page/command.go": {
+			"Frames []*Frame `json:\"frames\"`",
+		},
+		"dom/storage/cdtp.go": {
+			"package storage",
+			"type Item []string",
+		},
+		"dom/storage/event.go": {
+			"FrameID page.FrameID `json:\"frameId\"`",
+		},
+		"socket/cdtp.network.go": {
+			"type NetworkProtocol struct {",
+			"Enable enables network tracking.",
+			"func (protocol *NetworkProtocol) Enable() <-chan *network.EnableResult {",
+			"command := NewCommand(protocol.Socket, \"Network.getRequest\", params)",
+			"result.Err = json.Unmarshal(response.Result, &result)",
+			"Network.requestWillBeSent fires when page is about to send HTTP request.",
+			"func (protocol *NetworkProtocol) RequestWillBeSentChan(",
+			"e := *event\n\t\tstream.send(func(done <-chan struct{}) {\n\t\t\tselect {\n\t\t\tcase eventCh <- &e:",
+		},
+		"socket/cdtp.dom.storage.go": {
+			`"github.com/mkenney/go-chrome/tot/dom/storage"`,
+			"DOMStorageProtocol provides a namespace for the Chrome DOMStorage protocol\nmethods. EXPERIMENTAL.",
+			"callback func(event *storage.DOMStorageItemsClearedEvent),",
+		},
+	} {
+		src, ok := files[name]
+		if !ok {
+			t.Errorf("Expected %s to be generated", name)
+			continue
+		}
+		if !strings.HasPrefix(string(src), header) {
+			t.Errorf("Expected %s to be marked as generated", name)
+		}
+		for _, snippet := range snippets {
+			if !strings.Contains(string(src), snippet) {
+				t.Errorf("Expected %s to contain:\n%s\n\ngot:\n%s", name, snippet, src)
+			}
+		}
+	}
+
+	if strings.Contains(string(files["page/cdtp.go"]), "tot/network") {
+		t.Errorf("Expected page not to import network")
+	}
+	if _, ok := files["page/event.go"]; ok {
+		t.Errorf("Expected no event file for a domain without events")
+	}
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	gen := newTestGenerator(t)
+	gen.domains["Page"].Types[1].Properties[1].Ref = "Network.Missing"
+	if _, err := gen.Generate(); nil == err || !strings.Contains(err.Error(), "Network.Missing") {
+		t.Errorf("Expected an unknown type error, got %v", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	out, _ := ioutil.TempDir("", "protogen")
+	defer os.RemoveAll(out)
+
+	written, err := newTestGenerator(t).Write(out, []string{"Page"})
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	expected := []string{
+		filepath.Join(out, "page", "cdtp.go"),
+		filepath.Join(out, "page", "command.go"),
+		filepath.Join(out, "socket", "cdtp.page.go"),
+	}
+	if strings.Join(expected, ",") != strings.Join(written, ",") {
+		t.Errorf("Expected %v, got %v", expected, written)
+	}
+	if _, err := os.Stat(filepath.Join(out, "network")); !os.IsNotExist(err) {
+		t.Errorf("Expected unselected domains not to be written")
+	}
+
+	if _, err := newTestGenerator(t).Write(out, []string{"Nope"}); nil == err {
+		t.Errorf("Expected an unknown domain error")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Protogen generates the protocol packages and the socket protocol methods from
+Chromium's protocol definitions, browser_protocol.json and js_protocol.json.
+
+Usage:
+
+	go run ./cmd/protogen [flags]
+
+With the default flags the definitions are downloaded from the
+devtools-protocol repository and every domain is written to ./tot: the
+parameter, result and event structs to tot/<domain>/cdtp.go, command.go and
+event.go, and the methods and event handlers to tot/socket/cdtp.<domain>.go.
+Packages follow the layout of the hand-written packages, DOMSnapshot is
+tot/dom/snapshot and IndexedDB is tot/indexed/db. Go doesn't allow import
+cycles, so a type referenced from a domain that already imports the
+referencing domain is duplicated, like page.LoaderID duplicates
+network.LoaderID.
+
+Flags:
+
+	-browser  path or URL of browser_protocol.json
+	-js       path or URL of js_protocol.json
+	-out      output directory, default "tot"
+	-import   import path of the output directory, default
+	          "github.com/mkenney/go-chrome/tot"
+	-domains  comma separated list of the domains to write, default all
+
+Other files in the packages, such as the enum types and helpers, aren't
+touched. A domain that didn't exist before must still be added to Protocols
+and the protocoller interfaces in the socket package. Review the diff after
+regenerating, a changed definition can change the types of existing fields.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	browserProtocolURL = "https://raw.githubusercontent.com/ChromeDevTools/devtools-protocol/master/json/browser_protocol.json"
+	jsProtocolURL      = "https://raw.githubusercontent.com/ChromeDevTools/devtools-protocol/master/json/js_protocol.json"
+)
+
+func main() {
+	browser := flag.String("browser", browserProtocolURL, "path or URL of browser_protocol.json")
+	js := flag.String("js", jsProtocolURL, "path or URL of js_protocol.json")
+	out := flag.String("out", "tot", "output directory")
+	importBase := flag.String("import", "github.com/mkenney/go-chrome/tot", "import path of the output directory")
+	domains := flag.String("domains", "", "comma separated list of the domains to write, default all")
+	flag.Parse()
+
+	if err := run(*browser, *js, *out, *importBase, *domains); nil != err {
+		fmt.Fprintf(os.Stderr, "protogen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+/*
+run loads the protocol definitions and writes the generated files.
+*/
+func run(browser, js, out, importBase, domains string) error {
+	protocols := []*protocol{}
+	for _, source := range []string{js, browser} {
+		if "" == source {
+			continue
+		}
+		proto, err := loadProtocol(source)
+		if nil != err {
+			return err
+		}
+		protocols = append(protocols, proto)
+	}
+
+	only := []string{}
+	for _, name := range strings.Split(domains, ",") {
+		if name = strings.TrimSpace(name); "" != name {
+			only = append(only, name)
+		}
+	}
+
+	written, err := newGenerator(importBase, protocols...).Write(out, only)
+	if nil != err {
+		return err
+	}
+	fmt.Printf("wrote %d files to %s\n", len(written), out)
+	return nil
+}
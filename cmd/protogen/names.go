@@ -0,0 +1,146 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+/*
+initialisms are the words that are written in upper case in Go identifiers,
+so nodeId becomes NodeID and documentURL stays DocumentURL.
+*/
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "AX": true, "CPU": true,
+	"CSS": true, "DB": true, "DNS": true, "DOM": true, "EOF": true,
+	"GPU": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IO": true, "IP": true, "JSON": true, "JS": true,
+	"PDF": true, "SQL": true, "SSL": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "UID": true, "URI": true,
+	"URL": true, "UTF8": true, "UUID": true, "XML": true, "XSS": true,
+}
+
+/*
+words splits a protocol name into its words: "DOMSnapshot" is "DOM" and
+"Snapshot", "backendNodeId" is "backend", "Node" and "Id".
+*/
+func words(name string) []string {
+	result := []string{}
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		split := i == len(runes)
+		if !split {
+			prev, cur := runes[i-1], runes[i]
+			switch {
+			case '_' == cur || '-' == cur || '.' == cur:
+				split = true
+			case unicode.IsLower(prev) && unicode.IsUpper(cur):
+				split = true
+			case unicode.IsDigit(prev) && unicode.IsUpper(cur):
+				split = true
+			case unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				split = true
+			}
+		}
+		if split {
+			word := strings.Trim(string(runes[start:i]), "_-.")
+			if "" != word {
+				result = append(result, word)
+			}
+			start = i
+		}
+	}
+	return result
+}
+
+/*
+exported returns the exported Go identifier for a protocol name.
+*/
+func exported(name string) string {
+	var ident strings.Builder
+	for _, word := range words(name) {
+		upper := strings.ToUpper(word)
+		switch {
+		case initialisms[upper]:
+			ident.WriteString(upper)
+		case strings.HasSuffix(word, "s") && initialisms[strings.ToUpper(word[:len(word)-1])]:
+			ident.WriteString(strings.ToUpper(word[:len(word)-1]) + "s")
+		default:
+			ident.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return ident.String()
+}
+
+/*
+packagePath returns the package path of a domain relative to the import base,
+following the layout of the hand-written packages: DOMSnapshot is
+dom/snapshot and IndexedDB is indexed/db.
+*/
+func packagePath(domain string) string {
+	return strings.ToLower(strings.Join(words(domain), "/"))
+}
+
+/*
+packageName returns the package name of a domain, the last element of its
+package path.
+*/
+func packageName(domain string) string {
+	parts := strings.Split(packagePath(domain), "/")
+	return parts[len(parts)-1]
+}
+
+/*
+socketFile returns the name of the file holding a domain's protocol methods in
+the socket package, e.g. cdtp.dom.snapshot.go.
+*/
+func socketFile(domain string) string {
+	return "cdtp." + strings.ToLower(strings.Join(words(domain), ".")) + ".go"
+}
+
+/*
+lowerFirst lower-cases the first letter of a description so it can follow a
+method name, leaving acronyms such as "DOM" alone.
+*/
+func lowerFirst(text string) string {
+	runes := []rune(text)
+	if len(runes) > 1 && unicode.IsUpper(runes[0]) && unicode.IsLower(runes[1]) {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+/*
+sentence collapses the whitespace in a description and terminates it.
+*/
+func sentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.Replace(text, "*/", "* /", -1)
+	if "" != text && !strings.HasSuffix(text, ".") {
+		text += "."
+	}
+	return text
+}
+
+/*
+wrap splits text into lines of at most width characters. Words longer than
+the width get a line of their own.
+*/
+func wrap(text string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if "" != line && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if "" != line {
+			line += " "
+		}
+		line += word
+	}
+	if "" != line {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+/*
+protocol is the schema of browser_protocol.json and js_protocol.json.
+*/
+type protocol struct {
+	Version struct {
+		Major string `json:"major"`
+		Minor string `json:"minor"`
+	} `json:"version"`
+	Domains []*domain `json:"domains"`
+}
+
+/*
+domain is a protocol domain definition.
+*/
+type domain struct {
+	Domain       string     `json:"domain"`
+	Description  string     `json:"description"`
+	Experimental bool       `json:"experimental"`
+	Deprecated   bool       `json:"deprecated"`
+	Dependencies []string   `json:"dependencies"`
+	Types        []*typeDef `json:"types"`
+	Commands     []*command `json:"commands"`
+	Events       []*event   `json:"events"`
+}
+
+/*
+typeDef is a named type defined by a domain.
+*/
+type typeDef struct {
+	ID           string      `json:"id"`
+	Description  string      `json:"description"`
+	Type         string      `json:"type"`
+	Enum         []string    `json:"enum"`
+	Properties   []*property `json:"properties"`
+	Items        *property   `json:"items"`
+	Experimental bool        `json:"experimental"`
+	Deprecated   bool        `json:"deprecated"`
+}
+
+/*
+property is a type property, command parameter, command return value or event
+parameter. Array items are properties without a name.
+*/
+type property struct {
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Type         string    `json:"type"`
+	Ref          string    `json:"$ref"`
+	Enum         []string  `json:"enum"`
+	Items        *property `json:"items"`
+	Optional     bool      `json:"optional"`
+	Experimental bool      `json:"experimental"`
+	Deprecated   bool      `json:"deprecated"`
+}
+
+/*
+command is a protocol method definition.
+*/
+type command struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Parameters   []*property `json:"parameters"`
+	Returns      []*property `json:"returns"`
+	Redirect     string      `json:"redirect"`
+	Experimental bool        `json:"experimental"`
+	Deprecated   bool        `json:"deprecated"`
+}
+
+/*
+event is a protocol event definition.
+*/
+type event struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Parameters   []*property `json:"parameters"`
+	Experimental bool        `json:"experimental"`
+	Deprecated   bool        `json:"deprecated"`
+}
+
+/*
+loadProtocol reads a protocol definition from a file or an http(s) URL.
+*/
+func loadProtocol(source string) (*protocol, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		var response *http.Response
+		response, err = http.Get(source)
+		if nil != err {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if http.StatusOK != response.StatusCode {
+			return nil, fmt.Errorf("%s: unexpected status %s", source, response.Status)
+		}
+		data, err = ioutil.ReadAll(response.Body)
+	} else {
+		data, err = ioutil.ReadFile(source)
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	proto := &protocol{}
+	if err = json.Unmarshal(data, proto); nil != err {
+		return nil, fmt.Errorf("%s: %s", source, err)
+	}
+	return proto, nil
+}
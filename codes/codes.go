@@ -36,6 +36,40 @@ const (
 	ChromeTabNotFound
 	// ChromeVersionQueryFailed - 2008: Chromium version query failed.
 	ChromeVersionQueryFailed
+	// ChromeProxyUnavailable - 2009: No healthy proxy is available.
+	ChromeProxyUnavailable
+	// ChromeUserDataFailed - 2010: A user data directory could not be created from a template.
+	ChromeUserDataFailed
+	// ChromePreferencesFailed - 2011: Preferences or policies could not be written.
+	ChromePreferencesFailed
+	// ChromeUsageUnavailable - 2012: Process resource usage could not be read.
+	ChromeUsageUnavailable
+	// ChromeBudgetExceeded - 2013: The browser process tree exceeded its resource budget.
+	ChromeBudgetExceeded
+	// ChromeCleanupFailed - 2014: Stale user data directories or orphaned processes could not be cleaned up.
+	ChromeCleanupFailed
+	// ChromeBrowserNotFound - 2015: No installed browser was found.
+	ChromeBrowserNotFound
+	// ChromeHeadlessUnsupported - 2016: The browser doesn't support the requested headless mode.
+	ChromeHeadlessUnsupported
+	// ChromeNoDisplay - 2017: No display is available for a headful browser.
+	ChromeNoDisplay
+	// ChromeGraphicsProbeFailed - 2018: The graphics capabilities of the browser could not be probed.
+	ChromeGraphicsProbeFailed
+	// ChromeConnectionFailed - 2019: The shared browser connection failed.
+	ChromeConnectionFailed
+	// ChromeTunnelFailed - 2020: An SSH tunnel to a remote browser could not be opened.
+	ChromeTunnelFailed
+	// ChromeBrokerFailed - 2021: A request to a browser broker failed.
+	ChromeBrokerFailed
+	// ChromeWarmPoolFailed - 2022: A warm tab could not be created or reused.
+	ChromeWarmPoolFailed
+	// ChromeRenderFailed - 2023: A page could not be rendered.
+	ChromeRenderFailed
+	// ChromeJobFailed - 2024: A job could not be scheduled.
+	ChromeJobFailed
+	// ChromeTenancyFailed - 2025: A tenant's browser context could not be opened or closed.
+	ChromeTenancyFailed
 )
 
 ////////////////////////////////////////////////////////////////////////////
@@ -59,6 +93,51 @@ const (
 	TabURLInvalid
 	// TabWebsocketURLInvalid - 4002: Invalid websocket URL.
 	TabWebsocketURLInvalid
+	// TabCleanupFailed - 4003: One or more tab cleanup functions failed.
+	TabCleanupFailed
+	// TabProfileInvalid - 4004: A tab profile could not be read.
+	TabProfileInvalid
+	// TabProfileApplyFailed - 4005: A tab profile could not be applied.
+	TabProfileApplyFailed
+	// TabInterceptFailed - 4006: Request interception could not be enabled.
+	TabInterceptFailed
+	// TabGraphQLFailed - 4007: A GraphQL operation didn't return the expected response.
+	TabGraphQLFailed
+	// TabWaitFailed - 4008: No matching network request or response was received.
+	TabWaitFailed
+	// TabBodyUnavailable - 4009: A request or response body could not be read.
+	TabBodyUnavailable
+	// TabFieldNotFound - 4010: A JSON field could not be extracted from a body.
+	TabFieldNotFound
+	// TabHARInvalid - 4011: A HAR archive could not be read.
+	TabHARInvalid
+	// TabCanvasCaptureFailed - 4012: A canvas could not be captured.
+	TabCanvasCaptureFailed
+	// TabCanvasTainted - 4013: A canvas is tainted by cross-origin content.
+	TabCanvasTainted
+	// TabLifecycleFailed - 4014: The web lifecycle state of a tab could not be changed or verified.
+	TabLifecycleFailed
+	// TabInspectorFailed - 4015: The Inspector domain of a tab could not be enabled.
+	TabInspectorFailed
+	// TabEvalFailed - 4016: An expression could not be evaluated or its value could not be decoded.
+	TabEvalFailed
+	// TabSnapshotFailed - 4017: An accessibility or DOM snapshot of a tab could not be taken.
+	TabSnapshotFailed
+
+	// TabTraceFailed - 4018: A trace of a tab could not be recorded or written.
+	TabTraceFailed
+	// TabClockFailed - 4019: The fake clock of a tab could not be installed or controlled.
+	TabClockFailed
+	// TabRandomSeedFailed - 4020: The random number generators of a tab could not be seeded.
+	TabRandomSeedFailed
+	// TabStorageFixturesFailed - 4021: The storage fixtures of a tab could not be added.
+	TabStorageFixturesFailed
+	// TabLoginFailed - 4022: A login flow could not be completed in a tab.
+	TabLoginFailed
+	// TabChallengeFailed - 4023: The challenge detection of a tab could not be enabled.
+	TabChallengeFailed
+	// TabNavigationFailed - 4024: A navigation failed after every attempt.
+	TabNavigationFailed
 )
 
 ////////////////////////////////////////////////////////////////////////////
@@ -83,6 +162,36 @@ const (
 	SocketWriteFailed
 	// SocketPanic - 5003: A panic occurred while reading from a websocket.
 	SocketPanic
+	// SocketEventDecodeFailed - 5009: Event parameters could not be decoded.
+	SocketEventDecodeFailed
+	// SocketEventFieldNotFound - 5010: Event parameter field not found.
+	SocketEventFieldNotFound
+	// SocketEventHandlerPanic - 5011: An event handler callback panicked.
+	SocketEventHandlerPanic
+	// SocketCommandTimeout - 5012: A command did not receive a response in time.
+	SocketCommandTimeout
+	// SocketCommandCanceled - 5013: A command was canceled before it received a response.
+	SocketCommandCanceled
+	// SocketConnectionLost - 5014: The websocket connection was lost before a command received a response.
+	SocketConnectionLost
+	// SocketReconnectFailed - 5015: The websocket connection could not be re-established.
+	SocketReconnectFailed
+	// SocketCommandVetoed - 5016: A command was vetoed by socket middleware.
+	SocketCommandVetoed
+	// SocketShutdown - 5017: The socket was shut down before a command received a response.
+	SocketShutdown
+	// SocketMessageTooLarge - 5018: A message exceeded the maximum message size and could not be streamed.
+	SocketMessageTooLarge
+	// SocketDetached - 5019: The browser detached the connection or session, for example because DevTools was opened.
+	SocketDetached
+	// SocketReplayFailed - 5020: A recording could not be read or a command has no recorded response.
+	SocketReplayFailed
+	// SocketProtocolExposureDenied - 5021: Exposing the DevTools protocol to a target wasn't granted or was refused by the exposure guard.
+	SocketProtocolExposureDenied
+	// SocketDeadlock - 5022: A command was sent from the socket's read loop, or the read loop is blocked delivering a response.
+	SocketDeadlock
+	// SocketVersionUnavailable - 5023: The browser version could not be discovered.
+	SocketVersionUnavailable
 )
 
 ////////////////////////////////////////////////////////////////////////////
@@ -97,6 +206,48 @@ const (
 	WebsocketPanic
 )
 
+////////////////////////////////////////////////////////////////////////////
+// Fingerprint errors
+////////////////////////////////////////////////////////////////////////////
+const (
+	// FingerprintNotFound - 7000: The named fingerprint profile does not exist.
+	FingerprintNotFound std.Code = iota + 7000
+	// FingerprintApplyFailed - 7001: A fingerprint profile could not be applied.
+	FingerprintApplyFailed
+	// FingerprintSelfTestFailed - 7002: The fingerprint self-test could not be completed.
+	FingerprintSelfTestFailed
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Cookie errors
+////////////////////////////////////////////////////////////////////////////
+const (
+	// CookieInspectFailed - 8000: The browser cookies could not be read.
+	CookieInspectFailed std.Code = iota + 8000
+	// CookieSimulationFailed - 8001: The third-party cookie simulation could not be completed.
+	CookieSimulationFailed
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Media errors
+////////////////////////////////////////////////////////////////////////////
+const (
+	// MediaRecordFailed - 9000: A media recording could not be started or stopped.
+	MediaRecordFailed std.Code = iota + 9000
+	// MediaWriteFailed - 9001: A media recording could not be written.
+	MediaWriteFailed
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Source map errors
+////////////////////////////////////////////////////////////////////////////
+const (
+	// SourceMapInvalid - 10000: A source map could not be decoded.
+	SourceMapInvalid std.Code = iota + 10000
+	// SourceMapLoadFailed - 10001: The source map of a script could not be loaded.
+	SourceMapLoadFailed
+)
+
 func init() {
 	errs.Codes[Unspecified] = errs.ErrCode{Int: "The error code was unspecified", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[Unknown] = errs.ErrCode{Int: "An unspecified error occurred", Ext: "An unknown error occurred", HTTP: 500}
@@ -111,6 +262,23 @@ func init() {
 	errs.Codes[ChromeStartTimeout] = errs.ErrCode{Int: "Chromium took too long to start", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[ChromeTabNotFound] = errs.ErrCode{Int: "Chromium tab not found", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[ChromeVersionQueryFailed] = errs.ErrCode{Int: "Chromium version query failed", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeProxyUnavailable] = errs.ErrCode{Int: "No healthy proxy is available", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeUserDataFailed] = errs.ErrCode{Int: "A user data directory could not be created from a template", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromePreferencesFailed] = errs.ErrCode{Int: "Preferences or policies could not be written", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeUsageUnavailable] = errs.ErrCode{Int: "Process resource usage could not be read", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeBudgetExceeded] = errs.ErrCode{Int: "The browser process tree exceeded its resource budget", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeCleanupFailed] = errs.ErrCode{Int: "Stale user data directories or orphaned processes could not be cleaned up", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeBrowserNotFound] = errs.ErrCode{Int: "No installed browser was found", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeHeadlessUnsupported] = errs.ErrCode{Int: "The browser doesn't support the requested headless mode", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeNoDisplay] = errs.ErrCode{Int: "No display is available for a headful browser", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeGraphicsProbeFailed] = errs.ErrCode{Int: "The graphics capabilities of the browser could not be probed", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeConnectionFailed] = errs.ErrCode{Int: "The shared browser connection failed", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeTunnelFailed] = errs.ErrCode{Int: "An SSH tunnel to a remote browser could not be opened", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeBrokerFailed] = errs.ErrCode{Int: "A request to a browser broker failed", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeWarmPoolFailed] = errs.ErrCode{Int: "A warm tab could not be created or reused", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeRenderFailed] = errs.ErrCode{Int: "A page could not be rendered", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[ChromeJobFailed] = errs.ErrCode{Int: "A job could not be scheduled", Ext: "The service is unavailable", HTTP: 503}
+	errs.Codes[ChromeTenancyFailed] = errs.ErrCode{Int: "A tenant's browser context could not be opened or closed", Ext: "An unknown error occurred", HTTP: 500}
 
 	errs.Codes[FlagDoesNotExist] = errs.ErrCode{Int: "The specified argument does not exist", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[FlagTypeInvalid] = errs.ErrCode{Int: "Invalid data type for the specified argument", Ext: "An unknown error occurred", HTTP: 500}
@@ -118,12 +286,62 @@ func init() {
 	errs.Codes[TabQueryFailed] = errs.ErrCode{Int: "The new tab query failed", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[TabURLInvalid] = errs.ErrCode{Int: "Invalid URL passed to NewTab", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[TabWebsocketURLInvalid] = errs.ErrCode{Int: "Invalid websocket URL", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabCleanupFailed] = errs.ErrCode{Int: "One or more tab cleanup functions failed", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabProfileInvalid] = errs.ErrCode{Int: "A tab profile could not be read", Ext: "Invalid profile", HTTP: 400}
+	errs.Codes[TabProfileApplyFailed] = errs.ErrCode{Int: "A tab profile could not be applied", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabInterceptFailed] = errs.ErrCode{Int: "Request interception could not be enabled", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabGraphQLFailed] = errs.ErrCode{Int: "A GraphQL operation didn't return the expected response", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabWaitFailed] = errs.ErrCode{Int: "No matching network request or response was received", Ext: "The request timed out", HTTP: 504}
+	errs.Codes[TabBodyUnavailable] = errs.ErrCode{Int: "A request or response body could not be read", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabFieldNotFound] = errs.ErrCode{Int: "A JSON field could not be extracted from a body", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabHARInvalid] = errs.ErrCode{Int: "A HAR archive could not be read", Ext: "Invalid HAR archive", HTTP: 400}
+	errs.Codes[TabCanvasCaptureFailed] = errs.ErrCode{Int: "A canvas could not be captured", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabCanvasTainted] = errs.ErrCode{Int: "A canvas is tainted by cross-origin content and can't be read", Ext: "The canvas can't be captured", HTTP: 403}
+	errs.Codes[TabLifecycleFailed] = errs.ErrCode{Int: "The web lifecycle state of a tab could not be changed or verified", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabInspectorFailed] = errs.ErrCode{Int: "The Inspector domain of a tab could not be enabled", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabEvalFailed] = errs.ErrCode{Int: "An expression could not be evaluated or its value could not be decoded", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabSnapshotFailed] = errs.ErrCode{Int: "An accessibility or DOM snapshot of a tab could not be taken", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabTraceFailed] = errs.ErrCode{Int: "A trace of a tab could not be recorded or written", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabClockFailed] = errs.ErrCode{Int: "The fake clock of a tab could not be installed or controlled", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabRandomSeedFailed] = errs.ErrCode{Int: "The random number generators of a tab could not be seeded", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabStorageFixturesFailed] = errs.ErrCode{Int: "The storage fixtures of a tab could not be added", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabLoginFailed] = errs.ErrCode{Int: "A login flow could not be completed in a tab", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabChallengeFailed] = errs.ErrCode{Int: "The challenge detection of a tab could not be enabled", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[TabNavigationFailed] = errs.ErrCode{Int: "A navigation failed after every attempt", Ext: "The page could not be loaded", HTTP: 502}
 
 	errs.Codes[SocketCloseFailed] = errs.ErrCode{Int: "A failure occurred while closing a websocket", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[SocketReadFailed] = errs.ErrCode{Int: "A failure occurred while reading from a websocket", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[SocketPanic] = errs.ErrCode{Int: "A panic occurred while reading from a websocket", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketEventDecodeFailed] = errs.ErrCode{Int: "Event parameters could not be decoded", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketEventFieldNotFound] = errs.ErrCode{Int: "Event parameter field not found", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketEventHandlerPanic] = errs.ErrCode{Int: "An event handler callback panicked", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketCommandTimeout] = errs.ErrCode{Int: "A command did not receive a response in time", Ext: "The browser did not respond in time", HTTP: 504}
+	errs.Codes[SocketCommandCanceled] = errs.ErrCode{Int: "A command was canceled before it received a response", Ext: "The request was canceled", HTTP: 500}
+	errs.Codes[SocketConnectionLost] = errs.ErrCode{Int: "The websocket connection was lost before a command received a response", Ext: "The browser connection was lost", HTTP: 502}
+	errs.Codes[SocketReconnectFailed] = errs.ErrCode{Int: "The websocket connection could not be re-established", Ext: "The browser connection was lost", HTTP: 502}
+	errs.Codes[SocketCommandVetoed] = errs.ErrCode{Int: "A command was vetoed by socket middleware", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketShutdown] = errs.ErrCode{Int: "The socket was shut down before a command received a response", Ext: "The browser connection was closed", HTTP: 503}
+	errs.Codes[SocketMessageTooLarge] = errs.ErrCode{Int: "A message exceeded the maximum message size and could not be streamed", Ext: "The response was too large", HTTP: 500}
+	errs.Codes[SocketDetached] = errs.ErrCode{Int: "The browser detached the connection or session", Ext: "The browser connection was taken over or closed", HTTP: 502}
+	errs.Codes[SocketReplayFailed] = errs.ErrCode{Int: "A recording could not be read or a command has no recorded response", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketProtocolExposureDenied] = errs.ErrCode{Int: "Exposing the DevTools protocol to a target was denied", Ext: "An unknown error occurred", HTTP: 403}
+	errs.Codes[SocketDeadlock] = errs.ErrCode{Int: "The socket read loop would deadlock", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SocketVersionUnavailable] = errs.ErrCode{Int: "The browser version could not be discovered", Ext: "An unknown error occurred", HTTP: 500}
 
 	errs.Codes[WebsocketConnectFailed] = errs.ErrCode{Int: "Websocket connection failed", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[WebsocketNotConnected] = errs.ErrCode{Int: "Websocket not connected", Ext: "An unknown error occurred", HTTP: 500}
 	errs.Codes[WebsocketPanic] = errs.ErrCode{Int: "A panic occurred while reading from a websocket", Ext: "An unknown error occurred", HTTP: 500}
+
+	errs.Codes[FingerprintNotFound] = errs.ErrCode{Int: "The named fingerprint profile does not exist", Ext: "Fingerprint profile not found", HTTP: 404}
+	errs.Codes[FingerprintApplyFailed] = errs.ErrCode{Int: "A fingerprint profile could not be applied", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[FingerprintSelfTestFailed] = errs.ErrCode{Int: "The fingerprint self-test could not be completed", Ext: "An unknown error occurred", HTTP: 500}
+
+	errs.Codes[CookieInspectFailed] = errs.ErrCode{Int: "The browser cookies could not be read", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[CookieSimulationFailed] = errs.ErrCode{Int: "The third-party cookie simulation could not be completed", Ext: "An unknown error occurred", HTTP: 500}
+
+	errs.Codes[MediaRecordFailed] = errs.ErrCode{Int: "A media recording could not be started or stopped", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[MediaWriteFailed] = errs.ErrCode{Int: "A media recording could not be written", Ext: "An unknown error occurred", HTTP: 500}
+
+	errs.Codes[SourceMapInvalid] = errs.ErrCode{Int: "A source map could not be decoded", Ext: "An unknown error occurred", HTTP: 500}
+	errs.Codes[SourceMapLoadFailed] = errs.ErrCode{Int: "The source map of a script could not be loaded", Ext: "An unknown error occurred", HTTP: 502}
 }
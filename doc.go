@@ -178,5 +178,10 @@ For example:
 View the enum of possible reasons. (For reference: the original patch).
 After disconnection, some apps have chosen to pause their state and
 offer a reconnect button.
+
+In go-chrome the event is delivered to Inspector().OnDetached handlers,
+Tab.Detached returns the reason and the commands of a detached tab fail
+with a SocketDetached error. The chrome.WithReattach option attaches
+tabs again instead.
 */
 package gochrome
@@ -0,0 +1,221 @@
+/*
+Package logger defines the logging interface used by the chrome and socket
+packages, so applications can route their log output to the logging library
+they already use.
+
+The default logger writes to the bdlm/log standard logger. It can be replaced
+for every instance created afterwards with SetDefault, or for a single instance
+with the WithLogger options. The subpackages adapt other libraries:
+
+	zaplogger.New(zapLogger)       // go.uber.org/zap
+	logruslogger.New(logrusLogger) // github.com/sirupsen/logrus
+	sloglogger.New(slog.Default()) // log/slog, Go 1.21 and later
+
+Only the adapters that are imported add their library to a build. Func adapts
+anything else, Std adapts a standard library logger and Nop discards
+everything.
+*/
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	stdlog "log"
+	"sort"
+	"sync"
+
+	"github.com/bdlm/log"
+)
+
+/*
+Fields are the structured data logged with a message.
+*/
+type Fields map[string]interface{}
+
+/*
+Level is a log level.
+*/
+type Level int
+
+const (
+	// DebugLevel is for verbose diagnostics.
+	DebugLevel Level = iota
+	// InfoLevel is for routine events.
+	InfoLevel
+	// WarnLevel is for recoverable problems.
+	WarnLevel
+	// ErrorLevel is for failures.
+	ErrorLevel
+)
+
+/*
+String implements Stringer.
+*/
+func (level Level) String() string {
+	switch level {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(level))
+}
+
+/*
+Logger is the logging interface. Implementations must be safe for concurrent
+use.
+*/
+type Logger interface {
+	Debug(msg string, fields Fields)
+	Info(msg string, fields Fields)
+	Warn(msg string, fields Fields)
+	Error(msg string, fields Fields)
+}
+
+var (
+	defaultLogger Logger = bdlmLogger{}
+	defaultMux           = &sync.Mutex{}
+)
+
+/*
+Default returns the Logger used by instances that aren't given one. It writes
+to the bdlm/log standard logger unless it's replaced by SetDefault.
+*/
+func Default() Logger {
+	defaultMux.Lock()
+	defer defaultMux.Unlock()
+	return defaultLogger
+}
+
+/*
+SetDefault replaces the Logger returned by Default. Instances that were already
+created keep the logger they were given. A nil logger restores the bdlm/log
+standard logger.
+*/
+func SetDefault(logger Logger) {
+	defaultMux.Lock()
+	defer defaultMux.Unlock()
+	if nil == logger {
+		logger = bdlmLogger{}
+	}
+	defaultLogger = logger
+}
+
+/*
+SetLevel sets the minimum level of the bdlm/log standard logger the default
+Logger writes to, for example from an environment variable:
+
+	if level, err := logger.ParseLevel(os.Getenv("LOG_LEVEL")); nil == err {
+		logger.SetLevel(level)
+	}
+*/
+func SetLevel(level Level) {
+	if bdlmLevel, err := log.ParseLevel(level.String()); nil == err {
+		log.SetLevel(bdlmLevel)
+	}
+}
+
+/*
+ParseLevel returns the level with a name, one of 'debug', 'info', 'warn' or
+'error'.
+*/
+func ParseLevel(name string) (Level, error) {
+	for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		if level.String() == name {
+			return level, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level '%s'", name)
+}
+
+/*
+bdlmLogger writes to the bdlm/log standard logger.
+*/
+type bdlmLogger struct{}
+
+func (bdlmLogger) Debug(msg string, fields Fields) {
+	log.WithFields(log.Fields(fields)).Debug(msg)
+}
+
+func (bdlmLogger) Info(msg string, fields Fields) {
+	log.WithFields(log.Fields(fields)).Info(msg)
+}
+
+func (bdlmLogger) Warn(msg string, fields Fields) {
+	log.WithFields(log.Fields(fields)).Warn(msg)
+}
+
+func (bdlmLogger) Error(msg string, fields Fields) {
+	log.WithFields(log.Fields(fields)).Error(msg)
+}
+
+/*
+Func is a Logger that passes every message to a function.
+*/
+type Func func(level Level, msg string, fields Fields)
+
+/*
+Debug implements Logger.
+*/
+func (fn Func) Debug(msg string, fields Fields) {
+	fn(DebugLevel, msg, fields)
+}
+
+/*
+Info implements Logger.
+*/
+func (fn Func) Info(msg string, fields Fields) {
+	fn(InfoLevel, msg, fields)
+}
+
+/*
+Warn implements Logger.
+*/
+func (fn Func) Warn(msg string, fields Fields) {
+	fn(WarnLevel, msg, fields)
+}
+
+/*
+Error implements Logger.
+*/
+func (fn Func) Error(msg string, fields Fields) {
+	fn(ErrorLevel, msg, fields)
+}
+
+/*
+Nop returns a Logger that discards everything.
+*/
+func Nop() Logger {
+	return Func(func(level Level, msg string, fields Fields) {})
+}
+
+/*
+Std returns a Logger that writes messages at or above a level to a standard
+library logger, with the fields appended as sorted key=value pairs:
+
+	warn reconnect failed attempt=2 socketID=1
+*/
+func Std(logger *stdlog.Logger, min Level) Logger {
+	return Func(func(level Level, msg string, fields Fields) {
+		if level < min {
+			return
+		}
+		buf := &bytes.Buffer{}
+		buf.WriteString(level.String())
+		buf.WriteString(" ")
+		buf.WriteString(msg)
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(buf, " %s=%v", key, fields[key])
+		}
+		logger.Output(2, buf.String())
+	})
+}
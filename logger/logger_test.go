@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"bytes"
+	stdlog "log"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	levels := make([]Level, 0)
+	log := Func(func(level Level, msg string, fields Fields) {
+		if "message" != msg || 1 != fields["key"] {
+			t.Errorf("Expected the message and fields, got '%s' %v", msg, fields)
+		}
+		levels = append(levels, level)
+	})
+	log.Debug("message", Fields{"key": 1})
+	log.Info("message", Fields{"key": 1})
+	log.Warn("message", Fields{"key": 1})
+	log.Error("message", Fields{"key": 1})
+	if 4 != len(levels) || DebugLevel != levels[0] || InfoLevel != levels[1] || WarnLevel != levels[2] || ErrorLevel != levels[3] {
+		t.Errorf("Expected each level, got %v", levels)
+	}
+
+	Nop().Error("discarded", nil)
+	Default().Debug("message", Fields{"key": 1})
+}
+
+func TestStd(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := Std(stdlog.New(buf, "", 0), InfoLevel)
+	log.Debug("hidden", nil)
+	log.Warn("reconnect failed", Fields{"socketID": 1, "attempt": 2})
+	if "warn reconnect failed attempt=2 socketID=1\n" != buf.String() {
+		t.Errorf("Expected the message with sorted fields, got '%s'", buf.String())
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	for level, expect := range map[Level]string{
+		DebugLevel: "debug",
+		InfoLevel:  "info",
+		WarnLevel:  "warn",
+		ErrorLevel: "error",
+		Level(9):   "level(9)",
+	} {
+		if expect != level.String() {
+			t.Errorf("Expected %s, got %s", expect, level.String())
+		}
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	var logged string
+	SetDefault(Func(func(level Level, msg string, fields Fields) {
+		logged = msg
+	}))
+	Default().Info("message", nil)
+	if "message" != logged {
+		t.Errorf("Expected the replaced default to log, got '%s'", logged)
+	}
+
+	SetDefault(nil)
+	if _, ok := Default().(bdlmLogger); !ok {
+		t.Errorf("Expected the bdlm/log logger, got %T", Default())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, expect := range map[string]Level{
+		"debug": DebugLevel,
+		"info":  InfoLevel,
+		"warn":  WarnLevel,
+		"error": ErrorLevel,
+	} {
+		level, err := ParseLevel(name)
+		if nil != err || expect != level {
+			t.Errorf("Expected %s, got %s %v", expect, level, err)
+		}
+		SetLevel(level)
+	}
+	if _, err := ParseLevel("verbose"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Package logruslogger adapts a github.com/sirupsen/logrus logger to the
+logger.Logger interface:
+
+	browser := chrome.New(chrome.WithLogger(logruslogger.New(logrus.StandardLogger())))
+*/
+package logruslogger
+
+import (
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Logger writes to a logrus logger or entry, with the fields as logrus fields.
+*/
+type Logger struct {
+	logger logrus.FieldLogger
+}
+
+/*
+New returns a Logger that writes to a logrus logger or entry.
+*/
+func New(log logrus.FieldLogger) *Logger {
+	return &Logger{logger: log}
+}
+
+/*
+Debug implements logger.Logger.
+*/
+func (log *Logger) Debug(msg string, fields logger.Fields) {
+	log.logger.WithFields(logrus.Fields(fields)).Debug(msg)
+}
+
+/*
+Info implements logger.Logger.
+*/
+func (log *Logger) Info(msg string, fields logger.Fields) {
+	log.logger.WithFields(logrus.Fields(fields)).Info(msg)
+}
+
+/*
+Warn implements logger.Logger.
+*/
+func (log *Logger) Warn(msg string, fields logger.Fields) {
+	log.logger.WithFields(logrus.Fields(fields)).Warn(msg)
+}
+
+/*
+Error implements logger.Logger.
+*/
+func (log *Logger) Error(msg string, fields logger.Fields) {
+	log.logger.WithFields(logrus.Fields(fields)).Error(msg)
+}
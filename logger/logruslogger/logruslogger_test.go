@@ -0,0 +1,40 @@
+package logruslogger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logrusLogger := logrus.New()
+	logrusLogger.Out = buf
+	logrusLogger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	logrusLogger.Level = logrus.DebugLevel
+
+	var log logger.Logger = New(logrusLogger)
+	log.Debug("debug", nil)
+	log.Info("info", nil)
+	log.Warn("reconnect failed", logger.Fields{"socketID": 1, "attempt": 2})
+	log.Error("error", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`level=debug msg=debug`,
+		`level=info msg=info`,
+		`level=warning msg="reconnect failed" attempt=2 socketID=1`,
+		`level=error msg=error`,
+	}
+	if len(expected) != len(lines) {
+		t.Fatalf("Expected %d lines, got %q", len(expected), lines)
+	}
+	for a, line := range lines {
+		if expected[a] != line {
+			t.Errorf("Expected '%s', got '%s'", expected[a], line)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+/*
+Package sloglogger adapts a log/slog logger to the logger.Logger interface:
+
+	browser := chrome.New(chrome.WithLogger(sloglogger.New(slog.Default())))
+
+log/slog was added in Go 1.21, the package is empty in earlier releases.
+*/
+package sloglogger
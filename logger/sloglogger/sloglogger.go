@@ -0,0 +1,70 @@
+//go:build go1.21
+// +build go1.21
+
+package sloglogger
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+Logger writes to a slog logger, with the fields as attributes.
+*/
+type Logger struct {
+	logger *slog.Logger
+}
+
+/*
+New returns a Logger that writes to a slog logger.
+*/
+func New(log *slog.Logger) *Logger {
+	return &Logger{logger: log}
+}
+
+/*
+Debug implements logger.Logger.
+*/
+func (log *Logger) Debug(msg string, fields logger.Fields) {
+	log.log(slog.LevelDebug, msg, fields)
+}
+
+/*
+Info implements logger.Logger.
+*/
+func (log *Logger) Info(msg string, fields logger.Fields) {
+	log.log(slog.LevelInfo, msg, fields)
+}
+
+/*
+Warn implements logger.Logger.
+*/
+func (log *Logger) Warn(msg string, fields logger.Fields) {
+	log.log(slog.LevelWarn, msg, fields)
+}
+
+/*
+Error implements logger.Logger.
+*/
+func (log *Logger) Error(msg string, fields logger.Fields) {
+	log.log(slog.LevelError, msg, fields)
+}
+
+/*
+log logs a message with the fields as attributes, sorted by key.
+*/
+func (log *Logger) log(level slog.Level, msg string, fields logger.Fields) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, fields[key]))
+	}
+	log.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
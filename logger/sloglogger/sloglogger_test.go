@@ -0,0 +1,48 @@
+//go:build go1.21
+// +build go1.21
+
+package sloglogger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/logger"
+)
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if slog.TimeKey == attr.Key {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+
+	var log logger.Logger = New(slog.New(handler))
+	log.Debug("debug", nil)
+	log.Info("info", nil)
+	log.Warn("reconnect failed", logger.Fields{"socketID": 1, "attempt": 2})
+	log.Error("error", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`level=DEBUG msg=debug`,
+		`level=INFO msg=info`,
+		`level=WARN msg="reconnect failed" attempt=2 socketID=1`,
+		`level=ERROR msg=error`,
+	}
+	if len(expected) != len(lines) {
+		t.Fatalf("Expected %d lines, got %q", len(expected), lines)
+	}
+	for a, line := range lines {
+		if expected[a] != line {
+			t.Errorf("Expected '%s', got '%s'", expected[a], line)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Package zaplogger adapts a go.uber.org/zap logger to the logger.Logger
+interface:
+
+	browser := chrome.New(chrome.WithLogger(zaplogger.New(zapLogger)))
+*/
+package zaplogger
+
+import (
+	"sort"
+
+	"github.com/mkenney/go-chrome/logger"
+	"go.uber.org/zap"
+)
+
+/*
+Logger writes to a zap logger, with the fields as zap fields.
+*/
+type Logger struct {
+	logger *zap.Logger
+}
+
+/*
+New returns a Logger that writes to a zap logger. Callers are reported as the
+code that logged through the adapter.
+*/
+func New(log *zap.Logger) *Logger {
+	return &Logger{logger: log.WithOptions(zap.AddCallerSkip(1))}
+}
+
+/*
+Debug implements logger.Logger.
+*/
+func (log *Logger) Debug(msg string, fields logger.Fields) {
+	log.logger.Debug(msg, zapFields(fields)...)
+}
+
+/*
+Info implements logger.Logger.
+*/
+func (log *Logger) Info(msg string, fields logger.Fields) {
+	log.logger.Info(msg, zapFields(fields)...)
+}
+
+/*
+Warn implements logger.Logger.
+*/
+func (log *Logger) Warn(msg string, fields logger.Fields) {
+	log.logger.Warn(msg, zapFields(fields)...)
+}
+
+/*
+Error implements logger.Logger.
+*/
+func (log *Logger) Error(msg string, fields logger.Fields) {
+	log.logger.Error(msg, zapFields(fields)...)
+}
+
+/*
+zapFields converts fields to zap fields, sorted by key.
+*/
+func zapFields(fields logger.Fields) []zap.Field {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	zfields := make([]zap.Field, 0, len(keys))
+	for _, key := range keys {
+		zfields = append(zfields, zap.Any(key, fields[key]))
+	}
+	return zfields
+}
@@ -0,0 +1,33 @@
+package zaplogger
+
+import (
+	"testing"
+
+	"github.com/mkenney/go-chrome/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	var log logger.Logger = New(zap.New(core))
+	log.Debug("debug", nil)
+	log.Info("info", nil)
+	log.Warn("reconnect failed", logger.Fields{"socketID": 1, "attempt": 2})
+	log.Error("error", nil)
+
+	entries := logs.AllUntimed()
+	if 4 != len(entries) {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+	for a, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+		if level != entries[a].Level {
+			t.Errorf("Expected %s, got %s", level, entries[a].Level)
+		}
+	}
+	fields := entries[2].ContextMap()
+	if "reconnect failed" != entries[2].Message || int64(1) != fields["socketID"] || int64(2) != fields["attempt"] {
+		t.Errorf("Expected the message and fields, got '%s' %v", entries[2].Message, fields)
+	}
+}
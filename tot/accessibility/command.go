@@ -2,8 +2,38 @@ package accessibility
 
 import (
 	"github.com/mkenney/go-chrome/tot/dom"
+	"github.com/mkenney/go-chrome/tot/page"
 )
 
+/*
+GetFullAXTreeParams represents Accessibility.getFullAXTree parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getFullAXTree
+*/
+type GetFullAXTreeParams struct {
+	// Optional. The maximum depth at which descendants of the root node should
+	// be retrieved. If omitted, the full tree is returned.
+	Depth int `json:"depth,omitempty"`
+
+	// Optional. The frame for whose document the AX tree should be retrieved.
+	// If omitted, the root frame is used.
+	FrameID page.FrameID `json:"frameId,omitempty"`
+}
+
+/*
+GetFullAXTreeResult represents the result of calls to
+Accessibility.getFullAXTree.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getFullAXTree
+*/
+type GetFullAXTreeResult struct {
+	// The nodes of the accessibility tree.
+	Nodes []*AXNode `json:"nodes"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 PartialAXTreeParams represents Accessibility.partialAXTree parameters.
 
@@ -4,3 +4,987 @@ Package audits provides type definitions for use with the Chrome Audits protocol
 https://chromedevtools.github.io/devtools-protocol/tot/Audits/
 */
 package audits
+
+import (
+	"github.com/mkenney/go-chrome/tot/dom"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+)
+
+/*
+AffectedCookie is information about a cookie that is affected by an inspector
+issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-AffectedCookie
+*/
+type AffectedCookie struct {
+	// The following three properties uniquely identify a cookie.
+	Name string `json:"name"`
+
+	Path string `json:"path"`
+
+	Domain string `json:"domain"`
+}
+
+/*
+AffectedRequest is information about a request that is affected by an inspector
+issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-AffectedRequest
+*/
+type AffectedRequest struct {
+	// Optional. The unique request id.
+	RequestID network.RequestID `json:"requestId,omitempty"`
+
+	URL string `json:"url"`
+}
+
+/*
+AffectedFrame is information about the frame affected by an inspector issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-AffectedFrame
+*/
+type AffectedFrame struct {
+	FrameID page.FrameID `json:"frameId"`
+}
+
+/*
+CookieExclusionReason is a cookie exclusion reason. Allowed values:
+"ExcludeSameSiteUnspecifiedTreatedAsLax", "ExcludeSameSiteNoneInsecure",
+"ExcludeSameSiteLax", "ExcludeSameSiteStrict", "ExcludeInvalidSameParty",
+"ExcludeSamePartyCrossPartyContext", "ExcludeDomainNonASCII",
+"ExcludeThirdPartyCookieBlockedInFirstPartySet", "ExcludeThirdPartyPhaseout",
+"ExcludePortMismatch", "ExcludeSchemeMismatch".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieExclusionReason
+*/
+type CookieExclusionReason string
+
+/*
+CookieWarningReason is a cookie warning reason. Allowed values:
+"WarnSameSiteUnspecifiedCrossSiteContext", "WarnSameSiteNoneInsecure",
+"WarnSameSiteUnspecifiedLaxAllowUnsafe", "WarnSameSiteStrictLaxDowngradeStrict",
+"WarnSameSiteStrictCrossDowngradeStrict", "WarnSameSiteStrictCrossDowngradeLax",
+"WarnSameSiteLaxCrossDowngradeStrict", "WarnSameSiteLaxCrossDowngradeLax",
+"WarnAttributeValueExceedsMaxSize", "WarnDomainNonASCII",
+"WarnThirdPartyPhaseout", "WarnCrossSiteRedirectDowngradeChangesInclusion",
+"WarnDeprecationTrialMetadata", "WarnThirdPartyCookieHeuristic".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieWarningReason
+*/
+type CookieWarningReason string
+
+/*
+CookieOperation is a cookie operation. Allowed values: "SetCookie",
+"ReadCookie".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieOperation
+*/
+type CookieOperation string
+
+/*
+InsightType represents the category of insight that a cookie issue falls under.
+Allowed values: "GitHubResource", "GracePeriod", "Heuristics".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-InsightType
+*/
+type InsightType string
+
+/*
+CookieIssueInsight is information about the suggested solution to a cookie
+issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieIssueInsight
+*/
+type CookieIssueInsight struct {
+	Type InsightType `json:"type"`
+
+	// Optional. Link to table entry in third-party cookie migration readiness
+	// list.
+	TableEntryURL string `json:"tableEntryUrl,omitempty"`
+}
+
+/*
+CookieIssueDetails is the details of a cookie issue. The cookie is described in
+the issue rather than referenced, since it may not exist: it's identified by
+name, domain and path, or by the raw cookie line if it couldn't be parsed.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieIssueDetails
+*/
+type CookieIssueDetails struct {
+	// Optional. If AffectedCookie is not set then rawCookieLine contains the raw
+	// Set-Cookie header string. This hints at a problem where the cookie line is
+	// syntactically or semantically malformed in a way that no valid cookie could
+	// be created.
+	Cookie *AffectedCookie `json:"cookie,omitempty"`
+
+	// Optional.
+	RawCookieLine string `json:"rawCookieLine,omitempty"`
+
+	CookieWarningReasons []CookieWarningReason `json:"cookieWarningReasons"`
+
+	CookieExclusionReasons []CookieExclusionReason `json:"cookieExclusionReasons"`
+
+	// Optionally identifies the site-for-cookies and the cookie url, which may be
+	// used by the front-end as additional context.
+	Operation CookieOperation `json:"operation"`
+
+	// Optional.
+	SiteForCookies string `json:"siteForCookies,omitempty"`
+
+	// Optional.
+	CookieURL string `json:"cookieUrl,omitempty"`
+
+	// Optional.
+	Request *AffectedRequest `json:"request,omitempty"`
+
+	// Optional. The recommended solution to the issue.
+	Insight *CookieIssueInsight `json:"insight,omitempty"`
+}
+
+/*
+MixedContentResolutionStatus is a mixed content resolution status. Allowed
+values: "MixedContentBlocked", "MixedContentAutomaticallyUpgraded",
+"MixedContentWarning".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-MixedContentResolutionStatus
+*/
+type MixedContentResolutionStatus string
+
+/*
+MixedContentResourceType is a mixed content resource type. Allowed values:
+"AttributionSrc", "Audio", "Beacon", "CSPReport", "Download", "EventSource",
+"Favicon", "Font", "Form", "Frame", "Image", "Import", "JSON", "Manifest",
+"Ping", "PluginData", "PluginResource", "Prefetch", "Resource", "Script",
+"ServiceWorker", "SharedWorker", "SpeculationRules", "Stylesheet", "Track",
+"Video", "Worker", "XMLHttpRequest", "XSLT".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-MixedContentResourceType
+*/
+type MixedContentResourceType string
+
+/*
+MixedContentIssueDetails is the details of a mixed content issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-MixedContentIssueDetails
+*/
+type MixedContentIssueDetails struct {
+	// Optional. The type of resource causing the mixed content issue (css, js,
+	// iframe, form,...). Marked as optional because it is mapped to from
+	// blink::mojom::RequestContextType, which will be replaced by
+	// network::mojom::RequestDestination.
+	ResourceType MixedContentResourceType `json:"resourceType,omitempty"`
+
+	// The way the mixed content issue is being resolved.
+	ResolutionStatus MixedContentResolutionStatus `json:"resolutionStatus"`
+
+	// The unsafe http url causing the mixed content issue.
+	InsecureURL string `json:"insecureURL"`
+
+	// The url responsible for the call to an unsafe url.
+	MainResourceURL string `json:"mainResourceURL"`
+
+	// Optional. The mixed content request. Does not always exist (e.g. for unsafe
+	// form submission urls).
+	Request *AffectedRequest `json:"request,omitempty"`
+
+	// Optional. Optional because not every mixed content issue is necessarily
+	// linked to a frame.
+	Frame *AffectedFrame `json:"frame,omitempty"`
+}
+
+/*
+BlockedByResponseReason is an enum indicating the reason a response has been
+blocked. These reasons are refinements of the net error BLOCKED_BY_RESPONSE.
+Allowed values: "CoepFrameResourceNeedsCoepHeader",
+"CoopSandboxedIFrameCannotNavigateToCoopPage", "CorpNotSameOrigin",
+"CorpNotSameOriginAfterDefaultedToSameOriginByCoep",
+"CorpNotSameOriginAfterDefaultedToSameOriginByDip",
+"CorpNotSameOriginAfterDefaultedToSameOriginByCoepAndDip", "CorpNotSameSite",
+"SRIMessageSignatureMismatch".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-BlockedByResponseReason
+*/
+type BlockedByResponseReason string
+
+/*
+BlockedByResponseIssueDetails is details for a request that has been blocked
+with the BLOCKED_BY_RESPONSE code. Currently only used for COEP/COOP, but may be
+extended to include some CSP errors in the future.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-BlockedByResponseIssueDetails
+*/
+type BlockedByResponseIssueDetails struct {
+	Request *AffectedRequest `json:"request"`
+
+	// Optional.
+	ParentFrame *AffectedFrame `json:"parentFrame,omitempty"`
+
+	// Optional.
+	BlockedFrame *AffectedFrame `json:"blockedFrame,omitempty"`
+
+	Reason BlockedByResponseReason `json:"reason"`
+}
+
+/*
+HeavyAdResolutionStatus is a heavy ad resolution status. Allowed values:
+"HeavyAdBlocked", "HeavyAdWarning".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-HeavyAdResolutionStatus
+*/
+type HeavyAdResolutionStatus string
+
+/*
+HeavyAdReason is a heavy ad reason. Allowed values: "NetworkTotalLimit",
+"CpuTotalLimit", "CpuPeakLimit".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-HeavyAdReason
+*/
+type HeavyAdReason string
+
+/*
+HeavyAdIssueDetails is the details of a heavy ad issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-HeavyAdIssueDetails
+*/
+type HeavyAdIssueDetails struct {
+	// The resolution status, either blocking the content or warning.
+	Resolution HeavyAdResolutionStatus `json:"resolution"`
+
+	// The reason the ad was blocked, total network or cpu or peak cpu.
+	Reason HeavyAdReason `json:"reason"`
+
+	// The frame that was blocked.
+	Frame *AffectedFrame `json:"frame"`
+}
+
+/*
+ContentSecurityPolicyViolationType is a content security policy violation type.
+Allowed values: "kInlineViolation", "kEvalViolation", "kURLViolation",
+"kSRIViolation", "kTrustedTypesSinkViolation", "kTrustedTypesPolicyViolation",
+"kWasmEvalViolation".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ContentSecurityPolicyViolationType
+*/
+type ContentSecurityPolicyViolationType string
+
+/*
+SourceCodeLocation is a source code location.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SourceCodeLocation
+*/
+type SourceCodeLocation struct {
+	// Optional.
+	ScriptID runtime.ScriptID `json:"scriptId,omitempty"`
+
+	URL string `json:"url"`
+
+	LineNumber int `json:"lineNumber"`
+
+	ColumnNumber int `json:"columnNumber"`
+}
+
+/*
+ContentSecurityPolicyIssueDetails is the details of a content security policy
+issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ContentSecurityPolicyIssueDetails
+*/
+type ContentSecurityPolicyIssueDetails struct {
+	// Optional. The url not included in allowed sources.
+	BlockedURL string `json:"blockedURL,omitempty"`
+
+	// Specific directive that is violated, causing the CSP issue.
+	ViolatedDirective string `json:"violatedDirective"`
+
+	IsReportOnly bool `json:"isReportOnly"`
+
+	ContentSecurityPolicyViolationType ContentSecurityPolicyViolationType `json:"contentSecurityPolicyViolationType"`
+
+	// Optional.
+	FrameAncestor *AffectedFrame `json:"frameAncestor,omitempty"`
+
+	// Optional.
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation,omitempty"`
+
+	// Optional.
+	ViolatingNodeID dom.BackendNodeID `json:"violatingNodeId,omitempty"`
+}
+
+/*
+SharedArrayBufferIssueType is a shared array buffer issue type. Allowed values:
+"TransferIssue", "CreationIssue".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SharedArrayBufferIssueType
+*/
+type SharedArrayBufferIssueType string
+
+/*
+SharedArrayBufferIssueDetails is details for a issue arising from an SAB being
+instantiated in, or transferred to a context that is not cross-origin isolated.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SharedArrayBufferIssueDetails
+*/
+type SharedArrayBufferIssueDetails struct {
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation"`
+
+	IsWarning bool `json:"isWarning"`
+
+	Type SharedArrayBufferIssueType `json:"type"`
+}
+
+/*
+LowTextContrastIssueDetails is the details of a low text contrast issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-LowTextContrastIssueDetails
+*/
+type LowTextContrastIssueDetails struct {
+	ViolatingNodeID dom.BackendNodeID `json:"violatingNodeId"`
+
+	ViolatingNodeSelector string `json:"violatingNodeSelector"`
+
+	ContrastRatio float64 `json:"contrastRatio"`
+
+	ThresholdAA float64 `json:"thresholdAA"`
+
+	ThresholdAAA float64 `json:"thresholdAAA"`
+
+	FontSize string `json:"fontSize"`
+
+	FontWeight string `json:"fontWeight"`
+}
+
+/*
+CorsIssueDetails is details for a CORS related issue, e.g. a warning or error
+related to CORS RFC1918 enforcement.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CorsIssueDetails
+*/
+type CorsIssueDetails struct {
+	CorsErrorStatus *network.CorsErrorStatus `json:"corsErrorStatus"`
+
+	IsWarning bool `json:"isWarning"`
+
+	Request *AffectedRequest `json:"request"`
+
+	// Optional.
+	Location *SourceCodeLocation `json:"location,omitempty"`
+
+	// Optional.
+	InitiatorOrigin string `json:"initiatorOrigin,omitempty"`
+
+	// Optional.
+	ResourceIPAddressSpace network.IPAddressSpace `json:"resourceIPAddressSpace,omitempty"`
+
+	// Optional.
+	ClientSecurityState *network.ClientSecurityState `json:"clientSecurityState,omitempty"`
+}
+
+/*
+AttributionReportingIssueType is an attribution reporting issue type. Allowed
+values: "PermissionPolicyDisabled", "UntrustworthyReportingOrigin",
+"InsecureContext", "InvalidHeader", "InvalidRegisterTriggerHeader",
+"SourceAndTriggerHeaders", "SourceIgnored", "TriggerIgnored", "OsSourceIgnored",
+"OsTriggerIgnored", "InvalidRegisterOsSourceHeader",
+"InvalidRegisterOsTriggerHeader", "WebAndOsHeaders", "NoWebOrOsSupport",
+"NavigationRegistrationWithoutTransientUserActivation", "InvalidInfoHeader",
+"NoRegisterSourceHeader", "NoRegisterTriggerHeader", "NoRegisterOsSourceHeader",
+"NoRegisterOsTriggerHeader", "NavigationRegistrationUniqueScopeAlreadySet".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-AttributionReportingIssueType
+*/
+type AttributionReportingIssueType string
+
+/*
+SharedDictionaryError is a shared dictionary error. Allowed values:
+"UseErrorCrossOriginNoCorsRequest", "UseErrorDictionaryLoadFailure",
+"UseErrorMatchingDictionaryNotUsed",
+"UseErrorUnexpectedContentDictionaryHeader",
+"WriteErrorCossOriginNoCorsRequest", "WriteErrorDisallowedBySettings",
+"WriteErrorExpiredResponse", "WriteErrorFeatureDisabled",
+"WriteErrorInsufficientResources", "WriteErrorInvalidMatchField",
+"WriteErrorInvalidStructuredHeader", "WriteErrorNavigationRequest",
+"WriteErrorNoMatchField", "WriteErrorNonListMatchDestField",
+"WriteErrorNonSecureContext", "WriteErrorNonStringIdField",
+"WriteErrorNonStringInMatchDestList", "WriteErrorNonStringMatchField",
+"WriteErrorNonTokenTypeField", "WriteErrorRequestAborted",
+"WriteErrorShuttingDown", "WriteErrorTooLongIdField",
+"WriteErrorUnsupportedType".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SharedDictionaryError
+*/
+type SharedDictionaryError string
+
+/*
+SRIMessageSignatureError is a SRI message signature error. Allowed values:
+"MissingSignatureHeader", "MissingSignatureInputHeader",
+"InvalidSignatureHeader", "InvalidSignatureInputHeader",
+"SignatureHeaderValueIsNotByteSequence", "SignatureHeaderValueIsParameterized",
+"SignatureHeaderValueIsIncorrectLength", "SignatureInputHeaderMissingLabel",
+"SignatureInputHeaderValueNotInnerList",
+"SignatureInputHeaderValueMissingComponents",
+"SignatureInputHeaderInvalidComponentType",
+"SignatureInputHeaderInvalidComponentName",
+"SignatureInputHeaderInvalidHeaderComponentParameter",
+"SignatureInputHeaderInvalidDerivedComponentParameter",
+"SignatureInputHeaderKeyIdLength", "SignatureInputHeaderInvalidParameter",
+"SignatureInputHeaderMissingRequiredParameters",
+"ValidationFailedSignatureExpired", "ValidationFailedInvalidLength",
+"ValidationFailedSignatureMismatch", "ValidationFailedIntegrityMismatch".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SRIMessageSignatureError
+*/
+type SRIMessageSignatureError string
+
+/*
+UnencodedDigestError is an unencoded digest error. Allowed values:
+"MalformedDictionary", "UnknownAlgorithm", "IncorrectDigestType",
+"IncorrectDigestLength".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-UnencodedDigestError
+*/
+type UnencodedDigestError string
+
+/*
+AttributionReportingIssueDetails is details for issues around "Attribution
+Reporting API" usage. Explainer:
+https://github.com/WICG/attribution-reporting-api.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-AttributionReportingIssueDetails
+*/
+type AttributionReportingIssueDetails struct {
+	ViolationType AttributionReportingIssueType `json:"violationType"`
+
+	// Optional.
+	Request *AffectedRequest `json:"request,omitempty"`
+
+	// Optional.
+	ViolatingNodeID dom.BackendNodeID `json:"violatingNodeId,omitempty"`
+
+	// Optional.
+	InvalidParameter string `json:"invalidParameter,omitempty"`
+}
+
+/*
+QuirksModeIssueDetails is details for issues about documents in Quirks Mode or
+Limited Quirks Mode that affects page layouting.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-QuirksModeIssueDetails
+*/
+type QuirksModeIssueDetails struct {
+	// If false, it means the document's mode is "quirks" instead of
+	// "limited-quirks".
+	IsLimitedQuirksMode bool `json:"isLimitedQuirksMode"`
+
+	DocumentNodeID dom.BackendNodeID `json:"documentNodeId"`
+
+	URL string `json:"url"`
+
+	FrameID page.FrameID `json:"frameId"`
+
+	LoaderID network.LoaderID `json:"loaderId"`
+}
+
+/*
+NavigatorUserAgentIssueDetails is the details of a navigator user agent issue.
+DEPRECATED.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-NavigatorUserAgentIssueDetails
+*/
+type NavigatorUserAgentIssueDetails struct {
+	URL string `json:"url"`
+
+	// Optional.
+	Location *SourceCodeLocation `json:"location,omitempty"`
+}
+
+/*
+SharedDictionaryIssueDetails is the details of a shared dictionary issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SharedDictionaryIssueDetails
+*/
+type SharedDictionaryIssueDetails struct {
+	SharedDictionaryError SharedDictionaryError `json:"sharedDictionaryError"`
+
+	Request *AffectedRequest `json:"request"`
+}
+
+/*
+SRIMessageSignatureIssueDetails is the details of a SRI message signature issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-SRIMessageSignatureIssueDetails
+*/
+type SRIMessageSignatureIssueDetails struct {
+	Error SRIMessageSignatureError `json:"error"`
+
+	SignatureBase string `json:"signatureBase"`
+
+	IntegrityAssertions []string `json:"integrityAssertions"`
+
+	Request *AffectedRequest `json:"request"`
+}
+
+/*
+UnencodedDigestIssueDetails is the details of an unencoded digest issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-UnencodedDigestIssueDetails
+*/
+type UnencodedDigestIssueDetails struct {
+	Error UnencodedDigestError `json:"error"`
+
+	Request *AffectedRequest `json:"request"`
+}
+
+/*
+GenericIssueErrorType is a generic issue error type. Allowed values:
+"FormLabelForNameError", "FormDuplicateIdForInputError",
+"FormInputWithNoLabelError", "FormAutocompleteAttributeEmptyError",
+"FormEmptyIdAndNameAttributesForInputError",
+"FormAriaLabelledByToNonExistingId",
+"FormInputAssignedAutocompleteValueToIdOrNameAttributeError",
+"FormLabelHasNeitherForNorNestedInput", "FormLabelForMatchesNonExistingIdError",
+"FormInputHasWrongButWellIntendedAutocompleteValueError",
+"ResponseWasBlockedByORB".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-GenericIssueErrorType
+*/
+type GenericIssueErrorType string
+
+/*
+GenericIssueDetails is the details of a generic issue. Depending on the concrete
+errorType, different properties are set.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-GenericIssueDetails
+*/
+type GenericIssueDetails struct {
+	// Issues with the same errorType are aggregated in the frontend.
+	ErrorType GenericIssueErrorType `json:"errorType"`
+
+	// Optional.
+	FrameID page.FrameID `json:"frameId,omitempty"`
+
+	// Optional.
+	ViolatingNodeID dom.BackendNodeID `json:"violatingNodeId,omitempty"`
+
+	// Optional.
+	ViolatingNodeAttribute string `json:"violatingNodeAttribute,omitempty"`
+
+	// Optional.
+	Request *AffectedRequest `json:"request,omitempty"`
+}
+
+/*
+DeprecationIssueDetails is the details of an issue that tracks information
+needed to print a deprecation message.
+https://source.chromium.org/chromium/chromium/src/+/main:third_party/blink/renderer/core/frame/third_party/blink/renderer/core/frame/deprecation/README.md.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-DeprecationIssueDetails
+*/
+type DeprecationIssueDetails struct {
+	// Optional.
+	AffectedFrame *AffectedFrame `json:"affectedFrame,omitempty"`
+
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation"`
+
+	// One of the deprecation names from
+	// third_party/blink/renderer/core/frame/deprecation/deprecation.json5.
+	Type string `json:"type"`
+}
+
+/*
+BounceTrackingIssueDetails is the details of an issue that warns about sites in
+the redirect chain of a finished navigation that may be flagged as trackers and
+have their state cleared if they don't receive a user interaction. Note that in
+this context 'site' means eTLD+1. For example, if the URL
+`https://example.test:80/bounce` was in the redirect chain, the site reported
+would be `example.test`.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-BounceTrackingIssueDetails
+*/
+type BounceTrackingIssueDetails struct {
+	TrackingSites []string `json:"trackingSites"`
+}
+
+/*
+CookieDeprecationMetadataIssueDetails is the details of an issue that warns
+about third-party sites that are accessing cookies on the current page, and have
+been permitted due to having a global metadata grant. Note that in this context
+'site' means eTLD+1. For example, if the URL `https://example.test:80/web_page`
+was accessing cookies, the site reported would be `example.test`.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-CookieDeprecationMetadataIssueDetails
+*/
+type CookieDeprecationMetadataIssueDetails struct {
+	AllowedSites []string `json:"allowedSites"`
+
+	OptOutPercentage float64 `json:"optOutPercentage"`
+
+	IsOptOutTopLevel bool `json:"isOptOutTopLevel"`
+
+	Operation CookieOperation `json:"operation"`
+}
+
+/*
+ClientHintIssueReason is a client hint issue reason. Allowed values:
+"MetaTagAllowListInvalidOrigin", "MetaTagModifiedHTML".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ClientHintIssueReason
+*/
+type ClientHintIssueReason string
+
+/*
+FederatedAuthRequestIssueDetails is the details of a federated auth request
+issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-FederatedAuthRequestIssueDetails
+*/
+type FederatedAuthRequestIssueDetails struct {
+	FederatedAuthRequestIssueReason FederatedAuthRequestIssueReason `json:"federatedAuthRequestIssueReason"`
+}
+
+/*
+FederatedAuthRequestIssueReason represents the failure reason when a federated
+authentication reason fails. Should be updated alongside RequestIdTokenStatus in
+third_party/blink/public/mojom/devtools/inspector_issue.mojom to include all
+cases except for success. Allowed values: "ShouldEmbargo", "TooManyRequests",
+"WellKnownHttpNotFound", "WellKnownNoResponse", "WellKnownInvalidResponse",
+"WellKnownListEmpty", "WellKnownInvalidContentType", "ConfigNotInWellKnown",
+"WellKnownTooBig", "ConfigHttpNotFound", "ConfigNoResponse",
+"ConfigInvalidResponse", "ConfigInvalidContentType",
+"ClientMetadataHttpNotFound", "ClientMetadataNoResponse",
+"ClientMetadataInvalidResponse", "ClientMetadataInvalidContentType",
+"IdpNotPotentiallyTrustworthy", "DisabledInSettings", "DisabledInFlags",
+"ErrorFetchingSignin", "InvalidSigninResponse", "AccountsHttpNotFound",
+"AccountsNoResponse", "AccountsInvalidResponse", "AccountsListEmpty",
+"AccountsInvalidContentType", "IdTokenHttpNotFound", "IdTokenNoResponse",
+"IdTokenInvalidResponse", "IdTokenIdpErrorResponse",
+"IdTokenCrossSiteIdpErrorResponse", "IdTokenInvalidRequest",
+"IdTokenInvalidContentType", "ErrorIdToken", "Canceled", "RpPageNotVisible",
+"SilentMediationFailure", "ThirdPartyCookiesBlocked", "NotSignedInWithIdp",
+"MissingTransientUserActivation", "ReplacedByActiveMode",
+"InvalidFieldsSpecified", "RelyingPartyOriginIsOpaque", "TypeNotMatching",
+"UiDismissedNoEmbargo", "CorsError", "SuppressedBySegmentationPlatform".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-FederatedAuthRequestIssueReason
+*/
+type FederatedAuthRequestIssueReason string
+
+/*
+FederatedAuthUserInfoRequestIssueDetails is the details of a federated auth user
+info request issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-FederatedAuthUserInfoRequestIssueDetails
+*/
+type FederatedAuthUserInfoRequestIssueDetails struct {
+	FederatedAuthUserInfoRequestIssueReason FederatedAuthUserInfoRequestIssueReason `json:"federatedAuthUserInfoRequestIssueReason"`
+}
+
+/*
+FederatedAuthUserInfoRequestIssueReason represents the failure reason when a
+getUserInfo() call fails. Should be updated alongside
+FederatedAuthUserInfoRequestResult in
+third_party/blink/public/mojom/devtools/inspector_issue.mojom. Allowed values:
+"NotSameOrigin", "NotIframe", "NotPotentiallyTrustworthy", "NoApiPermission",
+"NotSignedInWithIdp", "NoAccountSharingPermission", "InvalidConfigOrWellKnown",
+"InvalidAccountsResponse", "NoReturningUserFromFetchedAccounts".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-FederatedAuthUserInfoRequestIssueReason
+*/
+type FederatedAuthUserInfoRequestIssueReason string
+
+/*
+ClientHintIssueDetails is the details of an issue that tracks client hints
+related issues. It's used to deprecate old features, encourage the use of new
+ones, and provide general guidance.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ClientHintIssueDetails
+*/
+type ClientHintIssueDetails struct {
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation"`
+
+	ClientHintIssueReason ClientHintIssueReason `json:"clientHintIssueReason"`
+}
+
+/*
+FailedRequestInfo is a failed request info.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-FailedRequestInfo
+*/
+type FailedRequestInfo struct {
+	// The URL that failed to load.
+	URL string `json:"url"`
+
+	// The failure message for the failed request.
+	FailureMessage string `json:"failureMessage"`
+
+	// Optional.
+	RequestID network.RequestID `json:"requestId,omitempty"`
+}
+
+/*
+PartitioningBlobURLInfo is a partitioning blob URL info. Allowed values:
+"BlockedCrossPartitionFetching", "EnforceNoopenerForNavigation".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-PartitioningBlobURLInfo
+*/
+type PartitioningBlobURLInfo string
+
+/*
+PartitioningBlobURLIssueDetails is the details of a partitioning blob URL issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-PartitioningBlobURLIssueDetails
+*/
+type PartitioningBlobURLIssueDetails struct {
+	// The BlobURL that failed to load.
+	URL string `json:"url"`
+
+	// Additional information about the Partitioning Blob URL issue.
+	PartitioningBlobURLInfo PartitioningBlobURLInfo `json:"partitioningBlobURLInfo"`
+}
+
+/*
+ElementAccessibilityIssueReason is an element accessibility issue reason.
+Allowed values: "DisallowedSelectChild", "DisallowedOptGroupChild",
+"NonPhrasingContentOptionChild", "InteractiveContentOptionChild",
+"InteractiveContentLegendChild", "InteractiveContentSummaryDescendant".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ElementAccessibilityIssueReason
+*/
+type ElementAccessibilityIssueReason string
+
+/*
+ElementAccessibilityIssueDetails is the details of an issue that warns about
+errors in the select or summary element content model.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-ElementAccessibilityIssueDetails
+*/
+type ElementAccessibilityIssueDetails struct {
+	NodeID dom.BackendNodeID `json:"nodeId"`
+
+	ElementAccessibilityIssueReason ElementAccessibilityIssueReason `json:"elementAccessibilityIssueReason"`
+
+	HasDisallowedAttributes bool `json:"hasDisallowedAttributes"`
+}
+
+/*
+StyleSheetLoadingIssueReason is a style sheet loading issue reason. Allowed
+values: "LateImportRule", "RequestFailed".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-StyleSheetLoadingIssueReason
+*/
+type StyleSheetLoadingIssueReason string
+
+/*
+StylesheetLoadingIssueDetails is the details of an issue that warns when a
+referenced stylesheet couldn't be loaded.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-StylesheetLoadingIssueDetails
+*/
+type StylesheetLoadingIssueDetails struct {
+	// Source code position that referenced the failing stylesheet.
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation"`
+
+	// Reason why the stylesheet couldn't be loaded.
+	StyleSheetLoadingIssueReason StyleSheetLoadingIssueReason `json:"styleSheetLoadingIssueReason"`
+
+	// Optional. Contains additional info when the failure was due to a request.
+	FailedRequestInfo *FailedRequestInfo `json:"failedRequestInfo,omitempty"`
+}
+
+/*
+PropertyRuleIssueReason is a property rule issue reason. Allowed values:
+"InvalidSyntax", "InvalidInitialValue", "InvalidInherits", "InvalidName".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-PropertyRuleIssueReason
+*/
+type PropertyRuleIssueReason string
+
+/*
+PropertyRuleIssueDetails is the details of an issue that warns about errors in
+property rules that lead to property registrations being ignored.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-PropertyRuleIssueDetails
+*/
+type PropertyRuleIssueDetails struct {
+	// Source code position of the property rule.
+	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation"`
+
+	// Reason why the property rule was discarded.
+	PropertyRuleIssueReason PropertyRuleIssueReason `json:"propertyRuleIssueReason"`
+
+	// Optional. The value of the property rule property that failed to parse.
+	PropertyValue string `json:"propertyValue,omitempty"`
+}
+
+/*
+UserReidentificationIssueType is an user reidentification issue type. Allowed
+values: "BlockedFrameNavigation", "BlockedSubresource".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-UserReidentificationIssueType
+*/
+type UserReidentificationIssueType string
+
+/*
+UserReidentificationIssueDetails is the details of an issue that warns about
+uses of APIs that may be considered misuse to re-identify users.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-UserReidentificationIssueDetails
+*/
+type UserReidentificationIssueDetails struct {
+	Type UserReidentificationIssueType `json:"type"`
+
+	// Optional. Applies to BlockedFrameNavigation and BlockedSubresource issue
+	// types.
+	Request *AffectedRequest `json:"request,omitempty"`
+}
+
+/*
+InspectorIssueCode is a unique identifier for the type of issue. Each type may
+use one of the optional fields in InspectorIssueDetails to convey more specific
+information about the kind of issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-InspectorIssueCode
+*/
+type InspectorIssueCode string
+
+/*
+The issue codes, which are the kinds of issues reported.
+*/
+const (
+	CookieIssue                       InspectorIssueCode = "CookieIssue"
+	MixedContentIssue                 InspectorIssueCode = "MixedContentIssue"
+	BlockedByResponseIssue            InspectorIssueCode = "BlockedByResponseIssue"
+	HeavyAdIssue                      InspectorIssueCode = "HeavyAdIssue"
+	ContentSecurityPolicyIssue        InspectorIssueCode = "ContentSecurityPolicyIssue"
+	SharedArrayBufferIssue            InspectorIssueCode = "SharedArrayBufferIssue"
+	LowTextContrastIssue              InspectorIssueCode = "LowTextContrastIssue"
+	CorsIssue                         InspectorIssueCode = "CorsIssue"
+	AttributionReportingIssue         InspectorIssueCode = "AttributionReportingIssue"
+	QuirksModeIssue                   InspectorIssueCode = "QuirksModeIssue"
+	PartitioningBlobURLIssue          InspectorIssueCode = "PartitioningBlobURLIssue"
+	NavigatorUserAgentIssue           InspectorIssueCode = "NavigatorUserAgentIssue"
+	GenericIssue                      InspectorIssueCode = "GenericIssue"
+	DeprecationIssue                  InspectorIssueCode = "DeprecationIssue"
+	ClientHintIssue                   InspectorIssueCode = "ClientHintIssue"
+	FederatedAuthRequestIssue         InspectorIssueCode = "FederatedAuthRequestIssue"
+	BounceTrackingIssue               InspectorIssueCode = "BounceTrackingIssue"
+	CookieDeprecationMetadataIssue    InspectorIssueCode = "CookieDeprecationMetadataIssue"
+	StylesheetLoadingIssue            InspectorIssueCode = "StylesheetLoadingIssue"
+	FederatedAuthUserInfoRequestIssue InspectorIssueCode = "FederatedAuthUserInfoRequestIssue"
+	PropertyRuleIssue                 InspectorIssueCode = "PropertyRuleIssue"
+	SharedDictionaryIssue             InspectorIssueCode = "SharedDictionaryIssue"
+	ElementAccessibilityIssue         InspectorIssueCode = "ElementAccessibilityIssue"
+	SRIMessageSignatureIssue          InspectorIssueCode = "SRIMessageSignatureIssue"
+	UnencodedDigestIssue              InspectorIssueCode = "UnencodedDigestIssue"
+	UserReidentificationIssue         InspectorIssueCode = "UserReidentificationIssue"
+)
+
+/*
+InspectorIssueDetails holds the details of an issue. Only the field matching the
+issue code is set.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-InspectorIssueDetails
+*/
+type InspectorIssueDetails struct {
+	// Optional. Set for CookieIssue.
+	CookieIssueDetails *CookieIssueDetails `json:"cookieIssueDetails,omitempty"`
+
+	// Optional. Set for MixedContentIssue.
+	MixedContentIssueDetails *MixedContentIssueDetails `json:"mixedContentIssueDetails,omitempty"`
+
+	// Optional. Set for BlockedByResponseIssue.
+	BlockedByResponseIssueDetails *BlockedByResponseIssueDetails `json:"blockedByResponseIssueDetails,omitempty"`
+
+	// Optional. Set for HeavyAdIssue.
+	HeavyAdIssueDetails *HeavyAdIssueDetails `json:"heavyAdIssueDetails,omitempty"`
+
+	// Optional. Set for ContentSecurityPolicyIssue.
+	ContentSecurityPolicyIssueDetails *ContentSecurityPolicyIssueDetails `json:"contentSecurityPolicyIssueDetails,omitempty"`
+
+	// Optional. Set for SharedArrayBufferIssue.
+	SharedArrayBufferIssueDetails *SharedArrayBufferIssueDetails `json:"sharedArrayBufferIssueDetails,omitempty"`
+
+	// Optional. Set for LowTextContrastIssue.
+	LowTextContrastIssueDetails *LowTextContrastIssueDetails `json:"lowTextContrastIssueDetails,omitempty"`
+
+	// Optional. Set for CorsIssue.
+	CorsIssueDetails *CorsIssueDetails `json:"corsIssueDetails,omitempty"`
+
+	// Optional. Set for AttributionReportingIssue.
+	AttributionReportingIssueDetails *AttributionReportingIssueDetails `json:"attributionReportingIssueDetails,omitempty"`
+
+	// Optional. Set for QuirksModeIssue.
+	QuirksModeIssueDetails *QuirksModeIssueDetails `json:"quirksModeIssueDetails,omitempty"`
+
+	// Optional. Set for PartitioningBlobURLIssue.
+	PartitioningBlobURLIssueDetails *PartitioningBlobURLIssueDetails `json:"partitioningBlobURLIssueDetails,omitempty"`
+
+	// Optional. Set for NavigatorUserAgentIssue. DEPRECATED.
+	NavigatorUserAgentIssueDetails *NavigatorUserAgentIssueDetails `json:"navigatorUserAgentIssueDetails,omitempty"`
+
+	// Optional. Set for GenericIssue.
+	GenericIssueDetails *GenericIssueDetails `json:"genericIssueDetails,omitempty"`
+
+	// Optional. Set for DeprecationIssue.
+	DeprecationIssueDetails *DeprecationIssueDetails `json:"deprecationIssueDetails,omitempty"`
+
+	// Optional. Set for ClientHintIssue.
+	ClientHintIssueDetails *ClientHintIssueDetails `json:"clientHintIssueDetails,omitempty"`
+
+	// Optional. Set for FederatedAuthRequestIssue.
+	FederatedAuthRequestIssueDetails *FederatedAuthRequestIssueDetails `json:"federatedAuthRequestIssueDetails,omitempty"`
+
+	// Optional. Set for BounceTrackingIssue.
+	BounceTrackingIssueDetails *BounceTrackingIssueDetails `json:"bounceTrackingIssueDetails,omitempty"`
+
+	// Optional. Set for CookieDeprecationMetadataIssue.
+	CookieDeprecationMetadataIssueDetails *CookieDeprecationMetadataIssueDetails `json:"cookieDeprecationMetadataIssueDetails,omitempty"`
+
+	// Optional. Set for StylesheetLoadingIssue.
+	StylesheetLoadingIssueDetails *StylesheetLoadingIssueDetails `json:"stylesheetLoadingIssueDetails,omitempty"`
+
+	// Optional. Set for PropertyRuleIssue.
+	PropertyRuleIssueDetails *PropertyRuleIssueDetails `json:"propertyRuleIssueDetails,omitempty"`
+
+	// Optional. Set for FederatedAuthUserInfoRequestIssue.
+	FederatedAuthUserInfoRequestIssueDetails *FederatedAuthUserInfoRequestIssueDetails `json:"federatedAuthUserInfoRequestIssueDetails,omitempty"`
+
+	// Optional. Set for SharedDictionaryIssue.
+	SharedDictionaryIssueDetails *SharedDictionaryIssueDetails `json:"sharedDictionaryIssueDetails,omitempty"`
+
+	// Optional. Set for ElementAccessibilityIssue.
+	ElementAccessibilityIssueDetails *ElementAccessibilityIssueDetails `json:"elementAccessibilityIssueDetails,omitempty"`
+
+	// Optional. Set for SRIMessageSignatureIssue.
+	SriMessageSignatureIssueDetails *SRIMessageSignatureIssueDetails `json:"sriMessageSignatureIssueDetails,omitempty"`
+
+	// Optional. Set for UnencodedDigestIssue.
+	UnencodedDigestIssueDetails *UnencodedDigestIssueDetails `json:"unencodedDigestIssueDetails,omitempty"`
+
+	// Optional. Set for UserReidentificationIssue.
+	UserReidentificationIssueDetails *UserReidentificationIssueDetails `json:"userReidentificationIssueDetails,omitempty"`
+}
+
+/*
+IssueID is a unique id for a DevTools inspector issue. Allows other entities
+(e.g. exceptions, CDP message, console messages, etc.) to reference an issue.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-IssueId
+*/
+type IssueID string
+
+/*
+InspectorIssue is an inspector issue reported from the back-end.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#type-InspectorIssue
+*/
+type InspectorIssue struct {
+	Code InspectorIssueCode `json:"code"`
+
+	Details *InspectorIssueDetails `json:"details"`
+
+	// Optional. A unique id for this issue. May be omitted if no other entity
+	// (e.g. exception, CDP message, etc.) is referencing this issue.
+	IssueID IssueID `json:"issueId,omitempty"`
+}
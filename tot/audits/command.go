@@ -4,6 +4,60 @@ import (
 	"github.com/mkenney/go-chrome/tot/network"
 )
 
+/*
+CheckContrastParams represents Audits.checkContrast parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkContrast
+*/
+type CheckContrastParams struct {
+	// Optional. Whether to report WCAG AAA level issues. Default is false.
+	ReportAAA bool `json:"reportAAA,omitempty"`
+}
+
+/*
+CheckContrastResult represents the result of calls to Audits.checkContrast.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkContrast
+*/
+type CheckContrastResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+CheckFormsIssuesResult represents the result of calls to
+Audits.checkFormsIssues.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkFormsIssues
+*/
+type CheckFormsIssuesResult struct {
+	// The issues found in the forms of the page.
+	FormIssues []*GenericIssueDetails `json:"formIssues"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DisableResult represents the result of calls to Audits.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableResult represents the result of calls to Audits.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 GetEncodedResponseParams represents Audits.getEncodedResponse parameters
 
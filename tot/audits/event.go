@@ -0,0 +1,14 @@
+package audits
+
+/*
+IssueAddedEvent represents Audits.issueAdded event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#event-issueAdded
+*/
+type IssueAddedEvent struct {
+	// The issue.
+	Issue *InspectorIssue `json:"issue"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
@@ -12,6 +12,17 @@ https://chromedevtools.github.io/devtools-protocol/tot/Browser/#type-WindowID
 */
 type WindowID int
 
+/*
+PermissionType is the type of a browser permission. Allowed values:
+accessibilityEvents, audioCapture, backgroundSync, clipboardRead,
+clipboardWrite, durableStorage, flash, geolocation, midi, midiSysex,
+notifications, paymentHandler, protectedMediaIdentifier, sensors,
+videoCapture. EXPERIMENTAL
+
+https://chromedevtools.github.io/devtools-protocol/tot/Browser/#type-PermissionType
+*/
+type PermissionType string
+
 /*
 WindowState holds the state of the browser window. EXPERIMENTAL
 
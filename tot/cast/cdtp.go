@@ -0,0 +1,23 @@
+/*
+Package cast provides type definitions for use with the Chrome Cast protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/
+*/
+package cast
+
+/*
+Sink is a Cast or Presentation API receiver.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#type-Sink
+*/
+type Sink struct {
+	// The name of the sink, used to select it.
+	Name string `json:"name"`
+
+	// The sink ID.
+	ID string `json:"id"`
+
+	// Optional. Text describing the current session. Present only if there is
+	// an active session on the sink.
+	Session string `json:"session,omitempty"`
+}
@@ -0,0 +1,115 @@
+package cast
+
+/*
+DisableResult represents the result of calls to Cast.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableParams represents Cast.enable parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-enable
+*/
+type EnableParams struct {
+	// Optional. Only sinks compatible with the presentation URL are
+	// reported, in addition to sinks compatible with tab and desktop
+	// mirroring.
+	PresentationURL string `json:"presentationUrl,omitempty"`
+}
+
+/*
+EnableResult represents the result of calls to Cast.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetSinkToUseParams represents Cast.setSinkToUse parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-setSinkToUse
+*/
+type SetSinkToUseParams struct {
+	// The name of the sink to use.
+	SinkName string `json:"sinkName"`
+}
+
+/*
+SetSinkToUseResult represents the result of calls to Cast.setSinkToUse.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-setSinkToUse
+*/
+type SetSinkToUseResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+StartDesktopMirroringParams represents Cast.startDesktopMirroring parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startDesktopMirroring
+*/
+type StartDesktopMirroringParams struct {
+	// The name of the sink to mirror the desktop to.
+	SinkName string `json:"sinkName"`
+}
+
+/*
+StartDesktopMirroringResult represents the result of calls to
+Cast.startDesktopMirroring.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startDesktopMirroring
+*/
+type StartDesktopMirroringResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+StartTabMirroringParams represents Cast.startTabMirroring parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startTabMirroring
+*/
+type StartTabMirroringParams struct {
+	// The name of the sink to mirror the tab to.
+	SinkName string `json:"sinkName"`
+}
+
+/*
+StartTabMirroringResult represents the result of calls to
+Cast.startTabMirroring.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startTabMirroring
+*/
+type StartTabMirroringResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+StopCastingParams represents Cast.stopCasting parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-stopCasting
+*/
+type StopCastingParams struct {
+	// The name of the sink to stop casting to.
+	SinkName string `json:"sinkName"`
+}
+
+/*
+StopCastingResult represents the result of calls to Cast.stopCasting.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-stopCasting
+*/
+type StopCastingResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
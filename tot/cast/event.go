@@ -0,0 +1,28 @@
+package cast
+
+/*
+IssueUpdatedEvent represents Cast.issueUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#event-issueUpdated
+*/
+type IssueUpdatedEvent struct {
+	// A message describing the issue, or an empty string if there's no
+	// outstanding issue.
+	IssueMessage string `json:"issueMessage"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+SinksUpdatedEvent represents Cast.sinksUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#event-sinksUpdated
+*/
+type SinksUpdatedEvent struct {
+	// The available sinks.
+	Sinks []*Sink `json:"sinks"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
@@ -0,0 +1,423 @@
+package chrome
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Broker actions.
+*/
+const (
+	BrokerCloseTab = "closeTab"
+	BrokerCommand  = "command"
+	BrokerEval     = "eval"
+	BrokerNewTab   = "newTab"
+	BrokerVersion  = "version"
+)
+
+/*
+BrokerRequest is a request sent to a broker. Requests and responses are
+newline delimited JSON.
+*/
+type BrokerRequest struct {
+	ID         int             `json:"id"`
+	Action     string          `json:"action"`
+	TabID      string          `json:"tabId,omitempty"`
+	URL        string          `json:"url,omitempty"`
+	Expression string          `json:"expression,omitempty"`
+	Method     string          `json:"method,omitempty"`
+	Params     json.RawMessage `json:"params,omitempty"`
+}
+
+/*
+BrokerResponse is the response to a BrokerRequest.
+*/
+type BrokerResponse struct {
+	ID     int             `json:"id"`
+	TabID  string          `json:"tabId,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+/*
+Broker owns the connection to a browser and serves high-level requests from
+other processes over a unix domain socket, so short-lived CLI invocations can
+reuse a warm browser instead of launching their own:
+
+	browser := chrome.New()
+	if err := browser.Launch(); nil != err {
+		...
+	}
+	broker := chrome.NewBroker(browser)
+	go broker.Listen("/tmp/go-chrome.sock")
+	defer broker.Close()
+
+Clients connect with DialBroker. Tabs opened through the broker stay open
+between client connections until they're closed.
+*/
+type Broker struct {
+	chrome   *Chrome
+	closed   bool
+	conns    map[net.Conn]struct{}
+	listener net.Listener
+	mux      *sync.Mutex
+	tabMux   *sync.Mutex
+	tabs     map[string]*Tab
+}
+
+/*
+NewBroker returns a broker for a browser.
+*/
+func NewBroker(chrome *Chrome) *Broker {
+	return &Broker{
+		chrome: chrome,
+		conns:  make(map[net.Conn]struct{}),
+		mux:    &sync.Mutex{},
+		tabMux: &sync.Mutex{},
+		tabs:   make(map[string]*Tab),
+	}
+}
+
+/*
+Listen serves requests on a unix domain socket until the broker is closed. A
+stale socket file left by a broker that exited is removed, a socket another
+broker is listening on is an error. Listen returns nil once the broker is
+closed, and an error if the socket fails before.
+*/
+func (broker *Broker) Listen(path string) error {
+	if conn, err := net.Dial("unix", path); nil == err {
+		conn.Close()
+		return errs.New(codes.ChromeBrokerFailed, fmt.Sprintf("a broker is already listening on '%s'", path))
+	}
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if nil != err {
+		return errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not listen on '%s'", path))
+	}
+	broker.mux.Lock()
+	if broker.closed {
+		broker.mux.Unlock()
+		listener.Close()
+		return nil
+	}
+	broker.listener = listener
+	broker.mux.Unlock()
+	return broker.accept(listener)
+}
+
+/*
+accept serves the connections of a listener. Temporary errors, such as running
+out of file descriptors, are retried with a backoff of up to a second.
+*/
+func (broker *Broker) accept(listener net.Listener) error {
+	var delay time.Duration
+	for {
+		conn, err := listener.Accept()
+		if nil != err {
+			broker.mux.Lock()
+			closed := broker.closed
+			broker.mux.Unlock()
+			if closed {
+				return nil
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				if 0 == delay {
+					delay = 5 * time.Millisecond
+				} else if delay *= 2; delay > time.Second {
+					delay = time.Second
+				}
+				broker.chrome.logger.Warn("broker accept failed, retrying", logger.Fields{"delay": delay.String(), "error": err.Error()})
+				time.Sleep(delay)
+				continue
+			}
+			return errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not accept a connection on '%s'", listener.Addr()))
+		}
+		delay = 0
+		broker.mux.Lock()
+		broker.conns[conn] = struct{}{}
+		broker.mux.Unlock()
+		go broker.serve(conn)
+	}
+}
+
+/*
+Close stops listening and disconnects the clients. The tabs opened through the
+broker and the browser are left open.
+*/
+func (broker *Broker) Close() error {
+	broker.mux.Lock()
+	defer broker.mux.Unlock()
+	broker.closed = true
+	var err error
+	if nil != broker.listener {
+		err = broker.listener.Close()
+	}
+	for conn := range broker.conns {
+		conn.Close()
+	}
+	return err
+}
+
+/*
+serve handles the requests of a client connection in order.
+*/
+func (broker *Broker) serve(conn net.Conn) {
+	defer func() {
+		broker.mux.Lock()
+		delete(broker.conns, conn)
+		broker.mux.Unlock()
+		conn.Close()
+	}()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		request := &BrokerRequest{}
+		response := &BrokerResponse{}
+		if err := json.Unmarshal(scanner.Bytes(), request); nil != err {
+			response.Error = fmt.Sprintf("invalid request: %s", err.Error())
+		} else {
+			response = broker.handle(request)
+		}
+		if err := encoder.Encode(response); nil != err {
+			broker.chrome.logger.Warn("could not write a broker response", logger.Fields{"error": err.Error()})
+			return
+		}
+	}
+}
+
+/*
+handle executes a request.
+*/
+func (broker *Broker) handle(request *BrokerRequest) *BrokerResponse {
+	response := &BrokerResponse{ID: request.ID, TabID: request.TabID}
+	var result interface{}
+	var err error
+
+	switch request.Action {
+	case BrokerVersion:
+		result, err = broker.chrome.Version()
+
+	case BrokerNewTab:
+		// The browser's list of tabs isn't safe for concurrent use.
+		broker.tabMux.Lock()
+		defer broker.tabMux.Unlock()
+		var tab *Tab
+		if tab, err = broker.chrome.NewTab(request.URL); nil == err {
+			response.TabID = tab.Data().ID
+			broker.mux.Lock()
+			broker.tabs[response.TabID] = tab
+			broker.mux.Unlock()
+		}
+
+	case BrokerCloseTab:
+		broker.tabMux.Lock()
+		defer broker.tabMux.Unlock()
+		var tab *Tab
+		if tab, err = broker.tab(request.TabID); nil == err {
+			broker.mux.Lock()
+			delete(broker.tabs, request.TabID)
+			broker.mux.Unlock()
+			_, err = tab.Close()
+		}
+
+	case BrokerEval:
+		var tab *Tab
+		if tab, err = broker.tab(request.TabID); nil == err {
+			value := json.RawMessage{}
+			if err = tab.Eval(request.Expression, &value); nil == err && len(value) > 0 {
+				result = value
+			}
+		}
+
+	case BrokerCommand:
+		var tab *Tab
+		if tab, err = broker.tab(request.TabID); nil == err {
+			var params interface{}
+			if len(request.Params) > 0 {
+				params = request.Params
+			}
+			command := socket.NewCommand(tab.Socket(), request.Method, params)
+			if r := <-tab.Socket().SendCommand(command); nil != r.Error {
+				err = r.Error
+			} else {
+				result = r.Result
+			}
+		}
+
+	default:
+		err = fmt.Errorf("unknown action '%s'", request.Action)
+	}
+
+	if nil != err {
+		response.Error = err.Error()
+		return response
+	}
+	if nil != result {
+		if response.Result, err = json.Marshal(result); nil != err {
+			response.Error = err.Error()
+		}
+	}
+	return response
+}
+
+/*
+tab returns an open tab by ID.
+*/
+func (broker *Broker) tab(tabID string) (*Tab, error) {
+	broker.mux.Lock()
+	tab, ok := broker.tabs[tabID]
+	broker.mux.Unlock()
+	if ok {
+		return tab, nil
+	}
+	for _, tab := range broker.chrome.Tabs() {
+		if tabID == tab.Data().ID {
+			return tab, nil
+		}
+	}
+	return nil, fmt.Errorf("tab '%s' not found", tabID)
+}
+
+/*
+BrokerClient sends requests to a broker. Requests are sent one at a time.
+*/
+type BrokerClient struct {
+	conn    net.Conn
+	encoder *json.Encoder
+	id      int
+	mux     *sync.Mutex
+	scanner *bufio.Scanner
+}
+
+/*
+DialBroker connects to a broker listening on a unix domain socket.
+*/
+func DialBroker(path string) (*BrokerClient, error) {
+	conn, err := net.Dial("unix", path)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not connect to the broker on '%s'", path))
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	return &BrokerClient{
+		conn:    conn,
+		encoder: json.NewEncoder(conn),
+		mux:     &sync.Mutex{},
+		scanner: scanner,
+	}, nil
+}
+
+/*
+Do sends a request to the broker and returns its response. A failed request is
+returned as an error.
+*/
+func (client *BrokerClient) Do(request *BrokerRequest) (*BrokerResponse, error) {
+	client.mux.Lock()
+	defer client.mux.Unlock()
+	client.id++
+	request.ID = client.id
+	if err := client.encoder.Encode(request); nil != err {
+		return nil, errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not send the '%s' request", request.Action))
+	}
+	if !client.scanner.Scan() {
+		err := client.scanner.Err()
+		if nil == err {
+			err = fmt.Errorf("connection closed")
+		}
+		return nil, errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not read the '%s' response", request.Action))
+	}
+	response := &BrokerResponse{}
+	if err := json.Unmarshal(client.scanner.Bytes(), response); nil != err {
+		return nil, errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("invalid '%s' response", request.Action))
+	}
+	if "" != response.Error {
+		return response, errs.New(codes.ChromeBrokerFailed, fmt.Sprintf("'%s' request failed: %s", request.Action, response.Error))
+	}
+	return response, nil
+}
+
+/*
+Version returns the version of the broker's browser.
+*/
+func (client *BrokerClient) Version() (*Version, error) {
+	version := &Version{}
+	return version, client.do(&BrokerRequest{Action: BrokerVersion}, version)
+}
+
+/*
+NewTab opens a tab and returns its ID.
+*/
+func (client *BrokerClient) NewTab(uri string) (string, error) {
+	response, err := client.Do(&BrokerRequest{Action: BrokerNewTab, URL: uri})
+	if nil != err {
+		return "", err
+	}
+	return response.TabID, nil
+}
+
+/*
+CloseTab closes a tab.
+*/
+func (client *BrokerClient) CloseTab(tabID string) error {
+	_, err := client.Do(&BrokerRequest{Action: BrokerCloseTab, TabID: tabID})
+	return err
+}
+
+/*
+Eval evaluates a JavaScript expression in a tab and decodes the result into v,
+see Tab.Eval.
+*/
+func (client *BrokerClient) Eval(tabID, expression string, v interface{}) error {
+	return client.do(&BrokerRequest{Action: BrokerEval, TabID: tabID, Expression: expression}, v)
+}
+
+/*
+Command sends a protocol command to a tab and decodes the result into v.
+*/
+func (client *BrokerClient) Command(tabID, method string, params, v interface{}) error {
+	request := &BrokerRequest{Action: BrokerCommand, TabID: tabID, Method: method}
+	if nil != params {
+		raw, err := json.Marshal(params)
+		if nil != err {
+			return errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("invalid '%s' params", method))
+		}
+		request.Params = raw
+	}
+	return client.do(request, v)
+}
+
+/*
+Close disconnects from the broker.
+*/
+func (client *BrokerClient) Close() error {
+	return client.conn.Close()
+}
+
+/*
+do sends a request and decodes the result into v.
+*/
+func (client *BrokerClient) do(request *BrokerRequest, v interface{}) error {
+	response, err := client.Do(request)
+	if nil != err {
+		return err
+	}
+	if nil == v || 0 == len(response.Result) {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, v); nil != err {
+		return errs.Wrap(err, codes.ChromeBrokerFailed, fmt.Sprintf("could not decode the '%s' result", request.Action))
+	}
+	return nil
+}
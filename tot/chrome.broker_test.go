@@ -0,0 +1,123 @@
+package chrome
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBroker(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	cdp.Respond("Runtime.evaluate", map[string]interface{}{
+		"result": map[string]interface{}{"type": "string", "value": "Example Domain"},
+	})
+	cdp.Respond("Page.navigate", map[string]interface{}{"frameId": "frame-1"})
+
+	dir, err := ioutil.TempDir("", "go-chrome-broker")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "broker.sock")
+	broker := NewBroker(browser)
+	go broker.Listen(path)
+	defer broker.Close()
+
+	var client *BrokerClient
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if client, err = DialBroker(path); nil == err {
+			break
+		}
+	}
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer client.Close()
+
+	tabID, err := client.NewTab("https://www.example.com")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "1" != tabID {
+		t.Errorf("Expected tab '1', got '%s'", tabID)
+	}
+	var title string
+	if err := client.Eval(tabID, "document.title", &title); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if "Example Domain" != title {
+		t.Errorf("Expected the title to be evaluated, got '%s'", title)
+	}
+	result := map[string]string{}
+	if err := client.Command(tabID, "Page.navigate", map[string]string{"url": "https://www.example.org"}, &result); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if "frame-1" != result["frameId"] {
+		t.Errorf("Expected the command result, got %v", result)
+	}
+	if err := client.Eval("unknown", "document.title", nil); nil == err {
+		t.Errorf("Expected an error for an unknown tab")
+	}
+
+	// A second client reuses the tabs of the first.
+	second, err := DialBroker(path)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer second.Close()
+	if err := second.Eval(tabID, "document.title", &title); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if err := broker.Listen(path); nil == err {
+		t.Errorf("Expected an error listening on a socket in use")
+	}
+}
+
+/*
+failingListener is a listener whose Accept returns queued errors.
+*/
+type failingListener struct {
+	net.Listener
+	errs []error
+}
+
+func (listener *failingListener) Accept() (net.Conn, error) {
+	err := listener.errs[0]
+	listener.errs = listener.errs[1:]
+	return nil, err
+}
+
+func (listener *failingListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: "broker.sock", Net: "unix"}
+}
+
+/*
+temporaryError is a temporary network error.
+*/
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary" }
+func (temporaryError) Temporary() bool { return true }
+func (temporaryError) Timeout() bool   { return false }
+
+func TestBrokerAccept(t *testing.T) {
+	broker := NewBroker(New())
+	listener := &failingListener{errs: []error{temporaryError{}, temporaryError{}, errors.New("failed")}}
+	if err := broker.accept(listener); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if 0 != len(listener.errs) {
+		t.Errorf("Expected the temporary errors to be retried, %d errors left", len(listener.errs))
+	}
+
+	broker.Close()
+	listener = &failingListener{errs: []error{errors.New("use of closed network connection")}}
+	if err := broker.accept(listener); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+}
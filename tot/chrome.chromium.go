@@ -1,6 +1,7 @@
 package chrome
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,30 +9,42 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/sourcemap"
+	"golang.org/x/crypto/ssh"
 )
 
 /*
-New returns a pointer to a Chromium instance.
+New returns a pointer to a Chromium instance configured with the specified
+options:
+
+	browser := chrome.New(
+		chrome.WithAddress("localhost"),
+		chrome.WithPort(9222),
+		chrome.WithTimeout(30*time.Second),
+	)
+
+Defaults are read from the CHROME_PATH and CHROME_WS_URL environment variables
+when they are set. Options take precedence over environment defaults.
 */
-func New(
-	flags ChromiumFlags,
-	binary string,
-	workdir string,
-	stdout string,
-	stderr string,
-) *Chrome {
-	return &Chrome{
-		flags:   flags,
-		binary:  binary,
-		stderr:  stderr,
-		stdout:  stdout,
-		workdir: workdir,
+func New(options ...Option) *Chrome {
+	chrome := &Chrome{
+		flags:   &Flags{},
+		logger:  logger.Default(),
+		timeout: 10 * time.Second,
 	}
+	for _, option := range options {
+		option(chrome)
+	}
+	applyEnv(chrome)
+	return chrome
 }
 
 /*
@@ -45,13 +58,24 @@ type Chrome struct {
 	// '/usr/bin/google-chrome'.
 	binary string
 
+	// Optional. channels are the release channels the binary is discovered
+	// for when it isn't set, in order of preference.
+	channels []Channel
+
 	// Optional. port is the port number the developer tools endpoints will
 	// listen on. Defaults to 9222.
 	//port int
 
+	// logger is used for all log output.
+	logger Logger
+
 	// tabs is a list of the currently open tabs.
 	tabs []*Tab
 
+	// timeout is the maximum time to wait for Chromium to start and for
+	// developer tools queries to complete.
+	timeout time.Duration
+
 	// version contains Chromium version information.
 	version *Version
 
@@ -77,6 +101,114 @@ type Chrome struct {
 
 	// process is a pointer to the os.Process struct containing the process PID.
 	process *os.Process
+
+	// Optional. proxy is the proxy assigned by WithProxyRotation.
+	proxy *Proxy
+
+	// proxyErr is the error WithProxyRotation failed with, returned by Launch.
+	proxyErr error
+
+	// Optional. userDataTemplate is the user data directory copied for the
+	// browser by Launch.
+	userDataTemplate string
+
+	// userDataDir is the copy of the user data template, or the temporary
+	// user data directory created for preferences, removed by Close.
+	userDataDir string
+
+	// Optional. preferences are written into the user data directory by
+	// Launch.
+	preferences *Preferences
+
+	// Optional. policies are written into the policy directory by Launch.
+	policies *Policies
+
+	// policyDir is the directory the policies are written into.
+	policyDir string
+
+	// policyFile is the policy file written by Launch, removed by Close.
+	policyFile string
+
+	// Optional. crashHandler is called when the browser crashes.
+	crashHandler func(report *CrashReport)
+
+	// Optional. sourceMaps maps evaluation error stack frames to the
+	// original sources.
+	sourceMaps *sourcemap.Resolver
+
+	// Optional. nonBlocking makes sending commands from the tabs' event
+	// handlers safe.
+	nonBlocking bool
+
+	// Optional. orderedEvents delivers the events of each domain in the
+	// order they were received.
+	orderedEvents bool
+
+	// launched is the time the browser process was started.
+	launched time.Time
+
+	// closing is set atomically by Close, so the process exiting isn't
+	// reported as a crash.
+	closing int32
+
+	// exited is closed when the browser process exits.
+	exited chan struct{}
+
+	// exitState and exitErr are the result of waiting for the browser
+	// process, set before exited is closed.
+	exitState *os.ProcessState
+	exitErr   error
+
+	// Optional. budget limits the resources the browser may use.
+	budget *ResourceBudget
+
+	// superviseStop stops sampling the resource usage of the browser. It's
+	// guarded by lifecycle.
+	superviseStop chan struct{}
+
+	// lifecycle serializes launching and closing the browser, including the
+	// restarts done when it exceeds its resource budget.
+	lifecycle sync.Mutex
+
+	// Optional. unixSocket is the path of a unix domain socket the developer
+	// tools endpoints are served on.
+	unixSocket string
+
+	// Optional. sshClient is an SSH connection the developer tools
+	// endpoints are reached through.
+	sshClient *ssh.Client
+
+	// Optional. tlsConfig is used to connect to developer tools endpoints
+	// served over TLS.
+	tlsConfig *tls.Config
+
+	// Optional. janitor cleans up stale user data each time the browser is
+	// launched.
+	janitor *Janitor
+
+	// Optional. headlessMode is the mode the browser is launched in.
+	headlessMode HeadlessMode
+
+	// Optional. displayProvider starts a display for a headful browser.
+	displayProvider DisplayProvider
+
+	// displayStop stops the display started by Launch, called by Close.
+	displayStop func() error
+
+	// Optional. graphicsFallback probes WebGL after launch and relaunches
+	// with SwiftShader if it doesn't work, graphicsReport receives the
+	// result.
+	graphicsFallback bool
+	graphicsReport   func(report *GraphicsReport)
+
+	// graphicsRelaunched is set once the browser has been relaunched with
+	// SwiftShader.
+	graphicsRelaunched bool
+
+	// Optional. reattach attaches tabs again when the browser detaches them,
+	// detachHook is called with each detachment.
+	reattach   bool
+	detachHook func(detachment *socket.Detachment)
 }
 
 /*
@@ -103,11 +235,12 @@ func (chrome *Chrome) Flags() ChromiumFlags {
 Binary implements Chromium.
 
 Default value is '/usr/bin/google-chrome' for use with the mkenney/chromium-headless
-Docker image.
+Docker image, or the browser discovered for the channels set by WithChannel.
 */
 func (chrome *Chrome) Binary() string {
 	if "" == chrome.binary {
 		chrome.binary = "/usr/bin/google-chrome"
+		chrome.discoverBinary()
 	}
 	return chrome.binary
 }
@@ -116,20 +249,47 @@ func (chrome *Chrome) Binary() string {
 Close implements Chromium.
 */
 func (chrome *Chrome) Close() error {
+	chrome.lifecycle.Lock()
+	defer chrome.lifecycle.Unlock()
+	return chrome.close()
+}
+
+/*
+close closes the browser. The lifecycle mutex must be locked.
+*/
+func (chrome *Chrome) close() (err error) {
+	chrome.stopSupervising()
+	defer func() {
+		if removeErr := chrome.removeUserData(); nil == err {
+			err = removeErr
+		}
+		if removeErr := chrome.removePolicies(); nil == err {
+			err = removeErr
+		}
+		if stopErr := chrome.stopDisplay(); nil == err {
+			err = stopErr
+		}
+	}()
+
 	if chrome.process != nil {
 		for _, tab := range chrome.Tabs() {
 			tab.Close()
 		}
-		if err := chrome.process.Signal(os.Interrupt); err != nil {
-			return errs.Wrap(err, codes.ChromeSigintFailed, "chrome process interrupt failed")
+		atomic.StoreInt32(&chrome.closing, 1)
+		select {
+		case <-chrome.exited:
+		default:
+			if err := chrome.process.Signal(os.Interrupt); err != nil {
+				return errs.Wrap(err, codes.ChromeSigintFailed, "chrome process interrupt failed")
+			}
 		}
-		ps, err := chrome.process.Wait()
-		if err != nil {
-			return errs.Wrap(err, codes.ChromeExitTimeout, "error waiting for process exit, result unknown")
+		<-chrome.exited
+		if chrome.exitErr != nil {
+			return errs.Wrap(chrome.exitErr, codes.ChromeExitTimeout, "error waiting for process exit, result unknown")
 		}
-		log.WithFields(log.Fields{
-			"signal": ps.String(),
-		}).Info("Chromium exited")
+		chrome.logger.Info("Chromium exited", logger.Fields{
+			"signal": chrome.exitState.String(),
+		})
 	}
 	if chrome.stdOUTFile != nil {
 		chrome.stdOUTFile.Close()
@@ -191,7 +351,36 @@ they aren't included in the Flags definition:
 	chrome.output = "/dev/stdout"
 */
 func (chrome *Chrome) Launch() error {
-	var err error
+	chrome.lifecycle.Lock()
+	defer chrome.lifecycle.Unlock()
+	return chrome.launch()
+}
+
+/*
+launch launches the browser. The lifecycle mutex must be locked.
+*/
+func (chrome *Chrome) launch() (err error) {
+	if nil != chrome.proxyErr {
+		return chrome.proxyErr
+	}
+	chrome.clean()
+
+	if err = chrome.cloneUserDataTemplate(); nil != err {
+		return err
+	}
+	defer func() {
+		if nil != err {
+			chrome.removeUserData()
+			chrome.removePolicies()
+			chrome.stopDisplay()
+		}
+	}()
+	if err = chrome.writePreferences(); nil != err {
+		return err
+	}
+	if err = chrome.applyHeadlessMode(); nil != err {
+		return err
+	}
 
 	// Default values for required parameters
 	chrome.Address()
@@ -205,6 +394,9 @@ func (chrome *Chrome) Launch() error {
 	if err = os.MkdirAll(chrome.Workdir(), 0700); err != nil {
 		return errs.Wrap(err, codes.ChromeInvalidWorkdir, fmt.Sprintf("cannot create working directory '%s'", chrome.Workdir()))
 	}
+	if err = chrome.enableCrashReporting(); err != nil {
+		return errs.Wrap(err, codes.ChromeInvalidWorkdir, fmt.Sprintf("cannot create crash dump directory in '%s'", chrome.Workdir()))
+	}
 
 	if "" == chrome.STDERR() {
 		chrome.stdERRFile = os.Stderr
@@ -232,10 +424,12 @@ func (chrome *Chrome) Launch() error {
 		}
 	}
 
-	log.WithFields(log.Fields{
+	chrome.logger.Info("Starting process", logger.Fields{
 		"flags": chrome.Flags(),
 		"path":  chrome.Binary(),
-	}).Info("Starting process")
+	})
+	chrome.launched = time.Now()
+	atomic.StoreInt32(&chrome.closing, 0)
 	var procAttributes os.ProcAttr
 	procAttributes.Dir = chrome.Workdir()
 	procAttributes.Files = []*os.File{nil, chrome.stdOUTFile, chrome.stdERRFile}
@@ -248,21 +442,24 @@ func (chrome *Chrome) Launch() error {
 		chrome.stdOUTFile.Close()
 		return errs.Wrap(err, codes.ChromeCannotOpenStdout, "error starting chrome")
 	}
+	chrome.exited = make(chan struct{})
+	go chrome.wait(chrome.process, chrome.exited)
 
-	// Wait up to 10 seconds for Chromium to start
-	for i := 0; i < 10; i++ {
+	// Wait for Chromium to start
+	for start := time.Now(); time.Since(start) < chrome.timeout; {
 		time.Sleep(time.Second)
 		if _, err = chrome.Version(); nil == err {
 			break
 		}
 	}
 	if err != nil {
-		log.Error("Chromium took too long to start")
-		chrome.Close()
+		chrome.logger.Error("Chromium took too long to start", logger.Fields{"timeout": chrome.timeout.String()})
+		chrome.close()
 		return errs.Wrap(err, codes.ChromeStartTimeout, "chromium took too long to start")
 	}
 
-	return nil
+	chrome.supervise()
+	return chrome.probeGraphics()
 }
 
 /*
@@ -290,30 +487,61 @@ func (chrome *Chrome) Query(
 		path += fmt.Sprintf("?%s", params.Encode())
 	}
 
-	uri := fmt.Sprintf("http://%s:%d%s", chrome.Address(), chrome.Port(), path)
-	resp, err := http.Get(uri)
+	content, _, err := chrome.request(http.MethodGet, path)
+	if nil != err {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &msg); err != nil {
+		// it's not JSON so just return it
+		return content, nil
+	}
+
+	return msg, nil
+}
+
+/*
+request sends a request to a developer tools endpoint and returns the response
+body and status code.
+*/
+func (chrome *Chrome) request(method, path string) ([]byte, int, error) {
+	scheme := "http"
+	client := &http.Client{Timeout: chrome.timeout}
+	if nil != chrome.tlsConfig {
+		scheme = "https"
+		client.Transport = &http.Transport{TLSClientConfig: chrome.tlsConfig}
+	}
+	uri := fmt.Sprintf("%s://%s:%d%s", scheme, chrome.Address(), chrome.Port(), path)
+	if "" != chrome.unixSocket {
+		client.Transport = chrome.unixTransport()
+		uri = fmt.Sprintf("http://localhost%s", path)
+	}
+	if nil != chrome.sshClient {
+		client.Transport = chrome.sshTransport()
+	}
+	req, err := http.NewRequest(method, uri, nil)
+	if err != nil {
+		return nil, 0, errs.Wrap(err, codes.ChromeQueryFailed, "invalid uri")
+	}
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, errs.Wrap(err, codes.ChromeQueryFailed, "get uri failed")
+		return nil, 0, errs.Wrap(err, codes.ChromeQueryFailed, "get uri failed")
 	}
 	defer resp.Body.Close()
 
-	log.WithFields(log.Fields{
+	chrome.logger.Debug("querying chrome", logger.Fields{
+		"method": method,
 		"path":   path,
 		"status": resp.Status,
-	}).Debug("querying chrome")
+	})
 	if 200 != resp.StatusCode {
-		return nil, errs.New(codes.ChromeQueryFailed, resp.Status)
+		return nil, resp.StatusCode, errs.New(codes.ChromeQueryFailed, resp.Status)
 	}
 
 	content, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errs.Wrap(err, codes.ChromeQueryFailed, "read failed")
-	} else if err := json.Unmarshal(content, &msg); err != nil {
-		// it's not JSON so just return it
-		return content, nil
+		return nil, resp.StatusCode, errs.Wrap(err, codes.ChromeQueryFailed, "read failed")
 	}
-
-	return msg, nil
+	return content, resp.StatusCode, nil
 }
 
 /*
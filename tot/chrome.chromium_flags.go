@@ -6,8 +6,8 @@ import (
 	"strings"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
 )
 
 /*
@@ -52,7 +52,7 @@ func (flags Flags) List() []string {
 	for _, arg := range orderedFlags {
 		val, err := flags.Get(arg)
 		if nil != err {
-			log.Error(err)
+			logger.Default().Error(err.Error(), logger.Fields{"flag": arg})
 		}
 		switch val.(type) {
 		case int:
@@ -8,13 +8,7 @@ import (
 )
 
 func TestChromiumNew(t *testing.T) {
-	chrome := New(
-		&Flags{},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
-	)
+	chrome := New()
 	if "localhost" != chrome.Address() {
 		t.Errorf("Expected 'localhost', received '%s'", chrome.Address())
 	}
@@ -42,24 +36,12 @@ func TestChromiumNew(t *testing.T) {
 }
 
 func TestChromiumClose(t *testing.T) {
-	chrome := New(
-		&Flags{},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
-	)
+	chrome := New()
 	chrome.Close()
 }
 
 func TestChromiumGetTab(t *testing.T) {
-	chrome := New(
-		&Flags{},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
-	)
+	chrome := New()
 	_, err := chrome.GetTab("some-tab")
 	if nil == err {
 		t.Errorf("Expected error, received nil")
@@ -67,13 +49,7 @@ func TestChromiumGetTab(t *testing.T) {
 }
 
 func TestChromiumLaunch(t *testing.T) {
-	chrome := New(
-		&Flags{},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
-	)
+	chrome := New()
 	err := chrome.Launch()
 	if nil == err {
 		t.Errorf("Expected error, received nil")
@@ -82,14 +58,10 @@ func TestChromiumLaunch(t *testing.T) {
 
 func TestChromiumQuery(t *testing.T) {
 	chrome := New(
-		&Flags{
+		WithFlags(&Flags{
 			"remote-debugging-port": 0,
 			"port":                  0,
-		},
-		"",
-		"",
-		"",
-		"",
+		}),
 	)
 	data, err := chrome.Query("/json/version", url.Values{}, nil)
 	if nil == err {
@@ -102,14 +74,10 @@ func TestChromiumQuery(t *testing.T) {
 
 func TestChromiumTabs(t *testing.T) {
 	chrome := New(
-		&Flags{
+		WithFlags(&Flags{
 			"remote-debugging-port": 0,
 			"port":                  0,
-		},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
+		}),
 	)
 	tabs := chrome.Tabs()
 	if nil != tabs {
@@ -119,16 +87,12 @@ func TestChromiumTabs(t *testing.T) {
 
 func TestChromiumVersion(t *testing.T) {
 	chrome := New(
-		&Flags{
-			"addr": "devnul",
+		WithFlags(&Flags{
+			"addr":                     "devnul",
 			"remote-debugging-address": "devnul",
-			"port":                  9222,
-			"remote-debugging-port": 9222,
-		},
-		"", //"path/to/chrome",
-		"", //"path/to/stderr",
-		"", //"path/to/stdout",
-		"", //"path/to/workdir",
+			"port":                     9222,
+			"remote-debugging-port":    9222,
+		}),
 	)
 	version, err := chrome.Version()
 	if nil == err {
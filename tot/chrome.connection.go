@@ -0,0 +1,211 @@
+package chrome
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+BrowserConnection is a single websocket connection to the browser target that
+tabs share. NewTab opens one websocket per tab, which exhausts file
+descriptors when hundreds of tabs are driven at once. Tabs opened through a
+BrowserConnection are flattened target sessions instead: their commands and
+events are multiplexed over the browser connection and each tab only costs a
+session ID:
+
+	conn, err := browser.Connect()
+	if nil != err {
+		...
+	}
+	defer conn.Close()
+	for _, uri := range uris {
+		tab, err := conn.NewTab(uri)
+		if nil != err {
+			...
+		}
+		...
+	}
+
+The tabs work like tabs opened with NewTab, except that a slow event handler
+in one tab delays the events of the others, as they're read by the same
+connection.
+*/
+type BrowserConnection struct {
+	chrome *Chrome
+	mux    *sync.Mutex
+	socket *socket.Socket
+	tabs   map[*Tab]struct{}
+}
+
+/*
+Connect opens a connection to the browser target for tabs to share.
+*/
+func (chrome *Chrome) Connect() (*BrowserConnection, error) {
+	version, err := chrome.Version()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeConnectionFailed, "could not read the browser websocket URL")
+	}
+	if "" == version.WebSocketDebuggerURL {
+		return nil, errs.New(codes.ChromeConnectionFailed, "the browser did not report a websocket URL")
+	}
+	websocketURL, err := chrome.websocketURL(version.WebSocketDebuggerURL)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeConnectionFailed, fmt.Sprintf("invalid browser websocket URL '%s'", version.WebSocketDebuggerURL))
+	}
+	return &BrowserConnection{
+		chrome: chrome,
+		mux:    &sync.Mutex{},
+		socket: socket.New(websocketURL, chrome.socketOptions(websocketURL)...),
+		tabs:   make(map[*Tab]struct{}),
+	}, nil
+}
+
+/*
+NewTab opens a tab that shares the browser connection. The tab is closed with
+Tab.Close as usual.
+*/
+func (conn *BrowserConnection) NewTab(uri string) (*Tab, error) {
+	return conn.newTab(uri, "")
+}
+
+/*
+newTab opens a tab that shares the browser connection in a browser context, or
+in the default context if the ID is empty.
+*/
+func (conn *BrowserConnection) newTab(uri string, browserContextID target.BrowserContextID) (*Tab, error) {
+	if "" == uri {
+		uri = "about:blank"
+	}
+	targetURL, err := url.Parse(uri)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabURLInvalid, "invalid URL")
+	}
+
+	result := <-conn.socket.Target().CreateTarget(&target.CreateTargetParams{
+		URL:              uri,
+		BrowserContextID: browserContextID,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.ChromeConnectionFailed, fmt.Sprintf("could not create a target for '%s'", uri))
+	}
+	session, err := conn.socket.AttachToTarget(result.ID)
+	if nil != err {
+		<-conn.socket.Target().CloseTarget(&target.CloseTargetParams{ID: result.ID})
+		return nil, errs.Wrap(err, codes.ChromeConnectionFailed, fmt.Sprintf("could not attach to target '%s'", result.ID))
+	}
+
+	tab := &Tab{
+		chrome:     conn.chrome,
+		cleanup:    newCleanupStack(),
+		connection: conn,
+		data: &TabData{
+			ID:   string(result.ID),
+			Type: "page",
+			URL:  uri,
+		},
+		logger:     conn.chrome.logger,
+		protocol:   session,
+		socket:     session,
+		sourceMaps: conn.chrome.sourceMaps,
+		url:        targetURL,
+	}
+	conn.mux.Lock()
+	conn.tabs[tab] = struct{}{}
+	conn.mux.Unlock()
+	conn.chrome.tabs = append(conn.chrome.tabs, tab)
+	return tab, nil
+}
+
+/*
+Tabs returns the number of open tabs sharing the connection.
+*/
+func (conn *BrowserConnection) Tabs() int {
+	conn.mux.Lock()
+	defer conn.mux.Unlock()
+	return len(conn.tabs)
+}
+
+/*
+Socket returns the browser connection, for commands to the browser target
+itself such as Target.setDiscoverTargets.
+*/
+func (conn *BrowserConnection) Socket() *socket.Socket {
+	return conn.socket
+}
+
+/*
+Client returns the browser connection as a BrowserClient, which only provides
+the browser level protocol domains.
+*/
+func (conn *BrowserConnection) Client() *socket.BrowserClient {
+	return socket.NewBrowserClient(conn.socket)
+}
+
+/*
+Close closes the open tabs sharing the connection and then the connection.
+*/
+func (conn *BrowserConnection) Close() error {
+	conn.mux.Lock()
+	tabs := make([]*Tab, 0, len(conn.tabs))
+	for tab := range conn.tabs {
+		tabs = append(tabs, tab)
+	}
+	conn.mux.Unlock()
+
+	var err error
+	for _, tab := range tabs {
+		if _, e := tab.Close(); nil != e {
+			if nil == err {
+				err = errs.Wrap(e, codes.ChromeConnectionFailed, "could not close a tab")
+			} else {
+				err = err.(errs.Err).With(e, "could not close a tab")
+			}
+		}
+	}
+	conn.socket.Stop()
+	return err
+}
+
+/*
+closeTarget closes the target of a tab sharing the connection.
+*/
+func (conn *BrowserConnection) closeTarget(tab *Tab) error {
+	conn.mux.Lock()
+	delete(conn.tabs, tab)
+	conn.mux.Unlock()
+	conn.chrome.RemoveTab(tab)
+
+	result := <-conn.socket.Target().CloseTarget(&target.CloseTargetParams{ID: target.ID(tab.Data().ID)})
+	if nil != result.Err {
+		tab.log().Warn(result.Err.Error(), logger.Fields{"error": result.Err, "tabID": tab.Data().ID})
+		return errs.Wrap(result.Err, codes.ChromeConnectionFailed, fmt.Sprintf("could not close target '%s'", tab.Data().ID))
+	}
+	return nil
+}
+
+/*
+browserConnection returns the browser connection the tab shares, or opens one
+for commands that are only allowed on the browser target. The returned
+function closes a connection opened for the caller.
+*/
+func (tab *Tab) browserConnection() (*BrowserConnection, func(), error) {
+	if nil != tab.connection {
+		return tab.connection, func() {}, nil
+	}
+	browser, ok := tab.chrome.(*Chrome)
+	if !ok {
+		return nil, nil, errs.New(codes.ChromeConnectionFailed, "the tab's browser doesn't support browser connections")
+	}
+	conn, err := browser.Connect()
+	if nil != err {
+		return nil, nil, err
+	}
+	return conn, func() { conn.Close() }, nil
+}
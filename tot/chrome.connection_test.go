@@ -0,0 +1,114 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+func TestBrowserConnection(t *testing.T) {
+	cdp := cdptest.NewServer(nil)
+	defer cdp.Close()
+	var targets int32
+	cdp.Handle("Target.createTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return map[string]interface{}{"targetId": fmt.Sprintf("target-%d", atomic.AddInt32(&targets, 1))}, nil
+	})
+	cdp.Handle("Target.attachToTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		p := struct {
+			TargetID string `json:"targetId"`
+		}{}
+		json.Unmarshal(params, &p)
+		return map[string]interface{}{"sessionId": "session-" + p.TargetID}, nil
+	})
+	cdp.Respond("Runtime.evaluate", map[string]interface{}{
+		"result": map[string]interface{}{"type": "number", "value": 2},
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Browser": "HeadlessChrome", "webSocketDebuggerUrl": "ws://` + cdp.URL().Host + `/devtools/browser/cdptest"}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	browser := New(WithAddress(serverURL.Hostname()), WithPort(port))
+	conn, err := browser.Connect()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	tabs := make([]*Tab, 0)
+	for a := 0; a < 3; a++ {
+		tab, err := conn.NewTab("https://example.com")
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		tabs = append(tabs, tab)
+	}
+	if err := cdp.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 1 != cdp.Connections() {
+		t.Errorf("Expected the tabs to share 1 connection, found %d", cdp.Connections())
+	}
+	if 3 != conn.Tabs() {
+		t.Errorf("Expected 3 tabs, found %d", conn.Tabs())
+	}
+
+	result := <-tabs[1].Runtime().Evaluate(&runtime.EvaluateParams{Expression: "1 + 1", ReturnByValue: true})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, received error: %v", result.Err)
+	}
+	sessionID := ""
+	for _, command := range cdp.Commands() {
+		if "Runtime.evaluate" == command.Method {
+			sessionID = command.SessionID
+		}
+	}
+	if "session-target-2" != sessionID {
+		t.Errorf("Expected the command to be sent to session-target-2, sent to '%s'", sessionID)
+	}
+
+	result = <-tabs[2].PageSession().Runtime().Evaluate(&runtime.EvaluateParams{Expression: "1 + 1", ReturnByValue: true})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, received error: %v", result.Err)
+	}
+	if discover := <-conn.Client().Target().SetDiscoverTargets(&target.SetDiscoverTargetsParams{Discover: true}); nil != discover.Err {
+		t.Fatalf("Expected nil, received error: %v", discover.Err)
+	}
+	commands := cdp.Commands()
+	if command := commands[len(commands)-2]; "session-target-3" != command.SessionID {
+		t.Errorf("Expected the page session command to be sent to session-target-3, sent to '%s'", command.SessionID)
+	}
+	if command := commands[len(commands)-1]; "Target.setDiscoverTargets" != command.Method || "" != command.SessionID {
+		t.Errorf("Expected the client command to be sent to the browser target, got %s '%s'", command.Method, command.SessionID)
+	}
+
+	if _, err := tabs[0].Close(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if 2 != conn.Tabs() {
+		t.Errorf("Expected 2 tabs, found %d", conn.Tabs())
+	}
+	if err := conn.Close(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	closed := make([]string, 0)
+	for _, command := range cdp.Commands() {
+		if "Target.closeTarget" == command.Method {
+			closed = append(closed, string(command.Params))
+		}
+	}
+	if 3 != len(closed) || !strings.Contains(closed[0], "target-1") {
+		t.Errorf("Expected the 3 targets to be closed, received %v", closed)
+	}
+}
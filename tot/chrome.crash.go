@@ -0,0 +1,193 @@
+package chrome
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+crashFrameHistory is the number of frames kept for each tab socket when crash
+reporting is enabled.
+*/
+const crashFrameHistory = 100
+
+/*
+CrashReport describes a browser crash. The dumps, debug log and frames are
+collected into a bundle directory in the working directory that is kept after
+the browser is closed, so it can be uploaded or inspected later.
+*/
+type CrashReport struct {
+	// The time the crash was detected.
+	Time time.Time
+
+	// The exit status of the browser process, for example
+	// 'signal: segmentation fault'.
+	State string
+
+	// The bundle directory.
+	Dir string
+
+	// The paths of the crashpad minidumps copied into the bundle.
+	Dumps []string
+
+	// The path of the copy of chrome_debug.log in the bundle, if the log was
+	// found.
+	DebugLog string
+
+	// The last CDP frames sent and received by each tab, by tab ID. The
+	// frames are also written to frames.json in the bundle.
+	Frames map[string][]*socket.Frame
+}
+
+/*
+OnBrowserCrash calls a handler when the browser process exits without being
+closed, with a bundle of the crashpad minidumps, chrome_debug.log and the last
+CDP frames of each tab:
+
+	browser := chrome.New(chrome.OnBrowserCrash(func(report *chrome.CrashReport) {
+		log.Printf("browser crashed (%s), report in %s", report.State, report.Dir)
+	}))
+
+Launch enables crash dumps and logging with the crash-dumps-dir, enable-logging
+and log-file flags unless they are already set, and the frames of tabs opened
+with NewTab are recorded. The handler is called from the goroutine that waits
+for the process.
+*/
+func OnBrowserCrash(handler func(report *CrashReport)) Option {
+	return func(chrome *Chrome) {
+		chrome.crashHandler = handler
+	}
+}
+
+/*
+enableCrashReporting sets the flags crash dumps and the debug log are written
+with. It's called by Launch once the working directory exists.
+*/
+func (chrome *Chrome) enableCrashReporting() error {
+	if nil == chrome.crashHandler {
+		return nil
+	}
+	if !chrome.Flags().Has("crash-dumps-dir") {
+		dir := filepath.Join(chrome.Workdir(), "crash-dumps")
+		if err := os.MkdirAll(dir, 0700); nil != err {
+			return err
+		}
+		chrome.Flags().Set("crash-dumps-dir", dir)
+	}
+	if !chrome.Flags().Has("log-file") {
+		chrome.Flags().Set("log-file", filepath.Join(chrome.Workdir(), "chrome_debug.log"))
+	}
+	if !chrome.Flags().Has("enable-logging") {
+		chrome.Flags().Set("enable-logging", nil)
+	}
+	return nil
+}
+
+/*
+wait waits for the browser process to exit. If it exits without being closed
+the crash is reported.
+*/
+func (chrome *Chrome) wait(process *os.Process, exited chan struct{}) {
+	chrome.exitState, chrome.exitErr = process.Wait()
+	close(exited)
+	if 1 == atomic.LoadInt32(&chrome.closing) {
+		return
+	}
+
+	state := "unknown"
+	if nil != chrome.exitState {
+		state = chrome.exitState.String()
+	} else if nil != chrome.exitErr {
+		state = chrome.exitErr.Error()
+	}
+	chrome.logger.Error("Chromium exited unexpectedly", logger.Fields{"state": state})
+	if nil == chrome.crashHandler {
+		return
+	}
+	chrome.crashHandler(chrome.collectCrash(state))
+}
+
+/*
+collectCrash collects the crash bundle. Anything that can't be collected is
+logged and left out of the report.
+*/
+func (chrome *Chrome) collectCrash(state string) *CrashReport {
+	report := &CrashReport{
+		Time:   time.Now(),
+		State:  state,
+		Dumps:  []string{},
+		Frames: make(map[string][]*socket.Frame),
+	}
+
+	dir, err := ioutil.TempDir(chrome.Workdir(), "crash-")
+	if nil != err {
+		chrome.logger.Warn("could not create crash report directory", logger.Fields{"error": err.Error()})
+		return report
+	}
+	report.Dir = dir
+
+	roots := []string{}
+	if value, err := chrome.Flags().Get("crash-dumps-dir"); nil == err {
+		if dumpDir, ok := value.(string); ok {
+			roots = append(roots, dumpDir)
+		}
+	}
+	if value, err := chrome.Flags().Get("user-data-dir"); nil == err {
+		if userDataDir, ok := value.(string); ok {
+			roots = append(roots, filepath.Join(userDataDir, "Crashpad"))
+		}
+	}
+	// Dumps from before the launch are left out. File times can be as coarse
+	// as a second.
+	since := chrome.launched.Add(-time.Second)
+	for _, root := range roots {
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if nil != err || info.IsDir() || ".dmp" != filepath.Ext(path) || info.ModTime().Before(since) {
+				return nil
+			}
+			dump := filepath.Join(dir, info.Name())
+			if err := copyFile(path, dump, 0600); nil != err {
+				chrome.logger.Warn("could not copy crash dump", logger.Fields{"error": err.Error(), "path": path})
+				return nil
+			}
+			report.Dumps = append(report.Dumps, dump)
+			return nil
+		})
+	}
+
+	if value, err := chrome.Flags().Get("log-file"); nil == err {
+		if debugLog, ok := value.(string); ok && "" != debugLog {
+			report.DebugLog = filepath.Join(dir, "chrome_debug.log")
+			if err := copyFile(debugLog, report.DebugLog, 0600); nil != err {
+				chrome.logger.Warn("could not copy debug log", logger.Fields{"error": err.Error(), "path": debugLog})
+				report.DebugLog = ""
+			}
+		}
+	}
+
+	for _, tab := range chrome.Tabs() {
+		if framer, ok := tab.socket.(interface {
+			Frames() []*socket.Frame
+		}); ok {
+			report.Frames[tab.Data().ID] = framer.Frames()
+		}
+	}
+	data, err := json.MarshalIndent(report.Frames, "", "  ")
+	if nil == err {
+		err = ioutil.WriteFile(filepath.Join(dir, "frames.json"), data, 0600)
+	}
+	if nil != err {
+		chrome.logger.Warn("could not write CDP frames", logger.Fields{"error": err.Error()})
+	}
+
+	chrome.logger.Info("crash report collected", logger.Fields{"dir": dir, "dumps": strings.Join(report.Dumps, ",")})
+	return report
+}
@@ -0,0 +1,106 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+newFakeBrowser writes a script that stands in for the browser binary.
+*/
+func newFakeBrowser(t *testing.T, dir, script string) string {
+	binary := filepath.Join(dir, "browser.sh")
+	if err := ioutil.WriteFile(binary, []byte("#!/bin/sh\n"+script), 0700); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	return binary
+}
+
+func TestOnBrowserCrash(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+	binary := newFakeBrowser(t, workdir, `
+for arg in "$0" "$@"; do
+	case "$arg" in
+		--crash-dumps-dir=*) dumps="${arg#*=}" ;;
+		--log-file=*) debugLog="${arg#*=}" ;;
+	esac
+done
+mkdir -p "$dumps/completed"
+echo dump > "$dumps/completed/1234.dmp"
+echo log > "$debugLog"
+kill -SEGV $$
+`)
+
+	reports := make(chan *CrashReport, 1)
+	chrome := New(
+		WithFlags(&Flags{}),
+		WithBinary(binary),
+		WithStderr(filepath.Join(workdir, "stderr")),
+		WithStdout(filepath.Join(workdir, "stdout")),
+		WithTimeout(1500*time.Millisecond),
+		WithWorkdir(workdir),
+		OnBrowserCrash(func(report *CrashReport) {
+			reports <- report
+		}),
+	)
+	if err := chrome.Launch(); nil == err {
+		t.Fatalf("Expected error, got nil")
+	}
+
+	var report *CrashReport
+	select {
+	case report = <-reports:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the crash to be reported")
+	}
+	if !strings.Contains(report.State, "segmentation fault") {
+		t.Errorf("Expected a segmentation fault, got '%s'", report.State)
+	}
+	if "" == report.Dir || workdir != filepath.Dir(report.Dir) {
+		t.Errorf("Expected a bundle in the working directory, got '%s'", report.Dir)
+	}
+	if 1 != len(report.Dumps) {
+		t.Fatalf("Expected 1 dump, got %v", report.Dumps)
+	}
+	if data, _ := ioutil.ReadFile(report.Dumps[0]); "dump\n" != string(data) {
+		t.Errorf("Expected the dump to be copied, got '%s'", data)
+	}
+	if data, _ := ioutil.ReadFile(report.DebugLog); "log\n" != string(data) {
+		t.Errorf("Expected the debug log to be copied, got '%s'", data)
+	}
+	if _, err := os.Stat(filepath.Join(report.Dir, "frames.json")); nil != err {
+		t.Errorf("Expected the frames to be written, got %v", err)
+	}
+}
+
+func TestOnBrowserCrashClosed(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+	binary := newFakeBrowser(t, workdir, "trap 'exit 0' INT\nwhile true; do sleep 0.1; done\n")
+
+	reports := make(chan *CrashReport, 1)
+	chrome := New(
+		WithFlags(&Flags{}),
+		WithBinary(binary),
+		WithStderr(filepath.Join(workdir, "stderr")),
+		WithStdout(filepath.Join(workdir, "stdout")),
+		WithTimeout(1500*time.Millisecond),
+		WithWorkdir(workdir),
+		OnBrowserCrash(func(report *CrashReport) {
+			reports <- report
+		}),
+	)
+	if err := chrome.Launch(); nil == err {
+		t.Fatalf("Expected error, got nil")
+	}
+	select {
+	case report := <-reports:
+		t.Errorf("Expected no crash report when the browser is closed, got %v", report)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,41 @@
+package chrome
+
+import (
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+WithReattach keeps tabs usable when another client takes over their target,
+for example when a user opens DevTools for a page the browser is driving. Tabs
+are attached again when the browser detaches them for a reason other than the
+page closing or crashing or the user canceling debugging, keeping their event
+handlers and enabled domains. The hook, which may be nil, is called with each
+detachment:
+
+	browser := chrome.New(chrome.WithReattach(func(detachment *socket.Detachment) {
+		log.Warn(detachment.Error())
+	}))
+
+Without it a detached tab's commands fail with a SocketDetached error that
+includes the reason the browser reported, see Tab.Detached.
+*/
+func WithReattach(hook func(detachment *socket.Detachment)) Option {
+	return func(chrome *Chrome) {
+		chrome.reattach = true
+		chrome.detachHook = hook
+	}
+}
+
+/*
+Detached returns the detachment of the tab's connection or session, or nil if
+the browser hasn't detached the tab.
+*/
+func (tab *Tab) Detached() *socket.Detachment {
+	detacher, ok := tab.socket.(interface {
+		Detached() *socket.Detachment
+	})
+	if !ok {
+		return nil
+	}
+	return detacher.Detached()
+}
@@ -0,0 +1,46 @@
+package chrome
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/inspector"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+func TestWithReattach(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	detachments := make(chan *socket.Detachment, 1)
+	WithReattach(func(detachment *socket.Detachment) {
+		detachments <- detachment
+	})(browser)
+	if !browser.reattach {
+		t.Errorf("Expected reattaching to be enabled")
+	}
+
+	tab, err := browser.NewTab("https://example.com")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Close()
+	if err := cdp.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if nil != tab.Detached() {
+		t.Errorf("Expected the tab not to be detached")
+	}
+
+	cdp.Emit("Inspector.detached", map[string]string{"reason": "replaced_with_devtools"})
+	select {
+	case detachment := <-detachments:
+		if inspector.DetachReason.ReplacedWithDevtools != detachment.DetachReason() {
+			t.Errorf("Expected %s, received %s", inspector.DetachReason.ReplacedWithDevtools, detachment.DetachReason())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the detach hook to be called")
+	}
+	if detachment := tab.Detached(); nil == detachment || "replaced_with_devtools" != detachment.Reason {
+		t.Errorf("Expected the tab to be detached, received %v", detachment)
+	}
+}
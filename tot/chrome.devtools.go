@@ -0,0 +1,119 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+DevTools is a typed client for the HTTP discovery endpoints of the browser, for
+enumerating and opening tabs before a websocket connection is established:
+
+	devtools := browser.DevTools()
+	targets, err := devtools.List()
+	if nil != err {
+		...
+	}
+	for _, target := range targets {
+		if "page" == target.Type {
+			devtools.Activate(target.ID)
+		}
+	}
+
+The endpoints are reached with the browser's address, port, TLS, unix socket
+and SSH tunnel configuration.
+*/
+type DevTools struct {
+	chrome *Chrome
+}
+
+/*
+DevTools returns a client for the HTTP discovery endpoints of the browser.
+*/
+func (chrome *Chrome) DevTools() *DevTools {
+	return &DevTools{chrome: chrome}
+}
+
+/*
+Version returns the browser version from /json/version. Unlike Chrome.Version
+the result isn't cached.
+*/
+func (devtools *DevTools) Version() (*Version, error) {
+	version := &Version{}
+	if _, err := devtools.get(http.MethodGet, "/json/version", version); nil != err {
+		return nil, err
+	}
+	return version, nil
+}
+
+/*
+List returns the open targets from /json/list.
+*/
+func (devtools *DevTools) List() ([]*TabData, error) {
+	targets := []*TabData{}
+	if _, err := devtools.get(http.MethodGet, "/json/list", &targets); nil != err {
+		return nil, err
+	}
+	return targets, nil
+}
+
+/*
+New opens a tab with /json/new and returns its target. The tab isn't connected,
+see Chrome.NewTab. Recent Chrome versions only accept PUT requests to open tabs,
+older versions only GET requests, so GET is tried when PUT isn't allowed.
+*/
+func (devtools *DevTools) New(uri string) (*TabData, error) {
+	if "" == uri {
+		uri = "about:blank"
+	}
+	path := fmt.Sprintf("/json/new?%s", url.QueryEscape(uri))
+	target := &TabData{}
+	status, err := devtools.get(http.MethodPut, path, target)
+	if http.StatusMethodNotAllowed == status {
+		_, err = devtools.get(http.MethodGet, path, target)
+	}
+	if nil != err {
+		return nil, err
+	}
+	return target, nil
+}
+
+/*
+Close closes a target with /json/close.
+*/
+func (devtools *DevTools) Close(targetID string) error {
+	_, err := devtools.get(http.MethodGet, fmt.Sprintf("/json/close/%s", url.PathEscape(targetID)), nil)
+	return err
+}
+
+/*
+Activate brings a target's tab to the front with /json/activate.
+*/
+func (devtools *DevTools) Activate(targetID string) error {
+	_, err := devtools.get(http.MethodGet, fmt.Sprintf("/json/activate/%s", url.PathEscape(targetID)), nil)
+	return err
+}
+
+/*
+get sends a request to an endpoint and decodes the JSON response into v, which
+may be nil for endpoints that respond with text. It returns the response status
+code.
+*/
+func (devtools *DevTools) get(method, path string, v interface{}) (int, error) {
+	content, status, err := devtools.chrome.request(method, path)
+	if nil != err {
+		return status, errs.Wrap(err, codes.ChromeQueryFailed, fmt.Sprintf("%s %s failed", method, path))
+	}
+	if nil == v {
+		return status, nil
+	}
+	if err := json.Unmarshal(content, v); nil != err {
+		return status, errs.Wrap(err, codes.ChromeQueryFailed, fmt.Sprintf("invalid %s response", path))
+	}
+	return status, nil
+}
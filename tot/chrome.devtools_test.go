@@ -0,0 +1,101 @@
+package chrome
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newDevToolsBrowser(t *testing.T, putOnly bool) (*Chrome, *[]string, func()) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case "/json/version" == r.URL.Path:
+			w.Write([]byte(`{"browser": "HeadlessChrome/120.0", "webSocketDebuggerUrl": "ws://localhost/devtools/browser/1"}`))
+		case "/json/list" == r.URL.Path:
+			w.Write([]byte(`[{"id": "1", "type": "page", "url": "about:blank"}, {"id": "2", "type": "service_worker"}]`))
+		case "/json/new" == r.URL.Path:
+			if putOnly != (http.MethodPut == r.Method) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte(`{"id": "3", "type": "page", "url": "` + r.URL.RawQuery + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/json/close/"):
+			w.Write([]byte(`Target is closing`))
+		case strings.HasPrefix(r.URL.Path, "/json/activate/"):
+			w.Write([]byte(`Target activated`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	return New(WithAddress(serverURL.Hostname()), WithPort(port)), &requests, server.Close
+}
+
+func TestDevTools(t *testing.T) {
+	browser, requests, stop := newDevToolsBrowser(t, true)
+	defer stop()
+	devtools := browser.DevTools()
+
+	version, err := devtools.Version()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "HeadlessChrome/120.0" != version.Browser {
+		t.Errorf("Expected the browser version, got '%s'", version.Browser)
+	}
+	targets, err := devtools.List()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 2 != len(targets) || "service_worker" != targets[1].Type {
+		t.Errorf("Expected 2 targets, got %+v", targets)
+	}
+	target, err := devtools.New("https://www.example.com")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "3" != target.ID {
+		t.Errorf("Expected target '3', got '%s'", target.ID)
+	}
+	if err := devtools.Activate("3"); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if err := devtools.Close("3"); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if err := devtools.Close("missing/target"); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	expected := "PUT /json/new?https%3A%2F%2Fwww.example.com"
+	if expected != (*requests)[2] {
+		t.Errorf("Expected '%s', got '%s'", expected, (*requests)[2])
+	}
+}
+
+func TestDevToolsNewGet(t *testing.T) {
+	browser, requests, stop := newDevToolsBrowser(t, false)
+	defer stop()
+
+	target, err := browser.DevTools().New("")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "about%3Ablank" != target.URL {
+		t.Errorf("Expected a blank tab, got '%s'", target.URL)
+	}
+	if 2 != len(*requests) || !strings.HasPrefix((*requests)[1], "GET ") {
+		t.Errorf("Expected GET to be tried after PUT, got %v", *requests)
+	}
+	if _, err := browser.DevTools().Version(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if _, err := browser.DevTools().List(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+}
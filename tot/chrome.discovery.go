@@ -0,0 +1,166 @@
+package chrome
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+Channel is a Chrome release channel.
+*/
+type Channel string
+
+/*
+Release channels, from the most to the least stable. Chromium builds are
+considered part of the Stable channel.
+*/
+const (
+	Stable Channel = "stable"
+	Beta   Channel = "beta"
+	Dev    Channel = "dev"
+	Canary Channel = "canary"
+)
+
+/*
+Channels lists the release channels, from the most to the least stable.
+*/
+var Channels = []Channel{Stable, Beta, Dev, Canary}
+
+/*
+Browser is an installed browser found by FindBrowsers.
+*/
+type Browser struct {
+	// The release channel of the browser.
+	Channel Channel
+
+	// The path to the browser executable.
+	Path string
+}
+
+/*
+FindBrowsers returns the browsers installed in the standard locations of the
+current platform, from the most to the least stable channel. On Linux those
+are the PATH, /opt/google and the snap and flatpak exports, on macOS the
+application bundles in /Applications and ~/Applications and on Windows the App
+Paths registry key and the Program Files and LocalAppData installation
+directories. The same executable is only returned once.
+*/
+func FindBrowsers() []*Browser {
+	browsers := []*Browser{}
+	found := make(map[string]bool)
+	for _, channel := range Channels {
+		for _, path := range browserCandidates(channel) {
+			if found[path] || !executable(path) {
+				continue
+			}
+			found[path] = true
+			browsers = append(browsers, &Browser{Channel: channel, Path: path})
+		}
+	}
+	return browsers
+}
+
+/*
+FindBrowser returns the first installed browser in order of channel
+preference. If no channels are specified the most stable channel installed is
+preferred:
+
+	browser, err := chrome.FindBrowser(chrome.Canary, chrome.Dev)
+	if nil != err {
+		...
+	}
+	fmt.Println(browser.Path)
+*/
+func FindBrowser(channels ...Channel) (*Browser, error) {
+	if 0 == len(channels) {
+		channels = Channels
+	}
+	browsers := FindBrowsers()
+	for _, channel := range channels {
+		for _, browser := range browsers {
+			if channel == browser.Channel {
+				return browser, nil
+			}
+		}
+	}
+	return nil, errs.New(codes.ChromeBrowserNotFound, fmt.Sprintf("no browser found for channels %v on %s", channels, runtime.GOOS))
+}
+
+/*
+WithChannel discovers the browser binary with FindBrowser when no binary is
+set, preferring the channels in the order they're passed:
+
+	browser := chrome.New(chrome.WithChannel(chrome.Beta, chrome.Stable))
+
+WithBinary and the CHROME_PATH environment variable override discovery, and
+the CHROME_CHANNEL environment variable sets the channels, for example
+'beta,stable'. If no browser is found the default binary is used.
+*/
+func WithChannel(channels ...Channel) Option {
+	return func(chrome *Chrome) {
+		chrome.channels = channels
+	}
+}
+
+/*
+parseChannels parses a comma separated list of channels.
+*/
+func parseChannels(list string) ([]Channel, error) {
+	channels := []Channel{}
+	for _, name := range strings.Split(list, ",") {
+		channel := Channel(strings.ToLower(strings.TrimSpace(name)))
+		switch channel {
+		case Stable, Beta, Dev, Canary:
+			channels = append(channels, channel)
+		default:
+			return nil, fmt.Errorf("unknown channel '%s'", name)
+		}
+	}
+	return channels, nil
+}
+
+/*
+discoverBinary sets the binary to the browser found for the channels, if any.
+*/
+func (chrome *Chrome) discoverBinary() {
+	if 0 == len(chrome.channels) {
+		return
+	}
+	browser, err := FindBrowser(chrome.channels...)
+	if nil != err {
+		chrome.logger.Warn("could not discover the browser binary", logger.Fields{"error": err.Error()})
+		return
+	}
+	chrome.binary = browser.Path
+}
+
+/*
+lookPaths returns the paths of the executables found in the PATH.
+*/
+func lookPaths(names ...string) []string {
+	paths := []string{}
+	for _, name := range names {
+		if path, err := exec.LookPath(name); nil == err {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+/*
+executable returns whether a path is an executable file.
+*/
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	if nil != err || !info.Mode().IsRegular() {
+		return false
+	}
+	return "windows" == runtime.GOOS || 0 != info.Mode()&0111
+}
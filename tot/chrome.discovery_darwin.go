@@ -0,0 +1,43 @@
+//go:build darwin
+// +build darwin
+
+package chrome
+
+import (
+	"os"
+	"path/filepath"
+)
+
+/*
+browserBundles maps the channels to their application bundles and the
+executables in them.
+*/
+var browserBundles = map[Channel][]string{
+	Stable: {"Google Chrome.app/Contents/MacOS/Google Chrome", "Chromium.app/Contents/MacOS/Chromium"},
+	Beta:   {"Google Chrome Beta.app/Contents/MacOS/Google Chrome Beta"},
+	Dev:    {"Google Chrome Dev.app/Contents/MacOS/Google Chrome Dev"},
+	Canary: {"Google Chrome Canary.app/Contents/MacOS/Google Chrome Canary"},
+}
+
+/*
+browserCandidates returns the paths a browser of a channel may be installed
+at: the application bundles in /Applications and ~/Applications, then the
+executables in the PATH.
+*/
+func browserCandidates(channel Channel) []string {
+	roots := []string{"/Applications"}
+	if home := os.Getenv("HOME"); "" != home {
+		roots = append(roots, filepath.Join(home, "Applications"))
+	}
+
+	paths := []string{}
+	for _, root := range roots {
+		for _, bundle := range browserBundles[channel] {
+			paths = append(paths, filepath.Join(root, bundle))
+		}
+	}
+	if Stable == channel {
+		paths = append(paths, lookPaths("google-chrome", "chromium")...)
+	}
+	return paths
+}
@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"os"
+	"path/filepath"
+)
+
+/*
+browserCandidates returns the paths a browser of a channel may be installed
+at: the executables in the PATH, the Google packages in /opt/google, the
+Chromium snap and the flatpak exports.
+*/
+func browserCandidates(channel Channel) []string {
+	flatpaks := []string{"/var/lib/flatpak/exports/bin"}
+	if home := os.Getenv("HOME"); "" != home {
+		flatpaks = append(flatpaks, filepath.Join(home, ".local/share/flatpak/exports/bin"))
+	}
+
+	var paths []string
+	switch channel {
+	case Stable:
+		paths = lookPaths("google-chrome-stable", "google-chrome", "chromium", "chromium-browser")
+		paths = append(paths, "/opt/google/chrome/chrome", "/snap/bin/chromium")
+		for _, dir := range flatpaks {
+			paths = append(paths, filepath.Join(dir, "com.google.Chrome"), filepath.Join(dir, "org.chromium.Chromium"))
+		}
+	case Beta:
+		paths = lookPaths("google-chrome-beta")
+		paths = append(paths, "/opt/google/chrome-beta/chrome")
+	case Dev:
+		paths = lookPaths("google-chrome-unstable")
+		paths = append(paths, "/opt/google/chrome-unstable/chrome")
+		for _, dir := range flatpaks {
+			paths = append(paths, filepath.Join(dir, "com.google.ChromeDev"))
+		}
+	case Canary:
+		paths = lookPaths("google-chrome-canary")
+		paths = append(paths, "/opt/google/chrome-canary/chrome")
+	}
+	return paths
+}
@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindBrowser(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "discovery")
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"google-chrome-beta", "google-chrome-unstable"} {
+		ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755)
+	}
+	// Not executable.
+	ioutil.WriteFile(filepath.Join(dir, "google-chrome-canary"), []byte("#!/bin/sh\n"), 0644)
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", dir)
+
+	browser, err := FindBrowser(Canary, Dev, Beta)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if Dev != browser.Channel || filepath.Join(dir, "google-chrome-unstable") != browser.Path {
+		t.Errorf("Expected the dev browser, got %+v", browser)
+	}
+
+	chrome := New(WithChannel(Beta))
+	if filepath.Join(dir, "google-chrome-beta") != chrome.Binary() {
+		t.Errorf("Expected the beta browser, received '%s'", chrome.Binary())
+	}
+
+	found := map[Channel]bool{}
+	for _, browser := range FindBrowsers() {
+		found[browser.Channel] = true
+	}
+	if !found[Beta] || !found[Dev] {
+		t.Errorf("Expected the beta and dev browsers, got %v", found)
+	}
+}
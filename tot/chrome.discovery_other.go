@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package chrome
+
+/*
+browserCandidates returns the executables in the PATH, other platforms have no
+standard installation locations.
+*/
+func browserCandidates(channel Channel) []string {
+	if Stable != channel {
+		return nil
+	}
+	return lookPaths("google-chrome", "chromium", "chromium-browser", "chrome")
+}
@@ -0,0 +1,66 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseChannels(t *testing.T) {
+	channels, err := parseChannels("Beta, stable")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 2 != len(channels) || Beta != channels[0] || Stable != channels[1] {
+		t.Errorf("Expected [beta stable], got %v", channels)
+	}
+	if _, err := parseChannels("beta,nightly"); nil == err {
+		t.Errorf("Expected an error for an unknown channel")
+	}
+}
+
+func TestExecutable(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "discovery")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "chrome"), []byte("#!/bin/sh\n"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "data"), []byte("data"), 0644)
+
+	if !executable(filepath.Join(dir, "chrome")) {
+		t.Errorf("Expected an executable file")
+	}
+	if executable(dir) || executable(filepath.Join(dir, "missing")) {
+		t.Errorf("Expected directories and missing files not to be executable")
+	}
+}
+
+func TestWithChannelFallback(t *testing.T) {
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", "")
+
+	chrome := New(WithChannel(Canary))
+	if _, err := FindBrowser(Canary); nil == err {
+		t.Skipf("A Canary browser is installed")
+	}
+	if "/usr/bin/google-chrome" != chrome.Binary() {
+		t.Errorf("Expected '/usr/bin/google-chrome', received '%s'", chrome.Binary())
+	}
+
+	chrome = New(WithChannel(Canary), WithBinary("/option/chrome"))
+	if "/option/chrome" != chrome.Binary() {
+		t.Errorf("Expected '/option/chrome', received '%s'", chrome.Binary())
+	}
+}
+
+func TestChannelEnv(t *testing.T) {
+	defer os.Unsetenv(EnvChannel)
+
+	os.Setenv(EnvChannel, "dev,beta")
+	if chrome := New(); 2 != len(chrome.channels) || Dev != chrome.channels[0] {
+		t.Errorf("Expected [dev beta], got %v", chrome.channels)
+	}
+	os.Setenv(EnvChannel, "nightly")
+	if chrome := New(); 0 != len(chrome.channels) {
+		t.Errorf("Expected an invalid channel list to be ignored, got %v", chrome.channels)
+	}
+}
@@ -0,0 +1,71 @@
+//go:build windows
+// +build windows
+
+package chrome
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+/*
+browserDirs maps the channels to their installation directories, relative to
+LocalAppData or Program Files.
+*/
+var browserDirs = map[Channel]string{
+	Stable: `Google\Chrome\Application`,
+	Beta:   `Google\Chrome Beta\Application`,
+	Dev:    `Google\Chrome Dev\Application`,
+	Canary: `Google\Chrome SxS\Application`,
+}
+
+/*
+browserCandidates returns the paths a browser of a channel may be installed
+at: for the Stable channel the executable registered in App Paths, then the
+per-user and system-wide installation directories.
+*/
+func browserCandidates(channel Channel) []string {
+	paths := []string{}
+	if Stable == channel {
+		for _, root := range []syscall.Handle{syscall.HKEY_CURRENT_USER, syscall.HKEY_LOCAL_MACHINE} {
+			if path := appPath(root, "chrome.exe"); "" != path {
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, env := range []string{"LOCALAPPDATA", "PROGRAMFILES", "PROGRAMFILES(X86)"} {
+		if root := os.Getenv(env); "" != root {
+			paths = append(paths, filepath.Join(root, browserDirs[channel], "chrome.exe"))
+		}
+	}
+	return paths
+}
+
+/*
+appPath reads the path of an executable registered in the App Paths registry
+key.
+*/
+func appPath(root syscall.Handle, exe string) string {
+	name, err := syscall.UTF16PtrFromString(`SOFTWARE\Microsoft\Windows\CurrentVersion\App Paths\` + exe)
+	if nil != err {
+		return ""
+	}
+	var key syscall.Handle
+	if err := syscall.RegOpenKeyEx(root, name, 0, syscall.KEY_READ, &key); nil != err {
+		return ""
+	}
+	defer syscall.RegCloseKey(key)
+
+	// Read the size of the default value, then the value.
+	var valueType, size uint32
+	if err := syscall.RegQueryValueEx(key, nil, nil, &valueType, nil, &size); nil != err || size < 2 {
+		return ""
+	}
+	value := make([]uint16, size/2)
+	if err := syscall.RegQueryValueEx(key, nil, nil, &valueType, (*byte)(unsafe.Pointer(&value[0])), &size); nil != err {
+		return ""
+	}
+	return syscall.UTF16ToString(value)
+}
@@ -0,0 +1,152 @@
+package chrome
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+xvfbTimeout is how long Xvfb is given to start.
+*/
+const xvfbTimeout = 10 * time.Second
+
+/*
+VirtualDisplay is an X display for headful browsers on servers without one:
+either an Xvfb virtual framebuffer started by StartXvfb or an existing display.
+*/
+type VirtualDisplay struct {
+	// The DISPLAY value, for example ':99'.
+	Display string
+
+	// cmd is the Xvfb process, nil for an existing display.
+	cmd *exec.Cmd
+
+	// exited receives the result of waiting for the Xvfb process.
+	exited chan error
+}
+
+/*
+StartXvfb starts an Xvfb virtual framebuffer server. If display is empty Xvfb
+picks the first free display, otherwise it's started on the display, for
+example ':99'. The arguments replace the default screen configuration,
+'-screen 0 1920x1080x24 -nolisten tcp':
+
+	display, err := chrome.StartXvfb("", "-screen", "0", "1280x720x24")
+	if nil != err {
+		...
+	}
+	defer display.Stop()
+
+StartXvfb returns once the server accepts connections.
+*/
+func StartXvfb(display string, args ...string) (*VirtualDisplay, error) {
+	if 0 == len(args) {
+		args = []string{"-screen", "0", "1920x1080x24", "-nolisten", "tcp"}
+	}
+
+	// Xvfb writes the display number to the file descriptor once it's ready.
+	reader, writer, err := os.Pipe()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeNoDisplay, "could not start Xvfb")
+	}
+	defer reader.Close()
+	argv := []string{"-displayfd", "3"}
+	if "" != display {
+		argv = append([]string{display}, argv...)
+	}
+	cmd := exec.Command("Xvfb", append(argv, args...)...)
+	cmd.ExtraFiles = []*os.File{writer}
+	err = cmd.Start()
+	writer.Close()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeNoDisplay, "could not start Xvfb, is it installed?")
+	}
+
+	vd := &VirtualDisplay{cmd: cmd, exited: make(chan error, 1)}
+	go func() { vd.exited <- cmd.Wait() }()
+
+	ready := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(reader).ReadString('\n')
+		ready <- strings.TrimSpace(line)
+	}()
+	select {
+	case number := <-ready:
+		if "" == number {
+			vd.Stop()
+			return nil, errs.New(codes.ChromeNoDisplay, fmt.Sprintf("Xvfb exited without opening display '%s'", display))
+		}
+		vd.Display = ":" + number
+		return vd, nil
+	case <-time.After(xvfbTimeout):
+		vd.Stop()
+		return nil, errs.New(codes.ChromeNoDisplay, fmt.Sprintf("Xvfb did not start within %s", xvfbTimeout))
+	}
+}
+
+/*
+EnsureDisplay returns the display in the DISPLAY environment variable if it's
+set, and otherwise starts Xvfb on the first free display. Stopping an existing
+display does nothing.
+*/
+func EnsureDisplay() (*VirtualDisplay, error) {
+	if display := os.Getenv("DISPLAY"); "" != display {
+		return &VirtualDisplay{Display: display}, nil
+	}
+	return StartXvfb("")
+}
+
+/*
+Stop stops the Xvfb server, if it was started by StartXvfb, and waits for it
+to exit.
+*/
+func (vd *VirtualDisplay) Stop() error {
+	if nil == vd.cmd {
+		return nil
+	}
+	cmd := vd.cmd
+	vd.cmd = nil
+	// The server may already have exited.
+	cmd.Process.Kill()
+	<-vd.exited
+	return nil
+}
+
+/*
+Xvfb returns a display provider that starts an Xvfb server with StartXvfb for
+WithDisplay.
+*/
+func Xvfb(display string, args ...string) DisplayProvider {
+	return func() (string, func() error, error) {
+		vd, err := StartXvfb(display, args...)
+		if nil != err {
+			return "", nil, err
+		}
+		return vd.Display, vd.Stop, nil
+	}
+}
+
+/*
+WithVirtualDisplay launches a headful browser, on a virtual display started
+with Xvfb if no display is available. This is needed on servers to test
+features unavailable in headless mode, such as some extensions and WebGL
+paths:
+
+	browser := chrome.New(chrome.WithVirtualDisplay())
+
+The display is stopped by Close. Xvfb must be installed, for example from the
+xvfb package on Debian and Ubuntu.
+*/
+func WithVirtualDisplay() Option {
+	return func(chrome *Chrome) {
+		WithHeadlessMode(Headful)(chrome)
+		WithDisplay(Xvfb(""))(chrome)
+	}
+}
@@ -0,0 +1,85 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+newFakeXvfb puts an Xvfb script in the PATH that records its arguments.
+*/
+func newFakeXvfb(t *testing.T, dir, script string) string {
+	args := filepath.Join(dir, "args")
+	xvfb := "#!/bin/sh\necho \"$@\" > " + args + "\n" + script
+	if err := ioutil.WriteFile(filepath.Join(dir, "Xvfb"), []byte(xvfb), 0700); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return args
+}
+
+func TestStartXvfb(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "xvfb")
+	defer os.RemoveAll(dir)
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	args := newFakeXvfb(t, dir, "echo 42 >&3\nexec sleep 30\n")
+
+	display, err := StartXvfb("")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if ":42" != display.Display {
+		t.Errorf("Expected ':42', got '%s'", display.Display)
+	}
+	if data, _ := ioutil.ReadFile(args); "-displayfd 3 -screen 0 1920x1080x24 -nolisten tcp" != strings.TrimSpace(string(data)) {
+		t.Errorf("Unexpected arguments '%s'", data)
+	}
+	if err := display.Stop(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+
+	if display, err = StartXvfb(":42", "-screen", "0", "800x600x16"); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer display.Stop()
+	if data, _ := ioutil.ReadFile(args); ":42 -displayfd 3 -screen 0 800x600x16" != strings.TrimSpace(string(data)) {
+		t.Errorf("Unexpected arguments '%s'", data)
+	}
+}
+
+func TestStartXvfbFailure(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "xvfb")
+	defer os.RemoveAll(dir)
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	newFakeXvfb(t, dir, "echo 'Server is already active for display 99' >&2\nexit 1\n")
+
+	if _, err := StartXvfb(":99"); nil == err {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestEnsureDisplay(t *testing.T) {
+	defer os.Setenv("DISPLAY", os.Getenv("DISPLAY"))
+	os.Setenv("DISPLAY", ":7")
+
+	display, err := EnsureDisplay()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if ":7" != display.Display {
+		t.Errorf("Expected ':7', got '%s'", display.Display)
+	}
+	if err := display.Stop(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+}
+
+func TestWithVirtualDisplay(t *testing.T) {
+	chrome := New(WithVirtualDisplay())
+	if Headful != chrome.headlessMode || nil == chrome.displayProvider {
+		t.Errorf("Expected a headful browser with a display provider")
+	}
+}
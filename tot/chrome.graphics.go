@@ -0,0 +1,90 @@
+package chrome
+
+import (
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+swiftShaderFlags are the flags that render WebGL with SwiftShader, Chrome's CPU
+implementation of Vulkan, instead of the GPU. Flags that a Chrome version
+doesn't recognize are ignored, so flags for older and newer versions are set
+together.
+*/
+var swiftShaderFlags = map[string]interface{}{
+	"enable-unsafe-swiftshader": nil,
+	"ignore-gpu-blocklist":      nil,
+	"use-angle":                 "swiftshader",
+	"use-gl":                    "angle",
+}
+
+/*
+WithGraphicsFallback probes WebGL in a new tab after the browser launches and,
+if it doesn't work, relaunches the browser with SwiftShader software rendering
+and probes again. The report of the final probe is passed to the callback, so
+the chosen mode can be logged or exported:
+
+	browser := chrome.New(chrome.WithGraphicsFallback(func(report *chrome.GraphicsReport) {
+		log.Printf("rendering WebGL with %s (%s)", report.Mode, report.Renderer)
+	}))
+
+This avoids blank canvases in screenshot services on hosts where the GPU is
+missing or blocklisted. Software rendering is much slower than a working GPU,
+so the browser is only relaunched when WebGL is unavailable. The callback may
+be nil.
+*/
+func WithGraphicsFallback(report func(report *GraphicsReport)) Option {
+	return func(chrome *Chrome) {
+		chrome.graphicsFallback = true
+		chrome.graphicsReport = report
+	}
+}
+
+/*
+probeGraphics runs the graphics probe after launch and relaunches the browser
+with SwiftShader if WebGL doesn't work. Probe failures are logged and don't
+fail the launch.
+*/
+func (chrome *Chrome) probeGraphics() error {
+	if !chrome.graphicsFallback {
+		return nil
+	}
+	report, err := chrome.runGraphicsProbe()
+	if nil != err {
+		chrome.logger.Warn("could not probe the graphics capabilities", logger.Fields{"error": err.Error()})
+		return nil
+	}
+
+	if GraphicsUnavailable == report.Mode && !chrome.graphicsRelaunched {
+		chrome.logger.Warn("WebGL is unavailable, relaunching with SwiftShader", logger.Fields{"error": report.Error, "renderer": report.Renderer})
+		chrome.graphicsRelaunched = true
+		if err := chrome.close(); nil != err {
+			chrome.logger.Warn("could not close the browser", logger.Fields{"error": err.Error()})
+		}
+		for flag, value := range swiftShaderFlags {
+			chrome.Flags().Set(flag, value)
+		}
+		chrome.version = nil
+		chrome.tabs = nil
+		// Launch probes again and reports the result.
+		return chrome.launch()
+	}
+
+	report.Fallback = chrome.graphicsRelaunched
+	chrome.logger.Info("Graphics probed", logger.Fields{"fallback": report.Fallback, "mode": report.Mode, "renderer": report.Renderer})
+	if nil != chrome.graphicsReport {
+		chrome.graphicsReport(report)
+	}
+	return nil
+}
+
+/*
+runGraphicsProbe probes the graphics capabilities in a temporary tab.
+*/
+func (chrome *Chrome) runGraphicsProbe() (*GraphicsReport, error) {
+	tab, err := chrome.NewTab("about:blank")
+	if nil != err {
+		return nil, err
+	}
+	defer tab.Close()
+	return tab.ProbeGraphics()
+}
@@ -0,0 +1,45 @@
+package chrome
+
+import (
+	"testing"
+)
+
+func TestProbeGraphics(t *testing.T) {
+	browser, stop := newEvaluateBrowser(t, `{"webgl": true, "renderer": "SwiftShader"}`)
+	defer stop()
+	if err := browser.probeGraphics(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+
+	var report *GraphicsReport
+	WithGraphicsFallback(func(r *GraphicsReport) { report = r })(browser)
+	browser.graphicsRelaunched = true
+	if err := browser.probeGraphics(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if nil == report {
+		t.Fatalf("Expected a report, received nil")
+	}
+	if GraphicsSoftware != report.Mode || !report.Fallback {
+		t.Errorf("Expected a software fallback report, received %+v", report)
+	}
+	if 0 != len(browser.tabs) {
+		t.Errorf("Expected the probe tab to be closed, %d tabs remain", len(browser.tabs))
+	}
+}
+
+func TestProbeGraphicsUnavailable(t *testing.T) {
+	// The relaunch has already happened, so an unavailable renderer is
+	// reported instead of relaunching again.
+	browser, stop := newEvaluateBrowser(t, `{"webgl": false, "error": "WebGL drew nothing"}`)
+	defer stop()
+	var report *GraphicsReport
+	WithGraphicsFallback(func(r *GraphicsReport) { report = r })(browser)
+	browser.graphicsRelaunched = true
+	if err := browser.probeGraphics(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if nil == report || GraphicsUnavailable != report.Mode || "WebGL drew nothing" != report.Error {
+		t.Errorf("Expected an unavailable report, received %+v", report)
+	}
+}
@@ -0,0 +1,240 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+HeadlessMode is the mode the browser is launched in.
+*/
+type HeadlessMode string
+
+/*
+Headless modes. The new headless mode runs the full browser without a window
+and behaves like a headful browser: extensions, screenshots and layout match.
+The old headless mode is a separate, lighter implementation with its own
+rendering differences. It was removed from the Chrome binary in version 132
+and is now only available as the chrome-headless-shell binary.
+*/
+const (
+	HeadlessNew HeadlessMode = "new"
+	HeadlessOld HeadlessMode = "old"
+	Headful     HeadlessMode = "headful"
+)
+
+/*
+newHeadlessVersion is the first version supporting the new headless mode, and
+oldHeadlessRemoved the first version without the old headless mode.
+*/
+const (
+	newHeadlessVersion = 109
+	oldHeadlessRemoved = 132
+)
+
+/*
+HeadlessSupport is the headless modes a browser binary supports.
+*/
+type HeadlessSupport struct {
+	// The version string printed by the binary, for example
+	// 'Google Chrome 120.0.6099.109'.
+	Version string
+
+	// The major version.
+	Major int
+
+	// Whether the binary is chrome-headless-shell, which only runs in the old
+	// headless mode.
+	Shell bool
+
+	// Whether the new headless mode is supported.
+	New bool
+
+	// Whether the old headless mode is supported.
+	Old bool
+}
+
+/*
+versionPattern matches the version number printed by a browser binary.
+*/
+var versionPattern = regexp.MustCompile(`(\d+)\.\d+\.\d+\.\d+`)
+
+/*
+DetectHeadless runs a browser binary with the version flag to detect the
+headless modes it supports.
+*/
+func DetectHeadless(binary string) (*HeadlessSupport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, binary, "--version").Output()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeHeadlessUnsupported, fmt.Sprintf("could not read the version of '%s'", binary))
+	}
+	return parseHeadlessSupport(binary, string(output))
+}
+
+/*
+parseHeadlessSupport derives the supported headless modes from the version
+printed by a binary.
+*/
+func parseHeadlessSupport(binary, version string) (*HeadlessSupport, error) {
+	match := versionPattern.FindStringSubmatch(version)
+	if nil == match {
+		return nil, errs.New(codes.ChromeHeadlessUnsupported, fmt.Sprintf("could not parse the version of '%s': %q", binary, version))
+	}
+	support := &HeadlessSupport{Version: strings.TrimSpace(version)}
+	support.Major, _ = strconv.Atoi(match[1])
+	support.Shell = strings.Contains(filepath.Base(binary), "headless-shell") ||
+		strings.Contains(strings.ToLower(version), "headless")
+	if support.Shell {
+		support.Old = true
+		return support, nil
+	}
+	support.New = support.Major >= newHeadlessVersion
+	support.Old = support.Major < oldHeadlessRemoved
+	return support, nil
+}
+
+/*
+WithHeadlessMode launches the browser in the new or old headless mode, or with
+a window. The modes the binary supports are detected at launch, so a mode the
+binary doesn't support fails with a clear error instead of a browser that
+silently behaves differently:
+
+	browser := chrome.New(chrome.WithHeadlessMode(chrome.HeadlessNew))
+
+A headful browser on Linux needs a display. If neither DISPLAY nor
+WAYLAND_DISPLAY is set and no display flag is passed, the display set by
+WithDisplay is started, for example an Xvfb server.
+
+Without WithHeadlessMode the headless flag is passed to the binary as it's set.
+*/
+func WithHeadlessMode(mode HeadlessMode) Option {
+	return func(chrome *Chrome) {
+		chrome.headlessMode = mode
+	}
+}
+
+/*
+DisplayProvider starts a display for a headful browser and returns its DISPLAY
+value and a function that stops it.
+*/
+type DisplayProvider func() (display string, stop func() error, err error)
+
+/*
+WithDisplay sets the display started for a headful browser on Linux when no
+display is available. The display is stopped by Close:
+
+	browser := chrome.New(
+		chrome.WithHeadlessMode(chrome.Headful),
+		chrome.WithDisplay(chrome.Xvfb(":99", "-screen", "0", "1280x720x24")),
+	)
+*/
+func WithDisplay(provider DisplayProvider) Option {
+	return func(chrome *Chrome) {
+		chrome.displayProvider = provider
+	}
+}
+
+/*
+applyHeadlessMode sets the headless flag for the headless mode, if any, and
+starts a display for a headful browser if needed.
+*/
+func (chrome *Chrome) applyHeadlessMode() error {
+	if "" == chrome.headlessMode {
+		return nil
+	}
+
+	support, err := DetectHeadless(chrome.Binary())
+	if nil != err {
+		// Assume the mode is supported rather than refuse to launch a
+		// binary that doesn't print its version.
+		chrome.logger.Warn("could not detect the supported headless modes", logger.Fields{"error": err.Error()})
+		support = &HeadlessSupport{New: true, Old: true}
+	}
+
+	switch chrome.headlessMode {
+	case HeadlessNew:
+		if !support.New {
+			return errs.New(codes.ChromeHeadlessUnsupported, fmt.Sprintf("'%s' does not support the new headless mode, which requires Chrome %d or later", support.Version, newHeadlessVersion))
+		}
+		chrome.Flags().Set("headless", "new")
+	case HeadlessOld:
+		if !support.Old {
+			return errs.New(codes.ChromeHeadlessUnsupported, fmt.Sprintf("'%s' does not support the old headless mode, which was removed in Chrome %d, use chrome-headless-shell instead", support.Version, oldHeadlessRemoved))
+		}
+		if support.Shell {
+			chrome.Flags().Set("headless", nil)
+		} else {
+			chrome.Flags().Set("headless", "old")
+		}
+	case Headful:
+		removeFlag(chrome.Flags(), "headless")
+		return chrome.startDisplay()
+	default:
+		return errs.New(codes.ChromeHeadlessUnsupported, fmt.Sprintf("unknown headless mode '%s'", chrome.headlessMode))
+	}
+	return nil
+}
+
+/*
+startDisplay starts the display for a headful browser on Linux when no display
+is available.
+*/
+func (chrome *Chrome) startDisplay() error {
+	if "linux" != runtime.GOOS ||
+		chrome.Flags().Has("display") ||
+		"" != os.Getenv("DISPLAY") ||
+		"" != os.Getenv("WAYLAND_DISPLAY") {
+		return nil
+	}
+	if nil == chrome.displayProvider {
+		return errs.New(codes.ChromeNoDisplay, "no display is available for a headful browser: set DISPLAY, run under xvfb-run or pass WithVirtualDisplay()")
+	}
+	display, stop, err := chrome.displayProvider()
+	if nil != err {
+		return errs.Wrap(err, codes.ChromeNoDisplay, "could not start a display for a headful browser")
+	}
+	chrome.displayStop = stop
+	chrome.Flags().Set("display", display)
+	return nil
+}
+
+/*
+stopDisplay stops the display started for the browser, if any.
+*/
+func (chrome *Chrome) stopDisplay() error {
+	if nil == chrome.displayStop {
+		return nil
+	}
+	stop := chrome.displayStop
+	chrome.displayStop = nil
+	if err := stop(); nil != err {
+		return errs.Wrap(err, codes.ChromeNoDisplay, "could not stop the display")
+	}
+	return nil
+}
+
+/*
+removeFlag removes a flag from a flag set.
+*/
+func removeFlag(flags ChromiumFlags, name string) {
+	switch flags := flags.(type) {
+	case *Flags:
+		delete(*flags, name)
+	case Flags:
+		delete(flags, name)
+	}
+}
@@ -0,0 +1,115 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestParseHeadlessSupport(t *testing.T) {
+	tests := []struct {
+		binary  string
+		version string
+		major   int
+		shell   bool
+		new     bool
+		old     bool
+	}{
+		{"/usr/bin/google-chrome", "Google Chrome 100.0.4896.127\n", 100, false, false, true},
+		{"/usr/bin/google-chrome", "Google Chrome 120.0.6099.109\n", 120, false, true, true},
+		{"/usr/bin/chromium", "Chromium 132.0.6834.83 snap\n", 132, false, true, false},
+		{"/opt/chrome-headless-shell", "Google Chrome for Testing 133.0.6943.53\n", 133, true, false, true},
+	}
+	for _, test := range tests {
+		support, err := parseHeadlessSupport(test.binary, test.version)
+		if nil != err {
+			t.Fatalf("Expected nil, got error: %v", err)
+		}
+		if test.major != support.Major || test.shell != support.Shell || test.new != support.New || test.old != support.Old {
+			t.Errorf("Unexpected support for %q: %+v", test.version, support)
+		}
+	}
+	if _, err := parseHeadlessSupport("/bin/true", "unknown"); nil == err {
+		t.Errorf("Expected an error for an unknown version")
+	}
+}
+
+func TestWithHeadlessMode(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "headless")
+	defer os.RemoveAll(dir)
+	binary := newFakeBrowser(t, dir, `echo "Google Chrome 120.0.6099.109"`)
+
+	chrome := New(WithBinary(binary), WithHeadlessMode(HeadlessOld))
+	if err := chrome.applyHeadlessMode(); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if value, _ := chrome.Flags().Get("headless"); "old" != value {
+		t.Errorf("Expected headless=old, got %v", value)
+	}
+
+	chrome = New(WithBinary(binary), WithHeadlessMode(HeadlessNew))
+	if err := chrome.applyHeadlessMode(); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if value, _ := chrome.Flags().Get("headless"); "new" != value {
+		t.Errorf("Expected headless=new, got %v", value)
+	}
+
+	binary = newFakeBrowser(t, dir, `echo "Google Chrome 133.0.6943.53"`)
+	chrome = New(WithBinary(binary), WithHeadlessMode(HeadlessOld))
+	if err := chrome.applyHeadlessMode(); nil == err {
+		t.Errorf("Expected an error, the old headless mode was removed")
+	}
+
+	chrome = New(WithBinary(binary), WithHeadlessMode("sideways"))
+	if err := chrome.applyHeadlessMode(); nil == err {
+		t.Errorf("Expected an error for an unknown mode")
+	}
+
+	// A binary that doesn't print its version is assumed to support the mode.
+	chrome = New(WithBinary("/nonexistent/chrome"), WithHeadlessMode(HeadlessNew))
+	if err := chrome.applyHeadlessMode(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+}
+
+func TestHeadful(t *testing.T) {
+	if "linux" != runtime.GOOS {
+		t.Skip("Displays are only started on Linux")
+	}
+	defer os.Setenv("DISPLAY", os.Getenv("DISPLAY"))
+	defer os.Setenv("WAYLAND_DISPLAY", os.Getenv("WAYLAND_DISPLAY"))
+	os.Unsetenv("DISPLAY")
+	os.Unsetenv("WAYLAND_DISPLAY")
+
+	dir, _ := ioutil.TempDir("", "headless")
+	defer os.RemoveAll(dir)
+	binary := newFakeBrowser(t, dir, `echo "Google Chrome 120.0.6099.109"`)
+
+	chrome := New(WithBinary(binary), WithHeadlessMode(Headful))
+	chrome.Flags().Set("headless", nil)
+	if err := chrome.applyHeadlessMode(); nil == err {
+		t.Errorf("Expected an error without a display")
+	}
+	if chrome.Flags().Has("headless") {
+		t.Errorf("Expected the headless flag to be removed")
+	}
+
+	stopped := false
+	chrome = New(WithBinary(binary), WithHeadlessMode(Headful), WithDisplay(func() (string, func() error, error) {
+		return ":42", func() error {
+			stopped = true
+			return nil
+		}, nil
+	}))
+	if err := chrome.applyHeadlessMode(); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if value, _ := chrome.Flags().Get("display"); ":42" != value {
+		t.Errorf("Expected display=:42, got %v", value)
+	}
+	if err := chrome.Close(); nil != err || !stopped {
+		t.Errorf("Expected Close to stop the display, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+userDataMarker is the file that marks a user data directory as created by this
+package. It records the process that created the directory so the directory
+can be cleaned up if that process dies without removing it.
+*/
+const userDataMarker = ".go-chrome"
+
+/*
+userDataPrefix is the name prefix of the temporary user data directories
+created by this package.
+*/
+const userDataPrefix = "chrome-user-data-"
+
+/*
+userDataOwner is the content of a user data marker.
+*/
+type userDataOwner struct {
+	PID     int       `json:"pid"`
+	Created time.Time `json:"created"`
+}
+
+/*
+newUserDataDir creates a temporary user data directory and marks it as owned by
+the current process.
+*/
+func newUserDataDir() (string, error) {
+	dir, err := ioutil.TempDir("", userDataPrefix)
+	if nil != err {
+		return "", err
+	}
+	data, _ := json.Marshal(userDataOwner{PID: os.Getpid(), Created: time.Now()})
+	if err := ioutil.WriteFile(filepath.Join(dir, userDataMarker), data, 0600); nil != err {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+/*
+Janitor cleans up after browsers that weren't closed: the temporary user data
+directories left behind when the process that created them died, and browser
+processes still running from them. On a busy worker that's restarted often
+these otherwise leak disk space and PIDs until the host is rebooted.
+
+Only directories created by this package, which are marked, are considered. A
+directory is stale when the process that created it is no longer running.
+*/
+type Janitor struct {
+	// Optional. The directory searched for stale user data directories.
+	// Default is os.TempDir().
+	TempDir string
+
+	// Optional. How long to wait for killed browser processes to exit before
+	// their user data directories are removed. Default is 5 seconds.
+	Timeout time.Duration
+}
+
+/*
+Cleanup is the result of a Janitor run.
+*/
+type Cleanup struct {
+	// The IDs of the orphaned browser processes that were killed.
+	Processes []int
+
+	// The stale user data directories that were removed.
+	Dirs []string
+}
+
+/*
+Clean kills the browser processes running from stale user data directories
+and removes the directories:
+
+	cleanup, err := (&chrome.Janitor{}).Clean()
+	if nil != err {
+		...
+	}
+	log.Printf("removed %d directories, killed %d processes", len(cleanup.Dirs), len(cleanup.Processes))
+
+Clean carries on past failures and returns everything it cleaned up along with
+the errors it encountered. Orphaned processes are only found on Linux, on other
+platforms only the directories are removed.
+*/
+func (janitor *Janitor) Clean() (*Cleanup, error) {
+	cleanup := &Cleanup{}
+	var err error
+	fail := func(e error, msg string) {
+		if nil == err {
+			err = errs.Wrap(e, codes.ChromeCleanupFailed, msg)
+		} else {
+			err = err.(errs.Err).With(e, msg)
+		}
+	}
+
+	tempDir := janitor.TempDir
+	if "" == tempDir {
+		tempDir = os.TempDir()
+	}
+	dirs, e := staleUserData(tempDir)
+	if nil != e {
+		fail(e, fmt.Sprintf("could not list user data directories in '%s'", tempDir))
+	}
+	if 0 == len(dirs) {
+		return cleanup, err
+	}
+
+	pids, e := orphanedProcesses(dirs)
+	if nil != e {
+		fail(e, "could not list orphaned processes")
+	}
+	for _, pid := range pids {
+		process, e := os.FindProcess(pid)
+		if nil == e {
+			e = process.Kill()
+		}
+		if nil != e {
+			fail(e, fmt.Sprintf("could not kill orphaned process %d", pid))
+			continue
+		}
+		cleanup.Processes = append(cleanup.Processes, pid)
+	}
+
+	timeout := janitor.Timeout
+	if 0 == timeout {
+		timeout = 5 * time.Second
+	}
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(50 * time.Millisecond) {
+		if alive, _ := orphanedProcesses(dirs); 0 == len(alive) {
+			break
+		}
+	}
+
+	for _, dir := range dirs {
+		if e := os.RemoveAll(dir); nil != e {
+			fail(e, fmt.Sprintf("could not remove user data directory '%s'", dir))
+			continue
+		}
+		cleanup.Dirs = append(cleanup.Dirs, dir)
+	}
+	return cleanup, err
+}
+
+/*
+staleUserData returns the marked user data directories in a directory whose
+owner is no longer running.
+*/
+func staleUserData(tempDir string) ([]string, error) {
+	dirs, err := filepath.Glob(filepath.Join(tempDir, userDataPrefix+"*"))
+	if nil != err {
+		return nil, err
+	}
+	stale := []string{}
+	for _, dir := range dirs {
+		data, err := ioutil.ReadFile(filepath.Join(dir, userDataMarker))
+		if nil != err {
+			// Not created by this package.
+			continue
+		}
+		owner := userDataOwner{}
+		if err := json.Unmarshal(data, &owner); nil != err || owner.PID <= 0 {
+			continue
+		}
+		if os.Getpid() == owner.PID || processRunning(owner.PID) {
+			continue
+		}
+		stale = append(stale, dir)
+	}
+	return stale, nil
+}
+
+/*
+processRunning returns whether a process exists. A process that exists but
+can't be signaled by this user is running.
+*/
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if nil != err {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return nil == err || syscall.EPERM == err
+}
+
+/*
+WithJanitor runs a janitor each time the browser is launched, before it
+starts, so a worker that crashed cleans up after itself when it's restarted:
+
+	browser := chrome.New(chrome.WithJanitor(&chrome.Janitor{}))
+
+Cleanup failures are logged and don't prevent the launch.
+*/
+func WithJanitor(janitor *Janitor) Option {
+	return func(chrome *Chrome) {
+		chrome.janitor = janitor
+	}
+}
+
+/*
+clean runs the janitor, if any.
+*/
+func (chrome *Chrome) clean() {
+	if nil == chrome.janitor {
+		return
+	}
+	cleanup, err := chrome.janitor.Clean()
+	if nil != err {
+		chrome.logger.Warn("could not clean up stale user data", logger.Fields{"error": err.Error()})
+	}
+	if len(cleanup.Dirs) > 0 || len(cleanup.Processes) > 0 {
+		chrome.logger.Info("Cleaned up stale user data", logger.Fields{
+			"dirs":      cleanup.Dirs,
+			"processes": cleanup.Processes,
+		})
+	}
+}
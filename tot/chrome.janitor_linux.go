@@ -0,0 +1,58 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+)
+
+/*
+orphanedProcesses returns the processes running with one of the user data
+directories, read from /proc. Chrome passes the user-data-dir flag on to its
+child processes, so the whole process tree is found.
+*/
+func orphanedProcesses(dirs []string) ([]int, error) {
+	flags := make([][]byte, 0, len(dirs))
+	for _, dir := range dirs {
+		flags = append(flags, []byte("--user-data-dir="+dir))
+	}
+
+	procs, err := filepath.Glob("/proc/[0-9]*")
+	if nil != err {
+		return nil, err
+	}
+	pids := []int{}
+	for _, proc := range procs {
+		cmdline, err := ioutil.ReadFile(filepath.Join(proc, "cmdline"))
+		if nil != err {
+			// The process exited while the list was read.
+			continue
+		}
+		for _, arg := range bytes.Split(cmdline, []byte{0}) {
+			if !matchesFlag(arg, flags) {
+				continue
+			}
+			if pid, err := strconv.Atoi(filepath.Base(proc)); nil == err {
+				pids = append(pids, pid)
+			}
+			break
+		}
+	}
+	return pids, nil
+}
+
+/*
+matchesFlag returns whether an argument is one of the flags.
+*/
+func matchesFlag(arg []byte, flags [][]byte) bool {
+	for _, flag := range flags {
+		if bytes.Equal(arg, flag) {
+			return true
+		}
+	}
+	return false
+}
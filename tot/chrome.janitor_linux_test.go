@@ -0,0 +1,57 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestJanitorKillsOrphans(t *testing.T) {
+	tempDir, _ := ioutil.TempDir("", "janitor")
+	defer os.RemoveAll(tempDir)
+	stale := newMarkedDir(t, tempDir, exitedPID(t))
+
+	// The test binary stays running with the flag in its command line, like
+	// an orphaned browser. It doesn't fork, so it's the only match.
+	cmd := exec.Command(os.Args[0], "-test.run=TestJanitorOrphanProcess", "--", "--user-data-dir="+stale)
+	cmd.Env = append(os.Environ(), "GO_CHROME_JANITOR_ORPHAN=1")
+	if err := cmd.Start(); nil != err {
+		t.Skipf("Could not start a process: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	cleanup, err := (&Janitor{TempDir: tempDir}).Clean()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 1 != len(cleanup.Processes) || cmd.Process.Pid != cleanup.Processes[0] {
+		t.Errorf("Expected process %d to be killed, got %v", cmd.Process.Pid, cleanup.Processes)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		cmd.Process.Kill()
+		t.Errorf("Expected the orphaned process to exit")
+	}
+	if 1 != len(cleanup.Dirs) {
+		t.Errorf("Expected the stale directory to be removed, got %v", cleanup.Dirs)
+	}
+}
+
+/*
+TestJanitorOrphanProcess isn't a test, it's the orphaned process started by
+TestJanitorKillsOrphans.
+*/
+func TestJanitorOrphanProcess(t *testing.T) {
+	if "1" != os.Getenv("GO_CHROME_JANITOR_ORPHAN") {
+		return
+	}
+	time.Sleep(30 * time.Second)
+	os.Exit(0)
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package chrome
+
+/*
+orphanedProcesses returns no processes, processes are only listed on Linux.
+*/
+func orphanedProcesses(dirs []string) ([]int, error) {
+	return nil, nil
+}
@@ -0,0 +1,97 @@
+package chrome
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*
+exitedPID returns the ID of a process that has exited.
+*/
+func exitedPID(t *testing.T) int {
+	cmd := exec.Command("true")
+	if err := cmd.Run(); nil != err {
+		t.Skipf("Could not start a process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+/*
+newMarkedDir creates a user data directory in tempDir owned by pid.
+*/
+func newMarkedDir(t *testing.T, tempDir string, pid int) string {
+	dir, err := ioutil.TempDir(tempDir, userDataPrefix)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	data, _ := json.Marshal(userDataOwner{PID: pid, Created: time.Now()})
+	ioutil.WriteFile(filepath.Join(dir, userDataMarker), data, 0600)
+	return dir
+}
+
+func TestNewUserDataDir(t *testing.T) {
+	dir, err := newUserDataDir()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	owner := userDataOwner{}
+	data, _ := ioutil.ReadFile(filepath.Join(dir, userDataMarker))
+	if err := json.Unmarshal(data, &owner); nil != err || os.Getpid() != owner.PID {
+		t.Errorf("Expected the directory to be owned by this process, got %s %v", data, err)
+	}
+}
+
+func TestJanitorClean(t *testing.T) {
+	tempDir, _ := ioutil.TempDir("", "janitor")
+	defer os.RemoveAll(tempDir)
+
+	stale := newMarkedDir(t, tempDir, exitedPID(t))
+	live := newMarkedDir(t, tempDir, os.Getpid())
+	unmarked := filepath.Join(tempDir, userDataPrefix+"unmarked")
+	os.Mkdir(unmarked, 0700)
+
+	cleanup, err := (&Janitor{TempDir: tempDir}).Clean()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 1 != len(cleanup.Dirs) || stale != cleanup.Dirs[0] {
+		t.Errorf("Expected the stale directory to be removed, got %v", cleanup.Dirs)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected '%s' to be removed", stale)
+	}
+	for _, dir := range []string{live, unmarked} {
+		if _, err := os.Stat(dir); nil != err {
+			t.Errorf("Expected '%s' to be kept, got error: %v", dir, err)
+		}
+	}
+
+	cleanup, err = (&Janitor{TempDir: tempDir}).Clean()
+	if nil != err || 0 != len(cleanup.Dirs) || 0 != len(cleanup.Processes) {
+		t.Errorf("Expected nothing to clean up, got %+v %v", cleanup, err)
+	}
+}
+
+func TestCloneUserDataMarker(t *testing.T) {
+	template := newUserDataTemplate(t)
+	defer os.RemoveAll(template)
+	ioutil.WriteFile(filepath.Join(template, userDataMarker), []byte(`{"pid":1}`), 0600)
+
+	dir, err := CloneUserData(template)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	owner := userDataOwner{}
+	data, _ := ioutil.ReadFile(filepath.Join(dir, userDataMarker))
+	if err := json.Unmarshal(data, &owner); nil != err || os.Getpid() != owner.PID {
+		t.Errorf("Expected the copy to be owned by this process, got %s %v", data, err)
+	}
+}
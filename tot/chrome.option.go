@@ -0,0 +1,240 @@
+package chrome
+
+import (
+	"crypto/tls"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+	"golang.org/x/crypto/ssh"
+)
+
+/*
+Option defines a functional option for configuring a Chrome instance. Options
+are applied by New in the order they are passed, after any environment
+defaults.
+*/
+type Option func(chrome *Chrome)
+
+/*
+Logger is the logging interface used by a Chrome instance, its tabs and their
+sockets. The logger package provides adapters for common logging libraries.
+*/
+type Logger interface {
+	logger.Logger
+}
+
+/*
+Environment variables used for default configuration.
+*/
+const (
+	// EnvPath is the name of the environment variable containing the path to
+	// the Chromium binary.
+	EnvPath = "CHROME_PATH"
+
+	// EnvChannel is the name of the environment variable containing the
+	// release channels the Chromium binary is discovered for, in order of
+	// preference, for example 'beta,stable'. See WithChannel.
+	EnvChannel = "CHROME_CHANNEL"
+
+	// EnvWebSocketURL is the name of the environment variable containing the
+	// developer tools URL of an already running Chromium instance, for example
+	// 'ws://localhost:9222/devtools/browser/<id>' or 'http://localhost:9222'.
+	// The host and port are used as the address and port of the instance. A
+	// 'unix:///path/to/devtools.sock' URL connects over a unix domain socket.
+	EnvWebSocketURL = "CHROME_WS_URL"
+)
+
+/*
+applyEnv applies the environment variable defaults to the settings the options
+left unset.
+*/
+func applyEnv(chrome *Chrome) {
+	if path := os.Getenv(EnvPath); "" != path && "" == chrome.binary {
+		WithBinary(path)(chrome)
+	}
+	if list := os.Getenv(EnvChannel); "" != list && 0 == len(chrome.channels) {
+		channels, err := parseChannels(list)
+		if nil != err {
+			chrome.logger.Warn("ignoring invalid "+EnvChannel, logger.Fields{"error": err, "value": list})
+		} else {
+			WithChannel(channels...)(chrome)
+		}
+	}
+	if wsURL := os.Getenv(EnvWebSocketURL); "" != wsURL && "" == chrome.unixSocket {
+		devtoolsURL, err := url.Parse(wsURL)
+		if nil == err && "unix" == devtoolsURL.Scheme && "" != devtoolsURL.Path {
+			if !chrome.Flags().Has("addr") && !chrome.Flags().Has("port") {
+				WithUnixSocket(devtoolsURL.Path)(chrome)
+			}
+			return
+		}
+		if nil != err || "" == devtoolsURL.Host {
+			chrome.logger.Warn("ignoring invalid "+EnvWebSocketURL, logger.Fields{"error": err, "value": wsURL})
+			return
+		}
+		host, port, err := net.SplitHostPort(devtoolsURL.Host)
+		if nil != err {
+			host = devtoolsURL.Host
+		}
+		if !chrome.Flags().Has("addr") {
+			WithAddress(host)(chrome)
+		}
+		if portNum, err := strconv.Atoi(port); nil == err && !chrome.Flags().Has("port") {
+			WithPort(portNum)(chrome)
+		}
+	}
+}
+
+/*
+WithAddress sets the address used to access the developer tools endpoints.
+Defaults to 'localhost'.
+*/
+func WithAddress(address string) Option {
+	return func(chrome *Chrome) {
+		chrome.Flags().Set("addr", address)
+	}
+}
+
+/*
+WithBinary sets the path to the Chromium binary. Defaults to the CHROME_PATH
+environment variable or '/usr/bin/google-chrome'.
+*/
+func WithBinary(binary string) Option {
+	return func(chrome *Chrome) {
+		chrome.binary = binary
+	}
+}
+
+/*
+WithFlags sets the CLI arguments for the Chromium binary. The flag set is
+replaced, so WithFlags should be passed before options that set individual
+flags such as WithAddress and WithPort. The address and port of the
+CHROME_WS_URL environment variable are added unless the flags set them.
+*/
+func WithFlags(flags ChromiumFlags) Option {
+	return func(chrome *Chrome) {
+		chrome.flags = flags
+	}
+}
+
+/*
+WithLogger sets the logger used by the Chrome instance, its tabs and their
+sockets. Defaults to logger.Default(), which writes to the bdlm/log standard
+logger unless it's replaced with logger.SetDefault.
+*/
+func WithLogger(log Logger) Option {
+	return func(chrome *Chrome) {
+		chrome.logger = log
+	}
+}
+
+/*
+WithNonBlockingCommands makes sending commands from the tabs' event handlers
+safe, see socket.WithNonBlockingCommands.
+*/
+func WithNonBlockingCommands() Option {
+	return func(chrome *Chrome) {
+		chrome.nonBlocking = true
+	}
+}
+
+/*
+WithOrderedEvents delivers the events of each protocol domain to the tabs'
+handlers in the order they were received, see socket.WithOrderedEvents.
+*/
+func WithOrderedEvents() Option {
+	return func(chrome *Chrome) {
+		chrome.orderedEvents = true
+	}
+}
+
+/*
+WithPort sets the port the developer tools endpoints listen on, both for
+querying the endpoints and for the remote-debugging-port flag used when
+launching Chromium. Defaults to 9222.
+*/
+func WithPort(port int) Option {
+	return func(chrome *Chrome) {
+		chrome.Flags().Set("port", port)
+		chrome.Flags().Set("remote-debugging-port", port)
+	}
+}
+
+/*
+WithStderr sets the path to a file to capture STDERR output. Defaults to the
+system STDERR.
+*/
+func WithStderr(stderr string) Option {
+	return func(chrome *Chrome) {
+		chrome.stderr = stderr
+	}
+}
+
+/*
+WithStdout sets the path to a file to capture STDOUT output. Defaults to the
+system STDOUT.
+*/
+func WithStdout(stdout string) Option {
+	return func(chrome *Chrome) {
+		chrome.stdout = stdout
+	}
+}
+
+/*
+WithSSHTunnel reaches the developer tools endpoints through an SSH connection,
+see SSHTunnel. The address and port are resolved on the remote machine.
+*/
+func WithSSHTunnel(client *ssh.Client) Option {
+	return func(chrome *Chrome) {
+		chrome.sshClient = client
+	}
+}
+
+/*
+WithTLSConfig connects to the developer tools endpoints over TLS, for an
+endpoint fronted by a TLS-terminating reverse proxy. Queries use https:// and
+tab sockets use wss://, with the configuration's CA bundle, client
+certificates and verification settings. See socket.WithTLSConfig.
+*/
+func WithTLSConfig(config *tls.Config) Option {
+	return func(chrome *Chrome) {
+		chrome.tlsConfig = config
+	}
+}
+
+/*
+WithUnixSocket connects to developer tools endpoints served on a unix domain
+socket instead of a TCP port, for sandboxed environments where TCP ports are
+forbidden. Queries are sent over the socket and tab sockets connect to
+unix:// URLs, see socket.New. The address, port and TLS configuration are
+ignored.
+*/
+func WithUnixSocket(path string) Option {
+	return func(chrome *Chrome) {
+		chrome.unixSocket = path
+	}
+}
+
+/*
+WithTimeout sets the maximum time to wait for Chromium to start and for
+developer tools endpoint queries to complete. Defaults to 10 seconds.
+*/
+func WithTimeout(timeout time.Duration) Option {
+	return func(chrome *Chrome) {
+		chrome.timeout = timeout
+	}
+}
+
+/*
+WithWorkdir sets the path to the Chromium working directory. Defaults to
+'/tmp/headless-chrome'.
+*/
+func WithWorkdir(workdir string) Option {
+	return func(chrome *Chrome) {
+		chrome.workdir = workdir
+	}
+}
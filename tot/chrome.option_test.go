@@ -0,0 +1,181 @@
+package chrome
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+type testLogger struct {
+	calls int
+}
+
+func (log *testLogger) Debug(msg string, fields logger.Fields) { log.calls++ }
+func (log *testLogger) Info(msg string, fields logger.Fields)  { log.calls++ }
+func (log *testLogger) Warn(msg string, fields logger.Fields)  { log.calls++ }
+func (log *testLogger) Error(msg string, fields logger.Fields) { log.calls++ }
+
+func TestChromiumOptions(t *testing.T) {
+	logger := &testLogger{}
+	chrome := New(
+		WithFlags(&Flags{"headless": nil}),
+		WithAddress("127.0.0.1"),
+		WithBinary("/path/to/chrome"),
+		WithLogger(logger),
+		WithPort(9333),
+		WithStderr("/path/to/stderr"),
+		WithStdout("/path/to/stdout"),
+		WithTimeout(time.Second),
+		WithWorkdir("/path/to/workdir"),
+	)
+	if !chrome.Flags().Has("headless") {
+		t.Errorf("Expected the headless flag to be set")
+	}
+	if "127.0.0.1" != chrome.Address() {
+		t.Errorf("Expected '127.0.0.1', received '%s'", chrome.Address())
+	}
+	if "/path/to/chrome" != chrome.Binary() {
+		t.Errorf("Expected '/path/to/chrome', received '%s'", chrome.Binary())
+	}
+	if 9333 != chrome.Port() || 9333 != chrome.DebuggingPort() {
+		t.Errorf("Expected 9333, received %d and %d", chrome.Port(), chrome.DebuggingPort())
+	}
+	if "/path/to/stderr" != chrome.STDERR() {
+		t.Errorf("Expected '/path/to/stderr', received '%s'", chrome.STDERR())
+	}
+	if "/path/to/stdout" != chrome.STDOUT() {
+		t.Errorf("Expected '/path/to/stdout', received '%s'", chrome.STDOUT())
+	}
+	if time.Second != chrome.timeout {
+		t.Errorf("Expected 1s, received %s", chrome.timeout)
+	}
+	if "/path/to/workdir" != chrome.Workdir() {
+		t.Errorf("Expected '/path/to/workdir', received '%s'", chrome.Workdir())
+	}
+
+	if chrome.orderedEvents {
+		t.Errorf("Expected events to be dispatched concurrently by default")
+	}
+	websocketURL, _ := url.Parse("ws://127.0.0.1:9333/devtools/page/1")
+	unordered := len(chrome.socketOptions(websocketURL))
+	WithOrderedEvents()(chrome)
+	if !chrome.orderedEvents || unordered+1 != len(chrome.socketOptions(websocketURL)) {
+		t.Errorf("Expected the ordered events socket option to be set")
+	}
+	WithNonBlockingCommands()(chrome)
+	if !chrome.nonBlocking || unordered+2 != len(chrome.socketOptions(websocketURL)) {
+		t.Errorf("Expected the non-blocking commands socket option to be set")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Browser": "HeadlessChrome"}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	chrome = New(WithAddress(serverURL.Hostname()), WithPort(port), WithLogger(logger))
+	if _, err := chrome.Version(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if 0 == logger.calls {
+		t.Errorf("Expected the custom logger to be used")
+	}
+}
+
+func TestChromiumEnv(t *testing.T) {
+	defer os.Unsetenv(EnvPath)
+	defer os.Unsetenv(EnvWebSocketURL)
+
+	os.Setenv(EnvPath, "/env/chrome")
+	os.Setenv(EnvWebSocketURL, "ws://10.0.0.1:9333/devtools/browser/1234")
+	chrome := New()
+	if "/env/chrome" != chrome.Binary() {
+		t.Errorf("Expected '/env/chrome', received '%s'", chrome.Binary())
+	}
+	if "10.0.0.1" != chrome.Address() {
+		t.Errorf("Expected '10.0.0.1', received '%s'", chrome.Address())
+	}
+	if 9333 != chrome.Port() {
+		t.Errorf("Expected 9333, received %d", chrome.Port())
+	}
+
+	// Options take precedence over the environment.
+	chrome = New(WithBinary("/option/chrome"), WithPort(9444))
+	if "/option/chrome" != chrome.Binary() {
+		t.Errorf("Expected '/option/chrome', received '%s'", chrome.Binary())
+	}
+	if 9444 != chrome.Port() {
+		t.Errorf("Expected 9444, received %d", chrome.Port())
+	}
+
+	// Flags replace the flag set without dropping the environment defaults.
+	flags := &Flags{}
+	flags.Set("headless", nil)
+	chrome = New(WithFlags(flags))
+	if "10.0.0.1" != chrome.Address() {
+		t.Errorf("Expected '10.0.0.1', received '%s'", chrome.Address())
+	}
+	if 9333 != chrome.Port() {
+		t.Errorf("Expected 9333, received %d", chrome.Port())
+	}
+	if !chrome.Flags().Has("headless") {
+		t.Errorf("Expected the flags to be kept, received '%s'", chrome.Flags().String())
+	}
+	flags = &Flags{}
+	flags.Set("addr", "flags-host")
+	if chrome = New(WithFlags(flags)); "flags-host" != chrome.Address() || 9333 != chrome.Port() {
+		t.Errorf("Expected 'flags-host:9333', received '%s:%d'", chrome.Address(), chrome.Port())
+	}
+
+	os.Setenv(EnvWebSocketURL, "http://devtools-host")
+	if chrome = New(); "devtools-host" != chrome.Address() {
+		t.Errorf("Expected 'devtools-host', received '%s'", chrome.Address())
+	}
+
+	os.Setenv(EnvWebSocketURL, "not a url")
+	if chrome = New(); "localhost" != chrome.Address() {
+		t.Errorf("Expected 'localhost', received '%s'", chrome.Address())
+	}
+}
+
+func TestChromiumTLS(t *testing.T) {
+	cdp := cdptest.NewTLSServer(nil)
+	defer cdp.Close()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/json/new") {
+			w.Write([]byte(`{"id": "1", "webSocketDebuggerUrl": "ws://` + cdp.URL().Host + `/devtools/page/cdptest"}`))
+			return
+		}
+		w.Write([]byte(`{"Browser": "HeadlessChrome"}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	chrome := New(WithAddress(serverURL.Hostname()), WithPort(port))
+	if _, err := chrome.Version(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	// httptest servers share a certificate, so the configuration trusts both.
+	chrome = New(WithAddress(serverURL.Hostname()), WithPort(port), WithTLSConfig(cdp.TLSConfig()))
+	if _, err := chrome.Version(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	tab, err := chrome.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+	if err := cdp.WaitForConnection(5 * time.Second); nil != err {
+		t.Errorf("Expected the tab to connect over wss://, received error: %v", err)
+	}
+}
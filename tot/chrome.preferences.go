@@ -0,0 +1,421 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+Content setting values used by the Preferences and Policies builders.
+*/
+const (
+	contentSettingAllow = 1
+	contentSettingBlock = 2
+)
+
+/*
+DefaultPolicyDir is the directory Google Chrome reads managed policies from on
+Linux. Chromium reads them from '/etc/chromium/policies/managed'.
+*/
+const DefaultPolicyDir = "/etc/opt/chrome/policies/managed"
+
+/*
+Preferences builds the Preferences file of the default profile. Preferences
+are written before the browser starts, so they are in effect from the first
+page load instead of being toggled at runtime:
+
+	prefs := chrome.NewPreferences().
+		DownloadDirectory("/srv/downloads").
+		Popups(false).
+		PasswordManager(false)
+	browser := chrome.New(chrome.WithPreferences(prefs))
+
+Preference names are dotted paths into the Preferences JSON, for example
+'download.default_directory'. Preferences that don't have a builder method can
+be set with Set.
+*/
+type Preferences struct {
+	values map[string]interface{}
+}
+
+/*
+NewPreferences returns an empty Preferences builder.
+*/
+func NewPreferences() *Preferences {
+	return &Preferences{values: make(map[string]interface{})}
+}
+
+/*
+Set sets a preference by its dotted name.
+*/
+func (prefs *Preferences) Set(name string, value interface{}) *Preferences {
+	prefs.values[name] = value
+	return prefs
+}
+
+/*
+Get returns the value of a preference, and whether it has been set.
+*/
+func (prefs *Preferences) Get(name string) (interface{}, bool) {
+	value, ok := prefs.values[name]
+	return value, ok
+}
+
+/*
+Autofill enables or disables address and credit card autofill.
+*/
+func (prefs *Preferences) Autofill(enabled bool) *Preferences {
+	return prefs.
+		Set("autofill.profile_enabled", enabled).
+		Set("autofill.credit_card_enabled", enabled)
+}
+
+/*
+DownloadDirectory saves downloads to a directory without prompting for a
+location.
+*/
+func (prefs *Preferences) DownloadDirectory(dir string) *Preferences {
+	return prefs.
+		Set("download.default_directory", dir).
+		Set("download.directory_upgrade", true).
+		Set("download.prompt_for_download", false)
+}
+
+/*
+Notifications allows or blocks notification permission requests.
+*/
+func (prefs *Preferences) Notifications(allow bool) *Preferences {
+	return prefs.Set("profile.default_content_setting_values.notifications", contentSetting(allow))
+}
+
+/*
+PasswordManager enables or disables the password manager and its save password
+prompts.
+*/
+func (prefs *Preferences) PasswordManager(enabled bool) *Preferences {
+	return prefs.
+		Set("credentials_enable_service", enabled).
+		Set("profile.password_manager_enabled", enabled)
+}
+
+/*
+Popups allows or blocks popup windows.
+*/
+func (prefs *Preferences) Popups(allow bool) *Preferences {
+	return prefs.Set("profile.default_content_setting_values.popups", contentSetting(allow))
+}
+
+/*
+PromptForDownload enables or disables prompting for a download location.
+*/
+func (prefs *Preferences) PromptForDownload(prompt bool) *Preferences {
+	return prefs.Set("download.prompt_for_download", prompt)
+}
+
+/*
+Translate enables or disables the translate bar.
+*/
+func (prefs *Preferences) Translate(enabled bool) *Preferences {
+	return prefs.Set("translate.enabled", enabled)
+}
+
+/*
+MarshalJSON implements json.Marshaler. The dotted names are expanded into
+nested objects.
+*/
+func (prefs *Preferences) MarshalJSON() ([]byte, error) {
+	doc := make(map[string]interface{})
+	if err := prefs.merge(doc); nil != err {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+/*
+Write writes the preferences into the Preferences file of the default profile
+of a user data directory. Preferences already in the file, for example from a
+user data template, are kept unless they are overwritten.
+*/
+func (prefs *Preferences) Write(userDataDir string) error {
+	profile := filepath.Join(userDataDir, "Default")
+	file := filepath.Join(profile, "Preferences")
+
+	doc := make(map[string]interface{})
+	data, err := ioutil.ReadFile(file)
+	if nil != err && !os.IsNotExist(err) {
+		return errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not read preferences '%s'", file))
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &doc); nil != err {
+			return errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("invalid preferences '%s'", file))
+		}
+	}
+	if err := prefs.merge(doc); nil != err {
+		return err
+	}
+
+	data, err = json.Marshal(doc)
+	if nil != err {
+		return errs.Wrap(err, codes.ChromePreferencesFailed, "could not encode preferences")
+	}
+	if err := os.MkdirAll(profile, 0700); nil != err {
+		return errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not create profile directory '%s'", profile))
+	}
+	if err := ioutil.WriteFile(file, data, 0600); nil != err {
+		return errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not write preferences '%s'", file))
+	}
+	return nil
+}
+
+/*
+merge sets the preferences in a decoded Preferences document, creating the
+intermediate objects of the dotted names. Names are applied in order so the
+result doesn't depend on map iteration.
+*/
+func (prefs *Preferences) merge(doc map[string]interface{}) error {
+	names := make([]string, 0, len(prefs.values))
+	for name := range prefs.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := strings.Split(name, ".")
+		parent := doc
+		for _, key := range path[:len(path)-1] {
+			child, ok := parent[key]
+			if !ok {
+				child = make(map[string]interface{})
+				parent[key] = child
+			}
+			obj, ok := child.(map[string]interface{})
+			if !ok {
+				return errs.New(codes.ChromePreferencesFailed, fmt.Sprintf("preference '%s' conflicts with a value at '%s'", name, key))
+			}
+			parent = obj
+		}
+		parent[path[len(path)-1]] = prefs.values[name]
+	}
+	return nil
+}
+
+/*
+Policies builds a managed policy file. Policies are enforced by the browser
+and can't be changed by pages or the user, which makes them more reliable
+than preferences for settings like the password manager:
+
+	policies := chrome.NewPolicies().
+		PasswordManager(false).
+		Popups(false)
+	browser := chrome.New(chrome.WithPolicies("", policies))
+
+Policies that don't have a builder method can be set by name with Set. See
+https://chromeenterprise.google/policies/ for the available policies.
+*/
+type Policies struct {
+	values map[string]interface{}
+}
+
+/*
+NewPolicies returns an empty Policies builder.
+*/
+func NewPolicies() *Policies {
+	return &Policies{values: make(map[string]interface{})}
+}
+
+/*
+Set sets a policy by name.
+*/
+func (policies *Policies) Set(name string, value interface{}) *Policies {
+	policies.values[name] = value
+	return policies
+}
+
+/*
+Get returns the value of a policy, and whether it has been set.
+*/
+func (policies *Policies) Get(name string) (interface{}, bool) {
+	value, ok := policies.values[name]
+	return value, ok
+}
+
+/*
+Autofill enables or disables address and credit card autofill.
+*/
+func (policies *Policies) Autofill(enabled bool) *Policies {
+	return policies.
+		Set("AutofillAddressEnabled", enabled).
+		Set("AutofillCreditCardEnabled", enabled)
+}
+
+/*
+DownloadDirectory saves downloads to a directory without prompting for a
+location.
+*/
+func (policies *Policies) DownloadDirectory(dir string) *Policies {
+	return policies.
+		Set("DownloadDirectory", dir).
+		Set("PromptForDownloadLocation", false)
+}
+
+/*
+Notifications allows or blocks notification permission requests.
+*/
+func (policies *Policies) Notifications(allow bool) *Policies {
+	return policies.Set("DefaultNotificationsSetting", contentSetting(allow))
+}
+
+/*
+PasswordManager enables or disables the password manager.
+*/
+func (policies *Policies) PasswordManager(enabled bool) *Policies {
+	return policies.Set("PasswordManagerEnabled", enabled)
+}
+
+/*
+Popups allows or blocks popup windows.
+*/
+func (policies *Policies) Popups(allow bool) *Policies {
+	return policies.Set("DefaultPopupsSetting", contentSetting(allow))
+}
+
+/*
+PromptForDownload enables or disables prompting for a download location.
+*/
+func (policies *Policies) PromptForDownload(prompt bool) *Policies {
+	return policies.Set("PromptForDownloadLocation", prompt)
+}
+
+/*
+Translate enables or disables the translate bar.
+*/
+func (policies *Policies) Translate(enabled bool) *Policies {
+	return policies.Set("TranslateEnabled", enabled)
+}
+
+/*
+MarshalJSON implements json.Marshaler.
+*/
+func (policies *Policies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policies.values)
+}
+
+/*
+Write writes the policies to a new file in a policy directory and returns its
+path. The browser reads every JSON file in the directory, so the caller is
+responsible for removing the file.
+*/
+func (policies *Policies) Write(dir string) (string, error) {
+	data, err := json.Marshal(policies)
+	if nil != err {
+		return "", errs.Wrap(err, codes.ChromePreferencesFailed, "could not encode policies")
+	}
+	if err := os.MkdirAll(dir, 0755); nil != err {
+		return "", errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not create policy directory '%s'", dir))
+	}
+	file := filepath.Join(dir, fmt.Sprintf("go-chrome-%d-%d.json", os.Getpid(), time.Now().UnixNano()))
+	if err := ioutil.WriteFile(file, data, 0644); nil != err {
+		return "", errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not write policies '%s'", file))
+	}
+	return file, nil
+}
+
+/*
+contentSetting returns the content setting value for allowing or blocking.
+*/
+func contentSetting(allow bool) int {
+	if allow {
+		return contentSettingAllow
+	}
+	return contentSettingBlock
+}
+
+/*
+WithPreferences writes preferences into the default profile of the user data
+directory before the browser starts. If no user data directory is configured
+a temporary one is created by Launch and removed by Close. Preferences passed
+more than once are merged, later values replacing earlier ones.
+*/
+func WithPreferences(prefs *Preferences) Option {
+	return func(chrome *Chrome) {
+		if nil == chrome.preferences {
+			chrome.preferences = NewPreferences()
+		}
+		for name, value := range prefs.values {
+			chrome.preferences.Set(name, value)
+		}
+	}
+}
+
+/*
+WithPolicies writes a managed policy file into a policy directory,
+DefaultPolicyDir if empty, before the browser starts. The file is removed by
+Close, or by Launch if the browser fails to start.
+
+Managed policies apply to every browser started from the same installation
+while the file exists, and the default policy directory usually requires root
+to write.
+*/
+func WithPolicies(dir string, policies *Policies) Option {
+	return func(chrome *Chrome) {
+		if "" == dir {
+			dir = DefaultPolicyDir
+		}
+		chrome.policies = policies
+		chrome.policyDir = dir
+	}
+}
+
+/*
+writePreferences writes the preferences and policies, if any.
+*/
+func (chrome *Chrome) writePreferences() error {
+	if nil != chrome.preferences {
+		value, _ := chrome.Flags().Get("user-data-dir")
+		dir, _ := value.(string)
+		if "" == dir {
+			var err error
+			if dir, err = newUserDataDir(); nil != err {
+				return errs.Wrap(err, codes.ChromePreferencesFailed, "could not create user data directory")
+			}
+			chrome.userDataDir = dir
+			chrome.Flags().Set("user-data-dir", dir)
+		}
+		if err := chrome.preferences.Write(dir); nil != err {
+			return err
+		}
+	}
+
+	if nil != chrome.policies {
+		file, err := chrome.policies.Write(chrome.policyDir)
+		if nil != err {
+			return err
+		}
+		chrome.policyFile = file
+	}
+	return nil
+}
+
+/*
+removePolicies removes the policy file, if any.
+*/
+func (chrome *Chrome) removePolicies() error {
+	if "" == chrome.policyFile {
+		return nil
+	}
+	file := chrome.policyFile
+	chrome.policyFile = ""
+	if err := os.Remove(file); nil != err && !os.IsNotExist(err) {
+		return errs.Wrap(err, codes.ChromePreferencesFailed, fmt.Sprintf("could not remove policies '%s'", file))
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package chrome
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readJSON(t *testing.T, file string) map[string]interface{} {
+	data, err := ioutil.ReadFile(file)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	doc := make(map[string]interface{})
+	if err := json.Unmarshal(data, &doc); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	return doc
+}
+
+func TestPreferences(t *testing.T) {
+	prefs := NewPreferences().
+		DownloadDirectory("/srv/downloads").
+		Popups(false).
+		PasswordManager(false)
+	if value, ok := prefs.Get("profile.default_content_setting_values.popups"); !ok || contentSettingBlock != value {
+		t.Errorf("Expected popups to be blocked, got %v", value)
+	}
+
+	data, err := json.Marshal(prefs)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	expected := `{"credentials_enable_service":false,"download":{"default_directory":"/srv/downloads","directory_upgrade":true,"prompt_for_download":false},"profile":{"default_content_setting_values":{"popups":2},"password_manager_enabled":false}}`
+	if expected != string(data) {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	if _, err := json.Marshal(NewPreferences().Set("download", true).Set("download.prompt_for_download", false)); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestPreferencesWrite(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "user-data")
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "Default"), 0700)
+	ioutil.WriteFile(filepath.Join(dir, "Default", "Preferences"), []byte(`{"download":{"prompt_for_download":true},"session":{"restore_on_startup":1}}`), 0600)
+
+	if err := NewPreferences().DownloadDirectory("/srv/downloads").Write(dir); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	doc := readJSON(t, filepath.Join(dir, "Default", "Preferences"))
+	download := doc["download"].(map[string]interface{})
+	if false != download["prompt_for_download"] || "/srv/downloads" != download["default_directory"] {
+		t.Errorf("Expected the download preferences to be set, got %v", download)
+	}
+	if _, ok := doc["session"]; !ok {
+		t.Errorf("Expected existing preferences to be kept, got %v", doc)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Default", "Preferences"), []byte(`not json`), 0600)
+	if err := NewPreferences().Write(dir); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "policies")
+	defer os.RemoveAll(dir)
+
+	file, err := NewPolicies().
+		PasswordManager(false).
+		Popups(true).
+		DownloadDirectory("/srv/downloads").
+		Write(dir)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if dir != filepath.Dir(file) || ".json" != filepath.Ext(file) {
+		t.Errorf("Expected a JSON file in %s, got %s", dir, file)
+	}
+	doc := readJSON(t, file)
+	if false != doc["PasswordManagerEnabled"] ||
+		float64(contentSettingAllow) != doc["DefaultPopupsSetting"] ||
+		"/srv/downloads" != doc["DownloadDirectory"] ||
+		false != doc["PromptForDownloadLocation"] {
+		t.Errorf("Unexpected policies %v", doc)
+	}
+}
+
+func TestWithPreferences(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+	policyDir, _ := ioutil.TempDir("", "policies")
+	defer os.RemoveAll(policyDir)
+
+	chrome := New(
+		WithFlags(&Flags{}),
+		WithBinary("/does/not/exist"),
+		WithPreferences(NewPreferences().PasswordManager(false)),
+		WithPolicies(policyDir, NewPolicies().PasswordManager(false)),
+		WithWorkdir(workdir),
+	)
+	if err := chrome.Launch(); nil == err {
+		t.Fatalf("Expected error, got nil")
+	}
+	dir, _ := chrome.Flags().Get("user-data-dir")
+	if "" == dir {
+		t.Fatalf("Expected a temporary user data directory")
+	}
+	if _, err := os.Stat(dir.(string)); !os.IsNotExist(err) {
+		t.Errorf("Expected the user data directory to be removed when the launch fails")
+	}
+	if files, _ := ioutil.ReadDir(policyDir); 0 != len(files) {
+		t.Errorf("Expected the policies to be removed when the launch fails, got %d files", len(files))
+	}
+
+	chrome = New(
+		WithFlags(&Flags{}),
+		WithPreferences(NewPreferences().PasswordManager(false)),
+		WithPolicies(policyDir, NewPolicies().PasswordManager(false)),
+	)
+	if err := chrome.writePreferences(); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	dir, _ = chrome.Flags().Get("user-data-dir")
+	doc := readJSON(t, filepath.Join(dir.(string), "Default", "Preferences"))
+	if false != doc["credentials_enable_service"] {
+		t.Errorf("Expected the preferences to be written, got %v", doc)
+	}
+	if files, _ := ioutil.ReadDir(policyDir); 1 != len(files) {
+		t.Errorf("Expected the policies to be written, got %d files", len(files))
+	}
+	if err := chrome.Close(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if _, err := os.Stat(dir.(string)); !os.IsNotExist(err) {
+		t.Errorf("Expected the user data directory to be removed when the browser is closed")
+	}
+	if files, _ := ioutil.ReadDir(policyDir); 0 != len(files) {
+		t.Errorf("Expected the policies to be removed when the browser is closed, got %d files", len(files))
+	}
+}
@@ -0,0 +1,94 @@
+package chrome
+
+import (
+	"strings"
+)
+
+/*
+promptFlags are the flags that suppress first-run, default browser, sign-in and
+keychain prompts. Flags that a Chrome version doesn't recognize are ignored, so
+flags for older and newer versions are set together.
+*/
+var promptFlags = []string{
+	"disable-default-apps",
+	"disable-search-engine-choice-screen",
+	"disable-sync",
+	"disable-translate",
+	"hide-crash-restore-bubble",
+	"no-default-browser-check",
+	"no-first-run",
+	"no-service-autorun",
+	"use-mock-keychain",
+}
+
+/*
+promptFeatures are the features disabled to suppress the translate bar, the
+privacy sandbox dialog, the what's new page and the local network permission
+prompt of the media router. Translate replaced TranslateUI in newer versions.
+*/
+var promptFeatures = []string{
+	"ChromeWhatsNewUI",
+	"MediaRouter",
+	"PrivacySandboxSettings4",
+	"Translate",
+	"TranslateUI",
+}
+
+/*
+WithPromptsSuppressed suppresses the dialogs, bars and bubbles that get in the
+way of automation: first-run dialogs, default browser prompts, the translate
+bar, sync and sign-in prompts, the session restore bubble and the password
+manager. The flags are set at launch and the rest is set in the preferences of
+the default profile, so the bundle works across Chrome versions where the flags
+alone don't:
+
+	browser := chrome.New(chrome.WithPromptsSuppressed())
+
+Any features already passed in the disable-features flag are kept. Preferences
+passed with WithPreferences are merged with the bundle's preferences.
+*/
+func WithPromptsSuppressed() Option {
+	return func(chrome *Chrome) {
+		for _, flag := range promptFlags {
+			chrome.Flags().Set(flag, nil)
+		}
+		disableFeatures(chrome.Flags(), promptFeatures...)
+
+		WithPreferences(NewPreferences().
+			PasswordManager(false).
+			Translate(false).
+			Set("browser.check_default_browser", false).
+			Set("browser.has_seen_welcome_page", true).
+			Set("profile.exit_type", "Normal").
+			Set("profile.exited_cleanly", true).
+			Set("signin.allowed", false).
+			Set("sync_promo.show_on_first_run_allowed", false),
+		)(chrome)
+	}
+}
+
+/*
+disableFeatures adds features to the disable-features flag, keeping the
+features already in it.
+*/
+func disableFeatures(flags ChromiumFlags, features ...string) {
+	disabled := []string{}
+	if value, err := flags.Get("disable-features"); nil == err {
+		if list, ok := value.(string); ok && "" != list {
+			disabled = strings.Split(list, ",")
+		}
+	}
+	for _, feature := range features {
+		found := false
+		for _, existing := range disabled {
+			if feature == existing {
+				found = true
+				break
+			}
+		}
+		if !found {
+			disabled = append(disabled, feature)
+		}
+	}
+	flags.Set("disable-features", strings.Join(disabled, ","))
+}
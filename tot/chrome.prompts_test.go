@@ -0,0 +1,36 @@
+package chrome
+
+import (
+	"testing"
+)
+
+func TestWithPromptsSuppressed(t *testing.T) {
+	flags := &Flags{}
+	flags.Set("disable-features", "Translate,SitePerProcess")
+	chrome := New(
+		WithFlags(flags),
+		WithPreferences(NewPreferences().PasswordManager(true)),
+		WithPromptsSuppressed(),
+		WithPreferences(NewPreferences().Translate(true)),
+	)
+
+	for _, flag := range []string{"no-first-run", "no-default-browser-check", "disable-sync"} {
+		if !chrome.Flags().Has(flag) {
+			t.Errorf("Expected the %s flag to be set", flag)
+		}
+	}
+	expected := "Translate,SitePerProcess,ChromeWhatsNewUI,MediaRouter,PrivacySandboxSettings4,TranslateUI"
+	if value, _ := chrome.Flags().Get("disable-features"); expected != value {
+		t.Errorf("Expected '%s', got '%v'", expected, value)
+	}
+
+	if value, _ := chrome.preferences.Get("signin.allowed"); false != value {
+		t.Errorf("Expected sign-in to be disabled, got %v", value)
+	}
+	if value, _ := chrome.preferences.Get("credentials_enable_service"); false != value {
+		t.Errorf("Expected the bundle to replace earlier preferences, got %v", value)
+	}
+	if value, _ := chrome.preferences.Get("translate.enabled"); true != value {
+		t.Errorf("Expected later preferences to replace the bundle, got %v", value)
+	}
+}
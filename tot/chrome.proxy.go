@@ -0,0 +1,290 @@
+package chrome
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+Proxy is a proxy server browsers and browser contexts can be routed through.
+*/
+type Proxy struct {
+	// The proxy server, in the format accepted by --proxy-server, for
+	// example 'http://us-east.proxy.example.com:3128' or
+	// 'socks5://10.0.0.1:1080'.
+	Server string
+
+	// Optional. The region the proxy exits in, for selecting proxies by
+	// location.
+	Region string
+
+	// Optional. Hosts that bypass the proxy, in the format accepted by
+	// --proxy-bypass-list.
+	Bypass string
+}
+
+/*
+ProxyProvider provides the proxies to rotate through. Proxies is called each
+time a proxy is selected, so providers backed by a service can add and remove
+proxies while the rotator is in use.
+*/
+type ProxyProvider interface {
+	Proxies() ([]*Proxy, error)
+}
+
+/*
+ProxyList is a static ProxyProvider.
+*/
+type ProxyList []*Proxy
+
+/*
+Proxies implements ProxyProvider.
+*/
+func (list ProxyList) Proxies() ([]*Proxy, error) {
+	return list, nil
+}
+
+/*
+ProxyHealth is the health of a proxy.
+*/
+type ProxyHealth struct {
+	// The proxy.
+	Proxy *Proxy
+
+	// The number of consecutive failures.
+	Failures int
+
+	// The number of successes.
+	Successes int
+
+	// The most recent failure, if any.
+	LastError error
+
+	// The proxy isn't selected until this time.
+	ExcludedUntil time.Time
+}
+
+/*
+Excluded returns whether the proxy is currently excluded from rotation.
+*/
+func (health *ProxyHealth) Excluded() bool {
+	return time.Now().Before(health.ExcludedUntil)
+}
+
+/*
+ProxyRotator hands out proxies in rotation and tracks their health. Each
+browser or browser context acquired from the rotator gets the next proxy:
+
+	rotator := chrome.NewProxyRotator(chrome.ProxyList{
+		{Server: "http://us.proxy.example.com:3128", Region: "us"},
+		{Server: "http://eu.proxy.example.com:3128", Region: "eu"},
+	}, 3, 5*time.Minute)
+	browser := chrome.New(chrome.WithProxyRotation(rotator, "eu"))
+
+A proxy that fails maxFailures times in a row is excluded from rotation for
+the cooldown period. After the cooldown it's selected again, and excluded again
+on its next failure unless it succeeds first.
+*/
+type ProxyRotator struct {
+	cooldown    time.Duration
+	health      map[string]*ProxyHealth
+	maxFailures int
+	mux         *sync.Mutex
+	next        int
+	provider    ProxyProvider
+}
+
+/*
+NewProxyRotator returns a rotator for the proxies of a provider. maxFailures
+defaults to 3 and cooldown defaults to 5 minutes.
+*/
+func NewProxyRotator(provider ProxyProvider, maxFailures int, cooldown time.Duration) *ProxyRotator {
+	if maxFailures <= 0 {
+		maxFailures = 3
+	}
+	if cooldown <= 0 {
+		cooldown = 5 * time.Minute
+	}
+	return &ProxyRotator{
+		cooldown:    cooldown,
+		health:      make(map[string]*ProxyHealth),
+		maxFailures: maxFailures,
+		mux:         &sync.Mutex{},
+		provider:    provider,
+	}
+}
+
+/*
+Next returns the next healthy proxy. If region isn't empty only proxies in
+that region are selected.
+*/
+func (rotator *ProxyRotator) Next(region string) (*Proxy, error) {
+	proxies, err := rotator.provider.Proxies()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeProxyUnavailable, "could not list proxies")
+	}
+
+	rotator.mux.Lock()
+	defer rotator.mux.Unlock()
+	for a := 0; a < len(proxies); a++ {
+		index := (rotator.next + a) % len(proxies)
+		proxy := proxies[index]
+		if "" != region && region != proxy.Region {
+			continue
+		}
+		if health, ok := rotator.health[proxy.Server]; ok && health.Excluded() {
+			continue
+		}
+		rotator.next = index + 1
+		return proxy, nil
+	}
+
+	if "" != region {
+		return nil, errs.New(codes.ChromeProxyUnavailable, fmt.Sprintf("no healthy proxy in region '%s'", region))
+	}
+	return nil, errs.New(codes.ChromeProxyUnavailable, "no healthy proxy")
+}
+
+/*
+Succeeded records a successful use of a proxy and resets its failure count.
+*/
+func (rotator *ProxyRotator) Succeeded(proxy *Proxy) {
+	rotator.mux.Lock()
+	defer rotator.mux.Unlock()
+	health := rotator.healthOf(proxy)
+	health.Failures = 0
+	health.Successes++
+}
+
+/*
+Failed records a failed use of a proxy, excluding it from rotation if it has
+failed too many times in a row.
+*/
+func (rotator *ProxyRotator) Failed(proxy *Proxy, err error) {
+	rotator.mux.Lock()
+	defer rotator.mux.Unlock()
+	health := rotator.healthOf(proxy)
+	health.Failures++
+	health.LastError = err
+	if health.Failures >= rotator.maxFailures {
+		health.ExcludedUntil = time.Now().Add(rotator.cooldown)
+		logger.Default().Warn("excluding failing proxy", logger.Fields{"cooldown": rotator.cooldown.String(), "error": err, "failures": health.Failures, "proxy": proxy.Server})
+	}
+}
+
+/*
+Health returns the health of the proxies that have been used, ordered by
+server.
+*/
+func (rotator *ProxyRotator) Health() []*ProxyHealth {
+	rotator.mux.Lock()
+	defer rotator.mux.Unlock()
+	health := make([]*ProxyHealth, 0, len(rotator.health))
+	for _, h := range rotator.health {
+		copied := *h
+		health = append(health, &copied)
+	}
+	sort.Slice(health, func(a, b int) bool {
+		return health[a].Proxy.Server < health[b].Proxy.Server
+	})
+	return health
+}
+
+/*
+healthOf returns the health of a proxy. The rotator must be locked.
+*/
+func (rotator *ProxyRotator) healthOf(proxy *Proxy) *ProxyHealth {
+	health, ok := rotator.health[proxy.Server]
+	if !ok {
+		health = &ProxyHealth{Proxy: proxy}
+		rotator.health[proxy.Server] = health
+	}
+	return health
+}
+
+/*
+NewContext creates a browser context that uses the next proxy. Pages are
+created in the context by passing its ID to Target.createTarget.
+*/
+func (rotator *ProxyRotator) NewContext(targets *socket.TargetProtocol, region string) (target.BrowserContextID, *Proxy, error) {
+	proxy, err := rotator.Next(region)
+	if nil != err {
+		return "", nil, err
+	}
+	result := <-targets.CreateBrowserContext(&target.CreateBrowserContextParams{
+		ProxyServer:     proxy.Server,
+		ProxyBypassList: proxy.Bypass,
+	})
+	if nil != result.Err {
+		return "", nil, result.Err
+	}
+	return result.BrowserContextID, proxy, nil
+}
+
+/*
+Watch tracks the health of a proxy from the network events of a tab or socket
+using it. Requests that fail with a proxy or tunnel error count as failures and
+responses count as successes. The Network domain must be enabled. The returned
+function stops watching.
+*/
+func (rotator *ProxyRotator) Watch(events *socket.NetworkProtocol, proxy *Proxy) func() {
+	failed := events.OnLoadingFailed(func(event *network.LoadingFailedEvent) {
+		if isProxyError(event.ErrorText) {
+			rotator.Failed(proxy, errs.New(codes.ChromeProxyUnavailable, fmt.Sprintf("request %s failed: %s", event.RequestID, event.ErrorText)))
+		}
+	})
+	received := events.OnResponseReceived(func(event *network.ResponseReceivedEvent) {
+		rotator.Succeeded(proxy)
+	})
+	return func() {
+		failed.Remove()
+		received.Remove()
+	}
+}
+
+/*
+isProxyError returns whether a network error was caused by the proxy, such as
+net::ERR_PROXY_CONNECTION_FAILED or net::ERR_TUNNEL_CONNECTION_FAILED.
+*/
+func isProxyError(errorText string) bool {
+	return strings.Contains(errorText, "ERR_PROXY_") ||
+		strings.Contains(errorText, "ERR_TUNNEL_") ||
+		strings.Contains(errorText, "ERR_SOCKS_")
+}
+
+/*
+WithProxyRotation routes the browser through the next proxy from a rotator. If
+region isn't empty only proxies in that region are used. If no proxy is
+available Launch fails with a ChromeProxyUnavailable error.
+*/
+func WithProxyRotation(rotator *ProxyRotator, region string) Option {
+	return func(chrome *Chrome) {
+		proxy, err := rotator.Next(region)
+		if nil != err {
+			chrome.proxyErr = err
+			return
+		}
+		chrome.proxy = proxy
+		chrome.Flags().Set("proxy-server", proxy.Server)
+		if "" != proxy.Bypass {
+			chrome.Flags().Set("proxy-bypass-list", proxy.Bypass)
+		}
+	}
+}
+
+/*
+Proxy returns the proxy the browser was assigned by WithProxyRotation, if any.
+*/
+func (chrome *Chrome) Proxy() *Proxy {
+	return chrome.proxy
+}
@@ -0,0 +1,134 @@
+package chrome
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+type proxyProvider struct {
+	err     error
+	proxies []*Proxy
+}
+
+func (provider *proxyProvider) Proxies() ([]*Proxy, error) {
+	return provider.proxies, provider.err
+}
+
+func TestProxyRotator(t *testing.T) {
+	us := &Proxy{Server: "http://us:3128", Region: "us"}
+	eu1 := &Proxy{Server: "http://eu1:3128", Region: "eu"}
+	eu2 := &Proxy{Server: "http://eu2:3128", Region: "eu"}
+	rotator := NewProxyRotator(ProxyList{us, eu1, eu2}, 2, time.Hour)
+
+	for _, expect := range []*Proxy{us, eu1, eu2, us} {
+		if proxy, err := rotator.Next(""); nil != err || expect != proxy {
+			t.Errorf("Expected %s, got %v %v", expect.Server, proxy, err)
+		}
+	}
+	for _, expect := range []*Proxy{eu1, eu2, eu1} {
+		if proxy, _ := rotator.Next("eu"); expect != proxy {
+			t.Errorf("Expected %s, got %v", expect.Server, proxy)
+		}
+	}
+	if _, err := rotator.Next("ap"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	rotator.Failed(eu1, errors.New("failed"))
+	rotator.Succeeded(eu1)
+	rotator.Failed(eu1, errors.New("failed"))
+	if proxy, _ := rotator.Next("eu"); eu2 != proxy {
+		t.Errorf("Expected a single failure not to exclude the proxy, got %v", proxy)
+	}
+	if proxy, _ := rotator.Next("eu"); eu1 != proxy {
+		t.Errorf("Expected eu1, got %v", proxy)
+	}
+	rotator.Failed(eu1, errors.New("failed again"))
+	for a := 0; a < 3; a++ {
+		if proxy, _ := rotator.Next("eu"); eu2 != proxy {
+			t.Errorf("Expected the failing proxy to be excluded, got %v", proxy)
+		}
+	}
+	rotator.Failed(eu2, errors.New("failed"))
+	rotator.Failed(eu2, errors.New("failed"))
+	if _, err := rotator.Next("eu"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	health := rotator.Health()
+	if 2 != len(health) || eu1 != health[0].Proxy || !health[0].Excluded() || 1 != health[0].Successes {
+		t.Errorf("Expected the health of eu1 and eu2, got %v", health)
+	}
+	if "failed again" != health[0].LastError.Error() {
+		t.Errorf("Expected the last error, got %v", health[0].LastError)
+	}
+
+	if _, err := NewProxyRotator(&proxyProvider{err: errors.New("unavailable")}, 0, 0).Next(""); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestWithProxyRotation(t *testing.T) {
+	rotator := NewProxyRotator(ProxyList{
+		{Server: "socks5://10.0.0.1:1080", Region: "us", Bypass: "localhost"},
+	}, 0, 0)
+	chrome := New(WithFlags(&Flags{}), WithProxyRotation(rotator, "us"))
+	if server, _ := chrome.Flags().Get("proxy-server"); "socks5://10.0.0.1:1080" != server {
+		t.Errorf("Expected the proxy server flag, got %v", server)
+	}
+	if bypass, _ := chrome.Flags().Get("proxy-bypass-list"); "localhost" != bypass {
+		t.Errorf("Expected the proxy bypass flag, got %v", bypass)
+	}
+	if nil == chrome.Proxy() || "us" != chrome.Proxy().Region {
+		t.Errorf("Expected the assigned proxy, got %v", chrome.Proxy())
+	}
+
+	chrome = New(WithFlags(&Flags{}), WithProxyRotation(rotator, "eu"))
+	if nil != chrome.Proxy() || chrome.Flags().Has("proxy-server") {
+		t.Errorf("Expected no proxy to be assigned")
+	}
+	if err := chrome.Launch(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestProxyRotatorContext(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestProxyRotatorContext")
+	rec := newRecordingSocket(tab.URL())
+	rec.target = &socket.TargetProtocol{Socket: rec}
+	rec.results["Target.createBrowserContext"] = `{"browserContextId": "context-1"}`
+
+	proxy := &Proxy{Server: "http://eu:3128", Bypass: "*.internal"}
+	rotator := NewProxyRotator(ProxyList{proxy}, 1, time.Hour)
+	contextID, assigned, err := rotator.NewContext(rec.Target(), "")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "context-1" != contextID || proxy != assigned {
+		t.Errorf("Expected context-1 with the proxy, got %s %v", contextID, assigned)
+	}
+	params := rec.commands[0].Params().(*target.CreateBrowserContextParams)
+	if "http://eu:3128" != params.ProxyServer || "*.internal" != params.ProxyBypassList {
+		t.Errorf("Expected the proxy to be passed to the context, got %v", params)
+	}
+
+	stop := rotator.Watch(rec.Network(), proxy)
+	defer stop()
+	rec.emit("Network.responseReceived", `{"requestId": "1", "response": {"url": "https://example.com/", "status": 200}}`)
+	rec.emit("Network.loadingFailed", `{"requestId": "2", "errorText": "net::ERR_NAME_NOT_RESOLVED"}`)
+	if health := rotator.Health(); 1 != health[0].Successes || 0 != health[0].Failures {
+		t.Errorf("Expected a success and no proxy failures, got %v", health[0])
+	}
+	rec.emit("Network.loadingFailed", `{"requestId": "3", "errorText": "net::ERR_TUNNEL_CONNECTION_FAILED"}`)
+	if health := rotator.Health(); !health[0].Excluded() {
+		t.Errorf("Expected the proxy to be excluded, got %v", health[0])
+	}
+	if _, _, err := rotator.NewContext(rec.Target(), ""); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,349 @@
+package chrome
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+RenderFormat is the output format of a render request.
+*/
+type RenderFormat string
+
+/*
+Render formats.
+*/
+const (
+	// RenderHTML renders the serialized DOM of the page.
+	RenderHTML RenderFormat = "html"
+
+	// RenderPDF prints the page to a PDF.
+	RenderPDF RenderFormat = "pdf"
+
+	// RenderScreenshot captures a screenshot of the page.
+	RenderScreenshot RenderFormat = "screenshot"
+)
+
+/*
+RenderRequest is a request to render a page with WarmPool.Render.
+*/
+type RenderRequest struct {
+	// The URL of the page.
+	URL string
+
+	// The output format.
+	Format RenderFormat
+
+	// Optional. Screenshot options, for the RenderScreenshot format.
+	Screenshot *page.CaptureScreenshotParams
+
+	// Optional. PDF options, for the RenderPDF format.
+	PDF *page.PrintToPDFParams
+
+	// Optional. How the page is loaded. Defaults to a single attempt.
+	Navigation *NavigationPolicy
+
+	// Optional. Renders the page even if it's cached. The result still
+	// replaces the cached one.
+	NoCache bool
+}
+
+/*
+Key returns the cache key of the request, a hash of the URL, the format and the
+output options. The navigation policy isn't part of the key.
+*/
+func (request *RenderRequest) Key() string {
+	data, _ := json.Marshal(struct {
+		URL        string
+		Format     RenderFormat
+		Screenshot *page.CaptureScreenshotParams
+		PDF        *page.PrintToPDFParams
+	}{request.URL, request.Format, request.Screenshot, request.PDF})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+RenderResult is the output of a render request.
+*/
+type RenderResult struct {
+	// The output, the image or PDF bytes or the HTML source.
+	Data []byte
+
+	// Whether the output was served from the cache.
+	Cached bool
+
+	// The attempts made to load the page, nil if the output was cached.
+	Attempts []*NavigationAttempt
+}
+
+/*
+RenderCacheStats are the counters of a RenderCache.
+*/
+type RenderCacheStats struct {
+	// The number of cached outputs.
+	Entries int
+
+	// The combined size of the cached outputs.
+	Bytes int64
+
+	// The number of lookups that found an output.
+	Hits int
+
+	// The number of lookups that didn't find an output.
+	Misses int
+
+	// The number of outputs removed to stay within the size limits.
+	Evictions int
+}
+
+/*
+renderEntry is a cached output.
+*/
+type renderEntry struct {
+	data    []byte
+	expires time.Time
+	key     string
+}
+
+/*
+RenderCache caches the outputs of render requests, keyed by RenderRequest.Key,
+so repeated renders of identical pages skip the browser. Outputs expire after
+the TTL, and the least recently used outputs are evicted when the cache
+exceeds its size limits.
+
+A cache is used by a pool through WarmPolicy.Cache and can be shared by
+several pools:
+
+	cache := chrome.NewRenderCache(10*time.Minute, 256<<20, 0)
+	pool := chrome.NewWarmPool(browser, &chrome.WarmPolicy{Size: 4, Cache: cache})
+
+Cached outputs are shared and must not be modified.
+*/
+type RenderCache struct {
+	entries    map[string]*list.Element
+	lru        *list.List
+	maxBytes   int64
+	maxEntries int
+	mux        *sync.Mutex
+	stats      RenderCacheStats
+	ttl        time.Duration
+}
+
+/*
+NewRenderCache returns a render cache. ttl defaults to 5 minutes and maxBytes
+to 64MB, a maxEntries of 0 doesn't limit the number of outputs.
+*/
+func NewRenderCache(ttl time.Duration, maxBytes int64, maxEntries int) *RenderCache {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	if maxBytes <= 0 {
+		maxBytes = 64 << 20
+	}
+	return &RenderCache{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxBytes:   maxBytes,
+		maxEntries: maxEntries,
+		mux:        &sync.Mutex{},
+		ttl:        ttl,
+	}
+}
+
+/*
+Get returns a cached output and whether it was found.
+*/
+func (cache *RenderCache) Get(key string) ([]byte, bool) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	element, ok := cache.entries[key]
+	if ok && time.Now().After(element.Value.(*renderEntry).expires) {
+		cache.remove(element)
+		ok = false
+	}
+	if !ok {
+		cache.stats.Misses++
+		return nil, false
+	}
+	cache.stats.Hits++
+	cache.lru.MoveToFront(element)
+	return element.Value.(*renderEntry).data, true
+}
+
+/*
+Put caches an output, replacing any output with the same key. Outputs larger
+than the cache aren't cached.
+*/
+func (cache *RenderCache) Put(key string, data []byte) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+	if int64(len(data)) > cache.maxBytes {
+		return
+	}
+	cache.entries[key] = cache.lru.PushFront(&renderEntry{
+		data:    data,
+		expires: time.Now().Add(cache.ttl),
+		key:     key,
+	})
+	cache.stats.Bytes += int64(len(data))
+	for cache.stats.Bytes > cache.maxBytes || (cache.maxEntries > 0 && cache.lru.Len() > cache.maxEntries) {
+		cache.remove(cache.lru.Back())
+		cache.stats.Evictions++
+	}
+}
+
+/*
+Remove removes a cached output.
+*/
+func (cache *RenderCache) Remove(key string) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+}
+
+/*
+Purge removes all cached outputs.
+*/
+func (cache *RenderCache) Purge() {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	cache.entries = make(map[string]*list.Element)
+	cache.lru.Init()
+	cache.stats.Bytes = 0
+}
+
+/*
+Stats returns the counters of the cache.
+*/
+func (cache *RenderCache) Stats() RenderCacheStats {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	stats := cache.stats
+	stats.Entries = cache.lru.Len()
+	return stats
+}
+
+/*
+remove removes an output. Must be called with the mutex locked.
+*/
+func (cache *RenderCache) remove(element *list.Element) {
+	entry := cache.lru.Remove(element).(*renderEntry)
+	delete(cache.entries, entry.key)
+	cache.stats.Bytes -= int64(len(entry.data))
+}
+
+/*
+Render renders a page in a tab of the pool, or returns the cached output if
+the pool has a cache:
+
+	result, err := pool.Render(ctx, &chrome.RenderRequest{
+		URL:    "https://example.com/report",
+		Format: chrome.RenderPDF,
+		PDF:    &page.PrintToPDFParams{PrintBackground: true},
+	})
+	if nil != err {
+		...
+	}
+	ioutil.WriteFile("report.pdf", result.Data, 0644)
+
+The tab is released to the pool when the page is rendered.
+*/
+func (pool *WarmPool) Render(ctx context.Context, request *RenderRequest) (*RenderResult, error) {
+	cache := pool.policy.Cache
+	key := request.Key()
+	if nil != cache && !request.NoCache {
+		if data, ok := cache.Get(key); ok {
+			return &RenderResult{Data: data, Cached: true}, nil
+		}
+	}
+
+	tab, err := pool.Acquire(ctx)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeRenderFailed, fmt.Sprintf("could not render '%s'", request.URL))
+	}
+	defer pool.Release(tab)
+
+	policy := request.Navigation
+	if nil == policy {
+		policy = &NavigationPolicy{MaxAttempts: 1}
+	}
+	navigation, err := tab.NavigateWithRetry(ctx, request.URL, policy)
+	if nil != err {
+		return &RenderResult{Attempts: navigation.Attempts}, errs.Wrap(err, codes.ChromeRenderFailed, fmt.Sprintf("could not render '%s'", request.URL))
+	}
+	if navigation.Tab != tab {
+		defer navigation.Tab.Socket().Stop()
+	}
+
+	data, err := navigation.Tab.render(request)
+	if nil != err {
+		return &RenderResult{Attempts: navigation.Attempts}, errs.Wrap(err, codes.ChromeRenderFailed, fmt.Sprintf("could not render '%s'", request.URL))
+	}
+	if nil != cache {
+		cache.Put(key, data)
+	}
+	return &RenderResult{Data: data, Attempts: navigation.Attempts}, nil
+}
+
+/*
+render renders the loaded page in the format of the request.
+*/
+func (tab *Tab) render(request *RenderRequest) ([]byte, error) {
+	var encoded string
+	switch request.Format {
+	case RenderHTML:
+		var html string
+		if err := tab.Eval("document.documentElement.outerHTML", &html); nil != err {
+			return nil, err
+		}
+		return []byte(html), nil
+
+	case RenderPDF:
+		params := request.PDF
+		if nil == params {
+			params = &page.PrintToPDFParams{}
+		}
+		result := <-tab.Page().PrintToPDF(params)
+		if nil != result.Err {
+			return nil, result.Err
+		}
+		encoded = result.Data
+
+	case RenderScreenshot:
+		params := request.Screenshot
+		if nil == params {
+			params = &page.CaptureScreenshotParams{}
+		}
+		result := <-tab.Page().CaptureScreenshot(params)
+		if nil != result.Err {
+			return nil, result.Err
+		}
+		encoded = result.Data
+
+	default:
+		return nil, errs.New(codes.ChromeRenderFailed, fmt.Sprintf("unknown render format '%s'", request.Format))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeRenderFailed, "could not decode the output")
+	}
+	return data, nil
+}
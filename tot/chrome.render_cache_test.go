@@ -0,0 +1,126 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestRenderRequestKey(t *testing.T) {
+	request := &RenderRequest{URL: "https://example.com/", Format: RenderScreenshot}
+	if request.Key() != (&RenderRequest{URL: "https://example.com/", Format: RenderScreenshot, NoCache: true, Navigation: &NavigationPolicy{MaxAttempts: 3}}).Key() {
+		t.Errorf("Expected the key to ignore the cache and navigation options")
+	}
+	for _, other := range []*RenderRequest{
+		{URL: "https://example.com/other", Format: RenderScreenshot},
+		{URL: "https://example.com/", Format: RenderPDF},
+		{URL: "https://example.com/", Format: RenderScreenshot, Screenshot: &page.CaptureScreenshotParams{Quality: 80}},
+	} {
+		if request.Key() == other.Key() {
+			t.Errorf("Expected %+v to have a different key", other)
+		}
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	cache := NewRenderCache(time.Hour, 10, 2)
+	cache.Put("a", []byte("aaaa"))
+	cache.Put("b", []byte("bbbb"))
+	if data, ok := cache.Get("a"); !ok || "aaaa" != string(data) {
+		t.Errorf("Expected 'aaaa', got '%s' %v", data, ok)
+	}
+
+	// b is the least recently used output.
+	cache.Put("c", []byte("cccc"))
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+	cache.Put("d", []byte("dddddd"))
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("Expected a to be evicted")
+	}
+	if stats := cache.Stats(); 2 != stats.Entries || 10 != stats.Bytes || 2 != stats.Evictions {
+		t.Errorf("Expected the size limits to be enforced, got %+v", stats)
+	}
+	cache.Put("e", []byte("eeeeeeeeeeee"))
+	if _, ok := cache.Get("e"); ok {
+		t.Errorf("Expected an output larger than the cache not to be cached")
+	}
+	cache.Remove("c")
+	cache.Remove("d")
+	if stats := cache.Stats(); 0 != stats.Entries || 0 != stats.Bytes {
+		t.Errorf("Expected an empty cache, got %+v", stats)
+	}
+
+	cache = NewRenderCache(10*time.Millisecond, 0, 0)
+	cache.Put("a", []byte("a"))
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("Expected the output to expire")
+	}
+	cache.Put("b", []byte("b"))
+	cache.Purge()
+	if stats := cache.Stats(); 0 != stats.Entries || 1 != stats.Misses {
+		t.Errorf("Expected a purged cache, got %+v", stats)
+	}
+}
+
+func TestWarmPoolRender(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		if strings.Contains(string(params), "outerHTML") {
+			return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": "<html></html>"}}, nil
+		}
+		check := map[string]interface{}{"status": 200, "length": 10, "selector": "", "url": "https://example.com/"}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "object", "value": check}}, nil
+	})
+	cdp.Respond("Page.captureScreenshot", map[string]interface{}{"data": base64.StdEncoding.EncodeToString([]byte("png"))})
+
+	cache := NewRenderCache(time.Hour, 0, 0)
+	pool := NewWarmPool(browser, &WarmPolicy{Cache: cache, MaxUses: 10})
+	if err := pool.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer pool.Close()
+
+	request := &RenderRequest{URL: "https://example.com/", Format: RenderScreenshot}
+	result, err := pool.Render(context.Background(), request)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if "png" != string(result.Data) || result.Cached || 1 != len(result.Attempts) {
+		t.Errorf("Expected a rendered screenshot, got %+v", result)
+	}
+	result, err = pool.Render(context.Background(), request)
+	if nil != err || "png" != string(result.Data) || !result.Cached {
+		t.Errorf("Expected a cached screenshot, got %+v %v", result, err)
+	}
+
+	result, err = pool.Render(context.Background(), &RenderRequest{URL: "https://example.com/", Format: RenderHTML})
+	if nil != err || "<html></html>" != string(result.Data) {
+		t.Errorf("Expected the HTML source, got %+v %v", result, err)
+	}
+	if _, err := pool.Render(context.Background(), &RenderRequest{URL: "https://example.com/", Format: "gif"}); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	var screenshots int
+	for _, command := range cdp.Commands() {
+		if "Page.captureScreenshot" == command.Method {
+			screenshots++
+		}
+	}
+	if 1 != screenshots {
+		t.Errorf("Expected 1 screenshot, got %d", screenshots)
+	}
+	if stats := cache.Stats(); 2 != stats.Entries || 1 != stats.Hits {
+		t.Errorf("Expected 2 cached outputs and a hit, got %+v", stats)
+	}
+}
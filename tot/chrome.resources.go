@@ -0,0 +1,292 @@
+package chrome
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+ProcessUsage is the resource usage of a process in the browser process tree.
+*/
+type ProcessUsage struct {
+	// The process ID.
+	PID int
+
+	// The parent process ID.
+	PPID int
+
+	// The process name, for example 'chrome'.
+	Name string
+
+	// The process state, for example 'S' for sleeping or 'Z' for a zombie.
+	State string
+
+	// The resident set size, in bytes.
+	RSS int64
+
+	// The CPU time used by the process.
+	CPUTime time.Duration
+}
+
+/*
+Zombie returns whether the process has exited and not been reaped, usually a
+renderer whose parent is stuck.
+*/
+func (usage *ProcessUsage) Zombie() bool {
+	return "Z" == usage.State
+}
+
+/*
+ResourceUsage is the resource usage of the browser process tree.
+*/
+type ResourceUsage struct {
+	// The time the usage was read.
+	Time time.Time
+
+	// The processes in the tree, the browser process first.
+	Processes []*ProcessUsage
+
+	// The total resident set size, in bytes.
+	RSS int64
+
+	// The total CPU time used by the processes in the tree.
+	CPUTime time.Duration
+
+	// The CPU used since the previous sample, in cores. It's only set for the
+	// samples taken by a ResourceBudget.
+	CPU float64
+
+	// The number of zombie processes.
+	Zombies int
+}
+
+/*
+Usage returns the resource usage of the launched browser and all of its child
+processes. It's only supported on Linux.
+*/
+func (chrome *Chrome) Usage() (*ResourceUsage, error) {
+	if nil == chrome.process {
+		return nil, errs.New(codes.ChromeUsageUnavailable, "the browser is not running")
+	}
+	processes, err := processTree(chrome.process.Pid)
+	if nil != err {
+		return nil, err
+	}
+	usage := &ResourceUsage{
+		Time:      time.Now(),
+		Processes: processes,
+	}
+	for _, process := range processes {
+		usage.RSS += process.RSS
+		usage.CPUTime += process.CPUTime
+		if process.Zombie() {
+			usage.Zombies++
+		}
+	}
+	return usage, nil
+}
+
+/*
+ResourceBudget limits the resources the browser process tree may use. Limits
+that are zero aren't enforced.
+*/
+type ResourceBudget struct {
+	// The maximum total resident set size, in bytes.
+	MaxRSS int64
+
+	// The maximum CPU use between samples, in cores.
+	MaxCPU float64
+
+	// The maximum number of zombie processes.
+	MaxZombies int
+
+	// The time between samples. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// The number of consecutive samples that must exceed the budget before
+	// the browser is killed, so short spikes are tolerated. Defaults to 1.
+	Samples int
+
+	// Whether to launch the browser again after killing it. Tabs aren't
+	// reopened.
+	Restart bool
+
+	// Optional. Called with every sample, for exporting metrics.
+	OnSample func(usage *ResourceUsage)
+
+	// Optional. Called with the sample and a ChromeBudgetExceeded error
+	// before the browser is killed.
+	OnExceeded func(usage *ResourceUsage, err error)
+}
+
+/*
+check returns a ChromeBudgetExceeded error if a sample exceeds the budget.
+*/
+func (budget *ResourceBudget) check(usage *ResourceUsage) error {
+	switch {
+	case budget.MaxRSS > 0 && usage.RSS > budget.MaxRSS:
+		return errs.New(codes.ChromeBudgetExceeded, fmt.Sprintf("RSS %d exceeds the budget of %d bytes", usage.RSS, budget.MaxRSS))
+	case budget.MaxCPU > 0 && usage.CPU > budget.MaxCPU:
+		return errs.New(codes.ChromeBudgetExceeded, fmt.Sprintf("CPU %.2f exceeds the budget of %.2f cores", usage.CPU, budget.MaxCPU))
+	case budget.MaxZombies > 0 && usage.Zombies > budget.MaxZombies:
+		return errs.New(codes.ChromeBudgetExceeded, fmt.Sprintf("%d zombie processes exceed the budget of %d", usage.Zombies, budget.MaxZombies))
+	}
+	return nil
+}
+
+/*
+WithResourceBudget samples the resource usage of the browser process tree
+while it runs and kills the tree when it exceeds the budget, optionally
+launching the browser again:
+
+	browser := chrome.New(chrome.WithResourceBudget(&chrome.ResourceBudget{
+		MaxRSS:   2 << 30,
+		MaxCPU:   1.5,
+		Samples:  3,
+		Restart:  true,
+		OnSample: func(usage *chrome.ResourceUsage) { rssGauge.Set(float64(usage.RSS)) },
+	}))
+
+Sampling starts when Launch succeeds and stops when the browser is closed. It's
+only supported on Linux.
+*/
+func WithResourceBudget(budget *ResourceBudget) Option {
+	return func(chrome *Chrome) {
+		chrome.budget = budget
+	}
+}
+
+/*
+supervise starts sampling the resource usage of the browser, if it has a
+budget. The lifecycle mutex must be locked.
+*/
+func (chrome *Chrome) supervise() {
+	if nil == chrome.budget {
+		return
+	}
+	interval := chrome.budget.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	samples := chrome.budget.Samples
+	if samples <= 0 {
+		samples = 1
+	}
+	stop := make(chan struct{})
+	exceeded := make(chan *ResourceUsage, 1)
+	chrome.superviseStop = stop
+	go chrome.sample(chrome.budget, interval, samples, stop, exceeded)
+	go chrome.enforce(chrome.budget, stop, exceeded)
+}
+
+/*
+stopSupervising stops sampling the resource usage of the browser. The
+lifecycle mutex must be locked.
+*/
+func (chrome *Chrome) stopSupervising() {
+	if nil != chrome.superviseStop {
+		close(chrome.superviseStop)
+		chrome.superviseStop = nil
+	}
+}
+
+/*
+sample samples the resource usage of the browser until it's stopped or the
+browser exceeds its budget, which is reported on exceeded.
+*/
+func (chrome *Chrome) sample(budget *ResourceBudget, interval time.Duration, samples int, stop chan struct{}, exceeded chan *ResourceUsage) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous *ResourceUsage
+	over := 0
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		usage, err := chrome.Usage()
+		if nil != err {
+			chrome.logger.Debug("could not read resource usage", logger.Fields{"error": err.Error()})
+			continue
+		}
+		if nil != previous {
+			// CPU time drops when processes exit, which isn't negative use.
+			if cpu := usage.CPUTime - previous.CPUTime; cpu > 0 {
+				usage.CPU = cpu.Seconds() / usage.Time.Sub(previous.Time).Seconds()
+			}
+		}
+		previous = usage
+		if nil != budget.OnSample {
+			budget.OnSample(usage)
+		}
+
+		err = budget.check(usage)
+		if nil == err {
+			over = 0
+			continue
+		}
+		over++
+		if over < samples {
+			continue
+		}
+
+		chrome.logger.Warn("killing the browser", logger.Fields{"error": err.Error(), "restart": budget.Restart})
+		if nil != budget.OnExceeded {
+			budget.OnExceeded(usage, err)
+		}
+		exceeded <- usage
+		return
+	}
+}
+
+/*
+enforce waits for the sampler to report that the browser exceeded its budget,
+then kills it and optionally launches it again. It holds the lifecycle mutex
+so it can't race Launch or Close, and does nothing if the browser was closed
+or launched again since the sample was taken.
+*/
+func (chrome *Chrome) enforce(budget *ResourceBudget, stop chan struct{}, exceeded chan *ResourceUsage) {
+	var usage *ResourceUsage
+	select {
+	case <-stop:
+		return
+	case usage = <-exceeded:
+	}
+
+	chrome.lifecycle.Lock()
+	defer chrome.lifecycle.Unlock()
+	if chrome.superviseStop != stop {
+		return
+	}
+	chrome.kill(usage)
+	if budget.Restart {
+		chrome.version = nil
+		chrome.tabs = nil
+		if err := chrome.launch(); nil != err {
+			chrome.logger.Error("could not restart the browser", logger.Fields{"error": err.Error()})
+		}
+	}
+}
+
+/*
+kill kills the processes in the tree, children first, and closes the browser.
+The lifecycle mutex must be locked.
+*/
+func (chrome *Chrome) kill(usage *ResourceUsage) {
+	atomic.StoreInt32(&chrome.closing, 1)
+	for a := len(usage.Processes) - 1; a >= 0; a-- {
+		if process, err := os.FindProcess(usage.Processes[a].PID); nil == err {
+			process.Kill()
+		}
+	}
+	chrome.close()
+}
@@ -0,0 +1,98 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+clockTicks is the unit of the CPU times in /proc/<pid>/stat. It's 100 on every
+Linux platform Chrome runs on.
+*/
+const clockTicks = 100
+
+/*
+processTree returns the usage of a process and all of its descendants, read
+from /proc.
+*/
+func processTree(pid int) ([]*ProcessUsage, error) {
+	dirs, err := filepath.Glob("/proc/[0-9]*")
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeUsageUnavailable, "could not list processes")
+	}
+
+	children := make(map[int][]*ProcessUsage)
+	var root *ProcessUsage
+	for _, dir := range dirs {
+		usage, err := readProcessStat(filepath.Join(dir, "stat"))
+		if nil != err {
+			// The process exited while the list was read.
+			continue
+		}
+		if pid == usage.PID {
+			root = usage
+		}
+		children[usage.PPID] = append(children[usage.PPID], usage)
+	}
+	if nil == root {
+		return nil, errs.New(codes.ChromeUsageUnavailable, fmt.Sprintf("process %d not found", pid))
+	}
+
+	tree := []*ProcessUsage{root}
+	for a := 0; a < len(tree); a++ {
+		tree = append(tree, children[tree[a].PID]...)
+	}
+	return tree, nil
+}
+
+/*
+readProcessStat parses a /proc/<pid>/stat file. The command name is in
+parentheses and may contain spaces, so the fields are split after it.
+*/
+func readProcessStat(file string) (*ProcessUsage, error) {
+	data, err := ioutil.ReadFile(file)
+	if nil != err {
+		return nil, err
+	}
+	stat := string(data)
+	start := strings.Index(stat, "(")
+	end := strings.LastIndex(stat, ")")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid stat '%s'", file)
+	}
+	// Fields from the state on: state ppid pgrp session tty_nr tpgid flags
+	// minflt cminflt majflt cmajflt utime stime cutime cstime priority nice
+	// num_threads itrealvalue starttime vsize rss ...
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 22 {
+		return nil, fmt.Errorf("invalid stat '%s'", file)
+	}
+
+	usage := &ProcessUsage{
+		Name:  stat[start+1 : end],
+		State: fields[0],
+	}
+	if usage.PID, err = strconv.Atoi(strings.TrimSpace(stat[:start])); nil != err {
+		return nil, err
+	}
+	if usage.PPID, err = strconv.Atoi(fields[1]); nil != err {
+		return nil, err
+	}
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	usage.CPUTime = time.Duration(utime+stime) * time.Second / clockTicks
+	pages, _ := strconv.ParseInt(fields[21], 10, 64)
+	usage.RSS = pages * int64(os.Getpagesize())
+	return usage, nil
+}
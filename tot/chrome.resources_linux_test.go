@@ -0,0 +1,165 @@
+//go:build linux
+// +build linux
+
+package chrome
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadProcessStat(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "stat")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "stat")
+	ioutil.WriteFile(file, []byte("4242 (Chrome (renderer)) Z 4200 4200 4200 0 -1 4194560 1 0 0 0 250 50 0 0 20 0 1 0 100 1000 3 18446744073709551615\n"), 0600)
+
+	usage, err := readProcessStat(file)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 4242 != usage.PID || 4200 != usage.PPID || "Chrome (renderer)" != usage.Name || !usage.Zombie() {
+		t.Errorf("Unexpected usage %+v", usage)
+	}
+	if 3*time.Second != usage.CPUTime {
+		t.Errorf("Expected 3s of CPU time, got %s", usage.CPUTime)
+	}
+	if int64(3*os.Getpagesize()) != usage.RSS {
+		t.Errorf("Expected 3 pages, got %d", usage.RSS)
+	}
+}
+
+/*
+newSupervisedBrowser launches a script that stands in for the browser and
+starts a child process, with a devtools endpoint that answers the version
+query.
+*/
+func newSupervisedBrowser(t *testing.T, workdir string, options ...Option) (*Chrome, func()) {
+	devtools := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"browser":"HeadlessChrome/0.0.0.0"}`))
+	}))
+	host, port, _ := net.SplitHostPort(devtools.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	binary := newFakeBrowser(t, workdir, "sleep 30 &\nchild=$!\ntrap 'kill $child; exit 0' INT\nwhile true; do sleep 0.1; done\n")
+
+	chrome := New(append([]Option{
+		WithFlags(&Flags{}),
+		WithAddress(host),
+		WithPort(portNum),
+		WithBinary(binary),
+		WithStderr(filepath.Join(workdir, "stderr")),
+		WithStdout(filepath.Join(workdir, "stdout")),
+		WithWorkdir(workdir),
+	}, options...)...)
+	if err := chrome.Launch(); nil != err {
+		devtools.Close()
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	return chrome, devtools.Close
+}
+
+func TestUsage(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+	chrome, done := newSupervisedBrowser(t, workdir)
+	defer done()
+	defer chrome.Close()
+
+	usage, err := chrome.Usage()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if len(usage.Processes) < 2 || chrome.process.Pid != usage.Processes[0].PID {
+		t.Errorf("Expected the browser and its child, got %+v", usage.Processes)
+	}
+	if usage.RSS <= 0 {
+		t.Errorf("Expected RSS, got %d", usage.RSS)
+	}
+}
+
+func TestWithResourceBudget(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+
+	var sampled int32
+	exceeded := make(chan *ResourceUsage, 1)
+	chrome, done := newSupervisedBrowser(t, workdir, WithResourceBudget(&ResourceBudget{
+		MaxRSS:   1,
+		Interval: 50 * time.Millisecond,
+		Samples:  2,
+		OnSample: func(usage *ResourceUsage) {
+			atomic.AddInt32(&sampled, 1)
+		},
+		OnExceeded: func(usage *ResourceUsage, err error) {
+			exceeded <- usage
+		},
+	}))
+	defer done()
+	defer chrome.Close()
+
+	var usage *ResourceUsage
+	select {
+	case usage = <-exceeded:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the budget to be exceeded")
+	}
+	if atomic.LoadInt32(&sampled) < 2 {
+		t.Errorf("Expected 2 samples before the browser is killed, got %d", sampled)
+	}
+
+	for _, process := range usage.Processes {
+		for start := time.Now(); ; {
+			if _, err := os.Stat(filepath.Join("/proc", strconv.Itoa(process.PID))); os.IsNotExist(err) {
+				break
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Errorf("Expected process %d to be killed", process.PID)
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+}
+
+func TestResourceBudgetRestart(t *testing.T) {
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+
+	exceeded := make(chan *ResourceUsage, 10)
+	chrome, done := newSupervisedBrowser(t, workdir, WithResourceBudget(&ResourceBudget{
+		MaxRSS:   1,
+		Interval: 50 * time.Millisecond,
+		Restart:  true,
+		OnExceeded: func(usage *ResourceUsage, err error) {
+			exceeded <- usage
+		},
+	}))
+	defer done()
+
+	for a := 0; a < 2; a++ {
+		select {
+		case <-exceeded:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Expected the budget to be exceeded after a restart")
+		}
+	}
+
+	// Closing races the next restart.
+	chrome.Close()
+	select {
+	case <-chrome.exited:
+	default:
+		t.Errorf("Expected the browser to be closed")
+	}
+	if nil != chrome.superviseStop {
+		t.Errorf("Expected supervision to be stopped")
+	}
+}
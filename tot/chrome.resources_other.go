@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package chrome
+
+import (
+	"runtime"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+processTree returns an error, process usage is only read on Linux.
+*/
+func processTree(pid int) ([]*ProcessUsage, error) {
+	return nil, errs.New(codes.ChromeUsageUnavailable, "process usage is not supported on "+runtime.GOOS)
+}
@@ -0,0 +1,33 @@
+package chrome
+
+import (
+	"testing"
+)
+
+func TestResourceBudgetCheck(t *testing.T) {
+	budget := &ResourceBudget{MaxRSS: 1000, MaxCPU: 1.5, MaxZombies: 2}
+	tests := []struct {
+		usage    *ResourceUsage
+		exceeded bool
+	}{
+		{&ResourceUsage{RSS: 1000, CPU: 1.5, Zombies: 2}, false},
+		{&ResourceUsage{RSS: 1001}, true},
+		{&ResourceUsage{CPU: 1.6}, true},
+		{&ResourceUsage{Zombies: 3}, true},
+	}
+	for _, test := range tests {
+		if err := budget.check(test.usage); test.exceeded != (nil != err) {
+			t.Errorf("Expected exceeded %v for %+v, got %v", test.exceeded, test.usage, err)
+		}
+	}
+
+	if err := (&ResourceBudget{}).check(&ResourceUsage{RSS: 1 << 40, CPU: 64, Zombies: 100}); nil != err {
+		t.Errorf("Expected an empty budget not to be enforced, got %v", err)
+	}
+}
+
+func TestUsageNotRunning(t *testing.T) {
+	if _, err := New().Usage(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
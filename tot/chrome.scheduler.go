@@ -0,0 +1,449 @@
+package chrome
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+Priority is the priority class of a job. Jobs of a higher class are started
+first and can preempt preemptible jobs of a lower class.
+*/
+type Priority int
+
+/*
+Priority classes. The zero value is PriorityNormal.
+*/
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+/*
+Job is work run by a Scheduler on a tab of its pool.
+*/
+type Job struct {
+	// Optional. Identifies the job in the audit records of a Tenancy.
+	// Defaults to the sequence number of the job.
+	ID string
+
+	// Optional. The tenant the job is accounted to. Jobs of the same priority
+	// class are shared fairly between tenants.
+	Tenant string
+
+	// Optional. The priority class of the job.
+	Priority Priority
+
+	// Optional. Whether the job yields its tab to jobs of a higher priority
+	// class when no tab is free. The context of a preempted job is canceled
+	// and the job is queued again to be restarted from the beginning, so only
+	// jobs that can be restarted should be preemptible.
+	Preemptible bool
+
+	// The work, run with a tab acquired from the pool. The tab is released
+	// when Run returns.
+	Run func(ctx context.Context, tab *Tab) error
+}
+
+/*
+TenantUsage is the accounting of the jobs of a tenant.
+*/
+type TenantUsage struct {
+	// The number of jobs waiting for a tab.
+	Queued int
+
+	// The number of jobs running.
+	Running int
+
+	// The number of jobs that completed, including those that failed.
+	Completed int
+
+	// The number of jobs that returned an error.
+	Failed int
+
+	// The number of times a job was preempted.
+	Preempted int
+
+	// The combined time the jobs held tabs.
+	Busy time.Duration
+
+	// The combined time the jobs waited for tabs.
+	Waited time.Duration
+}
+
+/*
+scheduledJob is a job queued or running in a scheduler.
+*/
+type scheduledJob struct {
+	cancel    context.CancelFunc
+	job       *Job
+	preempted bool
+	queued    time.Time
+	rejected  bool
+	seq       int
+	start     chan struct{}
+	started   time.Time
+}
+
+/*
+Scheduler runs jobs on the tabs of a WarmPool, at most concurrency at a time,
+for rendering platforms shared by several tenants:
+
+	scheduler := chrome.NewScheduler(pool, 8)
+	err := scheduler.Run(ctx, &chrome.Job{
+		Tenant:   "acme",
+		Priority: chrome.PriorityHigh,
+		Run: func(ctx context.Context, tab *chrome.Tab) error {
+			...
+		},
+	})
+
+Waiting jobs are started by priority class. Within a class the job of the
+tenant with the fewest running jobs and the least accumulated tab time is
+started first, so a tenant that submits many jobs doesn't starve the others.
+When a job of a higher class waits and every tab is busy, a preemptible job of
+a lower class is canceled and queued again.
+*/
+type Scheduler struct {
+	closed      bool
+	concurrency int
+	mux         *sync.Mutex
+	pool        *WarmPool
+	queue       []*scheduledJob
+	running     map[*scheduledJob]struct{}
+	seq         int
+	tenancy     *Tenancy
+	tenants     map[string]*TenantUsage
+}
+
+/*
+NewScheduler returns a scheduler that runs jobs on the tabs of a pool.
+concurrency defaults to the size of the pool.
+*/
+func NewScheduler(pool *WarmPool, concurrency int) *Scheduler {
+	if concurrency <= 0 {
+		concurrency = pool.policy.Size
+	}
+	return &Scheduler{
+		concurrency: concurrency,
+		mux:         &sync.Mutex{},
+		pool:        pool,
+		running:     make(map[*scheduledJob]struct{}),
+		tenants:     make(map[string]*TenantUsage),
+	}
+}
+
+/*
+NewIsolatedScheduler returns a scheduler that runs the jobs of each tenant in
+tabs of the tenant's browser contexts. concurrency defaults to 1.
+*/
+func NewIsolatedScheduler(tenancy *Tenancy, concurrency int) *Scheduler {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Scheduler{
+		concurrency: concurrency,
+		mux:         &sync.Mutex{},
+		running:     make(map[*scheduledJob]struct{}),
+		tenancy:     tenancy,
+		tenants:     make(map[string]*TenantUsage),
+	}
+}
+
+/*
+Run queues a job and waits for it to complete. It returns the error of the
+job, or an error if the context is canceled before the job completes or the
+scheduler is closed before the job starts.
+*/
+func (scheduler *Scheduler) Run(ctx context.Context, job *Job) error {
+	if nil == job.Run {
+		return errs.New(codes.ChromeJobFailed, "the job has no Run function")
+	}
+	scheduler.mux.Lock()
+	scheduler.seq++
+	scheduled := &scheduledJob{job: job, seq: scheduler.seq}
+	usage := scheduler.usage(job.Tenant)
+	scheduler.mux.Unlock()
+
+	for {
+		scheduler.mux.Lock()
+		if scheduler.closed {
+			scheduler.mux.Unlock()
+			return errs.New(codes.ChromeJobFailed, "the scheduler is closed")
+		}
+		scheduled.queued = time.Now()
+		scheduled.start = make(chan struct{})
+		scheduler.queue = append(scheduler.queue, scheduled)
+		usage.Queued++
+		scheduler.dispatch()
+		scheduler.mux.Unlock()
+
+		select {
+		case <-scheduled.start:
+			if scheduled.rejected {
+				return errs.New(codes.ChromeJobFailed, "the scheduler was closed before the job started")
+			}
+		case <-ctx.Done():
+			// The job may have been rejected or dispatched while the
+			// context was done.
+			scheduler.mux.Lock()
+			rejected := scheduled.rejected
+			if !rejected && scheduler.dequeue(scheduled) {
+				usage.Queued--
+			} else if _, ok := scheduler.running[scheduled]; ok {
+				delete(scheduler.running, scheduled)
+				usage.Running--
+				scheduler.dispatch()
+			}
+			scheduler.mux.Unlock()
+			if rejected {
+				return errs.New(codes.ChromeJobFailed, "the scheduler was closed before the job started")
+			}
+			return errs.Wrap(ctx.Err(), codes.ChromeJobFailed, "the job did not start")
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		scheduler.mux.Lock()
+		scheduled.cancel = cancel
+		if scheduled.preempted {
+			cancel()
+		}
+		scheduler.mux.Unlock()
+
+		tab, err := scheduler.acquire(runCtx, scheduled)
+		if nil == err {
+			err = job.Run(runCtx, tab)
+			scheduler.release(tab)
+		}
+		cancel()
+
+		preempted := scheduler.finish(scheduled, usage)
+		if preempted && nil == ctx.Err() {
+			continue
+		}
+		scheduler.mux.Lock()
+		usage.Completed++
+		if nil != err {
+			usage.Failed++
+		}
+		scheduler.mux.Unlock()
+		return err
+	}
+}
+
+/*
+Usage returns the accounting of the jobs of each tenant.
+*/
+func (scheduler *Scheduler) Usage() map[string]TenantUsage {
+	scheduler.mux.Lock()
+	defer scheduler.mux.Unlock()
+	usage := make(map[string]TenantUsage, len(scheduler.tenants))
+	for tenant, tenantUsage := range scheduler.tenants {
+		usage[tenant] = *tenantUsage
+	}
+	return usage
+}
+
+/*
+Close stops the scheduler. Waiting jobs fail and running jobs are canceled.
+*/
+func (scheduler *Scheduler) Close() {
+	scheduler.mux.Lock()
+	defer scheduler.mux.Unlock()
+	scheduler.closed = true
+	for _, scheduled := range scheduler.queue {
+		scheduler.usage(scheduled.job.Tenant).Queued--
+		scheduled.rejected = true
+		close(scheduled.start)
+	}
+	scheduler.queue = nil
+	for scheduled := range scheduler.running {
+		if nil != scheduled.cancel {
+			scheduled.cancel()
+		}
+	}
+}
+
+/*
+finish releases the slot of a job that returned and records its tab time. It
+returns whether the job was preempted, in which case it's retried.
+*/
+func (scheduler *Scheduler) finish(scheduled *scheduledJob, usage *TenantUsage) bool {
+	scheduler.mux.Lock()
+	defer scheduler.mux.Unlock()
+	delete(scheduler.running, scheduled)
+	usage.Running--
+	usage.Busy += time.Since(scheduled.started)
+	preempted := scheduled.preempted
+	if preempted {
+		usage.Preempted++
+	}
+	scheduled.cancel = nil
+	scheduled.preempted = false
+	scheduler.dispatch()
+	return preempted
+}
+
+/*
+acquire returns a tab for a job, from the pool or the tenancy of the
+scheduler.
+*/
+func (scheduler *Scheduler) acquire(ctx context.Context, scheduled *scheduledJob) (*Tab, error) {
+	if nil == scheduler.tenancy {
+		return scheduler.pool.Acquire(ctx)
+	}
+	id := scheduled.job.ID
+	if "" == id {
+		id = strconv.Itoa(scheduled.seq)
+	}
+	return scheduler.tenancy.Acquire(ctx, scheduled.job.Tenant, id)
+}
+
+/*
+release returns the tab of a job.
+*/
+func (scheduler *Scheduler) release(tab *Tab) {
+	if nil == scheduler.tenancy {
+		scheduler.pool.Release(tab)
+		return
+	}
+	scheduler.tenancy.Release(tab)
+}
+
+/*
+dispatch starts waiting jobs while slots are free, then preempts running jobs
+for the waiting jobs of higher classes. Must be called with the mutex locked.
+*/
+func (scheduler *Scheduler) dispatch() {
+	for len(scheduler.running) < scheduler.concurrency && 0 < len(scheduler.queue) {
+		scheduled := scheduler.next()
+		scheduler.dequeue(scheduled)
+		usage := scheduler.usage(scheduled.job.Tenant)
+		usage.Queued--
+		usage.Running++
+		usage.Waited += time.Since(scheduled.queued)
+		scheduled.started = time.Now()
+		scheduler.running[scheduled] = struct{}{}
+		close(scheduled.start)
+	}
+
+	// Each preempted job frees a slot for the highest waiting job, so jobs
+	// that are already being preempted are accounted for first.
+	waiting := make([]*scheduledJob, len(scheduler.queue))
+	copy(waiting, scheduler.queue)
+	for scheduled := range scheduler.running {
+		if scheduled.preempted && 0 < len(waiting) {
+			waiting = scheduler.without(waiting, scheduler.highest(waiting))
+		}
+	}
+	for 0 < len(waiting) {
+		highest := scheduler.highest(waiting)
+		waiting = scheduler.without(waiting, highest)
+		var victim *scheduledJob
+		for scheduled := range scheduler.running {
+			if !scheduled.job.Preemptible || scheduled.preempted || scheduled.job.Priority >= highest.job.Priority {
+				continue
+			}
+			if nil == victim || scheduled.job.Priority < victim.job.Priority ||
+				(scheduled.job.Priority == victim.job.Priority && scheduled.started.After(victim.started)) {
+				victim = scheduled
+			}
+		}
+		if nil == victim {
+			return
+		}
+		victim.preempted = true
+		if nil != victim.cancel {
+			victim.cancel()
+		}
+	}
+}
+
+/*
+next returns the waiting job to start next: the oldest job of the tenant with
+the fewest running jobs and the least tab time in the highest class. Must be
+called with the mutex locked.
+*/
+func (scheduler *Scheduler) next() *scheduledJob {
+	var next *scheduledJob
+	for _, scheduled := range scheduler.queue {
+		if nil == next || scheduler.before(scheduled, next) {
+			next = scheduled
+		}
+	}
+	return next
+}
+
+/*
+before returns whether a waiting job should start before another.
+*/
+func (scheduler *Scheduler) before(a, b *scheduledJob) bool {
+	if a.job.Priority != b.job.Priority {
+		return a.job.Priority > b.job.Priority
+	}
+	if a.job.Tenant != b.job.Tenant {
+		usageA, usageB := scheduler.usage(a.job.Tenant), scheduler.usage(b.job.Tenant)
+		if usageA.Running != usageB.Running {
+			return usageA.Running < usageB.Running
+		}
+		if usageA.Busy != usageB.Busy {
+			return usageA.Busy < usageB.Busy
+		}
+	}
+	return a.seq < b.seq
+}
+
+/*
+highest returns the waiting job of the highest class, the oldest one if there
+are several.
+*/
+func (scheduler *Scheduler) highest(jobs []*scheduledJob) *scheduledJob {
+	var highest *scheduledJob
+	for _, scheduled := range jobs {
+		if nil == highest || scheduled.job.Priority > highest.job.Priority ||
+			(scheduled.job.Priority == highest.job.Priority && scheduled.seq < highest.seq) {
+			highest = scheduled
+		}
+	}
+	return highest
+}
+
+/*
+without returns the jobs without a job.
+*/
+func (scheduler *Scheduler) without(jobs []*scheduledJob, job *scheduledJob) []*scheduledJob {
+	for a, scheduled := range jobs {
+		if scheduled == job {
+			return append(jobs[:a], jobs[a+1:]...)
+		}
+	}
+	return jobs
+}
+
+/*
+dequeue removes a job from the queue and returns whether it was queued. Must
+be called with the mutex locked.
+*/
+func (scheduler *Scheduler) dequeue(job *scheduledJob) bool {
+	queued := len(scheduler.queue)
+	scheduler.queue = scheduler.without(scheduler.queue, job)
+	return len(scheduler.queue) < queued
+}
+
+/*
+usage returns the accounting of a tenant. Must be called with the mutex
+locked.
+*/
+func (scheduler *Scheduler) usage(tenant string) *TenantUsage {
+	usage, ok := scheduler.tenants[tenant]
+	if !ok {
+		usage = &TenantUsage{}
+		scheduler.tenants[tenant] = usage
+	}
+	return usage
+}
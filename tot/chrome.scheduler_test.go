@@ -0,0 +1,257 @@
+package chrome
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+newTestScheduler returns a scheduler running one job at a time on a pool of a
+cdptest browser.
+*/
+func newTestScheduler(t *testing.T) (*Scheduler, func()) {
+	browser, _, stop := newCDPBrowser(t)
+	pool := NewWarmPool(browser, &WarmPolicy{Size: 1, MaxUses: 100})
+	if err := pool.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	scheduler := NewScheduler(pool, 1)
+	return scheduler, func() {
+		scheduler.Close()
+		pool.Close()
+		stop()
+	}
+}
+
+/*
+waitForQueued waits for a scheduler to have the specified number of waiting
+jobs.
+*/
+func waitForQueued(t *testing.T, scheduler *Scheduler, queued int) {
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		total := 0
+		for _, usage := range scheduler.Usage() {
+			total += usage.Queued
+		}
+		if queued == total {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d queued jobs, got %+v", queued, scheduler.Usage())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+/*
+recorder records the order jobs run in.
+*/
+type recorder struct {
+	mux   *sync.Mutex
+	order []string
+	wg    *sync.WaitGroup
+}
+
+func newRecorder() *recorder {
+	return &recorder{mux: &sync.Mutex{}, wg: &sync.WaitGroup{}}
+}
+
+func (rec *recorder) run(t *testing.T, scheduler *Scheduler, name string, job *Job) {
+	rec.wg.Add(1)
+	job.Run = func(ctx context.Context, tab *Tab) error {
+		rec.mux.Lock()
+		rec.order = append(rec.order, name)
+		rec.mux.Unlock()
+		return nil
+	}
+	go func() {
+		defer rec.wg.Done()
+		if err := scheduler.Run(context.Background(), job); nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+		}
+	}()
+}
+
+/*
+block runs a job that holds the only tab until the returned function is
+called.
+*/
+func block(t *testing.T, scheduler *Scheduler, tenant string) func() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go scheduler.Run(context.Background(), &Job{
+		Tenant: tenant,
+		Run: func(ctx context.Context, tab *Tab) error {
+			close(started)
+			<-release
+			return nil
+		},
+	})
+	<-started
+	return func() { close(release) }
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	scheduler, stop := newTestScheduler(t)
+	defer stop()
+
+	release := block(t, scheduler, "")
+	rec := newRecorder()
+	rec.run(t, scheduler, "low", &Job{Priority: PriorityLow})
+	waitForQueued(t, scheduler, 1)
+	rec.run(t, scheduler, "normal", &Job{})
+	waitForQueued(t, scheduler, 2)
+	rec.run(t, scheduler, "high", &Job{Priority: PriorityHigh})
+	waitForQueued(t, scheduler, 3)
+	release()
+	rec.wg.Wait()
+
+	if 3 != len(rec.order) || "high" != rec.order[0] || "normal" != rec.order[1] || "low" != rec.order[2] {
+		t.Errorf("Expected the jobs to run by priority, got %v", rec.order)
+	}
+}
+
+func TestSchedulerFairness(t *testing.T) {
+	scheduler, stop := newTestScheduler(t)
+	defer stop()
+
+	release := block(t, scheduler, "busy")
+	rec := newRecorder()
+	rec.run(t, scheduler, "busy1", &Job{Tenant: "busy"})
+	waitForQueued(t, scheduler, 1)
+	rec.run(t, scheduler, "busy2", &Job{Tenant: "busy"})
+	waitForQueued(t, scheduler, 2)
+	rec.run(t, scheduler, "idle", &Job{Tenant: "idle"})
+	waitForQueued(t, scheduler, 3)
+	time.Sleep(10 * time.Millisecond)
+	release()
+	rec.wg.Wait()
+
+	if 3 != len(rec.order) || "idle" != rec.order[0] || "busy1" != rec.order[1] {
+		t.Errorf("Expected the idle tenant to go first, got %v", rec.order)
+	}
+	usage := scheduler.Usage()
+	if busy := usage["busy"]; 3 != busy.Completed || 0 != busy.Queued || 0 != busy.Running || busy.Busy < 10*time.Millisecond {
+		t.Errorf("Expected the busy tenant to be accounted, got %+v", busy)
+	}
+	if idle := usage["idle"]; 1 != idle.Completed || idle.Waited < 10*time.Millisecond {
+		t.Errorf("Expected the idle tenant to have waited, got %+v", idle)
+	}
+}
+
+func TestSchedulerPreemption(t *testing.T) {
+	scheduler, stop := newTestScheduler(t)
+	defer stop()
+
+	mux := &sync.Mutex{}
+	var order []string
+	record := func(name string) {
+		mux.Lock()
+		order = append(order, name)
+		mux.Unlock()
+	}
+	started := make(chan struct{}, 2)
+	done := make(chan error)
+	go func() {
+		done <- scheduler.Run(context.Background(), &Job{
+			Tenant:      "batch",
+			Priority:    PriorityLow,
+			Preemptible: true,
+			Run: func(ctx context.Context, tab *Tab) error {
+				record("low")
+				started <- struct{}{}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(50 * time.Millisecond):
+					return nil
+				}
+			},
+		})
+	}()
+	<-started
+
+	err := scheduler.Run(context.Background(), &Job{
+		Tenant:   "interactive",
+		Priority: PriorityHigh,
+		Run: func(ctx context.Context, tab *Tab) error {
+			record("high")
+			return nil
+		},
+	})
+	if nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if err := <-done; nil != err {
+		t.Errorf("Expected the preempted job to be restarted, got %v", err)
+	}
+
+	if 3 != len(order) || "low" != order[0] || "high" != order[1] || "low" != order[2] {
+		t.Errorf("Expected the low job to yield and restart, got %v", order)
+	}
+	if batch := scheduler.Usage()["batch"]; 1 != batch.Preempted || 1 != batch.Completed || 0 != batch.Failed {
+		t.Errorf("Expected 1 preemption, got %+v", batch)
+	}
+}
+
+func TestSchedulerClose(t *testing.T) {
+	scheduler, stop := newTestScheduler(t)
+	defer stop()
+
+	release := block(t, scheduler, "")
+	defer release()
+	done := make(chan error)
+	go func() {
+		done <- scheduler.Run(context.Background(), &Job{Run: func(ctx context.Context, tab *Tab) error {
+			return nil
+		}})
+	}()
+	waitForQueued(t, scheduler, 1)
+	scheduler.Close()
+	if err := <-done; nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if err := scheduler.Run(context.Background(), &Job{Run: func(ctx context.Context, tab *Tab) error {
+		return nil
+	}}); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewScheduler(scheduler.pool, 0).Run(ctx, &Job{}); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestSchedulerCloseCanceled(t *testing.T) {
+	scheduler, stop := newTestScheduler(t)
+	defer stop()
+
+	// Closing the scheduler and canceling a waiting job at once must not
+	// count the job as running, whichever is seen first.
+	for a := 0; a < 10; a++ {
+		scheduler := NewScheduler(scheduler.pool, 1)
+		release := block(t, scheduler, "busy")
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- scheduler.Run(ctx, &Job{Tenant: "waiting", Run: func(ctx context.Context, tab *Tab) error {
+				return nil
+			}})
+		}()
+		waitForQueued(t, scheduler, 1)
+		cancel()
+		scheduler.Close()
+		if err := <-done; nil == err {
+			t.Errorf("Expected error, got nil")
+		}
+		release()
+		if waiting := scheduler.Usage()["waiting"]; 0 != waiting.Queued || 0 != waiting.Running {
+			t.Fatalf("Expected no waiting or running jobs, got %+v", waiting)
+		}
+	}
+}
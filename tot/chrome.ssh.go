@@ -0,0 +1,137 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"golang.org/x/crypto/ssh"
+)
+
+/*
+SSHTunnel reaches the developer tools endpoints of a browser on a remote
+machine through an SSH connection, so the debugging port never has to be
+exposed beyond the remote machine's loopback interface:
+
+	tunnel, err := chrome.DialSSH("build-01:22", &ssh.ClientConfig{
+		User:            "ci",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	}, 9222)
+	if nil != err {
+		...
+	}
+	defer tunnel.Close()
+	sock, err := tunnel.Socket()
+
+Endpoint queries and websocket connections are forwarded over the SSH
+connection, no local port is opened.
+*/
+type SSHTunnel struct {
+	client *ssh.Client
+	port   int
+}
+
+/*
+DialSSH opens an SSH connection to a remote machine and returns a tunnel to the
+browser debugging port on its loopback interface.
+*/
+func DialSSH(addr string, config *ssh.ClientConfig, port int) (*SSHTunnel, error) {
+	client, err := ssh.Dial("tcp", addr, config)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeTunnelFailed, fmt.Sprintf("could not connect to '%s'", addr))
+	}
+	return NewSSHTunnel(client, port), nil
+}
+
+/*
+NewSSHTunnel returns a tunnel to the browser debugging port on the loopback
+interface of the machine an existing SSH connection is open to.
+*/
+func NewSSHTunnel(client *ssh.Client, port int) *SSHTunnel {
+	return &SSHTunnel{
+		client: client,
+		port:   port,
+	}
+}
+
+/*
+Chrome returns a Chrome instance for the remote browser, for opening tabs and
+querying the endpoints. Additional options are applied after the tunnel
+configuration.
+*/
+func (tunnel *SSHTunnel) Chrome(options ...Option) *Chrome {
+	return New(append([]Option{
+		WithAddress("localhost"),
+		WithPort(tunnel.port),
+		WithSSHTunnel(tunnel.client),
+	}, options...)...)
+}
+
+/*
+Socket returns a socket connected to the browser target of the remote browser.
+*/
+func (tunnel *SSHTunnel) Socket(options ...socket.Option) (socket.Socketer, error) {
+	chrome := tunnel.Chrome()
+	version, err := chrome.Version()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeTunnelFailed, "could not read the browser websocket URL")
+	}
+	if "" == version.WebSocketDebuggerURL {
+		return nil, errs.New(codes.ChromeTunnelFailed, "the browser did not report a websocket URL")
+	}
+	websocketURL, err := chrome.websocketURL(version.WebSocketDebuggerURL)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.ChromeTunnelFailed, fmt.Sprintf("invalid browser websocket URL '%s'", version.WebSocketDebuggerURL))
+	}
+	return socket.New(websocketURL, append(chrome.socketOptions(websocketURL), options...)...), nil
+}
+
+/*
+Close closes the SSH connection, which also closes the connections forwarded
+over it.
+*/
+func (tunnel *SSHTunnel) Close() error {
+	return tunnel.client.Close()
+}
+
+/*
+sshConn is a connection forwarded over SSH. Forwarded connections don't support
+deadlines, which the websocket handshake sets, so they're ignored: a stalled
+connection is detected by the SSH transport rather than by the socket.
+*/
+type sshConn struct {
+	net.Conn
+}
+
+func (conn sshConn) SetDeadline(t time.Time) error      { return nil }
+func (conn sshConn) SetReadDeadline(t time.Time) error  { return nil }
+func (conn sshConn) SetWriteDeadline(t time.Time) error { return nil }
+
+/*
+sshDial opens a connection forwarded over the SSH connection.
+*/
+func (chrome *Chrome) sshDial(network, addr string) (net.Conn, error) {
+	conn, err := chrome.sshClient.Dial(network, addr)
+	if nil != err {
+		return nil, err
+	}
+	return sshConn{conn}, nil
+}
+
+/*
+sshTransport returns an HTTP transport that sends developer tools endpoint
+queries over the SSH connection.
+*/
+func (chrome *Chrome) sshTransport() *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return chrome.sshDial(network, addr)
+		},
+	}
+}
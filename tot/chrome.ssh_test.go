@@ -0,0 +1,121 @@
+package chrome
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+	"golang.org/x/crypto/ssh"
+)
+
+/*
+newSSHServer starts an SSH server that forwards direct-tcpip channels and
+returns its address and the number of forwarded connections.
+*/
+func newSSHServer(t *testing.T) (string, *int32, func()) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := ssh.NewSignerFromKey(key)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	forwarded := int32(0)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			go func() {
+				_, channels, requests, err := ssh.NewServerConn(conn, config)
+				if nil != err {
+					return
+				}
+				go ssh.DiscardRequests(requests)
+				for newChannel := range channels {
+					extra := newChannel.ExtraData()
+					size := binary.BigEndian.Uint32(extra)
+					host := string(extra[4 : 4+size])
+					port := binary.BigEndian.Uint32(extra[4+size:])
+					target, err := net.Dial("tcp", net.JoinHostPort(host, fmt.Sprint(port)))
+					if nil != err {
+						newChannel.Reject(ssh.ConnectionFailed, err.Error())
+						continue
+					}
+					channel, requests, _ := newChannel.Accept()
+					go ssh.DiscardRequests(requests)
+					atomic.AddInt32(&forwarded, 1)
+					go func() {
+						io.Copy(channel, target)
+						channel.Close()
+					}()
+					go func() {
+						io.Copy(target, channel)
+						target.Close()
+					}()
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), &forwarded, func() { listener.Close() }
+}
+
+func TestSSHTunnel(t *testing.T) {
+	addr, forwarded, stop := newSSHServer(t)
+	defer stop()
+	cdp := cdptest.NewServer(nil)
+	defer cdp.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"webSocketDebuggerUrl": "ws://` + cdp.URL().Host + `/devtools/browser/cdptest"}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	tunnel, err := DialSSH(addr, &ssh.ClientConfig{
+		User:            "test",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}, port)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer tunnel.Close()
+
+	sock, err := tunnel.Socket()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer sock.Stop()
+	if err := cdp.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	response := <-sock.SendCommand(socket.NewCommand(sock, "Browser.getVersion", nil))
+	if nil != response.Error {
+		t.Errorf("Expected nil, got error: %v", response.Error)
+	}
+	if count := atomic.LoadInt32(forwarded); count < 2 {
+		t.Errorf("Expected the query and the websocket to be forwarded, got %d connections", count)
+	}
+
+	if _, err := DialSSH("127.0.0.1:1", &ssh.ClientConfig{HostKeyCallback: ssh.InsecureIgnoreHostKey()}, port); nil == err {
+		t.Errorf("Expected an error")
+	}
+}
@@ -0,0 +1,373 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+TenancyPolicy configures how a Tenancy isolates tenants.
+*/
+type TenancyPolicy struct {
+	// Optional. Opens a browser context for every job instead of one per
+	// tenant, so that the jobs of a tenant don't share state either.
+	ContextPerJob bool
+
+	// Optional. Receives the audit record of every job when its tab is
+	// released. Records are also logged by the browser's logger.
+	Audit func(record TenancyAudit)
+}
+
+/*
+TenancyAudit records which browser context served a job.
+*/
+type TenancyAudit struct {
+	// The tenant of the job.
+	Tenant string
+
+	// The job.
+	Job string
+
+	// The browser context the job ran in.
+	BrowserContextID target.BrowserContextID
+
+	// The target the job ran in.
+	TargetID target.ID
+
+	// When the tab was acquired.
+	Acquired time.Time
+
+	// When the tab was released.
+	Released time.Time
+}
+
+/*
+tenantContext is a browser context opened for a tenant.
+*/
+type tenantContext struct {
+	disposed bool
+	evicted  bool
+	id       target.BrowserContextID
+	tabs    int
+	tenant  string
+}
+
+/*
+tenantTab is a tab acquired from a tenancy.
+*/
+type tenantTab struct {
+	audit   TenancyAudit
+	context *tenantContext
+}
+
+/*
+Tenancy runs the jobs of each tenant in browser contexts of its own, so tenants
+never share cookies, storage or caches:
+
+	tenancy := chrome.NewTenancy(browser, &chrome.TenancyPolicy{
+		Audit: func(record chrome.TenancyAudit) {
+			...
+		},
+	})
+	if err := tenancy.Start(); nil != err {
+		...
+	}
+	defer tenancy.Close()
+
+	tab, err := tenancy.Acquire(ctx, "acme", "invoice-42")
+	if nil != err {
+		...
+	}
+	defer tenancy.Release(tab)
+
+Every acquisition opens a new tab in the tenant's context, and every release
+closes it and records which context served the job. Browser contexts can only
+be managed through the browser target, so the tabs share a browser connection.
+A tenancy can also be scheduled with NewIsolatedScheduler.
+*/
+type Tenancy struct {
+	chrome    *Chrome
+	conn      *BrowserConnection
+	contexts  map[string]*tenantContext
+	createMux *sync.Mutex
+	mux       *sync.Mutex
+	policy    TenancyPolicy
+	tabs      map[*Tab]*tenantTab
+}
+
+/*
+NewTenancy returns a tenancy for a browser. The tenancy is started by Start.
+*/
+func NewTenancy(chrome *Chrome, policy *TenancyPolicy) *Tenancy {
+	tenancy := &Tenancy{
+		chrome:    chrome,
+		contexts:  make(map[string]*tenantContext),
+		createMux: &sync.Mutex{},
+		mux:       &sync.Mutex{},
+		tabs:      make(map[*Tab]*tenantTab),
+	}
+	if nil != policy {
+		tenancy.policy = *policy
+	}
+	return tenancy
+}
+
+/*
+Start connects to the browser target, which the browser contexts and the tabs
+are managed through.
+*/
+func (tenancy *Tenancy) Start() error {
+	tenancy.createMux.Lock()
+	defer tenancy.createMux.Unlock()
+	if nil != tenancy.conn {
+		return nil
+	}
+	conn, err := tenancy.chrome.Connect()
+	if nil != err {
+		return errs.Wrap(err, codes.ChromeTenancyFailed, "could not connect to the browser target")
+	}
+	tenancy.mux.Lock()
+	tenancy.conn = conn
+	tenancy.mux.Unlock()
+	return nil
+}
+
+/*
+Acquire opens a tab in the browser context of a tenant for a job. The tab must
+be returned with Release, which closes it and records the job.
+*/
+func (tenancy *Tenancy) Acquire(ctx context.Context, tenant, job string) (*Tab, error) {
+	if err := ctx.Err(); nil != err {
+		return nil, errs.Wrap(err, codes.ChromeTenancyFailed, fmt.Sprintf("could not open a tab for tenant '%s'", tenant))
+	}
+	tenancy.createMux.Lock()
+	defer tenancy.createMux.Unlock()
+	if nil == tenancy.conn {
+		return nil, errs.New(codes.ChromeTenancyFailed, "the tenancy isn't started")
+	}
+
+	browserContext, err := tenancy.context(tenant)
+	if nil != err {
+		return nil, err
+	}
+	tab, err := tenancy.conn.newTab("about:blank", browserContext.id)
+	if nil != err {
+		tenancy.mux.Lock()
+		browserContext.tabs--
+		unused := tenancy.unused(browserContext)
+		tenancy.mux.Unlock()
+		if unused {
+			tenancy.dispose(tenancy.conn, browserContext)
+		}
+		return nil, errs.Wrap(err, codes.ChromeTenancyFailed, fmt.Sprintf("could not open a tab for tenant '%s'", tenant))
+	}
+	tenancy.mux.Lock()
+	tenancy.tabs[tab] = &tenantTab{
+		audit: TenancyAudit{
+			Tenant:           tenant,
+			Job:              job,
+			BrowserContextID: browserContext.id,
+			TargetID:         target.ID(tab.Data().ID),
+			Acquired:         time.Now(),
+		},
+		context: browserContext,
+	}
+	tenancy.mux.Unlock()
+	return tab, nil
+}
+
+/*
+Release closes a tab opened by Acquire and records the job it served. The
+tenant's context is disposed if it was evicted or opened for the job.
+*/
+func (tenancy *Tenancy) Release(tab *Tab) error {
+	tenancy.mux.Lock()
+	acquired, ok := tenancy.tabs[tab]
+	delete(tenancy.tabs, tab)
+	conn := tenancy.conn
+	tenancy.mux.Unlock()
+	if nil == conn {
+		return errs.New(codes.ChromeTenancyFailed, "the tenancy is closed")
+	}
+	if !ok {
+		return errs.New(codes.ChromeTenancyFailed, fmt.Sprintf("tab '%s' wasn't acquired from the tenancy", tab.Data().ID))
+	}
+
+	_, err := tab.Close()
+	if nil != err {
+		err = errs.Wrap(err, codes.ChromeTenancyFailed, fmt.Sprintf("could not close the tab of job '%s'", acquired.audit.Job))
+	}
+
+	acquired.audit.Released = time.Now()
+	tenancy.chrome.logger.Info("tenant job released", logger.Fields{
+		"browserContextID": acquired.audit.BrowserContextID,
+		"duration":         acquired.audit.Released.Sub(acquired.audit.Acquired).String(),
+		"job":              acquired.audit.Job,
+		"targetID":         acquired.audit.TargetID,
+		"tenant":           acquired.audit.Tenant,
+	})
+	if nil != tenancy.policy.Audit {
+		tenancy.policy.Audit(acquired.audit)
+	}
+
+	tenancy.mux.Lock()
+	acquired.context.tabs--
+	unused := tenancy.unused(acquired.context)
+	tenancy.mux.Unlock()
+	if unused {
+		if disposeErr := tenancy.dispose(conn, acquired.context); nil == err {
+			err = disposeErr
+		}
+	}
+	return err
+}
+
+/*
+Evict disposes the browser context of a tenant, clearing its cookies, storage
+and caches. The next job of the tenant opens a new context. If tabs of the
+tenant are in use, the context is disposed when the last one is released.
+*/
+func (tenancy *Tenancy) Evict(tenant string) error {
+	tenancy.mux.Lock()
+	browserContext, ok := tenancy.contexts[tenant]
+	unused := false
+	if ok {
+		delete(tenancy.contexts, tenant)
+		browserContext.evicted = true
+		unused = tenancy.unused(browserContext)
+	}
+	conn := tenancy.conn
+	tenancy.mux.Unlock()
+	if !unused || nil == conn {
+		return nil
+	}
+	return tenancy.dispose(conn, browserContext)
+}
+
+/*
+Contexts returns the browser context of each tenant.
+*/
+func (tenancy *Tenancy) Contexts() map[string]target.BrowserContextID {
+	tenancy.mux.Lock()
+	defer tenancy.mux.Unlock()
+	contexts := make(map[string]target.BrowserContextID, len(tenancy.contexts))
+	for tenant, browserContext := range tenancy.contexts {
+		contexts[tenant] = browserContext.id
+	}
+	return contexts
+}
+
+/*
+Close closes the acquired tabs, disposes the browser contexts of the tenants
+and closes the browser connection. It returns the first failure.
+*/
+func (tenancy *Tenancy) Close() error {
+	tenancy.createMux.Lock()
+	defer tenancy.createMux.Unlock()
+	tenancy.mux.Lock()
+	contexts := map[*tenantContext]struct{}{}
+	for _, browserContext := range tenancy.contexts {
+		contexts[browserContext] = struct{}{}
+	}
+	tabs := make([]*Tab, 0, len(tenancy.tabs))
+	for tab, acquired := range tenancy.tabs {
+		contexts[acquired.context] = struct{}{}
+		tabs = append(tabs, tab)
+	}
+	for browserContext := range contexts {
+		if browserContext.disposed {
+			delete(contexts, browserContext)
+		}
+		browserContext.disposed = true
+	}
+	tenancy.contexts = make(map[string]*tenantContext)
+	tenancy.tabs = make(map[*Tab]*tenantTab)
+	conn := tenancy.conn
+	tenancy.conn = nil
+	tenancy.mux.Unlock()
+	if nil == conn {
+		return nil
+	}
+
+	var first error
+	for _, tab := range tabs {
+		if _, err := tab.Close(); nil != err && nil == first {
+			first = errs.Wrap(err, codes.ChromeTenancyFailed, fmt.Sprintf("could not close tab '%s'", tab.Data().ID))
+		}
+	}
+	for browserContext := range contexts {
+		if err := tenancy.dispose(conn, browserContext); nil != err && nil == first {
+			first = err
+		}
+	}
+	if err := conn.Close(); nil != err && nil == first {
+		first = errs.Wrap(err, codes.ChromeTenancyFailed, "could not close the browser connection")
+	}
+	return first
+}
+
+/*
+context returns the browser context a tenant's next tab is opened in, and
+counts the tab. Must be called with the creation mutex locked.
+*/
+func (tenancy *Tenancy) context(tenant string) (*tenantContext, error) {
+	tenancy.mux.Lock()
+	browserContext, ok := tenancy.contexts[tenant]
+	if ok && !tenancy.policy.ContextPerJob {
+		browserContext.tabs++
+		tenancy.mux.Unlock()
+		return browserContext, nil
+	}
+	tenancy.mux.Unlock()
+
+	result := <-tenancy.conn.Client().Target().CreateBrowserContext(&target.CreateBrowserContextParams{})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.ChromeTenancyFailed, fmt.Sprintf("could not open a browser context for tenant '%s'", tenant))
+	}
+	browserContext = &tenantContext{
+		evicted: tenancy.policy.ContextPerJob,
+		id:      result.BrowserContextID,
+		tabs:    1,
+		tenant:  tenant,
+	}
+	if !tenancy.policy.ContextPerJob {
+		tenancy.mux.Lock()
+		tenancy.contexts[tenant] = browserContext
+		tenancy.mux.Unlock()
+	}
+	return browserContext, nil
+}
+
+/*
+unused returns whether a context is evicted, no tab uses it anymore and it
+hasn't been disposed yet, and marks it disposed if so. Only the caller it
+returns true for disposes the context. Must be called with the mutex locked,
+in the same critical section that counts the context's tabs.
+*/
+func (tenancy *Tenancy) unused(browserContext *tenantContext) bool {
+	if browserContext.disposed || !browserContext.evicted || 0 != browserContext.tabs {
+		return false
+	}
+	browserContext.disposed = true
+	return true
+}
+
+/*
+dispose disposes a browser context.
+*/
+func (tenancy *Tenancy) dispose(conn *BrowserConnection, browserContext *tenantContext) error {
+	result := <-conn.Client().Target().DisposeBrowserContext(&target.DisposeBrowserContextParams{
+		BrowserContextID: browserContext.id,
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.ChromeTenancyFailed, fmt.Sprintf("could not dispose the browser context of tenant '%s'", browserContext.tenant))
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+newTestTenancy returns a started tenancy of a cdptest browser that numbers the
+browser contexts, targets and sessions it opens, and the audit records of the
+tenancy.
+*/
+func newTestTenancy(t *testing.T, policy *TenancyPolicy) (*Tenancy, *cdptest.Server, func() []TenancyAudit, func()) {
+	browser, cdp, stop := newCDPBrowser(t)
+	mux := &sync.Mutex{}
+	var contexts, targets, sessions int
+	cdp.Handle("Target.createBrowserContext", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		contexts++
+		return map[string]interface{}{"browserContextId": fmt.Sprintf("ctx%d", contexts)}, nil
+	})
+	cdp.Handle("Target.createTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		targets++
+		return map[string]interface{}{"targetId": fmt.Sprintf("target%d", targets)}, nil
+	})
+	cdp.Handle("Target.attachToTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		sessions++
+		return map[string]interface{}{"sessionId": fmt.Sprintf("session%d", sessions)}, nil
+	})
+
+	var records []TenancyAudit
+	if nil == policy {
+		policy = &TenancyPolicy{}
+	}
+	policy.Audit = func(record TenancyAudit) {
+		mux.Lock()
+		records = append(records, record)
+		mux.Unlock()
+	}
+	tenancy := NewTenancy(browser, policy)
+	if err := tenancy.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	return tenancy, cdp, func() []TenancyAudit {
+			mux.Lock()
+			defer mux.Unlock()
+			return append([]TenancyAudit{}, records...)
+		}, func() {
+			tenancy.Close()
+			stop()
+		}
+}
+
+/*
+commandContexts returns the browser context IDs of the commands of a method.
+*/
+func commandContexts(cdp *cdptest.Server, method string) []string {
+	var contexts []string
+	for _, command := range cdp.Commands() {
+		if method == command.Method {
+			params := struct {
+				BrowserContextID string `json:"browserContextId"`
+			}{}
+			json.Unmarshal(command.Params, &params)
+			contexts = append(contexts, params.BrowserContextID)
+		}
+	}
+	return contexts
+}
+
+func TestTenancy(t *testing.T) {
+	tenancy, cdp, records, stop := newTestTenancy(t, nil)
+	defer stop()
+
+	acme1, err := tenancy.Acquire(context.Background(), "acme", "job1")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	acme2, err := tenancy.Acquire(context.Background(), "acme", "job2")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	globex, err := tenancy.Acquire(context.Background(), "globex", "job3")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if acme1 == acme2 || "target1" != acme1.Data().ID || "target3" != globex.Data().ID {
+		t.Errorf("Expected a tab per job, got '%s' '%s' '%s'", acme1.Data().ID, acme2.Data().ID, globex.Data().ID)
+	}
+	if contexts := tenancy.Contexts(); 2 != len(contexts) || "ctx1" != contexts["acme"] || "ctx2" != contexts["globex"] {
+		t.Errorf("Expected a context per tenant, got %v", contexts)
+	}
+	if contexts := commandContexts(cdp, "Target.createTarget"); 3 != len(contexts) || "ctx1" != contexts[0] || "ctx1" != contexts[1] || "ctx2" != contexts[2] {
+		t.Errorf("Expected the tabs to be opened in the tenants' contexts, got %v", contexts)
+	}
+
+	for _, tab := range []*Tab{acme1, acme2, globex} {
+		if err := tenancy.Release(tab); nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+		}
+	}
+	if err := tenancy.Release(acme1); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	audit := records()
+	if 3 != len(audit) {
+		t.Fatalf("Expected 3 audit records, got %+v", audit)
+	}
+	expected := []TenancyAudit{
+		{Tenant: "acme", Job: "job1", BrowserContextID: "ctx1", TargetID: "target1"},
+		{Tenant: "acme", Job: "job2", BrowserContextID: "ctx1", TargetID: "target2"},
+		{Tenant: "globex", Job: "job3", BrowserContextID: "ctx2", TargetID: "target3"},
+	}
+	for a, record := range audit {
+		if expected[a].Tenant != record.Tenant || expected[a].Job != record.Job ||
+			expected[a].BrowserContextID != record.BrowserContextID || expected[a].TargetID != record.TargetID ||
+			record.Released.Before(record.Acquired) {
+			t.Errorf("Expected %+v, got %+v", expected[a], record)
+		}
+	}
+	var closed int
+	for _, command := range cdp.Commands() {
+		if "Target.closeTarget" == command.Method {
+			closed++
+		}
+	}
+	if 3 != closed {
+		t.Errorf("Expected 3 closed targets, got %d", closed)
+	}
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); 0 != len(disposed) {
+		t.Errorf("Expected the contexts to be kept, got %v", disposed)
+	}
+
+	// An evicted tenant gets a fresh context once its tabs are released.
+	tab, err := tenancy.Acquire(context.Background(), "acme", "job4")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if err := tenancy.Evict("acme"); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); 0 != len(disposed) {
+		t.Errorf("Expected the context to be kept while it's used, got %v", disposed)
+	}
+	tenancy.Release(tab)
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); 1 != len(disposed) || "ctx1" != disposed[0] {
+		t.Errorf("Expected the evicted context to be disposed, got %v", disposed)
+	}
+	if tab, err = tenancy.Acquire(context.Background(), "acme", "job5"); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if contexts := tenancy.Contexts(); "ctx3" != contexts["acme"] {
+		t.Errorf("Expected a new context, got %v", contexts)
+	}
+
+	if err := tenancy.Close(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); 3 != len(disposed) {
+		t.Errorf("Expected every context to be disposed, got %v", disposed)
+	}
+	if _, err := tenancy.Acquire(context.Background(), "acme", "job6"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if err := tenancy.Release(tab); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestTenancyEvictRace(t *testing.T) {
+	tenancy, cdp, _, stop := newTestTenancy(t, nil)
+	defer stop()
+
+	// Every tenant's context is evicted while its last tab is released.
+	tenants := 50
+	for a := 0; a < tenants; a++ {
+		tenant := fmt.Sprintf("tenant%d", a)
+		tab, err := tenancy.Acquire(context.Background(), tenant, "job")
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tenancy.Evict(tenant)
+		}()
+		go func() {
+			defer wg.Done()
+			tenancy.Release(tab)
+		}()
+		wg.Wait()
+	}
+
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); tenants != len(disposed) {
+		t.Errorf("Expected every context to be disposed once, got %v", disposed)
+	}
+}
+
+func TestTenancyContextPerJob(t *testing.T) {
+	tenancy, cdp, records, stop := newTestTenancy(t, &TenancyPolicy{ContextPerJob: true})
+	defer stop()
+
+	for _, job := range []string{"job1", "job2"} {
+		tab, err := tenancy.Acquire(context.Background(), "acme", job)
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		if err := tenancy.Release(tab); nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+		}
+	}
+	if disposed := commandContexts(cdp, "Target.disposeBrowserContext"); 2 != len(disposed) || "ctx1" != disposed[0] || "ctx2" != disposed[1] {
+		t.Errorf("Expected a context per job, got %v", disposed)
+	}
+	if audit := records(); 2 != len(audit) || "ctx1" != audit[0].BrowserContextID || "ctx2" != audit[1].BrowserContextID {
+		t.Errorf("Expected a context per job, got %+v", audit)
+	}
+	if 0 != len(tenancy.Contexts()) {
+		t.Errorf("Expected no tenant contexts, got %v", tenancy.Contexts())
+	}
+
+	cdp.Handle("Target.createBrowserContext", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: -32000, Message: "failed"}
+	})
+	if _, err := tenancy.Acquire(context.Background(), "acme", "job3"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := tenancy.Acquire(ctx, "acme", "job4"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestIsolatedScheduler(t *testing.T) {
+	tenancy, _, records, stop := newTestTenancy(t, nil)
+	defer stop()
+	scheduler := NewIsolatedScheduler(tenancy, 0)
+	defer scheduler.Close()
+
+	var tabs []string
+	for _, job := range []*Job{{Tenant: "acme", ID: "invoice"}, {Tenant: "globex"}} {
+		job.Run = func(ctx context.Context, tab *Tab) error {
+			tabs = append(tabs, tab.Data().ID)
+			return nil
+		}
+		if err := scheduler.Run(context.Background(), job); nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+		}
+	}
+	if 2 != len(tabs) || "target1" != tabs[0] || "target2" != tabs[1] {
+		t.Errorf("Expected the jobs to run in tenant tabs, got %v", tabs)
+	}
+	audit := records()
+	if 2 != len(audit) || "invoice" != audit[0].Job || "ctx1" != audit[0].BrowserContextID ||
+		"2" != audit[1].Job || target.BrowserContextID("ctx2") != audit[1].BrowserContextID {
+		t.Errorf("Expected the jobs to be audited, got %+v", audit)
+	}
+}
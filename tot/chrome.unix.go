@@ -0,0 +1,41 @@
+package chrome
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+/*
+unixTransport returns an HTTP transport that sends developer tools endpoint
+queries over the unix domain socket.
+*/
+func (chrome *Chrome) unixTransport() *http.Transport {
+	path := chrome.unixSocket
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}
+}
+
+/*
+websocketURL parses a websocket URL reported by the developer tools endpoints.
+When the endpoints are served on a unix domain socket the reported host is
+meaningless, so the URL is rewritten to address the socket:
+
+	unix:///path/to/devtools.sock#/devtools/page/<id>
+*/
+func (chrome *Chrome) websocketURL(raw string) (*url.URL, error) {
+	websocketURL, err := url.Parse(raw)
+	if nil != err || "" == chrome.unixSocket || "unix" == websocketURL.Scheme {
+		return websocketURL, err
+	}
+	return &url.URL{
+		Scheme:   "unix",
+		Path:     chrome.unixSocket,
+		Fragment: websocketURL.RequestURI(),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnixSocketQuery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-chrome-unix")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "devtools.sock")
+	listener, err := net.Listen("unix", path)
+	if nil != err {
+		t.Skipf("unix domain sockets aren't available: %v", err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"browser": "HeadlessChrome/120.0", "webSocketDebuggerUrl": "ws://localhost/devtools/browser/1"}`))
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	browser := New(WithUnixSocket(path))
+	version := &Version{}
+	if _, err := browser.Query("/json/version", url.Values{}, version); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "HeadlessChrome/120.0" != version.Browser {
+		t.Errorf("Expected the version to be read over the unix socket, got '%s'", version.Browser)
+	}
+	websocketURL, err := browser.websocketURL(version.WebSocketDebuggerURL)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if expected := "unix://" + path + "#/devtools/browser/1"; expected != websocketURL.String() {
+		t.Errorf("Expected '%s', got '%s'", expected, websocketURL.String())
+	}
+}
+
+func TestUnixSocketEnv(t *testing.T) {
+	os.Setenv(EnvWebSocketURL, "unix:///tmp/devtools.sock")
+	defer os.Unsetenv(EnvWebSocketURL)
+	browser := New()
+	if "/tmp/devtools.sock" != browser.unixSocket {
+		t.Errorf("Expected the unix socket to be set from the environment, got '%s'", browser.unixSocket)
+	}
+}
@@ -0,0 +1,174 @@
+package chrome
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+userDataSkip lists the user data entries that aren't copied from a template:
+the lock files of the browser that created the template, which would prevent
+the copy from being opened, the marker of a directory created by this package,
+and caches, which are large and rebuilt on demand.
+*/
+var userDataSkip = map[string]bool{
+	"SingletonCookie": true,
+	"SingletonLock":   true,
+	"SingletonSocket": true,
+	"lockfile":        true,
+	userDataMarker:    true,
+	"Cache":           true,
+	"Code Cache":      true,
+	"GPUCache":        true,
+	"GrShaderCache":   true,
+	"ShaderCache":     true,
+}
+
+/*
+CloneUserData copies a template user data directory into a new temporary
+directory and returns its path. The template is typically the user data
+directory of a browser that was set up once by hand or by a script: logged in,
+with extensions installed and preferences set. Lock files and caches aren't
+copied.
+
+The caller is responsible for removing the copy. WithUserDataTemplate manages
+the copy for a Chrome instance.
+*/
+func CloneUserData(template string) (string, error) {
+	info, err := os.Stat(template)
+	if nil != err {
+		return "", errs.Wrap(err, codes.ChromeUserDataFailed, fmt.Sprintf("invalid user data template '%s'", template))
+	}
+	if !info.IsDir() {
+		return "", errs.New(codes.ChromeUserDataFailed, fmt.Sprintf("user data template '%s' is not a directory", template))
+	}
+
+	dir, err := newUserDataDir()
+	if nil != err {
+		return "", errs.Wrap(err, codes.ChromeUserDataFailed, "could not create user data directory")
+	}
+	if err := copyUserData(template, dir); nil != err {
+		os.RemoveAll(dir)
+		return "", errs.Wrap(err, codes.ChromeUserDataFailed, fmt.Sprintf("could not copy user data template '%s'", template))
+	}
+	return dir, nil
+}
+
+/*
+copyUserData recursively copies the contents of a user data directory,
+preserving file modes and symlinks.
+*/
+func copyUserData(src, dst string) error {
+	entries, err := ioutil.ReadDir(src)
+	if nil != err {
+		return err
+	}
+	for _, entry := range entries {
+		if userDataSkip[entry.Name()] {
+			continue
+		}
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		switch {
+		case entry.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(from)
+			if nil != err {
+				return err
+			}
+			if err := os.Symlink(link, to); nil != err {
+				return err
+			}
+		case entry.IsDir():
+			if err := os.Mkdir(to, entry.Mode().Perm()); nil != err {
+				return err
+			}
+			if err := copyUserData(from, to); nil != err {
+				return err
+			}
+		case entry.Mode().IsRegular():
+			if err := copyFile(from, to, entry.Mode().Perm()); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+/*
+copyFile copies a regular file.
+*/
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if nil != err {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if nil != err {
+		return err
+	}
+	if _, err := io.Copy(out, in); nil != err {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+/*
+WithUserDataTemplate starts the browser from a copy of a template user data
+directory, so every browser starts from the same known state, such as an
+authenticated session, and changes made while it runs never reach the
+template:
+
+	browser := chrome.New(chrome.WithUserDataTemplate("/srv/profiles/logged-in"))
+	if err := browser.Launch(); nil != err {
+		...
+	}
+	defer browser.Close()
+
+The copy is made by Launch and removed by Close, or by Launch if the browser
+fails to start. See CloneUserData for what is copied.
+*/
+func WithUserDataTemplate(template string) Option {
+	return func(chrome *Chrome) {
+		chrome.userDataTemplate = template
+	}
+}
+
+/*
+cloneUserDataTemplate copies the user data template, if any, and points the
+user-data-dir flag at the copy.
+*/
+func (chrome *Chrome) cloneUserDataTemplate() error {
+	if "" == chrome.userDataTemplate {
+		return nil
+	}
+	dir, err := CloneUserData(chrome.userDataTemplate)
+	if nil != err {
+		return err
+	}
+	chrome.userDataDir = dir
+	chrome.Flags().Set("user-data-dir", dir)
+	return nil
+}
+
+/*
+removeUserData removes the user data directory created by Launch, if any.
+*/
+func (chrome *Chrome) removeUserData() error {
+	if "" == chrome.userDataDir {
+		return nil
+	}
+	dir := chrome.userDataDir
+	chrome.userDataDir = ""
+	if err := os.RemoveAll(dir); nil != err {
+		return errs.Wrap(err, codes.ChromeUserDataFailed, fmt.Sprintf("could not remove user data directory '%s'", dir))
+	}
+	return nil
+}
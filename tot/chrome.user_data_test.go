@@ -0,0 +1,94 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newUserDataTemplate(t *testing.T) string {
+	template, err := ioutil.TempDir("", "template")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	os.MkdirAll(filepath.Join(template, "Default", "Cache"), 0700)
+	ioutil.WriteFile(filepath.Join(template, "Default", "Cookies"), []byte("cookies"), 0600)
+	ioutil.WriteFile(filepath.Join(template, "Default", "Cache", "data_0"), []byte("cache"), 0600)
+	ioutil.WriteFile(filepath.Join(template, "Local State"), []byte("{}"), 0644)
+	os.Symlink("host-1234", filepath.Join(template, "SingletonLock"))
+	os.Symlink("Local State", filepath.Join(template, "State"))
+	return template
+}
+
+func TestCloneUserData(t *testing.T) {
+	template := newUserDataTemplate(t)
+	defer os.RemoveAll(template)
+
+	dir, err := CloneUserData(template)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, "Default", "Cookies")); "cookies" != string(data) {
+		t.Errorf("Expected the cookies to be copied, got '%s'", data)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "Default", "Cookies")); nil != err || 0600 != info.Mode().Perm() {
+		t.Errorf("Expected the file mode to be preserved, got %v %v", info, err)
+	}
+	if link, _ := os.Readlink(filepath.Join(dir, "State")); "Local State" != link {
+		t.Errorf("Expected the symlink to be copied, got '%s'", link)
+	}
+	for _, skipped := range []string{"SingletonLock", filepath.Join("Default", "Cache")} {
+		if _, err := os.Lstat(filepath.Join(dir, skipped)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s not to be copied", skipped)
+		}
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Default", "Cookies"), []byte("changed"), 0600)
+	if data, _ := ioutil.ReadFile(filepath.Join(template, "Default", "Cookies")); "cookies" != string(data) {
+		t.Errorf("Expected the template to be unchanged, got '%s'", data)
+	}
+
+	if _, err := CloneUserData(filepath.Join(template, "missing")); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if _, err := CloneUserData(filepath.Join(template, "Local State")); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestWithUserDataTemplate(t *testing.T) {
+	template := newUserDataTemplate(t)
+	defer os.RemoveAll(template)
+	workdir, _ := ioutil.TempDir("", "workdir")
+	defer os.RemoveAll(workdir)
+
+	chrome := New(
+		WithFlags(&Flags{}),
+		WithBinary("/does/not/exist"),
+		WithUserDataTemplate(template),
+		WithWorkdir(workdir),
+	)
+	if err := chrome.Launch(); nil == err {
+		t.Fatalf("Expected error, got nil")
+	}
+	dir, _ := chrome.Flags().Get("user-data-dir")
+	if "" == dir || template == dir {
+		t.Fatalf("Expected a copy of the template, got %v", dir)
+	}
+	if _, err := os.Stat(dir.(string)); !os.IsNotExist(err) {
+		t.Errorf("Expected the copy to be removed when the launch fails")
+	}
+
+	if err := chrome.cloneUserDataTemplate(); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	dir, _ = chrome.Flags().Get("user-data-dir")
+	if err := chrome.Close(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if _, err := os.Stat(dir.(string)); !os.IsNotExist(err) {
+		t.Errorf("Expected the copy to be removed when the browser is closed")
+	}
+}
@@ -0,0 +1,557 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+WarmPolicy configures the tabs a WarmPool keeps ready and how they're
+refreshed.
+*/
+type WarmPolicy struct {
+	// Optional. Number of warm tabs kept ready. Defaults to 1, can be changed
+	// with Resize.
+	Size int
+
+	// Optional. Time a warm tab is kept before it's closed and replaced, so
+	// that requests aren't served by tabs that idled for long. 0 keeps warm
+	// tabs until they're acquired.
+	MaxAge time.Duration
+
+	// Optional. Interval at which expired tabs are replaced and the pool is
+	// refilled. Defaults to half of MaxAge, or 1 minute without a MaxAge.
+	// The pool is also refilled as soon as a tab is acquired.
+	RefreshInterval time.Duration
+
+	// Optional. Domains enabled in every warm tab, like "Page", "Network" and
+	// "Runtime".
+	Domains []string
+
+	// Optional. Emulation overrides applied to every warm tab.
+	Overrides *OverrideState
+
+	// Optional. Prepares a warm tab after the domains are enabled and the
+	// overrides applied, for example to install scripts or handlers.
+	WarmUp func(tab *Tab) error
+
+	// Optional. Number of times a tab returned with Release is reused before
+	// it's closed. 0 closes released tabs.
+	MaxUses int
+
+	// Optional. Caches the outputs of Render.
+	Cache *RenderCache
+}
+
+/*
+WarmPoolStats are the counters of a WarmPool.
+*/
+type WarmPoolStats struct {
+	// The number of warm tabs ready.
+	Warm int
+
+	// The number of acquisitions served by a warm tab.
+	Hits int
+
+	// The number of acquisitions that had to create a tab.
+	Misses int
+
+	// The number of tabs created.
+	Created int
+
+	// The number of released tabs that were reused.
+	Reused int
+
+	// The number of warm tabs closed because they reached MaxAge.
+	Expired int
+
+	// The number of tabs that could not be warmed.
+	Failures int
+}
+
+/*
+warmTab is a tab ready to be acquired.
+*/
+type warmTab struct {
+	tab    *Tab
+	uses   int
+	warmed time.Time
+}
+
+/*
+WarmPool keeps pre-created "warm" tabs of a browser ready, navigated to
+about:blank with domains enabled and overrides applied, so that a render
+request doesn't wait for a tab to be created and prepared:
+
+	pool := chrome.NewWarmPool(browser, &chrome.WarmPolicy{
+		Size:    4,
+		MaxAge:  10 * time.Minute,
+		Domains: []string{"Page", "Network", "Runtime"},
+	})
+	if err := pool.Start(); nil != err {
+		...
+	}
+	defer pool.Close()
+
+	tab, err := pool.Acquire(ctx)
+	if nil != err {
+		...
+	}
+	defer pool.Release(tab)
+
+The pool is refilled in the background as tabs are acquired. An acquisition
+from an empty pool creates and warms a tab on demand.
+*/
+type WarmPool struct {
+	acquired  map[*Tab]int
+	chrome    *Chrome
+	createMux *sync.Mutex
+	done      chan struct{}
+	mux       *sync.Mutex
+	policy    WarmPolicy
+	refill    chan struct{}
+	releasing int
+	stats     WarmPoolStats
+	stop      chan struct{}
+	tabs      []*warmTab
+}
+
+/*
+NewWarmPool returns a warm pool for a browser. A nil policy keeps a single warm
+tab. The pool is filled by Start.
+*/
+func NewWarmPool(chrome *Chrome, policy *WarmPolicy) *WarmPool {
+	pool := &WarmPool{
+		acquired:  make(map[*Tab]int),
+		chrome:    chrome,
+		createMux: &sync.Mutex{},
+		mux:       &sync.Mutex{},
+		refill:    make(chan struct{}, 1),
+	}
+	if nil != policy {
+		pool.policy = *policy
+	}
+	if pool.policy.Size <= 0 {
+		pool.policy.Size = 1
+	}
+	return pool
+}
+
+/*
+Start fills the pool and starts refreshing it. It returns once the pool is
+full, or with the error of the first tab that could not be warmed.
+*/
+func (pool *WarmPool) Start() error {
+	pool.mux.Lock()
+	if nil != pool.stop {
+		pool.mux.Unlock()
+		return nil
+	}
+	pool.stop = make(chan struct{})
+	pool.done = make(chan struct{})
+	stop, done := pool.stop, pool.done
+	pool.mux.Unlock()
+
+	err := pool.fill(stop)
+	go pool.refresh(stop, done)
+	return err
+}
+
+/*
+Acquire returns a warm tab, or creates and warms one if the pool is empty. The
+tab should be returned with Release or closed when it's no longer needed. If
+the context is done before a tab is created, Acquire returns an error and the
+tab is kept in the pool when it's ready, or closed if the pool is full.
+*/
+func (pool *WarmPool) Acquire(ctx context.Context) (*Tab, error) {
+	pool.mux.Lock()
+	if nil == pool.stop {
+		pool.mux.Unlock()
+		return nil, errs.New(codes.ChromeWarmPoolFailed, "the warm pool isn't started")
+	}
+	var warm *warmTab
+	var expired []*Tab
+	for nil == warm && 0 < len(pool.tabs) {
+		warm, pool.tabs = pool.tabs[0], pool.tabs[1:]
+		if pool.expired(warm) {
+			expired = append(expired, warm.tab)
+			pool.stats.Expired++
+			warm = nil
+		}
+	}
+	if nil != warm {
+		pool.stats.Hits++
+		pool.acquired[warm.tab] = warm.uses
+	} else {
+		pool.stats.Misses++
+	}
+	pool.mux.Unlock()
+
+	pool.close(expired...)
+	pool.requestRefill()
+	if nil != warm {
+		return warm.tab, nil
+	}
+
+	if err := ctx.Err(); nil != err {
+		return nil, errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not acquire a tab")
+	}
+	created := make(chan createdTab, 1)
+	go func() {
+		tab, err := pool.create()
+		created <- createdTab{err: err, tab: tab}
+	}()
+	select {
+	case result := <-created:
+		if nil != result.err {
+			return nil, result.err
+		}
+		pool.mux.Lock()
+		pool.acquired[result.tab] = 0
+		pool.mux.Unlock()
+		return result.tab, nil
+	case <-ctx.Done():
+		go func() {
+			if result := <-created; nil == result.err {
+				pool.keep(result.tab)
+			}
+		}()
+		return nil, errs.Wrap(ctx.Err(), codes.ChromeWarmPoolFailed, "could not acquire a tab")
+	}
+}
+
+/*
+createdTab is the result of creating a tab for an acquisition.
+*/
+type createdTab struct {
+	err error
+	tab *Tab
+}
+
+/*
+keep adds a tab created for an acquisition that was abandoned to the pool, or
+closes it if the pool is full or closed.
+*/
+func (pool *WarmPool) keep(tab *Tab) {
+	pool.mux.Lock()
+	if nil == pool.stop || pool.free() <= 0 {
+		pool.mux.Unlock()
+		pool.close(tab)
+		return
+	}
+	pool.tabs = append(pool.tabs, &warmTab{tab: tab, warmed: time.Now()})
+	pool.mux.Unlock()
+}
+
+/*
+Release returns an acquired tab. The tab is cleaned up, navigated to
+about:blank and warmed again if it can be reused and the pool isn't full,
+otherwise it's closed. A reused tab takes precedence over a tab that is being
+created to refill the pool, the created tab is closed if there's no room left
+for it.
+*/
+func (pool *WarmPool) Release(tab *Tab) error {
+	pool.mux.Lock()
+	uses, ok := pool.acquired[tab]
+	delete(pool.acquired, tab)
+	reuse := ok && nil != pool.stop && uses < pool.policy.MaxUses && pool.free() > 0
+	if reuse {
+		pool.releasing++
+	}
+	pool.mux.Unlock()
+
+	if !reuse {
+		pool.close(tab)
+		return nil
+	}
+	err := pool.prepare(tab)
+	pool.mux.Lock()
+	pool.releasing--
+	if nil != err {
+		pool.mux.Unlock()
+		pool.close(tab)
+		// The refills skipped the slot the tab reserved.
+		pool.requestRefill()
+		return err
+	}
+	if nil == pool.stop || len(pool.tabs) >= pool.policy.Size {
+		pool.mux.Unlock()
+		pool.close(tab)
+		return nil
+	}
+	pool.tabs = append(pool.tabs, &warmTab{tab: tab, uses: uses + 1, warmed: time.Now()})
+	pool.stats.Reused++
+	pool.mux.Unlock()
+	return nil
+}
+
+/*
+prepare cleans up a released tab, navigates it to about:blank and warms it
+again.
+*/
+func (pool *WarmPool) prepare(tab *Tab) error {
+	if err := tab.Cleanup(); nil != err {
+		return errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not clean up the released tab")
+	}
+	if result := <-tab.Page().Navigate(&page.NavigateParams{URL: "about:blank"}); nil != result.Err {
+		return errs.Wrap(result.Err, codes.ChromeWarmPoolFailed, "could not navigate the released tab to about:blank")
+	}
+	if err := pool.warm(tab); nil != err {
+		pool.failed()
+		return err
+	}
+	return nil
+}
+
+/*
+free returns the number of warm tabs missing from the pool, not counting the
+slots reserved by released tabs being prepared for reuse. Must be called with
+the mutex locked.
+*/
+func (pool *WarmPool) free() int {
+	return pool.policy.Size - len(pool.tabs) - pool.releasing
+}
+
+/*
+Resize changes the number of warm tabs kept ready. Excess tabs are closed and
+missing tabs are created in the background.
+*/
+func (pool *WarmPool) Resize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	pool.mux.Lock()
+	pool.policy.Size = size
+	var excess []*Tab
+	for len(pool.tabs) > size {
+		excess = append(excess, pool.tabs[len(pool.tabs)-1].tab)
+		pool.tabs = pool.tabs[:len(pool.tabs)-1]
+	}
+	pool.mux.Unlock()
+
+	pool.close(excess...)
+	pool.requestRefill()
+}
+
+/*
+Stats returns the counters of the pool.
+*/
+func (pool *WarmPool) Stats() WarmPoolStats {
+	pool.mux.Lock()
+	defer pool.mux.Unlock()
+	stats := pool.stats
+	stats.Warm = len(pool.tabs)
+	return stats
+}
+
+/*
+Close stops refreshing the pool and closes the warm tabs. Acquired tabs aren't
+closed, they're closed when they're released.
+*/
+func (pool *WarmPool) Close() error {
+	pool.mux.Lock()
+	stop, done := pool.stop, pool.done
+	pool.stop = nil
+	tabs := pool.tabs
+	pool.tabs = nil
+	pool.mux.Unlock()
+
+	if nil != stop {
+		close(stop)
+		<-done
+	}
+	closing := make([]*Tab, len(tabs))
+	for a, warm := range tabs {
+		closing[a] = warm.tab
+	}
+	return pool.close(closing...)
+}
+
+/*
+refresh replaces expired tabs and refills the pool until it's stopped.
+*/
+func (pool *WarmPool) refresh(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(pool.refreshInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			pool.expire()
+		case <-pool.refill:
+		}
+		if err := pool.fill(stop); nil != err {
+			pool.chrome.logger.Warn("could not refill the warm pool", logger.Fields{"error": err.Error()})
+		}
+	}
+}
+
+/*
+fill creates tabs until the pool is full. It stops at the first tab that can't
+be warmed so that a broken browser isn't hammered, the next refresh retries.
+*/
+func (pool *WarmPool) fill(stop chan struct{}) error {
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		pool.mux.Lock()
+		full := pool.free() <= 0
+		pool.mux.Unlock()
+		if full {
+			return nil
+		}
+
+		tab, err := pool.create()
+		if nil != err {
+			return err
+		}
+		pool.mux.Lock()
+		if pool.stop != stop || pool.free() <= 0 {
+			pool.mux.Unlock()
+			pool.close(tab)
+			return nil
+		}
+		pool.tabs = append(pool.tabs, &warmTab{tab: tab, warmed: time.Now()})
+		pool.mux.Unlock()
+	}
+}
+
+/*
+expire closes the warm tabs that reached the maximum age.
+*/
+func (pool *WarmPool) expire() {
+	pool.mux.Lock()
+	var expired []*Tab
+	tabs := pool.tabs[:0]
+	for _, warm := range pool.tabs {
+		if pool.expired(warm) {
+			expired = append(expired, warm.tab)
+			pool.stats.Expired++
+			continue
+		}
+		tabs = append(tabs, warm)
+	}
+	pool.tabs = tabs
+	pool.mux.Unlock()
+
+	pool.close(expired...)
+}
+
+/*
+expired returns whether a warm tab reached the maximum age.
+*/
+func (pool *WarmPool) expired(warm *warmTab) bool {
+	return pool.policy.MaxAge > 0 && time.Since(warm.warmed) >= pool.policy.MaxAge
+}
+
+/*
+create creates and warms a tab. Tabs are created one at a time because the
+browser's tab list isn't safe for concurrent use.
+*/
+func (pool *WarmPool) create() (*Tab, error) {
+	pool.createMux.Lock()
+	tab, err := pool.chrome.NewTab("about:blank")
+	pool.createMux.Unlock()
+	if nil != err {
+		pool.failed()
+		return nil, errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not create a tab")
+	}
+	pool.mux.Lock()
+	pool.stats.Created++
+	pool.mux.Unlock()
+
+	if err := pool.warm(tab); nil != err {
+		pool.failed()
+		pool.close(tab)
+		return nil, err
+	}
+	return tab, nil
+}
+
+/*
+warm enables the domains of the policy in a tab, applies its overrides and
+runs its warm-up function.
+*/
+func (pool *WarmPool) warm(tab *Tab) error {
+	for _, domain := range pool.policy.Domains {
+		response := <-tab.Socket().SendCommand(socket.NewCommand(tab.Socket(), domain+".enable", nil))
+		if nil != response.Error && 0 != response.Error.Code {
+			return errs.Wrap(response.Err(), codes.ChromeWarmPoolFailed, fmt.Sprintf("could not enable the %s domain", domain))
+		}
+	}
+	if nil != pool.policy.Overrides {
+		if err := tab.Overrides().Restore(*pool.policy.Overrides); nil != err {
+			return errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not apply the overrides")
+		}
+	}
+	if nil != pool.policy.WarmUp {
+		if err := pool.policy.WarmUp(tab); nil != err {
+			return errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not warm up the tab")
+		}
+	}
+	return nil
+}
+
+/*
+close closes tabs, logging failures. It returns the first failure.
+*/
+func (pool *WarmPool) close(tabs ...*Tab) error {
+	var first error
+	for _, tab := range tabs {
+		pool.createMux.Lock()
+		_, err := tab.Close()
+		pool.createMux.Unlock()
+		if nil != err {
+			pool.chrome.logger.Debug("could not close a warm tab", logger.Fields{"error": err.Error(), "tabID": tab.Data().ID})
+			if nil == first {
+				first = errs.Wrap(err, codes.ChromeWarmPoolFailed, "could not close a warm tab")
+			}
+		}
+	}
+	return first
+}
+
+/*
+failed counts a tab that could not be warmed.
+*/
+func (pool *WarmPool) failed() {
+	pool.mux.Lock()
+	pool.stats.Failures++
+	pool.mux.Unlock()
+}
+
+/*
+requestRefill wakes the refresher up to refill the pool.
+*/
+func (pool *WarmPool) requestRefill() {
+	select {
+	case pool.refill <- struct{}{}:
+	default:
+	}
+}
+
+/*
+refreshInterval returns the interval at which the pool is refreshed.
+*/
+func (pool *WarmPool) refreshInterval() time.Duration {
+	switch {
+	case pool.policy.RefreshInterval > 0:
+		return pool.policy.RefreshInterval
+	case pool.policy.MaxAge > 0:
+		return pool.policy.MaxAge / 2
+	}
+	return time.Minute
+}
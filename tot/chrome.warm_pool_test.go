@@ -0,0 +1,231 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+waitForWarm waits for a pool to have the specified number of warm tabs.
+*/
+func waitForWarm(t *testing.T, pool *WarmPool, warm int) {
+	deadline := time.Now().Add(2 * time.Second)
+	for warm != pool.Stats().Warm {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d warm tabs, got %+v", warm, pool.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWarmPool(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+
+	mux := &sync.Mutex{}
+	var warmed []*Tab
+	// Refills wait for the gate while it's set, released tabs don't.
+	var refillGate chan struct{}
+	var released *Tab
+	pool := NewWarmPool(browser, &WarmPolicy{
+		Size:    2,
+		Domains: []string{"Page", "Runtime"},
+		Overrides: &OverrideState{
+			UserAgent: &network.SetUserAgentOverrideParams{UserAgent: "warm"},
+		},
+		WarmUp: func(tab *Tab) error {
+			mux.Lock()
+			warmed = append(warmed, tab)
+			gate, reused := refillGate, tab == released
+			mux.Unlock()
+			if nil != gate && !reused {
+				<-gate
+			}
+			return nil
+		},
+		MaxUses: 1,
+	})
+	if _, err := pool.Acquire(context.Background()); nil == err {
+		t.Errorf("Expected an error before the pool is started")
+	}
+	if err := pool.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer pool.Close()
+	if stats := pool.Stats(); 2 != stats.Warm || 2 != stats.Created {
+		t.Fatalf("Expected 2 warm tabs, got %+v", stats)
+	}
+
+	counts := map[string]int{}
+	for _, command := range cdp.Commands() {
+		counts[command.Method]++
+	}
+	for _, method := range []string{"Page.enable", "Runtime.enable", "Network.setUserAgentOverride"} {
+		if 2 != counts[method] {
+			t.Errorf("Expected %s to be sent to both tabs, got %d", method, counts[method])
+		}
+	}
+
+	tab, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	mux.Lock()
+	if tab != warmed[0] {
+		t.Errorf("Expected the oldest warm tab")
+	}
+	mux.Unlock()
+	waitForWarm(t, pool, 2)
+	if stats := pool.Stats(); 1 != stats.Hits || 3 != stats.Created {
+		t.Errorf("Expected a hit and a refill, got %+v", stats)
+	}
+
+	// The pool is full, so the released tab is closed rather than reused.
+	if err := pool.Release(tab); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if stats := pool.Stats(); 0 != stats.Reused {
+		t.Errorf("Expected the released tab to be closed, got %+v", stats)
+	}
+
+	pool.Resize(1)
+	if 1 != pool.Stats().Warm {
+		t.Errorf("Expected 1 warm tab, got %d", pool.Stats().Warm)
+	}
+	tab, _ = pool.Acquire(context.Background())
+	waitForWarm(t, pool, 1)
+	pool.Resize(0)
+	if err := pool.Release(tab); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	// A released tab takes the last slot before the refill that the next
+	// acquisition started.
+	pool.Resize(1)
+	tab, _ = pool.Acquire(context.Background())
+	waitForWarm(t, pool, 1)
+	gate := make(chan struct{})
+	mux.Lock()
+	refillGate, released = gate, tab
+	mux.Unlock()
+	other, _ := pool.Acquire(context.Background())
+	if err := pool.Release(tab); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if stats := pool.Stats(); 1 != stats.Reused || 1 != stats.Warm {
+		t.Errorf("Expected the released tab to be reused, got %+v", stats)
+	}
+	close(gate)
+	time.Sleep(20 * time.Millisecond)
+	if stats := pool.Stats(); 1 != stats.Warm {
+		t.Errorf("Expected the refilled tab to be closed, got %+v", stats)
+	}
+	pool.Release(other)
+}
+
+func TestWarmPoolAcquireCanceled(t *testing.T) {
+	browser, _, stop := newCDPBrowser(t)
+	defer stop()
+
+	mux := &sync.Mutex{}
+	var gate chan struct{}
+	pool := NewWarmPool(browser, &WarmPolicy{
+		WarmUp: func(tab *Tab) error {
+			mux.Lock()
+			wait := gate
+			mux.Unlock()
+			if nil != wait {
+				<-wait
+			}
+			return nil
+		},
+	})
+	if err := pool.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer pool.Close()
+
+	// The pool is empty and the tab created for the acquisition is slow.
+	pool.Resize(0)
+	mux.Lock()
+	gate = make(chan struct{})
+	mux.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	acquired := make(chan error, 1)
+	go func() {
+		_, err := pool.Acquire(ctx)
+		acquired <- err
+	}()
+	select {
+	case err := <-acquired:
+		if nil == err {
+			t.Errorf("Expected error, got nil")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected Acquire to return when the context is done")
+	}
+
+	// The abandoned tab is kept once it's ready.
+	pool.mux.Lock()
+	pool.policy.Size = 1
+	pool.mux.Unlock()
+	close(gate)
+	waitForWarm(t, pool, 1)
+	pool.mux.Lock()
+	defer pool.mux.Unlock()
+	if 0 != len(pool.acquired) {
+		t.Errorf("Expected the abandoned tab not to be acquired, got %d", len(pool.acquired))
+	}
+}
+
+func TestWarmPoolExpiry(t *testing.T) {
+	browser, _, stop := newCDPBrowser(t)
+	defer stop()
+
+	pool := NewWarmPool(browser, &WarmPolicy{
+		MaxAge:          20 * time.Millisecond,
+		RefreshInterval: 5 * time.Millisecond,
+	})
+	if err := pool.Start(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer pool.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for 0 == pool.Stats().Expired {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the warm tab to expire, got %+v", pool.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	waitForWarm(t, pool, 1)
+	if stats := pool.Stats(); stats.Created < 2 {
+		t.Errorf("Expected the expired tab to be replaced, got %+v", stats)
+	}
+}
+
+func TestWarmPoolFailure(t *testing.T) {
+	browser, _, stop := newCDPBrowser(t)
+	defer stop()
+
+	pool := NewWarmPool(browser, &WarmPolicy{
+		WarmUp: func(tab *Tab) error {
+			return errors.New("warm up failed")
+		},
+	})
+	if err := pool.Start(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	defer pool.Close()
+	if _, err := pool.Acquire(context.Background()); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if stats := pool.Stats(); 0 != stats.Warm || stats.Failures < 2 || 1 != stats.Misses {
+		t.Errorf("Expected failures and a miss, got %+v", stats)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Package cookies records the cookies set during a browser session and checks
+them against a cookie policy, for privacy compliance checks:
+
+	recorder := cookies.Record(tab)
+	defer recorder.Stop()
+	...
+	for _, violation := range recorder.Assert(&cookies.Policy{
+		Secure:      true,
+		HTTPOnly:    true,
+		SameSite:    []string{"Lax", "Strict"},
+		MaxLifetime: 30 * 24 * time.Hour,
+		Domains:     []string{"example.com", "*.example.com"},
+	}) {
+		fmt.Printf("%s: %s (set by %s)\n", violation.Cookie.Name, violation.Message, violation.Cookie.URL)
+	}
+
+Cookies are read from the Set-Cookie headers of the responses the tab
+receives, so the Network domain must be enabled. Cookies set by scripts are not
+recorded.
+
+InspectPartitions reports the partitioned and unpartitioned browser cookies per
+top-level site, to prepare for third-party cookie deprecation, and
+SimulateThirdPartyBlocking compares application behavior with and without
+third-party cookies.
+*/
+package cookies
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Target is the interface cookies are recorded from. *chrome.Tab satisfies it.
+*/
+type Target interface {
+	Network() *socket.NetworkProtocol
+}
+
+/*
+Cookie is a cookie set by a response.
+*/
+type Cookie struct {
+	Name     string
+	Value    string
+	Domain   string
+	Path     string
+	Secure   bool
+	HTTPOnly bool
+	SameSite string
+
+	// Expires is the time the cookie expires, computed from the Max-Age or
+	// Expires attribute. Zero for session cookies.
+	Expires time.Time
+
+	// Set is the time the cookie was set.
+	Set time.Time
+
+	// URL is the URL of the response that set the cookie.
+	URL string
+
+	// Response is the response that set the cookie.
+	Response *network.ResponseReceivedEvent
+
+	// Raw is the Set-Cookie header value.
+	Raw string
+}
+
+/*
+Lifetime returns how long the cookie is valid for. Session cookies have a zero
+lifetime.
+*/
+func (cookie *Cookie) Lifetime() time.Duration {
+	if cookie.Expires.IsZero() {
+		return 0
+	}
+	return cookie.Expires.Sub(cookie.Set)
+}
+
+/*
+Recorder records the cookies set by the responses a target receives.
+*/
+type Recorder struct {
+	cookies []*Cookie
+	mux     *sync.Mutex
+	sub     *socket.Subscription
+}
+
+/*
+Record starts recording the cookies set by responses received by the target.
+*/
+func Record(target Target) *Recorder {
+	recorder := &Recorder{
+		cookies: make([]*Cookie, 0),
+		mux:     &sync.Mutex{},
+	}
+	recorder.sub = target.Network().OnResponseReceived(recorder.record)
+	return recorder
+}
+
+/*
+Cookies returns the recorded cookies.
+*/
+func (recorder *Recorder) Cookies() []*Cookie {
+	recorder.mux.Lock()
+	defer recorder.mux.Unlock()
+	return append([]*Cookie{}, recorder.cookies...)
+}
+
+/*
+Stop stops recording cookies. Recorded cookies can still be asserted.
+*/
+func (recorder *Recorder) Stop() {
+	recorder.sub.Remove()
+}
+
+/*
+Assert checks the recorded cookies against a policy and returns the
+violations.
+*/
+func (recorder *Recorder) Assert(policy *Policy) []*Violation {
+	violations := make([]*Violation, 0)
+	for _, cookie := range recorder.Cookies() {
+		violations = append(violations, policy.Check(cookie)...)
+	}
+	return violations
+}
+
+/*
+record parses the Set-Cookie headers of a response.
+*/
+func (recorder *Recorder) record(event *network.ResponseReceivedEvent) {
+	if nil != event.Err || nil == event.Response {
+		return
+	}
+	set := time.Now()
+	var headers []string
+	for name, value := range event.Response.Headers {
+		switch strings.ToLower(name) {
+		case "set-cookie":
+			// Multiple headers with the same name are joined by newlines.
+			headers = append(headers, strings.Split(value, "\n")...)
+		case "date":
+			if date, err := time.Parse(time.RFC1123, value); nil == err {
+				set = date
+			}
+		}
+	}
+
+	recorder.mux.Lock()
+	defer recorder.mux.Unlock()
+	for _, header := range headers {
+		if cookie := parse(header, set); nil != cookie {
+			cookie.URL = event.Response.URL
+			cookie.Response = event
+			recorder.cookies = append(recorder.cookies, cookie)
+		}
+	}
+}
+
+/*
+expiresLayouts are the Expires attribute formats in common use.
+*/
+var expiresLayouts = []string{
+	time.RFC1123,
+	"Mon, 02-Jan-2006 15:04:05 MST",
+	"Monday, 02-Jan-06 15:04:05 MST",
+	time.ANSIC,
+}
+
+/*
+parse parses a Set-Cookie header value. Invalid cookies return nil.
+*/
+func parse(header string, set time.Time) *Cookie {
+	parts := strings.Split(strings.TrimSpace(header), ";")
+	nameValue := strings.SplitN(parts[0], "=", 2)
+	if 2 != len(nameValue) || "" == strings.TrimSpace(nameValue[0]) {
+		return nil
+	}
+	cookie := &Cookie{
+		Name:  strings.TrimSpace(nameValue[0]),
+		Value: strings.TrimSpace(nameValue[1]),
+		Set:   set,
+		Raw:   header,
+	}
+
+	maxAge := ""
+	for _, part := range parts[1:] {
+		attr := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		value := ""
+		if 2 == len(attr) {
+			value = strings.TrimSpace(attr[1])
+		}
+		switch strings.ToLower(strings.TrimSpace(attr[0])) {
+		case "domain":
+			cookie.Domain = strings.ToLower(strings.TrimPrefix(value, "."))
+		case "path":
+			cookie.Path = value
+		case "secure":
+			cookie.Secure = true
+		case "httponly":
+			cookie.HTTPOnly = true
+		case "samesite":
+			cookie.SameSite = value
+		case "max-age":
+			maxAge = value
+		case "expires":
+			for _, layout := range expiresLayouts {
+				if expires, err := time.Parse(layout, value); nil == err {
+					cookie.Expires = expires
+					break
+				}
+			}
+		}
+	}
+	// Max-Age takes precedence over Expires.
+	if "" != maxAge {
+		if seconds, err := strconv.Atoi(maxAge); nil == err {
+			cookie.Expires = set.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return cookie
+}
@@ -0,0 +1,82 @@
+package cookies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestRecord(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	recorder := Record(sock)
+	server.Emit("Network.responseReceived", map[string]interface{}{
+		"requestId": "1",
+		"type":      "Document",
+		"response": map[string]interface{}{
+			"url":    "https://www.example.com/login",
+			"status": 200,
+			"headers": map[string]string{
+				"date":       "Mon, 02 Jan 2006 15:04:05 GMT",
+				"set-cookie": "session=abc; Path=/; Secure; HttpOnly; SameSite=Lax\nprefs=dark; Domain=.Example.com; Max-Age=3600\ninvalid",
+			},
+		},
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for 2 != len(recorder.Cookies()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 cookies, got %d", len(recorder.Cookies()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	recorder.Stop()
+
+	cookies := recorder.Cookies()
+	session, prefs := cookies[0], cookies[1]
+	if "session" != session.Name || "abc" != session.Value || "/" != session.Path {
+		t.Errorf("Expected the session cookie, got %v", session)
+	}
+	if !session.Secure || !session.HTTPOnly || "Lax" != session.SameSite || 0 != session.Lifetime() {
+		t.Errorf("Expected a secure session cookie, got %v", session)
+	}
+	if "https://www.example.com/login" != session.URL || "1" != session.Response.RequestID {
+		t.Errorf("Expected the setting response, got %s", session.URL)
+	}
+	if "example.com" != prefs.Domain || time.Hour != prefs.Lifetime() {
+		t.Errorf("Expected a one hour example.com cookie, got %s %s", prefs.Domain, prefs.Lifetime())
+	}
+	if 2006 != prefs.Set.Year() {
+		t.Errorf("Expected the response date, got %s", prefs.Set)
+	}
+
+	violations := recorder.Assert(&Policy{Secure: true})
+	if 1 != len(violations) || prefs != violations[0].Cookie || "Secure" != violations[0].Rule {
+		t.Errorf("Expected a Secure violation, got %v", violations)
+	}
+}
+
+func TestParse(t *testing.T) {
+	set := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cookie := parse("id=1; Expires=Wed, 08-Jan-2020 00:00:00 GMT", set)
+	if nil == cookie || 7*24*time.Hour != cookie.Lifetime() {
+		t.Errorf("Expected a one week cookie, got %v", cookie)
+	}
+	cookie = parse("id=1; Expires=Wed, 08 Jan 2020 00:00:00 GMT; Max-Age=60", set)
+	if nil == cookie || time.Minute != cookie.Lifetime() {
+		t.Errorf("Expected Max-Age to take precedence, got %v", cookie)
+	}
+	for _, header := range []string{"", "id", "=1"} {
+		if cookie := parse(header, set); nil != cookie {
+			t.Errorf("Expected nil for '%s', got %v", header, cookie)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cookies
+
+import (
+	"sort"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+PartitionReport groups browser cookies by storage partition, to find the
+unpartitioned third-party cookies that stop working when third-party cookies
+are blocked.
+*/
+type PartitionReport struct {
+	// Partitioned cookies by the top-level site of their partition key.
+	Partitioned map[string][]*network.Cookie
+
+	// Unpartitioned cookies.
+	Unpartitioned []*network.Cookie
+}
+
+/*
+Partitions returns the partition report for a set of cookies.
+*/
+func Partitions(cookies []*network.Cookie) *PartitionReport {
+	report := &PartitionReport{
+		Partitioned:   make(map[string][]*network.Cookie),
+		Unpartitioned: make([]*network.Cookie, 0),
+	}
+	for _, cookie := range cookies {
+		if nil == cookie.PartitionKey {
+			report.Unpartitioned = append(report.Unpartitioned, cookie)
+			continue
+		}
+		site := cookie.PartitionKey.TopLevelSite
+		report.Partitioned[site] = append(report.Partitioned[site], cookie)
+	}
+	return report
+}
+
+/*
+InspectPartitions returns the partition report for all browser cookies.
+*/
+func InspectPartitions(target Target) (*PartitionReport, error) {
+	result := <-target.Network().GetAllCookies()
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.CookieInspectFailed, "could not read browser cookies")
+	}
+	return Partitions(result.Cookies), nil
+}
+
+/*
+Sites returns the top-level sites that have partitioned cookies, sorted.
+*/
+func (report *PartitionReport) Sites() []string {
+	sites := make([]string, 0, len(report.Partitioned))
+	for site := range report.Partitioned {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+	return sites
+}
@@ -0,0 +1,53 @@
+package cookies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestInspectPartitions(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Network.getAllCookies", map[string]interface{}{
+		"cookies": []map[string]interface{}{
+			{"name": "tracker", "domain": ".tracker.net"},
+			{"name": "chat", "domain": "widget.net", "partitionKey": map[string]interface{}{"topLevelSite": "https://shop.com", "hasCrossSiteAncestor": false}},
+			{"name": "chat", "domain": "widget.net", "partitionKey": map[string]interface{}{"topLevelSite": "https://news.com", "hasCrossSiteAncestor": true}},
+			{"name": "cart", "domain": "cdn.net", "partitionKey": map[string]interface{}{"topLevelSite": "https://shop.com", "hasCrossSiteAncestor": false}},
+		},
+	})
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	report, err := InspectPartitions(sock)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 1 != len(report.Unpartitioned) || "tracker" != report.Unpartitioned[0].Name {
+		t.Errorf("Expected 1 unpartitioned cookie, got %v", report.Unpartitioned)
+	}
+	sites := report.Sites()
+	if 2 != len(sites) || "https://news.com" != sites[0] || "https://shop.com" != sites[1] {
+		t.Errorf("Expected 2 sorted sites, got %v", sites)
+	}
+	if 2 != len(report.Partitioned["https://shop.com"]) {
+		t.Errorf("Expected 2 cookies for https://shop.com, got %d", len(report.Partitioned["https://shop.com"]))
+	}
+	if cookie := report.Partitioned["https://news.com"][0]; !cookie.PartitionKey.HasCrossSiteAncestor {
+		t.Errorf("Expected a cross-site ancestor, got %v", cookie.PartitionKey)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	report := Partitions([]*network.Cookie{})
+	if 0 != len(report.Unpartitioned) || 0 != len(report.Sites()) {
+		t.Errorf("Expected an empty report, got %v", report)
+	}
+}
@@ -0,0 +1,108 @@
+package cookies
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+/*
+Policy defines the constraints cookies must satisfy. Zero values are not
+checked.
+*/
+type Policy struct {
+	// Optional. Cookies must have the Secure attribute.
+	Secure bool
+
+	// Optional. Cookies must have the HttpOnly attribute.
+	HTTPOnly bool
+
+	// Optional. The allowed SameSite values, case-insensitive. An empty
+	// string allows cookies without the attribute.
+	SameSite []string
+
+	// Optional. The maximum cookie lifetime. Session cookies are always
+	// allowed.
+	MaxLifetime time.Duration
+
+	// Optional. Domain patterns the cookie scope must match, such as
+	// "*.example.com". Host-only cookies are scoped to the host of the
+	// response that set them.
+	Domains []string
+}
+
+/*
+Violation is a cookie that doesn't satisfy a policy constraint.
+*/
+type Violation struct {
+	// The cookie.
+	Cookie *Cookie
+
+	// The violated constraint: "Secure", "HttpOnly", "SameSite",
+	// "MaxLifetime" or "Domains".
+	Rule string
+
+	// A description of the violation.
+	Message string
+}
+
+/*
+Check checks a cookie against the policy and returns the violations.
+*/
+func (policy *Policy) Check(cookie *Cookie) []*Violation {
+	violations := make([]*Violation, 0)
+	violate := func(rule, message string) {
+		violations = append(violations, &Violation{Cookie: cookie, Rule: rule, Message: message})
+	}
+
+	if policy.Secure && !cookie.Secure {
+		violate("Secure", "cookie is not Secure")
+	}
+	if policy.HTTPOnly && !cookie.HTTPOnly {
+		violate("HttpOnly", "cookie is not HttpOnly")
+	}
+	if len(policy.SameSite) > 0 {
+		allowed := false
+		for _, sameSite := range policy.SameSite {
+			if strings.EqualFold(sameSite, cookie.SameSite) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violate("SameSite", fmt.Sprintf("SameSite '%s' is not one of %v", cookie.SameSite, policy.SameSite))
+		}
+	}
+	if policy.MaxLifetime > 0 && cookie.Lifetime() > policy.MaxLifetime {
+		violate("MaxLifetime", fmt.Sprintf("lifetime %s exceeds %s", cookie.Lifetime(), policy.MaxLifetime))
+	}
+	if len(policy.Domains) > 0 {
+		domain := scope(cookie)
+		allowed := false
+		for _, pattern := range policy.Domains {
+			if ok, _ := path.Match(strings.ToLower(pattern), domain); ok {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violate("Domains", fmt.Sprintf("domain '%s' is not one of %v", domain, policy.Domains))
+		}
+	}
+	return violations
+}
+
+/*
+scope returns the domain a cookie is scoped to.
+*/
+func scope(cookie *Cookie) string {
+	if "" != cookie.Domain {
+		return cookie.Domain
+	}
+	if parsed, err := url.Parse(cookie.URL); nil == err {
+		return strings.ToLower(parsed.Hostname())
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package cookies
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	policy := &Policy{
+		Secure:      true,
+		HTTPOnly:    true,
+		SameSite:    []string{"lax", "Strict"},
+		MaxLifetime: time.Hour,
+		Domains:     []string{"example.com", "*.example.com"},
+	}
+	set := time.Now()
+
+	compliant := &Cookie{
+		Name:     "session",
+		Secure:   true,
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Set:      set,
+		Expires:  set.Add(time.Minute),
+		URL:      "https://www.example.com/",
+	}
+	if violations := policy.Check(compliant); 0 != len(violations) {
+		t.Errorf("Expected no violations, got %v", violations[0])
+	}
+
+	tracker := &Cookie{
+		Name:    "tracker",
+		Domain:  "tracker.net",
+		Set:     set,
+		Expires: set.Add(365 * 24 * time.Hour),
+		URL:     "https://www.example.com/",
+	}
+	violations := policy.Check(tracker)
+	rules := []string{"Secure", "HttpOnly", "SameSite", "MaxLifetime", "Domains"}
+	if len(rules) != len(violations) {
+		t.Fatalf("Expected %d violations, got %d", len(rules), len(violations))
+	}
+	for a, rule := range rules {
+		if rule != violations[a].Rule || tracker != violations[a].Cookie || "" == violations[a].Message {
+			t.Errorf("Expected a %s violation, got %v", rule, violations[a])
+		}
+	}
+
+	hostOnly := &Cookie{Name: "id", URL: "https://cdn.other.com/"}
+	violations = (&Policy{Domains: []string{"*.example.com"}}).Check(hostOnly)
+	if 1 != len(violations) || "Domains" != violations[0].Rule {
+		t.Errorf("Expected a Domains violation, got %v", violations)
+	}
+}
@@ -0,0 +1,270 @@
+package cookies
+
+import (
+	"net/url"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+FlagSetter is the interface launch flags are set on. chrome.Flags satisfies
+it.
+*/
+type FlagSetter interface {
+	Set(flag string, values interface{}) error
+}
+
+/*
+ObserveTarget is the interface application behavior is observed on.
+*chrome.Tab satisfies it.
+*/
+type ObserveTarget interface {
+	Network() *socket.NetworkProtocol
+	Page() *socket.PageProtocol
+}
+
+/*
+SetThirdPartyFlags sets the launch flag that blocks third-party cookies for
+the whole browser, the way they are blocked when third-party cookies are
+phased out.
+*/
+func SetThirdPartyFlags(flags FlagSetter) error {
+	return flags.Set("test-third-party-cookie-phaseout", nil)
+}
+
+/*
+BlockThirdParty enables or disables third-party cookie restrictions for a
+target. The page must be reloaded before the new cookie behavior is observed.
+*/
+func BlockThirdParty(target Target, block bool) error {
+	result := <-target.Network().SetCookieControls(&network.SetCookieControlsParams{
+		EnableThirdPartyCookieRestriction: block,
+	})
+	return result.Err
+}
+
+/*
+RequestFailure is a request that failed or received an error status.
+*/
+type RequestFailure struct {
+	// The request URL.
+	URL string
+
+	// The response status, zero for network errors.
+	Status int
+
+	// Optional. The network error.
+	ErrorText string
+
+	// Optional. The reason the request was blocked.
+	BlockedReason network.BlockedReasonEnum
+}
+
+/*
+key identifies a failure across runs. Query strings are ignored because they
+often contain cache busters.
+*/
+func (failure *RequestFailure) key() string {
+	parsed, err := url.Parse(failure.URL)
+	if nil != err {
+		return failure.URL
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+/*
+Behavior is the application behavior observed during a run.
+*/
+type Behavior struct {
+	// Failed requests and error responses.
+	Failures []*RequestFailure
+
+	// The URL of the last main frame navigation.
+	URL string
+}
+
+/*
+Observer records application behavior signals: failed requests, error
+responses and main frame navigations. The Network and Page domains must be
+enabled.
+*/
+type Observer struct {
+	behavior *Behavior
+	mux      *sync.Mutex
+	requests map[network.RequestID]string
+	subs     []*socket.Subscription
+}
+
+/*
+Observe starts observing the behavior of a target.
+*/
+func Observe(target ObserveTarget) *Observer {
+	observer := &Observer{
+		behavior: &Behavior{Failures: make([]*RequestFailure, 0)},
+		mux:      &sync.Mutex{},
+		requests: make(map[network.RequestID]string),
+	}
+	observer.subs = []*socket.Subscription{
+		target.Network().OnRequestWillBeSent(observer.requestWillBeSent),
+		target.Network().OnResponseReceived(observer.responseReceived),
+		target.Network().OnLoadingFailed(observer.loadingFailed),
+		target.Page().OnFrameNavigated(observer.frameNavigated),
+	}
+	return observer
+}
+
+/*
+Stop stops observing and returns the observed behavior.
+*/
+func (observer *Observer) Stop() *Behavior {
+	for _, sub := range observer.subs {
+		sub.Remove()
+	}
+	observer.mux.Lock()
+	defer observer.mux.Unlock()
+	return observer.behavior
+}
+
+func (observer *Observer) requestWillBeSent(event *network.RequestWillBeSentEvent) {
+	if nil != event.Err || nil == event.Request {
+		return
+	}
+	observer.mux.Lock()
+	observer.requests[event.RequestID] = event.Request.URL
+	observer.mux.Unlock()
+}
+
+func (observer *Observer) responseReceived(event *network.ResponseReceivedEvent) {
+	if nil != event.Err || nil == event.Response || event.Response.Status < 400 {
+		return
+	}
+	observer.mux.Lock()
+	observer.behavior.Failures = append(observer.behavior.Failures, &RequestFailure{
+		URL:    event.Response.URL,
+		Status: event.Response.Status,
+	})
+	observer.mux.Unlock()
+}
+
+func (observer *Observer) loadingFailed(event *network.LoadingFailedEvent) {
+	if nil != event.Err || event.Canceled {
+		return
+	}
+	observer.mux.Lock()
+	observer.behavior.Failures = append(observer.behavior.Failures, &RequestFailure{
+		URL:           observer.requests[event.RequestID],
+		ErrorText:     event.ErrorText,
+		BlockedReason: event.BlockedReason,
+	})
+	observer.mux.Unlock()
+}
+
+func (observer *Observer) frameNavigated(event *page.FrameNavigatedEvent) {
+	if nil != event.Err || nil == event.Frame || "" != event.Frame.ParentID {
+		return
+	}
+	observer.mux.Lock()
+	observer.behavior.URL = event.Frame.URL
+	observer.mux.Unlock()
+}
+
+/*
+Impact is the difference in application behavior between a baseline run and a
+run with third-party cookies blocked.
+*/
+type Impact struct {
+	Baseline *Behavior
+	Blocked  *Behavior
+
+	// Requests that only failed with third-party cookies blocked.
+	NewFailures []*RequestFailure
+
+	// New failures with a 401 or 403 status, a common sign of broken logins.
+	AuthFailures []*RequestFailure
+
+	// Whether the run ended on a different page, for example because it was
+	// redirected to a login page.
+	NavigationChanged bool
+}
+
+/*
+Broken returns whether blocking third-party cookies changed the application
+behavior.
+*/
+func (impact *Impact) Broken() bool {
+	return len(impact.NewFailures) > 0 || impact.NavigationChanged
+}
+
+/*
+Compare returns the impact of blocking third-party cookies given the behavior
+of a baseline run and a run with third-party cookies blocked.
+*/
+func Compare(baseline, blocked *Behavior) *Impact {
+	impact := &Impact{
+		Baseline:          baseline,
+		Blocked:           blocked,
+		NewFailures:       make([]*RequestFailure, 0),
+		AuthFailures:      make([]*RequestFailure, 0),
+		NavigationChanged: baseline.URL != blocked.URL,
+	}
+	failed := make(map[string]bool)
+	for _, failure := range baseline.Failures {
+		failed[failure.key()] = true
+	}
+	for _, failure := range blocked.Failures {
+		if failed[failure.key()] {
+			continue
+		}
+		impact.NewFailures = append(impact.NewFailures, failure)
+		if 401 == failure.Status || 403 == failure.Status {
+			impact.AuthFailures = append(impact.AuthFailures, failure)
+		}
+	}
+	return impact
+}
+
+/*
+SimulateThirdPartyBlocking runs a scenario twice, first with the current
+cookie settings and then with third-party cookies blocked, and returns the
+difference in application behavior. The scenario should load the page and
+wait until it settles:
+
+	impact, err := cookies.SimulateThirdPartyBlocking(tab, func() error {
+		result := <-tab.Page().Navigate(&page.NavigateParams{URL: "https://example.com/account"})
+		if nil != result.Err {
+			return result.Err
+		}
+		time.Sleep(2 * time.Second)
+		return nil
+	})
+
+Third-party cookie restrictions are lifted when the simulation completes.
+*/
+func SimulateThirdPartyBlocking(target ObserveTarget, scenario func() error) (*Impact, error) {
+	observer := Observe(target)
+	err := scenario()
+	baseline := observer.Stop()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.CookieSimulationFailed, "baseline run failed")
+	}
+
+	if err := BlockThirdParty(target, true); nil != err {
+		return nil, errs.Wrap(err, codes.CookieSimulationFailed, "could not block third-party cookies")
+	}
+	defer BlockThirdParty(target, false)
+
+	observer = Observe(target)
+	err = scenario()
+	blocked := observer.Stop()
+	if nil != err {
+		return nil, errs.Wrap(err, codes.CookieSimulationFailed, "run with third-party cookies blocked failed")
+	}
+	return Compare(baseline, blocked), nil
+}
@@ -0,0 +1,114 @@
+package cookies
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+type flags map[string]interface{}
+
+func (flags flags) Set(flag string, values interface{}) error {
+	flags[flag] = values
+	return nil
+}
+
+func TestSetThirdPartyFlags(t *testing.T) {
+	set := flags{}
+	if err := SetThirdPartyFlags(set); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if _, ok := set["test-third-party-cookie-phaseout"]; !ok {
+		t.Errorf("Expected the phaseout flag, got %v", set)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Behavior{
+		Failures: []*RequestFailure{{URL: "https://example.com/favicon.ico?v=1", Status: 404}},
+		URL:      "https://example.com/account",
+	}
+	blocked := &Behavior{
+		Failures: []*RequestFailure{
+			{URL: "https://example.com/favicon.ico?v=2", Status: 404},
+			{URL: "https://sso.example.net/session", Status: 401},
+			{URL: "https://widget.example.org/embed.js", ErrorText: "net::ERR_BLOCKED_BY_CLIENT"},
+		},
+		URL: "https://example.com/account",
+	}
+	impact := Compare(baseline, blocked)
+	if 2 != len(impact.NewFailures) || 1 != len(impact.AuthFailures) || "https://sso.example.net/session" != impact.AuthFailures[0].URL {
+		t.Errorf("Expected 2 new failures and 1 auth failure, got %v %v", impact.NewFailures, impact.AuthFailures)
+	}
+	if impact.NavigationChanged || !impact.Broken() {
+		t.Errorf("Expected a broken run without a navigation change")
+	}
+
+	if impact := Compare(baseline, baseline); impact.Broken() {
+		t.Errorf("Expected identical runs not to be broken")
+	}
+	if impact := Compare(baseline, &Behavior{URL: "https://example.com/login"}); !impact.NavigationChanged || !impact.Broken() {
+		t.Errorf("Expected a navigation change")
+	}
+}
+
+func TestSimulateThirdPartyBlocking(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	runs := 0
+	impact, err := SimulateThirdPartyBlocking(sock, func() error {
+		runs++
+		server.Emit("Page.frameNavigated", map[string]interface{}{
+			"frame": map[string]interface{}{"id": "main", "url": "https://example.com/account"},
+		})
+		if 2 == runs {
+			server.Emit("Network.requestWillBeSent", map[string]interface{}{
+				"requestId": "1",
+				"request":   map[string]interface{}{"url": "https://sso.example.net/session", "method": "GET", "headers": map[string]string{}},
+			})
+			time.Sleep(50 * time.Millisecond)
+			server.Emit("Network.loadingFailed", map[string]interface{}{"requestId": "1", "errorText": "net::ERR_FAILED"})
+			server.Emit("Page.frameNavigated", map[string]interface{}{
+				"frame": map[string]interface{}{"id": "child", "parentId": "main", "url": "https://widget.example.org/"},
+			})
+		}
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 1 != len(impact.NewFailures) || "https://sso.example.net/session" != impact.NewFailures[0].URL {
+		t.Errorf("Expected the failed session request, got %v", impact.NewFailures)
+	}
+	if impact.NavigationChanged {
+		t.Errorf("Expected child frame navigations to be ignored")
+	}
+
+	controls := make([]bool, 0)
+	for _, command := range server.Commands() {
+		if "Network.setCookieControls" == command.Method {
+			params := &network.SetCookieControlsParams{}
+			json.Unmarshal(command.Params, params)
+			controls = append(controls, params.EnableThirdPartyCookieRestriction)
+		}
+	}
+	if 2 != len(controls) || !controls[0] || controls[1] {
+		t.Errorf("Expected the restriction to be enabled and lifted, got %v", controls)
+	}
+
+	if _, err := SimulateThirdPartyBlocking(sock, func() error { return errors.New("scenario failed") }); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Package access provides type definitions for use with the Chrome DeviceAccess protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/
+*/
+package access
+
+/*
+RequestID is the ID of a device request prompt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#type-RequestId
+*/
+type RequestID string
+
+/*
+DeviceID is the ID of a device in a device request prompt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#type-DeviceId
+*/
+type DeviceID string
+
+/*
+PromptDevice is a device listed in a device request prompt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#type-PromptDevice
+*/
+type PromptDevice struct {
+	// The ID of the device.
+	ID DeviceID `json:"id"`
+
+	// The name of the device as it appears in the prompt.
+	Name string `json:"name"`
+}
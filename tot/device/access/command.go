@@ -0,0 +1,64 @@
+package access
+
+/*
+CancelPromptParams represents DeviceAccess.cancelPrompt parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-cancelPrompt
+*/
+type CancelPromptParams struct {
+	// The prompt, from the DeviceAccess.deviceRequestPrompted event.
+	ID RequestID `json:"id"`
+}
+
+/*
+CancelPromptResult represents the result of calls to DeviceAccess.cancelPrompt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-cancelPrompt
+*/
+type CancelPromptResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DisableResult represents the result of calls to DeviceAccess.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableResult represents the result of calls to DeviceAccess.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SelectPromptParams represents DeviceAccess.selectPrompt parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-selectPrompt
+*/
+type SelectPromptParams struct {
+	// The prompt, from the DeviceAccess.deviceRequestPrompted event.
+	ID RequestID `json:"id"`
+
+	// The device to select.
+	DeviceID DeviceID `json:"deviceId"`
+}
+
+/*
+SelectPromptResult represents the result of calls to DeviceAccess.selectPrompt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-selectPrompt
+*/
+type SelectPromptResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
@@ -0,0 +1,19 @@
+package access
+
+/*
+DeviceRequestPromptedEvent represents DeviceAccess.deviceRequestPrompted event
+data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#event-deviceRequestPrompted
+*/
+type DeviceRequestPromptedEvent struct {
+	// The ID of the prompt, passed to SelectPrompt or CancelPrompt.
+	ID RequestID `json:"id"`
+
+	// The devices listed in the prompt. The list is updated with new events
+	// as devices are discovered.
+	Devices []*PromptDevice `json:"devices"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
@@ -34,3 +34,49 @@ EXPERIMENTAL
 https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#type-VirtualTimePolicy
 */
 type VirtualTimePolicy string
+
+/*
+UserAgentBrandVersion is used to specify User Agent Client Hints to emulate.
+See https://wicg.github.io/ua-client-hints
+EXPERIMENTAL
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#type-UserAgentBrandVersion
+*/
+type UserAgentBrandVersion struct {
+	// Brand name.
+	Brand string `json:"brand"`
+
+	// Brand version.
+	Version string `json:"version"`
+}
+
+/*
+UserAgentMetadata is used to specify User Agent Client Hints to emulate. See
+https://wicg.github.io/ua-client-hints
+EXPERIMENTAL
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#type-UserAgentMetadata
+*/
+type UserAgentMetadata struct {
+	// Optional. Brands and versions reported in the Sec-CH-UA header.
+	Brands []*UserAgentBrandVersion `json:"brands,omitempty"`
+
+	// Optional. Full browser version reported in the Sec-CH-UA-Full-Version
+	// header.
+	FullVersion string `json:"fullVersion,omitempty"`
+
+	// Platform name reported in the Sec-CH-UA-Platform header.
+	Platform string `json:"platform"`
+
+	// Platform version reported in the Sec-CH-UA-Platform-Version header.
+	PlatformVersion string `json:"platformVersion"`
+
+	// CPU architecture reported in the Sec-CH-UA-Arch header.
+	Architecture string `json:"architecture"`
+
+	// Device model reported in the Sec-CH-UA-Model header.
+	Model string `json:"model"`
+
+	// Whether the Sec-CH-UA-Mobile header reports a mobile device.
+	Mobile bool `json:"mobile"`
+}
@@ -230,6 +230,27 @@ type SetGeolocationOverrideResult struct {
 	Err error `json:"-"`
 }
 
+/*
+SetLocaleOverrideParams represents Emulation.setLocaleOverride parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setLocaleOverride
+*/
+type SetLocaleOverrideParams struct {
+	// Optional. ICU style C locale (e.g. "en_US"). If not specified or empty,
+	// disables the override and restores the default host system locale.
+	Locale string `json:"locale,omitempty"`
+}
+
+/*
+SetLocaleOverrideResult represents the result of calls to Emulation.setLocaleOverride.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setLocaleOverride
+*/
+type SetLocaleOverrideResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 SetNavigatorOverridesParams represents Emulation.setNavigatorOverrides parameters.
 
@@ -291,6 +312,27 @@ type SetScriptExecutionDisabledResult struct {
 	Err error `json:"-"`
 }
 
+/*
+SetTimezoneOverrideParams represents Emulation.setTimezoneOverride parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setTimezoneOverride
+*/
+type SetTimezoneOverrideParams struct {
+	// The timezone identifier. If empty, disables the override and restores
+	// the default host system timezone.
+	TimezoneID string `json:"timezoneId"`
+}
+
+/*
+SetTimezoneOverrideResult represents the result of calls to Emulation.setTimezoneOverride.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setTimezoneOverride
+*/
+type SetTimezoneOverrideResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 SetTouchEmulationEnabledParams represents Emulation.setTouchEmulationEnabled parameters.
 
@@ -315,6 +357,36 @@ type SetTouchEmulationEnabledResult struct {
 	Err error `json:"-"`
 }
 
+/*
+SetUserAgentOverrideParams represents Emulation.setUserAgentOverride parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setUserAgentOverride
+*/
+type SetUserAgentOverrideParams struct {
+	// User agent to use.
+	UserAgent string `json:"userAgent"`
+
+	// Optional. Browser language to emulate.
+	AcceptLanguage string `json:"acceptLanguage,omitempty"`
+
+	// Optional. The platform navigator.platform should return.
+	Platform string `json:"platform,omitempty"`
+
+	// Optional. To be sent in Sec-CH-UA-* headers and returned in
+	// navigator.userAgentData. EXPERIMENTAL.
+	UserAgentMetadata *UserAgentMetadata `json:"userAgentMetadata,omitempty"`
+}
+
+/*
+SetUserAgentOverrideResult represents the result of calls to Emulation.setUserAgentOverride.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setUserAgentOverride
+*/
+type SetUserAgentOverrideResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 SetVirtualTimePolicyParams represents Emulation.setVirtualTimePolicy parameters.
 
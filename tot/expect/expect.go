@@ -0,0 +1,337 @@
+/*
+Package expect provides assertions on the elements of a page that are retried
+until they pass or time out, so tests don't need polling loops:
+
+	func TestLogin(t *testing.T) {
+		...
+		expect.Element(tab, "h1").ToHaveText("Welcome back").Check(t)
+		expect.Element(tab, "#spinner").Not().ToBeVisible().Check(t)
+	}
+
+Assertions are values, so they can be listed in a table and checked together:
+
+	expect.All(t,
+		expect.Element(tab, "h1").ToHaveText("Welcome back"),
+		expect.Element(tab, "nav a").ToHaveCount(4),
+		expect.Element(tab, "#logout").ToHaveAttribute("href", "/logout"),
+		expect.Element(tab, ".error").Within(time.Second).Not().ToExist(),
+	)
+
+Each check evaluates a small script in the page every PollInterval until the
+assertion passes or its timeout, DefaultTimeout unless set with Within,
+expires. Errors evaluating the script, for example while the page navigates,
+are retried like failures.
+*/
+package expect
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+DefaultTimeout is how long assertions are retried unless set with Within.
+*/
+var DefaultTimeout = 5 * time.Second
+
+/*
+PollInterval is how often a failing assertion is retried.
+*/
+var PollInterval = 100 * time.Millisecond
+
+/*
+Target is the interface elements are inspected through. *chrome.Tab satisfies
+it.
+*/
+type Target interface {
+	Eval(expression string, v interface{}) error
+}
+
+/*
+Locator selects the elements of a page that assertions are made about.
+*/
+type Locator struct {
+	target   Target
+	selector string
+	timeout  time.Duration
+	negate   bool
+}
+
+/*
+Element returns a locator for the elements matching a CSS selector. Assertions
+about a single element apply to the first match.
+*/
+func Element(target Target, selector string) *Locator {
+	return &Locator{
+		target:   target,
+		selector: selector,
+		timeout:  DefaultTimeout,
+	}
+}
+
+/*
+Within returns a copy of the locator whose assertions are retried for the
+specified duration.
+*/
+func (locator *Locator) Within(timeout time.Duration) *Locator {
+	clone := *locator
+	clone.timeout = timeout
+	return &clone
+}
+
+/*
+Not returns a copy of the locator whose assertions are negated.
+*/
+func (locator *Locator) Not() *Locator {
+	clone := *locator
+	clone.negate = !locator.negate
+	return &clone
+}
+
+/*
+ToExist asserts that at least one element matches the selector.
+*/
+func (locator *Locator) ToExist() *Assertion {
+	return locator.assertion("to exist", "", func(state *elementState) (bool, string) {
+		return state.Count > 0, fmt.Sprintf("%d elements", state.Count)
+	})
+}
+
+/*
+ToHaveCount asserts that the specified number of elements match the selector.
+*/
+func (locator *Locator) ToHaveCount(count int) *Assertion {
+	return locator.assertion(fmt.Sprintf("to have count %d", count), "", func(state *elementState) (bool, string) {
+		return count == state.Count, fmt.Sprintf("%d elements", state.Count)
+	})
+}
+
+/*
+ToHaveText asserts that the text content of the element equals the specified
+text. Whitespace is collapsed and trimmed before comparing.
+*/
+func (locator *Locator) ToHaveText(text string) *Assertion {
+	return locator.assertion(fmt.Sprintf("to have text %q", text), "", func(state *elementState) (bool, string) {
+		if 0 == state.Count {
+			return false, "no elements"
+		}
+		actual := normalize(state.Text)
+		return normalize(text) == actual, fmt.Sprintf("%q", actual)
+	})
+}
+
+/*
+ToContainText asserts that the text content of the element contains the
+specified text. Whitespace is collapsed before comparing.
+*/
+func (locator *Locator) ToContainText(text string) *Assertion {
+	return locator.assertion(fmt.Sprintf("to contain text %q", text), "", func(state *elementState) (bool, string) {
+		if 0 == state.Count {
+			return false, "no elements"
+		}
+		actual := normalize(state.Text)
+		return strings.Contains(actual, normalize(text)), fmt.Sprintf("%q", actual)
+	})
+}
+
+/*
+ToBeVisible asserts that the element is rendered: it has a size, and neither
+it nor its ancestors are hidden with display or visibility styles.
+*/
+func (locator *Locator) ToBeVisible() *Assertion {
+	return locator.assertion("to be visible", "", func(state *elementState) (bool, string) {
+		if 0 == state.Count {
+			return false, "no elements"
+		}
+		if state.Visible {
+			return true, "visible"
+		}
+		return false, "hidden"
+	})
+}
+
+/*
+ToHaveAttribute asserts that the element has an attribute with the specified
+value.
+*/
+func (locator *Locator) ToHaveAttribute(name, value string) *Assertion {
+	return locator.assertion(fmt.Sprintf("to have attribute %s=%q", name, value), name, func(state *elementState) (bool, string) {
+		switch {
+		case 0 == state.Count:
+			return false, "no elements"
+		case nil == state.Attribute:
+			return false, fmt.Sprintf("no %s attribute", name)
+		}
+		return value == *state.Attribute, fmt.Sprintf("%s=%q", name, *state.Attribute)
+	})
+}
+
+/*
+Assertion is an assertion about the elements matching a locator. It isn't
+evaluated until it's checked.
+*/
+type Assertion struct {
+	locator     *Locator
+	description string
+	attribute   string
+	match       func(state *elementState) (bool, string)
+}
+
+/*
+Failure is the error returned by an assertion that didn't pass before it timed
+out.
+*/
+type Failure struct {
+	// Selector is the CSS selector of the locator.
+	Selector string
+
+	// Expected describes the assertion, for example 'to be visible'.
+	Expected string
+
+	// Actual describes the last state of the element, or is empty if the page
+	// could never be inspected.
+	Actual string
+
+	// Timeout is how long the assertion was retried.
+	Timeout time.Duration
+
+	// Err is the last error inspecting the page, if any.
+	Err error
+}
+
+/*
+Error implements error.
+*/
+func (failure *Failure) Error() string {
+	msg := fmt.Sprintf("expected element %q %s", failure.Selector, failure.Expected)
+	if "" != failure.Actual {
+		msg += ", got " + failure.Actual
+	}
+	if nil != failure.Err {
+		msg += fmt.Sprintf(", last error: %s", failure.Err)
+	}
+	return fmt.Sprintf("%s (after %s)", msg, failure.Timeout)
+}
+
+/*
+Err evaluates the assertion until it passes or times out. It returns nil if the
+assertion passed and a *Failure otherwise.
+*/
+func (assertion *Assertion) Err() error {
+	locator := assertion.locator
+	failure := &Failure{
+		Selector: locator.selector,
+		Expected: assertion.description,
+		Timeout:  locator.timeout,
+	}
+	if locator.negate {
+		failure.Expected = "not " + assertion.description
+	}
+
+	expression := probe(locator.selector, assertion.attribute)
+	deadline := time.Now().Add(locator.timeout)
+	for {
+		state := &elementState{}
+		if err := locator.target.Eval(expression, state); nil != err {
+			failure.Err = err
+		} else {
+			failure.Err = nil
+			var ok bool
+			ok, failure.Actual = assertion.match(state)
+			if ok != locator.negate {
+				return nil
+			}
+		}
+		if !time.Now().Before(deadline) {
+			return failure
+		}
+		time.Sleep(PollInterval)
+	}
+}
+
+/*
+Check evaluates the assertion and reports a failure to the test. It returns
+whether the assertion passed.
+*/
+func (assertion *Assertion) Check(t testing.TB) bool {
+	t.Helper()
+	if err := assertion.Err(); nil != err {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+/*
+All checks a list of assertions in order and reports every failure to the
+test. It returns whether all of them passed.
+*/
+func All(t testing.TB, assertions ...*Assertion) bool {
+	t.Helper()
+	passed := true
+	for _, assertion := range assertions {
+		if err := assertion.Err(); nil != err {
+			t.Error(err)
+			passed = false
+		}
+	}
+	return passed
+}
+
+/*
+assertion returns an assertion about the locator's elements.
+*/
+func (locator *Locator) assertion(description, attribute string, match func(state *elementState) (bool, string)) *Assertion {
+	return &Assertion{
+		locator:     locator,
+		description: description,
+		attribute:   attribute,
+		match:       match,
+	}
+}
+
+/*
+elementState is the state of the elements matching a selector, reported by
+the probe script.
+*/
+type elementState struct {
+	Count     int     `json:"count"`
+	Text      string  `json:"text"`
+	Visible   bool    `json:"visible"`
+	Attribute *string `json:"attribute"`
+}
+
+/*
+probe returns the script reporting the state of the elements matching a
+selector. The attribute is only read if one is specified.
+*/
+func probe(selector, attribute string) string {
+	sel, _ := json.Marshal(selector)
+	attr, _ := json.Marshal(attribute)
+	return fmt.Sprintf(`(() => {
+	const nodes = document.querySelectorAll(%s);
+	const el = nodes[0];
+	if (!el) {
+		return {count: 0};
+	}
+	const rect = el.getBoundingClientRect();
+	const style = getComputedStyle(el);
+	const name = %s;
+	return {
+		count: nodes.length,
+		text: el.textContent,
+		visible: rect.width > 0 && rect.height > 0 && "hidden" !== style.visibility && "collapse" !== style.visibility,
+		attribute: name ? el.getAttribute(name) : null
+	};
+})()`, sel, attr)
+}
+
+/*
+normalize collapses and trims the whitespace in a text.
+*/
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,152 @@
+package expect
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTarget struct {
+	mux         *sync.Mutex
+	calls       int
+	expressions []string
+	states      []interface{}
+}
+
+func newFakeTarget(states ...interface{}) *fakeTarget {
+	return &fakeTarget{mux: &sync.Mutex{}, states: states}
+}
+
+func (target *fakeTarget) Eval(expression string, v interface{}) error {
+	target.mux.Lock()
+	defer target.mux.Unlock()
+	target.expressions = append(target.expressions, expression)
+	state := target.states[len(target.states)-1]
+	if target.calls < len(target.states) {
+		state = target.states[target.calls]
+	}
+	target.calls++
+	if err, ok := state.(error); ok {
+		return err
+	}
+	data, _ := json.Marshal(state)
+	return json.Unmarshal(data, v)
+}
+
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Error(args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprint(args...))
+}
+
+func init() {
+	DefaultTimeout = 50 * time.Millisecond
+	PollInterval = time.Millisecond
+}
+
+func TestAssertions(t *testing.T) {
+	href := "/logout"
+	state := map[string]interface{}{"count": 2, "text": "\n  Welcome   back ", "visible": true, "attribute": href}
+	hidden := map[string]interface{}{"count": 1, "text": "", "visible": false}
+	none := map[string]interface{}{"count": 0}
+
+	for name, test := range map[string]struct {
+		assertion *Assertion
+		passes    bool
+	}{
+		"exist":               {Element(newFakeTarget(state), "a").ToExist(), true},
+		"not exist":           {Element(newFakeTarget(none), "a").Not().ToExist(), true},
+		"count":               {Element(newFakeTarget(state), "a").ToHaveCount(2), true},
+		"wrong count":         {Element(newFakeTarget(state), "a").ToHaveCount(3), false},
+		"text":                {Element(newFakeTarget(state), "a").ToHaveText("Welcome back"), true},
+		"partial text":        {Element(newFakeTarget(state), "a").ToHaveText("Welcome"), false},
+		"contain text":        {Element(newFakeTarget(state), "a").ToContainText("back"), true},
+		"text without match":  {Element(newFakeTarget(none), "a").ToContainText(""), false},
+		"visible":             {Element(newFakeTarget(state), "a").ToBeVisible(), true},
+		"hidden":              {Element(newFakeTarget(hidden), "a").ToBeVisible(), false},
+		"not visible":         {Element(newFakeTarget(hidden), "a").Not().ToBeVisible(), true},
+		"attribute":           {Element(newFakeTarget(state), "a").ToHaveAttribute("href", "/logout"), true},
+		"missing attribute":   {Element(newFakeTarget(hidden), "a").ToHaveAttribute("href", "/logout"), false},
+		"double negation":     {Element(newFakeTarget(state), "a").Not().Not().ToBeVisible(), true},
+		"not count, negative": {Element(newFakeTarget(state), "a").Not().ToHaveCount(2), false},
+	} {
+		err := test.assertion.Err()
+		if test.passes && nil != err {
+			t.Errorf("%s: expected nil, got error: %v", name, err)
+		}
+		if !test.passes && nil == err {
+			t.Errorf("%s: expected a failure", name)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	target := newFakeTarget(
+		fmt.Errorf("Execution context was destroyed"),
+		map[string]interface{}{"count": 1, "text": "Loading..."},
+		map[string]interface{}{"count": 1, "text": "Done"},
+	)
+	if err := Element(target, "#status").ToHaveText("Done").Err(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if 3 != target.calls {
+		t.Errorf("Expected the assertion to be retried until it passed, got %d calls", target.calls)
+	}
+	if !strings.Contains(target.expressions[0], `document.querySelectorAll("#status")`) {
+		t.Errorf("Expected the selector to be quoted, got %s", target.expressions[0])
+	}
+}
+
+func TestFailure(t *testing.T) {
+	target := newFakeTarget(map[string]interface{}{"count": 1, "text": "Hello"})
+	err := Element(target, "h1").Within(20 * time.Millisecond).ToHaveText("Welcome").Err()
+	failure, ok := err.(*Failure)
+	if !ok {
+		t.Fatalf("Expected a *Failure, got %T: %v", err, err)
+	}
+	if "h1" != failure.Selector || `"Hello"` != failure.Actual {
+		t.Errorf("Expected the selector and the last text, got %+v", failure)
+	}
+	if `expected element "h1" to have text "Welcome", got "Hello" (after 20ms)` != failure.Error() {
+		t.Errorf("Unexpected message: %s", failure.Error())
+	}
+
+	target = newFakeTarget(fmt.Errorf("Execution context was destroyed"))
+	err = Element(target, "h1").Within(0).Not().ToExist().Err()
+	if `expected element "h1" not to exist, last error: Execution context was destroyed (after 0s)` != err.Error() {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+	if 1 != target.calls {
+		t.Errorf("Expected a single evaluation without a timeout, got %d", target.calls)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	target := newFakeTarget(map[string]interface{}{"count": 1, "text": "Hello", "visible": true})
+	tb := &fakeTB{}
+	passed := All(tb,
+		Element(target, "h1").ToHaveText("Hello"),
+		Element(target, "h1").Within(0).ToHaveText("Welcome"),
+		Element(target, "h1").ToBeVisible(),
+		Element(target, "h1").Within(0).ToHaveCount(2),
+	)
+	if passed || 2 != len(tb.errors) {
+		t.Errorf("Expected 2 failures, got %v", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	if !Element(target, "h1").ToBeVisible().Check(tb) || 0 != len(tb.errors) {
+		t.Errorf("Expected the check to pass, got %v", tb.errors)
+	}
+	if Element(target, "h1").Within(0).Not().ToBeVisible().Check(tb) || 1 != len(tb.errors) {
+		t.Errorf("Expected the check to fail, got %v", tb.errors)
+	}
+}
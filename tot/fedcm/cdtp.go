@@ -0,0 +1,88 @@
+/*
+Package fedcm provides type definitions for use with the Chrome FedCm protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/
+*/
+package fedcm
+
+/*
+LoginState is whether an account signs in to the relying party or signs up,
+because it was never used with the relying party before. Allowed values:
+"SignIn", "SignUp".
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#type-LoginState
+*/
+type LoginState string
+
+/*
+DialogType is the type of a FedCM dialog. Allowed values: "AccountChooser",
+"AutoReauthn", "ConfirmIdpLogin", "Error".
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#type-DialogType
+*/
+type DialogType string
+
+/*
+FedCM dialog types. ErrorDialog is the "Error" dialog type.
+*/
+const (
+	AccountChooser  DialogType = "AccountChooser"
+	AutoReauthn     DialogType = "AutoReauthn"
+	ConfirmIdpLogin DialogType = "ConfirmIdpLogin"
+	ErrorDialog     DialogType = "Error"
+)
+
+/*
+DialogButton is a button of a FedCM dialog. Allowed values:
+"ConfirmIdpLoginContinue", "ErrorGotIt", "ErrorMoreDetails".
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#type-DialogButton
+*/
+type DialogButton string
+
+/*
+AccountURLType is a URL of an account. Allowed values: "TermsOfService",
+"PrivacyPolicy".
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#type-AccountUrlType
+*/
+type AccountURLType string
+
+/*
+Account is an account of an identity provider shown in a FedCM dialog.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#type-Account
+*/
+type Account struct {
+	// The ID of the account at the identity provider.
+	AccountID string `json:"accountId"`
+
+	// The email address of the account.
+	Email string `json:"email"`
+
+	// The full name of the account holder.
+	Name string `json:"name"`
+
+	// The given name of the account holder.
+	GivenName string `json:"givenName"`
+
+	// The URL of the account picture.
+	PictureURL string `json:"pictureUrl"`
+
+	// The config URL of the identity provider.
+	IdpConfigURL string `json:"idpConfigUrl"`
+
+	// The login URL of the identity provider.
+	IdpLoginURL string `json:"idpLoginUrl"`
+
+	// Whether the account signs in or signs up.
+	LoginState LoginState `json:"loginState"`
+
+	// Optional. The terms of service of the relying party, only set when the
+	// account signs up.
+	TermsOfServiceURL string `json:"termsOfServiceUrl,omitempty"`
+
+	// Optional. The privacy policy of the relying party, only set when the
+	// account signs up.
+	PrivacyPolicyURL string `json:"privacyPolicyUrl,omitempty"`
+}
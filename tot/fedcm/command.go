@@ -0,0 +1,140 @@
+package fedcm
+
+/*
+ClickDialogButtonParams represents FedCm.clickDialogButton parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-clickDialogButton
+*/
+type ClickDialogButtonParams struct {
+	// The dialog, from the FedCm.dialogShown event.
+	DialogID string `json:"dialogId"`
+
+	// The button to click.
+	DialogButton DialogButton `json:"dialogButton"`
+}
+
+/*
+ClickDialogButtonResult represents the result of calls to
+FedCm.clickDialogButton.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-clickDialogButton
+*/
+type ClickDialogButtonResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DisableResult represents the result of calls to FedCm.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DismissDialogParams represents FedCm.dismissDialog parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-dismissDialog
+*/
+type DismissDialogParams struct {
+	// The dialog, from the FedCm.dialogShown event.
+	DialogID string `json:"dialogId"`
+
+	// Optional. Whether the dismissal triggers the cooldown during which the
+	// dialog isn't shown again, as if the user dismissed it.
+	TriggerCooldown bool `json:"triggerCooldown,omitempty"`
+}
+
+/*
+DismissDialogResult represents the result of calls to FedCm.dismissDialog.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-dismissDialog
+*/
+type DismissDialogResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableParams represents FedCm.enable parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-enable
+*/
+type EnableParams struct {
+	// Optional. Disables the delay before a rejected
+	// navigator.credentials.get() promise settles, so tests of rejections
+	// don't wait for it.
+	DisableRejectionDelay bool `json:"disableRejectionDelay,omitempty"`
+}
+
+/*
+EnableResult represents the result of calls to FedCm.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+OpenURLParams represents FedCm.openUrl parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-openUrl
+*/
+type OpenURLParams struct {
+	// The dialog, from the FedCm.dialogShown event.
+	DialogID string `json:"dialogId"`
+
+	// The index of the account in the dialog.
+	AccountIndex int `json:"accountIndex"`
+
+	// The URL of the account to open.
+	AccountURLType AccountURLType `json:"accountUrlType"`
+}
+
+/*
+OpenURLResult represents the result of calls to FedCm.openUrl.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-openUrl
+*/
+type OpenURLResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+ResetCooldownResult represents the result of calls to FedCm.resetCooldown.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-resetCooldown
+*/
+type ResetCooldownResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SelectAccountParams represents FedCm.selectAccount parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-selectAccount
+*/
+type SelectAccountParams struct {
+	// The dialog, from the FedCm.dialogShown event.
+	DialogID string `json:"dialogId"`
+
+	// The index of the account in the dialog.
+	AccountIndex int `json:"accountIndex"`
+}
+
+/*
+SelectAccountResult represents the result of calls to FedCm.selectAccount.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-selectAccount
+*/
+type SelectAccountResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
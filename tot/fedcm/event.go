@@ -0,0 +1,39 @@
+package fedcm
+
+/*
+DialogClosedEvent represents FedCm.dialogClosed event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#event-dialogClosed
+*/
+type DialogClosedEvent struct {
+	// The closed dialog.
+	DialogID string `json:"dialogId"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+DialogShownEvent represents FedCm.dialogShown event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#event-dialogShown
+*/
+type DialogShownEvent struct {
+	// The ID of the dialog, passed to the commands that act on it.
+	DialogID string `json:"dialogId"`
+
+	// The type of the dialog.
+	DialogType DialogType `json:"dialogType"`
+
+	// The accounts shown in the dialog.
+	Accounts []*Account `json:"accounts"`
+
+	// The title of the dialog, to verify the relying party context.
+	Title string `json:"title"`
+
+	// Optional. The subtitle of the dialog.
+	Subtitle string `json:"subtitle,omitempty"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
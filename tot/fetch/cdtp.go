@@ -0,0 +1,95 @@
+/*
+Package fetch provides type definitions for use with the Chrome Fetch protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/
+*/
+package fetch
+
+import (
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+RequestID is the unique request identifier.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-RequestId
+*/
+type RequestID string
+
+/*
+RequestPattern describes the requests to intercept.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-RequestPattern
+*/
+type RequestPattern struct {
+	// Optional. Wildcards ('*' -> zero or more, '?' -> exactly one) are
+	// allowed. Escape character is backslash. Omitting is equivalent to "*".
+	URLPattern string `json:"urlPattern,omitempty"`
+
+	// Optional. If set, only requests for matching resource types will be
+	// intercepted.
+	ResourceType page.ResourceTypeEnum `json:"resourceType,omitempty"`
+
+	// Optional. Stage at which to begin intercepting requests. Default is
+	// Request.
+	RequestStage RequestStageEnum `json:"requestStage,omitempty"`
+}
+
+/*
+HeaderEntry is a response HTTP header entry.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-HeaderEntry
+*/
+type HeaderEntry struct {
+	// Header name.
+	Name string `json:"name"`
+
+	// Header value.
+	Value string `json:"value"`
+}
+
+/*
+AuthChallenge is an authorization challenge for HTTP status code 401 or 407.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-AuthChallenge
+*/
+type AuthChallenge struct {
+	// Optional. Source of the authentication challenge. Allowed values:
+	//	- network.Source.Server
+	//	- network.Source.Proxy
+	Source network.SourceEnum `json:"source,omitempty"`
+
+	// Origin of the challenger.
+	Origin string `json:"origin"`
+
+	// The authentication scheme used, such as basic or digest.
+	Scheme string `json:"scheme"`
+
+	// The realm of the challenge. May be empty.
+	Realm string `json:"realm"`
+}
+
+/*
+AuthChallengeResponse is the response to an AuthChallenge.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-AuthChallengeResponse
+*/
+type AuthChallengeResponse struct {
+	// The decision on what to do in response to the authorization challenge.
+	// Default means deferring to the default behavior of the net stack, which
+	// will likely either the Cancel authentication or display a popup dialog
+	// box. Allowed values:
+	//	- network.ChallengeResponse.Default
+	//	- network.ChallengeResponse.CancelAuth
+	//	- network.ChallengeResponse.ProvideCredentials
+	Response network.ChallengeResponseEnum `json:"response"`
+
+	// Optional. The username to provide, possibly empty. Should only be set if
+	// response is ProvideCredentials.
+	Username string `json:"username,omitempty"`
+
+	// Optional. The password to provide, possibly empty. Should only be set if
+	// response is ProvideCredentials.
+	Password string `json:"password,omitempty"`
+}
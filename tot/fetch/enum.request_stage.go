@@ -0,0 +1,78 @@
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type requestStageEnum struct {
+	Request  RequestStageEnum
+	Response RequestStageEnum
+}
+
+/*
+RequestStage provides named acces to the RequestStageEnum values.
+*/
+var RequestStage = requestStageEnum{
+	Request:  requestStageRequest,
+	Response: requestStageResponse,
+}
+
+/*
+RequestStageEnum represents the stages of the request to handle. Request will
+intercept before the request is sent. Response will intercept after the
+response is received (but before response body is received). Allowed Values:
+	- RequestStage.Request  "Request"
+	- RequestStage.Response "Response"
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#type-RequestStage
+*/
+type RequestStageEnum int
+
+/*
+String implements Stringer
+*/
+func (enum RequestStageEnum) String() string {
+	return _requestStageEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum RequestStageEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *RequestStageEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _requestStageEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// requestStageRequest represents the "Request" value.
+	requestStageRequest RequestStageEnum = iota + 1
+	// requestStageResponse represents the "Response" value.
+	requestStageResponse
+)
+
+var _requestStageEnums = map[RequestStageEnum]string{
+	requestStageRequest:  "Request",
+	requestStageResponse: "Response",
+}
@@ -0,0 +1,51 @@
+package fetch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumRequestStage(t *testing.T) {
+	var enum RequestStageEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = RequestStage.Request
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"Request"` != string(result) {
+		t.Errorf("Expected '\"Request\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"Request"`), &enum)
+	if RequestStage.Request != enum {
+		t.Errorf("Expcected %d, got %d", RequestStage.Request, enum)
+	}
+
+	enum = RequestStage.Response
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"Response"` != string(result) {
+		t.Errorf("Expected '\"Response\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"Response"`), &enum)
+	if RequestStage.Response != enum {
+		t.Errorf("Expcected %d, got %d", RequestStage.Response, enum)
+	}
+}
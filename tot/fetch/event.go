@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+AuthRequiredEvent represents Fetch.authRequired event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#event-authRequired
+*/
+type AuthRequiredEvent struct {
+	// Each request the page makes will have a unique ID.
+	RequestID RequestID `json:"requestId"`
+
+	// The details of the request.
+	Request *network.Request `json:"request"`
+
+	// The ID of the frame that initiated the request.
+	FrameID page.FrameID `json:"frameId"`
+
+	// How the requested resource will be used.
+	ResourceType page.ResourceTypeEnum `json:"resourceType"`
+
+	// Details of the Authorization Challenge encountered. If this is set,
+	// client should respond with continueWithAuth that contains
+	// AuthChallengeResponse.
+	AuthChallenge *AuthChallenge `json:"authChallenge"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+RequestPausedEvent represents Fetch.requestPaused event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#event-requestPaused
+*/
+type RequestPausedEvent struct {
+	// Each request the page makes will have a unique ID.
+	RequestID RequestID `json:"requestId"`
+
+	// The details of the request.
+	Request *network.Request `json:"request"`
+
+	// The ID of the frame that initiated the request.
+	FrameID page.FrameID `json:"frameId"`
+
+	// How the requested resource will be used.
+	ResourceType page.ResourceTypeEnum `json:"resourceType"`
+
+	// Optional. Response error if intercepted at response stage.
+	ResponseErrorReason network.ErrorReasonEnum `json:"responseErrorReason,omitempty"`
+
+	// Optional. Response code if intercepted at response stage.
+	ResponseStatusCode int `json:"responseStatusCode,omitempty"`
+
+	// Optional. Response status text if intercepted at response stage.
+	ResponseStatusText string `json:"responseStatusText,omitempty"`
+
+	// Optional. Response headers if intercepted at the response stage.
+	ResponseHeaders []*HeaderEntry `json:"responseHeaders,omitempty"`
+
+	// Optional. If the intercepted request had a corresponding
+	// Network.requestWillBeSent event fired for it, then this networkId will
+	// be the same as the requestId present in the requestWillBeSent event.
+	NetworkID network.RequestID `json:"networkId,omitempty"`
+
+	// Optional. If the request is due to a redirect response from the server,
+	// the ID of the request that has caused the redirect.
+	RedirectedRequestID RequestID `json:"redirectedRequestId,omitempty"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
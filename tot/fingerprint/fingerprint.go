@@ -0,0 +1,304 @@
+/*
+Package fingerprint bundles the browser properties commonly used to fingerprint
+a client into named profiles that can be applied to a tab, for bot-detection
+research and QA.
+
+A profile covers the user agent and User Agent Client Hints, languages,
+timezone, screen metrics, WebGL vendor and renderer, and the
+navigator.hardwareConcurrency and navigator.deviceMemory values. Properties
+that the DevTools protocol can override directly are set through the Emulation
+domain, the remaining properties are spoofed by a script injected into every
+new document:
+
+	profile, err := fingerprint.Get("windows-desktop")
+	if nil != err {
+		...
+	}
+	if err := profile.Apply(tab); nil != err {
+		...
+	}
+
+Injected scripts only run in documents, so values read from workers are not
+spoofed. Launch flags for the properties Chromium accepts on the command line
+can be set with Profile.SetFlags.
+
+Headless detection mitigations are opt-in, they are applied with Mitigate or
+by listing them in a profile.
+
+SelfTest loads a bundled test page that reports which common automation
+signals still leak, to validate a configuration.
+*/
+package fingerprint
+
+import (
+	"fmt"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/emulation"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Profile is a named set of browser fingerprint properties. Empty fields are not
+overridden.
+*/
+type Profile struct {
+	// Name of the profile.
+	Name string `json:"name"`
+
+	// Optional. User agent string.
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// Optional. User Agent Client Hints sent in the Sec-CH-UA-* headers and
+	// returned by navigator.userAgentData.
+	UserAgentMetadata *emulation.UserAgentMetadata `json:"userAgentMetadata,omitempty"`
+
+	// Optional. Value returned by navigator.platform.
+	Platform string `json:"platform,omitempty"`
+
+	// Optional. Preferred languages, most preferred first, for example
+	// ["de-DE", "de", "en"]. Used for the Accept-Language header, the locale
+	// and navigator.languages.
+	Languages []string `json:"languages,omitempty"`
+
+	// Optional. IANA timezone identifier, for example "Europe/Berlin".
+	Timezone string `json:"timezone,omitempty"`
+
+	// Optional. Screen metrics.
+	Screen *Screen `json:"screen,omitempty"`
+
+	// Optional. WebGL vendor and renderer.
+	WebGL *WebGL `json:"webgl,omitempty"`
+
+	// Optional. Value returned by navigator.hardwareConcurrency.
+	HardwareConcurrency int `json:"hardwareConcurrency,omitempty"`
+
+	// Optional. Value returned by navigator.deviceMemory, in gigabytes.
+	DeviceMemory float64 `json:"deviceMemory,omitempty"`
+
+	// Optional. Headless detection mitigations to apply.
+	Mitigations []Mitigation `json:"mitigations,omitempty"`
+}
+
+/*
+Screen describes the emulated screen. The viewport is sized to match the
+screen.
+*/
+type Screen struct {
+	// Screen width in pixels.
+	Width int `json:"width"`
+
+	// Screen height in pixels.
+	Height int `json:"height"`
+
+	// Optional. Device scale factor. Defaults to 1.
+	DeviceScaleFactor float64 `json:"deviceScaleFactor,omitempty"`
+
+	// Optional. Whether to emulate a mobile device.
+	Mobile bool `json:"mobile,omitempty"`
+}
+
+/*
+WebGL describes the values returned for the WEBGL_debug_renderer_info
+extension parameters.
+*/
+type WebGL struct {
+	// Optional. Value returned for UNMASKED_VENDOR_WEBGL.
+	Vendor string `json:"vendor,omitempty"`
+
+	// Optional. Value returned for UNMASKED_RENDERER_WEBGL.
+	Renderer string `json:"renderer,omitempty"`
+}
+
+/*
+Target is the interface a profile is applied to. *chrome.Tab satisfies it.
+*/
+type Target interface {
+	Emulation() *socket.EmulationProtocol
+	Page() *socket.PageProtocol
+	OnClose(fn func() error)
+}
+
+/*
+FlagSetter is the interface launch flags are set on. chrome.ChromiumFlags
+satisfies it.
+*/
+type FlagSetter interface {
+	Set(flag string, values interface{}) error
+}
+
+/*
+Copy returns a deep copy of the profile.
+*/
+func (profile *Profile) Copy() *Profile {
+	cp := *profile
+	if nil != profile.UserAgentMetadata {
+		metadata := *profile.UserAgentMetadata
+		metadata.Brands = make([]*emulation.UserAgentBrandVersion, len(profile.UserAgentMetadata.Brands))
+		for a, brand := range profile.UserAgentMetadata.Brands {
+			tmp := *brand
+			metadata.Brands[a] = &tmp
+		}
+		cp.UserAgentMetadata = &metadata
+	}
+	if nil != profile.Languages {
+		cp.Languages = append([]string{}, profile.Languages...)
+	}
+	if nil != profile.Mitigations {
+		cp.Mitigations = append([]Mitigation{}, profile.Mitigations...)
+	}
+	if nil != profile.Screen {
+		tmp := *profile.Screen
+		cp.Screen = &tmp
+	}
+	if nil != profile.WebGL {
+		tmp := *profile.WebGL
+		cp.WebGL = &tmp
+	}
+	return &cp
+}
+
+/*
+Apply applies the profile to a target. Every override is registered with the
+target's cleanup functions and is undone when the target is cleaned up. Apply
+stops at the first failure, overrides that were applied successfully are still
+undone on cleanup.
+
+Profiles should be applied before navigating, the injected script only runs in
+documents created after it is added.
+*/
+func (profile *Profile) Apply(target Target) error {
+	if "" != profile.UserAgent || "" != profile.Platform || len(profile.Languages) > 0 || nil != profile.UserAgentMetadata {
+		result := <-target.Emulation().SetUserAgentOverride(&emulation.SetUserAgentOverrideParams{
+			UserAgent:         profile.UserAgent,
+			AcceptLanguage:    profile.acceptLanguage(),
+			Platform:          profile.Platform,
+			UserAgentMetadata: profile.UserAgentMetadata,
+		})
+		if nil != result.Err {
+			return profile.applyErr(result.Err, "user agent")
+		}
+		target.OnClose(func() error {
+			// An empty user agent disables the override.
+			return (<-target.Emulation().SetUserAgentOverride(&emulation.SetUserAgentOverrideParams{})).Err
+		})
+	}
+
+	if len(profile.Languages) > 0 {
+		result := <-target.Emulation().SetLocaleOverride(&emulation.SetLocaleOverrideParams{
+			Locale: strings.Replace(profile.Languages[0], "-", "_", -1),
+		})
+		if nil != result.Err {
+			return profile.applyErr(result.Err, "locale")
+		}
+		target.OnClose(func() error {
+			return (<-target.Emulation().SetLocaleOverride(&emulation.SetLocaleOverrideParams{})).Err
+		})
+	}
+
+	if "" != profile.Timezone {
+		result := <-target.Emulation().SetTimezoneOverride(&emulation.SetTimezoneOverrideParams{
+			TimezoneID: profile.Timezone,
+		})
+		if nil != result.Err {
+			return profile.applyErr(result.Err, "timezone")
+		}
+		target.OnClose(func() error {
+			return (<-target.Emulation().SetTimezoneOverride(&emulation.SetTimezoneOverrideParams{})).Err
+		})
+	}
+
+	if nil != profile.Screen {
+		scale := profile.Screen.DeviceScaleFactor
+		if 0 == scale {
+			scale = 1
+		}
+		result := <-target.Emulation().SetDeviceMetricsOverride(&emulation.SetDeviceMetricsOverrideParams{
+			Width:             profile.Screen.Width,
+			Height:            profile.Screen.Height,
+			DeviceScaleFactor: scale,
+			Mobile:            profile.Screen.Mobile,
+			ScreenWidth:       profile.Screen.Width,
+			ScreenHeight:      profile.Screen.Height,
+		})
+		if nil != result.Err {
+			return profile.applyErr(result.Err, "screen metrics")
+		}
+		target.OnClose(func() error {
+			return (<-target.Emulation().ClearDeviceMetricsOverride()).Err
+		})
+	}
+
+	if script := profile.Script(); "" != script {
+		result := <-target.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+			Source: script,
+		})
+		if nil != result.Err {
+			return profile.applyErr(result.Err, "navigator script")
+		}
+		identifier := result.Identifier
+		target.OnClose(func() error {
+			return (<-target.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+				Identifier: identifier,
+			})).Err
+		})
+	}
+
+	if err := Mitigate(target, profile.Mitigations...); nil != err {
+		return profile.applyErr(err, "mitigations")
+	}
+
+	return nil
+}
+
+/*
+SetFlags sets the launch flags for the properties Chromium accepts on the
+command line: the user agent, the UI language and the window size. WebGL,
+timezone and navigator overrides have no equivalent flags and are only applied
+by Apply.
+*/
+func (profile *Profile) SetFlags(flags FlagSetter) error {
+	if "" != profile.UserAgent {
+		if err := flags.Set("user-agent", profile.UserAgent); nil != err {
+			return err
+		}
+	}
+	if len(profile.Languages) > 0 {
+		if err := flags.Set("lang", profile.Languages[0]); nil != err {
+			return err
+		}
+	}
+	if nil != profile.Screen {
+		if err := flags.Set("window-size", fmt.Sprintf("%d,%d", profile.Screen.Width, profile.Screen.Height)); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+acceptLanguage returns the Accept-Language header value for the profile
+languages, with decreasing quality values.
+*/
+func (profile *Profile) acceptLanguage() string {
+	languages := make([]string, len(profile.Languages))
+	for a, language := range profile.Languages {
+		if 0 == a {
+			languages[a] = language
+			continue
+		}
+		quality := 1 - float64(a)/10
+		if quality < 0.1 {
+			quality = 0.1
+		}
+		languages[a] = fmt.Sprintf("%s;q=%.1f", language, quality)
+	}
+	return strings.Join(languages, ",")
+}
+
+func (profile *Profile) applyErr(err error, what string) error {
+	return errs.Wrap(err, codes.FingerprintApplyFailed, fmt.Sprintf("could not apply %s for fingerprint '%s'", what, profile.Name))
+}
@@ -0,0 +1,201 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/emulation"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+target is a Target backed by a socket connected to a simulated CDP server.
+*/
+type target struct {
+	*socket.Socket
+	cleanups []func() error
+}
+
+func (target *target) OnClose(fn func() error) {
+	target.cleanups = append(target.cleanups, fn)
+}
+
+func (target *target) cleanup() error {
+	for a := len(target.cleanups) - 1; a >= 0; a-- {
+		if err := target.cleanups[a](); nil != err {
+			return err
+		}
+	}
+	target.cleanups = nil
+	return nil
+}
+
+func newTarget(server *cdptest.Server) *target {
+	return &target{Socket: socket.New(server.URL())}
+}
+
+func methods(server *cdptest.Server) []string {
+	commands := server.Commands()
+	methods := make([]string, len(commands))
+	for a, command := range commands {
+		methods[a] = command.Method
+	}
+	return methods
+}
+
+func TestProfileApply(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Page.addScriptToEvaluateOnNewDocument", map[string]string{"identifier": "script-1"})
+	target := newTarget(server)
+	defer target.Stop()
+
+	profile, err := Get("android-mobile")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if err := profile.Apply(target); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+
+	expected := []string{
+		"Emulation.setUserAgentOverride",
+		"Emulation.setLocaleOverride",
+		"Emulation.setTimezoneOverride",
+		"Emulation.setDeviceMetricsOverride",
+		"Page.addScriptToEvaluateOnNewDocument",
+	}
+	received := methods(server)
+	if len(expected) != len(received) {
+		t.Fatalf("Expected %v, got %v", expected, received)
+	}
+	for a := range expected {
+		if expected[a] != received[a] {
+			t.Errorf("Expected %v, got %v", expected, received)
+			break
+		}
+	}
+
+	commands := server.Commands()
+	userAgent := &emulation.SetUserAgentOverrideParams{}
+	json.Unmarshal(commands[0].Params, userAgent)
+	if "de-DE,de;q=0.9,en;q=0.8" != userAgent.AcceptLanguage {
+		t.Errorf("Expected Accept-Language 'de-DE,de;q=0.9,en;q=0.8', got '%s'", userAgent.AcceptLanguage)
+	}
+	if nil == userAgent.UserAgentMetadata || !userAgent.UserAgentMetadata.Mobile {
+		t.Errorf("Expected mobile user agent metadata, got %+v", userAgent.UserAgentMetadata)
+	}
+	locale := &emulation.SetLocaleOverrideParams{}
+	json.Unmarshal(commands[1].Params, locale)
+	if "de_DE" != locale.Locale {
+		t.Errorf("Expected locale 'de_DE', got '%s'", locale.Locale)
+	}
+	metrics := &emulation.SetDeviceMetricsOverrideParams{}
+	json.Unmarshal(commands[3].Params, metrics)
+	if 393 != metrics.ScreenWidth || 786 != metrics.ScreenHeight || !metrics.Mobile {
+		t.Errorf("Expected mobile 393x786 screen, got %+v", metrics)
+	}
+
+	if err := target.cleanup(); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	expected = []string{
+		"Page.removeScriptToEvaluateOnNewDocument",
+		"Emulation.clearDeviceMetricsOverride",
+		"Emulation.setTimezoneOverride",
+		"Emulation.setLocaleOverride",
+		"Emulation.setUserAgentOverride",
+	}
+	received = methods(server)[5:]
+	if len(expected) != len(received) {
+		t.Fatalf("Expected %v, got %v", expected, received)
+	}
+	for a := range expected {
+		if expected[a] != received[a] {
+			t.Errorf("Expected %v, got %v", expected, received)
+			break
+		}
+	}
+	if `{"identifier":"script-1"}` != string(server.Commands()[5].Params) {
+		t.Errorf("Expected script-1 to be removed, got %s", server.Commands()[5].Params)
+	}
+}
+
+func TestProfileApplyPartial(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	target := newTarget(server)
+	defer target.Stop()
+
+	profile := &Profile{Name: "timezone", Timezone: "Asia/Tokyo"}
+	if err := profile.Apply(target); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if received := methods(server); 1 != len(received) || "Emulation.setTimezoneOverride" != received[0] {
+		t.Errorf("Expected only a timezone override, got %v", received)
+	}
+}
+
+func TestProfileApplyError(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Emulation.setTimezoneOverride", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: -32000, Message: "Invalid timezone"}
+	})
+	target := newTarget(server)
+	defer target.Stop()
+
+	profile := &Profile{Name: "invalid", UserAgent: "user-agent", Timezone: "Invalid/Zone"}
+	if err := profile.Apply(target); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if 1 != len(target.cleanups) {
+		t.Errorf("Expected the user agent override to be cleaned up, got %d cleanup functions", len(target.cleanups))
+	}
+}
+
+type flags map[string]interface{}
+
+func (flags flags) Set(flag string, values interface{}) error {
+	if "lang" == flag {
+		return errors.New("flag error")
+	}
+	flags[flag] = values
+	return nil
+}
+
+func TestProfileSetFlags(t *testing.T) {
+	profile := &Profile{
+		UserAgent: "user-agent",
+		Screen:    &Screen{Width: 800, Height: 600},
+	}
+	set := flags{}
+	if err := profile.SetFlags(set); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if "user-agent" != set["user-agent"] || "800,600" != set["window-size"] {
+		t.Errorf("Expected user agent and window size flags, got %v", set)
+	}
+
+	profile.Languages = []string{"fr-FR"}
+	if err := profile.SetFlags(set); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestProfileCopy(t *testing.T) {
+	profile, _ := Get("windows-desktop")
+	cp := profile.Copy()
+	cp.Languages[0] = "changed"
+	cp.Screen.Width = 1
+	cp.WebGL.Vendor = "changed"
+	cp.UserAgentMetadata.Brands[0].Brand = "changed"
+	if "changed" == profile.Languages[0] ||
+		1 == profile.Screen.Width ||
+		"changed" == profile.WebGL.Vendor ||
+		"changed" == profile.UserAgentMetadata.Brands[0].Brand {
+		t.Errorf("Expected copy to not share data with the original")
+	}
+}
@@ -0,0 +1,158 @@
+package fingerprint
+
+import (
+	"bytes"
+	"fmt"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+Mitigation is an opt-in script that hides a common headless or automation
+signal. Mitigations only patch the JavaScript environment of documents created
+after they are applied, they don't change network level behavior.
+*/
+type Mitigation string
+
+/*
+Available mitigations.
+*/
+const (
+	// HideWebdriver makes navigator.webdriver return false, as it does in a
+	// browser that isn't controlled by automation.
+	HideWebdriver Mitigation = "hide-webdriver"
+
+	// ChromeShim defines a minimal window.chrome object if it is missing, as
+	// it is in headless mode.
+	ChromeShim Mitigation = "chrome-shim"
+
+	// LanguagesShim makes navigator.languages return the browser language if
+	// it is empty.
+	LanguagesShim Mitigation = "languages-shim"
+
+	// PluginsShim makes navigator.plugins and navigator.mimeTypes return the
+	// built-in PDF viewer if they are empty, as they are in headless mode.
+	PluginsShim Mitigation = "plugins-shim"
+)
+
+/*
+mitigationScripts maps mitigations to their scripts. Each script runs in its
+own function scope.
+*/
+var mitigationScripts = map[Mitigation]string{
+	HideWebdriver: `Object.defineProperty(Navigator.prototype, 'webdriver', {
+	configurable: true,
+	enumerable: true,
+	get: function () { return false; }
+});`,
+
+	ChromeShim: `if (!window.chrome) {
+	Object.defineProperty(window, 'chrome', {
+		configurable: true,
+		enumerable: true,
+		writable: true,
+		value: {app: {isInstalled: false}, runtime: {}}
+	});
+}`,
+
+	LanguagesShim: `if (!navigator.languages || 0 === navigator.languages.length) {
+	var language = navigator.language || 'en-US';
+	var languages = [language];
+	if (-1 !== language.indexOf('-')) {
+		languages.push(language.split('-')[0]);
+	}
+	Object.defineProperty(Navigator.prototype, 'languages', {
+		configurable: true,
+		enumerable: true,
+		get: function () { return Object.freeze(languages.slice()); }
+	});
+}`,
+
+	PluginsShim: `if (0 === navigator.plugins.length) {
+	var list = function (items, key) {
+		var result = {
+			length: items.length,
+			item: function (index) { return items[index] || null; },
+			namedItem: function (name) {
+				for (var a = 0; a < items.length; a++) {
+					if (items[a][key] === name) {
+						return items[a];
+					}
+				}
+				return null;
+			},
+			refresh: function () {}
+		};
+		for (var a = 0; a < items.length; a++) {
+			result[a] = items[a];
+		}
+		return result;
+	};
+	var plugin = {name: 'Chrome PDF Viewer', filename: 'internal-pdf-viewer', description: 'Portable Document Format'};
+	var mimeType = {type: 'application/pdf', suffixes: 'pdf', description: 'Portable Document Format', enabledPlugin: plugin};
+	plugin.length = 1;
+	plugin[0] = mimeType;
+	var plugins = list([plugin], 'name');
+	var mimeTypes = list([mimeType], 'type');
+	Object.defineProperty(Navigator.prototype, 'plugins', {
+		configurable: true,
+		enumerable: true,
+		get: function () { return plugins; }
+	});
+	Object.defineProperty(Navigator.prototype, 'mimeTypes', {
+		configurable: true,
+		enumerable: true,
+		get: function () { return mimeTypes; }
+	});
+}`,
+}
+
+/*
+MitigationScript returns the combined script for the specified mitigations. An
+error is returned for unknown mitigations.
+*/
+func MitigationScript(mitigations ...Mitigation) (string, error) {
+	buf := &bytes.Buffer{}
+	for _, mitigation := range mitigations {
+		script, ok := mitigationScripts[mitigation]
+		if !ok {
+			return "", errs.New(codes.FingerprintApplyFailed, fmt.Sprintf("unknown mitigation '%s'", mitigation))
+		}
+		fmt.Fprintf(buf, "(function () {\n%s\n})();\n", script)
+	}
+	return buf.String(), nil
+}
+
+/*
+Mitigate adds a script that applies the specified mitigations to every new
+document. The script is removed when the target is cleaned up. Mitigations are
+opt-in, none are applied unless they are requested here or listed in a
+profile:
+
+	err := fingerprint.Mitigate(tab, fingerprint.HideWebdriver, fingerprint.ChromeShim)
+*/
+func Mitigate(target Target, mitigations ...Mitigation) error {
+	if 0 == len(mitigations) {
+		return nil
+	}
+	script, err := MitigationScript(mitigations...)
+	if nil != err {
+		return err
+	}
+
+	result := <-target.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+		Source: script,
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.FingerprintApplyFailed, "could not add mitigation script")
+	}
+	identifier := result.Identifier
+	target.OnClose(func() error {
+		return (<-target.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+			Identifier: identifier,
+		})).Err
+	})
+	return nil
+}
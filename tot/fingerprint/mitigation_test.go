@@ -0,0 +1,78 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestMitigationScript(t *testing.T) {
+	script, err := MitigationScript(HideWebdriver, PluginsShim)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if !strings.Contains(script, "'webdriver'") || !strings.Contains(script, "'plugins'") {
+		t.Errorf("Expected webdriver and plugins mitigations, got '%s'", script)
+	}
+	if strings.Contains(script, "window.chrome") {
+		t.Errorf("Expected only the requested mitigations, got '%s'", script)
+	}
+
+	if _, err := MitigationScript(Mitigation("does-not-exist")); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestMitigate(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Page.addScriptToEvaluateOnNewDocument", map[string]string{"identifier": "script-1"})
+	target := newTarget(server)
+	defer target.Stop()
+
+	if err := Mitigate(target); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if 0 != len(server.Commands()) {
+		t.Errorf("Expected no mitigations by default, got %v", methods(server))
+	}
+
+	if err := Mitigate(target, ChromeShim, LanguagesShim); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	params := &page.AddScriptToEvaluateOnNewDocumentParams{}
+	json.Unmarshal(server.Commands()[0].Params, params)
+	if !strings.Contains(params.Source, "window.chrome") || !strings.Contains(params.Source, "'languages'") {
+		t.Errorf("Expected chrome and languages mitigations, got '%s'", params.Source)
+	}
+
+	if err := target.cleanup(); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if received := methods(server); 2 != len(received) || "Page.removeScriptToEvaluateOnNewDocument" != received[1] {
+		t.Errorf("Expected the mitigation script to be removed, got %v", received)
+	}
+}
+
+func TestProfileApplyMitigations(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	target := newTarget(server)
+	defer target.Stop()
+
+	profile := &Profile{Name: "mitigations", Mitigations: []Mitigation{HideWebdriver}}
+	if err := profile.Apply(target); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if received := methods(server); 1 != len(received) || "Page.addScriptToEvaluateOnNewDocument" != received[0] {
+		t.Errorf("Expected a mitigation script, got %v", received)
+	}
+
+	profile.Mitigations = []Mitigation{"does-not-exist"}
+	if err := profile.Apply(target); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
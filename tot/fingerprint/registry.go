@@ -0,0 +1,121 @@
+package fingerprint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/emulation"
+)
+
+var (
+	profiles   = make(map[string]*Profile)
+	profileMux = &sync.Mutex{}
+)
+
+func init() {
+	Register(&Profile{
+		Name:      "windows-desktop",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Safari/537.36",
+		UserAgentMetadata: &emulation.UserAgentMetadata{
+			Brands: []*emulation.UserAgentBrandVersion{
+				{Brand: "Chromium", Version: "80"},
+				{Brand: "Google Chrome", Version: "80"},
+			},
+			FullVersion:     "80.0.3987.132",
+			Platform:        "Windows",
+			PlatformVersion: "10.0",
+			Architecture:    "x86",
+		},
+		Platform:            "Win32",
+		Languages:           []string{"en-US", "en"},
+		Timezone:            "America/New_York",
+		Screen:              &Screen{Width: 1920, Height: 1080, DeviceScaleFactor: 1},
+		WebGL:               &WebGL{Vendor: "Google Inc.", Renderer: "ANGLE (Intel(R) UHD Graphics 620 Direct3D11 vs_5_0 ps_5_0)"},
+		HardwareConcurrency: 8,
+		DeviceMemory:        8,
+	})
+	Register(&Profile{
+		Name:      "macos-desktop",
+		UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Safari/537.36",
+		UserAgentMetadata: &emulation.UserAgentMetadata{
+			Brands: []*emulation.UserAgentBrandVersion{
+				{Brand: "Chromium", Version: "80"},
+				{Brand: "Google Chrome", Version: "80"},
+			},
+			FullVersion:     "80.0.3987.132",
+			Platform:        "macOS",
+			PlatformVersion: "10.15.3",
+			Architecture:    "x86",
+		},
+		Platform:            "MacIntel",
+		Languages:           []string{"en-GB", "en"},
+		Timezone:            "Europe/London",
+		Screen:              &Screen{Width: 1440, Height: 900, DeviceScaleFactor: 2},
+		WebGL:               &WebGL{Vendor: "Intel Inc.", Renderer: "Intel Iris Plus Graphics 655"},
+		HardwareConcurrency: 4,
+		DeviceMemory:        8,
+	})
+	Register(&Profile{
+		Name:      "android-mobile",
+		UserAgent: "Mozilla/5.0 (Linux; Android 10; Pixel 3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Mobile Safari/537.36",
+		UserAgentMetadata: &emulation.UserAgentMetadata{
+			Brands: []*emulation.UserAgentBrandVersion{
+				{Brand: "Chromium", Version: "80"},
+				{Brand: "Google Chrome", Version: "80"},
+			},
+			FullVersion:     "80.0.3987.132",
+			Platform:        "Android",
+			PlatformVersion: "10",
+			Model:           "Pixel 3",
+			Mobile:          true,
+		},
+		Platform:            "Linux armv8l",
+		Languages:           []string{"de-DE", "de", "en"},
+		Timezone:            "Europe/Berlin",
+		Screen:              &Screen{Width: 393, Height: 786, DeviceScaleFactor: 2.75, Mobile: true},
+		WebGL:               &WebGL{Vendor: "Qualcomm", Renderer: "Adreno (TM) 630"},
+		HardwareConcurrency: 8,
+		DeviceMemory:        4,
+	})
+}
+
+/*
+Get returns a copy of a registered profile.
+*/
+func Get(name string) (*Profile, error) {
+	profileMux.Lock()
+	defer profileMux.Unlock()
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, errs.New(codes.FingerprintNotFound, fmt.Sprintf("fingerprint profile '%s' not found", name))
+	}
+	return profile.Copy(), nil
+}
+
+/*
+Names returns the names of the registered profiles in alphabetical order.
+*/
+func Names() []string {
+	profileMux.Lock()
+	defer profileMux.Unlock()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+Register adds a named profile, replacing any existing profile with the same
+name. The profile is copied, later changes to it don't affect the registered
+profile.
+*/
+func Register(profile *Profile) {
+	profileMux.Lock()
+	profiles[profile.Name] = profile.Copy()
+	profileMux.Unlock()
+}
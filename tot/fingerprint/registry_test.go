@@ -0,0 +1,46 @@
+package fingerprint
+
+import (
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"android-mobile", "macos-desktop", "windows-desktop"} {
+		if _, err := Get(name); nil != err {
+			t.Errorf("Expected built-in profile '%s', got error: '%s'", name, err.Error())
+		}
+	}
+
+	profile := &Profile{Name: "TestRegistry", Timezone: "UTC"}
+	Register(profile)
+	profile.Timezone = "changed"
+	registered, err := Get("TestRegistry")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if "UTC" != registered.Timezone {
+		t.Errorf("Expected registered profile to be unaffected by later changes, got '%s'", registered.Timezone)
+	}
+	registered.Timezone = "changed"
+	if registered, _ = Get("TestRegistry"); "UTC" != registered.Timezone {
+		t.Errorf("Expected Get to return a copy, got '%s'", registered.Timezone)
+	}
+
+	found := false
+	names := Names()
+	for a, name := range names {
+		if a > 0 && names[a-1] > name {
+			t.Errorf("Expected sorted names, got %v", names)
+		}
+		if "TestRegistry" == name {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected 'TestRegistry' in %v", names)
+	}
+
+	if _, err = Get("does-not-exist"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,103 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*
+WebGL debug renderer info parameter values.
+
+https://www.khronos.org/registry/webgl/extensions/WEBGL_debug_renderer_info/
+*/
+const (
+	unmaskedVendorWebGL   = 0x9245
+	unmaskedRendererWebGL = 0x9246
+)
+
+/*
+scriptValues are the values spoofed by the injected script.
+*/
+type scriptValues struct {
+	DeviceMemory        float64  `json:"deviceMemory,omitempty"`
+	HardwareConcurrency int      `json:"hardwareConcurrency,omitempty"`
+	Languages           []string `json:"languages,omitempty"`
+	WebGLRenderer       string   `json:"webglRenderer,omitempty"`
+	WebGLVendor         string   `json:"webglVendor,omitempty"`
+}
+
+/*
+scriptTemplate spoofs navigator and WebGL properties. Navigator properties are
+redefined on the prototype so that the instance has no own properties that
+would reveal the override.
+*/
+const scriptTemplate = `(function (values) {
+	function define(proto, prop, value) {
+		try {
+			Object.defineProperty(proto, prop, {
+				configurable: true,
+				enumerable: true,
+				get: function () { return value; }
+			});
+		} catch (e) {}
+	}
+	if (values.deviceMemory) {
+		define(Navigator.prototype, 'deviceMemory', values.deviceMemory);
+	}
+	if (values.hardwareConcurrency) {
+		define(Navigator.prototype, 'hardwareConcurrency', values.hardwareConcurrency);
+	}
+	if (values.languages) {
+		define(Navigator.prototype, 'language', values.languages[0]);
+		define(Navigator.prototype, 'languages', Object.freeze(values.languages));
+	}
+	if (values.webglVendor || values.webglRenderer) {
+		[
+			typeof WebGLRenderingContext === 'undefined' ? null : WebGLRenderingContext,
+			typeof WebGL2RenderingContext === 'undefined' ? null : WebGL2RenderingContext
+		].forEach(function (context) {
+			if (!context) {
+				return;
+			}
+			var getParameter = context.prototype.getParameter;
+			context.prototype.getParameter = function (parameter) {
+				if (%d === parameter && values.webglVendor) {
+					return values.webglVendor;
+				}
+				if (%d === parameter && values.webglRenderer) {
+					return values.webglRenderer;
+				}
+				return getParameter.apply(this, arguments);
+			};
+		});
+	}
+})(%s);`
+
+/*
+Script returns the JavaScript source that spoofs the profile properties the
+DevTools protocol can't override directly: navigator.hardwareConcurrency,
+navigator.deviceMemory, navigator.language(s) and the WebGL vendor and
+renderer. An empty string is returned if the profile doesn't set any of them.
+*/
+func (profile *Profile) Script() string {
+	values := scriptValues{
+		DeviceMemory:        profile.DeviceMemory,
+		HardwareConcurrency: profile.HardwareConcurrency,
+		Languages:           profile.Languages,
+	}
+	if nil != profile.WebGL {
+		values.WebGLRenderer = profile.WebGL.Renderer
+		values.WebGLVendor = profile.WebGL.Vendor
+	}
+	if 0 == values.DeviceMemory &&
+		0 == values.HardwareConcurrency &&
+		0 == len(values.Languages) &&
+		"" == values.WebGLRenderer &&
+		"" == values.WebGLVendor {
+		return ""
+	}
+
+	// json.Marshal can't fail for these types.
+	data, _ := json.Marshal(values)
+	return fmt.Sprintf(scriptTemplate, unmaskedVendorWebGL, unmaskedRendererWebGL, data)
+}
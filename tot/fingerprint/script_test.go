@@ -0,0 +1,35 @@
+package fingerprint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfileScript(t *testing.T) {
+	profile := &Profile{Name: "empty", UserAgent: "user-agent", Timezone: "UTC"}
+	if script := profile.Script(); "" != script {
+		t.Errorf("Expected empty script, got '%s'", script)
+	}
+
+	profile = &Profile{
+		Name:                "script",
+		Languages:           []string{"de-DE", "de"},
+		WebGL:               &WebGL{Vendor: "Vendor \"quoted\"", Renderer: "Renderer"},
+		HardwareConcurrency: 4,
+		DeviceMemory:        0.5,
+	}
+	script := profile.Script()
+	for _, expected := range []string{
+		`"deviceMemory":0.5`,
+		`"hardwareConcurrency":4`,
+		`"languages":["de-DE","de"]`,
+		`"webglVendor":"Vendor \"quoted\""`,
+		`"webglRenderer":"Renderer"`,
+		"37445 === parameter",
+		"37446 === parameter",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected script to contain '%s', got '%s'", expected, script)
+		}
+	}
+}
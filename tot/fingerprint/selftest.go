@@ -0,0 +1,203 @@
+package fingerprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+selfTestPage is the bundled self-test page. The checks run when the page loads
+and window.goChromeSelfTest resolves to a JSON encoded list of signals.
+*/
+const selfTestPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>go-chrome self-test</title>
+</head>
+<body>
+<script>
+window.goChromeSelfTest = (function () {
+	function signal(name, description, leaked, value) {
+		return {name: name, description: description, leaked: !!leaked, value: String(value)};
+	}
+	function webgl() {
+		try {
+			var gl = document.createElement('canvas').getContext('webgl');
+			var info = gl.getExtension('WEBGL_debug_renderer_info');
+			return gl.getParameter(info.UNMASKED_VENDOR_WEBGL) + ' / ' + gl.getParameter(info.UNMASKED_RENDERER_WEBGL);
+		} catch (e) {
+			return '';
+		}
+	}
+
+	var signals = [
+		signal('webdriver', 'navigator.webdriver is true', true === navigator.webdriver, navigator.webdriver),
+		signal('headless-user-agent', 'the user agent contains HeadlessChrome', /HeadlessChrome/.test(navigator.userAgent), navigator.userAgent),
+		signal('plugins', 'navigator.plugins is empty', 0 === navigator.plugins.length, navigator.plugins.length),
+		signal('mime-types', 'navigator.mimeTypes is empty', 0 === navigator.mimeTypes.length, navigator.mimeTypes.length),
+		signal('languages', 'navigator.languages is empty', !navigator.languages || 0 === navigator.languages.length, navigator.languages),
+		signal('window-chrome', 'window.chrome is missing', !window.chrome, typeof window.chrome),
+		signal('window-size', 'the window has no outer dimensions', 0 === window.outerWidth && 0 === window.outerHeight, window.outerWidth + 'x' + window.outerHeight),
+		signal('webgl-renderer', 'WebGL uses a software renderer', /SwiftShader|llvmpipe/.test(webgl()), webgl())
+	];
+
+	var description = 'Notification.permission contradicts the permissions API';
+	if (!navigator.permissions || !window.Notification) {
+		signals.push(signal('permissions', description, true, 'permissions API unavailable'));
+		return Promise.resolve(JSON.stringify(signals));
+	}
+	return navigator.permissions.query({name: 'notifications'}).then(function (status) {
+		signals.push(signal('permissions', description, 'denied' === Notification.permission && 'prompt' === status.state, Notification.permission + ' / ' + status.state));
+		return JSON.stringify(signals);
+	}, function (e) {
+		signals.push(signal('permissions', description, true, e));
+		return JSON.stringify(signals);
+	});
+})();
+</script>
+</body>
+</html>
+`
+
+/*
+Signal is the result of a single automation signal check.
+*/
+type Signal struct {
+	// Name of the signal.
+	Name string `json:"name"`
+
+	// Description of what the page observed if the signal leaks.
+	Description string `json:"description"`
+
+	// Whether the signal reveals an automated browser.
+	Leaked bool `json:"leaked"`
+
+	// The value observed by the page.
+	Value string `json:"value"`
+}
+
+/*
+Report is the result of a self-test.
+*/
+type Report struct {
+	Signals []*Signal
+}
+
+/*
+Leaks returns the signals that reveal an automated browser.
+*/
+func (report *Report) Leaks() []*Signal {
+	leaks := make([]*Signal, 0)
+	for _, signal := range report.Signals {
+		if signal.Leaked {
+			leaks = append(leaks, signal)
+		}
+	}
+	return leaks
+}
+
+/*
+String implements Stringer. It returns one line per signal.
+*/
+func (report *Report) String() string {
+	buf := &bytes.Buffer{}
+	for _, signal := range report.Signals {
+		status := "ok  "
+		if signal.Leaked {
+			status = "LEAK"
+		}
+		fmt.Fprintf(buf, "%s %-20s %s (%s)\n", status, signal.Name, signal.Description, signal.Value)
+	}
+	return buf.String()
+}
+
+/*
+SelfTestTarget is the interface the self-test runs in. *chrome.Tab satisfies
+it.
+*/
+type SelfTestTarget interface {
+	Page() *socket.PageProtocol
+	Runtime() *socket.RuntimeProtocol
+}
+
+/*
+SelfTest loads a bundled test page that checks common automation signals and
+reports which of them leak, to validate a fingerprint configuration. Apply the
+profile under test before running it. The checked signals are:
+
+	- webdriver: navigator.webdriver is true
+	- headless-user-agent: the user agent contains HeadlessChrome
+	- plugins: navigator.plugins is empty
+	- mime-types: navigator.mimeTypes is empty
+	- languages: navigator.languages is empty
+	- window-chrome: window.chrome is missing
+	- window-size: the window has no outer dimensions
+	- webgl-renderer: WebGL uses a software renderer
+	- permissions: Notification.permission contradicts the permissions API
+
+The page is served from a temporary listener on the loopback interface and the
+target is left on that page. An error is returned if the page can't be loaded
+or doesn't report its results within the timeout.
+*/
+func SelfTest(target SelfTestTarget, timeout time.Duration) (*Report, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		return nil, errs.Wrap(err, codes.FingerprintSelfTestFailed, "could not start the self-test server")
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, selfTestPage)
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	pageURL := fmt.Sprintf("http://%s/", listener.Addr().String())
+	result := <-target.Page().Navigate(&page.NavigateParams{URL: pageURL})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.FingerprintSelfTestFailed, "could not load the self-test page")
+	}
+	if "" != result.ErrorText {
+		return nil, errs.New(codes.FingerprintSelfTestFailed, fmt.Sprintf("could not load the self-test page: %s", result.ErrorText))
+	}
+
+	// Navigate can return before the new document is created, so the
+	// expression checks that it's evaluated in the self-test page.
+	quotedURL, _ := json.Marshal(pageURL)
+	params := &runtime.EvaluateParams{
+		Expression:    fmt.Sprintf("(location.href === %s && window.goChromeSelfTest) || null", quotedURL),
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		result := <-target.Runtime().Evaluate(params)
+		if nil != result.Err {
+			return nil, errs.Wrap(result.Err, codes.FingerprintSelfTestFailed, "could not read the self-test results")
+		}
+		if nil != result.Result {
+			if value, ok := result.Result.Value.(string); ok {
+				report := &Report{}
+				if err := json.Unmarshal([]byte(value), &report.Signals); nil != err {
+					return nil, errs.Wrap(err, codes.FingerprintSelfTestFailed, "could not decode the self-test results")
+				}
+				return report, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, errs.New(codes.FingerprintSelfTestFailed, fmt.Sprintf("no self-test results after %s", timeout))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,91 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSelfTest(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	var pageBody string
+	server.Handle("Page.navigate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		navigate := &page.NavigateParams{}
+		json.Unmarshal(params, navigate)
+		response, err := http.Get(navigate.URL)
+		if nil != err {
+			return nil, &cdptest.Error{Code: -32000, Message: err.Error()}
+		}
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		pageBody = string(body)
+		return map[string]string{"frameId": "frame-id"}, nil
+	})
+	evaluations := 0
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluations++
+		// The first evaluation runs before the page is loaded.
+		if 1 == evaluations {
+			return map[string]interface{}{"result": map[string]interface{}{"type": "object", "subtype": "null", "value": nil}}, nil
+		}
+		signals, _ := json.Marshal([]*Signal{
+			{Name: "webdriver", Description: "navigator.webdriver is true", Leaked: true, Value: "true"},
+			{Name: "plugins", Description: "navigator.plugins is empty", Value: "3"},
+		})
+		return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": string(signals)}}, nil
+	})
+	target := newTarget(server)
+	defer target.Stop()
+
+	report, err := SelfTest(target, time.Second)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if !strings.Contains(pageBody, "window.goChromeSelfTest") {
+		t.Errorf("Expected the self-test page to be served, got '%s'", pageBody)
+	}
+	if 2 != evaluations {
+		t.Errorf("Expected 2 evaluations, got %d", evaluations)
+	}
+	if 2 != len(report.Signals) {
+		t.Fatalf("Expected 2 signals, got %d", len(report.Signals))
+	}
+	if leaks := report.Leaks(); 1 != len(leaks) || "webdriver" != leaks[0].Name {
+		t.Errorf("Expected the webdriver signal to leak, got %v", leaks)
+	}
+	if !strings.HasPrefix(report.String(), "LEAK webdriver") {
+		t.Errorf("Expected the report to list the webdriver leak first, got '%s'", report.String())
+	}
+}
+
+func TestSelfTestTimeout(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]interface{}{"type": "undefined"}})
+	target := newTarget(server)
+	defer target.Stop()
+
+	if _, err := SelfTest(target, 100*time.Millisecond); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestSelfTestNavigateError(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Page.navigate", map[string]string{"frameId": "frame-id", "errorText": "net::ERR_CONNECTION_REFUSED"})
+	target := newTarget(server)
+	defer target.Stop()
+
+	if _, err := SelfTest(target, time.Second); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
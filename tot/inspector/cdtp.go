@@ -0,0 +1,6 @@
+/*
+Package inspector provides type definitions for use with the Chrome Inspector protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/
+*/
+package inspector
@@ -0,0 +1,90 @@
+package inspector
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type detachReasonEnum struct {
+	TargetClosed         DetachReasonEnum
+	CanceledByUser       DetachReasonEnum
+	ReplacedWithDevtools DetachReasonEnum
+	RenderProcessGone    DetachReasonEnum
+}
+
+/*
+DetachReason provides named acces to the DetachReasonEnum values.
+*/
+var DetachReason = detachReasonEnum{
+	TargetClosed:         detachReasonTargetClosed,
+	CanceledByUser:       detachReasonCanceledByUser,
+	ReplacedWithDevtools: detachReasonReplacedWithDevtools,
+	RenderProcessGone:    detachReasonRenderProcessGone,
+}
+
+/*
+DetachReasonEnum defines the reason an Inspector.detached event reports for a
+terminated connection. Allowed values:
+	- DetachReason.TargetClosed         "target_closed"
+	- DetachReason.CanceledByUser       "canceled_by_user"
+	- DetachReason.ReplacedWithDevtools "replaced_with_devtools"
+	- DetachReason.RenderProcessGone    "Render process gone."
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-detached
+*/
+type DetachReasonEnum int
+
+/*
+String implements Stringer
+*/
+func (enum DetachReasonEnum) String() string {
+	return _detachReasonEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum DetachReasonEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *DetachReasonEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _detachReasonEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// detachReasonTargetClosed represents the "target_closed" value.
+	detachReasonTargetClosed DetachReasonEnum = iota + 1
+	// detachReasonCanceledByUser represents the "canceled_by_user" value.
+	detachReasonCanceledByUser
+	// detachReasonReplacedWithDevtools represents the "replaced_with_devtools" value.
+	detachReasonReplacedWithDevtools
+	// detachReasonRenderProcessGone represents the "Render process gone." value.
+	detachReasonRenderProcessGone
+)
+
+var _detachReasonEnums = map[DetachReasonEnum]string{
+	DetachReasonEnum(0):              "",
+	detachReasonTargetClosed:         "target_closed",
+	detachReasonCanceledByUser:       "canceled_by_user",
+	detachReasonReplacedWithDevtools: "replaced_with_devtools",
+	detachReasonRenderProcessGone:    "Render process gone.",
+}
@@ -0,0 +1,94 @@
+package inspector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumDetachReason(t *testing.T) {
+	var enum DetachReasonEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+
+	err = json.Unmarshal([]byte(`"invalid value"`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = DetachReason.TargetClosed
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"target_closed"` != string(result) {
+		t.Errorf("Expected '\"target_closed\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"target_closed"`), &enum)
+	if DetachReason.TargetClosed != enum {
+		t.Errorf("Expcected %d, got %d", DetachReason.TargetClosed, enum)
+	}
+
+	enum = DetachReason.CanceledByUser
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"canceled_by_user"` != string(result) {
+		t.Errorf("Expected '\"canceled_by_user\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"canceled_by_user"`), &enum)
+	if DetachReason.CanceledByUser != enum {
+		t.Errorf("Expcected %d, got %d", DetachReason.CanceledByUser, enum)
+	}
+
+	enum = DetachReason.ReplacedWithDevtools
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"replaced_with_devtools"` != string(result) {
+		t.Errorf("Expected '\"replaced_with_devtools\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"replaced_with_devtools"`), &enum)
+	if DetachReason.ReplacedWithDevtools != enum {
+		t.Errorf("Expcected %d, got %d", DetachReason.ReplacedWithDevtools, enum)
+	}
+
+	enum = DetachReason.RenderProcessGone
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"Render process gone."` != string(result) {
+		t.Errorf("Expected '\"Render process gone.\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"Render process gone."`), &enum)
+	if DetachReason.RenderProcessGone != enum {
+		t.Errorf("Expcected %d, got %d", DetachReason.RenderProcessGone, enum)
+	}
+}
+
+func TestDetachedEventDetachReason(t *testing.T) {
+	event := &DetachedEvent{Reason: "replaced_with_devtools"}
+	if DetachReason.ReplacedWithDevtools != event.DetachReason() {
+		t.Errorf("Expected %d, got %d", DetachReason.ReplacedWithDevtools, event.DetachReason())
+	}
+
+	event = &DetachedEvent{Reason: "some future reason"}
+	if DetachReasonEnum(0) != event.DetachReason() {
+		t.Errorf("Expected the zero value for an unknown reason, got %d", event.DetachReason())
+	}
+}
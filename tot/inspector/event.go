@@ -0,0 +1,49 @@
+package inspector
+
+/*
+DetachedEvent represents Inspector.detached event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-detached
+*/
+type DetachedEvent struct {
+	// The reason why connection has been terminated. See DetachReason.
+	Reason string `json:"reason"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+DetachReason returns the typed reason the connection was terminated. Reasons
+that aren't known to DetachReasonEnum return the zero value, Reason still
+holds the reason reported by the browser.
+*/
+func (event *DetachedEvent) DetachReason() DetachReasonEnum {
+	for k, v := range _detachReasonEnums {
+		if v == event.Reason {
+			return k
+		}
+	}
+	return DetachReasonEnum(0)
+}
+
+/*
+TargetCrashedEvent represents Inspector.targetCrashed event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-targetCrashed
+*/
+type TargetCrashedEvent struct {
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+TargetReloadedAfterCrashEvent represents Inspector.targetReloadedAfterCrash
+event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-targetReloadedAfterCrash
+*/
+type TargetReloadedAfterCrashEvent struct {
+	// Error information related to this event
+	Err error `json:"-"`
+}
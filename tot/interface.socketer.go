@@ -9,8 +9,8 @@ Socketer defins a simple socket interface for the tab struct to implement
 */
 type Socketer interface {
 	// AddEventHandler adds an event handler to the stack of listeners for an
-	// event.
-	AddEventHandler(handler socket.EventHandler)
+	// event and returns a subscription that removes it.
+	AddEventHandler(handler socket.EventHandler) *socket.Subscription
 
 	// RemoveEventHandler removes a handler from the stack of listeners for an
 	// event.
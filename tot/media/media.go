@@ -0,0 +1,118 @@
+/*
+Package media records the audio and video a page plays, for media playback QA.
+
+Record captures a <video>, <audio> or <canvas> element with the MediaStream
+capture API and MediaRecorder, the recording is transferred back when it's
+stopped:
+
+	recording, err := media.Record(tab, "video#player", nil)
+	if nil != err {
+		...
+	}
+	time.Sleep(10 * time.Second)
+	if err := recording.Save("player.webm"); nil != err {
+		...
+	}
+
+RecordScreencast saves the frames Chromium renders for the whole page as
+images, without audio.
+
+Pages that use getUserMedia can be fed prerecorded media instead of real
+devices by setting launch flags with SetFakeDeviceFlags.
+*/
+package media
+
+import (
+	"fmt"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Target is the interface element recordings are made on. *chrome.Tab satisfies
+it.
+*/
+type Target interface {
+	Runtime() *socket.RuntimeProtocol
+}
+
+/*
+FlagSetter is the interface launch flags are set on. chrome.Flags satisfies
+it.
+*/
+type FlagSetter interface {
+	Set(flag string, values interface{}) error
+}
+
+/*
+FakeDevices configures the fake capture devices Chromium uses in place of real
+cameras and microphones.
+*/
+type FakeDevices struct {
+	// Optional. A .y4m or .mjpeg file played by the fake camera. Omitting
+	// uses a generated test pattern.
+	Video string
+
+	// Optional. A .wav file played by the fake microphone. Omitting uses a
+	// generated beep.
+	Audio string
+}
+
+/*
+SetFakeDeviceFlags sets the launch flags that replace capture devices with
+fake devices, grant media permissions without a prompt and allow media to
+autoplay.
+*/
+func SetFakeDeviceFlags(flags FlagSetter, devices *FakeDevices) error {
+	if err := flags.Set("use-fake-device-for-media-stream", nil); nil != err {
+		return err
+	}
+	if err := flags.Set("use-fake-ui-for-media-stream", nil); nil != err {
+		return err
+	}
+	if err := flags.Set("autoplay-policy", "no-user-gesture-required"); nil != err {
+		return err
+	}
+	if nil == devices {
+		return nil
+	}
+	if "" != devices.Video {
+		if err := flags.Set("use-file-for-fake-video-capture", devices.Video); nil != err {
+			return err
+		}
+	}
+	if "" != devices.Audio {
+		if err := flags.Set("use-file-for-fake-audio-capture", devices.Audio); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+evaluate evaluates an expression and returns the value of the result.
+*/
+func evaluate(target Target, expression string) (interface{}, error) {
+	result := <-target.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:    expression,
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	})
+	if nil != result.Err {
+		return nil, result.Err
+	}
+	if nil != result.ExceptionDetails {
+		message := result.ExceptionDetails.Text
+		if nil != result.ExceptionDetails.Exception && "" != result.ExceptionDetails.Exception.Description {
+			message = result.ExceptionDetails.Exception.Description
+		}
+		return nil, errs.New(codes.MediaRecordFailed, fmt.Sprintf("script error: %s", message))
+	}
+	if nil == result.Result {
+		return nil, nil
+	}
+	return result.Result.Value, nil
+}
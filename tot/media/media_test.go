@@ -0,0 +1,30 @@
+package media
+
+import (
+	"testing"
+)
+
+type flags map[string]interface{}
+
+func (flags flags) Set(flag string, values interface{}) error {
+	flags[flag] = values
+	return nil
+}
+
+func TestSetFakeDeviceFlags(t *testing.T) {
+	set := flags{}
+	if err := SetFakeDeviceFlags(set, nil); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 3 != len(set) || "no-user-gesture-required" != set["autoplay-policy"] {
+		t.Errorf("Expected the fake device flags, got %v", set)
+	}
+
+	set = flags{}
+	if err := SetFakeDeviceFlags(set, &FakeDevices{Video: "clip.y4m", Audio: "tone.wav"}); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "clip.y4m" != set["use-file-for-fake-video-capture"] || "tone.wav" != set["use-file-for-fake-audio-capture"] {
+		t.Errorf("Expected the capture files, got %v", set)
+	}
+}
@@ -0,0 +1,142 @@
+package media
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+recordScript starts a MediaRecorder for an element and returns the recording
+ID. Its arguments are the element selector, the MIME type and the canvas frame
+rate.
+*/
+var recordScript = `(function(selector, mimeType, frameRate) {
+	var el = document.querySelector(selector);
+	if (!el) {
+		throw new Error("no element matches " + selector);
+	}
+	if (!el.captureStream) {
+		throw new Error(selector + " can't be captured");
+	}
+	var stream = (frameRate > 0 && el instanceof HTMLCanvasElement) ? el.captureStream(frameRate) : el.captureStream();
+	var recorder = new MediaRecorder(stream, mimeType ? {mimeType: mimeType} : {});
+	var chunks = [];
+	recorder.ondataavailable = function(event) {
+		if (event.data.size > 0) {
+			chunks.push(event.data);
+		}
+	};
+	var id = Date.now().toString(36) + Math.random().toString(36).slice(2);
+	window.__goChromeRecordings = window.__goChromeRecordings || {};
+	window.__goChromeRecordings[id] = {recorder: recorder, chunks: chunks};
+	recorder.start(1000);
+	return id;
+})(%s, %s, %d)`
+
+/*
+stopScript stops a recording and resolves with the base64 encoded recording.
+Its argument is the recording ID.
+*/
+var stopScript = `(function(id) {
+	var recording = window.__goChromeRecordings && window.__goChromeRecordings[id];
+	if (!recording) {
+		throw new Error("recording " + id + " not found");
+	}
+	delete window.__goChromeRecordings[id];
+	return new Promise(function(resolve, reject) {
+		recording.recorder.onstop = function() {
+			var reader = new FileReader();
+			reader.onload = function() {
+				resolve(reader.result.split(",")[1] || "");
+			};
+			reader.onerror = function() {
+				reject(reader.error);
+			};
+			reader.readAsDataURL(new Blob(recording.chunks, {type: recording.recorder.mimeType}));
+		};
+		recording.recorder.stop();
+	});
+})(%s)`
+
+/*
+RecordOptions configures an element recording.
+*/
+type RecordOptions struct {
+	// Optional. The recording MIME type, such as "video/webm;codecs=vp9".
+	// Omitting uses the browser default, usually WebM.
+	MimeType string
+
+	// Optional. The frame rate of canvas recordings. Omitting captures a new
+	// frame each time the canvas is painted.
+	FrameRate int
+}
+
+/*
+Recording is an element recording in progress.
+*/
+type Recording struct {
+	id     string
+	target Target
+}
+
+/*
+Record starts recording the media played by the first element matching a
+selector. The element must be a <video>, <audio> or <canvas> element. The
+recording continues until it's stopped, and stops with the page if the page
+navigates.
+*/
+func Record(target Target, selector string, options *RecordOptions) (*Recording, error) {
+	if nil == options {
+		options = &RecordOptions{}
+	}
+	quotedSelector, _ := json.Marshal(selector)
+	quotedMimeType, _ := json.Marshal(options.MimeType)
+	value, err := evaluate(target, fmt.Sprintf(recordScript, quotedSelector, quotedMimeType, options.FrameRate))
+	if nil != err {
+		return nil, errs.Wrap(err, codes.MediaRecordFailed, fmt.Sprintf("could not record '%s'", selector))
+	}
+	id, ok := value.(string)
+	if !ok {
+		return nil, errs.New(codes.MediaRecordFailed, fmt.Sprintf("could not record '%s': unexpected result %v", selector, value))
+	}
+	return &Recording{id: id, target: target}, nil
+}
+
+/*
+Stop stops the recording and returns the recorded media.
+*/
+func (recording *Recording) Stop() ([]byte, error) {
+	quotedID, _ := json.Marshal(recording.id)
+	value, err := evaluate(recording.target, fmt.Sprintf(stopScript, quotedID))
+	if nil != err {
+		return nil, errs.Wrap(err, codes.MediaRecordFailed, "could not stop recording")
+	}
+	encoded, ok := value.(string)
+	if !ok {
+		return nil, errs.New(codes.MediaRecordFailed, fmt.Sprintf("could not stop recording: unexpected result %v", value))
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.MediaRecordFailed, "could not decode recording")
+	}
+	return data, nil
+}
+
+/*
+Save stops the recording and writes the recorded media to a file.
+*/
+func (recording *Recording) Save(file string) error {
+	data, err := recording.Stop()
+	if nil != err {
+		return err
+	}
+	if err := ioutil.WriteFile(file, data, 0644); nil != err {
+		return errs.Wrap(err, codes.MediaWriteFailed, fmt.Sprintf("could not write recording to '%s'", file))
+	}
+	return nil
+}
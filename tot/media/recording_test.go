@@ -0,0 +1,66 @@
+package media
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestRecord(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		if strings.Contains(evaluate.Expression, "new MediaRecorder") {
+			return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": "rec-1"}}, nil
+		}
+		if !strings.Contains(evaluate.Expression, `"rec-1"`) {
+			return nil, &cdptest.Error{Code: -32000, Message: "unexpected recording ID"}
+		}
+		return map[string]interface{}{"result": map[string]interface{}{
+			"type":  "string",
+			"value": base64.StdEncoding.EncodeToString([]byte("webm")),
+		}}, nil
+	})
+
+	recording, err := Record(sock, "video#player", &RecordOptions{MimeType: "video/webm"})
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	dir, _ := ioutil.TempDir("", "media")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "player.webm")
+	if err := recording.Save(file); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if data, _ := ioutil.ReadFile(file); "webm" != string(data) {
+		t.Errorf("Expected the recording to be saved, got '%s'", data)
+	}
+	if err := recording.Save(filepath.Join(dir, "missing", "player.webm")); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	server.Respond("Runtime.evaluate", map[string]interface{}{
+		"result":           map[string]interface{}{"type": "object"},
+		"exceptionDetails": map[string]interface{}{"exceptionId": 1, "text": "Uncaught", "exception": map[string]interface{}{"type": "object", "description": "Error: no element matches video"}},
+	})
+	if _, err := Record(sock, "video", nil); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+}
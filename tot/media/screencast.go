@@ -0,0 +1,111 @@
+package media
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+ScreencastTarget is the interface screencasts are recorded on. *chrome.Tab
+satisfies it.
+*/
+type ScreencastTarget interface {
+	Page() *socket.PageProtocol
+}
+
+/*
+Screencast is a screencast recording in progress.
+*/
+type Screencast struct {
+	dir          string
+	err          error
+	ext          string
+	frames       int
+	mux          *sync.Mutex
+	subscription *socket.Subscription
+	target       ScreencastTarget
+}
+
+/*
+RecordScreencast starts a screencast and writes each frame to dir as
+frame-00000.jpg, frame-00001.jpg, etc. Frames are PNG files if params requests
+the PNG format. Frames are acknowledged as they're written, so Chromium sends
+frames only as fast as they can be saved.
+
+The frames can be assembled into a video with a tool such as ffmpeg.
+*/
+func RecordScreencast(target ScreencastTarget, dir string, params *page.StartScreencastParams) (*Screencast, error) {
+	if nil == params {
+		params = &page.StartScreencastParams{}
+	}
+	if err := os.MkdirAll(dir, 0755); nil != err {
+		return nil, errs.Wrap(err, codes.MediaWriteFailed, fmt.Sprintf("could not create screencast directory '%s'", dir))
+	}
+	screencast := &Screencast{
+		dir:    dir,
+		ext:    "jpg",
+		mux:    &sync.Mutex{},
+		target: target,
+	}
+	if page.Format.Png == params.Format {
+		screencast.ext = "png"
+	}
+	screencast.subscription = target.Page().OnScreencastFrame(screencast.frame)
+
+	if result := <-target.Page().StartScreencast(params); nil != result.Err {
+		screencast.subscription.Remove()
+		return nil, errs.Wrap(result.Err, codes.MediaRecordFailed, "could not start screencast")
+	}
+	return screencast, nil
+}
+
+/*
+frame writes a screencast frame and acknowledges it.
+*/
+func (screencast *Screencast) frame(event *page.ScreencastFrameEvent) {
+	screencast.mux.Lock()
+	file := filepath.Join(screencast.dir, fmt.Sprintf("frame-%05d.%s", screencast.frames, screencast.ext))
+	screencast.frames++
+	screencast.mux.Unlock()
+
+	data, err := base64.StdEncoding.DecodeString(event.Data)
+	if nil == err {
+		err = ioutil.WriteFile(file, data, 0644)
+	}
+	if nil != err {
+		screencast.mux.Lock()
+		if nil == screencast.err {
+			screencast.err = errs.Wrap(err, codes.MediaWriteFailed, fmt.Sprintf("could not write screencast frame '%s'", file))
+		}
+		screencast.mux.Unlock()
+	}
+
+	<-screencast.target.Page().ScreencastFrameAck(&page.ScreencastFrameAckParams{
+		SessionID: event.SessionID,
+	})
+}
+
+/*
+Stop stops the screencast and returns the number of frames recorded. If a frame
+could not be written the first error is returned.
+*/
+func (screencast *Screencast) Stop() (int, error) {
+	result := <-screencast.target.Page().StopScreencast()
+	screencast.subscription.Remove()
+
+	screencast.mux.Lock()
+	defer screencast.mux.Unlock()
+	if nil != result.Err {
+		return screencast.frames, errs.Wrap(result.Err, codes.MediaRecordFailed, "could not stop screencast")
+	}
+	return screencast.frames, screencast.err
+}
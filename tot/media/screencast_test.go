@@ -0,0 +1,64 @@
+package media
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestRecordScreencast(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	dir, _ := ioutil.TempDir("", "media")
+	defer os.RemoveAll(dir)
+	screencast, err := RecordScreencast(sock, dir, &page.StartScreencastParams{Format: page.Format.Png})
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	for a := 1; a <= 2; a++ {
+		server.Emit("Page.screencastFrame", map[string]interface{}{
+			"data":      base64.StdEncoding.EncodeToString([]byte("frame")),
+			"metadata":  map[string]interface{}{"offsetTop": 0},
+			"sessionId": a,
+		})
+	}
+
+	acks := 0
+	for start := time.Now(); acks < 2 && time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		acks = 0
+		for _, command := range server.Commands() {
+			if "Page.screencastFrameAck" == command.Method {
+				acks++
+			}
+		}
+	}
+	frames, err := screencast.Stop()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 2 != frames || 2 != acks {
+		t.Errorf("Expected 2 acknowledged frames, got %d frames and %d acks", frames, acks)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, "frame-00001.png")); "frame" != string(data) {
+		t.Errorf("Expected the frame to be written, got '%s'", data)
+	}
+
+	server.Emit("Page.screencastFrame", map[string]interface{}{"data": "frame", "sessionId": 3})
+	time.Sleep(50 * time.Millisecond)
+	if frames, _ := screencast.Stop(); 2 != frames {
+		t.Errorf("Expected frames after Stop to be ignored, got %d", frames)
+	}
+}
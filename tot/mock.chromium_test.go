@@ -310,7 +310,8 @@ func (chrome *MockChrome) NewTab(uri string) (*Tab, error) {
 	}
 
 	tab := &Tab{
-		chrome: chrome,
+		chrome:  chrome,
+		cleanup: newCleanupStack(),
 		data: &TabData{
 			Description:          "",
 			DevtoolsFrontendURL:  "",
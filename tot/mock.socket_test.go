@@ -1,6 +1,7 @@
 package chrome
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/mkenney/go-chrome/tot/socket"
@@ -18,20 +19,25 @@ func NewMockSocket(url *url.URL) *MockSocket {
 	mockSocket.audits = &socket.AuditsProtocol{Socket: mockSocket}
 	mockSocket.browser = &socket.BrowserProtocol{Socket: mockSocket}
 	mockSocket.cacheStorage = &socket.CacheStorageProtocol{Socket: mockSocket}
+	mockSocket.cast = &socket.CastProtocol{Socket: mockSocket}
 	mockSocket.console = &socket.ConsoleProtocol{Socket: mockSocket}
 	mockSocket.css = &socket.CSSProtocol{Socket: mockSocket}
 	mockSocket.database = &socket.DatabaseProtocol{Socket: mockSocket}
 	mockSocket.debugger = &socket.DebuggerProtocol{Socket: mockSocket}
+	mockSocket.deviceAccess = &socket.DeviceAccessProtocol{Socket: mockSocket}
 	mockSocket.deviceOrientation = &socket.DeviceOrientationProtocol{Socket: mockSocket}
 	mockSocket.domDebugger = &socket.DOMDebuggerProtocol{Socket: mockSocket}
 	mockSocket.domSnapshot = &socket.DOMSnapshotProtocol{Socket: mockSocket}
 	mockSocket.domStorage = &socket.DOMStorageProtocol{Socket: mockSocket}
 	mockSocket.dom = &socket.DOMProtocol{Socket: mockSocket}
 	mockSocket.emulation = &socket.EmulationProtocol{Socket: mockSocket}
+	mockSocket.fedCM = &socket.FedCMProtocol{Socket: mockSocket}
+	mockSocket.fetch = &socket.FetchProtocol{Socket: mockSocket}
 	mockSocket.headlessExperimental = &socket.HeadlessExperimentalProtocol{Socket: mockSocket}
 	mockSocket.heapProfiler = &socket.HeapProfilerProtocol{Socket: mockSocket}
 	mockSocket.indexedDB = &socket.IndexedDBProtocol{Socket: mockSocket}
 	mockSocket.input = &socket.InputProtocol{Socket: mockSocket}
+	mockSocket.inspector = &socket.InspectorProtocol{Socket: mockSocket}
 	mockSocket.io = &socket.IOProtocol{Socket: mockSocket}
 	mockSocket.layerTree = &socket.LayerTreeProtocol{Socket: mockSocket}
 	mockSocket.log = &socket.LogProtocol{Socket: mockSocket}
@@ -40,6 +46,8 @@ func NewMockSocket(url *url.URL) *MockSocket {
 	mockSocket.overlay = &socket.OverlayProtocol{Socket: mockSocket}
 	mockSocket.page = &socket.PageProtocol{Socket: mockSocket}
 	mockSocket.performance = &socket.PerformanceProtocol{Socket: mockSocket}
+	mockSocket.performanceTimeline = &socket.PerformanceTimelineProtocol{Socket: mockSocket}
+	mockSocket.preload = &socket.PreloadProtocol{Socket: mockSocket}
 	mockSocket.profiler = &socket.ProfilerProtocol{Socket: mockSocket}
 	mockSocket.runtime = &socket.RuntimeProtocol{Socket: mockSocket}
 	mockSocket.schema = &socket.SchemaProtocol{Socket: mockSocket}
@@ -50,6 +58,7 @@ func NewMockSocket(url *url.URL) *MockSocket {
 	mockSocket.target = &socket.TargetProtocol{Socket: mockSocket}
 	mockSocket.tethering = &socket.TetheringProtocol{Socket: mockSocket}
 	mockSocket.tracing = &socket.TracingProtocol{Socket: mockSocket}
+	mockSocket.webAuthn = &socket.WebAuthnProtocol{Socket: mockSocket}
 
 	return mockSocket
 }
@@ -69,20 +78,25 @@ type MockSocket struct {
 	audits               *socket.AuditsProtocol
 	browser              *socket.BrowserProtocol
 	cacheStorage         *socket.CacheStorageProtocol
+	cast                 *socket.CastProtocol
 	console              *socket.ConsoleProtocol
 	css                  *socket.CSSProtocol
 	database             *socket.DatabaseProtocol
 	debugger             *socket.DebuggerProtocol
+	deviceAccess         *socket.DeviceAccessProtocol
 	deviceOrientation    *socket.DeviceOrientationProtocol
 	domDebugger          *socket.DOMDebuggerProtocol
 	domSnapshot          *socket.DOMSnapshotProtocol
 	domStorage           *socket.DOMStorageProtocol
 	dom                  *socket.DOMProtocol
 	emulation            *socket.EmulationProtocol
+	fedCM                *socket.FedCMProtocol
+	fetch                *socket.FetchProtocol
 	headlessExperimental *socket.HeadlessExperimentalProtocol
 	heapProfiler         *socket.HeapProfilerProtocol
 	indexedDB            *socket.IndexedDBProtocol
 	input                *socket.InputProtocol
+	inspector            *socket.InspectorProtocol
 	io                   *socket.IOProtocol
 	layerTree            *socket.LayerTreeProtocol
 	log                  *socket.LogProtocol
@@ -91,6 +105,8 @@ type MockSocket struct {
 	overlay              *socket.OverlayProtocol
 	page                 *socket.PageProtocol
 	performance          *socket.PerformanceProtocol
+	performanceTimeline  *socket.PerformanceTimelineProtocol
+	preload              *socket.PreloadProtocol
 	profiler             *socket.ProfilerProtocol
 	runtime              *socket.RuntimeProtocol
 	schema               *socket.SchemaProtocol
@@ -101,14 +117,16 @@ type MockSocket struct {
 	target               *socket.TargetProtocol
 	tethering            *socket.TetheringProtocol
 	tracing              *socket.TracingProtocol
+	webAuthn             *socket.WebAuthnProtocol
 }
 
 /*
 AddEventHandler is a Socketer implementation.
 */
-func (socket *MockSocket) AddEventHandler(
+func (sock *MockSocket) AddEventHandler(
 	handler socket.EventHandler,
-) {
+) *socket.Subscription {
+	return socket.NewSubscription(handler, func() error { return nil })
 }
 
 /*
@@ -156,6 +174,13 @@ func (socket *MockSocket) SendCommand(command socket.Commander) chan *socket.Res
 	return command.Response()
 }
 
+/*
+SendCommandContext is a Socketer implementation.
+*/
+func (socket *MockSocket) SendCommandContext(ctx context.Context, command socket.Commander) chan *socket.Response {
+	return socket.SendCommand(command)
+}
+
 /*
 Stop is a Socketer implementation.
 */
@@ -211,6 +236,13 @@ func (socket *MockSocket) CacheStorage() *socket.CacheStorageProtocol {
 	return socket.cacheStorage
 }
 
+/*
+Cast is a Protocoller implementation.
+*/
+func (socket *MockSocket) Cast() *socket.CastProtocol {
+	return socket.cast
+}
+
 /*
 Console is a Protocoller implementation.
 */
@@ -239,6 +271,13 @@ func (socket *MockSocket) Debugger() *socket.DebuggerProtocol {
 	return socket.debugger
 }
 
+/*
+DeviceAccess is a Protocoller implementation.
+*/
+func (socket *MockSocket) DeviceAccess() *socket.DeviceAccessProtocol {
+	return socket.deviceAccess
+}
+
 /*
 DeviceOrientation is a Protocoller implementation.
 */
@@ -281,6 +320,20 @@ func (socket *MockSocket) Emulation() *socket.EmulationProtocol {
 	return socket.emulation
 }
 
+/*
+FedCM is a Protocoller implementation.
+*/
+func (socket *MockSocket) FedCM() *socket.FedCMProtocol {
+	return socket.fedCM
+}
+
+/*
+Fetch is a Protocoller implementation.
+*/
+func (socket *MockSocket) Fetch() *socket.FetchProtocol {
+	return socket.fetch
+}
+
 /*
 HeadlessExperimental is a Protocoller implementation.
 */
@@ -309,6 +362,13 @@ func (socket *MockSocket) Input() *socket.InputProtocol {
 	return socket.input
 }
 
+/*
+Inspector is a Protocoller implementation.
+*/
+func (socket *MockSocket) Inspector() *socket.InspectorProtocol {
+	return socket.inspector
+}
+
 /*
 IO is a Protocoller implementation.
 */
@@ -365,6 +425,20 @@ func (socket *MockSocket) Performance() *socket.PerformanceProtocol {
 	return socket.performance
 }
 
+/*
+PerformanceTimeline is a Protocoller implementation.
+*/
+func (socket *MockSocket) PerformanceTimeline() *socket.PerformanceTimelineProtocol {
+	return socket.performanceTimeline
+}
+
+/*
+Preload is a Protocoller implementation.
+*/
+func (socket *MockSocket) Preload() *socket.PreloadProtocol {
+	return socket.preload
+}
+
 /*
 Profiler is a Protocoller implementation.
 */
@@ -434,3 +508,10 @@ Tracing is a Protocoller implementation.
 func (socket *MockSocket) Tracing() *socket.TracingProtocol {
 	return socket.tracing
 }
+
+/*
+WebAuthn is a Protocoller implementation.
+*/
+func (socket *MockSocket) WebAuthn() *socket.WebAuthnProtocol {
+	return socket.webAuthn
+}
@@ -429,6 +429,29 @@ type Cookie struct {
 	//	- CookieSameSite.Strict
 	//	- CookieSameSite.Lax
 	SameSite CookieSameSiteEnum `json:"sameSite,omitempty"`
+
+	// Optional. Cookie partition key. Unpartitioned cookies have no key.
+	// EXPERIMENTAL.
+	PartitionKey *CookiePartitionKey `json:"partitionKey,omitempty"`
+
+	// Optional. True if the cookie partition key is opaque. EXPERIMENTAL.
+	PartitionKeyOpaque bool `json:"partitionKeyOpaque,omitempty"`
+}
+
+/*
+CookiePartitionKey is the partition key of a partitioned cookie (CHIPS).
+EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-CookiePartitionKey
+*/
+type CookiePartitionKey struct {
+	// The site of the top-level URL the browser was visiting at the start of
+	// the request to the endpoint that set the cookie.
+	TopLevelSite string `json:"topLevelSite"`
+
+	// Indicates if the cookie has any ancestors that are cross-site to the
+	// topLevelSite.
+	HasCrossSiteAncestor bool `json:"hasCrossSiteAncestor"`
 }
 
 /*
@@ -496,3 +519,76 @@ type RequestPattern struct {
 	//	- InterceptionStage.HeadersReceived
 	InterceptionStage InterceptionStageEnum `json:"interceptionStage,omitempty"`
 }
+
+/*
+CorsError is the reason a request was blocked by CORS. The list of reasons
+grows with new Chrome versions. Values include:
+"DisallowedByMode", "InvalidResponse", "WildcardOriginNotAllowed",
+"MissingAllowOriginHeader", "MultipleAllowOriginValues",
+"InvalidAllowOriginValue", "AllowOriginMismatch", "InvalidAllowCredentials",
+"CorsDisabledScheme", "PreflightInvalidStatus", "PreflightDisallowedRedirect",
+"PreflightWildcardOriginNotAllowed", "PreflightMissingAllowOriginHeader",
+"PreflightMultipleAllowOriginValues", "PreflightInvalidAllowOriginValue",
+"PreflightAllowOriginMismatch", "PreflightInvalidAllowCredentials",
+"PreflightMissingAllowExternal", "PreflightInvalidAllowExternal",
+"PreflightMissingAllowPrivateNetwork", "PreflightInvalidAllowPrivateNetwork",
+"InvalidAllowMethodsPreflightResponse", "InvalidAllowHeadersPreflightResponse",
+"MethodDisallowedByPreflightResponse", "HeaderDisallowedByPreflightResponse",
+"RedirectContainsCredentials", "InsecurePrivateNetwork",
+"InvalidPrivateNetworkAccess", "UnexpectedPrivateNetworkAccess",
+"NoCorsRedirectModeNotFollow", "PreflightMissingPrivateNetworkAccessId",
+"PreflightMissingPrivateNetworkAccessName",
+"PrivateNetworkAccessPermissionUnavailable",
+"PrivateNetworkAccessPermissionDenied", "LocalNetworkAccessPermissionDenied".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-CorsError
+*/
+type CorsError string
+
+/*
+CorsErrorStatus is the CORS error of a blocked request.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-CorsErrorStatus
+*/
+type CorsErrorStatus struct {
+	// The reason the request was blocked.
+	CorsError CorsError `json:"corsError"`
+
+	// The header or value that failed the check, if any.
+	FailedParameter string `json:"failedParameter"`
+}
+
+/*
+IPAddressSpace is the address space of an IP address. EXPERIMENTAL. Allowed
+values: "Loopback", "Local", "Public", "Unknown".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-IPAddressSpace
+*/
+type IPAddressSpace string
+
+/*
+PrivateNetworkRequestPolicy is the policy applied to requests to more private
+address spaces. EXPERIMENTAL. Allowed values: "Allow",
+"BlockFromInsecureToMorePrivate", "WarnFromInsecureToMorePrivate",
+"PreflightBlock", "PreflightWarn", "PermissionBlock", "PermissionWarn".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-PrivateNetworkRequestPolicy
+*/
+type PrivateNetworkRequestPolicy string
+
+/*
+ClientSecurityState is the security state of the client that initiated a
+request. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-ClientSecurityState
+*/
+type ClientSecurityState struct {
+	// Whether the initiator is a secure context.
+	InitiatorIsSecureContext bool `json:"initiatorIsSecureContext"`
+
+	// The address space of the initiator.
+	InitiatorIPAddressSpace IPAddressSpace `json:"initiatorIPAddressSpace"`
+
+	// The private network request policy applied to the initiator.
+	PrivateNetworkRequestPolicy PrivateNetworkRequestPolicy `json:"privateNetworkRequestPolicy"`
+}
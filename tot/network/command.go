@@ -145,6 +145,11 @@ type DeleteCookiesParams struct {
 
 	// Optional. If specified, deletes only cookies with the exact path.
 	Path string `json:"path,omitempty"`
+
+	// Optional. If specified, deletes only cookies with the given name
+	// and partitionKey where all partition key attributes match the cookie
+	// partition key attribute. EXPERIMENTAL.
+	PartitionKey *CookiePartitionKey `json:"partitionKey,omitempty"`
 }
 
 /*
@@ -294,6 +299,29 @@ type GetCookiesResult struct {
 	Err error `json:"-"`
 }
 
+/*
+GetRequestPostDataParams represents Network.getRequestPostData parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getRequestPostData
+*/
+type GetRequestPostDataParams struct {
+	// Identifier of the network request to get content for.
+	RequestID RequestID `json:"requestId"`
+}
+
+/*
+GetRequestPostDataResult represents the result of calls to Network.getRequestPostData.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getRequestPostData
+*/
+type GetRequestPostDataResult struct {
+	// Request body string, omitting files from multipart requests.
+	PostData string `json:"postData"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 GetResponseBodyParams represents Network.getResponseBody parameters.
 
@@ -495,6 +523,10 @@ type SetCookieParams struct {
 
 	// Optional. Cookie expiration date, session cookie if not set.
 	Expires TimeSinceEpoch `json:"expires,omitempty"`
+
+	// Optional. Cookie partition key. If not set, the cookie will be set as
+	// not partitioned. EXPERIMENTAL.
+	PartitionKey *CookiePartitionKey `json:"partitionKey,omitempty"`
 }
 
 /*
@@ -510,6 +542,32 @@ type SetCookieResult struct {
 	Err error `json:"-"`
 }
 
+/*
+SetCookieControlsParams represents Network.setCookieControls parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-setCookieControls
+*/
+type SetCookieControlsParams struct {
+	// Whether third-party cookie restriction is enabled.
+	EnableThirdPartyCookieRestriction bool `json:"enableThirdPartyCookieRestriction"`
+
+	// Whether third-party cookie deprecation metadata grants are disabled.
+	DisableThirdPartyCookieMetadata bool `json:"disableThirdPartyCookieMetadata"`
+
+	// Whether third-party cookie deprecation heuristics are disabled.
+	DisableThirdPartyCookieHeuristics bool `json:"disableThirdPartyCookieHeuristics"`
+}
+
+/*
+SetCookieControlsResult represents the result of calls to Network.setCookieControls.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-setCookieControls
+*/
+type SetCookieControlsResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 SetCookiesParams represents Network.setCookies parameters.
 
@@ -643,6 +643,26 @@ type SetLifecycleEventsEnabledResult struct {
 	Err error `json:"-"`
 }
 
+/*
+SetWebLifecycleStateParams represents Page.setWebLifecycleState parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setWebLifecycleState
+*/
+type SetWebLifecycleStateParams struct {
+	// Target lifecycle state. Allowed values: frozen, active.
+	State WebLifecycleStateEnum `json:"state"`
+}
+
+/*
+SetWebLifecycleStateResult represents the result of calls to Page.setWebLifecycleState.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setWebLifecycleState
+*/
+type SetWebLifecycleStateResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 StartScreencastParams represents Page.startScreencast parameters.
 
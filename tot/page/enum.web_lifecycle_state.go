@@ -0,0 +1,77 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type webLifecycleStateEnum struct {
+	Frozen WebLifecycleStateEnum
+	Active WebLifecycleStateEnum
+}
+
+/*
+WebLifecycleState provides named acces to the WebLifecycleStateEnum values.
+*/
+var WebLifecycleState = webLifecycleStateEnum{
+	Frozen: webLifecycleStateFrozen,
+	Active: webLifecycleStateActive,
+}
+
+/*
+WebLifecycleStateEnum defines the web lifecycle state of a page. Allowed values:
+	- WebLifecycleState.Frozen "frozen"
+	- WebLifecycleState.Active "active"
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setWebLifecycleState
+*/
+type WebLifecycleStateEnum int
+
+/*
+String implements Stringer
+*/
+func (enum WebLifecycleStateEnum) String() string {
+	return _webLifecycleStateEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum WebLifecycleStateEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *WebLifecycleStateEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _webLifecycleStateEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// webLifecycleStateFrozen represents the "frozen" value.
+	webLifecycleStateFrozen WebLifecycleStateEnum = iota + 1
+	// webLifecycleStateActive represents the "active" value.
+	webLifecycleStateActive
+)
+
+var _webLifecycleStateEnums = map[WebLifecycleStateEnum]string{
+	WebLifecycleStateEnum(0): "",
+	webLifecycleStateFrozen:  "frozen",
+	webLifecycleStateActive:  "active",
+}
@@ -0,0 +1,56 @@
+package page
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumWebLifecycleState(t *testing.T) {
+	var enum WebLifecycleStateEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+
+	err = json.Unmarshal([]byte(`"invalid value"`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = WebLifecycleState.Frozen
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"frozen"` != string(result) {
+		t.Errorf("Expected '\"frozen\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"frozen"`), &enum)
+	if WebLifecycleState.Frozen != enum {
+		t.Errorf("Expcected %d, got %d", WebLifecycleState.Frozen, enum)
+	}
+
+	enum = WebLifecycleState.Active
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"active"` != string(result) {
+		t.Errorf("Expected '\"active\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"active"`), &enum)
+	if WebLifecycleState.Active != enum {
+		t.Errorf("Expcected %d, got %d", WebLifecycleState.Active, enum)
+	}
+}
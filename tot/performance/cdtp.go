@@ -5,6 +5,50 @@ https://chromedevtools.github.io/devtools-protocol/tot/Performance/
 */
 package performance
 
+/*
+Names of common run-time metrics reported by Performance.getMetrics. Durations
+are in seconds and sizes in bytes.
+*/
+const (
+	// Documents is the number of documents in the page.
+	Documents = "Documents"
+
+	// Frames is the number of frames in the page.
+	Frames = "Frames"
+
+	// JSEventListeners is the number of JavaScript event listeners.
+	JSEventListeners = "JSEventListeners"
+
+	// Nodes is the number of DOM nodes.
+	Nodes = "Nodes"
+
+	// LayoutCount is the number of full or partial page layouts.
+	LayoutCount = "LayoutCount"
+
+	// RecalcStyleCount is the number of page style recalculations.
+	RecalcStyleCount = "RecalcStyleCount"
+
+	// LayoutDuration is the combined duration of all page layouts.
+	LayoutDuration = "LayoutDuration"
+
+	// RecalcStyleDuration is the combined duration of all page style
+	// recalculations.
+	RecalcStyleDuration = "RecalcStyleDuration"
+
+	// ScriptDuration is the combined duration of JavaScript execution.
+	ScriptDuration = "ScriptDuration"
+
+	// TaskDuration is the combined duration of all tasks performed by the
+	// browser.
+	TaskDuration = "TaskDuration"
+
+	// JSHeapUsedSize is the used JavaScript heap size.
+	JSHeapUsedSize = "JSHeapUsedSize"
+
+	// JSHeapTotalSize is the total JavaScript heap size.
+	JSHeapTotalSize = "JSHeapTotalSize"
+)
+
 /*
 Metric is a run-time execution metric.
 
@@ -15,5 +59,17 @@ type Metric struct {
 	Name string `json:"name"`
 
 	// Metric value.
-	Value int `json:"value"`
+	Value float64 `json:"value"`
+}
+
+/*
+metricValue returns the value of the named metric.
+*/
+func metricValue(metrics []*Metric, name string) (float64, bool) {
+	for _, metric := range metrics {
+		if name == metric.Name {
+			return metric.Value, true
+		}
+	}
+	return 0, false
 }
@@ -10,6 +10,19 @@ type DisableResult struct {
 	Err error `json:"-"`
 }
 
+/*
+EnableParams represents Performance.enable parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-enable
+*/
+type EnableParams struct {
+	// Optional. Time domain to use for collecting and reporting duration
+	// metrics. Allowed values:
+	//	- TimeDomain.TimeTicks
+	//	- TimeDomain.ThreadTicks
+	TimeDomain TimeDomainEnum `json:"timeDomain,omitempty"`
+}
+
 /*
 EnableResult represents the result of calls to Performance.enable.
 
@@ -32,3 +45,33 @@ type GetMetricsResult struct {
 	// Error information related to executing this method
 	Err error `json:"-"`
 }
+
+/*
+Value returns the value of the named metric, like JSHeapUsedSize, and whether
+it was reported.
+*/
+func (result *GetMetricsResult) Value(name string) (float64, bool) {
+	return metricValue(result.Metrics, name)
+}
+
+/*
+SetTimeDomainParams represents Performance.setTimeDomain parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-setTimeDomain
+*/
+type SetTimeDomainParams struct {
+	// Time domain. Allowed values:
+	//	- TimeDomain.TimeTicks
+	//	- TimeDomain.ThreadTicks
+	TimeDomain TimeDomainEnum `json:"timeDomain"`
+}
+
+/*
+SetTimeDomainResult represents the result of calls to Performance.setTimeDomain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-setTimeDomain
+*/
+type SetTimeDomainResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
@@ -0,0 +1,77 @@
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type timeDomainEnum struct {
+	TimeTicks   TimeDomainEnum
+	ThreadTicks TimeDomainEnum
+}
+
+/*
+TimeDomain provides named acces to the TimeDomainEnum values.
+*/
+var TimeDomain = timeDomainEnum{
+	TimeTicks:   timeDomainTimeTicks,
+	ThreadTicks: timeDomainThreadTicks,
+}
+
+/*
+TimeDomainEnum represents the time domain to use for collecting and reporting
+duration metrics. Allowed values:
+	- TimeDomain.TimeTicks   "timeTicks"
+	- TimeDomain.ThreadTicks "threadTicks"
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-enable
+*/
+type TimeDomainEnum int
+
+/*
+String implements Stringer
+*/
+func (enum TimeDomainEnum) String() string {
+	return _timeDomainEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum TimeDomainEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *TimeDomainEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _timeDomainEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// timeDomainTimeTicks represents the "timeTicks" value.
+	timeDomainTimeTicks TimeDomainEnum = iota + 1
+	// timeDomainThreadTicks represents the "threadTicks" value.
+	timeDomainThreadTicks
+)
+
+var _timeDomainEnums = map[TimeDomainEnum]string{
+	timeDomainTimeTicks:   "timeTicks",
+	timeDomainThreadTicks: "threadTicks",
+}
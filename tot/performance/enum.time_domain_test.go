@@ -0,0 +1,51 @@
+package performance
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumTimeDomain(t *testing.T) {
+	var enum TimeDomainEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = TimeDomain.TimeTicks
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"timeTicks"` != string(result) {
+		t.Errorf("Expected '\"timeTicks\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"timeTicks"`), &enum)
+	if TimeDomain.TimeTicks != enum {
+		t.Errorf("Expcected %d, got %d", TimeDomain.TimeTicks, enum)
+	}
+
+	enum = TimeDomain.ThreadTicks
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"threadTicks"` != string(result) {
+		t.Errorf("Expected '\"threadTicks\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"threadTicks"`), &enum)
+	if TimeDomain.ThreadTicks != enum {
+		t.Errorf("Expcected %d, got %d", TimeDomain.ThreadTicks, enum)
+	}
+}
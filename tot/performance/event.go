@@ -15,3 +15,11 @@ type MetricsEvent struct {
 	// Error information related to this event
 	Err error `json:"-"`
 }
+
+/*
+Value returns the value of the named metric, like JSHeapUsedSize, and whether
+it was reported.
+*/
+func (event *MetricsEvent) Value(name string) (float64, bool) {
+	return metricValue(event.Metrics, name)
+}
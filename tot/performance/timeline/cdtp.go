@@ -0,0 +1,123 @@
+/*
+Package timeline provides type definitions for use with the Chrome PerformanceTimeline protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/
+*/
+package timeline
+
+import (
+	"github.com/mkenney/go-chrome/tot/dom"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+Entry types of the timeline events that can be reported, as specified in
+https://w3c.github.io/performance-timeline/#dom-performanceentry-entrytype.
+Not every entry type exposed to the web platform is supported.
+*/
+const (
+	// LargestContentfulPaintType is the type of largest contentful paint
+	// events, their details are in Event.LcpDetails.
+	LargestContentfulPaintType = "largest-contentful-paint"
+
+	// LayoutShiftType is the type of layout shift events, their details are in
+	// Event.LayoutShiftDetails.
+	LayoutShiftType = "layout-shift"
+)
+
+/*
+LargestContentfulPaint is the largest image or text block rendered in the
+viewport so far. See https://github.com/WICG/LargestContentfulPaint and
+largest_contentful_paint.idl.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#type-LargestContentfulPaint
+*/
+type LargestContentfulPaint struct {
+	// The time the element was rendered, in seconds since Epoch.
+	RenderTime float64 `json:"renderTime"`
+
+	// The time the element's resource was loaded, in seconds since Epoch.
+	LoadTime float64 `json:"loadTime"`
+
+	// The number of pixels being painted.
+	Size float64 `json:"size"`
+
+	// Optional. The id attribute of the element, if available.
+	ElementID string `json:"elementId,omitempty"`
+
+	// Optional. The URL of the image (may be trimmed).
+	URL string `json:"url,omitempty"`
+
+	// Optional. The element.
+	NodeID dom.BackendNodeID `json:"nodeId,omitempty"`
+}
+
+/*
+LayoutShiftAttribution is an element that moved in a layout shift.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#type-LayoutShiftAttribution
+*/
+type LayoutShiftAttribution struct {
+	// The area of the element before the shift.
+	PreviousRect *dom.Rect `json:"previousRect"`
+
+	// The area of the element after the shift.
+	CurrentRect *dom.Rect `json:"currentRect"`
+
+	// Optional. The element.
+	NodeID dom.BackendNodeID `json:"nodeId,omitempty"`
+}
+
+/*
+LayoutShift is an unexpected movement of visible elements. See
+https://wicg.github.io/layout-instability/#sec-layout-shift and
+layout_shift.idl.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#type-LayoutShift
+*/
+type LayoutShift struct {
+	// Score increment produced by this event.
+	Value float64 `json:"value"`
+
+	// Whether there was user input shortly before the shift. Shifts with
+	// recent input are excluded from the cumulative layout shift score.
+	HadRecentInput bool `json:"hadRecentInput"`
+
+	// The time of the most recent user input, in seconds since Epoch.
+	LastInputTime float64 `json:"lastInputTime"`
+
+	// The elements that moved.
+	Sources []*LayoutShiftAttribution `json:"sources"`
+}
+
+/*
+Event is a performance timeline event.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#type-TimelineEvent
+*/
+type Event struct {
+	// Identifies the frame that this event is related to. Empty for non-frame
+	// targets.
+	FrameID page.FrameID `json:"frameId"`
+
+	// The event type, as specified in
+	// https://w3c.github.io/performance-timeline/#dom-performanceentry-entrytype
+	// This determines which of the optional "details" fields is present.
+	Type string `json:"type"`
+
+	// Name may be empty depending on the type.
+	Name string `json:"name"`
+
+	// Time in seconds since Epoch, monotonically increasing within document
+	// lifetime.
+	Time float64 `json:"time"`
+
+	// Optional. Event duration, if applicable.
+	Duration float64 `json:"duration,omitempty"`
+
+	// Optional. The details of largest contentful paint events.
+	LcpDetails *LargestContentfulPaint `json:"lcpDetails,omitempty"`
+
+	// Optional. The details of layout shift events.
+	LayoutShiftDetails *LayoutShift `json:"layoutShiftDetails,omitempty"`
+}
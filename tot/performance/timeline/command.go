@@ -0,0 +1,23 @@
+package timeline
+
+/*
+EnableParams represents PerformanceTimeline.enable parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#method-enable
+*/
+type EnableParams struct {
+	// The types of event to report, like LargestContentfulPaintType and
+	// LayoutShiftType. The specified filter overrides any previous filters,
+	// passing an empty filter disables recording.
+	EventTypes []string `json:"eventTypes"`
+}
+
+/*
+EnableResult represents the result of calls to PerformanceTimeline.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
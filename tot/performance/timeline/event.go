@@ -0,0 +1,14 @@
+package timeline
+
+/*
+EventAddedEvent represents PerformanceTimeline.timelineEventAdded event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#event-timelineEventAdded
+*/
+type EventAddedEvent struct {
+	// The timeline event.
+	Event *Event `json:"event"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
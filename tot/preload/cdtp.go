@@ -0,0 +1,236 @@
+/*
+Package preload provides type definitions for use with the Chrome Preload protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/
+*/
+package preload
+
+import (
+	"github.com/mkenney/go-chrome/tot/dom"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+RuleSetID is the unique ID of a speculation rule set.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-RuleSetId
+*/
+type RuleSetID string
+
+/*
+RuleSet is a speculation rule set of a document.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-RuleSet
+*/
+type RuleSet struct {
+	// The ID of the rule set.
+	ID RuleSetID `json:"id"`
+
+	// The document the rule set is associated with.
+	LoaderID network.LoaderID `json:"loaderId"`
+
+	// The JSON source of the rule set. For a `<script>` tag it's the text
+	// content of the node.
+	SourceText string `json:"sourceText"`
+
+	// Optional. The `<script>` tag the rule set was added by.
+	BackendNodeID dom.BackendNodeID `json:"backendNodeId,omitempty"`
+
+	// Optional. The URL the rule set was loaded from, for rule sets added by
+	// a Speculation-Rules header.
+	URL string `json:"url,omitempty"`
+
+	// Optional. The request the rule set was loaded by, if the Network domain
+	// is enabled.
+	RequestID network.RequestID `json:"requestId,omitempty"`
+
+	// Optional. The reason the rule set is invalid.
+	ErrorType RuleSetErrorType `json:"errorType,omitempty"`
+
+	// Optional. DEPRECATED.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+/*
+RuleSetErrorType is the reason a rule set is invalid. Allowed values:
+"SourceIsNotJsonObject", "InvalidRulesSkipped", "InvalidRulesetLevelTag".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-RuleSetErrorType
+*/
+type RuleSetErrorType string
+
+/*
+SpeculationAction is the type of preloading attempted. Allowed values:
+"Prefetch", "Prerender".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-SpeculationAction
+*/
+type SpeculationAction string
+
+/*
+SpeculationTargetHint is the window a speculation is for. Allowed values:
+"Blank", "Self".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-SpeculationTargetHint
+*/
+type SpeculationTargetHint string
+
+/*
+PreloadingAttemptKey identifies a preloading attempt. The URL is the URL
+specified by the trigger, not the URL that's finally navigated to.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PreloadingAttemptKey
+*/
+type PreloadingAttemptKey struct {
+	// The document that triggered the attempt.
+	LoaderID network.LoaderID `json:"loaderId"`
+
+	// The type of preloading.
+	Action SpeculationAction `json:"action"`
+
+	// The URL that's preloaded.
+	URL string `json:"url"`
+
+	// Optional. The window the preloaded page is for.
+	TargetHint SpeculationTargetHint `json:"targetHint,omitempty"`
+}
+
+/*
+PreloadingAttemptSource lists the rule sets and the links that triggered a
+preloading attempt.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PreloadingAttemptSource
+*/
+type PreloadingAttemptSource struct {
+	// The attempt.
+	Key *PreloadingAttemptKey `json:"key"`
+
+	// The rule sets with a rule that triggered the attempt.
+	RuleSetIDs []RuleSetID `json:"ruleSetIds"`
+
+	// The `<a href>` or `<area href>` elements that triggered the attempt.
+	NodeIDs []dom.BackendNodeID `json:"nodeIds"`
+}
+
+/*
+PreloadPipelineID identifies a preloading pipeline. A prerender starts as a
+prefetch that's upgraded, the events of both share the pipeline ID.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PreloadPipelineId
+*/
+type PreloadPipelineID string
+
+/*
+PrerenderFinalStatus is the reason a prerender ended. Allowed values: "Activated", "Destroyed",
+"LowEndDevice", "InvalidSchemeRedirect", "InvalidSchemeNavigation",
+"NavigationRequestBlockedByCsp", "MojoBinderPolicy", "RendererProcessCrashed",
+"RendererProcessKilled", "Download", "TriggerDestroyed",
+"NavigationNotCommitted", "NavigationBadHttpStatus", "ClientCertRequested",
+"NavigationRequestNetworkError", "CancelAllHostsForTesting", "DidFailLoad",
+"Stop", "SslCertificateError", "LoginAuthRequested", "UaChangeRequiresReload",
+"BlockedByClient", "AudioOutputDeviceRequested", "MixedContent",
+"TriggerBackgrounded", "MemoryLimitExceeded", "DataSaverEnabled",
+"TriggerUrlHasEffectiveUrl", "ActivatedBeforeStarted",
+"InactivePageRestriction", "StartFailed", "TimeoutBackgrounded",
+"CrossSiteRedirectInInitialNavigation",
+"CrossSiteNavigationInInitialNavigation",
+"SameSiteCrossOriginRedirectNotOptInInInitialNavigation",
+"SameSiteCrossOriginNavigationNotOptInInInitialNavigation",
+"ActivationNavigationParameterMismatch", "ActivatedInBackground",
+"EmbedderHostDisallowed", "ActivationNavigationDestroyedBeforeSuccess",
+"TabClosedByUserGesture", "TabClosedWithoutUserGesture",
+"PrimaryMainFrameRendererProcessCrashed",
+"PrimaryMainFrameRendererProcessKilled", "ActivationFramePolicyNotCompatible",
+"PreloadingDisabled", "BatterySaverEnabled",
+"ActivatedDuringMainFrameNavigation", "PreloadingUnsupportedByWebContents",
+"CrossSiteRedirectInMainFrameNavigation",
+"CrossSiteNavigationInMainFrameNavigation",
+"SameSiteCrossOriginRedirectNotOptInInMainFrameNavigation",
+"SameSiteCrossOriginNavigationNotOptInInMainFrameNavigation",
+"MemoryPressureOnTrigger", "MemoryPressureAfterTriggered",
+"PrerenderingDisabledByDevTools", "SpeculationRuleRemoved",
+"ActivatedWithAuxiliaryBrowsingContexts",
+"MaxNumOfRunningEagerPrerendersExceeded",
+"MaxNumOfRunningNonEagerPrerendersExceeded",
+"MaxNumOfRunningEmbedderPrerendersExceeded", "PrerenderingUrlHasEffectiveUrl",
+"RedirectedPrerenderingUrlHasEffectiveUrl", "ActivationUrlHasEffectiveUrl",
+"JavaScriptInterfaceAdded", "JavaScriptInterfaceRemoved",
+"AllPrerenderingCanceled", "WindowClosed", "SlowNetwork",
+"OtherPrerenderedPageActivated", "V8OptimizerDisabled",
+"PrerenderFailedDuringPrefetch", "BrowsingDataRemoved", "PrerenderHostReused".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PrerenderFinalStatus
+*/
+type PrerenderFinalStatus string
+
+/*
+Activated is the final status of a prerendered page that was navigated to.
+*/
+const Activated PrerenderFinalStatus = "Activated"
+
+/*
+PreloadingStatus is the status of a prefetch or prerender attempt. Allowed
+values are the PreloadingStatus constants.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PreloadingStatus
+*/
+type PreloadingStatus string
+
+/*
+Preloading statuses. An attempt is pending until it's started, running while
+it loads and ready when it can be used. Success means a prerendered page was
+activated or a prefetched response was used.
+*/
+const (
+	Pending      PreloadingStatus = "Pending"
+	Running      PreloadingStatus = "Running"
+	Ready        PreloadingStatus = "Ready"
+	Success      PreloadingStatus = "Success"
+	Failure      PreloadingStatus = "Failure"
+	NotSupported PreloadingStatus = "NotSupported"
+)
+
+/*
+PrefetchStatus is the detailed status of a prefetch. Allowed values:
+"PrefetchAllowed", "PrefetchFailedIneligibleRedirect",
+"PrefetchFailedInvalidRedirect", "PrefetchFailedMIMENotSupported",
+"PrefetchFailedNetError", "PrefetchFailedNon2XX",
+"PrefetchEvictedAfterBrowsingDataRemoved",
+"PrefetchEvictedAfterCandidateRemoved", "PrefetchEvictedForNewerPrefetch",
+"PrefetchHeldback", "PrefetchIneligibleRetryAfter", "PrefetchIsPrivacyDecoy",
+"PrefetchIsStale", "PrefetchNotEligibleBrowserContextOffTheRecord",
+"PrefetchNotEligibleDataSaverEnabled", "PrefetchNotEligibleExistingProxy",
+"PrefetchNotEligibleHostIsNonUnique",
+"PrefetchNotEligibleNonDefaultStoragePartition",
+"PrefetchNotEligibleSameSiteCrossOriginPrefetchRequiredProxy",
+"PrefetchNotEligibleSchemeIsNotHttps", "PrefetchNotEligibleUserHasCookies",
+"PrefetchNotEligibleUserHasServiceWorker",
+"PrefetchNotEligibleUserHasServiceWorkerNoFetchHandler",
+"PrefetchNotEligibleRedirectFromServiceWorker",
+"PrefetchNotEligibleRedirectToServiceWorker",
+"PrefetchNotEligibleBatterySaverEnabled",
+"PrefetchNotEligiblePreloadingDisabled", "PrefetchNotFinishedInTime",
+"PrefetchNotStarted", "PrefetchNotUsedCookiesChanged",
+"PrefetchProxyNotAvailable", "PrefetchResponseUsed",
+"PrefetchSuccessfulButNotUsed", "PrefetchNotUsedProbeFailed".
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PrefetchStatus
+*/
+type PrefetchStatus string
+
+/*
+PrerenderMismatchedHeaders is a header that differs between the prerender and
+the activation navigations.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#type-PrerenderMismatchedHeaders
+*/
+type PrerenderMismatchedHeaders struct {
+	// The name of the header.
+	HeaderName string `json:"headerName"`
+
+	// Optional. The value of the header in the prerender navigation.
+	InitialValue string `json:"initialValue,omitempty"`
+
+	// Optional. The value of the header in the activation navigation.
+	ActivationValue string `json:"activationValue,omitempty"`
+}
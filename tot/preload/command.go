@@ -0,0 +1,21 @@
+package preload
+
+/*
+DisableResult represents the result of calls to Preload.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableResult represents the result of calls to Preload.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
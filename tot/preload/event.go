@@ -0,0 +1,137 @@
+package preload
+
+import (
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+RuleSetUpdatedEvent represents Preload.ruleSetUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-ruleSetUpdated
+*/
+type RuleSetUpdatedEvent struct {
+	// The added or updated rule set.
+	RuleSet *RuleSet `json:"ruleSet"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+RuleSetRemovedEvent represents Preload.ruleSetRemoved event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-ruleSetRemoved
+*/
+type RuleSetRemovedEvent struct {
+	// The ID of the removed rule set.
+	ID RuleSetID `json:"id"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+PreloadEnabledStateUpdatedEvent represents Preload.preloadEnabledStateUpdated
+event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-preloadEnabledStateUpdated
+*/
+type PreloadEnabledStateUpdatedEvent struct {
+	// Whether preloading is disabled by the user preferences.
+	DisabledByPreference bool `json:"disabledByPreference"`
+
+	// Whether preloading is disabled by the data saver.
+	DisabledByDataSaver bool `json:"disabledByDataSaver"`
+
+	// Whether preloading is disabled by the battery saver.
+	DisabledByBatterySaver bool `json:"disabledByBatterySaver"`
+
+	// Whether prefetch speculation rules are held back by an experiment.
+	DisabledByHoldbackPrefetchSpeculationRules bool `json:"disabledByHoldbackPrefetchSpeculationRules"`
+
+	// Whether prerender speculation rules are held back by an experiment.
+	DisabledByHoldbackPrerenderSpeculationRules bool `json:"disabledByHoldbackPrerenderSpeculationRules"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+PrefetchStatusUpdatedEvent represents Preload.prefetchStatusUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-prefetchStatusUpdated
+*/
+type PrefetchStatusUpdatedEvent struct {
+	// The attempt.
+	Key *PreloadingAttemptKey `json:"key"`
+
+	// The pipeline of the attempt.
+	PipelineID PreloadPipelineID `json:"pipelineId"`
+
+	// The frame id of the frame initiating prefetch.
+	InitiatingFrameID page.FrameID `json:"initiatingFrameId"`
+
+	// The URL that's prefetched.
+	PrefetchURL string `json:"prefetchUrl"`
+
+	// The status of the attempt.
+	Status PreloadingStatus `json:"status"`
+
+	// The detailed status of the prefetch.
+	PrefetchStatus PrefetchStatus `json:"prefetchStatus"`
+
+	// The request of the prefetch.
+	RequestID network.RequestID `json:"requestId"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+PrerenderStatusUpdatedEvent represents Preload.prerenderStatusUpdated event
+data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-prerenderStatusUpdated
+*/
+type PrerenderStatusUpdatedEvent struct {
+	// The attempt.
+	Key *PreloadingAttemptKey `json:"key"`
+
+	// The pipeline of the attempt.
+	PipelineID PreloadPipelineID `json:"pipelineId"`
+
+	// The status of the attempt.
+	Status PreloadingStatus `json:"status"`
+
+	// Optional. The reason the prerender ended, like Activated.
+	PrerenderStatus PrerenderFinalStatus `json:"prerenderStatus,omitempty"`
+
+	// Optional. The Mojo interface that's incompatible with prerendering and
+	// canceled the attempt.
+	DisallowedMojoInterface string `json:"disallowedMojoInterface,omitempty"`
+
+	// Optional. The headers that differ between the prerender and the
+	// activation navigations.
+	MismatchedHeaders []*PrerenderMismatchedHeaders `json:"mismatchedHeaders,omitempty"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+PreloadingAttemptSourcesUpdatedEvent represents
+Preload.preloadingAttemptSourcesUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-preloadingAttemptSourcesUpdated
+*/
+type PreloadingAttemptSourcesUpdatedEvent struct {
+	// The document of the attempts.
+	LoaderID network.LoaderID `json:"loaderId"`
+
+	// The sources of the preloading attempts of the document.
+	PreloadingAttemptSources []*PreloadingAttemptSource `json:"preloadingAttemptSources"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
@@ -0,0 +1,172 @@
+package bench
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bdlm/log"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func init() {
+	log.SetLevel(log.ErrorLevel)
+}
+
+/*
+stormParams are the parameters for the Bench.storm command, which makes the
+simulated server emit a burst of Bench.event events before responding.
+*/
+type stormParams struct {
+	Count int `json:"count"`
+}
+
+var eventParams = json.RawMessage(`{"requestId":"1000.1","timestamp":12345,"dataLength":65536,"encodedDataLength":1024}`)
+
+/*
+newServer starts a simulated CDP server that responds to every command with an
+empty result and implements the Bench.storm command.
+*/
+func newServer(tb testing.TB) *cdptest.Server {
+	server := cdptest.NewServer(nil)
+	server.Handle("Bench.storm", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		storm := &stormParams{}
+		json.Unmarshal(params, storm)
+		if err := server.Storm("Bench.event", eventParams, storm.Count); nil != err {
+			tb.Errorf("event storm failed: %s", err.Error())
+		}
+		return map[string]interface{}{}, nil
+	})
+	return server
+}
+
+/*
+storm sends a Bench.storm command and waits for the server to acknowledge it.
+*/
+func storm(sock *socket.Socket, count int) *socket.Response {
+	command := socket.NewCommand(sock, "Bench.storm", &stormParams{Count: count})
+	return <-sock.SendCommand(command)
+}
+
+/*
+fanOut registers the specified number of handlers for Bench.event and returns a
+channel that is closed once every handler has received the expected number of
+events, along with the registered handlers.
+*/
+func fanOut(sock *socket.Socket, handlers, events int) (chan bool, []socket.EventHandler) {
+	var received int64
+	expected := int64(handlers * events)
+	done := make(chan bool)
+	registered := make([]socket.EventHandler, 0, handlers)
+	for a := 0; a < handlers; a++ {
+		handler := socket.NewEventHandler("Bench.event", func(response *socket.Response) {
+			if atomic.AddInt64(&received, 1) == expected {
+				close(done)
+			}
+		})
+		sock.AddEventHandler(handler)
+		registered = append(registered, handler)
+	}
+	return done, registered
+}
+
+func TestServer(t *testing.T) {
+	server := newServer(t)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+
+	done, _ := fanOut(sock, 2, 10)
+	if response := storm(sock, 10); nil != response.Error && 0 != response.Error.Code {
+		t.Errorf("Expected nil, got error: '%s'", response.Error.Error())
+	}
+	<-done
+}
+
+func BenchmarkCommandThroughput(b *testing.B) {
+	server := newServer(b)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for a := 0; a < b.N; a++ {
+		<-sock.SendCommand(socket.NewCommand(sock, "Bench.command", nil))
+	}
+	b.StopTimer()
+}
+
+func BenchmarkCommandThroughputParallel(b *testing.B) {
+	server := newServer(b)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+
+	b.ReportAllocs()
+	b.SetParallelism(16)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			<-sock.SendCommand(socket.NewCommand(sock, "Bench.command", nil))
+		}
+	})
+	b.StopTimer()
+}
+
+func BenchmarkEventFanOut1(b *testing.B) {
+	benchmarkEventFanOut(b, 1)
+}
+
+func BenchmarkEventFanOut10(b *testing.B) {
+	benchmarkEventFanOut(b, 10)
+}
+
+func BenchmarkEventFanOut100(b *testing.B) {
+	benchmarkEventFanOut(b, 100)
+}
+
+/*
+benchmarkEventFanOut measures the time it takes for an event to be read from the
+websocket and delivered to every registered handler.
+*/
+func benchmarkEventFanOut(b *testing.B, handlers int) {
+	server := newServer(b)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+
+	done, _ := fanOut(sock, handlers, b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+	storm(sock, b.N)
+	<-done
+	b.StopTimer()
+}
+
+/*
+BenchmarkEventMemory100k reports the memory allocated while reading and
+dispatching 100k events. Each benchmark operation is 100k events, so the B/op
+and allocs/op columns are per 100k events.
+*/
+func BenchmarkEventMemory100k(b *testing.B) {
+	server := newServer(b)
+	defer server.Close()
+	sock := socket.New(server.URL())
+	defer sock.Stop()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for a := 0; a < b.N; a++ {
+		b.StopTimer()
+		done, handlers := fanOut(sock, 1, 100000)
+		b.StartTimer()
+		storm(sock, 100000)
+		<-done
+		b.StopTimer()
+		sock.RemoveEventHandler(handlers[0])
+		b.StartTimer()
+	}
+	b.StopTimer()
+}
@@ -0,0 +1,11 @@
+/*
+Package bench contains the socket layer benchmark suite. The benchmarks measure
+command throughput, event fan-out latency and memory usage against a simulated
+CDP server and are used to guard against performance regressions in the socket
+dispatch code.
+
+Run the suite with:
+
+	go test -run XXX -bench . -benchmem github.com/mkenney/go-chrome/tot/socket/bench
+*/
+package bench
@@ -0,0 +1,143 @@
+/*
+Package cdptest provides a simulated Chrome DevTools Protocol server for load,
+integration and conformance testing, in the same spirit as net/http/httptest.
+
+The server speaks the CDP websocket protocol. Commands are validated against an
+optional recorded protocol schema (the browser_protocol.json and
+js_protocol.json files published by the Chrome DevTools team), canned responses
+can be registered per method, events can be emitted individually or in storms,
+and a chaos mode can delay or drop responses and drop connections.
+*/
+package cdptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+/*
+Error represents a protocol error returned by the simulated server.
+*/
+type Error struct {
+	Code    int             `json:"code"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Message string          `json:"message"`
+}
+
+/*
+Error implements the error interface.
+*/
+func (err *Error) Error() string {
+	return fmt.Sprintf("code=%d, data=%s, msg=%s", err.Code, err.Data, err.Message)
+}
+
+/*
+Command is a command received by the simulated server.
+*/
+type Command struct {
+	ID        int             `json:"id"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params"`
+	SessionID string          `json:"sessionId,omitempty"`
+}
+
+/*
+HandlerFunc generates the result for a command. Returning a non-nil error sends
+a protocol error response instead of a result.
+*/
+type HandlerFunc func(params json.RawMessage) (result interface{}, err *Error)
+
+/*
+Schema contains the commands and events defined by a recorded protocol schema.
+*/
+type Schema struct {
+	commands map[string]bool
+	events   map[string]bool
+}
+
+/*
+schemaFile is the structure of the browser_protocol.json and js_protocol.json
+files.
+*/
+type schemaFile struct {
+	Domains []struct {
+		Domain   string `json:"domain"`
+		Commands []struct {
+			Name string `json:"name"`
+		} `json:"commands"`
+		Events []struct {
+			Name string `json:"name"`
+		} `json:"events"`
+	} `json:"domains"`
+}
+
+/*
+NewSchema returns a pointer to an empty Schema. Recorded protocol files can be
+added with Load.
+*/
+func NewSchema() *Schema {
+	return &Schema{
+		commands: make(map[string]bool),
+		events:   make(map[string]bool),
+	}
+}
+
+/*
+LoadSchema returns a pointer to a Schema loaded from the specified protocol
+files.
+*/
+func LoadSchema(files ...string) (*Schema, error) {
+	schema := NewSchema()
+	for _, file := range files {
+		fh, err := os.Open(file)
+		if nil != err {
+			return nil, err
+		}
+		err = schema.Load(fh)
+		fh.Close()
+		if nil != err {
+			return nil, fmt.Errorf("%s: %s", file, err.Error())
+		}
+	}
+	return schema, nil
+}
+
+/*
+Load adds the commands and events defined in a protocol file to the schema.
+*/
+func (schema *Schema) Load(reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	if nil != err {
+		return err
+	}
+	file := &schemaFile{}
+	if err := json.Unmarshal(data, file); nil != err {
+		return err
+	}
+	for _, domain := range file.Domains {
+		for _, command := range domain.Commands {
+			schema.commands[domain.Domain+"."+command.Name] = true
+		}
+		for _, event := range domain.Events {
+			schema.events[domain.Domain+"."+event.Name] = true
+		}
+	}
+	return nil
+}
+
+/*
+HasCommand returns whether the schema defines the specified command.
+*/
+func (schema *Schema) HasCommand(method string) bool {
+	return schema.commands[method]
+}
+
+/*
+HasEvent returns whether the schema defines the specified event.
+*/
+func (schema *Schema) HasEvent(method string) bool {
+	return schema.events[method]
+}
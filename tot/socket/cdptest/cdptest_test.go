@@ -0,0 +1,32 @@
+package cdptest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	schema, err := LoadSchema("testdata/protocol.json")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if !schema.HasCommand("Page.navigate") {
+		t.Errorf("Expected Page.navigate to be defined")
+	}
+	if schema.HasCommand("Page.reload") {
+		t.Errorf("Expected Page.reload to be undefined")
+	}
+	if !schema.HasEvent("Network.dataReceived") {
+		t.Errorf("Expected Network.dataReceived to be defined")
+	}
+	if schema.HasEvent("Network.enable") {
+		t.Errorf("Expected Network.enable not to be an event")
+	}
+
+	if _, err := LoadSchema("testdata/missing.json"); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+	if err := NewSchema().Load(strings.NewReader(`{"domains":`)); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+}
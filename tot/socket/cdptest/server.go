@@ -0,0 +1,396 @@
+package cdptest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+/*
+Chaos configures fault injection for the simulated server.
+*/
+type Chaos struct {
+	// Latency is added before every command response.
+	Latency time.Duration
+
+	// Jitter is the maximum random duration added to Latency.
+	Jitter time.Duration
+
+	// DropRate is the probability, from 0 to 1, that a command response is
+	// never sent.
+	DropRate float64
+
+	// DisconnectAfter closes a connection after the specified number of
+	// commands have been received on it. Zero disables disconnects.
+	DisconnectAfter int
+}
+
+/*
+NewServer starts and returns a simulated CDP server. If schema is not nil,
+commands that are not defined in the schema are rejected with the same error
+Chrome returns for unknown methods. Commands without a registered handler
+receive an empty result.
+
+Like Chrome, the server rejects browser-only commands such as
+Target.createBrowserContext unless they're sent to the browser target, a
+connection to BrowserURL without a session ID.
+*/
+func NewServer(schema *Schema) *Server {
+	server := newServer(schema)
+	server.http = httptest.NewServer(http.HandlerFunc(server.serve))
+	return server
+}
+
+/*
+NewTLSServer starts and returns a simulated CDP server that listens for wss://
+connections with a self-signed certificate. TLSConfig returns a client
+configuration that trusts it.
+*/
+func NewTLSServer(schema *Schema) *Server {
+	server := newServer(schema)
+	server.http = httptest.NewTLSServer(http.HandlerFunc(server.serve))
+	return server
+}
+
+/*
+newServer returns a server that isn't listening yet.
+*/
+func newServer(schema *Schema) *Server {
+	return &Server{
+		commands: make([]*Command, 0),
+		conns:    make(map[*conn]bool),
+		connCh:   make(chan bool, 1),
+		handlers: make(map[string]HandlerFunc),
+		mux:      &sync.Mutex{},
+		schema:   schema,
+	}
+}
+
+/*
+Server is a simulated CDP websocket server.
+*/
+type Server struct {
+	chaos    Chaos
+	commands []*Command
+	conns    map[*conn]bool
+	connCh   chan bool
+	handlers map[string]HandlerFunc
+	http     *httptest.Server
+	mux      *sync.Mutex
+	schema   *Schema
+}
+
+/*
+browserOnly are the commands Chrome only allows on the browser target.
+*/
+var browserOnly = map[string]bool{
+	"Target.createBrowserContext":  true,
+	"Target.disposeBrowserContext": true,
+	"Target.getBrowserContexts":    true,
+}
+
+/*
+conn is a single client connection. gorilla/websocket supports one concurrent
+writer so all writes are serialized. browser is set for connections to the
+browser target.
+*/
+type conn struct {
+	browser bool
+	mux     *sync.Mutex
+	ws      *websocket.Conn
+}
+
+func (c *conn) write(data []byte) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, data)
+}
+
+/*
+Close disconnects all clients and shuts down the server.
+*/
+func (server *Server) Close() {
+	server.mux.Lock()
+	for c := range server.conns {
+		c.ws.Close()
+	}
+	server.mux.Unlock()
+	server.http.Close()
+}
+
+/*
+Commands returns the commands received by the server, in the order they were
+received.
+*/
+func (server *Server) Commands() []*Command {
+	server.mux.Lock()
+	defer server.mux.Unlock()
+	commands := make([]*Command, len(server.commands))
+	copy(commands, server.commands)
+	return commands
+}
+
+/*
+Connections returns the number of connected clients.
+*/
+func (server *Server) Connections() int {
+	server.mux.Lock()
+	defer server.mux.Unlock()
+	return len(server.conns)
+}
+
+/*
+Emit sends an event to all connected clients.
+*/
+func (server *Server) Emit(method string, params interface{}) error {
+	return server.EmitSession("", method, params)
+}
+
+/*
+EmitSession sends an event for a flattened target session to all connected
+clients. An empty session ID sends a browser level event.
+*/
+func (server *Server) EmitSession(sessionID string, method string, params interface{}) error {
+	event := map[string]interface{}{
+		"method": method,
+		"params": params,
+	}
+	if "" != sessionID {
+		event["sessionId"] = sessionID
+	}
+	data, err := json.Marshal(event)
+	if nil != err {
+		return err
+	}
+	if nil != server.schema && !server.schema.HasEvent(method) {
+		return fmt.Errorf("event '%s' is not defined in the schema", method)
+	}
+
+	server.mux.Lock()
+	conns := make([]*conn, 0, len(server.conns))
+	for c := range server.conns {
+		conns = append(conns, c)
+	}
+	server.mux.Unlock()
+
+	for _, c := range conns {
+		if err := c.write(data); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Handle registers a handler that generates responses for the specified command.
+*/
+func (server *Server) Handle(method string, handler HandlerFunc) {
+	server.mux.Lock()
+	server.handlers[method] = handler
+	server.mux.Unlock()
+}
+
+/*
+Respond registers a canned result for the specified command.
+*/
+func (server *Server) Respond(method string, result interface{}) {
+	server.Handle(method, func(params json.RawMessage) (interface{}, *Error) {
+		return result, nil
+	})
+}
+
+/*
+SetChaos enables fault injection. Passing a zero value Chaos disables it.
+*/
+func (server *Server) SetChaos(chaos Chaos) {
+	server.mux.Lock()
+	server.chaos = chaos
+	server.mux.Unlock()
+}
+
+/*
+Storm emits the specified number of identical events to all connected clients
+as fast as the connections allow.
+*/
+func (server *Server) Storm(method string, params interface{}, count int) error {
+	for a := 0; a < count; a++ {
+		if err := server.Emit(method, params); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+TLSConfig returns a client TLS configuration that trusts the certificate of a
+server started with NewTLSServer.
+*/
+func (server *Server) TLSConfig() *tls.Config {
+	pool := x509.NewCertPool()
+	if nil != server.http.TLS {
+		pool.AddCert(server.http.Certificate())
+	}
+	return &tls.Config{RootCAs: pool}
+}
+
+/*
+BrowserURL returns the websocket URL of the browser target of the server,
+wss:// for servers started with NewTLSServer.
+*/
+func (server *Server) BrowserURL() *url.URL {
+	socketURL, _ := url.Parse("ws" + strings.TrimPrefix(server.http.URL, "http") + "/devtools/browser/cdptest")
+	return socketURL
+}
+
+/*
+URL returns the websocket URL of a page target of the server, wss:// for
+servers started with NewTLSServer.
+*/
+func (server *Server) URL() *url.URL {
+	socketURL, _ := url.Parse("ws" + strings.TrimPrefix(server.http.URL, "http") + "/devtools/page/cdptest")
+	return socketURL
+}
+
+/*
+WaitForConnection blocks until a client connects or the timeout expires.
+*/
+func (server *Server) WaitForConnection(timeout time.Duration) error {
+	if server.Connections() > 0 {
+		return nil
+	}
+	select {
+	case <-server.connCh:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("no connection after %s", timeout)
+	}
+}
+
+/*
+response generates the response to a command received on a connection.
+Responses to commands sent to a flattened target session carry the session ID.
+*/
+func (server *Server) response(c *conn, command *Command) map[string]interface{} {
+	response := server.result(c, command)
+	if "" != command.SessionID {
+		response["sessionId"] = command.SessionID
+	}
+	return response
+}
+
+/*
+result generates the result or error for a command received on a connection.
+*/
+func (server *Server) result(c *conn, command *Command) map[string]interface{} {
+	if browserOnly[command.Method] && (!c.browser || "" != command.SessionID) {
+		return map[string]interface{}{
+			"id":    command.ID,
+			"error": &Error{Code: -32000, Message: "Not allowed"},
+		}
+	}
+
+	server.mux.Lock()
+	handler, ok := server.handlers[command.Method]
+	server.mux.Unlock()
+
+	if !ok && nil != server.schema && !server.schema.HasCommand(command.Method) {
+		return map[string]interface{}{
+			"id": command.ID,
+			"error": &Error{
+				Code:    -32601,
+				Message: fmt.Sprintf("'%s' wasn't found", command.Method),
+			},
+		}
+	}
+
+	var result interface{} = map[string]interface{}{}
+	if ok {
+		var err *Error
+		result, err = handler(command.Params)
+		if nil != err {
+			return map[string]interface{}{"id": command.ID, "error": err}
+		}
+	}
+	return map[string]interface{}{"id": command.ID, "result": result}
+}
+
+/*
+serve upgrades a client connection and runs its command loop.
+*/
+func (server *Server) serve(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if nil != err {
+		return
+	}
+	c := &conn{
+		browser: strings.HasPrefix(r.URL.Path, "/devtools/browser/"),
+		mux:     &sync.Mutex{},
+		ws:      ws,
+	}
+
+	server.mux.Lock()
+	server.conns[c] = true
+	server.mux.Unlock()
+	select {
+	case server.connCh <- true:
+	default:
+	}
+
+	defer func() {
+		server.mux.Lock()
+		delete(server.conns, c)
+		server.mux.Unlock()
+		ws.Close()
+	}()
+
+	received := 0
+	for {
+		command := &Command{}
+		if err := ws.ReadJSON(command); nil != err {
+			return
+		}
+		received++
+
+		server.mux.Lock()
+		server.commands = append(server.commands, command)
+		chaos := server.chaos
+		server.mux.Unlock()
+
+		if chaos.DisconnectAfter > 0 && received >= chaos.DisconnectAfter {
+			return
+		}
+		if chaos.DropRate > 0 && rand.Float64() < chaos.DropRate {
+			continue
+		}
+
+		data, err := json.Marshal(server.response(c, command))
+		if nil != err {
+			return
+		}
+		delay := chaos.Latency
+		if chaos.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(chaos.Jitter)))
+		}
+		if delay > 0 {
+			go func() {
+				time.Sleep(delay)
+				c.write(data)
+			}()
+			continue
+		}
+		if err := c.write(data); nil != err {
+			return
+		}
+	}
+}
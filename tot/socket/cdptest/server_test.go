@@ -0,0 +1,220 @@
+package cdptest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dial(t *testing.T, server *Server) *websocket.Conn {
+	ws, _, err := websocket.DefaultDialer.Dial(server.URL().String(), nil)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if err := server.WaitForConnection(time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	return ws
+}
+
+/*
+waitForCommands blocks until the server has received the specified number of
+commands.
+*/
+func waitForCommands(t *testing.T, server *Server, count int) {
+	deadline := time.Now().Add(time.Second)
+	for len(server.Commands()) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d commands, got %d", count, len(server.Commands()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type message struct {
+	ID        int             `json:"id"`
+	Error     *Error          `json:"error"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params"`
+	Result    json.RawMessage `json:"result"`
+	SessionID string          `json:"sessionId"`
+}
+
+func TestServerRespond(t *testing.T) {
+	schema, _ := LoadSchema("testdata/protocol.json")
+	server := NewServer(schema)
+	defer server.Close()
+	server.Respond("Page.navigate", map[string]string{"frameId": "frame-id"})
+	server.Handle("Page.enable", func(params json.RawMessage) (interface{}, *Error) {
+		return nil, &Error{Code: -32000, Message: "Not allowed"}
+	})
+	ws := dial(t, server)
+	defer ws.Close()
+
+	ws.WriteJSON(&Command{ID: 1, Method: "Page.navigate", Params: json.RawMessage(`{"url":"about:blank"}`)})
+	msg := &message{}
+	ws.ReadJSON(msg)
+	if 1 != msg.ID || `{"frameId":"frame-id"}` != string(msg.Result) {
+		t.Errorf("Expected frame-id result, got %d %s", msg.ID, msg.Result)
+	}
+
+	ws.WriteJSON(&Command{ID: 2, Method: "Page.enable"})
+	msg = &message{}
+	ws.ReadJSON(msg)
+	if nil == msg.Error || -32000 != msg.Error.Code {
+		t.Errorf("Expected error -32000, got %v", msg.Error)
+	}
+
+	ws.WriteJSON(&Command{ID: 3, Method: "Network.enable"})
+	msg = &message{}
+	ws.ReadJSON(msg)
+	if nil != msg.Error || "{}" != string(msg.Result) {
+		t.Errorf("Expected empty result, got %v %s", msg.Error, msg.Result)
+	}
+
+	ws.WriteJSON(&Command{ID: 4, Method: "Page.reload"})
+	msg = &message{}
+	ws.ReadJSON(msg)
+	if nil == msg.Error || -32601 != msg.Error.Code {
+		t.Errorf("Expected error -32601, got %v", msg.Error)
+	}
+
+	commands := server.Commands()
+	if 4 != len(commands) {
+		t.Fatalf("Expected 4 commands, got %d", len(commands))
+	}
+	if "Page.navigate" != commands[0].Method || `{"url":"about:blank"}` != string(commands[0].Params) {
+		t.Errorf("Expected Page.navigate, got %s %s", commands[0].Method, commands[0].Params)
+	}
+}
+
+func TestServerSession(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	ws := dial(t, server)
+	defer ws.Close()
+
+	ws.WriteJSON(&Command{ID: 1, Method: "Page.enable", SessionID: "session-1"})
+	msg := &message{}
+	ws.ReadJSON(msg)
+	if 1 != msg.ID || "session-1" != msg.SessionID {
+		t.Errorf("Expected a session-1 response, got %d '%s'", msg.ID, msg.SessionID)
+	}
+
+	if err := server.EmitSession("session-1", "Page.loadEventFired", map[string]int{"timestamp": 1}); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	msg = &message{}
+	ws.ReadJSON(msg)
+	if "Page.loadEventFired" != msg.Method || "session-1" != msg.SessionID {
+		t.Errorf("Expected a session-1 event, got %s '%s'", msg.Method, msg.SessionID)
+	}
+}
+
+func TestServerBrowserOnly(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.createBrowserContext", map[string]string{"browserContextId": "ctx"})
+	page := dial(t, server)
+	defer page.Close()
+	browser, _, err := websocket.DefaultDialer.Dial(server.BrowserURL().String(), nil)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	defer browser.Close()
+
+	for _, test := range []struct {
+		ws        *websocket.Conn
+		sessionID string
+		allowed   bool
+	}{
+		{page, "", false},
+		{browser, "session-1", false},
+		{browser, "", true},
+	} {
+		test.ws.WriteJSON(&Command{ID: 1, Method: "Target.createBrowserContext", SessionID: test.sessionID})
+		msg := &message{}
+		test.ws.ReadJSON(msg)
+		if test.allowed && (nil != msg.Error || `{"browserContextId":"ctx"}` != string(msg.Result)) {
+			t.Errorf("Expected the browser context, got %v %s", msg.Error, msg.Result)
+		}
+		if !test.allowed && (nil == msg.Error || "Not allowed" != msg.Error.Message) {
+			t.Errorf("Expected a Not allowed error, got %v %s", msg.Error, msg.Result)
+		}
+	}
+}
+
+func TestServerStorm(t *testing.T) {
+	schema, _ := LoadSchema("testdata/protocol.json")
+	server := NewServer(schema)
+	defer server.Close()
+	ws := dial(t, server)
+	defer ws.Close()
+
+	if err := server.Emit("Page.frameNavigated", nil); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+	if err := server.Storm("Network.dataReceived", map[string]int{"dataLength": 1}, 10); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	for a := 0; a < 10; a++ {
+		msg := &message{}
+		if err := ws.ReadJSON(msg); nil != err {
+			t.Fatalf("Expected nil, got error: '%s'", err.Error())
+		}
+		if "Network.dataReceived" != msg.Method || `{"dataLength":1}` != string(msg.Params) {
+			t.Errorf("Expected Network.dataReceived, got %s %s", msg.Method, msg.Params)
+		}
+	}
+}
+
+func TestServerChaos(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	ws := dial(t, server)
+	defer ws.Close()
+
+	server.SetChaos(Chaos{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	ws.WriteJSON(&Command{ID: 1, Method: "Test.latency"})
+	ws.ReadJSON(&message{})
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected at least 50ms latency, got %s", elapsed)
+	}
+
+	server.SetChaos(Chaos{DropRate: 1})
+	ws.WriteJSON(&Command{ID: 2, Method: "Test.drop"})
+	waitForCommands(t, server, 2)
+	server.SetChaos(Chaos{})
+	ws.WriteJSON(&Command{ID: 3, Method: "Test.keep"})
+	msg := &message{}
+	ws.ReadJSON(msg)
+	if 3 != msg.ID {
+		t.Errorf("Expected the response to command 2 to be dropped, got %d", msg.ID)
+	}
+
+	server.SetChaos(Chaos{DisconnectAfter: 1})
+	ws.WriteJSON(&Command{ID: 4, Method: "Test.disconnect"})
+	if err := ws.ReadJSON(&message{}); nil == err {
+		t.Errorf("Expected a disconnect, got a response")
+	}
+}
+
+func TestTLSServer(t *testing.T) {
+	server := NewTLSServer(nil)
+	defer server.Close()
+
+	dialer := &websocket.Dialer{TLSClientConfig: server.TLSConfig()}
+	ws, _, err := dialer.Dial(server.URL().String(), nil)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	defer ws.Close()
+	ws.WriteJSON(map[string]interface{}{"id": 1, "method": "Some.method"})
+	msg := &message{}
+	if err := ws.ReadJSON(msg); nil != err || 1 != msg.ID {
+		t.Errorf("Expected a response to command 1, got %+v %v", msg, err)
+	}
+}
@@ -17,6 +17,33 @@ type AccessibilityProtocol struct {
 	Socket Socketer
 }
 
+/*
+GetFullAXTree fetches the entire accessibility tree for the root document.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getFullAXTree
+EXPERIMENTAL.
+*/
+func (protocol *AccessibilityProtocol) GetFullAXTree(
+	params *accessibility.GetFullAXTreeParams,
+) <-chan *accessibility.GetFullAXTreeResult {
+	resultChan := make(chan *accessibility.GetFullAXTreeResult)
+	command := NewCommand(protocol.Socket, "Accessibility.getFullAXTree", params)
+	result := &accessibility.GetFullAXTreeResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 GetPartialAXTree fetches the accessibility node and partial accessibility tree
 for this DOM node, if it exists.
@@ -33,7 +60,7 @@ func (protocol *AccessibilityProtocol) GetPartialAXTree(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -9,6 +9,51 @@ import (
 	"github.com/mkenney/go-chrome/tot/dom"
 )
 
+func TestAccessibilityGetFullAXTree(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAccessibilityGetFullAXTree")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &accessibility.GetFullAXTreeParams{Depth: 2}
+	resultChan := mockSocket.Accessibility().GetFullAXTree(params)
+	mockResult := accessibility.GetFullAXTreeResult{
+		Nodes: []*accessibility.AXNode{{
+			NodeID:   accessibility.AXNodeID("1"),
+			Role:     &accessibility.AXValue{Type: "role", Value: "RootWebArea"},
+			ChildIDs: []accessibility.AXNodeID{accessibility.AXNodeID("2")},
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected success, got error: %s", result.Err)
+	}
+	if 1 != len(result.Nodes) || "RootWebArea" != result.Nodes[0].Role.Value {
+		tmp, _ := json.Marshal(result.Nodes)
+		t.Errorf("Expected the root node, got '%s'", tmp)
+	}
+
+	resultChan = mockSocket.Accessibility().GetFullAXTree(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestAccessibilityGetPartialAXTree(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestAccessibilityGetPartialAXTree")
 	mockSocket := NewMock(socketURL)
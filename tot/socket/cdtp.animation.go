@@ -28,7 +28,7 @@ func (protocol *AnimationProtocol) Disable() <-chan *animation.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,7 +50,7 @@ func (protocol *AnimationProtocol) Enable() <-chan *animation.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -74,7 +74,7 @@ func (protocol *AnimationProtocol) GetCurrentTime(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -98,7 +98,7 @@ func (protocol *AnimationProtocol) GetPlaybackRate() <-chan *animation.GetPlayba
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -124,7 +124,7 @@ func (protocol *AnimationProtocol) ReleaseAnimations(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -148,7 +148,7 @@ func (protocol *AnimationProtocol) ResolveAnimation(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -175,7 +175,7 @@ func (protocol *AnimationProtocol) SeekAnimations(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -199,7 +199,7 @@ func (protocol *AnimationProtocol) SetPaused(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -223,7 +223,7 @@ func (protocol *AnimationProtocol) SetPlaybackRate(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -247,7 +247,7 @@ func (protocol *AnimationProtocol) SetTiming(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -264,7 +264,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationCanceled(
 	callback func(event *animation.CanceledEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Animation.animationCanceled",
 		func(response *Response) {
@@ -276,7 +276,28 @@ func (protocol *AnimationProtocol) OnAnimationCanceled(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AnimationCanceledChan returns a channel of Animation.animationCanceled events, as an alternative to OnAnimationCanceled.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *AnimationProtocol) AnimationCanceledChan(
+	buffer int,
+) (<-chan *animation.CanceledEvent, func()) {
+	eventCh := make(chan *animation.CanceledEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAnimationCanceled(func(event *animation.CanceledEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -287,7 +308,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationCreated(
 	callback func(event *animation.CreatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Animation.animationCreated",
 		func(response *Response) {
@@ -299,7 +320,28 @@ func (protocol *AnimationProtocol) OnAnimationCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AnimationCreatedChan returns a channel of Animation.animationCreated events, as an alternative to OnAnimationCreated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *AnimationProtocol) AnimationCreatedChan(
+	buffer int,
+) (<-chan *animation.CreatedEvent, func()) {
+	eventCh := make(chan *animation.CreatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAnimationCreated(func(event *animation.CreatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -310,7 +352,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationStarted(
 	callback func(event *animation.StartedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Animation.animationStarted",
 		func(response *Response) {
@@ -322,5 +364,26 @@ func (protocol *AnimationProtocol) OnAnimationStarted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AnimationStartedChan returns a channel of Animation.animationStarted events, as an alternative to OnAnimationStarted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *AnimationProtocol) AnimationStartedChan(
+	buffer int,
+) (<-chan *animation.StartedEvent, func()) {
+	eventCh := make(chan *animation.StartedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAnimationStarted(func(event *animation.StartedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
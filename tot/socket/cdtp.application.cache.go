@@ -29,7 +29,7 @@ func (protocol *ApplicationCacheProtocol) Enable() <-chan *cache.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,7 +54,7 @@ func (protocol *ApplicationCacheProtocol) GetForFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -79,7 +79,7 @@ func (protocol *ApplicationCacheProtocol) GetFramesWithManifests() <-chan *cache
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -105,7 +105,7 @@ func (protocol *ApplicationCacheProtocol) GetManifestForFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -124,7 +124,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-a
 */
 func (protocol *ApplicationCacheProtocol) OnApplicationCacheStatusUpdated(
 	callback func(event *cache.StatusUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"ApplicationCache.applicationCacheStatusUpdated",
 		func(response *Response) {
@@ -136,7 +136,28 @@ func (protocol *ApplicationCacheProtocol) OnApplicationCacheStatusUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ApplicationCacheStatusUpdatedChan returns a channel of ApplicationCache.applicationCacheStatusUpdated events, as an alternative to OnApplicationCacheStatusUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ApplicationCacheProtocol) ApplicationCacheStatusUpdatedChan(
+	buffer int,
+) (<-chan *cache.StatusUpdatedEvent, func()) {
+	eventCh := make(chan *cache.StatusUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnApplicationCacheStatusUpdated(func(event *cache.StatusUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -146,7 +167,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-n
 */
 func (protocol *ApplicationCacheProtocol) OnNetworkStateUpdated(
 	callback func(event *cache.NetworkStateUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"ApplicationCache.networkStateUpdated",
 		func(response *Response) {
@@ -158,5 +179,26 @@ func (protocol *ApplicationCacheProtocol) OnNetworkStateUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+NetworkStateUpdatedChan returns a channel of ApplicationCache.networkStateUpdated events, as an alternative to OnNetworkStateUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ApplicationCacheProtocol) NetworkStateUpdatedChan(
+	buffer int,
+) (<-chan *cache.NetworkStateUpdatedEvent, func()) {
+	eventCh := make(chan *cache.NetworkStateUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnNetworkStateUpdated(func(event *cache.NetworkStateUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -17,6 +17,102 @@ type AuditsProtocol struct {
 	Socket Socketer
 }
 
+/*
+CheckContrast runs the contrast check for the target page. Found issues are
+reported using the Audits.issueAdded event.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkContrast
+*/
+func (protocol *AuditsProtocol) CheckContrast(
+	params *audits.CheckContrastParams,
+) <-chan *audits.CheckContrastResult {
+	resultChan := make(chan *audits.CheckContrastResult)
+	command := NewCommand(protocol.Socket, "Audits.checkContrast", params)
+	result := &audits.CheckContrastResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+CheckFormsIssues runs the form issues check for the target page. Found issues
+are returned, they're not reported with the Audits.issueAdded event.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkFormsIssues
+*/
+func (protocol *AuditsProtocol) CheckFormsIssues() <-chan *audits.CheckFormsIssuesResult {
+	resultChan := make(chan *audits.CheckFormsIssuesResult)
+	command := NewCommand(protocol.Socket, "Audits.checkFormsIssues", nil)
+	result := &audits.CheckFormsIssuesResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Disable disables issues domain, prevents further issues from being reported to
+the client.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-disable
+*/
+func (protocol *AuditsProtocol) Disable() <-chan *audits.DisableResult {
+	resultChan := make(chan *audits.DisableResult)
+	command := NewCommand(protocol.Socket, "Audits.disable", nil)
+	result := &audits.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables issues domain, sends the issues collected so far to the client by
+means of the Audits.issueAdded event.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-enable
+*/
+func (protocol *AuditsProtocol) Enable() <-chan *audits.EnableResult {
+	resultChan := make(chan *audits.EnableResult)
+	command := NewCommand(protocol.Socket, "Audits.enable", nil)
+	result := &audits.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 GetEncodedResponse returns the response body and size if it were re-encoded with
 the specified settings. Only applies to images.
@@ -33,7 +129,7 @@ func (protocol *AuditsProtocol) GetEncodedResponse(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -43,3 +139,50 @@ func (protocol *AuditsProtocol) GetEncodedResponse(
 
 	return resultChan
 }
+
+/*
+OnIssueAdded adds a handler to the Audits.issueAdded event. Audits.issueAdded
+fires when an issue is found on the page, like a blocked CORS request, mixed
+content, a rejected cookie, a heavy ad or a content security policy violation.
+Issues found before the domain was enabled are sent when it's enabled.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Audits/#event-issueAdded
+*/
+func (protocol *AuditsProtocol) OnIssueAdded(
+	callback func(event *audits.IssueAddedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Audits.issueAdded",
+		func(response *Response) {
+			event := &audits.IssueAddedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+IssueAddedChan returns a channel of Audits.issueAdded events, as an alternative
+to OnIssueAdded. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to arrive
+in order.
+*/
+func (protocol *AuditsProtocol) IssueAddedChan(
+	buffer int,
+) (<-chan *audits.IssueAddedEvent, func()) {
+	eventCh := make(chan *audits.IssueAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnIssueAdded(func(event *audits.IssueAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
@@ -63,3 +63,215 @@ func TestAuditsGetEncodedResponse(t *testing.T) {
 		t.Errorf("Expected error, got success")
 	}
 }
+
+func TestAuditsCheckContrast(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAuditsCheckContrast")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &audits.CheckContrastParams{
+		ReportAAA: true,
+	}
+	resultChan := mockSocket.Audits().CheckContrast(params)
+	mockResult := &audits.CheckContrastResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Audits().CheckContrast(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestAuditsCheckFormsIssues(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAuditsCheckFormsIssues")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Audits().CheckFormsIssues()
+	mockResult := &audits.CheckFormsIssuesResult{
+		FormIssues: []*audits.GenericIssueDetails{{
+			ErrorType: "FormLabelForNameError",
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if 1 != len(result.FormIssues) || "FormLabelForNameError" != result.FormIssues[0].ErrorType {
+		t.Errorf("Expected %v, got %v", mockResult.FormIssues, result.FormIssues)
+	}
+
+	resultChan = mockSocket.Audits().CheckFormsIssues()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestAuditsDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAuditsDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Audits().Disable()
+	mockResult := &audits.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Audits().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestAuditsEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAuditsEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Audits().Enable()
+	mockResult := &audits.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Audits().Enable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestAuditsOnIssueAdded(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestAuditsOnIssueAdded")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *audits.IssueAddedEvent)
+	mockSocket.Audits().OnIssueAdded(func(eventData *audits.IssueAddedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &audits.IssueAddedEvent{
+		Issue: &audits.InspectorIssue{
+			Code: audits.CorsIssue,
+			Details: &audits.InspectorIssueDetails{
+				CorsIssueDetails: &audits.CorsIssueDetails{
+					CorsErrorStatus: &network.CorsErrorStatus{
+						CorsError:       "MissingAllowOriginHeader",
+						FailedParameter: "",
+					},
+					IsWarning: false,
+					Request: &audits.AffectedRequest{
+						RequestID: "RequestID",
+						URL:       "https://api.example.com/data",
+					},
+				},
+			},
+			IssueID: "IssueID",
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Audits.issueAdded",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.Issue || audits.CorsIssue != result.Issue.Code || nil == result.Issue.Details.CorsIssueDetails {
+		t.Errorf("Expected %v, got %v", mockResult.Issue, result.Issue)
+	}
+	if "MissingAllowOriginHeader" != result.Issue.Details.CorsIssueDetails.CorsErrorStatus.CorsError {
+		t.Errorf("Expected the CORS error, got %v", result.Issue.Details.CorsIssueDetails.CorsErrorStatus)
+	}
+
+	resultChan = make(chan *audits.IssueAddedEvent)
+	mockSocket.Audits().OnIssueAdded(func(eventData *audits.IssueAddedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Audits.issueAdded",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
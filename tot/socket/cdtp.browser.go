@@ -29,7 +29,7 @@ func (protocol *BrowserProtocol) Close() <-chan *browser.CloseResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,7 +51,7 @@ func (protocol *BrowserProtocol) GetVersion() <-chan *browser.GetVersionResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -77,7 +77,7 @@ func (protocol *BrowserProtocol) GetWindowBounds(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -104,7 +104,7 @@ func (protocol *BrowserProtocol) GetWindowForTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -115,6 +115,55 @@ func (protocol *BrowserProtocol) GetWindowForTarget(
 	return resultChan
 }
 
+/*
+GrantPermissions grants specific permissions to the given origin and rejects
+all others.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-grantPermissions EXPERIMENTAL.
+*/
+func (protocol *BrowserProtocol) GrantPermissions(
+	params *browser.GrantPermissionsParams,
+) <-chan *browser.GrantPermissionsResult {
+	resultChan := make(chan *browser.GrantPermissionsResult)
+	command := NewCommand(protocol.Socket, "Browser.grantPermissions", params)
+	result := &browser.GrantPermissionsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ResetPermissions resets all permission management for all origins.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-resetPermissions EXPERIMENTAL.
+*/
+func (protocol *BrowserProtocol) ResetPermissions(
+	params *browser.ResetPermissionsParams,
+) <-chan *browser.ResetPermissionsResult {
+	resultChan := make(chan *browser.ResetPermissionsResult)
+	command := NewCommand(protocol.Socket, "Browser.resetPermissions", params)
+	result := &browser.ResetPermissionsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 SetWindowBounds sets the position and/or size of the browser window.
 
@@ -130,7 +179,7 @@ func (protocol *BrowserProtocol) SetWindowBounds(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -31,7 +31,7 @@ func (protocol *CacheStorageProtocol) DeleteCache(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,7 +55,7 @@ func (protocol *CacheStorageProtocol) DeleteEntry(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -79,7 +79,7 @@ func (protocol *CacheStorageProtocol) RequestCacheNames(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -105,7 +105,7 @@ func (protocol *CacheStorageProtocol) RequestCachedResponse(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -131,7 +131,7 @@ func (protocol *CacheStorageProtocol) RequestEntries(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
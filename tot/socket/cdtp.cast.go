@@ -0,0 +1,257 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/cast"
+)
+
+/*
+CastProtocol provides a namespace for the Chrome Cast protocol methods. The Cast
+protocol lists the Cast and Presentation API receivers (sinks) available to the
+browser and selects which one to use, for automating sink selection without the
+Cast dialog.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/
+*/
+type CastProtocol struct {
+	Socket Socketer
+}
+
+/*
+Disable stops observing for sinks and issues.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-disable
+*/
+func (protocol *CastProtocol) Disable() <-chan *cast.DisableResult {
+	resultChan := make(chan *cast.DisableResult)
+	command := NewCommand(protocol.Socket, "Cast.disable", nil)
+	result := &cast.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable starts observing for sinks that can be used for tab mirroring, and if
+set, sinks compatible with the presentation URL are also reported. When sinks
+are found, a Cast.sinksUpdated event is fired. Also starts observing for issue
+messages, which fire Cast.issueUpdated events.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-enable
+*/
+func (protocol *CastProtocol) Enable(
+	params *cast.EnableParams,
+) <-chan *cast.EnableResult {
+	resultChan := make(chan *cast.EnableResult)
+	command := NewCommand(protocol.Socket, "Cast.enable", params)
+	result := &cast.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetSinkToUse sets a sink to be used when the web page requests the browser to
+choose a sink via the Presentation API, Remote Playback API, or Cast SDK.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-setSinkToUse
+*/
+func (protocol *CastProtocol) SetSinkToUse(
+	params *cast.SetSinkToUseParams,
+) <-chan *cast.SetSinkToUseResult {
+	resultChan := make(chan *cast.SetSinkToUseResult)
+	command := NewCommand(protocol.Socket, "Cast.setSinkToUse", params)
+	result := &cast.SetSinkToUseResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+StartDesktopMirroring starts mirroring the desktop to the sink.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startDesktopMirroring
+*/
+func (protocol *CastProtocol) StartDesktopMirroring(
+	params *cast.StartDesktopMirroringParams,
+) <-chan *cast.StartDesktopMirroringResult {
+	resultChan := make(chan *cast.StartDesktopMirroringResult)
+	command := NewCommand(protocol.Socket, "Cast.startDesktopMirroring", params)
+	result := &cast.StartDesktopMirroringResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+StartTabMirroring starts mirroring the tab to the sink.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-startTabMirroring
+*/
+func (protocol *CastProtocol) StartTabMirroring(
+	params *cast.StartTabMirroringParams,
+) <-chan *cast.StartTabMirroringResult {
+	resultChan := make(chan *cast.StartTabMirroringResult)
+	command := NewCommand(protocol.Socket, "Cast.startTabMirroring", params)
+	result := &cast.StartTabMirroringResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+StopCasting stops the active Cast session on the sink.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-stopCasting
+*/
+func (protocol *CastProtocol) StopCasting(
+	params *cast.StopCastingParams,
+) <-chan *cast.StopCastingResult {
+	resultChan := make(chan *cast.StopCastingResult)
+	command := NewCommand(protocol.Socket, "Cast.stopCasting", params)
+	result := &cast.StopCastingResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnIssueUpdated adds a handler to the Cast.issueUpdated event. Cast.issueUpdated
+fires whenever there's a new issue with a sink, or the outstanding issue is
+resolved, in which case the message is empty.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#event-issueUpdated
+*/
+func (protocol *CastProtocol) OnIssueUpdated(
+	callback func(event *cast.IssueUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Cast.issueUpdated",
+		func(response *Response) {
+			event := &cast.IssueUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+IssueUpdatedChan returns a channel of Cast.issueUpdated events, as an
+alternative to OnIssueUpdated. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *CastProtocol) IssueUpdatedChan(
+	buffer int,
+) (<-chan *cast.IssueUpdatedEvent, func()) {
+	eventCh := make(chan *cast.IssueUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnIssueUpdated(func(event *cast.IssueUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnSinksUpdated adds a handler to the Cast.sinksUpdated event. Cast.sinksUpdated
+fires whenever the list of available sinks changes. A sink is a device or a
+software surface that you can cast to.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Cast/#event-sinksUpdated
+*/
+func (protocol *CastProtocol) OnSinksUpdated(
+	callback func(event *cast.SinksUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Cast.sinksUpdated",
+		func(response *Response) {
+			event := &cast.SinksUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SinksUpdatedChan returns a channel of Cast.sinksUpdated events, as an
+alternative to OnSinksUpdated. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *CastProtocol) SinksUpdatedChan(
+	buffer int,
+) (<-chan *cast.SinksUpdatedEvent, func()) {
+	eventCh := make(chan *cast.SinksUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnSinksUpdated(func(event *cast.SinksUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
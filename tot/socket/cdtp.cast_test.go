@@ -0,0 +1,326 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/cast"
+)
+
+func TestCastDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Cast().Disable()
+	mockResult := &cast.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &cast.EnableParams{
+		PresentationURL: "https://example.com/presentation",
+	}
+	resultChan := mockSocket.Cast().Enable(params)
+	mockResult := &cast.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().Enable(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastSetSinkToUse(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastSetSinkToUse")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &cast.SetSinkToUseParams{
+		SinkName: "Living Room",
+	}
+	resultChan := mockSocket.Cast().SetSinkToUse(params)
+	mockResult := &cast.SetSinkToUseResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().SetSinkToUse(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastStartDesktopMirroring(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastStartDesktopMirroring")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &cast.StartDesktopMirroringParams{
+		SinkName: "Living Room",
+	}
+	resultChan := mockSocket.Cast().StartDesktopMirroring(params)
+	mockResult := &cast.StartDesktopMirroringResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().StartDesktopMirroring(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastStartTabMirroring(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastStartTabMirroring")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &cast.StartTabMirroringParams{
+		SinkName: "Living Room",
+	}
+	resultChan := mockSocket.Cast().StartTabMirroring(params)
+	mockResult := &cast.StartTabMirroringResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().StartTabMirroring(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastStopCasting(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastStopCasting")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &cast.StopCastingParams{
+		SinkName: "Living Room",
+	}
+	resultChan := mockSocket.Cast().StopCasting(params)
+	mockResult := &cast.StopCastingResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Cast().StopCasting(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastOnIssueUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastOnIssueUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *cast.IssueUpdatedEvent)
+	mockSocket.Cast().OnIssueUpdated(func(eventData *cast.IssueUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &cast.IssueUpdatedEvent{
+		IssueMessage: "Unable to connect to the sink",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Cast.issueUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if mockResult.IssueMessage != result.IssueMessage {
+		t.Errorf("Expected %s, got %s", mockResult.IssueMessage, result.IssueMessage)
+	}
+
+	resultChan = make(chan *cast.IssueUpdatedEvent)
+	mockSocket.Cast().OnIssueUpdated(func(eventData *cast.IssueUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Cast.issueUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestCastOnSinksUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCastOnSinksUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *cast.SinksUpdatedEvent)
+	mockSocket.Cast().OnSinksUpdated(func(eventData *cast.SinksUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &cast.SinksUpdatedEvent{
+		Sinks: []*cast.Sink{{
+			Name:    "Living Room",
+			ID:      "SinkID",
+			Session: "YouTube",
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Cast.sinksUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if 1 != len(result.Sinks) || "Living Room" != result.Sinks[0].Name {
+		t.Errorf("Expected %v, got %v", mockResult.Sinks, result.Sinks)
+	}
+
+	resultChan = make(chan *cast.SinksUpdatedEvent)
+	mockSocket.Cast().OnSinksUpdated(func(eventData *cast.SinksUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Cast.sinksUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -29,7 +29,7 @@ func (protocol *ConsoleProtocol) ClearMessages() <-chan *console.ClearMessagesRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -52,7 +52,7 @@ func (protocol *ConsoleProtocol) Disable() <-chan *console.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,7 +75,7 @@ func (protocol *ConsoleProtocol) Enable() <-chan *console.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -92,7 +92,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Console/#event-messageAdd
 */
 func (protocol *ConsoleProtocol) OnMessageAdded(
 	callback func(event *console.MessageAddedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Console.messageAdded",
 		func(response *Response) {
@@ -104,5 +104,26 @@ func (protocol *ConsoleProtocol) OnMessageAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+MessageAddedChan returns a channel of Console.messageAdded events, as an alternative to OnMessageAdded.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ConsoleProtocol) MessageAddedChan(
+	buffer int,
+) (<-chan *console.MessageAddedEvent, func()) {
+	eventCh := make(chan *console.MessageAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnMessageAdded(func(event *console.MessageAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
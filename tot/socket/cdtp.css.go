@@ -39,7 +39,7 @@ func (protocol *CSSProtocol) AddRule(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -65,7 +65,7 @@ func (protocol *CSSProtocol) CollectClassNames(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -92,7 +92,7 @@ func (protocol *CSSProtocol) CreateStyleSheet(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -116,7 +116,7 @@ func (protocol *CSSProtocol) Disable() <-chan *css.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -139,7 +139,7 @@ func (protocol *CSSProtocol) Enable() <-chan *css.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -164,7 +164,7 @@ func (protocol *CSSProtocol) ForcePseudoState(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -188,7 +188,7 @@ func (protocol *CSSProtocol) GetBackgroundColors(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -215,7 +215,7 @@ func (protocol *CSSProtocol) GetComputedStyleForNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -243,7 +243,7 @@ func (protocol *CSSProtocol) GetInlineStylesForNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -270,7 +270,7 @@ func (protocol *CSSProtocol) GetMatchedStylesForNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -294,7 +294,7 @@ func (protocol *CSSProtocol) GetMediaQueries() <-chan *css.GetMediaQueriesResult
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -321,7 +321,7 @@ func (protocol *CSSProtocol) GetPlatformFontsForNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -348,7 +348,7 @@ func (protocol *CSSProtocol) GetStyleSheetText(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -375,7 +375,7 @@ func (protocol *CSSProtocol) SetEffectivePropertyValueForNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -399,7 +399,7 @@ func (protocol *CSSProtocol) SetKeyframeKey(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -425,7 +425,7 @@ func (protocol *CSSProtocol) SetMediaText(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -451,7 +451,7 @@ func (protocol *CSSProtocol) SetRuleSelector(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -477,7 +477,7 @@ func (protocol *CSSProtocol) SetStyleSheetText(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -503,7 +503,7 @@ func (protocol *CSSProtocol) SetStyleTexts(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -527,7 +527,7 @@ func (protocol *CSSProtocol) StartRuleUsageTracking() <-chan *css.StartRuleUsage
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -550,7 +550,7 @@ func (protocol *CSSProtocol) StopRuleUsageTracking() <-chan *css.StopRuleUsageTr
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -575,7 +575,7 @@ func (protocol *CSSProtocol) TakeCoverageDelta() <-chan *css.TakeCoverageDeltaRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -594,7 +594,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-fontsUpdated
 */
 func (protocol *CSSProtocol) OnFontsUpdated(
 	callback func(event *css.FontsUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"CSS.fontsUpdated",
 		func(response *Response) {
@@ -606,7 +606,28 @@ func (protocol *CSSProtocol) OnFontsUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FontsUpdatedChan returns a channel of CSS.fontsUpdated events, as an alternative to OnFontsUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *CSSProtocol) FontsUpdatedChan(
+	buffer int,
+) (<-chan *css.FontsUpdatedEvent, func()) {
+	eventCh := make(chan *css.FontsUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFontsUpdated(func(event *css.FontsUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -619,7 +640,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-mediaQueryResu
 */
 func (protocol *CSSProtocol) OnMediaQueryResultChanged(
 	callback func(event *css.MediaQueryResultChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"CSS.mediaQueryResultChanged",
 		func(response *Response) {
@@ -631,7 +652,28 @@ func (protocol *CSSProtocol) OnMediaQueryResultChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+MediaQueryResultChangedChan returns a channel of CSS.mediaQueryResultChanged events, as an alternative to OnMediaQueryResultChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *CSSProtocol) MediaQueryResultChangedChan(
+	buffer int,
+) (<-chan *css.MediaQueryResultChangedEvent, func()) {
+	eventCh := make(chan *css.MediaQueryResultChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnMediaQueryResultChanged(func(event *css.MediaQueryResultChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -642,7 +684,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetAdde
 */
 func (protocol *CSSProtocol) OnStyleSheetAdded(
 	callback func(event *css.StyleSheetAddedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"CSS.styleSheetAdded",
 		func(response *Response) {
@@ -654,7 +696,28 @@ func (protocol *CSSProtocol) OnStyleSheetAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+StyleSheetAddedChan returns a channel of CSS.styleSheetAdded events, as an alternative to OnStyleSheetAdded.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *CSSProtocol) StyleSheetAddedChan(
+	buffer int,
+) (<-chan *css.StyleSheetAddedEvent, func()) {
+	eventCh := make(chan *css.StyleSheetAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnStyleSheetAdded(func(event *css.StyleSheetAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -666,7 +729,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetChan
 */
 func (protocol *CSSProtocol) OnStyleSheetChanged(
 	callback func(event *css.StyleSheetChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"CSS.styleSheetChanged",
 		func(response *Response) {
@@ -678,7 +741,28 @@ func (protocol *CSSProtocol) OnStyleSheetChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+StyleSheetChangedChan returns a channel of CSS.styleSheetChanged events, as an alternative to OnStyleSheetChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *CSSProtocol) StyleSheetChangedChan(
+	buffer int,
+) (<-chan *css.StyleSheetChangedEvent, func()) {
+	eventCh := make(chan *css.StyleSheetChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnStyleSheetChanged(func(event *css.StyleSheetChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -689,7 +773,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetRemo
 */
 func (protocol *CSSProtocol) OnStyleSheetRemoved(
 	callback func(event *css.StyleSheetRemovedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"CSS.styleSheetRemoved",
 		func(response *Response) {
@@ -701,5 +785,26 @@ func (protocol *CSSProtocol) OnStyleSheetRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+StyleSheetRemovedChan returns a channel of CSS.styleSheetRemoved events, as an alternative to OnStyleSheetRemoved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *CSSProtocol) StyleSheetRemovedChan(
+	buffer int,
+) (<-chan *css.StyleSheetRemovedEvent, func()) {
+	eventCh := make(chan *css.StyleSheetRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnStyleSheetRemoved(func(event *css.StyleSheetRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
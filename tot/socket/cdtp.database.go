@@ -29,7 +29,7 @@ func (protocol *DatabaseProtocol) Disable() <-chan *database.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -52,7 +52,7 @@ func (protocol *DatabaseProtocol) Enable() <-chan *database.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,7 +76,7 @@ func (protocol *DatabaseProtocol) ExecuteSQL(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -102,7 +102,7 @@ func (protocol *DatabaseProtocol) GetTableNames(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -121,7 +121,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Database/#event-addDataba
 */
 func (protocol *DatabaseProtocol) OnAdd(
 	callback func(event *database.AddEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Database.addDatabase",
 		func(response *Response) {
@@ -133,5 +133,26 @@ func (protocol *DatabaseProtocol) OnAdd(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AddChan returns a channel of Database.addDatabase events, as an alternative to OnAdd.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DatabaseProtocol) AddChan(
+	buffer int,
+) (<-chan *database.AddEvent, func()) {
+	eventCh := make(chan *database.AddEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAdd(func(event *database.AddEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -33,7 +33,7 @@ func (protocol *DebuggerProtocol) ContinueToLocation(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,7 +55,7 @@ func (protocol *DebuggerProtocol) Disable() <-chan *debugger.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -78,7 +78,7 @@ func (protocol *DebuggerProtocol) Enable() <-chan *debugger.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -104,7 +104,7 @@ func (protocol *DebuggerProtocol) EvaluateOnCallFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -131,7 +131,7 @@ func (protocol *DebuggerProtocol) GetPossibleBreakpoints(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -157,7 +157,7 @@ func (protocol *DebuggerProtocol) GetScriptSource(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -183,7 +183,7 @@ func (protocol *DebuggerProtocol) GetStackTrace(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -207,7 +207,7 @@ func (protocol *DebuggerProtocol) Pause() <-chan *debugger.PauseResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -231,7 +231,7 @@ func (protocol *DebuggerProtocol) PauseOnAsyncCall(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -255,7 +255,7 @@ func (protocol *DebuggerProtocol) RemoveBreakpoint(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -279,7 +279,7 @@ func (protocol *DebuggerProtocol) RestartFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -303,7 +303,7 @@ func (protocol *DebuggerProtocol) Resume() <-chan *debugger.ResumeResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -330,7 +330,7 @@ func (protocol *DebuggerProtocol) ScheduleStepIntoAsync() <-chan *debugger.Sched
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -354,7 +354,7 @@ func (protocol *DebuggerProtocol) SearchInContent(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -380,7 +380,7 @@ func (protocol *DebuggerProtocol) SetAsyncCallStackDepth(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -409,7 +409,7 @@ func (protocol *DebuggerProtocol) SetBlackboxPatterns(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -436,7 +436,7 @@ func (protocol *DebuggerProtocol) SetBlackboxedRanges(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -460,7 +460,7 @@ func (protocol *DebuggerProtocol) SetBreakpoint(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -489,7 +489,7 @@ func (protocol *DebuggerProtocol) SetBreakpointByURL(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -515,7 +515,7 @@ func (protocol *DebuggerProtocol) SetBreakpointsActive(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -540,7 +540,7 @@ func (protocol *DebuggerProtocol) SetPauseOnExceptions(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -565,7 +565,7 @@ func (protocol *DebuggerProtocol) SetReturnValue(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -589,7 +589,7 @@ func (protocol *DebuggerProtocol) SetScriptSource(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -615,7 +615,7 @@ func (protocol *DebuggerProtocol) SetSkipAllPauses(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -640,7 +640,7 @@ func (protocol *DebuggerProtocol) SetVariableValue(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -664,7 +664,7 @@ func (protocol *DebuggerProtocol) StepInto(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -686,7 +686,7 @@ func (protocol *DebuggerProtocol) StepOut() <-chan *debugger.StepOutResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -708,7 +708,7 @@ func (protocol *DebuggerProtocol) StepOver() <-chan *debugger.StepOverResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -725,7 +725,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-breakpoin
 */
 func (protocol *DebuggerProtocol) OnBreakpointResolved(
 	callback func(event *debugger.BreakpointResolvedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Debugger.breakpointResolved",
 		func(response *Response) {
@@ -737,7 +737,28 @@ func (protocol *DebuggerProtocol) OnBreakpointResolved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+BreakpointResolvedChan returns a channel of Debugger.breakpointResolved events, as an alternative to OnBreakpointResolved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DebuggerProtocol) BreakpointResolvedChan(
+	buffer int,
+) (<-chan *debugger.BreakpointResolvedEvent, func()) {
+	eventCh := make(chan *debugger.BreakpointResolvedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnBreakpointResolved(func(event *debugger.BreakpointResolvedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -748,7 +769,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-paused
 */
 func (protocol *DebuggerProtocol) OnPaused(
 	callback func(event *debugger.PausedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Debugger.paused",
 		func(response *Response) {
@@ -760,7 +781,28 @@ func (protocol *DebuggerProtocol) OnPaused(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PausedChan returns a channel of Debugger.paused events, as an alternative to OnPaused.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DebuggerProtocol) PausedChan(
+	buffer int,
+) (<-chan *debugger.PausedEvent, func()) {
+	eventCh := make(chan *debugger.PausedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPaused(func(event *debugger.PausedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -771,7 +813,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-resumed
 */
 func (protocol *DebuggerProtocol) OnResumed(
 	callback func(event *debugger.ResumedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Debugger.resumed",
 		func(response *Response) {
@@ -783,7 +825,28 @@ func (protocol *DebuggerProtocol) OnResumed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ResumedChan returns a channel of Debugger.resumed events, as an alternative to OnResumed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DebuggerProtocol) ResumedChan(
+	buffer int,
+) (<-chan *debugger.ResumedEvent, func()) {
+	eventCh := make(chan *debugger.ResumedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnResumed(func(event *debugger.ResumedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -794,7 +857,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptFai
 */
 func (protocol *DebuggerProtocol) OnScriptFailedToParse(
 	callback func(event *debugger.ScriptFailedToParseEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Debugger.scriptFailedToParse",
 		func(response *Response) {
@@ -806,7 +869,28 @@ func (protocol *DebuggerProtocol) OnScriptFailedToParse(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ScriptFailedToParseChan returns a channel of Debugger.scriptFailedToParse events, as an alternative to OnScriptFailedToParse.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DebuggerProtocol) ScriptFailedToParseChan(
+	buffer int,
+) (<-chan *debugger.ScriptFailedToParseEvent, func()) {
+	eventCh := make(chan *debugger.ScriptFailedToParseEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnScriptFailedToParse(func(event *debugger.ScriptFailedToParseEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -818,7 +902,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptPar
 */
 func (protocol *DebuggerProtocol) OnScriptParsed(
 	callback func(event *debugger.ScriptParsedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Debugger.scriptParsed",
 		func(response *Response) {
@@ -830,5 +914,26 @@ func (protocol *DebuggerProtocol) OnScriptParsed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ScriptParsedChan returns a channel of Debugger.scriptParsed events, as an alternative to OnScriptParsed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DebuggerProtocol) ScriptParsedChan(
+	buffer int,
+) (<-chan *debugger.ScriptParsedEvent, func()) {
+	eventCh := make(chan *debugger.ScriptParsedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnScriptParsed(func(event *debugger.ScriptParsedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -0,0 +1,160 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/device/access"
+)
+
+/*
+DeviceAccessProtocol provides a namespace for the Chrome DeviceAccess protocol
+methods. The DeviceAccess protocol scripts the device chooser prompts of WebUSB,
+WebBluetooth and WebHID, which would otherwise wait for a user and hang headless
+runs.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/
+*/
+type DeviceAccessProtocol struct {
+	Socket Socketer
+}
+
+/*
+CancelPrompt cancels a device request prompt, as if the user dismissed it.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-cancelPrompt
+*/
+func (protocol *DeviceAccessProtocol) CancelPrompt(
+	params *access.CancelPromptParams,
+) <-chan *access.CancelPromptResult {
+	resultChan := make(chan *access.CancelPromptResult)
+	command := NewCommand(protocol.Socket, "DeviceAccess.cancelPrompt", params)
+	result := &access.CancelPromptResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Disable disables the DeviceAccess domain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-disable
+*/
+func (protocol *DeviceAccessProtocol) Disable() <-chan *access.DisableResult {
+	resultChan := make(chan *access.DisableResult)
+	command := NewCommand(protocol.Socket, "DeviceAccess.disable", nil)
+	result := &access.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables the DeviceAccess domain. Device request prompts are reported with
+the DeviceAccess.deviceRequestPrompted event while it's enabled.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-enable
+*/
+func (protocol *DeviceAccessProtocol) Enable() <-chan *access.EnableResult {
+	resultChan := make(chan *access.EnableResult)
+	command := NewCommand(protocol.Socket, "DeviceAccess.enable", nil)
+	result := &access.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SelectPrompt selects a device in a device request prompt, as if the user chose
+it.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#method-selectPrompt
+*/
+func (protocol *DeviceAccessProtocol) SelectPrompt(
+	params *access.SelectPromptParams,
+) <-chan *access.SelectPromptResult {
+	resultChan := make(chan *access.SelectPromptResult)
+	command := NewCommand(protocol.Socket, "DeviceAccess.selectPrompt", params)
+	result := &access.SelectPromptResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnDeviceRequestPrompted adds a handler to the DeviceAccess.deviceRequestPrompted
+event. Fired when a page requests a device and a chooser prompt is shown, and
+again when the list of devices in the prompt changes.
+
+https://chromedevtools.github.io/devtools-protocol/tot/DeviceAccess/#event-deviceRequestPrompted
+*/
+func (protocol *DeviceAccessProtocol) OnDeviceRequestPrompted(
+	callback func(event *access.DeviceRequestPromptedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"DeviceAccess.deviceRequestPrompted",
+		func(response *Response) {
+			event := &access.DeviceRequestPromptedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DeviceRequestPromptedChan returns a channel of
+DeviceAccess.deviceRequestPrompted events, as an alternative to
+OnDeviceRequestPrompted. The returned function removes the event handler and
+closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *DeviceAccessProtocol) DeviceRequestPromptedChan(
+	buffer int,
+) (<-chan *access.DeviceRequestPromptedEvent, func()) {
+	eventCh := make(chan *access.DeviceRequestPromptedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDeviceRequestPrompted(func(event *access.DeviceRequestPromptedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
@@ -0,0 +1,202 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/device/access"
+)
+
+func TestDeviceAccessCancelPrompt(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDeviceAccessCancelPrompt")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &access.CancelPromptParams{
+		ID: access.RequestID("request"),
+	}
+	resultChan := mockSocket.DeviceAccess().CancelPrompt(params)
+	mockResult := &access.CancelPromptResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.DeviceAccess().CancelPrompt(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestDeviceAccessDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDeviceAccessDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.DeviceAccess().Disable()
+	mockResult := &access.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.DeviceAccess().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestDeviceAccessEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDeviceAccessEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.DeviceAccess().Enable()
+	mockResult := &access.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.DeviceAccess().Enable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestDeviceAccessSelectPrompt(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDeviceAccessSelectPrompt")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &access.SelectPromptParams{
+		ID:       access.RequestID("request"),
+		DeviceID: access.DeviceID("device"),
+	}
+	resultChan := mockSocket.DeviceAccess().SelectPrompt(params)
+	mockResult := &access.SelectPromptResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.DeviceAccess().SelectPrompt(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestDeviceAccessOnDeviceRequestPrompted(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDeviceAccessOnDeviceRequestPrompted")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *access.DeviceRequestPromptedEvent)
+	mockSocket.DeviceAccess().OnDeviceRequestPrompted(func(eventData *access.DeviceRequestPromptedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &access.DeviceRequestPromptedEvent{
+		ID: access.RequestID("request"),
+		Devices: []*access.PromptDevice{
+			{ID: access.DeviceID("device"), Name: "Security Key"},
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "DeviceAccess.deviceRequestPrompted",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if "request" != result.ID || 1 != len(result.Devices) || "Security Key" != result.Devices[0].Name {
+		t.Errorf("Expected a prompt with a device, got %v", result)
+	}
+
+	resultChan = make(chan *access.DeviceRequestPromptedEvent)
+	mockSocket.DeviceAccess().OnDeviceRequestPrompted(func(eventData *access.DeviceRequestPromptedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "DeviceAccess.deviceRequestPrompted",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -41,7 +41,7 @@ func (protocol *DOMProtocol) CollectClassNamesFromSubtree(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -68,7 +68,7 @@ func (protocol *DOMProtocol) CopyTo(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -95,7 +95,7 @@ func (protocol *DOMProtocol) DescribeNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -119,7 +119,7 @@ func (protocol *DOMProtocol) Disable() <-chan *dom.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -145,7 +145,7 @@ func (protocol *DOMProtocol) DiscardSearchResults(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -167,7 +167,7 @@ func (protocol *DOMProtocol) Enable() <-chan *dom.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -191,7 +191,7 @@ func (protocol *DOMProtocol) Focus(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -215,7 +215,7 @@ func (protocol *DOMProtocol) GetAttributes(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -241,7 +241,7 @@ func (protocol *DOMProtocol) GetBoxModel(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -268,7 +268,7 @@ func (protocol *DOMProtocol) GetDocument(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -295,7 +295,7 @@ func (protocol *DOMProtocol) GetFlattenedDocument(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -321,7 +321,7 @@ func (protocol *DOMProtocol) GetNodeForLocation(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -347,7 +347,7 @@ func (protocol *DOMProtocol) GetOuterHTML(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -374,7 +374,7 @@ func (protocol *DOMProtocol) GetRelayoutBoundary(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -402,7 +402,7 @@ func (protocol *DOMProtocol) GetSearchResults(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -427,7 +427,7 @@ func (protocol *DOMProtocol) MarkUndoableState() <-chan *dom.MarkUndoableStateRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -451,7 +451,7 @@ func (protocol *DOMProtocol) MoveTo(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -479,7 +479,7 @@ func (protocol *DOMProtocol) PerformSearch(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -507,7 +507,7 @@ func (protocol *DOMProtocol) PushNodeByPathToFrontend(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -535,7 +535,7 @@ func (protocol *DOMProtocol) PushNodesByBackendIDsToFrontend(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -561,7 +561,7 @@ func (protocol *DOMProtocol) QuerySelector(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -587,7 +587,7 @@ func (protocol *DOMProtocol) QuerySelectorAll(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -611,7 +611,7 @@ func (protocol *DOMProtocol) Redo() <-chan *dom.RedoResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -635,7 +635,7 @@ func (protocol *DOMProtocol) RemoveAttribute(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -659,7 +659,7 @@ func (protocol *DOMProtocol) RemoveNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -685,7 +685,7 @@ func (protocol *DOMProtocol) RequestChildNodes(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -711,7 +711,7 @@ func (protocol *DOMProtocol) RequestNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -738,7 +738,7 @@ func (protocol *DOMProtocol) ResolveNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -764,7 +764,7 @@ func (protocol *DOMProtocol) SetAttributeValue(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -790,7 +790,7 @@ func (protocol *DOMProtocol) SetAttributesAsText(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -814,7 +814,7 @@ func (protocol *DOMProtocol) SetFileInputFiles(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -839,7 +839,7 @@ func (protocol *DOMProtocol) SetInspectedNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -863,7 +863,7 @@ func (protocol *DOMProtocol) SetNodeName(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -889,7 +889,7 @@ func (protocol *DOMProtocol) SetNodeValue(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -913,7 +913,7 @@ func (protocol *DOMProtocol) SetOuterHTML(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -936,7 +936,7 @@ func (protocol *DOMProtocol) Undo() <-chan *dom.UndoResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -953,7 +953,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeModif
 */
 func (protocol *DOMProtocol) OnAttributeModified(
 	callback func(event *dom.AttributeModifiedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.attributeModified",
 		func(response *Response) {
@@ -965,7 +965,28 @@ func (protocol *DOMProtocol) OnAttributeModified(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AttributeModifiedChan returns a channel of DOM.attributeModified events, as an alternative to OnAttributeModified.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) AttributeModifiedChan(
+	buffer int,
+) (<-chan *dom.AttributeModifiedEvent, func()) {
+	eventCh := make(chan *dom.AttributeModifiedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAttributeModified(func(event *dom.AttributeModifiedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -976,7 +997,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeRemov
 */
 func (protocol *DOMProtocol) OnAttributeRemoved(
 	callback func(event *dom.AttributeRemovedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.attributeRemoved",
 		func(response *Response) {
@@ -988,7 +1009,28 @@ func (protocol *DOMProtocol) OnAttributeRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AttributeRemovedChan returns a channel of DOM.attributeRemoved events, as an alternative to OnAttributeRemoved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) AttributeRemovedChan(
+	buffer int,
+) (<-chan *dom.AttributeRemovedEvent, func()) {
+	eventCh := make(chan *dom.AttributeRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAttributeRemoved(func(event *dom.AttributeRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -999,7 +1041,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-characterDataM
 */
 func (protocol *DOMProtocol) OnCharacterDataModified(
 	callback func(event *dom.CharacterDataModifiedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.characterDataModified",
 		func(response *Response) {
@@ -1011,7 +1053,28 @@ func (protocol *DOMProtocol) OnCharacterDataModified(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CharacterDataModifiedChan returns a channel of DOM.characterDataModified events, as an alternative to OnCharacterDataModified.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) CharacterDataModifiedChan(
+	buffer int,
+) (<-chan *dom.CharacterDataModifiedEvent, func()) {
+	eventCh := make(chan *dom.CharacterDataModifiedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCharacterDataModified(func(event *dom.CharacterDataModifiedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1022,7 +1085,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeCount
 */
 func (protocol *DOMProtocol) OnChildNodeCountUpdated(
 	callback func(event *dom.ChildNodeCountUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.childNodeCountUpdated",
 		func(response *Response) {
@@ -1034,7 +1097,28 @@ func (protocol *DOMProtocol) OnChildNodeCountUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ChildNodeCountUpdatedChan returns a channel of DOM.childNodeCountUpdated events, as an alternative to OnChildNodeCountUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) ChildNodeCountUpdatedChan(
+	buffer int,
+) (<-chan *dom.ChildNodeCountUpdatedEvent, func()) {
+	eventCh := make(chan *dom.ChildNodeCountUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnChildNodeCountUpdated(func(event *dom.ChildNodeCountUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1045,7 +1129,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeInser
 */
 func (protocol *DOMProtocol) OnChildNodeInserted(
 	callback func(event *dom.ChildNodeInsertedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.childNodeInserted",
 		func(response *Response) {
@@ -1057,7 +1141,28 @@ func (protocol *DOMProtocol) OnChildNodeInserted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ChildNodeInsertedChan returns a channel of DOM.childNodeInserted events, as an alternative to OnChildNodeInserted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) ChildNodeInsertedChan(
+	buffer int,
+) (<-chan *dom.ChildNodeInsertedEvent, func()) {
+	eventCh := make(chan *dom.ChildNodeInsertedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnChildNodeInserted(func(event *dom.ChildNodeInsertedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1068,7 +1173,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeRemov
 */
 func (protocol *DOMProtocol) OnChildNodeRemoved(
 	callback func(event *dom.ChildNodeRemovedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.childNodeRemoved",
 		func(response *Response) {
@@ -1080,7 +1185,28 @@ func (protocol *DOMProtocol) OnChildNodeRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ChildNodeRemovedChan returns a channel of DOM.childNodeRemoved events, as an alternative to OnChildNodeRemoved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) ChildNodeRemovedChan(
+	buffer int,
+) (<-chan *dom.ChildNodeRemovedEvent, func()) {
+	eventCh := make(chan *dom.ChildNodeRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnChildNodeRemoved(func(event *dom.ChildNodeRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1092,7 +1218,7 @@ EXPERIMENTAL.
 */
 func (protocol *DOMProtocol) OnDistributedNodesUpdated(
 	callback func(event *dom.DistributedNodesUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.distributedNodesUpdated",
 		func(response *Response) {
@@ -1104,7 +1230,28 @@ func (protocol *DOMProtocol) OnDistributedNodesUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DistributedNodesUpdatedChan returns a channel of DOM.distributedNodesUpdated events, as an alternative to OnDistributedNodesUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) DistributedNodesUpdatedChan(
+	buffer int,
+) (<-chan *dom.DistributedNodesUpdatedEvent, func()) {
+	eventCh := make(chan *dom.DistributedNodesUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDistributedNodesUpdated(func(event *dom.DistributedNodesUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1116,7 +1263,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-documentUpdate
 */
 func (protocol *DOMProtocol) OnDocumentUpdated(
 	callback func(event *dom.DocumentUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.documentUpdated",
 		func(response *Response) {
@@ -1128,7 +1275,28 @@ func (protocol *DOMProtocol) OnDocumentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DocumentUpdatedChan returns a channel of DOM.documentUpdated events, as an alternative to OnDocumentUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) DocumentUpdatedChan(
+	buffer int,
+) (<-chan *dom.DocumentUpdatedEvent, func()) {
+	eventCh := make(chan *dom.DocumentUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDocumentUpdated(func(event *dom.DocumentUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1139,7 +1307,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-inlineStyleInv
 */
 func (protocol *DOMProtocol) OnInlineStyleInvalidated(
 	callback func(event *dom.InlineStyleInvalidatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.inlineStyleInvalidated",
 		func(response *Response) {
@@ -1151,7 +1319,28 @@ func (protocol *DOMProtocol) OnInlineStyleInvalidated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+InlineStyleInvalidatedChan returns a channel of DOM.inlineStyleInvalidated events, as an alternative to OnInlineStyleInvalidated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) InlineStyleInvalidatedChan(
+	buffer int,
+) (<-chan *dom.InlineStyleInvalidatedEvent, func()) {
+	eventCh := make(chan *dom.InlineStyleInvalidatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnInlineStyleInvalidated(func(event *dom.InlineStyleInvalidatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1162,7 +1351,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementA
 */
 func (protocol *DOMProtocol) OnPseudoElementAdded(
 	callback func(event *dom.PseudoElementAddedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.pseudoElementAdded",
 		func(response *Response) {
@@ -1174,7 +1363,28 @@ func (protocol *DOMProtocol) OnPseudoElementAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PseudoElementAddedChan returns a channel of DOM.pseudoElementAdded events, as an alternative to OnPseudoElementAdded.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) PseudoElementAddedChan(
+	buffer int,
+) (<-chan *dom.PseudoElementAddedEvent, func()) {
+	eventCh := make(chan *dom.PseudoElementAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPseudoElementAdded(func(event *dom.PseudoElementAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1185,7 +1395,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementR
 */
 func (protocol *DOMProtocol) OnPseudoElementRemoved(
 	callback func(event *dom.PseudoElementRemovedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.pseudoElementRemoved",
 		func(response *Response) {
@@ -1197,7 +1407,28 @@ func (protocol *DOMProtocol) OnPseudoElementRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PseudoElementRemovedChan returns a channel of DOM.pseudoElementRemoved events, as an alternative to OnPseudoElementRemoved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) PseudoElementRemovedChan(
+	buffer int,
+) (<-chan *dom.PseudoElementRemovedEvent, func()) {
+	eventCh := make(chan *dom.PseudoElementRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPseudoElementRemoved(func(event *dom.PseudoElementRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1209,7 +1440,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-setChildNodes
 */
 func (protocol *DOMProtocol) OnSetChildNodes(
 	callback func(event *dom.SetChildNodesEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.setChildNodes",
 		func(response *Response) {
@@ -1221,7 +1452,28 @@ func (protocol *DOMProtocol) OnSetChildNodes(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SetChildNodesChan returns a channel of DOM.setChildNodes events, as an alternative to OnSetChildNodes.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) SetChildNodesChan(
+	buffer int,
+) (<-chan *dom.SetChildNodesEvent, func()) {
+	eventCh := make(chan *dom.SetChildNodesEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnSetChildNodes(func(event *dom.SetChildNodesEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1232,7 +1484,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPopp
 */
 func (protocol *DOMProtocol) OnShadowRootPopped(
 	callback func(event *dom.ShadowRootPoppedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.shadowRootPopped",
 		func(response *Response) {
@@ -1244,7 +1496,28 @@ func (protocol *DOMProtocol) OnShadowRootPopped(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ShadowRootPoppedChan returns a channel of DOM.shadowRootPopped events, as an alternative to OnShadowRootPopped.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) ShadowRootPoppedChan(
+	buffer int,
+) (<-chan *dom.ShadowRootPoppedEvent, func()) {
+	eventCh := make(chan *dom.ShadowRootPoppedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnShadowRootPopped(func(event *dom.ShadowRootPoppedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1255,7 +1528,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPush
 */
 func (protocol *DOMProtocol) OnShadowRootPushed(
 	callback func(event *dom.ShadowRootPushedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOM.shadowRootPushed",
 		func(response *Response) {
@@ -1267,5 +1540,26 @@ func (protocol *DOMProtocol) OnShadowRootPushed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ShadowRootPushedChan returns a channel of DOM.shadowRootPushed events, as an alternative to OnShadowRootPushed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMProtocol) ShadowRootPushedChan(
+	buffer int,
+) (<-chan *dom.ShadowRootPushedEvent, func()) {
+	eventCh := make(chan *dom.ShadowRootPushedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnShadowRootPushed(func(event *dom.ShadowRootPushedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -31,7 +31,7 @@ func (protocol *DOMStorageProtocol) Clear(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,7 +54,7 @@ func (protocol *DOMStorageProtocol) Disable() <-chan *storage.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -77,7 +77,7 @@ func (protocol *DOMStorageProtocol) Enable() <-chan *storage.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -101,7 +101,7 @@ func (protocol *DOMStorageProtocol) GetItems(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -127,7 +127,7 @@ func (protocol *DOMStorageProtocol) RemoveItem(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -151,7 +151,7 @@ func (protocol *DOMStorageProtocol) SetItem(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -168,7 +168,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemAdded(
 	callback func(event *storage.ItemAddedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOMStorage.domStorageItemAdded",
 		func(response *Response) {
@@ -180,7 +180,28 @@ func (protocol *DOMStorageProtocol) OnItemAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ItemAddedChan returns a channel of DOMStorage.domStorageItemAdded events, as an alternative to OnItemAdded.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMStorageProtocol) ItemAddedChan(
+	buffer int,
+) (<-chan *storage.ItemAddedEvent, func()) {
+	eventCh := make(chan *storage.ItemAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnItemAdded(func(event *storage.ItemAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -191,7 +212,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemRemoved(
 	callback func(event *storage.ItemRemovedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOMStorage.domStorageItemRemoved",
 		func(response *Response) {
@@ -203,7 +224,28 @@ func (protocol *DOMStorageProtocol) OnItemRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ItemRemovedChan returns a channel of DOMStorage.domStorageItemRemoved events, as an alternative to OnItemRemoved.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMStorageProtocol) ItemRemovedChan(
+	buffer int,
+) (<-chan *storage.ItemRemovedEvent, func()) {
+	eventCh := make(chan *storage.ItemRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnItemRemoved(func(event *storage.ItemRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -214,7 +256,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemUpdated(
 	callback func(event *storage.ItemUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOMStorage.domStorageItemUpdated",
 		func(response *Response) {
@@ -226,7 +268,28 @@ func (protocol *DOMStorageProtocol) OnItemUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ItemUpdatedChan returns a channel of DOMStorage.domStorageItemUpdated events, as an alternative to OnItemUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMStorageProtocol) ItemUpdatedChan(
+	buffer int,
+) (<-chan *storage.ItemUpdatedEvent, func()) {
+	eventCh := make(chan *storage.ItemUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnItemUpdated(func(event *storage.ItemUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -237,7 +300,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemsCleared(
 	callback func(event *storage.ItemsClearedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"DOMStorage.domStorageItemsCleared",
 		func(response *Response) {
@@ -249,5 +312,26 @@ func (protocol *DOMStorageProtocol) OnItemsCleared(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ItemsClearedChan returns a channel of DOMStorage.domStorageItemsCleared events, as an alternative to OnItemsCleared.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *DOMStorageProtocol) ItemsClearedChan(
+	buffer int,
+) (<-chan *storage.ItemsClearedEvent, func()) {
+	eventCh := make(chan *storage.ItemsClearedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnItemsCleared(func(event *storage.ItemsClearedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -29,7 +29,7 @@ func (protocol *EmulationProtocol) CanEmulate() <-chan *emulation.CanEmulateResu
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -53,7 +53,7 @@ func (protocol *EmulationProtocol) ClearDeviceMetricsOverride() <-chan *emulatio
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,7 +75,7 @@ func (protocol *EmulationProtocol) ClearGeolocationOverride() <-chan *emulation.
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -98,7 +98,7 @@ func (protocol *EmulationProtocol) ResetPageScaleFactor() <-chan *emulation.Rese
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -123,7 +123,7 @@ func (protocol *EmulationProtocol) SetCPUThrottlingRate(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -149,7 +149,7 @@ func (protocol *EmulationProtocol) SetDefaultBackgroundColorOverride(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -176,7 +176,7 @@ func (protocol *EmulationProtocol) SetDeviceMetricsOverride(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -201,7 +201,7 @@ func (protocol *EmulationProtocol) SetEmitTouchEventsForMouse(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -225,7 +225,7 @@ func (protocol *EmulationProtocol) SetEmulatedMedia(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -250,7 +250,33 @@ func (protocol *EmulationProtocol) SetGeolocationOverride(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetLocaleOverride overrides the default host system locale with the specified
+one.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setLocaleOverride
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) SetLocaleOverride(
+	params *emulation.SetLocaleOverrideParams,
+) <-chan *emulation.SetLocaleOverrideResult {
+	resultChan := make(chan *emulation.SetLocaleOverrideResult)
+	command := NewCommand(protocol.Socket, "Emulation.setLocaleOverride", params)
+	result := &emulation.SetLocaleOverrideResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -276,7 +302,7 @@ func (protocol *EmulationProtocol) SetNavigatorOverrides(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -301,7 +327,7 @@ func (protocol *EmulationProtocol) SetPageScaleFactor(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -325,7 +351,33 @@ func (protocol *EmulationProtocol) SetScriptExecutionDisabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetTimezoneOverride overrides the default host system timezone with the
+specified one.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setTimezoneOverride
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) SetTimezoneOverride(
+	params *emulation.SetTimezoneOverrideParams,
+) <-chan *emulation.SetTimezoneOverrideResult {
+	resultChan := make(chan *emulation.SetTimezoneOverrideResult)
+	command := NewCommand(protocol.Socket, "Emulation.setTimezoneOverride", params)
+	result := &emulation.SetTimezoneOverrideResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -349,7 +401,32 @@ func (protocol *EmulationProtocol) SetTouchEmulationEnabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetUserAgentOverride allows overriding the user agent with the given string and
+User Agent Client Hints metadata.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setUserAgentOverride
+*/
+func (protocol *EmulationProtocol) SetUserAgentOverride(
+	params *emulation.SetUserAgentOverrideParams,
+) <-chan *emulation.SetUserAgentOverrideResult {
+	resultChan := make(chan *emulation.SetUserAgentOverrideResult)
+	command := NewCommand(protocol.Socket, "Emulation.setUserAgentOverride", params)
+	result := &emulation.SetUserAgentOverrideResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -376,7 +453,7 @@ func (protocol *EmulationProtocol) SetVirtualTimePolicy(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -405,7 +482,7 @@ func (protocol *EmulationProtocol) SetVisibleSize(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -423,7 +500,7 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimeAdvanced(
 	callback func(event *emulation.VirtualTimeAdvancedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Emulation.virtualTimeAdvanced",
 		func(response *Response) {
@@ -435,7 +512,28 @@ func (protocol *EmulationProtocol) OnVirtualTimeAdvanced(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+VirtualTimeAdvancedChan returns a channel of Emulation.virtualTimeAdvanced events, as an alternative to OnVirtualTimeAdvanced.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *EmulationProtocol) VirtualTimeAdvancedChan(
+	buffer int,
+) (<-chan *emulation.VirtualTimeAdvancedEvent, func()) {
+	eventCh := make(chan *emulation.VirtualTimeAdvancedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnVirtualTimeAdvanced(func(event *emulation.VirtualTimeAdvancedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -448,7 +546,7 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimeBudgetExpired(
 	callback func(event *emulation.VirtualTimeBudgetExpiredEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Emulation.virtualTimeBudgetExpired",
 		func(response *Response) {
@@ -460,7 +558,28 @@ func (protocol *EmulationProtocol) OnVirtualTimeBudgetExpired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+VirtualTimeBudgetExpiredChan returns a channel of Emulation.virtualTimeBudgetExpired events, as an alternative to OnVirtualTimeBudgetExpired.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *EmulationProtocol) VirtualTimeBudgetExpiredChan(
+	buffer int,
+) (<-chan *emulation.VirtualTimeBudgetExpiredEvent, func()) {
+	eventCh := make(chan *emulation.VirtualTimeBudgetExpiredEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnVirtualTimeBudgetExpired(func(event *emulation.VirtualTimeBudgetExpiredEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -472,7 +591,7 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimePaused(
 	callback func(event *emulation.VirtualTimePausedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Emulation.virtualTimePaused",
 		func(response *Response) {
@@ -484,5 +603,26 @@ func (protocol *EmulationProtocol) OnVirtualTimePaused(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+VirtualTimePausedChan returns a channel of Emulation.virtualTimePaused events, as an alternative to OnVirtualTimePaused.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *EmulationProtocol) VirtualTimePausedChan(
+	buffer int,
+) (<-chan *emulation.VirtualTimePausedEvent, func()) {
+	eventCh := make(chan *emulation.VirtualTimePausedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnVirtualTimePaused(func(event *emulation.VirtualTimePausedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -0,0 +1,278 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/fedcm"
+)
+
+/*
+FedCMProtocol provides a namespace for the Chrome FedCm protocol methods. The
+FedCm protocol automates the dialogs of the Federated Credential Management API,
+so sign in flows with an identity provider can be tested without user
+interaction.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/
+*/
+type FedCMProtocol struct {
+	Socket Socketer
+}
+
+/*
+ClickDialogButton clicks a button of a FedCM dialog.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-clickDialogButton
+*/
+func (protocol *FedCMProtocol) ClickDialogButton(
+	params *fedcm.ClickDialogButtonParams,
+) <-chan *fedcm.ClickDialogButtonResult {
+	resultChan := make(chan *fedcm.ClickDialogButtonResult)
+	command := NewCommand(protocol.Socket, "FedCm.clickDialogButton", params)
+	result := &fedcm.ClickDialogButtonResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Disable disables the FedCm domain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-disable
+*/
+func (protocol *FedCMProtocol) Disable() <-chan *fedcm.DisableResult {
+	resultChan := make(chan *fedcm.DisableResult)
+	command := NewCommand(protocol.Socket, "FedCm.disable", nil)
+	result := &fedcm.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+DismissDialog dismisses a FedCM dialog, rejecting the
+navigator.credentials.get() promise of the page.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-dismissDialog
+*/
+func (protocol *FedCMProtocol) DismissDialog(
+	params *fedcm.DismissDialogParams,
+) <-chan *fedcm.DismissDialogResult {
+	resultChan := make(chan *fedcm.DismissDialogResult)
+	command := NewCommand(protocol.Socket, "FedCm.dismissDialog", params)
+	result := &fedcm.DismissDialogResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables the FedCm domain. FedCM dialogs are reported with the
+FedCm.dialogShown event and wait for a command instead of the user while it's
+enabled.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-enable
+*/
+func (protocol *FedCMProtocol) Enable(
+	params *fedcm.EnableParams,
+) <-chan *fedcm.EnableResult {
+	resultChan := make(chan *fedcm.EnableResult)
+	command := NewCommand(protocol.Socket, "FedCm.enable", params)
+	result := &fedcm.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OpenURL opens a URL of an account shown in a FedCM dialog, like its terms of
+service.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-openUrl
+*/
+func (protocol *FedCMProtocol) OpenURL(
+	params *fedcm.OpenURLParams,
+) <-chan *fedcm.OpenURLResult {
+	resultChan := make(chan *fedcm.OpenURLResult)
+	command := NewCommand(protocol.Socket, "FedCm.openUrl", params)
+	result := &fedcm.OpenURLResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ResetCooldown resets the cooldown after a dismissed dialog, so the next FedCM
+request shows a dialog again.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-resetCooldown
+*/
+func (protocol *FedCMProtocol) ResetCooldown() <-chan *fedcm.ResetCooldownResult {
+	resultChan := make(chan *fedcm.ResetCooldownResult)
+	command := NewCommand(protocol.Socket, "FedCm.resetCooldown", nil)
+	result := &fedcm.ResetCooldownResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SelectAccount selects an account in a FedCM dialog, as if the user chose it.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#method-selectAccount
+*/
+func (protocol *FedCMProtocol) SelectAccount(
+	params *fedcm.SelectAccountParams,
+) <-chan *fedcm.SelectAccountResult {
+	resultChan := make(chan *fedcm.SelectAccountResult)
+	command := NewCommand(protocol.Socket, "FedCm.selectAccount", params)
+	result := &fedcm.SelectAccountResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnDialogClosed adds a handler to the FedCm.dialogClosed event. Fired when a
+FedCM dialog is closed.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#event-dialogClosed
+*/
+func (protocol *FedCMProtocol) OnDialogClosed(
+	callback func(event *fedcm.DialogClosedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"FedCm.dialogClosed",
+		func(response *Response) {
+			event := &fedcm.DialogClosedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DialogClosedChan returns a channel of FedCm.dialogClosed events, as an
+alternative to OnDialogClosed. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *FedCMProtocol) DialogClosedChan(
+	buffer int,
+) (<-chan *fedcm.DialogClosedEvent, func()) {
+	eventCh := make(chan *fedcm.DialogClosedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDialogClosed(func(event *fedcm.DialogClosedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnDialogShown adds a handler to the FedCm.dialogShown event. Fired when a FedCM
+dialog is shown. The dialog ID is passed to the commands that act on the dialog.
+
+https://chromedevtools.github.io/devtools-protocol/tot/FedCm/#event-dialogShown
+*/
+func (protocol *FedCMProtocol) OnDialogShown(
+	callback func(event *fedcm.DialogShownEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"FedCm.dialogShown",
+		func(response *Response) {
+			event := &fedcm.DialogShownEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DialogShownChan returns a channel of FedCm.dialogShown events, as an alternative
+to OnDialogShown. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to arrive
+in order.
+*/
+func (protocol *FedCMProtocol) DialogShownChan(
+	buffer int,
+) (<-chan *fedcm.DialogShownEvent, func()) {
+	eventCh := make(chan *fedcm.DialogShownEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDialogShown(func(event *fedcm.DialogShownEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
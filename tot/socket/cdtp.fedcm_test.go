@@ -0,0 +1,365 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/fedcm"
+)
+
+func TestFedCmClickDialogButton(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmClickDialogButton")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fedcm.ClickDialogButtonParams{
+		DialogID:     "dialog",
+		DialogButton: fedcm.DialogButton("ConfirmIdpLoginContinue"),
+	}
+	resultChan := mockSocket.FedCM().ClickDialogButton(params)
+	mockResult := &fedcm.ClickDialogButtonResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().ClickDialogButton(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.FedCM().Disable()
+	mockResult := &fedcm.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmDismissDialog(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmDismissDialog")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fedcm.DismissDialogParams{
+		DialogID: "dialog",
+	}
+	resultChan := mockSocket.FedCM().DismissDialog(params)
+	mockResult := &fedcm.DismissDialogResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().DismissDialog(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fedcm.EnableParams{
+		DisableRejectionDelay: true,
+	}
+	resultChan := mockSocket.FedCM().Enable(params)
+	mockResult := &fedcm.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().Enable(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmOpenURL(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmOpenURL")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fedcm.OpenURLParams{
+		DialogID:       "dialog",
+		AccountIndex:   0,
+		AccountURLType: fedcm.AccountURLType("TermsOfService"),
+	}
+	resultChan := mockSocket.FedCM().OpenURL(params)
+	mockResult := &fedcm.OpenURLResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().OpenURL(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmResetCooldown(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmResetCooldown")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.FedCM().ResetCooldown()
+	mockResult := &fedcm.ResetCooldownResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().ResetCooldown()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmSelectAccount(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmSelectAccount")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fedcm.SelectAccountParams{
+		DialogID:     "dialog",
+		AccountIndex: 1,
+	}
+	resultChan := mockSocket.FedCM().SelectAccount(params)
+	mockResult := &fedcm.SelectAccountResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.FedCM().SelectAccount(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmOnDialogClosed(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmOnDialogClosed")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *fedcm.DialogClosedEvent)
+	mockSocket.FedCM().OnDialogClosed(func(eventData *fedcm.DialogClosedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &fedcm.DialogClosedEvent{
+		DialogID: "dialog",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "FedCm.dialogClosed",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if "dialog" != result.DialogID {
+		t.Errorf("Expected dialog, got '%s'", result.DialogID)
+	}
+
+	resultChan = make(chan *fedcm.DialogClosedEvent)
+	mockSocket.FedCM().OnDialogClosed(func(eventData *fedcm.DialogClosedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "FedCm.dialogClosed",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFedCmOnDialogShown(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFedCmOnDialogShown")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *fedcm.DialogShownEvent)
+	mockSocket.FedCM().OnDialogShown(func(eventData *fedcm.DialogShownEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &fedcm.DialogShownEvent{
+		DialogID:   "dialog",
+		DialogType: fedcm.AccountChooser,
+		Accounts: []*fedcm.Account{
+			{AccountID: "1", Email: "user@example.com"},
+		},
+		Title: "Sign in to example.com",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "FedCm.dialogShown",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if fedcm.AccountChooser != result.DialogType || 1 != len(result.Accounts) || "user@example.com" != result.Accounts[0].Email {
+		t.Errorf("Expected an account chooser, got %v", result)
+	}
+
+	resultChan = make(chan *fedcm.DialogShownEvent)
+	mockSocket.FedCM().OnDialogShown(func(eventData *fedcm.DialogShownEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "FedCm.dialogShown",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -0,0 +1,345 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+)
+
+/*
+FetchProtocol provides a namespace for the Chrome Fetch protocol methods. The
+Fetch protocol allows a client to substitute the browser's network layer with
+client code.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/
+*/
+type FetchProtocol struct {
+	Socket Socketer
+}
+
+/*
+ContinueRequest continues the request, optionally modifying some of its
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-continueRequest
+*/
+func (protocol *FetchProtocol) ContinueRequest(
+	params *fetch.ContinueRequestParams,
+) <-chan *fetch.ContinueRequestResult {
+	resultChan := make(chan *fetch.ContinueRequestResult)
+	command := NewCommand(protocol.Socket, "Fetch.continueRequest", params)
+	result := &fetch.ContinueRequestResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ContinueResponse continues loading of the paused response, optionally modifying
+the response headers. If either responseCode or headers are modified, all of
+them must be present.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-continueResponse
+*/
+func (protocol *FetchProtocol) ContinueResponse(
+	params *fetch.ContinueResponseParams,
+) <-chan *fetch.ContinueResponseResult {
+	resultChan := make(chan *fetch.ContinueResponseResult)
+	command := NewCommand(protocol.Socket, "Fetch.continueResponse", params)
+	result := &fetch.ContinueResponseResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ContinueWithAuth continues a request supplying authChallengeResponse following
+an authRequired event.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-continueWithAuth
+*/
+func (protocol *FetchProtocol) ContinueWithAuth(
+	params *fetch.ContinueWithAuthParams,
+) <-chan *fetch.ContinueWithAuthResult {
+	resultChan := make(chan *fetch.ContinueWithAuthResult)
+	command := NewCommand(protocol.Socket, "Fetch.continueWithAuth", params)
+	result := &fetch.ContinueWithAuthResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Disable disables the fetch domain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-disable
+*/
+func (protocol *FetchProtocol) Disable() <-chan *fetch.DisableResult {
+	resultChan := make(chan *fetch.DisableResult)
+	command := NewCommand(protocol.Socket, "Fetch.disable", nil)
+	result := &fetch.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables issuing of requestPaused events. A request will be paused until
+client calls one of failRequest, fulfillRequest or continueRequest.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-enable
+*/
+func (protocol *FetchProtocol) Enable(
+	params *fetch.EnableParams,
+) <-chan *fetch.EnableResult {
+	resultChan := make(chan *fetch.EnableResult)
+	command := NewCommand(protocol.Socket, "Fetch.enable", params)
+	result := &fetch.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+FailRequest causes the request to fail with specified reason.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-failRequest
+*/
+func (protocol *FetchProtocol) FailRequest(
+	params *fetch.FailRequestParams,
+) <-chan *fetch.FailRequestResult {
+	resultChan := make(chan *fetch.FailRequestResult)
+	command := NewCommand(protocol.Socket, "Fetch.failRequest", params)
+	result := &fetch.FailRequestResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+FulfillRequest provides a response to the request.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-fulfillRequest
+*/
+func (protocol *FetchProtocol) FulfillRequest(
+	params *fetch.FulfillRequestParams,
+) <-chan *fetch.FulfillRequestResult {
+	resultChan := make(chan *fetch.FulfillRequestResult)
+	command := NewCommand(protocol.Socket, "Fetch.fulfillRequest", params)
+	result := &fetch.FulfillRequestResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetResponseBody causes the body of the response to be received from the server
+and returned as a single string. May only be issued for a request that is
+paused in the Response stage and is mutually exclusive with
+TakeResponseBodyAsStream. Calling other methods that affect the request or
+disabling fetch domain before body is received results in an undefined
+behavior.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-getResponseBody
+*/
+func (protocol *FetchProtocol) GetResponseBody(
+	params *fetch.GetResponseBodyParams,
+) <-chan *fetch.GetResponseBodyResult {
+	resultChan := make(chan *fetch.GetResponseBodyResult)
+	command := NewCommand(protocol.Socket, "Fetch.getResponseBody", params)
+	result := &fetch.GetResponseBodyResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+TakeResponseBodyAsStream returns a handle to the stream representing the
+response body. The request must be paused in the HeadersReceived stage. Note
+that after this command the request can't be continued as is -- client either
+needs to cancel it or to provide the response body. The stream only supports
+sequential read, IO.read will fail if the position is specified. This method is
+mutually exclusive with GetResponseBody. Calling other methods that affect the
+request or disabling fetch domain before body is received results in an
+undefined behavior.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-takeResponseBodyAsStream
+*/
+func (protocol *FetchProtocol) TakeResponseBodyAsStream(
+	params *fetch.TakeResponseBodyAsStreamParams,
+) <-chan *fetch.TakeResponseBodyAsStreamResult {
+	resultChan := make(chan *fetch.TakeResponseBodyAsStreamResult)
+	command := NewCommand(protocol.Socket, "Fetch.takeResponseBodyAsStream", params)
+	result := &fetch.TakeResponseBodyAsStreamResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnAuthRequired adds a handler to the Fetch.authRequired event.
+Fetch.authRequired fires when an authentication challenge is received for a
+request, if Fetch.enable was called with HandleAuthRequests. The request is
+paused until ContinueWithAuth is called.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#event-authRequired
+*/
+func (protocol *FetchProtocol) OnAuthRequired(
+	callback func(event *fetch.AuthRequiredEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Fetch.authRequired",
+		func(response *Response) {
+			event := &fetch.AuthRequiredEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AuthRequiredChan returns a channel of Fetch.authRequired events, as an alternative to OnAuthRequired.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *FetchProtocol) AuthRequiredChan(
+	buffer int,
+) (<-chan *fetch.AuthRequiredEvent, func()) {
+	eventCh := make(chan *fetch.AuthRequiredEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAuthRequired(func(event *fetch.AuthRequiredEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnRequestPaused adds a handler to the Fetch.requestPaused event.
+Fetch.requestPaused fires when a request matching the patterns passed to
+Fetch.enable is paused. The request must be resumed with one of
+ContinueRequest, ContinueResponse, FailRequest or FulfillRequest.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#event-requestPaused
+*/
+func (protocol *FetchProtocol) OnRequestPaused(
+	callback func(event *fetch.RequestPausedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Fetch.requestPaused",
+		func(response *Response) {
+			event := &fetch.RequestPausedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RequestPausedChan returns a channel of Fetch.requestPaused events, as an alternative to OnRequestPaused.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *FetchProtocol) RequestPausedChan(
+	buffer int,
+) (<-chan *fetch.RequestPausedEvent, func()) {
+	eventCh := make(chan *fetch.RequestPausedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRequestPaused(func(event *fetch.RequestPausedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
@@ -0,0 +1,485 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+func TestFetchContinueRequest(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchContinueRequest")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.ContinueRequestParams{
+		RequestID: "RequestID",
+		Headers: []*fetch.HeaderEntry{{
+			Name:  "X-Signature",
+			Value: "signature",
+		}},
+	}
+	resultChan := mockSocket.Fetch().ContinueRequest(params)
+	mockResult := &fetch.ContinueRequestResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().ContinueRequest(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchContinueResponse(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchContinueResponse")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.ContinueResponseParams{
+		RequestID:    "RequestID",
+		ResponseCode: 200,
+		ResponseHeaders: []*fetch.HeaderEntry{{
+			Name:  "Content-Type",
+			Value: "text/plain",
+		}},
+	}
+	resultChan := mockSocket.Fetch().ContinueResponse(params)
+	mockResult := &fetch.ContinueResponseResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().ContinueResponse(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchContinueWithAuth(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchContinueWithAuth")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.ContinueWithAuthParams{
+		RequestID: "RequestID",
+		AuthChallengeResponse: &fetch.AuthChallengeResponse{
+			Response: network.ChallengeResponse.ProvideCredentials,
+			Username: "user",
+			Password: "secret",
+		},
+	}
+	resultChan := mockSocket.Fetch().ContinueWithAuth(params)
+	mockResult := &fetch.ContinueWithAuthResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().ContinueWithAuth(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Fetch().Disable()
+	mockResult := &fetch.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.EnableParams{
+		Patterns: []*fetch.RequestPattern{{
+			URLPattern:   "*",
+			RequestStage: fetch.RequestStage.Request,
+		}},
+	}
+	resultChan := mockSocket.Fetch().Enable(params)
+	mockResult := &fetch.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().Enable(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchFailRequest(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchFailRequest")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.FailRequestParams{
+		RequestID:   "RequestID",
+		ErrorReason: network.ErrorReason.Failed,
+	}
+	resultChan := mockSocket.Fetch().FailRequest(params)
+	mockResult := &fetch.FailRequestResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().FailRequest(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchFulfillRequest(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchFulfillRequest")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.FulfillRequestParams{
+		RequestID:    "RequestID",
+		ResponseCode: 200,
+		Body:         "Ym9keQ==",
+	}
+	resultChan := mockSocket.Fetch().FulfillRequest(params)
+	mockResult := &fetch.FulfillRequestResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Fetch().FulfillRequest(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchGetResponseBody(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchGetResponseBody")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.GetResponseBodyParams{
+		RequestID: "RequestID",
+	}
+	resultChan := mockSocket.Fetch().GetResponseBody(params)
+	mockResult := &fetch.GetResponseBodyResult{
+		Body:          "aGVsbG8=",
+		Base64Encoded: true,
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if mockResult.Body != result.Body || !result.Base64Encoded {
+		t.Errorf("Expected %v, got %v", mockResult, result)
+	}
+
+	resultChan = mockSocket.Fetch().GetResponseBody(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchTakeResponseBodyAsStream(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchTakeResponseBodyAsStream")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &fetch.TakeResponseBodyAsStreamParams{
+		RequestID: "RequestID",
+	}
+	resultChan := mockSocket.Fetch().TakeResponseBodyAsStream(params)
+	mockResult := &fetch.TakeResponseBodyAsStreamResult{
+		Stream: "stream-1",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if mockResult.Stream != result.Stream {
+		t.Errorf("Expected %s, got %s", mockResult.Stream, result.Stream)
+	}
+
+	resultChan = mockSocket.Fetch().TakeResponseBodyAsStream(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchOnAuthRequired(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchOnAuthRequired")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *fetch.AuthRequiredEvent)
+	mockSocket.Fetch().OnAuthRequired(func(eventData *fetch.AuthRequiredEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &fetch.AuthRequiredEvent{
+		RequestID: "RequestID",
+		Request: &network.Request{
+			URL:    "https://example.com/private",
+			Method: "GET",
+		},
+		FrameID: "FrameID",
+		AuthChallenge: &fetch.AuthChallenge{
+			Source: network.Source.Server,
+			Origin: "https://example.com",
+			Scheme: "basic",
+			Realm:  "private",
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Fetch.authRequired",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.AuthChallenge || network.Source.Server != result.AuthChallenge.Source || "private" != result.AuthChallenge.Realm {
+		t.Errorf("Expected challenge %v, got %v", mockResult.AuthChallenge, result.AuthChallenge)
+	}
+
+	resultChan = make(chan *fetch.AuthRequiredEvent)
+	mockSocket.Fetch().OnAuthRequired(func(eventData *fetch.AuthRequiredEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Fetch.authRequired",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestFetchOnRequestPaused(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestFetchOnRequestPaused")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *fetch.RequestPausedEvent)
+	mockSocket.Fetch().OnRequestPaused(func(eventData *fetch.RequestPausedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &fetch.RequestPausedEvent{
+		RequestID: "RequestID",
+		Request: &network.Request{
+			URL:     "https://example.com/",
+			Method:  "GET",
+			Headers: network.Headers{"Accept": "*/*"},
+		},
+		FrameID: "FrameID",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Fetch.requestPaused",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if mockResult.RequestID != result.RequestID {
+		t.Errorf("Expected %s, got %s", mockResult.RequestID, result.RequestID)
+	}
+	if nil == result.Request || mockResult.Request.URL != result.Request.URL {
+		t.Errorf("Expected request %v, got %v", mockResult.Request, result.Request)
+	}
+
+	resultChan = make(chan *fetch.RequestPausedEvent)
+	mockSocket.Fetch().OnRequestPaused(func(eventData *fetch.RequestPausedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Fetch.requestPaused",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
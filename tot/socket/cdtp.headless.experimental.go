@@ -34,7 +34,7 @@ func (protocol *HeadlessExperimentalProtocol) BeginFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -58,7 +58,7 @@ func (protocol *HeadlessExperimentalProtocol) Disable() <-chan *experimental.Dis
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -80,7 +80,7 @@ func (protocol *HeadlessExperimentalProtocol) Enable() <-chan *experimental.Enab
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -99,7 +99,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#eve
 */
 func (protocol *HeadlessExperimentalProtocol) OnMainFrameReadyForScreenshots(
 	callback func(event *experimental.MainFrameReadyForScreenshotsEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeadlessExperimental.mainFrameReadyForScreenshots",
 		func(response *Response) {
@@ -111,7 +111,28 @@ func (protocol *HeadlessExperimentalProtocol) OnMainFrameReadyForScreenshots(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+MainFrameReadyForScreenshotsChan returns a channel of HeadlessExperimental.mainFrameReadyForScreenshots events, as an alternative to OnMainFrameReadyForScreenshots.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeadlessExperimentalProtocol) MainFrameReadyForScreenshotsChan(
+	buffer int,
+) (<-chan *experimental.MainFrameReadyForScreenshotsEvent, func()) {
+	eventCh := make(chan *experimental.MainFrameReadyForScreenshotsEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnMainFrameReadyForScreenshots(func(event *experimental.MainFrameReadyForScreenshotsEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -123,7 +144,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#eve
 */
 func (protocol *HeadlessExperimentalProtocol) OnNeedsBeginFramesChanged(
 	callback func(event *experimental.NeedsBeginFramesChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeadlessExperimental.needsBeginFramesChanged",
 		func(response *Response) {
@@ -135,5 +156,26 @@ func (protocol *HeadlessExperimentalProtocol) OnNeedsBeginFramesChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+NeedsBeginFramesChangedChan returns a channel of HeadlessExperimental.needsBeginFramesChanged events, as an alternative to OnNeedsBeginFramesChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeadlessExperimentalProtocol) NeedsBeginFramesChangedChan(
+	buffer int,
+) (<-chan *experimental.NeedsBeginFramesChangedEvent, func()) {
+	eventCh := make(chan *experimental.NeedsBeginFramesChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnNeedsBeginFramesChanged(func(event *experimental.NeedsBeginFramesChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
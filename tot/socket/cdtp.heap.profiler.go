@@ -32,7 +32,7 @@ func (protocol *HeapProfilerProtocol) AddInspectedHeapObject(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,7 +55,7 @@ func (protocol *HeapProfilerProtocol) CollectGarbage() <-chan *profiler.CollectG
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -77,7 +77,7 @@ func (protocol *HeapProfilerProtocol) Disable() <-chan *profiler.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -99,7 +99,7 @@ func (protocol *HeapProfilerProtocol) Enable() <-chan *profiler.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -124,7 +124,7 @@ func (protocol *HeapProfilerProtocol) GetHeapObjectID(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -151,7 +151,7 @@ func (protocol *HeapProfilerProtocol) GetObjectByHeapObjectID(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -178,7 +178,7 @@ func (protocol *HeapProfilerProtocol) GetSamplingProfile(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -203,7 +203,7 @@ func (protocol *HeapProfilerProtocol) StartSampling(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -228,7 +228,7 @@ func (protocol *HeapProfilerProtocol) StartTrackingHeapObjects(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -253,7 +253,7 @@ func (protocol *HeapProfilerProtocol) StopSampling(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -278,7 +278,7 @@ func (protocol *HeapProfilerProtocol) StopTrackingHeapObjects(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -303,7 +303,7 @@ func (protocol *HeapProfilerProtocol) TakeHeapSnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -321,7 +321,7 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnAddHeapSnapshotChunk(
 	callback func(event *profiler.AddHeapSnapshotChunkEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeapProfiler.addHeapSnapshotChunk",
 		func(response *Response) {
@@ -333,7 +333,28 @@ func (protocol *HeapProfilerProtocol) OnAddHeapSnapshotChunk(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AddHeapSnapshotChunkChan returns a channel of HeapProfiler.addHeapSnapshotChunk events, as an alternative to OnAddHeapSnapshotChunk.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeapProfilerProtocol) AddHeapSnapshotChunkChan(
+	buffer int,
+) (<-chan *profiler.AddHeapSnapshotChunkEvent, func()) {
+	eventCh := make(chan *profiler.AddHeapSnapshotChunkEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAddHeapSnapshotChunk(func(event *profiler.AddHeapSnapshotChunkEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -345,7 +366,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-heapS
 */
 func (protocol *HeapProfilerProtocol) OnHeapStatsUpdate(
 	callback func(event *profiler.HeapStatsUpdateEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeapProfiler.heapStatsUpdate",
 		func(response *Response) {
@@ -357,7 +378,28 @@ func (protocol *HeapProfilerProtocol) OnHeapStatsUpdate(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+HeapStatsUpdateChan returns a channel of HeapProfiler.heapStatsUpdate events, as an alternative to OnHeapStatsUpdate.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeapProfilerProtocol) HeapStatsUpdateChan(
+	buffer int,
+) (<-chan *profiler.HeapStatsUpdateEvent, func()) {
+	eventCh := make(chan *profiler.HeapStatsUpdateEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnHeapStatsUpdate(func(event *profiler.HeapStatsUpdateEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -371,7 +413,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-lastS
 */
 func (protocol *HeapProfilerProtocol) OnLastSeenObjectID(
 	callback func(event *profiler.LastSeenObjectIDEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeapProfiler.lastSeenObjectID",
 		func(response *Response) {
@@ -383,7 +425,28 @@ func (protocol *HeapProfilerProtocol) OnLastSeenObjectID(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LastSeenObjectIDChan returns a channel of HeapProfiler.lastSeenObjectID events, as an alternative to OnLastSeenObjectID.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeapProfilerProtocol) LastSeenObjectIDChan(
+	buffer int,
+) (<-chan *profiler.LastSeenObjectIDEvent, func()) {
+	eventCh := make(chan *profiler.LastSeenObjectIDEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLastSeenObjectID(func(event *profiler.LastSeenObjectIDEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -396,7 +459,7 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnReportHeapSnapshotProgress(
 	callback func(event *profiler.ReportHeapSnapshotProgressEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeapProfiler.reportHeapSnapshotProgress",
 		func(response *Response) {
@@ -408,7 +471,28 @@ func (protocol *HeapProfilerProtocol) OnReportHeapSnapshotProgress(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ReportHeapSnapshotProgressChan returns a channel of HeapProfiler.reportHeapSnapshotProgress events, as an alternative to OnReportHeapSnapshotProgress.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeapProfilerProtocol) ReportHeapSnapshotProgressChan(
+	buffer int,
+) (<-chan *profiler.ReportHeapSnapshotProgressEvent, func()) {
+	eventCh := make(chan *profiler.ReportHeapSnapshotProgressEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnReportHeapSnapshotProgress(func(event *profiler.ReportHeapSnapshotProgressEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -419,7 +503,7 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnResetProfiles(
 	callback func(event *profiler.ResetProfilesEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"HeapProfiler.resetProfiles",
 		func(response *Response) {
@@ -431,5 +515,26 @@ func (protocol *HeapProfilerProtocol) OnResetProfiles(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ResetProfilesChan returns a channel of HeapProfiler.resetProfiles events, as an alternative to OnResetProfiles.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *HeapProfilerProtocol) ResetProfilesChan(
+	buffer int,
+) (<-chan *profiler.ResetProfilesEvent, func()) {
+	eventCh := make(chan *profiler.ResetProfilesEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnResetProfiles(func(event *profiler.ResetProfilesEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
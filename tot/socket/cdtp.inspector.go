@@ -0,0 +1,196 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/inspector"
+)
+
+/*
+InspectorProtocol provides a namespace for the Chrome Inspector protocol
+methods. The Inspector protocol reports when the connection to a target is
+terminated, for example because DevTools was opened for it, and when the target
+crashes.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/
+*/
+type InspectorProtocol struct {
+	Socket Socketer
+}
+
+/*
+Disable disables inspector domain notifications.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#method-disable
+*/
+func (protocol *InspectorProtocol) Disable() <-chan *inspector.DisableResult {
+	resultChan := make(chan *inspector.DisableResult)
+	command := NewCommand(protocol.Socket, "Inspector.disable", nil)
+	result := &inspector.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables inspector domain notifications.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#method-enable
+*/
+func (protocol *InspectorProtocol) Enable() <-chan *inspector.EnableResult {
+	resultChan := make(chan *inspector.EnableResult)
+	command := NewCommand(protocol.Socket, "Inspector.enable", nil)
+	result := &inspector.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnDetached adds a handler to the Inspector.detached event. Inspector.detached
+fires when remote debugging connection is about to be terminated. Contains
+detach reason, see DetachedEvent.DetachReason.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-detached
+*/
+func (protocol *InspectorProtocol) OnDetached(
+	callback func(event *inspector.DetachedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Inspector.detached",
+		func(response *Response) {
+			event := &inspector.DetachedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DetachedChan returns a channel of Inspector.detached events, as an alternative to OnDetached.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *InspectorProtocol) DetachedChan(
+	buffer int,
+) (<-chan *inspector.DetachedEvent, func()) {
+	eventCh := make(chan *inspector.DetachedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDetached(func(event *inspector.DetachedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnTargetCrashed adds a handler to the Inspector.targetCrashed event. Inspector.targetCrashed
+fires when debugging target has crashed.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-targetCrashed
+*/
+func (protocol *InspectorProtocol) OnTargetCrashed(
+	callback func(event *inspector.TargetCrashedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Inspector.targetCrashed",
+		func(response *Response) {
+			event := &inspector.TargetCrashedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TargetCrashedChan returns a channel of Inspector.targetCrashed events, as an alternative to OnTargetCrashed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *InspectorProtocol) TargetCrashedChan(
+	buffer int,
+) (<-chan *inspector.TargetCrashedEvent, func()) {
+	eventCh := make(chan *inspector.TargetCrashedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTargetCrashed(func(event *inspector.TargetCrashedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnTargetReloadedAfterCrash adds a handler to the Inspector.targetReloadedAfterCrash event. Inspector.targetReloadedAfterCrash
+fires when debugging target has reloaded after crash.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Inspector/#event-targetReloadedAfterCrash
+*/
+func (protocol *InspectorProtocol) OnTargetReloadedAfterCrash(
+	callback func(event *inspector.TargetReloadedAfterCrashEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Inspector.targetReloadedAfterCrash",
+		func(response *Response) {
+			event := &inspector.TargetReloadedAfterCrashEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TargetReloadedAfterCrashChan returns a channel of Inspector.targetReloadedAfterCrash events, as an alternative to OnTargetReloadedAfterCrash.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *InspectorProtocol) TargetReloadedAfterCrashChan(
+	buffer int,
+) (<-chan *inspector.TargetReloadedAfterCrashEvent, func()) {
+	eventCh := make(chan *inspector.TargetReloadedAfterCrashEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTargetReloadedAfterCrash(func(event *inspector.TargetReloadedAfterCrashEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
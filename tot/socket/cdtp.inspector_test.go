@@ -0,0 +1,208 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/inspector"
+)
+
+func TestInspectorDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestInspectorDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Inspector().Disable()
+	mockResult := &inspector.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Inspector().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestInspectorEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestInspectorEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Inspector().Enable()
+	mockResult := &inspector.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Inspector().Enable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestInspectorOnDetached(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestInspectorOnDetached")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *inspector.DetachedEvent)
+	mockSocket.Inspector().OnDetached(func(eventData *inspector.DetachedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &inspector.DetachedEvent{
+		Reason: "replaced_with_devtools",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Inspector.detached",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if inspector.DetachReason.ReplacedWithDevtools != result.DetachReason() {
+		t.Errorf("Expected %s, got %s", inspector.DetachReason.ReplacedWithDevtools, result.DetachReason())
+	}
+
+	resultChan = make(chan *inspector.DetachedEvent)
+	mockSocket.Inspector().OnDetached(func(eventData *inspector.DetachedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Inspector.detached",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestInspectorOnTargetCrashed(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestInspectorOnTargetCrashed")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *inspector.TargetCrashedEvent)
+	mockSocket.Inspector().OnTargetCrashed(func(eventData *inspector.TargetCrashedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &inspector.TargetCrashedEvent{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Inspector.targetCrashed",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+
+	resultChan = make(chan *inspector.TargetCrashedEvent)
+	mockSocket.Inspector().OnTargetCrashed(func(eventData *inspector.TargetCrashedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Inspector.targetCrashed",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestInspectorOnTargetReloadedAfterCrash(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestInspectorOnTargetReloadedAfterCrash")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *inspector.TargetReloadedAfterCrashEvent)
+	mockSocket.Inspector().OnTargetReloadedAfterCrash(func(eventData *inspector.TargetReloadedAfterCrashEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &inspector.TargetReloadedAfterCrashEvent{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Inspector.targetReloadedAfterCrash",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+
+	resultChan = make(chan *inspector.TargetReloadedAfterCrashEvent)
+	mockSocket.Inspector().OnTargetReloadedAfterCrash(func(eventData *inspector.TargetReloadedAfterCrashEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Inspector.targetReloadedAfterCrash",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -31,7 +31,7 @@ func (protocol *LayerTreeProtocol) CompositingReasons(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -55,7 +55,7 @@ func (protocol *LayerTreeProtocol) Disable() <-chan *tree.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -77,7 +77,7 @@ func (protocol *LayerTreeProtocol) Enable() <-chan *tree.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -101,7 +101,7 @@ func (protocol *LayerTreeProtocol) LoadSnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -127,7 +127,7 @@ func (protocol *LayerTreeProtocol) MakeSnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -153,7 +153,7 @@ func (protocol *LayerTreeProtocol) ProfileSnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -179,7 +179,7 @@ func (protocol *LayerTreeProtocol) ReleaseSnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -203,7 +203,7 @@ func (protocol *LayerTreeProtocol) ReplaySnapshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -229,7 +229,7 @@ func (protocol *LayerTreeProtocol) SnapshotCommandLog(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -248,19 +248,46 @@ https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerPai
 */
 func (protocol *LayerTreeProtocol) OnLayerPainted(
 	callback func(event *tree.LayerPaintedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"LayerTree.layerPainted",
 		func(response *Response) {
-			event := &tree.LayerPaintedEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireLayerPaintedEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseLayerPaintedEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LayerPaintedChan returns a channel of LayerTree.layerPainted events, as an alternative to OnLayerPainted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *LayerTreeProtocol) LayerPaintedChan(
+	buffer int,
+) (<-chan *tree.LayerPaintedEvent, func()) {
+	eventCh := make(chan *tree.LayerPaintedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLayerPainted(func(event *tree.LayerPaintedEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -271,17 +298,44 @@ https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerTre
 */
 func (protocol *LayerTreeProtocol) OnLayerTreeDidChange(
 	callback func(event *tree.DidChangeEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"LayerTree.layerTreeDidChange",
 		func(response *Response) {
-			event := &tree.DidChangeEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireLayerTreeDidChangeEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseLayerTreeDidChangeEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LayerTreeDidChangeChan returns a channel of LayerTree.layerTreeDidChange events, as an alternative to OnLayerTreeDidChange.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *LayerTreeProtocol) LayerTreeDidChangeChan(
+	buffer int,
+) (<-chan *tree.DidChangeEvent, func()) {
+	eventCh := make(chan *tree.DidChangeEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLayerTreeDidChange(func(event *tree.DidChangeEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -29,7 +29,7 @@ func (protocol *LogProtocol) Clear() <-chan *log.ClearResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -52,7 +52,7 @@ func (protocol *LogProtocol) Disable() <-chan *log.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,7 +75,7 @@ func (protocol *LogProtocol) Enable() <-chan *log.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -99,7 +99,7 @@ func (protocol *LogProtocol) StartViolationsReport(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -121,7 +121,7 @@ func (protocol *LogProtocol) StopViolationsReport() <-chan *log.StopViolationsRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -138,7 +138,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Log/#event-entryAdded
 */
 func (protocol *LogProtocol) OnEntryAdded(
 	callback func(event *log.EntryAddedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Log.entryAdded",
 		func(response *Response) {
@@ -150,5 +150,26 @@ func (protocol *LogProtocol) OnEntryAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+EntryAddedChan returns a channel of Log.entryAdded events, as an alternative to OnEntryAdded.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *LogProtocol) EntryAddedChan(
+	buffer int,
+) (<-chan *log.EntryAddedEvent, func()) {
+	eventCh := make(chan *log.EntryAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnEntryAdded(func(event *log.EntryAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
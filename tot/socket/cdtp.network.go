@@ -32,7 +32,7 @@ func (protocol *NetworkProtocol) CanClearBrowserCache() <-chan *network.CanClear
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -57,7 +57,7 @@ func (protocol *NetworkProtocol) CanClearBrowserCookies() <-chan *network.CanCle
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -82,7 +82,7 @@ func (protocol *NetworkProtocol) CanEmulateConditions() <-chan *network.CanEmula
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -106,7 +106,7 @@ func (protocol *NetworkProtocol) ClearBrowserCache() <-chan *network.ClearBrowse
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -128,7 +128,7 @@ func (protocol *NetworkProtocol) ClearBrowserCookies() <-chan *network.ClearBrow
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -157,7 +157,7 @@ func (protocol *NetworkProtocol) ContinueInterceptedRequest(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -182,7 +182,7 @@ func (protocol *NetworkProtocol) DeleteCookies(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -205,7 +205,7 @@ func (protocol *NetworkProtocol) Disable() <-chan *network.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -229,7 +229,7 @@ func (protocol *NetworkProtocol) EmulateConditions(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -254,7 +254,7 @@ func (protocol *NetworkProtocol) Enable(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -277,7 +277,7 @@ func (protocol *NetworkProtocol) GetAllCookies() <-chan *network.GetAllCookiesRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -304,7 +304,7 @@ func (protocol *NetworkProtocol) GetCertificate(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -331,7 +331,34 @@ func (protocol *NetworkProtocol) GetCookies(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetRequestPostData returns post data sent with the request. Returns an error
+when no data was sent with the request.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getRequestPostData
+*/
+func (protocol *NetworkProtocol) GetRequestPostData(
+	params *network.GetRequestPostDataParams,
+) <-chan *network.GetRequestPostDataResult {
+	resultChan := make(chan *network.GetRequestPostDataResult)
+	command := NewCommand(protocol.Socket, "Network.getRequestPostData", params)
+	result := &network.GetRequestPostDataResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -357,7 +384,7 @@ func (protocol *NetworkProtocol) GetResponseBody(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -385,7 +412,7 @@ func (protocol *NetworkProtocol) GetResponseBodyForInterception(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -413,7 +440,7 @@ func (protocol *NetworkProtocol) ReplayXHR(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -438,7 +465,7 @@ func (protocol *NetworkProtocol) SearchInResponseBody(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -465,7 +492,7 @@ func (protocol *NetworkProtocol) SetBlockedURLs(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -490,7 +517,7 @@ func (protocol *NetworkProtocol) SetBypassServiceWorker(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -515,7 +542,7 @@ func (protocol *NetworkProtocol) SetCacheDisabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -540,7 +567,7 @@ func (protocol *NetworkProtocol) SetCookie(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -551,6 +578,32 @@ func (protocol *NetworkProtocol) SetCookie(
 	return resultChan
 }
 
+/*
+SetCookieControls sets controls for third-party cookie access. Page reload is
+required before the new cookie behavior will be observed.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-setCookieControls
+EXPERIMENTAL.
+*/
+func (protocol *NetworkProtocol) SetCookieControls(
+	params *network.SetCookieControlsParams,
+) <-chan *network.SetCookieControlsResult {
+	resultChan := make(chan *network.SetCookieControlsResult)
+	command := NewCommand(protocol.Socket, "Network.setCookieControls", params)
+	result := &network.SetCookieControlsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 SetCookies sets given cookies.
 
@@ -566,7 +619,7 @@ func (protocol *NetworkProtocol) SetCookies(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -591,7 +644,7 @@ func (protocol *NetworkProtocol) SetDataSizeLimitsForTest(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -616,7 +669,7 @@ func (protocol *NetworkProtocol) SetExtraHTTPHeaders(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -642,7 +695,7 @@ func (protocol *NetworkProtocol) SetRequestInterception(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -666,7 +719,7 @@ func (protocol *NetworkProtocol) SetUserAgentOverride(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -683,19 +736,46 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-dataReceiv
 */
 func (protocol *NetworkProtocol) OnDataReceived(
 	callback func(event *network.DataReceivedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.dataReceived",
 		func(response *Response) {
-			event := &network.DataReceivedEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireDataReceivedEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseDataReceivedEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DataReceivedChan returns a channel of Network.dataReceived events, as an alternative to OnDataReceived.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) DataReceivedChan(
+	buffer int,
+) (<-chan *network.DataReceivedEvent, func()) {
+	eventCh := make(chan *network.DataReceivedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDataReceived(func(event *network.DataReceivedEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -707,7 +787,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-eventSourc
 */
 func (protocol *NetworkProtocol) OnEventSourceMessageReceived(
 	callback func(event *network.EventSourceMessageReceivedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.eventSourceMessageReceived",
 		func(response *Response) {
@@ -719,7 +799,28 @@ func (protocol *NetworkProtocol) OnEventSourceMessageReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+EventSourceMessageReceivedChan returns a channel of Network.eventSourceMessageReceived events, as an alternative to OnEventSourceMessageReceived.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) EventSourceMessageReceivedChan(
+	buffer int,
+) (<-chan *network.EventSourceMessageReceivedEvent, func()) {
+	eventCh := make(chan *network.EventSourceMessageReceivedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnEventSourceMessageReceived(func(event *network.EventSourceMessageReceivedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -730,7 +831,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFai
 */
 func (protocol *NetworkProtocol) OnLoadingFailed(
 	callback func(event *network.LoadingFailedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.loadingFailed",
 		func(response *Response) {
@@ -742,7 +843,28 @@ func (protocol *NetworkProtocol) OnLoadingFailed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LoadingFailedChan returns a channel of Network.loadingFailed events, as an alternative to OnLoadingFailed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) LoadingFailedChan(
+	buffer int,
+) (<-chan *network.LoadingFailedEvent, func()) {
+	eventCh := make(chan *network.LoadingFailedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLoadingFailed(func(event *network.LoadingFailedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -753,19 +875,46 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFin
 */
 func (protocol *NetworkProtocol) OnLoadingFinished(
 	callback func(event *network.LoadingFinishedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.loadingFinished",
 		func(response *Response) {
-			event := &network.LoadingFinishedEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireLoadingFinishedEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseLoadingFinishedEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LoadingFinishedChan returns a channel of Network.loadingFinished events, as an alternative to OnLoadingFinished.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) LoadingFinishedChan(
+	buffer int,
+) (<-chan *network.LoadingFinishedEvent, func()) {
+	eventCh := make(chan *network.LoadingFinishedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLoadingFinished(func(event *network.LoadingFinishedEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -778,7 +927,7 @@ EXPERIMENTAL.
 */
 func (protocol *NetworkProtocol) OnRequestIntercepted(
 	callback func(event *network.RequestInterceptedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.requestIntercepted",
 		func(response *Response) {
@@ -790,7 +939,28 @@ func (protocol *NetworkProtocol) OnRequestIntercepted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RequestInterceptedChan returns a channel of Network.requestIntercepted events, as an alternative to OnRequestIntercepted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) RequestInterceptedChan(
+	buffer int,
+) (<-chan *network.RequestInterceptedEvent, func()) {
+	eventCh := make(chan *network.RequestInterceptedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRequestIntercepted(func(event *network.RequestInterceptedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -802,7 +972,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestSer
 */
 func (protocol *NetworkProtocol) OnRequestServedFromCache(
 	callback func(event *network.RequestServedFromCacheEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.requestServedFromCache",
 		func(response *Response) {
@@ -814,7 +984,28 @@ func (protocol *NetworkProtocol) OnRequestServedFromCache(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RequestServedFromCacheChan returns a channel of Network.requestServedFromCache events, as an alternative to OnRequestServedFromCache.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) RequestServedFromCacheChan(
+	buffer int,
+) (<-chan *network.RequestServedFromCacheEvent, func()) {
+	eventCh := make(chan *network.RequestServedFromCacheEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRequestServedFromCache(func(event *network.RequestServedFromCacheEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -825,19 +1016,46 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestWil
 */
 func (protocol *NetworkProtocol) OnRequestWillBeSent(
 	callback func(event *network.RequestWillBeSentEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.requestWillBeSent",
 		func(response *Response) {
-			event := &network.RequestWillBeSentEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireRequestWillBeSentEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseRequestWillBeSentEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RequestWillBeSentChan returns a channel of Network.requestWillBeSent events, as an alternative to OnRequestWillBeSent.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) RequestWillBeSentChan(
+	buffer int,
+) (<-chan *network.RequestWillBeSentEvent, func()) {
+	eventCh := make(chan *network.RequestWillBeSentEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRequestWillBeSent(func(event *network.RequestWillBeSentEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -850,7 +1068,7 @@ EXPERIMENTAL.
 */
 func (protocol *NetworkProtocol) OnResourceChangedPriority(
 	callback func(event *network.ResourceChangedPriorityEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.resourceChangedPriority",
 		func(response *Response) {
@@ -862,7 +1080,28 @@ func (protocol *NetworkProtocol) OnResourceChangedPriority(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ResourceChangedPriorityChan returns a channel of Network.resourceChangedPriority events, as an alternative to OnResourceChangedPriority.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) ResourceChangedPriorityChan(
+	buffer int,
+) (<-chan *network.ResourceChangedPriorityEvent, func()) {
+	eventCh := make(chan *network.ResourceChangedPriorityEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnResourceChangedPriority(func(event *network.ResourceChangedPriorityEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -873,19 +1112,46 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-responseRe
 */
 func (protocol *NetworkProtocol) OnResponseReceived(
 	callback func(event *network.ResponseReceivedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.responseReceived",
 		func(response *Response) {
-			event := &network.ResponseReceivedEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireResponseReceivedEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseResponseReceivedEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ResponseReceivedChan returns a channel of Network.responseReceived events, as an alternative to OnResponseReceived.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) ResponseReceivedChan(
+	buffer int,
+) (<-chan *network.ResponseReceivedEvent, func()) {
+	eventCh := make(chan *network.ResponseReceivedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnResponseReceived(func(event *network.ResponseReceivedEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -896,7 +1162,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketC
 */
 func (protocol *NetworkProtocol) OnWebSocketClosed(
 	callback func(event *network.WebSocketClosedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketClosed",
 		func(response *Response) {
@@ -908,7 +1174,28 @@ func (protocol *NetworkProtocol) OnWebSocketClosed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketClosedChan returns a channel of Network.webSocketClosed events, as an alternative to OnWebSocketClosed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketClosedChan(
+	buffer int,
+) (<-chan *network.WebSocketClosedEvent, func()) {
+	eventCh := make(chan *network.WebSocketClosedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketClosed(func(event *network.WebSocketClosedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -919,7 +1206,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketC
 */
 func (protocol *NetworkProtocol) OnWebSocketCreated(
 	callback func(event *network.WebSocketCreatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketCreated",
 		func(response *Response) {
@@ -931,7 +1218,28 @@ func (protocol *NetworkProtocol) OnWebSocketCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketCreatedChan returns a channel of Network.webSocketCreated events, as an alternative to OnWebSocketCreated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketCreatedChan(
+	buffer int,
+) (<-chan *network.WebSocketCreatedEvent, func()) {
+	eventCh := make(chan *network.WebSocketCreatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketCreated(func(event *network.WebSocketCreatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -942,7 +1250,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameError(
 	callback func(event *network.WebSocketFrameErrorEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketFrameError",
 		func(response *Response) {
@@ -954,7 +1262,28 @@ func (protocol *NetworkProtocol) OnWebSocketFrameError(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketFrameErrorChan returns a channel of Network.webSocketFrameError events, as an alternative to OnWebSocketFrameError.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketFrameErrorChan(
+	buffer int,
+) (<-chan *network.WebSocketFrameErrorEvent, func()) {
+	eventCh := make(chan *network.WebSocketFrameErrorEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketFrameError(func(event *network.WebSocketFrameErrorEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -965,7 +1294,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameReceived(
 	callback func(event *network.WebSocketFrameReceivedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketFrameReceived",
 		func(response *Response) {
@@ -977,7 +1306,28 @@ func (protocol *NetworkProtocol) OnWebSocketFrameReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketFrameReceivedChan returns a channel of Network.webSocketFrameReceived events, as an alternative to OnWebSocketFrameReceived.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketFrameReceivedChan(
+	buffer int,
+) (<-chan *network.WebSocketFrameReceivedEvent, func()) {
+	eventCh := make(chan *network.WebSocketFrameReceivedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketFrameReceived(func(event *network.WebSocketFrameReceivedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -988,7 +1338,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameSent(
 	callback func(event *network.WebSocketFrameSentEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketFrameSent",
 		func(response *Response) {
@@ -1000,7 +1350,28 @@ func (protocol *NetworkProtocol) OnWebSocketFrameSent(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketFrameSentChan returns a channel of Network.webSocketFrameSent events, as an alternative to OnWebSocketFrameSent.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketFrameSentChan(
+	buffer int,
+) (<-chan *network.WebSocketFrameSentEvent, func()) {
+	eventCh := make(chan *network.WebSocketFrameSentEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketFrameSent(func(event *network.WebSocketFrameSentEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1012,7 +1383,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketH
 */
 func (protocol *NetworkProtocol) OnWebSocketHandshakeResponseReceived(
 	callback func(event *network.WebSocketHandshakeResponseReceivedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketHandshakeResponseReceived",
 		func(response *Response) {
@@ -1024,7 +1395,28 @@ func (protocol *NetworkProtocol) OnWebSocketHandshakeResponseReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketHandshakeResponseReceivedChan returns a channel of Network.webSocketHandshakeResponseReceived events, as an alternative to OnWebSocketHandshakeResponseReceived.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketHandshakeResponseReceivedChan(
+	buffer int,
+) (<-chan *network.WebSocketHandshakeResponseReceivedEvent, func()) {
+	eventCh := make(chan *network.WebSocketHandshakeResponseReceivedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketHandshakeResponseReceived(func(event *network.WebSocketHandshakeResponseReceivedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1036,7 +1428,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketW
 */
 func (protocol *NetworkProtocol) OnWebSocketWillSendHandshakeRequest(
 	callback func(event *network.WebSocketWillSendHandshakeRequestEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Network.webSocketWillSendHandshakeRequest",
 		func(response *Response) {
@@ -1048,5 +1440,26 @@ func (protocol *NetworkProtocol) OnWebSocketWillSendHandshakeRequest(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WebSocketWillSendHandshakeRequestChan returns a channel of Network.webSocketWillSendHandshakeRequest events, as an alternative to OnWebSocketWillSendHandshakeRequest.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *NetworkProtocol) WebSocketWillSendHandshakeRequestChan(
+	buffer int,
+) (<-chan *network.WebSocketWillSendHandshakeRequestEvent, func()) {
+	eventCh := make(chan *network.WebSocketWillSendHandshakeRequestEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWebSocketWillSendHandshakeRequest(func(event *network.WebSocketWillSendHandshakeRequestEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
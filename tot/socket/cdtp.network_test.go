@@ -543,6 +543,45 @@ func TestNetworkGetCookies(t *testing.T) {
 	}
 }
 
+func TestNetworkGetRequestPostData(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestNetworkGetRequestPostData")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &network.GetRequestPostDataParams{
+		RequestID: network.RequestID("request-id"),
+	}
+	resultChan := mockSocket.Network().GetRequestPostData(params)
+	mockResult := &network.GetRequestPostDataResult{
+		PostData: "post data",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Network().GetRequestPostData(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestNetworkGetResponseBody(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestNetworkGetResponseBody")
 	mockSocket := NewMock(socketURL)
@@ -875,6 +914,43 @@ func TestNetworkSetCookie(t *testing.T) {
 	}
 }
 
+func TestNetworkSetCookieControls(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestNetworkSetCookieControls")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &network.SetCookieControlsParams{
+		EnableThirdPartyCookieRestriction: true,
+	}
+	resultChan := mockSocket.Network().SetCookieControls(params)
+	mockResult := &network.SetCookieControlsResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Network().SetCookieControls(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestNetworkSetCookies(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestNetworkSetCookies")
 	mockSocket := NewMock(socketURL)
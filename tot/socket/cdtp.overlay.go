@@ -30,7 +30,7 @@ func (protocol *OverlayProtocol) Disable() <-chan *overlay.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -52,7 +52,7 @@ func (protocol *OverlayProtocol) Enable() <-chan *overlay.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,7 +76,7 @@ func (protocol *OverlayProtocol) GetHighlightObjectForTest(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -100,7 +100,7 @@ func (protocol *OverlayProtocol) HideHighlight() <-chan *overlay.HideHighlightRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -124,7 +124,7 @@ func (protocol *OverlayProtocol) HighlightFrame(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -149,7 +149,7 @@ func (protocol *OverlayProtocol) HighlightNode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -174,7 +174,7 @@ func (protocol *OverlayProtocol) HighlightQuad(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -199,7 +199,7 @@ func (protocol *OverlayProtocol) HighlightRect(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -225,7 +225,7 @@ func (protocol *OverlayProtocol) SetInspectMode(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -249,7 +249,7 @@ func (protocol *OverlayProtocol) SetPausedInDebuggerMessage(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -273,7 +273,7 @@ func (protocol *OverlayProtocol) SetShowDebugBorders(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -297,7 +297,7 @@ func (protocol *OverlayProtocol) SetShowFPSCounter(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -321,7 +321,7 @@ func (protocol *OverlayProtocol) SetShowPaintRects(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -345,7 +345,7 @@ func (protocol *OverlayProtocol) SetShowScrollBottleneckRects(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -369,7 +369,7 @@ func (protocol *OverlayProtocol) SetShowViewportSizeOnResize(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -393,7 +393,7 @@ func (protocol *OverlayProtocol) SetSuspended(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -412,7 +412,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-inspectNod
 */
 func (protocol *OverlayProtocol) OnInspectNodeRequested(
 	callback func(event *overlay.InspectNodeRequestedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Overlay.inspectNodeRequested",
 		func(response *Response) {
@@ -424,7 +424,28 @@ func (protocol *OverlayProtocol) OnInspectNodeRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+InspectNodeRequestedChan returns a channel of Overlay.inspectNodeRequested events, as an alternative to OnInspectNodeRequested.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *OverlayProtocol) InspectNodeRequestedChan(
+	buffer int,
+) (<-chan *overlay.InspectNodeRequestedEvent, func()) {
+	eventCh := make(chan *overlay.InspectNodeRequestedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnInspectNodeRequested(func(event *overlay.InspectNodeRequestedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -436,7 +457,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-nodeHighli
 */
 func (protocol *OverlayProtocol) OnNodeHighlightRequested(
 	callback func(event *overlay.NodeHighlightRequestedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Overlay.nodeHighlightRequested",
 		func(response *Response) {
@@ -448,7 +469,28 @@ func (protocol *OverlayProtocol) OnNodeHighlightRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+NodeHighlightRequestedChan returns a channel of Overlay.nodeHighlightRequested events, as an alternative to OnNodeHighlightRequested.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *OverlayProtocol) NodeHighlightRequestedChan(
+	buffer int,
+) (<-chan *overlay.NodeHighlightRequestedEvent, func()) {
+	eventCh := make(chan *overlay.NodeHighlightRequestedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnNodeHighlightRequested(func(event *overlay.NodeHighlightRequestedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -460,7 +502,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-screenshot
 */
 func (protocol *OverlayProtocol) OnScreenshotRequested(
 	callback func(event *overlay.ScreenshotRequestedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Overlay.screenshotRequested",
 		func(response *Response) {
@@ -472,5 +514,26 @@ func (protocol *OverlayProtocol) OnScreenshotRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ScreenshotRequestedChan returns a channel of Overlay.screenshotRequested events, as an alternative to OnScreenshotRequested.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *OverlayProtocol) ScreenshotRequestedChan(
+	buffer int,
+) (<-chan *overlay.ScreenshotRequestedEvent, func()) {
+	eventCh := make(chan *overlay.ScreenshotRequestedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnScreenshotRequested(func(event *overlay.ScreenshotRequestedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
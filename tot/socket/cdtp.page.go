@@ -34,7 +34,7 @@ func (protocol *PageProtocol) AddScriptToEvaluateOnLoad(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -61,7 +61,7 @@ func (protocol *PageProtocol) AddScriptToEvaluateOnNewDocument(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -85,7 +85,7 @@ func (protocol *PageProtocol) BringToFront() <-chan *page.BringToFrontResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -109,7 +109,7 @@ func (protocol *PageProtocol) CaptureScreenshot(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -135,7 +135,7 @@ func (protocol *PageProtocol) CreateIsolatedWorld(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -159,7 +159,7 @@ func (protocol *PageProtocol) Disable() <-chan *page.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -181,7 +181,7 @@ func (protocol *PageProtocol) Enable() <-chan *page.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -205,7 +205,7 @@ func (protocol *PageProtocol) GetAppManifest(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -227,7 +227,7 @@ func (protocol *PageProtocol) GetFrameTree() <-chan *page.GetFrameTreeResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -252,7 +252,7 @@ func (protocol *PageProtocol) GetLayoutMetrics() <-chan *page.GetLayoutMetricsRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -276,7 +276,7 @@ func (protocol *PageProtocol) GetNavigationHistory() <-chan *page.GetNavigationH
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -303,7 +303,7 @@ func (protocol *PageProtocol) GetResourceContent(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -328,7 +328,7 @@ func (protocol *PageProtocol) GetResourceTree() <-chan *page.GetResourceTreeResu
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -355,7 +355,7 @@ func (protocol *PageProtocol) HandleJavaScriptDialog(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -379,7 +379,7 @@ func (protocol *PageProtocol) Navigate(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -405,7 +405,7 @@ func (protocol *PageProtocol) NavigateToHistoryEntry(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -429,7 +429,7 @@ func (protocol *PageProtocol) PrintToPDF(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -455,7 +455,7 @@ func (protocol *PageProtocol) Reload(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -481,7 +481,7 @@ func (protocol *PageProtocol) RemoveScriptToEvaluateOnLoad(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -505,7 +505,7 @@ func (protocol *PageProtocol) RemoveScriptToEvaluateOnNewDocument(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -527,7 +527,7 @@ func (protocol *PageProtocol) RequestAppBanner() <-chan *page.RequestAppBannerRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -552,7 +552,7 @@ func (protocol *PageProtocol) ScreencastFrameAck(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -577,7 +577,7 @@ func (protocol *PageProtocol) SearchInResource(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -604,7 +604,7 @@ func (protocol *PageProtocol) SetAdBlockingEnabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -630,7 +630,7 @@ func (protocol *PageProtocol) SetAutoAttachToCreatedPages(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -654,7 +654,7 @@ func (protocol *PageProtocol) SetDocumentContent(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -679,7 +679,7 @@ func (protocol *PageProtocol) SetDownloadBehavior(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -704,7 +704,34 @@ func (protocol *PageProtocol) SetLifecycleEventsEnabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetWebLifecycleState tries to update the web lifecycle state of the page. It
+transitions the page to the given state according to the Web Lifecycle API,
+https://github.com/WICG/web-lifecycle/.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setWebLifecycleState
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SetWebLifecycleState(
+	params *page.SetWebLifecycleStateParams,
+) <-chan *page.SetWebLifecycleStateResult {
+	resultChan := make(chan *page.SetWebLifecycleStateResult)
+	command := NewCommand(protocol.Socket, "Page.setWebLifecycleState", params)
+	result := &page.SetWebLifecycleStateResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -729,7 +756,7 @@ func (protocol *PageProtocol) StartScreencast(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -751,7 +778,7 @@ func (protocol *PageProtocol) StopLoading() <-chan *page.StopLoadingResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -774,7 +801,7 @@ func (protocol *PageProtocol) StopScreencast() <-chan *page.StopScreencastResult
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -791,7 +818,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-domContentEve
 */
 func (protocol *PageProtocol) OnDOMContentEventFired(
 	callback func(event *page.DOMContentEventFiredEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.domContentEventFired",
 		func(response *Response) {
@@ -803,7 +830,28 @@ func (protocol *PageProtocol) OnDOMContentEventFired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DOMContentEventFiredChan returns a channel of Page.domContentEventFired events, as an alternative to OnDOMContentEventFired.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) DOMContentEventFiredChan(
+	buffer int,
+) (<-chan *page.DOMContentEventFiredEvent, func()) {
+	eventCh := make(chan *page.DOMContentEventFiredEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDOMContentEventFired(func(event *page.DOMContentEventFiredEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -814,7 +862,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameAttached
 */
 func (protocol *PageProtocol) OnFrameAttached(
 	callback func(event *page.FrameAttachedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameAttached",
 		func(response *Response) {
@@ -826,7 +874,28 @@ func (protocol *PageProtocol) OnFrameAttached(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameAttachedChan returns a channel of Page.frameAttached events, as an alternative to OnFrameAttached.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameAttachedChan(
+	buffer int,
+) (<-chan *page.FrameAttachedEvent, func()) {
+	eventCh := make(chan *page.FrameAttachedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameAttached(func(event *page.FrameAttachedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -839,7 +908,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameClearedScheduledNavigation(
 	callback func(event *page.FrameClearedScheduledNavigationEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameClearedScheduledNavigation",
 		func(response *Response) {
@@ -851,7 +920,28 @@ func (protocol *PageProtocol) OnFrameClearedScheduledNavigation(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameClearedScheduledNavigationChan returns a channel of Page.frameClearedScheduledNavigation events, as an alternative to OnFrameClearedScheduledNavigation.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameClearedScheduledNavigationChan(
+	buffer int,
+) (<-chan *page.FrameClearedScheduledNavigationEvent, func()) {
+	eventCh := make(chan *page.FrameClearedScheduledNavigationEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameClearedScheduledNavigation(func(event *page.FrameClearedScheduledNavigationEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -862,7 +952,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameDetached
 */
 func (protocol *PageProtocol) OnFrameDetached(
 	callback func(event *page.FrameDetachedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameDetached",
 		func(response *Response) {
@@ -874,7 +964,28 @@ func (protocol *PageProtocol) OnFrameDetached(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameDetachedChan returns a channel of Page.frameDetached events, as an alternative to OnFrameDetached.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameDetachedChan(
+	buffer int,
+) (<-chan *page.FrameDetachedEvent, func()) {
+	eventCh := make(chan *page.FrameDetachedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameDetached(func(event *page.FrameDetachedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -886,7 +997,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameNavigate
 */
 func (protocol *PageProtocol) OnFrameNavigated(
 	callback func(event *page.FrameNavigatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameNavigated",
 		func(response *Response) {
@@ -898,7 +1009,28 @@ func (protocol *PageProtocol) OnFrameNavigated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameNavigatedChan returns a channel of Page.frameNavigated events, as an alternative to OnFrameNavigated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameNavigatedChan(
+	buffer int,
+) (<-chan *page.FrameNavigatedEvent, func()) {
+	eventCh := make(chan *page.FrameNavigatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameNavigated(func(event *page.FrameNavigatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -910,7 +1042,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameResized(
 	callback func(event *page.FrameResizedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameResized",
 		func(response *Response) {
@@ -922,7 +1054,28 @@ func (protocol *PageProtocol) OnFrameResized(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameResizedChan returns a channel of Page.frameResized events, as an alternative to OnFrameResized.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameResizedChan(
+	buffer int,
+) (<-chan *page.FrameResizedEvent, func()) {
+	eventCh := make(chan *page.FrameResizedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameResized(func(event *page.FrameResizedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -935,7 +1088,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameScheduledNavigation(
 	callback func(event *page.FrameScheduledNavigationEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameScheduledNavigation",
 		func(response *Response) {
@@ -947,7 +1100,28 @@ func (protocol *PageProtocol) OnFrameScheduledNavigation(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameScheduledNavigationChan returns a channel of Page.frameScheduledNavigation events, as an alternative to OnFrameScheduledNavigation.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameScheduledNavigationChan(
+	buffer int,
+) (<-chan *page.FrameScheduledNavigationEvent, func()) {
+	eventCh := make(chan *page.FrameScheduledNavigationEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameScheduledNavigation(func(event *page.FrameScheduledNavigationEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -959,7 +1133,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameStartedLoading(
 	callback func(event *page.FrameStartedLoadingEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameStartedLoading",
 		func(response *Response) {
@@ -971,7 +1145,28 @@ func (protocol *PageProtocol) OnFrameStartedLoading(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameStartedLoadingChan returns a channel of Page.frameStartedLoading events, as an alternative to OnFrameStartedLoading.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameStartedLoadingChan(
+	buffer int,
+) (<-chan *page.FrameStartedLoadingEvent, func()) {
+	eventCh := make(chan *page.FrameStartedLoadingEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameStartedLoading(func(event *page.FrameStartedLoadingEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -983,7 +1178,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameStoppedLoading(
 	callback func(event *page.FrameStoppedLoadingEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.frameStoppedLoading",
 		func(response *Response) {
@@ -995,7 +1190,28 @@ func (protocol *PageProtocol) OnFrameStoppedLoading(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+FrameStoppedLoadingChan returns a channel of Page.frameStoppedLoading events, as an alternative to OnFrameStoppedLoading.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) FrameStoppedLoadingChan(
+	buffer int,
+) (<-chan *page.FrameStoppedLoadingEvent, func()) {
+	eventCh := make(chan *page.FrameStoppedLoadingEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnFrameStoppedLoading(func(event *page.FrameStoppedLoadingEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1006,7 +1222,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialH
 */
 func (protocol *PageProtocol) OnInterstitialHidden(
 	callback func(event *page.InterstitialHiddenEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.interstitialHidden",
 		func(response *Response) {
@@ -1018,7 +1234,28 @@ func (protocol *PageProtocol) OnInterstitialHidden(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+InterstitialHiddenChan returns a channel of Page.interstitialHidden events, as an alternative to OnInterstitialHidden.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) InterstitialHiddenChan(
+	buffer int,
+) (<-chan *page.InterstitialHiddenEvent, func()) {
+	eventCh := make(chan *page.InterstitialHiddenEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnInterstitialHidden(func(event *page.InterstitialHiddenEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1029,7 +1266,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialS
 */
 func (protocol *PageProtocol) OnInterstitialShown(
 	callback func(event *page.InterstitialShownEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.interstitialShown",
 		func(response *Response) {
@@ -1041,7 +1278,28 @@ func (protocol *PageProtocol) OnInterstitialShown(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+InterstitialShownChan returns a channel of Page.interstitialShown events, as an alternative to OnInterstitialShown.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) InterstitialShownChan(
+	buffer int,
+) (<-chan *page.InterstitialShownEvent, func()) {
+	eventCh := make(chan *page.InterstitialShownEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnInterstitialShown(func(event *page.InterstitialShownEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1053,7 +1311,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDia
 */
 func (protocol *PageProtocol) OnJavascriptDialogClosed(
 	callback func(event *page.JavascriptDialogClosedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.javascriptDialogClosed",
 		func(response *Response) {
@@ -1065,7 +1323,28 @@ func (protocol *PageProtocol) OnJavascriptDialogClosed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+JavascriptDialogClosedChan returns a channel of Page.javascriptDialogClosed events, as an alternative to OnJavascriptDialogClosed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) JavascriptDialogClosedChan(
+	buffer int,
+) (<-chan *page.JavascriptDialogClosedEvent, func()) {
+	eventCh := make(chan *page.JavascriptDialogClosedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnJavascriptDialogClosed(func(event *page.JavascriptDialogClosedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1077,7 +1356,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDia
 */
 func (protocol *PageProtocol) OnJavascriptDialogOpening(
 	callback func(event *page.JavascriptDialogOpeningEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.javascriptDialogOpening",
 		func(response *Response) {
@@ -1089,7 +1368,28 @@ func (protocol *PageProtocol) OnJavascriptDialogOpening(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+JavascriptDialogOpeningChan returns a channel of Page.javascriptDialogOpening events, as an alternative to OnJavascriptDialogOpening.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) JavascriptDialogOpeningChan(
+	buffer int,
+) (<-chan *page.JavascriptDialogOpeningEvent, func()) {
+	eventCh := make(chan *page.JavascriptDialogOpeningEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnJavascriptDialogOpening(func(event *page.JavascriptDialogOpeningEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1100,7 +1400,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-lifecycleEven
 */
 func (protocol *PageProtocol) OnLifecycleEvent(
 	callback func(event *page.LifecycleEventEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.lifecycleEvent",
 		func(response *Response) {
@@ -1112,7 +1412,28 @@ func (protocol *PageProtocol) OnLifecycleEvent(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LifecycleEventChan returns a channel of Page.lifecycleEvent events, as an alternative to OnLifecycleEvent.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) LifecycleEventChan(
+	buffer int,
+) (<-chan *page.LifecycleEventEvent, func()) {
+	eventCh := make(chan *page.LifecycleEventEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLifecycleEvent(func(event *page.LifecycleEventEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1123,7 +1444,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-loadEventFire
 */
 func (protocol *PageProtocol) OnLoadEventFired(
 	callback func(event *page.LoadEventFiredEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.loadEventFired",
 		func(response *Response) {
@@ -1135,7 +1456,28 @@ func (protocol *PageProtocol) OnLoadEventFired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+LoadEventFiredChan returns a channel of Page.loadEventFired events, as an alternative to OnLoadEventFired.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) LoadEventFiredChan(
+	buffer int,
+) (<-chan *page.LoadEventFiredEvent, func()) {
+	eventCh := make(chan *page.LoadEventFiredEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1147,19 +1489,46 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnScreencastFrame(
 	callback func(event *page.ScreencastFrameEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.screencastFrame",
 		func(response *Response) {
-			event := &page.ScreencastFrameEvent{}
-			json.Unmarshal([]byte(response.Params), event)
+			event, pooled := acquireScreencastFrameEvent(protocol.Socket)
+			decodeEvent(response.Params, event)
 			if nil != response.Error && 0 != response.Error.Code {
 				event.Err = response.Error
 			}
 			callback(event)
+			if pooled {
+				releaseScreencastFrameEvent(event)
+			}
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ScreencastFrameChan returns a channel of Page.screencastFrame events, as an alternative to OnScreencastFrame.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) ScreencastFrameChan(
+	buffer int,
+) (<-chan *page.ScreencastFrameEvent, func()) {
+	eventCh := make(chan *page.ScreencastFrameEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnScreencastFrame(func(event *page.ScreencastFrameEvent) {
+		// Pooled events are reused once the handler returns, the channel
+		// receives a copy.
+		e := *event
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- &e:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1172,7 +1541,7 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnScreencastVisibilityChanged(
 	callback func(event *page.ScreencastVisibilityChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.screencastVisibilityChanged",
 		func(response *Response) {
@@ -1184,7 +1553,28 @@ func (protocol *PageProtocol) OnScreencastVisibilityChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ScreencastVisibilityChangedChan returns a channel of Page.screencastVisibilityChanged events, as an alternative to OnScreencastVisibilityChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) ScreencastVisibilityChangedChan(
+	buffer int,
+) (<-chan *page.ScreencastVisibilityChangedEvent, func()) {
+	eventCh := make(chan *page.ScreencastVisibilityChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnScreencastVisibilityChanged(func(event *page.ScreencastVisibilityChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -1196,7 +1586,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-windowOpen
 */
 func (protocol *PageProtocol) OnWindowOpen(
 	callback func(event *page.WindowOpenEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Page.windowOpen",
 		func(response *Response) {
@@ -1208,5 +1598,26 @@ func (protocol *PageProtocol) OnWindowOpen(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WindowOpenChan returns a channel of Page.windowOpen events, as an alternative to OnWindowOpen.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PageProtocol) WindowOpenChan(
+	buffer int,
+) (<-chan *page.WindowOpenEvent, func()) {
+	eventCh := make(chan *page.WindowOpenEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWindowOpen(func(event *page.WindowOpenEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
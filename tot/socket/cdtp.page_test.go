@@ -1180,6 +1180,43 @@ func TestPageSetLifecycleEventsEnabled(t *testing.T) {
 	}
 }
 
+func TestPageSetWebLifecycleState(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPageSetWebLifecycleState")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &page.SetWebLifecycleStateParams{
+		State: page.WebLifecycleState.Frozen,
+	}
+	resultChan := mockSocket.Page().SetWebLifecycleState(params)
+	mockResult := &page.SetWebLifecycleStateResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Page().SetWebLifecycleState(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestPageStartScreencast(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestPageStartScreencast")
 	mockSocket := NewMock(socketURL)
@@ -29,7 +29,7 @@ func (protocol *PerformanceProtocol) Disable() <-chan *performance.DisableResult
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -44,14 +44,26 @@ Enable enables collecting and reporting metrics.
 https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-enable
 */
 func (protocol *PerformanceProtocol) Enable() <-chan *performance.EnableResult {
+	return protocol.EnableWithParams(nil)
+}
+
+/*
+EnableWithParams enables collecting and reporting metrics with parameters, for
+example the time domain of the duration metrics.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-enable
+*/
+func (protocol *PerformanceProtocol) EnableWithParams(
+	params *performance.EnableParams,
+) <-chan *performance.EnableResult {
 	resultChan := make(chan *performance.EnableResult)
-	command := NewCommand(protocol.Socket, "Performance.enable", nil)
+	command := NewCommand(protocol.Socket, "Performance.enable", params)
 	result := &performance.EnableResult{}
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -73,7 +85,7 @@ func (protocol *PerformanceProtocol) GetMetrics() <-chan *performance.GetMetrics
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -84,6 +96,33 @@ func (protocol *PerformanceProtocol) GetMetrics() <-chan *performance.GetMetrics
 	return resultChan
 }
 
+/*
+SetTimeDomain sets the time domain to use for collecting and reporting duration
+metrics. It must be called before enabling metrics collection, calling it while
+metrics collection is enabled returns an error. DEPRECATED, use the TimeDomain
+parameter of EnableWithParams instead.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-setTimeDomain
+*/
+func (protocol *PerformanceProtocol) SetTimeDomain(
+	params *performance.SetTimeDomainParams,
+) <-chan *performance.SetTimeDomainResult {
+	resultChan := make(chan *performance.SetTimeDomainResult)
+	command := NewCommand(protocol.Socket, "Performance.setTimeDomain", params)
+	result := &performance.SetTimeDomainResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 OnMetrics adds a handler to the Performance.metrics event. Performance.metrics
 returns current values of the metrics.
@@ -92,7 +131,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Performance/#event-metric
 */
 func (protocol *PerformanceProtocol) OnMetrics(
 	callback func(event *performance.MetricsEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Performance.metrics",
 		func(response *Response) {
@@ -104,5 +143,26 @@ func (protocol *PerformanceProtocol) OnMetrics(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+MetricsChan returns a channel of Performance.metrics events, as an alternative to OnMetrics.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PerformanceProtocol) MetricsChan(
+	buffer int,
+) (<-chan *performance.MetricsEvent, func()) {
+	eventCh := make(chan *performance.MetricsEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnMetrics(func(event *performance.MetricsEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
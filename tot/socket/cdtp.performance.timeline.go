@@ -0,0 +1,94 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/performance/timeline"
+)
+
+/*
+PerformanceTimelineProtocol provides a namespace for the Chrome
+PerformanceTimeline protocol methods. The PerformanceTimeline protocol reports
+performance timeline events, as specified in
+https://w3c.github.io/performance-timeline/#dom-performanceobserver.
+EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/
+*/
+type PerformanceTimelineProtocol struct {
+	Socket Socketer
+}
+
+/*
+Enable starts reporting the timeline events of the given types. Previously
+buffered events are reported before the method returns. See also:
+timelineEventAdded.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#method-enable
+*/
+func (protocol *PerformanceTimelineProtocol) Enable(
+	params *timeline.EnableParams,
+) <-chan *timeline.EnableResult {
+	resultChan := make(chan *timeline.EnableResult)
+	command := NewCommand(protocol.Socket, "PerformanceTimeline.enable", params)
+	result := &timeline.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnTimelineEventAdded adds a handler to the
+PerformanceTimeline.timelineEventAdded event.
+PerformanceTimeline.timelineEventAdded fires when a performance timeline event
+of an enabled type is added.
+
+https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#event-timelineEventAdded
+*/
+func (protocol *PerformanceTimelineProtocol) OnTimelineEventAdded(
+	callback func(event *timeline.EventAddedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"PerformanceTimeline.timelineEventAdded",
+		func(response *Response) {
+			event := &timeline.EventAddedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TimelineEventAddedChan returns a channel of
+PerformanceTimeline.timelineEventAdded events, as an alternative to
+OnTimelineEventAdded. The returned function removes the event handler and closes
+the channel. Event handlers run concurrently, so events are not guaranteed to
+arrive in order.
+*/
+func (protocol *PerformanceTimelineProtocol) TimelineEventAddedChan(
+	buffer int,
+) (<-chan *timeline.EventAddedEvent, func()) {
+	eventCh := make(chan *timeline.EventAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTimelineEventAdded(func(event *timeline.EventAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
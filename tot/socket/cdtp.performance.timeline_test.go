@@ -0,0 +1,99 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/performance/timeline"
+)
+
+func TestPerformanceTimelineEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPerformanceTimelineEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &timeline.EnableParams{
+		EventTypes: []string{timeline.LargestContentfulPaintType, timeline.LayoutShiftType},
+	}
+	resultChan := mockSocket.PerformanceTimeline().Enable(params)
+	mockResult := &timeline.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.PerformanceTimeline().Enable(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPerformanceTimelineOnTimelineEventAdded(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPerformanceTimelineOnTimelineEventAdded")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *timeline.EventAddedEvent)
+	mockSocket.PerformanceTimeline().OnTimelineEventAdded(func(eventData *timeline.EventAddedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &timeline.EventAddedEvent{
+		Event: &timeline.Event{
+			Type: timeline.LayoutShiftType,
+			Time: 1700000000.25,
+			LayoutShiftDetails: &timeline.LayoutShift{
+				Value: 0.05,
+			},
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "PerformanceTimeline.timelineEventAdded",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.Event || nil == result.Event.LayoutShiftDetails || 0.05 != result.Event.LayoutShiftDetails.Value {
+		t.Errorf("Expected the layout shift details, got %v", result.Event)
+	}
+
+	resultChan = make(chan *timeline.EventAddedEvent)
+	mockSocket.PerformanceTimeline().OnTimelineEventAdded(func(eventData *timeline.EventAddedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "PerformanceTimeline.timelineEventAdded",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
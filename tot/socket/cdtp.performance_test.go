@@ -76,6 +76,44 @@ func TestPerformanceEnable(t *testing.T) {
 	}
 }
 
+func TestPerformanceEnableWithParams(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPerformanceEnableWithParams")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Performance().EnableWithParams(&performance.EnableParams{
+		TimeDomain: performance.TimeDomain.ThreadTicks,
+	})
+	mockResult := &performance.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Performance().EnableWithParams(&performance.EnableParams{
+		TimeDomain: performance.TimeDomain.ThreadTicks,
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestPerformanceGetMetrics(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestPerformanceGetMetrics")
 	mockSocket := NewMock(socketURL)
@@ -87,6 +125,9 @@ func TestPerformanceGetMetrics(t *testing.T) {
 		Metrics: []*performance.Metric{{
 			Name:  "metric name",
 			Value: 1,
+		}, {
+			Name:  performance.TaskDuration,
+			Value: 0.25,
 		}},
 	}
 	mockResultBytes, _ := json.Marshal(mockResult)
@@ -102,6 +143,12 @@ func TestPerformanceGetMetrics(t *testing.T) {
 	if mockResult.Metrics[0].Name != result.Metrics[0].Name {
 		t.Errorf("Expected %s, got %s", mockResult.Metrics[0].Name, result.Metrics[0].Name)
 	}
+	if value, ok := result.Value(performance.TaskDuration); !ok || 0.25 != value {
+		t.Errorf("Expected 0.25, got %v", value)
+	}
+	if _, ok := result.Value(performance.LayoutCount); ok {
+		t.Errorf("Expected no LayoutCount metric")
+	}
 
 	resultChan = mockSocket.Performance().GetMetrics()
 	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
@@ -118,6 +165,43 @@ func TestPerformanceGetMetrics(t *testing.T) {
 	}
 }
 
+func TestPerformanceSetTimeDomain(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPerformanceSetTimeDomain")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &performance.SetTimeDomainParams{
+		TimeDomain: performance.TimeDomain.ThreadTicks,
+	}
+	resultChan := mockSocket.Performance().SetTimeDomain(params)
+	mockResult := &performance.SetTimeDomainResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Performance().SetTimeDomain(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestPerformanceOnMetrics(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestPerformanceOnMetrics")
 	mockSocket := NewMock(socketURL)
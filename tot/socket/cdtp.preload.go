@@ -0,0 +1,338 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/preload"
+)
+
+/*
+PreloadProtocol provides a namespace for the Chrome Preload protocol methods.
+The Preload protocol reports the speculation rule sets of a page and the outcome
+of the prefetch and prerender attempts they trigger.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/
+*/
+type PreloadProtocol struct {
+	Socket Socketer
+}
+
+/*
+Disable disables the Preload domain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#method-disable
+*/
+func (protocol *PreloadProtocol) Disable() <-chan *preload.DisableResult {
+	resultChan := make(chan *preload.DisableResult)
+	command := NewCommand(protocol.Socket, "Preload.disable", nil)
+	result := &preload.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables the Preload domain. The rule sets and preloading attempts of the
+page are reported when it's enabled.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#method-enable
+*/
+func (protocol *PreloadProtocol) Enable() <-chan *preload.EnableResult {
+	resultChan := make(chan *preload.EnableResult)
+	command := NewCommand(protocol.Socket, "Preload.enable", nil)
+	result := &preload.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnRuleSetUpdated adds a handler to the Preload.ruleSetUpdated event. Fired when
+a speculation rule set is added or updated.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-ruleSetUpdated
+*/
+func (protocol *PreloadProtocol) OnRuleSetUpdated(
+	callback func(event *preload.RuleSetUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.ruleSetUpdated",
+		func(response *Response) {
+			event := &preload.RuleSetUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RuleSetUpdatedChan returns a channel of Preload.ruleSetUpdated events, as an
+alternative to OnRuleSetUpdated. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) RuleSetUpdatedChan(
+	buffer int,
+) (<-chan *preload.RuleSetUpdatedEvent, func()) {
+	eventCh := make(chan *preload.RuleSetUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRuleSetUpdated(func(event *preload.RuleSetUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnRuleSetRemoved adds a handler to the Preload.ruleSetRemoved event. Fired when
+a speculation rule set is removed.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-ruleSetRemoved
+*/
+func (protocol *PreloadProtocol) OnRuleSetRemoved(
+	callback func(event *preload.RuleSetRemovedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.ruleSetRemoved",
+		func(response *Response) {
+			event := &preload.RuleSetRemovedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+RuleSetRemovedChan returns a channel of Preload.ruleSetRemoved events, as an
+alternative to OnRuleSetRemoved. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) RuleSetRemovedChan(
+	buffer int,
+) (<-chan *preload.RuleSetRemovedEvent, func()) {
+	eventCh := make(chan *preload.RuleSetRemovedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnRuleSetRemoved(func(event *preload.RuleSetRemovedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnPreloadEnabledStateUpdated adds a handler to the
+Preload.preloadEnabledStateUpdated event. Fired when preloading is enabled or
+disabled, for example by the data saver.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-preloadEnabledStateUpdated
+*/
+func (protocol *PreloadProtocol) OnPreloadEnabledStateUpdated(
+	callback func(event *preload.PreloadEnabledStateUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.preloadEnabledStateUpdated",
+		func(response *Response) {
+			event := &preload.PreloadEnabledStateUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PreloadEnabledStateUpdatedChan returns a channel of
+Preload.preloadEnabledStateUpdated events, as an alternative to
+OnPreloadEnabledStateUpdated. The returned function removes the event handler
+and closes the channel. Event handlers run concurrently, so events are not
+guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) PreloadEnabledStateUpdatedChan(
+	buffer int,
+) (<-chan *preload.PreloadEnabledStateUpdatedEvent, func()) {
+	eventCh := make(chan *preload.PreloadEnabledStateUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPreloadEnabledStateUpdated(func(event *preload.PreloadEnabledStateUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnPrefetchStatusUpdated adds a handler to the Preload.prefetchStatusUpdated
+event. Fired when the status of a prefetch attempt changes.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-prefetchStatusUpdated
+*/
+func (protocol *PreloadProtocol) OnPrefetchStatusUpdated(
+	callback func(event *preload.PrefetchStatusUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.prefetchStatusUpdated",
+		func(response *Response) {
+			event := &preload.PrefetchStatusUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PrefetchStatusUpdatedChan returns a channel of Preload.prefetchStatusUpdated
+events, as an alternative to OnPrefetchStatusUpdated. The returned function
+removes the event handler and closes the channel. Event handlers run
+concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) PrefetchStatusUpdatedChan(
+	buffer int,
+) (<-chan *preload.PrefetchStatusUpdatedEvent, func()) {
+	eventCh := make(chan *preload.PrefetchStatusUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPrefetchStatusUpdated(func(event *preload.PrefetchStatusUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnPrerenderStatusUpdated adds a handler to the Preload.prerenderStatusUpdated
+event. Fired when the status of a prerender attempt changes. The PrerenderStatus
+of a finished attempt is the reason it ended.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-prerenderStatusUpdated
+*/
+func (protocol *PreloadProtocol) OnPrerenderStatusUpdated(
+	callback func(event *preload.PrerenderStatusUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.prerenderStatusUpdated",
+		func(response *Response) {
+			event := &preload.PrerenderStatusUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PrerenderStatusUpdatedChan returns a channel of Preload.prerenderStatusUpdated
+events, as an alternative to OnPrerenderStatusUpdated. The returned function
+removes the event handler and closes the channel. Event handlers run
+concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) PrerenderStatusUpdatedChan(
+	buffer int,
+) (<-chan *preload.PrerenderStatusUpdatedEvent, func()) {
+	eventCh := make(chan *preload.PrerenderStatusUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPrerenderStatusUpdated(func(event *preload.PrerenderStatusUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnPreloadingAttemptSourcesUpdated adds a handler to the
+Preload.preloadingAttemptSourcesUpdated event. Fired when the rule sets and
+links that trigger the preloading attempts of a document change.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Preload/#event-preloadingAttemptSourcesUpdated
+*/
+func (protocol *PreloadProtocol) OnPreloadingAttemptSourcesUpdated(
+	callback func(event *preload.PreloadingAttemptSourcesUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"Preload.preloadingAttemptSourcesUpdated",
+		func(response *Response) {
+			event := &preload.PreloadingAttemptSourcesUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+PreloadingAttemptSourcesUpdatedChan returns a channel of
+Preload.preloadingAttemptSourcesUpdated events, as an alternative to
+OnPreloadingAttemptSourcesUpdated. The returned function removes the event
+handler and closes the channel. Event handlers run concurrently, so events are
+not guaranteed to arrive in order.
+*/
+func (protocol *PreloadProtocol) PreloadingAttemptSourcesUpdatedChan(
+	buffer int,
+) (<-chan *preload.PreloadingAttemptSourcesUpdatedEvent, func()) {
+	eventCh := make(chan *preload.PreloadingAttemptSourcesUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnPreloadingAttemptSourcesUpdated(func(event *preload.PreloadingAttemptSourcesUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
@@ -0,0 +1,375 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/preload"
+)
+
+func TestPreloadDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Preload().Disable()
+	mockResult := &preload.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Preload().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Preload().Enable()
+	mockResult := &preload.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Preload().Enable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnRuleSetUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnRuleSetUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.RuleSetUpdatedEvent)
+	mockSocket.Preload().OnRuleSetUpdated(func(eventData *preload.RuleSetUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.RuleSetUpdatedEvent{
+		RuleSet: &preload.RuleSet{
+			ID:         preload.RuleSetID("1"),
+			SourceText: `{"prerender": [{"urls": ["/next"]}]}`,
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.ruleSetUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.RuleSet || "1" != result.RuleSet.ID {
+		t.Errorf("Expected rule set 1, got %v", result.RuleSet)
+	}
+
+	resultChan = make(chan *preload.RuleSetUpdatedEvent)
+	mockSocket.Preload().OnRuleSetUpdated(func(eventData *preload.RuleSetUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.ruleSetUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnRuleSetRemoved(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnRuleSetRemoved")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.RuleSetRemovedEvent)
+	mockSocket.Preload().OnRuleSetRemoved(func(eventData *preload.RuleSetRemovedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.RuleSetRemovedEvent{
+		ID: preload.RuleSetID("1"),
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.ruleSetRemoved",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if "1" != result.ID {
+		t.Errorf("Expected rule set 1, got '%s'", result.ID)
+	}
+
+	resultChan = make(chan *preload.RuleSetRemovedEvent)
+	mockSocket.Preload().OnRuleSetRemoved(func(eventData *preload.RuleSetRemovedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.ruleSetRemoved",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnPreloadEnabledStateUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnPreloadEnabledStateUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.PreloadEnabledStateUpdatedEvent)
+	mockSocket.Preload().OnPreloadEnabledStateUpdated(func(eventData *preload.PreloadEnabledStateUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.PreloadEnabledStateUpdatedEvent{
+		DisabledByDataSaver: true,
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.preloadEnabledStateUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if !result.DisabledByDataSaver {
+		t.Errorf("Expected preloading to be disabled by the data saver")
+	}
+
+	resultChan = make(chan *preload.PreloadEnabledStateUpdatedEvent)
+	mockSocket.Preload().OnPreloadEnabledStateUpdated(func(eventData *preload.PreloadEnabledStateUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.preloadEnabledStateUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnPrefetchStatusUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnPrefetchStatusUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.PrefetchStatusUpdatedEvent)
+	mockSocket.Preload().OnPrefetchStatusUpdated(func(eventData *preload.PrefetchStatusUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.PrefetchStatusUpdatedEvent{
+		Key: &preload.PreloadingAttemptKey{
+			Action: "Prefetch",
+			URL:    "https://example.com/next",
+		},
+		PrefetchURL:    "https://example.com/next",
+		Status:         preload.Ready,
+		PrefetchStatus: "PrefetchSuccessfulButNotUsed",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.prefetchStatusUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if preload.Ready != result.Status || "https://example.com/next" != result.PrefetchURL {
+		t.Errorf("Expected a ready prefetch, got %v", result)
+	}
+
+	resultChan = make(chan *preload.PrefetchStatusUpdatedEvent)
+	mockSocket.Preload().OnPrefetchStatusUpdated(func(eventData *preload.PrefetchStatusUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.prefetchStatusUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnPrerenderStatusUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnPrerenderStatusUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.PrerenderStatusUpdatedEvent)
+	mockSocket.Preload().OnPrerenderStatusUpdated(func(eventData *preload.PrerenderStatusUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.PrerenderStatusUpdatedEvent{
+		Key: &preload.PreloadingAttemptKey{
+			Action: "Prerender",
+			URL:    "https://example.com/next",
+		},
+		Status:          preload.Success,
+		PrerenderStatus: preload.Activated,
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.prerenderStatusUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if preload.Success != result.Status || preload.Activated != result.PrerenderStatus {
+		t.Errorf("Expected an activated prerender, got %v", result)
+	}
+
+	resultChan = make(chan *preload.PrerenderStatusUpdatedEvent)
+	mockSocket.Preload().OnPrerenderStatusUpdated(func(eventData *preload.PrerenderStatusUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.prerenderStatusUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestPreloadOnPreloadingAttemptSourcesUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPreloadOnPreloadingAttemptSourcesUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *preload.PreloadingAttemptSourcesUpdatedEvent)
+	mockSocket.Preload().OnPreloadingAttemptSourcesUpdated(func(eventData *preload.PreloadingAttemptSourcesUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &preload.PreloadingAttemptSourcesUpdatedEvent{
+		PreloadingAttemptSources: []*preload.PreloadingAttemptSource{{
+			RuleSetIDs: []preload.RuleSetID{"1"},
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Preload.preloadingAttemptSourcesUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if 1 != len(result.PreloadingAttemptSources) {
+		t.Errorf("Expected 1 source, got %d", len(result.PreloadingAttemptSources))
+	}
+
+	resultChan = make(chan *preload.PreloadingAttemptSourcesUpdatedEvent)
+	mockSocket.Preload().OnPreloadingAttemptSourcesUpdated(func(eventData *preload.PreloadingAttemptSourcesUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Preload.preloadingAttemptSourcesUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -28,7 +28,7 @@ func (protocol *ProfilerProtocol) Disable() <-chan *profiler.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,7 +50,7 @@ func (protocol *ProfilerProtocol) Enable() <-chan *profiler.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -73,7 +73,7 @@ func (protocol *ProfilerProtocol) GetBestEffortCoverage() <-chan *profiler.GetBe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -100,7 +100,7 @@ func (protocol *ProfilerProtocol) SetSamplingInterval(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -122,7 +122,7 @@ func (protocol *ProfilerProtocol) Start() <-chan *profiler.StartResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -148,7 +148,7 @@ func (protocol *ProfilerProtocol) StartPreciseCoverage(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -171,7 +171,7 @@ func (protocol *ProfilerProtocol) StartTypeProfile() <-chan *profiler.StartTypeP
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -193,7 +193,7 @@ func (protocol *ProfilerProtocol) Stop() <-chan *profiler.StopResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -218,7 +218,7 @@ func (protocol *ProfilerProtocol) StopPreciseCoverage() <-chan *profiler.StopPre
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -242,7 +242,7 @@ func (protocol *ProfilerProtocol) StopTypeProfile() <-chan *profiler.StopTypePro
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -265,7 +265,7 @@ func (protocol *ProfilerProtocol) TakePreciseCoverage() <-chan *profiler.TakePre
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -290,7 +290,7 @@ func (protocol *ProfilerProtocol) TakeTypeProfile() <-chan *profiler.TakeTypePro
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -309,7 +309,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consolePr
 */
 func (protocol *ProfilerProtocol) OnConsoleProfileFinished(
 	callback func(event *profiler.ConsoleProfileFinishedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Profiler.consoleProfileFinished",
 		func(response *Response) {
@@ -321,7 +321,28 @@ func (protocol *ProfilerProtocol) OnConsoleProfileFinished(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ConsoleProfileFinishedChan returns a channel of Profiler.consoleProfileFinished events, as an alternative to OnConsoleProfileFinished.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ProfilerProtocol) ConsoleProfileFinishedChan(
+	buffer int,
+) (<-chan *profiler.ConsoleProfileFinishedEvent, func()) {
+	eventCh := make(chan *profiler.ConsoleProfileFinishedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnConsoleProfileFinished(func(event *profiler.ConsoleProfileFinishedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -333,7 +354,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consolePr
 */
 func (protocol *ProfilerProtocol) OnConsoleProfileStarted(
 	callback func(event *profiler.ConsoleProfileStartedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Profiler.consoleProfileStarted",
 		func(response *Response) {
@@ -345,5 +366,26 @@ func (protocol *ProfilerProtocol) OnConsoleProfileStarted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ConsoleProfileStartedChan returns a channel of Profiler.consoleProfileStarted events, as an alternative to OnConsoleProfileStarted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ProfilerProtocol) ConsoleProfileStartedChan(
+	buffer int,
+) (<-chan *profiler.ConsoleProfileStartedEvent, func()) {
+	eventCh := make(chan *profiler.ConsoleProfileStartedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnConsoleProfileStarted(func(event *profiler.ConsoleProfileStartedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
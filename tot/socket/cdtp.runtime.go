@@ -36,7 +36,7 @@ func (protocol *RuntimeProtocol) AwaitPromise(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -63,7 +63,7 @@ func (protocol *RuntimeProtocol) CallFunctionOn(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -89,7 +89,7 @@ func (protocol *RuntimeProtocol) CompileScript(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -113,7 +113,7 @@ func (protocol *RuntimeProtocol) Disable() <-chan *runtime.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -135,7 +135,7 @@ func (protocol *RuntimeProtocol) DiscardConsoleEntries() <-chan *runtime.Discard
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -159,7 +159,7 @@ func (protocol *RuntimeProtocol) Enable() <-chan *runtime.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -183,7 +183,7 @@ func (protocol *RuntimeProtocol) Evaluate(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -210,7 +210,7 @@ func (protocol *RuntimeProtocol) GetProperties(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -237,7 +237,7 @@ func (protocol *RuntimeProtocol) GlobalLexicalScopeNames(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -263,7 +263,7 @@ func (protocol *RuntimeProtocol) QueryObjects(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -289,7 +289,7 @@ func (protocol *RuntimeProtocol) ReleaseObject(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -313,7 +313,7 @@ func (protocol *RuntimeProtocol) ReleaseObjectGroup(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -336,7 +336,7 @@ func (protocol *RuntimeProtocol) RunIfWaitingForDebugger() <-chan *runtime.RunIf
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -360,7 +360,7 @@ func (protocol *RuntimeProtocol) RunScript(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -387,7 +387,7 @@ func (protocol *RuntimeProtocol) SetCustomObjectFormatterEnabled(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -404,7 +404,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-consoleAPI
 */
 func (protocol *RuntimeProtocol) OnConsoleAPICalled(
 	callback func(event *runtime.ConsoleAPICalledEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.consoleAPICalled",
 		func(response *Response) {
@@ -416,7 +416,28 @@ func (protocol *RuntimeProtocol) OnConsoleAPICalled(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ConsoleAPICalledChan returns a channel of Runtime.consoleAPICalled events, as an alternative to OnConsoleAPICalled.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ConsoleAPICalledChan(
+	buffer int,
+) (<-chan *runtime.ConsoleAPICalledEvent, func()) {
+	eventCh := make(chan *runtime.ConsoleAPICalledEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnConsoleAPICalled(func(event *runtime.ConsoleAPICalledEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -427,7 +448,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionR
 */
 func (protocol *RuntimeProtocol) OnExceptionRevoked(
 	callback func(event *runtime.ExceptionRevokedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.exceptionRevoked",
 		func(response *Response) {
@@ -439,7 +460,28 @@ func (protocol *RuntimeProtocol) OnExceptionRevoked(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ExceptionRevokedChan returns a channel of Runtime.exceptionRevoked events, as an alternative to OnExceptionRevoked.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ExceptionRevokedChan(
+	buffer int,
+) (<-chan *runtime.ExceptionRevokedEvent, func()) {
+	eventCh := make(chan *runtime.ExceptionRevokedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnExceptionRevoked(func(event *runtime.ExceptionRevokedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -450,7 +492,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionT
 */
 func (protocol *RuntimeProtocol) OnExceptionThrown(
 	callback func(event *runtime.ExceptionThrownEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.exceptionThrown",
 		func(response *Response) {
@@ -462,7 +504,28 @@ func (protocol *RuntimeProtocol) OnExceptionThrown(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ExceptionThrownChan returns a channel of Runtime.exceptionThrown events, as an alternative to OnExceptionThrown.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ExceptionThrownChan(
+	buffer int,
+) (<-chan *runtime.ExceptionThrownEvent, func()) {
+	eventCh := make(chan *runtime.ExceptionThrownEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnExceptionThrown(func(event *runtime.ExceptionThrownEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -474,7 +537,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextCreated(
 	callback func(event *runtime.ExecutionContextCreatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.executionContextCreated",
 		func(response *Response) {
@@ -486,7 +549,28 @@ func (protocol *RuntimeProtocol) OnExecutionContextCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ExecutionContextCreatedChan returns a channel of Runtime.executionContextCreated events, as an alternative to OnExecutionContextCreated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ExecutionContextCreatedChan(
+	buffer int,
+) (<-chan *runtime.ExecutionContextCreatedEvent, func()) {
+	eventCh := make(chan *runtime.ExecutionContextCreatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnExecutionContextCreated(func(event *runtime.ExecutionContextCreatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -498,7 +582,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextDestroyed(
 	callback func(event *runtime.ExecutionContextDestroyedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.executionContextDestroyed",
 		func(response *Response) {
@@ -510,7 +594,28 @@ func (protocol *RuntimeProtocol) OnExecutionContextDestroyed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ExecutionContextDestroyedChan returns a channel of Runtime.executionContextDestroyed events, as an alternative to OnExecutionContextDestroyed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ExecutionContextDestroyedChan(
+	buffer int,
+) (<-chan *runtime.ExecutionContextDestroyedEvent, func()) {
+	eventCh := make(chan *runtime.ExecutionContextDestroyedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnExecutionContextDestroyed(func(event *runtime.ExecutionContextDestroyedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -522,7 +627,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextsCleared(
 	callback func(event *runtime.ExecutionContextsClearedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.executionContextsCleared",
 		func(response *Response) {
@@ -534,7 +639,28 @@ func (protocol *RuntimeProtocol) OnExecutionContextsCleared(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ExecutionContextsClearedChan returns a channel of Runtime.executionContextsCleared events, as an alternative to OnExecutionContextsCleared.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) ExecutionContextsClearedChan(
+	buffer int,
+) (<-chan *runtime.ExecutionContextsClearedEvent, func()) {
+	eventCh := make(chan *runtime.ExecutionContextsClearedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnExecutionContextsCleared(func(event *runtime.ExecutionContextsClearedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -546,7 +672,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-inspectReq
 */
 func (protocol *RuntimeProtocol) OnInspectRequested(
 	callback func(event *runtime.InspectRequestedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Runtime.inspectRequested",
 		func(response *Response) {
@@ -558,5 +684,26 @@ func (protocol *RuntimeProtocol) OnInspectRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+InspectRequestedChan returns a channel of Runtime.inspectRequested events, as an alternative to OnInspectRequested.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *RuntimeProtocol) InspectRequestedChan(
+	buffer int,
+) (<-chan *runtime.InspectRequestedEvent, func()) {
+	eventCh := make(chan *runtime.InspectRequestedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnInspectRequested(func(event *runtime.InspectRequestedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
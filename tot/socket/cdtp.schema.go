@@ -29,7 +29,7 @@ func (protocol *SchemaProtocol) GetDomains() <-chan *schema.GetDomainsResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
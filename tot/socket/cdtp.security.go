@@ -28,7 +28,7 @@ func (protocol *SecurityProtocol) Disable() <-chan *security.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,7 +50,7 @@ func (protocol *SecurityProtocol) Enable() <-chan *security.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,7 +75,7 @@ func (protocol *SecurityProtocol) HandleCertificateError(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -101,7 +101,7 @@ func (protocol *SecurityProtocol) SetIgnoreCertificateErrors(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -127,7 +127,7 @@ func (protocol *SecurityProtocol) SetOverrideCertificateErrors(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -147,7 +147,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-certifica
 */
 func (protocol *SecurityProtocol) OnCertificateError(
 	callback func(event *security.CertificateErrorEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Security.certificateError",
 		func(response *Response) {
@@ -159,7 +159,28 @@ func (protocol *SecurityProtocol) OnCertificateError(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CertificateErrorChan returns a channel of Security.certificateError events, as an alternative to OnCertificateError.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *SecurityProtocol) CertificateErrorChan(
+	buffer int,
+) (<-chan *security.CertificateErrorEvent, func()) {
+	eventCh := make(chan *security.CertificateErrorEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCertificateError(func(event *security.CertificateErrorEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -170,7 +191,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-securityS
 */
 func (protocol *SecurityProtocol) OnSecurityStateChanged(
 	callback func(event *security.StateChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Security.securityStateChanged",
 		func(response *Response) {
@@ -182,5 +203,26 @@ func (protocol *SecurityProtocol) OnSecurityStateChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SecurityStateChangedChan returns a channel of Security.securityStateChanged events, as an alternative to OnSecurityStateChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *SecurityProtocol) SecurityStateChangedChan(
+	buffer int,
+) (<-chan *security.StateChangedEvent, func()) {
+	eventCh := make(chan *security.StateChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnSecurityStateChanged(func(event *security.StateChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -33,7 +33,7 @@ func (protocol *ServiceWorkerProtocol) DeliverPushMessage(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -56,7 +56,7 @@ func (protocol *ServiceWorkerProtocol) Disable() <-chan *worker.DisableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -81,7 +81,7 @@ func (protocol *ServiceWorkerProtocol) DispatchSyncEvent(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -104,7 +104,7 @@ func (protocol *ServiceWorkerProtocol) Enable() <-chan *worker.EnableResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -129,7 +129,7 @@ func (protocol *ServiceWorkerProtocol) InspectWorker(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -154,7 +154,7 @@ func (protocol *ServiceWorkerProtocol) SetForceUpdateOnPageLoad(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -179,7 +179,7 @@ func (protocol *ServiceWorkerProtocol) SkipWaiting(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -204,7 +204,7 @@ func (protocol *ServiceWorkerProtocol) StartWorker(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -227,7 +227,7 @@ func (protocol *ServiceWorkerProtocol) StopAllWorkers() <-chan *worker.StopAllWo
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -252,7 +252,7 @@ func (protocol *ServiceWorkerProtocol) StopWorker(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -277,7 +277,7 @@ func (protocol *ServiceWorkerProtocol) Unregister(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -302,7 +302,7 @@ func (protocol *ServiceWorkerProtocol) UpdateRegistration(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -319,7 +319,7 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerErrorReported(
 	callback func(event *worker.ErrorReportedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"ServiceWorker.workerErrorReported",
 		func(response *Response) {
@@ -331,7 +331,28 @@ func (protocol *ServiceWorkerProtocol) OnWorkerErrorReported(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WorkerErrorReportedChan returns a channel of ServiceWorker.workerErrorReported events, as an alternative to OnWorkerErrorReported.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ServiceWorkerProtocol) WorkerErrorReportedChan(
+	buffer int,
+) (<-chan *worker.ErrorReportedEvent, func()) {
+	eventCh := make(chan *worker.ErrorReportedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWorkerErrorReported(func(event *worker.ErrorReportedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -342,7 +363,7 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerRegistrationUpdated(
 	callback func(event *worker.RegistrationUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"ServiceWorker.workerRegistrationUpdated",
 		func(response *Response) {
@@ -354,7 +375,28 @@ func (protocol *ServiceWorkerProtocol) OnWorkerRegistrationUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WorkerRegistrationUpdatedChan returns a channel of ServiceWorker.workerRegistrationUpdated events, as an alternative to OnWorkerRegistrationUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ServiceWorkerProtocol) WorkerRegistrationUpdatedChan(
+	buffer int,
+) (<-chan *worker.RegistrationUpdatedEvent, func()) {
+	eventCh := make(chan *worker.RegistrationUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWorkerRegistrationUpdated(func(event *worker.RegistrationUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -365,7 +407,7 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerVersionUpdated(
 	callback func(event *worker.VersionUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"ServiceWorker.workerVersionUpdated",
 		func(response *Response) {
@@ -377,5 +419,26 @@ func (protocol *ServiceWorkerProtocol) OnWorkerVersionUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+WorkerVersionUpdatedChan returns a channel of ServiceWorker.workerVersionUpdated events, as an alternative to OnWorkerVersionUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *ServiceWorkerProtocol) WorkerVersionUpdatedChan(
+	buffer int,
+) (<-chan *worker.VersionUpdatedEvent, func()) {
+	eventCh := make(chan *worker.VersionUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnWorkerVersionUpdated(func(event *worker.VersionUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -30,7 +30,190 @@ func (protocol *StorageProtocol) ClearDataForOrigin(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ClearSharedStorageEntries clears all entries for a given origin's shared
+storage. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearSharedStorageEntries
+*/
+func (protocol *StorageProtocol) ClearSharedStorageEntries(
+	params *storage.ClearSharedStorageEntriesParams,
+) <-chan *storage.ClearSharedStorageEntriesResult {
+	resultChan := make(chan *storage.ClearSharedStorageEntriesResult)
+	command := NewCommand(protocol.Socket, "Storage.clearSharedStorageEntries", params)
+	result := &storage.ClearSharedStorageEntriesResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ClearTrustTokens removes all Trust Tokens issued by the provided issuerOrigin.
+Leaves other stored data, including the issuer's Redemption Records, intact.
+EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearTrustTokens
+*/
+func (protocol *StorageProtocol) ClearTrustTokens(
+	params *storage.ClearTrustTokensParams,
+) <-chan *storage.ClearTrustTokensResult {
+	resultChan := make(chan *storage.ClearTrustTokensResult)
+	command := NewCommand(protocol.Socket, "Storage.clearTrustTokens", params)
+	result := &storage.ClearTrustTokensResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+DeleteSharedStorageEntry deletes an entry, if it exists, of an origin's shared
+storage. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-deleteSharedStorageEntry
+*/
+func (protocol *StorageProtocol) DeleteSharedStorageEntry(
+	params *storage.DeleteSharedStorageEntryParams,
+) <-chan *storage.DeleteSharedStorageEntryResult {
+	resultChan := make(chan *storage.DeleteSharedStorageEntryResult)
+	command := NewCommand(protocol.Socket, "Storage.deleteSharedStorageEntry", params)
+	result := &storage.DeleteSharedStorageEntryResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetInterestGroupDetails gets details for a named interest group. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getInterestGroupDetails
+*/
+func (protocol *StorageProtocol) GetInterestGroupDetails(
+	params *storage.GetInterestGroupDetailsParams,
+) <-chan *storage.GetInterestGroupDetailsResult {
+	resultChan := make(chan *storage.GetInterestGroupDetailsResult)
+	command := NewCommand(protocol.Socket, "Storage.getInterestGroupDetails", params)
+	result := &storage.GetInterestGroupDetailsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetSharedStorageEntries gets the entries of an origin's shared storage.
+EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageEntries
+*/
+func (protocol *StorageProtocol) GetSharedStorageEntries(
+	params *storage.GetSharedStorageEntriesParams,
+) <-chan *storage.GetSharedStorageEntriesResult {
+	resultChan := make(chan *storage.GetSharedStorageEntriesResult)
+	command := NewCommand(protocol.Socket, "Storage.getSharedStorageEntries", params)
+	result := &storage.GetSharedStorageEntriesResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetSharedStorageMetadata gets metadata for an origin's shared storage.
+EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageMetadata
+*/
+func (protocol *StorageProtocol) GetSharedStorageMetadata(
+	params *storage.GetSharedStorageMetadataParams,
+) <-chan *storage.GetSharedStorageMetadataResult {
+	resultChan := make(chan *storage.GetSharedStorageMetadataResult)
+	command := NewCommand(protocol.Socket, "Storage.getSharedStorageMetadata", params)
+	result := &storage.GetSharedStorageMetadataResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetTrustTokens returns the number of stored Trust Tokens per issuer for the
+current browsing context. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getTrustTokens
+*/
+func (protocol *StorageProtocol) GetTrustTokens() <-chan *storage.GetTrustTokensResult {
+	resultChan := make(chan *storage.GetTrustTokensResult)
+	command := NewCommand(protocol.Socket, "Storage.getTrustTokens", nil)
+	result := &storage.GetTrustTokensResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,7 +237,57 @@ func (protocol *StorageProtocol) GetUsageAndQuota(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ResetSharedStorageBudget resets the budget of an origin's shared storage by
+clearing all budget withdrawals. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-resetSharedStorageBudget
+*/
+func (protocol *StorageProtocol) ResetSharedStorageBudget(
+	params *storage.ResetSharedStorageBudgetParams,
+) <-chan *storage.ResetSharedStorageBudgetResult {
+	resultChan := make(chan *storage.ResetSharedStorageBudgetResult)
+	command := NewCommand(protocol.Socket, "Storage.resetSharedStorageBudget", params)
+	result := &storage.ResetSharedStorageBudgetResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SendPendingAttributionReports sends all pending Attribution Reports immediately,
+regardless of their scheduled report time. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-sendPendingAttributionReports
+*/
+func (protocol *StorageProtocol) SendPendingAttributionReports() <-chan *storage.SendPendingAttributionReportsResult {
+	resultChan := make(chan *storage.SendPendingAttributionReportsResult)
+	command := NewCommand(protocol.Socket, "Storage.sendPendingAttributionReports", nil)
+	result := &storage.SendPendingAttributionReportsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -65,6 +298,131 @@ func (protocol *StorageProtocol) GetUsageAndQuota(
 	return resultChan
 }
 
+/*
+SetAttributionReportingLocalTestingMode enables or disables the Attribution
+Reporting local testing mode, which suppresses noise and sends reports
+immediately. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingLocalTestingMode
+*/
+func (protocol *StorageProtocol) SetAttributionReportingLocalTestingMode(
+	params *storage.SetAttributionReportingLocalTestingModeParams,
+) <-chan *storage.SetAttributionReportingLocalTestingModeResult {
+	resultChan := make(chan *storage.SetAttributionReportingLocalTestingModeResult)
+	command := NewCommand(protocol.Socket, "Storage.setAttributionReportingLocalTestingMode", params)
+	result := &storage.SetAttributionReportingLocalTestingModeResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetAttributionReportingTracking enables/disables issuing of Attribution
+Reporting events. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingTracking
+*/
+func (protocol *StorageProtocol) SetAttributionReportingTracking(
+	params *storage.SetAttributionReportingTrackingParams,
+) <-chan *storage.SetAttributionReportingTrackingResult {
+	resultChan := make(chan *storage.SetAttributionReportingTrackingResult)
+	command := NewCommand(protocol.Socket, "Storage.setAttributionReportingTracking", params)
+	result := &storage.SetAttributionReportingTrackingResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetInterestGroupTracking enables/disables issuing of interestGroupAccessed
+events. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setInterestGroupTracking
+*/
+func (protocol *StorageProtocol) SetInterestGroupTracking(
+	params *storage.SetInterestGroupTrackingParams,
+) <-chan *storage.SetInterestGroupTrackingResult {
+	resultChan := make(chan *storage.SetInterestGroupTrackingResult)
+	command := NewCommand(protocol.Socket, "Storage.setInterestGroupTracking", params)
+	result := &storage.SetInterestGroupTrackingResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetSharedStorageEntry sets an entry of an origin's shared storage. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageEntry
+*/
+func (protocol *StorageProtocol) SetSharedStorageEntry(
+	params *storage.SetSharedStorageEntryParams,
+) <-chan *storage.SetSharedStorageEntryResult {
+	resultChan := make(chan *storage.SetSharedStorageEntryResult)
+	command := NewCommand(protocol.Socket, "Storage.setSharedStorageEntry", params)
+	result := &storage.SetSharedStorageEntryResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetSharedStorageTracking enables/disables issuing of sharedStorageAccessed
+events. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageTracking
+*/
+func (protocol *StorageProtocol) SetSharedStorageTracking(
+	params *storage.SetSharedStorageTrackingParams,
+) <-chan *storage.SetSharedStorageTrackingResult {
+	resultChan := make(chan *storage.SetSharedStorageTrackingResult)
+	command := NewCommand(protocol.Socket, "Storage.setSharedStorageTracking", params)
+	result := &storage.SetSharedStorageTrackingResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 TrackCacheStorageForOrigin registers origin to be notified when an update occurs
 to its cache storage list.
@@ -81,7 +439,7 @@ func (protocol *StorageProtocol) TrackCacheStorageForOrigin(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -106,7 +464,7 @@ func (protocol *StorageProtocol) TrackIndexedDBForOrigin(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -131,7 +489,7 @@ func (protocol *StorageProtocol) UntrackCacheStorageForOrigin(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -156,7 +514,7 @@ func (protocol *StorageProtocol) UntrackIndexedDBForOrigin(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -174,7 +532,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStora
 */
 func (protocol *StorageProtocol) OnCacheStorageContentUpdated(
 	callback func(event *storage.CacheStorageContentUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Storage.cacheStorageContentUpdated",
 		func(response *Response) {
@@ -186,7 +544,28 @@ func (protocol *StorageProtocol) OnCacheStorageContentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CacheStorageContentUpdatedChan returns a channel of Storage.cacheStorageContentUpdated events, as an alternative to OnCacheStorageContentUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *StorageProtocol) CacheStorageContentUpdatedChan(
+	buffer int,
+) (<-chan *storage.CacheStorageContentUpdatedEvent, func()) {
+	eventCh := make(chan *storage.CacheStorageContentUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCacheStorageContentUpdated(func(event *storage.CacheStorageContentUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -197,7 +576,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStora
 */
 func (protocol *StorageProtocol) OnCacheStorageListUpdated(
 	callback func(event *storage.CacheStorageListUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Storage.cacheStorageListUpdated",
 		func(response *Response) {
@@ -209,7 +588,28 @@ func (protocol *StorageProtocol) OnCacheStorageListUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CacheStorageListUpdatedChan returns a channel of Storage.cacheStorageListUpdated events, as an alternative to OnCacheStorageListUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *StorageProtocol) CacheStorageListUpdatedChan(
+	buffer int,
+) (<-chan *storage.CacheStorageListUpdatedEvent, func()) {
+	eventCh := make(chan *storage.CacheStorageListUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCacheStorageListUpdated(func(event *storage.CacheStorageListUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -221,7 +621,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBC
 */
 func (protocol *StorageProtocol) OnIndexedDBContentUpdated(
 	callback func(event *storage.IndexedDBContentUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Storage.indexedDBContentUpdated",
 		func(response *Response) {
@@ -233,7 +633,28 @@ func (protocol *StorageProtocol) OnIndexedDBContentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+IndexedDBContentUpdatedChan returns a channel of Storage.indexedDBContentUpdated events, as an alternative to OnIndexedDBContentUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *StorageProtocol) IndexedDBContentUpdatedChan(
+	buffer int,
+) (<-chan *storage.IndexedDBContentUpdatedEvent, func()) {
+	eventCh := make(chan *storage.IndexedDBContentUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnIndexedDBContentUpdated(func(event *storage.IndexedDBContentUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -245,7 +666,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBL
 */
 func (protocol *StorageProtocol) OnIndexedDBListUpdated(
 	callback func(event *storage.IndexedDBListUpdatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Storage.indexedDBListUpdated",
 		func(response *Response) {
@@ -257,5 +678,26 @@ func (protocol *StorageProtocol) OnIndexedDBListUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+IndexedDBListUpdatedChan returns a channel of Storage.indexedDBListUpdated events, as an alternative to OnIndexedDBListUpdated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *StorageProtocol) IndexedDBListUpdatedChan(
+	buffer int,
+) (<-chan *storage.IndexedDBListUpdatedEvent, func()) {
+	eventCh := make(chan *storage.IndexedDBListUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnIndexedDBListUpdated(func(event *storage.IndexedDBListUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -46,6 +46,289 @@ func TestStorageClearDataForOrigin(t *testing.T) {
 	}
 }
 
+func TestStorageClearSharedStorageEntries(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageClearSharedStorageEntries")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.ClearSharedStorageEntriesParams{
+		OwnerOrigin: "https://example.com",
+	}
+	resultChan := mockSocket.Storage().ClearSharedStorageEntries(params)
+	mockResult := &storage.ClearSharedStorageEntriesResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().ClearSharedStorageEntries(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageClearTrustTokens(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageClearTrustTokens")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.ClearTrustTokensParams{
+		IssuerOrigin: "https://issuer.example",
+	}
+	resultChan := mockSocket.Storage().ClearTrustTokens(params)
+	mockResult := &storage.ClearTrustTokensResult{
+		DidDeleteTokens: true,
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if !result.DidDeleteTokens {
+		t.Errorf("Expected deleted tokens, got %+v", result)
+	}
+
+	resultChan = mockSocket.Storage().ClearTrustTokens(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageDeleteSharedStorageEntry(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageDeleteSharedStorageEntry")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.DeleteSharedStorageEntryParams{
+		OwnerOrigin: "https://example.com",
+		Key:         "key",
+	}
+	resultChan := mockSocket.Storage().DeleteSharedStorageEntry(params)
+	mockResult := &storage.DeleteSharedStorageEntryResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().DeleteSharedStorageEntry(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageGetInterestGroupDetails(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageGetInterestGroupDetails")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.GetInterestGroupDetailsParams{
+		OwnerOrigin: "https://example.com",
+		Name:        "group",
+	}
+	resultChan := mockSocket.Storage().GetInterestGroupDetails(params)
+	mockResult := &storage.GetInterestGroupDetailsResult{
+		Details: map[string]interface{}{"name": "group"},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if "group" != result.Details["name"] {
+		t.Errorf("Expected group, got %+v", result.Details)
+	}
+
+	resultChan = mockSocket.Storage().GetInterestGroupDetails(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageGetSharedStorageEntries(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageGetSharedStorageEntries")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.GetSharedStorageEntriesParams{
+		OwnerOrigin: "https://example.com",
+	}
+	resultChan := mockSocket.Storage().GetSharedStorageEntries(params)
+	mockResult := &storage.GetSharedStorageEntriesResult{
+		Entries: []*storage.SharedStorageEntry{{Key: "key", Value: "value"}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if 1 != len(result.Entries) || "value" != result.Entries[0].Value {
+		t.Errorf("Expected 1 entry, got %+v", result.Entries)
+	}
+
+	resultChan = mockSocket.Storage().GetSharedStorageEntries(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageGetSharedStorageMetadata(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageGetSharedStorageMetadata")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.GetSharedStorageMetadataParams{
+		OwnerOrigin: "https://example.com",
+	}
+	resultChan := mockSocket.Storage().GetSharedStorageMetadata(params)
+	mockResult := &storage.GetSharedStorageMetadataResult{
+		Metadata: &storage.SharedStorageMetadata{Length: 2, RemainingBudget: 12},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if nil == result.Metadata || 2 != result.Metadata.Length {
+		t.Errorf("Expected 2 entries, got %+v", result.Metadata)
+	}
+
+	resultChan = mockSocket.Storage().GetSharedStorageMetadata(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageGetTrustTokens(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageGetTrustTokens")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Storage().GetTrustTokens()
+	mockResult := &storage.GetTrustTokensResult{
+		Tokens: []*storage.TrustTokens{{IssuerOrigin: "https://issuer.example", Count: 3}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if 1 != len(result.Tokens) || 3 != result.Tokens[0].Count {
+		t.Errorf("Expected 3 tokens, got %+v", result.Tokens)
+	}
+
+	resultChan = mockSocket.Storage().GetTrustTokens()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestStorageGetUsageAndQuota(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestStorageGetUsageAndQuota")
 	mockSocket := NewMock(socketURL)
@@ -93,6 +376,269 @@ func TestStorageGetUsageAndQuota(t *testing.T) {
 	}
 }
 
+func TestStorageResetSharedStorageBudget(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageResetSharedStorageBudget")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.ResetSharedStorageBudgetParams{
+		OwnerOrigin: "https://example.com",
+	}
+	resultChan := mockSocket.Storage().ResetSharedStorageBudget(params)
+	mockResult := &storage.ResetSharedStorageBudgetResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().ResetSharedStorageBudget(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSendPendingAttributionReports(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSendPendingAttributionReports")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.Storage().SendPendingAttributionReports()
+	mockResult := &storage.SendPendingAttributionReportsResult{
+		NumSent: 2,
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if 2 != result.NumSent {
+		t.Errorf("Expected 2 reports, got %d", result.NumSent)
+	}
+
+	resultChan = mockSocket.Storage().SendPendingAttributionReports()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSetAttributionReportingLocalTestingMode(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSetAttributionReportingLocalTestingMode")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.SetAttributionReportingLocalTestingModeParams{
+		Enabled: true,
+	}
+	resultChan := mockSocket.Storage().SetAttributionReportingLocalTestingMode(params)
+	mockResult := &storage.SetAttributionReportingLocalTestingModeResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().SetAttributionReportingLocalTestingMode(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSetAttributionReportingTracking(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSetAttributionReportingTracking")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.SetAttributionReportingTrackingParams{
+		Enable: true,
+	}
+	resultChan := mockSocket.Storage().SetAttributionReportingTracking(params)
+	mockResult := &storage.SetAttributionReportingTrackingResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().SetAttributionReportingTracking(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSetInterestGroupTracking(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSetInterestGroupTracking")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.SetInterestGroupTrackingParams{
+		Enable: true,
+	}
+	resultChan := mockSocket.Storage().SetInterestGroupTracking(params)
+	mockResult := &storage.SetInterestGroupTrackingResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().SetInterestGroupTracking(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSetSharedStorageEntry(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSetSharedStorageEntry")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.SetSharedStorageEntryParams{
+		OwnerOrigin: "https://example.com",
+		Key:         "key",
+		Value:       "value",
+	}
+	resultChan := mockSocket.Storage().SetSharedStorageEntry(params)
+	mockResult := &storage.SetSharedStorageEntryResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().SetSharedStorageEntry(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestStorageSetSharedStorageTracking(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestStorageSetSharedStorageTracking")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &storage.SetSharedStorageTrackingParams{
+		Enable: true,
+	}
+	resultChan := mockSocket.Storage().SetSharedStorageTracking(params)
+	mockResult := &storage.SetSharedStorageTrackingResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Storage().SetSharedStorageTracking(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestStorageTrackCacheStorageForOrigin(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestStorageTrackCacheStorageForOrigin")
 	mockSocket := NewMock(socketURL)
@@ -300,7 +846,9 @@ func TestStorageOnCacheStorageListUpdated(t *testing.T) {
 		resultChan <- eventData
 	})
 	mockResult := &storage.CacheStorageListUpdatedEvent{
-		Origin: "origin",
+		Origin:     "origin",
+		StorageKey: "https://example.com/",
+		BucketID:   "bucket",
 	}
 	mockResultBytes, _ := json.Marshal(mockResult)
 	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
@@ -316,6 +864,9 @@ func TestStorageOnCacheStorageListUpdated(t *testing.T) {
 	if mockResult.Origin != result.Origin {
 		t.Errorf("Expected %s, got %s", mockResult.Origin, result.Origin)
 	}
+	if mockResult.StorageKey != result.StorageKey || mockResult.BucketID != result.BucketID {
+		t.Errorf("Expected %s %s, got %s %s", mockResult.StorageKey, mockResult.BucketID, result.StorageKey, result.BucketID)
+	}
 
 	resultChan = make(chan *storage.CacheStorageListUpdatedEvent)
 	mockSocket.Storage().OnCacheStorageListUpdated(func(eventData *storage.CacheStorageListUpdatedEvent) {
@@ -396,7 +947,9 @@ func TestStorageOnIndexedDBListUpdated(t *testing.T) {
 		resultChan <- eventData
 	})
 	mockResult := &storage.IndexedDBListUpdatedEvent{
-		Origin: "origin",
+		Origin:     "origin",
+		StorageKey: "https://example.com/",
+		BucketID:   "bucket",
 	}
 	mockResultBytes, _ := json.Marshal(mockResult)
 	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
@@ -412,6 +965,9 @@ func TestStorageOnIndexedDBListUpdated(t *testing.T) {
 	if mockResult.Origin != result.Origin {
 		t.Errorf("Expected %s, got %s", mockResult.Origin, result.Origin)
 	}
+	if mockResult.StorageKey != result.StorageKey || mockResult.BucketID != result.BucketID {
+		t.Errorf("Expected %s %s, got %s %s", mockResult.StorageKey, mockResult.BucketID, result.StorageKey, result.BucketID)
+	}
 
 	resultChan = make(chan *storage.IndexedDBListUpdatedEvent)
 	mockSocket.Storage().OnIndexedDBListUpdated(func(eventData *storage.IndexedDBListUpdatedEvent) {
@@ -31,7 +31,7 @@ func (protocol *TargetProtocol) ActivateTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,7 +55,7 @@ func (protocol *TargetProtocol) AttachToTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -81,7 +81,7 @@ func (protocol *TargetProtocol) CloseTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -99,15 +99,17 @@ profile but you can have more than one.
 https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createBrowserContext
 EXPERIMENTAL.
 */
-func (protocol *TargetProtocol) CreateBrowserContext() <-chan *target.CreateBrowserContextResult {
+func (protocol *TargetProtocol) CreateBrowserContext(
+	params *target.CreateBrowserContextParams,
+) <-chan *target.CreateBrowserContextResult {
 	resultChan := make(chan *target.CreateBrowserContextResult)
-	command := NewCommand(protocol.Socket, "Target.createBrowserContext", nil)
+	command := NewCommand(protocol.Socket, "Target.createBrowserContext", params)
 	result := &target.CreateBrowserContextResult{}
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -133,7 +135,7 @@ func (protocol *TargetProtocol) CreateTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -159,7 +161,7 @@ func (protocol *TargetProtocol) DetachFromTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -185,7 +187,7 @@ func (protocol *TargetProtocol) DisposeBrowserContext(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -196,6 +198,42 @@ func (protocol *TargetProtocol) DisposeBrowserContext(
 	return resultChan
 }
 
+/*
+ExposeDevToolsProtocol injects an object into the target's main frame that
+provides a communication channel with the browser target, letting the page
+send protocol commands. The params must include target.GrantProtocolAccess()
+and the command must pass the socket's exposure guard, if any, see
+WithProtocolExposureGuard. Otherwise it isn't sent and the result has a
+SocketProtocolExposureDenied error.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-exposeDevToolsProtocol
+EXPERIMENTAL.
+*/
+func (protocol *TargetProtocol) ExposeDevToolsProtocol(
+	params *target.ExposeDevToolsProtocolParams,
+) <-chan *target.ExposeDevToolsProtocolResult {
+	resultChan := make(chan *target.ExposeDevToolsProtocolResult)
+	command := NewCommand(protocol.Socket, "Target.exposeDevToolsProtocol", params)
+	result := &target.ExposeDevToolsProtocolResult{}
+
+	go func() {
+		if err := guardExposure(protocol.Socket, params); nil != err {
+			result.Err = err
+			resultChan <- result
+			close(resultChan)
+			return
+		}
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
 /*
 GetTargetInfo returns information about a target.
 
@@ -212,7 +250,7 @@ func (protocol *TargetProtocol) GetTargetInfo(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -238,7 +276,7 @@ func (protocol *TargetProtocol) GetTargets(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -262,7 +300,7 @@ func (protocol *TargetProtocol) SendMessageToTarget(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -287,7 +325,7 @@ func (protocol *TargetProtocol) SetAttachToFrames(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -314,7 +352,7 @@ func (protocol *TargetProtocol) SetAutoAttach(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -339,7 +377,7 @@ func (protocol *TargetProtocol) SetDiscoverTargets(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -365,7 +403,7 @@ func (protocol *TargetProtocol) SetRemoteLocations(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -383,7 +421,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-attachedToT
 */
 func (protocol *TargetProtocol) OnAttachedToTarget(
 	callback func(event *target.AttachedToTargetEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.attachedToTarget",
 		func(response *Response) {
@@ -395,7 +433,28 @@ func (protocol *TargetProtocol) OnAttachedToTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AttachedToTargetChan returns a channel of Target.attachedToTarget events, as an alternative to OnAttachedToTarget.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) AttachedToTargetChan(
+	buffer int,
+) (<-chan *target.AttachedToTargetEvent, func()) {
+	eventCh := make(chan *target.AttachedToTargetEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAttachedToTarget(func(event *target.AttachedToTargetEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -409,7 +468,7 @@ EXPERIMENTAL.
 */
 func (protocol *TargetProtocol) OnDetachedFromTarget(
 	callback func(event *target.DetachedFromTargetEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.detachedFromTarget",
 		func(response *Response) {
@@ -421,7 +480,28 @@ func (protocol *TargetProtocol) OnDetachedFromTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DetachedFromTargetChan returns a channel of Target.detachedFromTarget events, as an alternative to OnDetachedFromTarget.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) DetachedFromTargetChan(
+	buffer int,
+) (<-chan *target.DetachedFromTargetEvent, func()) {
+	eventCh := make(chan *target.DetachedFromTargetEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDetachedFromTarget(func(event *target.DetachedFromTargetEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -433,7 +513,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-receivedMes
 */
 func (protocol *TargetProtocol) OnReceivedMessageFromTarget(
 	callback func(event *target.ReceivedMessageFromTargetEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.receivedMessageFromTarget",
 		func(response *Response) {
@@ -445,7 +525,28 @@ func (protocol *TargetProtocol) OnReceivedMessageFromTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+ReceivedMessageFromTargetChan returns a channel of Target.receivedMessageFromTarget events, as an alternative to OnReceivedMessageFromTarget.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) ReceivedMessageFromTargetChan(
+	buffer int,
+) (<-chan *target.ReceivedMessageFromTargetEvent, func()) {
+	eventCh := make(chan *target.ReceivedMessageFromTargetEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnReceivedMessageFromTarget(func(event *target.ReceivedMessageFromTargetEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -456,7 +557,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetCreat
 */
 func (protocol *TargetProtocol) OnTargetCreated(
 	callback func(event *target.CreatedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.targetCreated",
 		func(response *Response) {
@@ -468,7 +569,28 @@ func (protocol *TargetProtocol) OnTargetCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TargetCreatedChan returns a channel of Target.targetCreated events, as an alternative to OnTargetCreated.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) TargetCreatedChan(
+	buffer int,
+) (<-chan *target.CreatedEvent, func()) {
+	eventCh := make(chan *target.CreatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTargetCreated(func(event *target.CreatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -479,7 +601,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetDestr
 */
 func (protocol *TargetProtocol) OnTargetDestroyed(
 	callback func(event *target.DestroyedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.targetDestroyed",
 		func(response *Response) {
@@ -491,7 +613,28 @@ func (protocol *TargetProtocol) OnTargetDestroyed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TargetDestroyedChan returns a channel of Target.targetDestroyed events, as an alternative to OnTargetDestroyed.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) TargetDestroyedChan(
+	buffer int,
+) (<-chan *target.DestroyedEvent, func()) {
+	eventCh := make(chan *target.DestroyedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTargetDestroyed(func(event *target.DestroyedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -503,7 +646,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetInfoC
 */
 func (protocol *TargetProtocol) OnTargetInfoChanged(
 	callback func(event *target.InfoChangedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Target.targetInfoChanged",
 		func(response *Response) {
@@ -515,5 +658,26 @@ func (protocol *TargetProtocol) OnTargetInfoChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TargetInfoChangedChan returns a channel of Target.targetInfoChanged events, as an alternative to OnTargetInfoChanged.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TargetProtocol) TargetInfoChangedChan(
+	buffer int,
+) (<-chan *target.InfoChangedEvent, func()) {
+	eventCh := make(chan *target.InfoChangedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTargetInfoChanged(func(event *target.InfoChangedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
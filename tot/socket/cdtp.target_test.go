@@ -135,7 +135,9 @@ func TestTargetCreateBrowserContext(t *testing.T) {
 	mockSocket.Listen()
 	defer mockSocket.Stop()
 
-	resultChan := mockSocket.Target().CreateBrowserContext()
+	resultChan := mockSocket.Target().CreateBrowserContext(&target.CreateBrowserContextParams{
+		ProxyServer: "http://proxy:3128",
+	})
 	mockResult := &target.CreateBrowserContextResult{
 		BrowserContextID: target.BrowserContextID("BrowserContextID"),
 	}
@@ -153,7 +155,9 @@ func TestTargetCreateBrowserContext(t *testing.T) {
 		t.Errorf("Expected %s, got %s", mockResult.BrowserContextID, result.BrowserContextID)
 	}
 
-	resultChan = mockSocket.Target().CreateBrowserContext()
+	resultChan = mockSocket.Target().CreateBrowserContext(&target.CreateBrowserContextParams{
+		ProxyServer: "http://proxy:3128",
+	})
 	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
 		ID: mockSocket.CurCommandID(),
 		Error: &Error{
@@ -259,7 +263,7 @@ func TestTargetDisposeBrowserContext(t *testing.T) {
 	defer mockSocket.Stop()
 
 	params := &target.DisposeBrowserContextParams{
-		ID: target.ID("ID"),
+		BrowserContextID: target.BrowserContextID("BrowserContextID"),
 	}
 	resultChan := mockSocket.Target().DisposeBrowserContext(params)
 	mockResult := &target.DisposeBrowserContextResult{
@@ -294,6 +298,44 @@ func TestTargetDisposeBrowserContext(t *testing.T) {
 	}
 }
 
+func TestTargetExposeDevToolsProtocol(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestTargetExposeDevToolsProtocol")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &target.ExposeDevToolsProtocolParams{
+		ID:     target.ID("ID"),
+		Access: target.GrantProtocolAccess(),
+	}
+	resultChan := mockSocket.Target().ExposeDevToolsProtocol(params)
+	mockResult := &target.ExposeDevToolsProtocolResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.Target().ExposeDevToolsProtocol(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
 func TestTargetGetTargetInfo(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestTargetGetTargetInfo")
 	mockSocket := NewMock(socketURL)
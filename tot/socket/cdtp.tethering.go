@@ -32,7 +32,7 @@ func (protocol *TetheringProtocol) Bind(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -56,7 +56,7 @@ func (protocol *TetheringProtocol) Unbind(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -73,7 +73,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tethering/#event-accepted
 */
 func (protocol *TetheringProtocol) OnAccepted(
 	callback func(event *tethering.AcceptedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Tethering.accepted",
 		func(response *Response) {
@@ -85,5 +85,26 @@ func (protocol *TetheringProtocol) OnAccepted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+AcceptedChan returns a channel of Tethering.accepted events, as an alternative to OnAccepted.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TetheringProtocol) AcceptedChan(
+	buffer int,
+) (<-chan *tethering.AcceptedEvent, func()) {
+	eventCh := make(chan *tethering.AcceptedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnAccepted(func(event *tethering.AcceptedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
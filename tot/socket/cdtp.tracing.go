@@ -28,7 +28,7 @@ func (protocol *TracingProtocol) End() <-chan *tracing.EndResult {
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,7 +50,7 @@ func (protocol *TracingProtocol) GetCategories() <-chan *tracing.GetCategoriesRe
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -75,7 +75,7 @@ func (protocol *TracingProtocol) RecordClockSyncMarker(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -97,7 +97,7 @@ func (protocol *TracingProtocol) RequestMemoryDump() <-chan *tracing.RequestMemo
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -122,7 +122,7 @@ func (protocol *TracingProtocol) Start(
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
 		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+			result.Err = response.Err()
 		}
 		resultChan <- result
 		close(resultChan)
@@ -139,7 +139,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-bufferUsag
 */
 func (protocol *TracingProtocol) OnBufferUsage(
 	callback func(event *tracing.BufferUsageEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Tracing.bufferUsage",
 		func(response *Response) {
@@ -151,7 +151,28 @@ func (protocol *TracingProtocol) OnBufferUsage(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+BufferUsageChan returns a channel of Tracing.bufferUsage events, as an alternative to OnBufferUsage.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TracingProtocol) BufferUsageChan(
+	buffer int,
+) (<-chan *tracing.BufferUsageEvent, func()) {
+	eventCh := make(chan *tracing.BufferUsageEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnBufferUsage(func(event *tracing.BufferUsageEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -164,7 +185,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-dataCollec
 */
 func (protocol *TracingProtocol) OnDataCollected(
 	callback func(event *tracing.DataCollectedEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Tracing.dataCollected",
 		func(response *Response) {
@@ -176,7 +197,28 @@ func (protocol *TracingProtocol) OnDataCollected(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+DataCollectedChan returns a channel of Tracing.dataCollected events, as an alternative to OnDataCollected.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TracingProtocol) DataCollectedChan(
+	buffer int,
+) (<-chan *tracing.DataCollectedEvent, func()) {
+	eventCh := make(chan *tracing.DataCollectedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnDataCollected(func(event *tracing.DataCollectedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
 
 /*
@@ -188,7 +230,7 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-tracingCom
 */
 func (protocol *TracingProtocol) OnTracingComplete(
 	callback func(event *tracing.CompleteEvent),
-) {
+) *Subscription {
 	handler := NewEventHandler(
 		"Tracing.tracingComplete",
 		func(response *Response) {
@@ -200,5 +242,26 @@ func (protocol *TracingProtocol) OnTracingComplete(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+TracingCompleteChan returns a channel of Tracing.tracingComplete events, as an alternative to OnTracingComplete.
+The returned function removes the event handler and closes the channel. Event
+handlers run concurrently, so events are not guaranteed to arrive in order.
+*/
+func (protocol *TracingProtocol) TracingCompleteChan(
+	buffer int,
+) (<-chan *tracing.CompleteEvent, func()) {
+	eventCh := make(chan *tracing.CompleteEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnTracingComplete(func(event *tracing.CompleteEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
 }
@@ -0,0 +1,528 @@
+package socket
+
+import (
+	"encoding/json"
+
+	"github.com/mkenney/go-chrome/tot/web/authn"
+)
+
+/*
+WebAuthnProtocol provides a namespace for the Chrome WebAuthn protocol methods.
+The WebAuthn protocol allows configuring virtual authenticators to test the
+WebAuthn API, e.g. passkey and FIDO2 flows, without hardware security keys.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/
+*/
+type WebAuthnProtocol struct {
+	Socket Socketer
+}
+
+/*
+AddCredential adds a credential to the specified authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addCredential
+*/
+func (protocol *WebAuthnProtocol) AddCredential(
+	params *authn.AddCredentialParams,
+) <-chan *authn.AddCredentialResult {
+	resultChan := make(chan *authn.AddCredentialResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.addCredential", params)
+	result := &authn.AddCredentialResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+AddVirtualAuthenticator creates and adds a virtual authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addVirtualAuthenticator
+*/
+func (protocol *WebAuthnProtocol) AddVirtualAuthenticator(
+	params *authn.AddVirtualAuthenticatorParams,
+) <-chan *authn.AddVirtualAuthenticatorResult {
+	resultChan := make(chan *authn.AddVirtualAuthenticatorResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.addVirtualAuthenticator", params)
+	result := &authn.AddVirtualAuthenticatorResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+ClearCredentials clears all the credentials from the specified device.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-clearCredentials
+*/
+func (protocol *WebAuthnProtocol) ClearCredentials(
+	params *authn.ClearCredentialsParams,
+) <-chan *authn.ClearCredentialsResult {
+	resultChan := make(chan *authn.ClearCredentialsResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.clearCredentials", params)
+	result := &authn.ClearCredentialsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Disable disables the WebAuthn domain.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-disable
+*/
+func (protocol *WebAuthnProtocol) Disable() <-chan *authn.DisableResult {
+	resultChan := make(chan *authn.DisableResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.disable", nil)
+	result := &authn.DisableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+Enable enables the WebAuthn domain and starts intercepting credential storage
+and retrieval with a virtual authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-enable
+*/
+func (protocol *WebAuthnProtocol) Enable(
+	params *authn.EnableParams,
+) <-chan *authn.EnableResult {
+	resultChan := make(chan *authn.EnableResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.enable", params)
+	result := &authn.EnableResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetCredential returns a single credential stored in the given virtual
+authenticator that matches the credential ID.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredential
+*/
+func (protocol *WebAuthnProtocol) GetCredential(
+	params *authn.GetCredentialParams,
+) <-chan *authn.GetCredentialResult {
+	resultChan := make(chan *authn.GetCredentialResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.getCredential", params)
+	result := &authn.GetCredentialResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+GetCredentials returns all the credentials stored in the given virtual
+authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredentials
+*/
+func (protocol *WebAuthnProtocol) GetCredentials(
+	params *authn.GetCredentialsParams,
+) <-chan *authn.GetCredentialsResult {
+	resultChan := make(chan *authn.GetCredentialsResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.getCredentials", params)
+	result := &authn.GetCredentialsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+RemoveCredential removes a credential from the authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeCredential
+*/
+func (protocol *WebAuthnProtocol) RemoveCredential(
+	params *authn.RemoveCredentialParams,
+) <-chan *authn.RemoveCredentialResult {
+	resultChan := make(chan *authn.RemoveCredentialResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.removeCredential", params)
+	result := &authn.RemoveCredentialResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+RemoveVirtualAuthenticator removes the given authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeVirtualAuthenticator
+*/
+func (protocol *WebAuthnProtocol) RemoveVirtualAuthenticator(
+	params *authn.RemoveVirtualAuthenticatorParams,
+) <-chan *authn.RemoveVirtualAuthenticatorResult {
+	resultChan := make(chan *authn.RemoveVirtualAuthenticatorResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.removeVirtualAuthenticator", params)
+	result := &authn.RemoveVirtualAuthenticatorResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetAutomaticPresenceSimulation sets whether tests of user presence will
+succeed immediately (if true) or fail to resolve (if false) for an
+authenticator. The default is true.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setAutomaticPresenceSimulation
+*/
+func (protocol *WebAuthnProtocol) SetAutomaticPresenceSimulation(
+	params *authn.SetAutomaticPresenceSimulationParams,
+) <-chan *authn.SetAutomaticPresenceSimulationResult {
+	resultChan := make(chan *authn.SetAutomaticPresenceSimulationResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.setAutomaticPresenceSimulation", params)
+	result := &authn.SetAutomaticPresenceSimulationResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetCredentialProperties allows setting credential properties.
+https://w3c.github.io/webauthn/#sctn-automation-set-credential-properties
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setCredentialProperties
+*/
+func (protocol *WebAuthnProtocol) SetCredentialProperties(
+	params *authn.SetCredentialPropertiesParams,
+) <-chan *authn.SetCredentialPropertiesResult {
+	resultChan := make(chan *authn.SetCredentialPropertiesResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.setCredentialProperties", params)
+	result := &authn.SetCredentialPropertiesResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetResponseOverrideBits resets parameters isBogusSignature, isBadUV, isBadUP
+to false if they are not present.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setResponseOverrideBits
+*/
+func (protocol *WebAuthnProtocol) SetResponseOverrideBits(
+	params *authn.SetResponseOverrideBitsParams,
+) <-chan *authn.SetResponseOverrideBitsResult {
+	resultChan := make(chan *authn.SetResponseOverrideBitsResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.setResponseOverrideBits", params)
+	result := &authn.SetResponseOverrideBitsResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+SetUserVerified sets whether User Verification succeeds or fails for an
+authenticator. The default is true.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setUserVerified
+*/
+func (protocol *WebAuthnProtocol) SetUserVerified(
+	params *authn.SetUserVerifiedParams,
+) <-chan *authn.SetUserVerifiedResult {
+	resultChan := make(chan *authn.SetUserVerifiedResult)
+	command := NewCommand(protocol.Socket, "WebAuthn.setUserVerified", params)
+	result := &authn.SetUserVerifiedResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if nil != response.Error && 0 != response.Error.Code {
+			result.Err = response.Err()
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+OnCredentialAdded adds a handler to the WebAuthn.credentialAdded event.
+WebAuthn.credentialAdded fires when a
+credential is added to an authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialAdded
+*/
+func (protocol *WebAuthnProtocol) OnCredentialAdded(
+	callback func(event *authn.CredentialAddedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"WebAuthn.credentialAdded",
+		func(response *Response) {
+			event := &authn.CredentialAddedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CredentialAddedChan returns a channel of WebAuthn.credentialAdded events, as an alternative to
+OnCredentialAdded. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to
+arrive in order.
+*/
+func (protocol *WebAuthnProtocol) CredentialAddedChan(
+	buffer int,
+) (<-chan *authn.CredentialAddedEvent, func()) {
+	eventCh := make(chan *authn.CredentialAddedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCredentialAdded(func(event *authn.CredentialAddedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnCredentialAsserted adds a handler to the WebAuthn.credentialAsserted event.
+WebAuthn.credentialAsserted fires when a
+credential is used in a webauthn assertion.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialAsserted
+*/
+func (protocol *WebAuthnProtocol) OnCredentialAsserted(
+	callback func(event *authn.CredentialAssertedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"WebAuthn.credentialAsserted",
+		func(response *Response) {
+			event := &authn.CredentialAssertedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CredentialAssertedChan returns a channel of WebAuthn.credentialAsserted events, as an alternative to
+OnCredentialAsserted. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to
+arrive in order.
+*/
+func (protocol *WebAuthnProtocol) CredentialAssertedChan(
+	buffer int,
+) (<-chan *authn.CredentialAssertedEvent, func()) {
+	eventCh := make(chan *authn.CredentialAssertedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCredentialAsserted(func(event *authn.CredentialAssertedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnCredentialDeleted adds a handler to the WebAuthn.credentialDeleted event.
+WebAuthn.credentialDeleted fires when a
+credential is deleted, e.g. through
+PublicKeyCredential.signalUnknownCredential().
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialDeleted
+*/
+func (protocol *WebAuthnProtocol) OnCredentialDeleted(
+	callback func(event *authn.CredentialDeletedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"WebAuthn.credentialDeleted",
+		func(response *Response) {
+			event := &authn.CredentialDeletedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CredentialDeletedChan returns a channel of WebAuthn.credentialDeleted events, as an alternative to
+OnCredentialDeleted. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to
+arrive in order.
+*/
+func (protocol *WebAuthnProtocol) CredentialDeletedChan(
+	buffer int,
+) (<-chan *authn.CredentialDeletedEvent, func()) {
+	eventCh := make(chan *authn.CredentialDeletedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCredentialDeleted(func(event *authn.CredentialDeletedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
+
+/*
+OnCredentialUpdated adds a handler to the WebAuthn.credentialUpdated event.
+WebAuthn.credentialUpdated fires when a
+credential is updated, e.g. through
+PublicKeyCredential.signalCurrentUserDetails().
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialUpdated
+*/
+func (protocol *WebAuthnProtocol) OnCredentialUpdated(
+	callback func(event *authn.CredentialUpdatedEvent),
+) *Subscription {
+	handler := NewEventHandler(
+		"WebAuthn.credentialUpdated",
+		func(response *Response) {
+			event := &authn.CredentialUpdatedEvent{}
+			json.Unmarshal([]byte(response.Params), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	return protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+CredentialUpdatedChan returns a channel of WebAuthn.credentialUpdated events, as an alternative to
+OnCredentialUpdated. The returned function removes the event handler and closes the
+channel. Event handlers run concurrently, so events are not guaranteed to
+arrive in order.
+*/
+func (protocol *WebAuthnProtocol) CredentialUpdatedChan(
+	buffer int,
+) (<-chan *authn.CredentialUpdatedEvent, func()) {
+	eventCh := make(chan *authn.CredentialUpdatedEvent, buffer)
+	stream := newEventStream()
+	sub := protocol.OnCredentialUpdated(func(event *authn.CredentialUpdatedEvent) {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- event:
+			case <-done:
+			}
+		})
+	})
+	return eventCh, stream.cancel(sub, func() { close(eventCh) })
+}
@@ -0,0 +1,746 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/web/authn"
+)
+
+func TestWebAuthnAddCredential(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnAddCredential")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.AddCredentialParams{
+		AuthenticatorID: "AuthenticatorID",
+		Credential: &authn.Credential{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		},
+	}
+	resultChan := mockSocket.WebAuthn().AddCredential(params)
+	mockResult := &authn.AddCredentialResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().AddCredential(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnAddVirtualAuthenticator(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnAddVirtualAuthenticator")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.AddVirtualAuthenticatorParams{
+		Options: &authn.VirtualAuthenticatorOptions{
+			Protocol:            authn.AuthenticatorProtocol.Ctap2,
+			Ctap2Version:        authn.Ctap2Version.Ctap21,
+			Transport:           authn.AuthenticatorTransport.Internal,
+			HasResidentKey:      true,
+			HasUserVerification: true,
+			IsUserVerified:      true,
+		},
+	}
+	resultChan := mockSocket.WebAuthn().AddVirtualAuthenticator(params)
+	mockResult := &authn.AddVirtualAuthenticatorResult{
+		AuthenticatorID: "AuthenticatorID",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if mockResult.AuthenticatorID != result.AuthenticatorID {
+		t.Errorf("Expected %s, got %s", mockResult.AuthenticatorID, result.AuthenticatorID)
+	}
+
+	resultChan = mockSocket.WebAuthn().AddVirtualAuthenticator(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnClearCredentials(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnClearCredentials")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.ClearCredentialsParams{
+		AuthenticatorID: "AuthenticatorID",
+	}
+	resultChan := mockSocket.WebAuthn().ClearCredentials(params)
+	mockResult := &authn.ClearCredentialsResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().ClearCredentials(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnDisable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnDisable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := mockSocket.WebAuthn().Disable()
+	mockResult := &authn.DisableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().Disable()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnEnable(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnEnable")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.EnableParams{
+		EnableUI: true,
+	}
+	resultChan := mockSocket.WebAuthn().Enable(params)
+	mockResult := &authn.EnableResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().Enable(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnGetCredential(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnGetCredential")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.GetCredentialParams{
+		AuthenticatorID: "AuthenticatorID",
+		CredentialID:    "Q3JlZGVudGlhbElE",
+	}
+	resultChan := mockSocket.WebAuthn().GetCredential(params)
+	mockResult := &authn.GetCredentialResult{
+		Credential: &authn.Credential{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if nil == result.Credential || mockResult.Credential.CredentialID != result.Credential.CredentialID {
+		t.Errorf("Expected %v, got %v", mockResult.Credential, result.Credential)
+	}
+
+	resultChan = mockSocket.WebAuthn().GetCredential(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnGetCredentials(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnGetCredentials")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.GetCredentialsParams{
+		AuthenticatorID: "AuthenticatorID",
+	}
+	resultChan := mockSocket.WebAuthn().GetCredentials(params)
+	mockResult := &authn.GetCredentialsResult{
+		Credentials: []*authn.Credential{{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if 1 != len(result.Credentials) || 1 != result.Credentials[0].SignCount {
+		t.Errorf("Expected %v, got %v", mockResult.Credentials, result.Credentials)
+	}
+
+	resultChan = mockSocket.WebAuthn().GetCredentials(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnRemoveCredential(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnRemoveCredential")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.RemoveCredentialParams{
+		AuthenticatorID: "AuthenticatorID",
+		CredentialID:    "Q3JlZGVudGlhbElE",
+	}
+	resultChan := mockSocket.WebAuthn().RemoveCredential(params)
+	mockResult := &authn.RemoveCredentialResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().RemoveCredential(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnRemoveVirtualAuthenticator(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnRemoveVirtualAuthenticator")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.RemoveVirtualAuthenticatorParams{
+		AuthenticatorID: "AuthenticatorID",
+	}
+	resultChan := mockSocket.WebAuthn().RemoveVirtualAuthenticator(params)
+	mockResult := &authn.RemoveVirtualAuthenticatorResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().RemoveVirtualAuthenticator(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnSetAutomaticPresenceSimulation(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnSetAutomaticPresenceSimulation")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.SetAutomaticPresenceSimulationParams{
+		AuthenticatorID: "AuthenticatorID",
+		Enabled:         false,
+	}
+	resultChan := mockSocket.WebAuthn().SetAutomaticPresenceSimulation(params)
+	mockResult := &authn.SetAutomaticPresenceSimulationResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().SetAutomaticPresenceSimulation(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnSetCredentialProperties(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnSetCredentialProperties")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.SetCredentialPropertiesParams{
+		AuthenticatorID:   "AuthenticatorID",
+		CredentialID:      "Q3JlZGVudGlhbElE",
+		BackupEligibility: true,
+		BackupState:       true,
+	}
+	resultChan := mockSocket.WebAuthn().SetCredentialProperties(params)
+	mockResult := &authn.SetCredentialPropertiesResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().SetCredentialProperties(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnSetResponseOverrideBits(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnSetResponseOverrideBits")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.SetResponseOverrideBitsParams{
+		AuthenticatorID:  "AuthenticatorID",
+		IsBogusSignature: true,
+	}
+	resultChan := mockSocket.WebAuthn().SetResponseOverrideBits(params)
+	mockResult := &authn.SetResponseOverrideBitsResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().SetResponseOverrideBits(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnSetUserVerified(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnSetUserVerified")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &authn.SetUserVerifiedParams{
+		AuthenticatorID: "AuthenticatorID",
+		IsUserVerified:  false,
+	}
+	resultChan := mockSocket.WebAuthn().SetUserVerified(params)
+	mockResult := &authn.SetUserVerifiedResult{}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+
+	resultChan = mockSocket.WebAuthn().SetUserVerified(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnOnCredentialAdded(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnOnCredentialAdded")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *authn.CredentialAddedEvent)
+	mockSocket.WebAuthn().OnCredentialAdded(func(eventData *authn.CredentialAddedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &authn.CredentialAddedEvent{
+		AuthenticatorID: "AuthenticatorID",
+		Credential: &authn.Credential{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "WebAuthn.credentialAdded",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.Credential || "example.com" != result.Credential.RpID {
+		t.Errorf("Expected %v, got %v", mockResult.Credential, result.Credential)
+	}
+
+	resultChan = make(chan *authn.CredentialAddedEvent)
+	mockSocket.WebAuthn().OnCredentialAdded(func(eventData *authn.CredentialAddedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "WebAuthn.credentialAdded",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnOnCredentialAsserted(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnOnCredentialAsserted")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *authn.CredentialAssertedEvent)
+	mockSocket.WebAuthn().OnCredentialAsserted(func(eventData *authn.CredentialAssertedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &authn.CredentialAssertedEvent{
+		AuthenticatorID: "AuthenticatorID",
+		Credential: &authn.Credential{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "WebAuthn.credentialAsserted",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.Credential || "example.com" != result.Credential.RpID {
+		t.Errorf("Expected %v, got %v", mockResult.Credential, result.Credential)
+	}
+
+	resultChan = make(chan *authn.CredentialAssertedEvent)
+	mockSocket.WebAuthn().OnCredentialAsserted(func(eventData *authn.CredentialAssertedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "WebAuthn.credentialAsserted",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnOnCredentialDeleted(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnOnCredentialDeleted")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *authn.CredentialDeletedEvent)
+	mockSocket.WebAuthn().OnCredentialDeleted(func(eventData *authn.CredentialDeletedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &authn.CredentialDeletedEvent{
+		AuthenticatorID: "AuthenticatorID",
+		CredentialID:    "Q3JlZGVudGlhbElE",
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "WebAuthn.credentialDeleted",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if mockResult.CredentialID != result.CredentialID {
+		t.Errorf("Expected %s, got %s", mockResult.CredentialID, result.CredentialID)
+	}
+
+	resultChan = make(chan *authn.CredentialDeletedEvent)
+	mockSocket.WebAuthn().OnCredentialDeleted(func(eventData *authn.CredentialDeletedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "WebAuthn.credentialDeleted",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestWebAuthnOnCredentialUpdated(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestWebAuthnOnCredentialUpdated")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *authn.CredentialUpdatedEvent)
+	mockSocket.WebAuthn().OnCredentialUpdated(func(eventData *authn.CredentialUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockResult := &authn.CredentialUpdatedEvent{
+		AuthenticatorID: "AuthenticatorID",
+		Credential: &authn.Credential{
+			CredentialID:         "Q3JlZGVudGlhbElE",
+			IsResidentCredential: true,
+			RpID:                 "example.com",
+			PrivateKey:           "UHJpdmF0ZUtleQ==",
+			SignCount:            1,
+		},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "WebAuthn.credentialUpdated",
+		Params: mockResultBytes,
+	})
+	result := <-resultChan
+	if mockResult.Err != result.Err {
+		t.Errorf("Expected '%v', got: '%v'", mockResult, result)
+	}
+	if nil == result.Credential || "example.com" != result.Credential.RpID {
+		t.Errorf("Expected %v, got %v", mockResult.Credential, result.Credential)
+	}
+
+	resultChan = make(chan *authn.CredentialUpdatedEvent)
+	mockSocket.WebAuthn().OnCredentialUpdated(func(eventData *authn.CredentialUpdatedEvent) {
+		resultChan <- eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: 0,
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "WebAuthn.credentialUpdated",
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
@@ -0,0 +1,36 @@
+package socket
+
+/*
+BrowserProtocoller defines the protocol domains available on the browser
+target. A BrowserClient provides only these domains.
+
+https://chromedevtools.github.io/devtools-protocol/
+*/
+type BrowserProtocoller interface {
+	// Browser returns the BrowserProtocol instance.
+	Browser() *BrowserProtocol
+
+	// IO returns the IOProtocol instance.
+	IO() *IOProtocol
+
+	// Memory returns the MemoryProtocol instance.
+	Memory() *MemoryProtocol
+
+	// Schema returns the SchemaProtocol instance.
+	Schema() *SchemaProtocol
+
+	// Storage returns the StorageProtocol instance.
+	Storage() *StorageProtocol
+
+	// SystemInfo returns the SystemInfoProtocol instance.
+	SystemInfo() *SystemInfoProtocol
+
+	// Target returns the TargetProtocol instance.
+	Target() *TargetProtocol
+
+	// Tethering returns the TetheringProtocol instance.
+	Tethering() *TetheringProtocol
+
+	// Tracing returns the TracingProtocol instance.
+	Tracing() *TracingProtocol
+}
@@ -10,6 +10,16 @@ type CommandMapper interface {
 	// Get retrieves a command from the stack.
 	Get(commandID int) (Commander, error)
 
+	// Len returns the number of commands in the stack.
+	Len() int
+
+	// Pop retrieves and removes a command from the stack in a single
+	// operation so that only one caller can claim a command.
+	Pop(commandID int) (Commander, error)
+
+	// PopAll retrieves and removes all commands from the stack.
+	PopAll() []Commander
+
 	// Set sets a command in the stack.
 	Set(command Commander)
 }
@@ -1,5 +1,9 @@
 package socket
 
+import (
+	"time"
+)
+
 /*
 Commander defines the interface for websocket commands.
 */
@@ -28,4 +32,8 @@ type Commander interface {
 
 	// SetID sets the ID value
 	SetID(int)
+
+	// Timeout returns the command timeout. Zero uses the socket's default
+	// command timeout.
+	Timeout() time.Duration
 }
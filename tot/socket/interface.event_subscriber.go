@@ -0,0 +1,11 @@
+package socket
+
+/*
+EventSubscriber defines the interface for adding event handlers. Socketer
+implementations and tabs are event subscribers.
+*/
+type EventSubscriber interface {
+	// AddEventHandler adds an event handler to the stack of listeners for an
+	// event and returns a subscription that removes it.
+	AddEventHandler(handler EventHandler) *Subscription
+}
@@ -0,0 +1,30 @@
+package socket
+
+import (
+	"time"
+)
+
+/*
+MetricsCollector defines the interface for collecting socket metrics. Methods
+are called from the socket's goroutines and must be safe for concurrent use.
+*/
+type MetricsCollector interface {
+	// CommandSent is called when a command is sent.
+	CommandSent(method string)
+
+	// CommandCompleted is called once for each sent command when it's
+	// responded to, with the time it took and the response error, if any.
+	// Commands that time out, are canceled or lose their connection are
+	// completed with an error.
+	CommandCompleted(method string, duration time.Duration, err error)
+
+	// EventReceived is called with the method of each event received.
+	EventReceived(method string)
+
+	// Reconnected is called after the socket reconnects.
+	Reconnected()
+
+	// WebsocketError is called when reading from or writing to the websocket
+	// connection fails.
+	WebsocketError(err error)
+}
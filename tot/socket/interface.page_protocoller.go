@@ -0,0 +1,133 @@
+package socket
+
+/*
+PageProtocoller defines the protocol domains available on page targets, which
+excludes the browser level Browser, SystemInfo, Target, Tethering and Tracing
+domains. A PageSession provides only these domains.
+
+https://chromedevtools.github.io/devtools-protocol/
+*/
+type PageProtocoller interface {
+	// Accessibility returns the AccessibilityProtocol instance.
+	Accessibility() *AccessibilityProtocol
+
+	// Animation returns the AnimationProtocol instance.
+	Animation() *AnimationProtocol
+
+	// ApplicationCache returns the ApplicationCacheProtocol instance.
+	ApplicationCache() *ApplicationCacheProtocol
+
+	// Audits returns the AuditsProtocol instance.
+	Audits() *AuditsProtocol
+
+	// CacheStorage returns the CacheStorageProtocol instance.
+	CacheStorage() *CacheStorageProtocol
+
+	// Cast returns the CastProtocol instance.
+	Cast() *CastProtocol
+
+	// Console returns the ConsoleProtocol instance.
+	Console() *ConsoleProtocol
+
+	// CSS returns the CSSProtocol instance.
+	CSS() *CSSProtocol
+
+	// Database returns the DatabaseProtocol instance.
+	Database() *DatabaseProtocol
+
+	// Debugger returns the DebuggerProtocol instance.
+	Debugger() *DebuggerProtocol
+
+	// DeviceAccess returns the DeviceAccessProtocol instance.
+	DeviceAccess() *DeviceAccessProtocol
+
+	// DeviceOrientation returns the DeviceOrientationProtocol instance.
+	DeviceOrientation() *DeviceOrientationProtocol
+
+	// DOMDebugger returns the DOMDebuggerProtocol instance.
+	DOMDebugger() *DOMDebuggerProtocol
+
+	// DOMSnapshot returns the DOMSnapshotProtocol instance.
+	DOMSnapshot() *DOMSnapshotProtocol
+
+	// DOMStorage returns the DOMStorageProtocol instance.
+	DOMStorage() *DOMStorageProtocol
+
+	// DOM returns the DOMProtocol instance.
+	DOM() *DOMProtocol
+
+	// Emulation returns the EmulationProtocol instance.
+	Emulation() *EmulationProtocol
+
+	// FedCM returns the FedCMProtocol instance.
+	FedCM() *FedCMProtocol
+
+	// Fetch returns the FetchProtocol instance.
+	Fetch() *FetchProtocol
+
+	// HeadlessExperimental returns the HeadlessExperimentalProtocol instance.
+	HeadlessExperimental() *HeadlessExperimentalProtocol
+
+	// HeapProfiler returns the HeapProfilerProtocol instance.
+	HeapProfiler() *HeapProfilerProtocol
+
+	// IndexedDB returns the IndexedDBProtocol instance.
+	IndexedDB() *IndexedDBProtocol
+
+	// Input returns the InputProtocol instance.
+	Input() *InputProtocol
+
+	// Inspector returns the InspectorProtocol instance.
+	Inspector() *InspectorProtocol
+
+	// IO returns the IOProtocol instance.
+	IO() *IOProtocol
+
+	// LayerTree returns the LayerTreeProtocol instance.
+	LayerTree() *LayerTreeProtocol
+
+	// Log returns the LogProtocol instance.
+	Log() *LogProtocol
+
+	// Memory returns the MemoryProtocol instance.
+	Memory() *MemoryProtocol
+
+	// Network returns the NetworkProtocol instance.
+	Network() *NetworkProtocol
+
+	// Overlay returns the OverlayProtocol instance.
+	Overlay() *OverlayProtocol
+
+	// Page returns the PageProtocol instance.
+	Page() *PageProtocol
+
+	// Performance returns the PerformanceProtocol instance.
+	Performance() *PerformanceProtocol
+
+	// PerformanceTimeline returns the PerformanceTimelineProtocol instance.
+	PerformanceTimeline() *PerformanceTimelineProtocol
+
+	// Preload returns the PreloadProtocol instance.
+	Preload() *PreloadProtocol
+
+	// Profiler returns the ProfilerProtocol instance.
+	Profiler() *ProfilerProtocol
+
+	// Runtime returns the RuntimeProtocol instance.
+	Runtime() *RuntimeProtocol
+
+	// Schema returns the SchemaProtocol instance.
+	Schema() *SchemaProtocol
+
+	// Security returns the SecurityProtocol instance.
+	Security() *SecurityProtocol
+
+	// ServiceWorker returns the ServiceWorkerProtocol instance.
+	ServiceWorker() *ServiceWorkerProtocol
+
+	// Storage returns the StorageProtocol instance.
+	Storage() *StorageProtocol
+
+	// WebAuthn returns the WebAuthnProtocol instance.
+	WebAuthn() *WebAuthnProtocol
+}
@@ -24,6 +24,9 @@ type Protocoller interface {
 	// CacheStorage returns the CacheStorageProtocol instance.
 	CacheStorage() *CacheStorageProtocol
 
+	// Cast returns the CastProtocol instance.
+	Cast() *CastProtocol
+
 	// Console returns the ConsoleProtocol instance.
 	Console() *ConsoleProtocol
 
@@ -36,6 +39,9 @@ type Protocoller interface {
 	// Debugger returns the DebuggerProtocol instance.
 	Debugger() *DebuggerProtocol
 
+	// DeviceAccess returns the DeviceAccessProtocol instance.
+	DeviceAccess() *DeviceAccessProtocol
+
 	// DeviceOrientation returns the DeviceOrientationProtocol instance.
 	DeviceOrientation() *DeviceOrientationProtocol
 
@@ -54,6 +60,12 @@ type Protocoller interface {
 	// Emulation returns the EmulationProtocol instance.
 	Emulation() *EmulationProtocol
 
+	// FedCM returns the FedCMProtocol instance.
+	FedCM() *FedCMProtocol
+
+	// Fetch returns the FetchProtocol instance.
+	Fetch() *FetchProtocol
+
 	// HeadlessExperimental returns the HeadlessExperimentalProtocol instance.
 	HeadlessExperimental() *HeadlessExperimentalProtocol
 
@@ -66,6 +78,9 @@ type Protocoller interface {
 	// Input returns the InputProtocol instance.
 	Input() *InputProtocol
 
+	// Inspector returns the InspectorProtocol instance.
+	Inspector() *InspectorProtocol
+
 	// IO returns the IOProtocol instance.
 	IO() *IOProtocol
 
@@ -90,6 +105,12 @@ type Protocoller interface {
 	// Performance returns the PerformanceProtocol instance.
 	Performance() *PerformanceProtocol
 
+	// PerformanceTimeline returns the PerformanceTimelineProtocol instance.
+	PerformanceTimeline() *PerformanceTimelineProtocol
+
+	// Preload returns the PreloadProtocol instance.
+	Preload() *PreloadProtocol
+
 	// Profiler returns the ProfilerProtocol instance.
 	Profiler() *ProfilerProtocol
 
@@ -119,4 +140,7 @@ type Protocoller interface {
 
 	// Tracing returns the TracingProtocol instance.
 	Tracing() *TracingProtocol
+
+	// WebAuthn returns the WebAuthnProtocol instance.
+	WebAuthn() *WebAuthnProtocol
 }
@@ -1,6 +1,7 @@
 package socket
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -9,8 +10,8 @@ Socketer defines the websocket connection interface for managing sockets.
 */
 type Socketer interface {
 	// AddEventHandler adds an event handler to the stack of listeners for an
-	// event.
-	AddEventHandler(handler EventHandler)
+	// event and returns a subscription that removes it.
+	AddEventHandler(handler EventHandler) *Subscription
 
 	// CurCommandID returns the latest command ID.
 	CurCommandID() int
@@ -32,6 +33,10 @@ type Socketer interface {
 	// SendCommand delivers a command payload to the websocket connection.
 	SendCommand(command Commander) chan *Response
 
+	// SendCommandContext delivers a command payload to the websocket
+	// connection and stops waiting for the response when the context is done.
+	SendCommandContext(ctx context.Context, command Commander) chan *Response
+
 	// Stop signals the socket read loop to stop listening for data and close
 	// the websocket connection.
 	Stop()
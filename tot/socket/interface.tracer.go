@@ -0,0 +1,28 @@
+package socket
+
+import (
+	"context"
+)
+
+/*
+Tracer defines the interface for tracing commands, for example with
+OpenTelemetry. Methods are called from the socket's goroutines and must be safe
+for concurrent use.
+*/
+type Tracer interface {
+	// StartCommand starts a span for a command when it's sent. ctx is the
+	// context the command was sent with, or context.Background() for
+	// commands sent without one. sessionID is the flattened target session
+	// the command was sent to, if any.
+	StartCommand(ctx context.Context, command Commander, sessionID string) CommandSpan
+}
+
+/*
+CommandSpan defines the interface for the span of a command.
+*/
+type CommandSpan interface {
+	// End ends the span when the command is responded to. Commands that
+	// time out, are canceled or lose their connection end with an error
+	// response.
+	End(response *Response)
+}
@@ -0,0 +1,251 @@
+/*
+Package metrics collects socket metrics and exposes them to Prometheus in the
+Prometheus text exposition format, without depending on the Prometheus client
+library.
+
+	collector := metrics.NewPrometheus("chrome")
+	http.Handle("/metrics", collector)
+	sock := socket.New(socketURL, socket.WithMetrics(collector))
+
+The following metrics are exposed, prefixed with the namespace:
+
+	commands_in_flight                gauge      commands waiting for a response
+	command_duration_seconds{method}  histogram  command latency per CDP method
+	command_errors_total{method}      counter    commands completed with an error
+	events_total{domain}              counter    events received per CDP domain
+	reconnects_total                  counter    reconnects
+	websocket_errors_total            counter    websocket read and write errors
+*/
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+DefaultBuckets are the command latency histogram buckets, in seconds. They
+match the Prometheus client library defaults.
+*/
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+/*
+Prometheus is a socket.MetricsCollector that exposes the metrics to
+Prometheus. It's an http.Handler that serves the metrics.
+*/
+type Prometheus struct {
+	buckets         []float64
+	commandErrors   map[string]int64
+	durations       map[string]*histogram
+	events          map[string]int64
+	inFlight        int64
+	mux             *sync.Mutex
+	namespace       string
+	reconnects      int64
+	websocketErrors int64
+}
+
+/*
+NewPrometheus returns a collector with metric names prefixed with a namespace,
+"chrome" if empty. Command latency is recorded in buckets, DefaultBuckets if
+none are specified.
+*/
+func NewPrometheus(namespace string, buckets ...float64) *Prometheus {
+	if "" == namespace {
+		namespace = "chrome"
+	}
+	if 0 == len(buckets) {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	return &Prometheus{
+		buckets:       buckets,
+		commandErrors: make(map[string]int64),
+		durations:     make(map[string]*histogram),
+		events:        make(map[string]int64),
+		mux:           &sync.Mutex{},
+		namespace:     namespace,
+	}
+}
+
+/*
+histogram is a cumulative latency histogram.
+*/
+type histogram struct {
+	count  int64
+	counts []int64
+	sum    float64
+}
+
+/*
+CommandSent is a socket.MetricsCollector implementation.
+*/
+func (prom *Prometheus) CommandSent(method string) {
+	prom.mux.Lock()
+	prom.inFlight++
+	prom.mux.Unlock()
+}
+
+/*
+CommandCompleted is a socket.MetricsCollector implementation.
+*/
+func (prom *Prometheus) CommandCompleted(method string, duration time.Duration, err error) {
+	prom.mux.Lock()
+	defer prom.mux.Unlock()
+	prom.inFlight--
+	if nil != err {
+		prom.commandErrors[method]++
+	}
+
+	hist, ok := prom.durations[method]
+	if !ok {
+		hist = &histogram{counts: make([]int64, len(prom.buckets))}
+		prom.durations[method] = hist
+	}
+	seconds := duration.Seconds()
+	for a, bucket := range prom.buckets {
+		if seconds <= bucket {
+			hist.counts[a]++
+		}
+	}
+	hist.count++
+	hist.sum += seconds
+}
+
+/*
+EventReceived is a socket.MetricsCollector implementation. Events are counted
+per domain.
+*/
+func (prom *Prometheus) EventReceived(method string) {
+	domain := method
+	if index := strings.Index(method, "."); index > 0 {
+		domain = method[:index]
+	}
+	prom.mux.Lock()
+	prom.events[domain]++
+	prom.mux.Unlock()
+}
+
+/*
+Reconnected is a socket.MetricsCollector implementation.
+*/
+func (prom *Prometheus) Reconnected() {
+	prom.mux.Lock()
+	prom.reconnects++
+	prom.mux.Unlock()
+}
+
+/*
+WebsocketError is a socket.MetricsCollector implementation.
+*/
+func (prom *Prometheus) WebsocketError(err error) {
+	prom.mux.Lock()
+	prom.websocketErrors++
+	prom.mux.Unlock()
+}
+
+/*
+ServeHTTP serves the metrics in the Prometheus text exposition format.
+*/
+func (prom *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	prom.WriteTo(w)
+}
+
+/*
+WriteTo writes the metrics in the Prometheus text exposition format.
+*/
+func (prom *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+
+	prom.mux.Lock()
+	prom.writeHeader(buf, "commands_in_flight", "gauge", "Commands waiting for a response.")
+	fmt.Fprintf(buf, "%s_commands_in_flight %d\n", prom.namespace, prom.inFlight)
+
+	prom.writeHeader(buf, "command_duration_seconds", "histogram", "Command latency per CDP method.")
+	for _, method := range sortedKeys(prom.durations) {
+		hist := prom.durations[method]
+		label := quote(method)
+		for a, bucket := range prom.buckets {
+			fmt.Fprintf(buf, "%s_command_duration_seconds_bucket{method=%s,le=%q} %d\n", prom.namespace, label, formatFloat(bucket), hist.counts[a])
+		}
+		fmt.Fprintf(buf, "%s_command_duration_seconds_bucket{method=%s,le=\"+Inf\"} %d\n", prom.namespace, label, hist.count)
+		fmt.Fprintf(buf, "%s_command_duration_seconds_sum{method=%s} %s\n", prom.namespace, label, formatFloat(hist.sum))
+		fmt.Fprintf(buf, "%s_command_duration_seconds_count{method=%s} %d\n", prom.namespace, label, hist.count)
+	}
+
+	prom.writeHeader(buf, "command_errors_total", "counter", "Commands completed with an error per CDP method.")
+	for _, method := range sortedKeys(prom.commandErrors) {
+		fmt.Fprintf(buf, "%s_command_errors_total{method=%s} %d\n", prom.namespace, quote(method), prom.commandErrors[method])
+	}
+
+	prom.writeHeader(buf, "events_total", "counter", "Events received per CDP domain.")
+	for _, domain := range sortedKeys(prom.events) {
+		fmt.Fprintf(buf, "%s_events_total{domain=%s} %d\n", prom.namespace, quote(domain), prom.events[domain])
+	}
+
+	prom.writeHeader(buf, "reconnects_total", "counter", "Websocket reconnects.")
+	fmt.Fprintf(buf, "%s_reconnects_total %d\n", prom.namespace, prom.reconnects)
+
+	prom.writeHeader(buf, "websocket_errors_total", "counter", "Websocket read and write errors.")
+	fmt.Fprintf(buf, "%s_websocket_errors_total %d\n", prom.namespace, prom.websocketErrors)
+	prom.mux.Unlock()
+
+	return buf.WriteTo(w)
+}
+
+/*
+writeHeader writes the HELP and TYPE lines of a metric.
+*/
+func (prom *Prometheus) writeHeader(buf *bytes.Buffer, name, kind, help string) {
+	fmt.Fprintf(buf, "# HELP %s_%s %s\n", prom.namespace, name, help)
+	fmt.Fprintf(buf, "# TYPE %s_%s %s\n", prom.namespace, name, kind)
+}
+
+/*
+formatFloat formats a float the way Prometheus expects.
+*/
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+/*
+quote quotes a label value, escaping backslashes, double quotes and newlines.
+*/
+func quote(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	return `"` + value + `"`
+}
+
+/*
+sortedKeys returns the keys of a metric map in order.
+*/
+func sortedKeys(values interface{}) []string {
+	keys := make([]string, 0)
+	switch values := values.(type) {
+	case map[string]int64:
+		for key := range values {
+			keys = append(keys, key)
+		}
+	case map[string]*histogram:
+		for key := range values {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheus(t *testing.T) {
+	prom := NewPrometheus("", 0.1, 0.01)
+	prom.CommandSent("Page.navigate")
+	prom.CommandSent("Page.navigate")
+	prom.CommandSent("Runtime.evaluate")
+	prom.CommandCompleted("Page.navigate", 5*time.Millisecond, nil)
+	prom.CommandCompleted("Page.navigate", 50*time.Millisecond, errors.New("failed"))
+	prom.EventReceived("Network.requestWillBeSent")
+	prom.EventReceived("Network.loadingFinished")
+	prom.EventReceived("Page.loadEventFired")
+	prom.Reconnected()
+	prom.WebsocketError(errors.New("read failed"))
+
+	buf := &bytes.Buffer{}
+	if _, err := prom.WriteTo(buf); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	output := buf.String()
+	for _, line := range []string{
+		"# TYPE chrome_commands_in_flight gauge",
+		"chrome_commands_in_flight 1",
+		"# TYPE chrome_command_duration_seconds histogram",
+		`chrome_command_duration_seconds_bucket{method="Page.navigate",le="0.01"} 1`,
+		`chrome_command_duration_seconds_bucket{method="Page.navigate",le="0.1"} 2`,
+		`chrome_command_duration_seconds_bucket{method="Page.navigate",le="+Inf"} 2`,
+		`chrome_command_duration_seconds_sum{method="Page.navigate"} 0.055`,
+		`chrome_command_duration_seconds_count{method="Page.navigate"} 2`,
+		`chrome_command_errors_total{method="Page.navigate"} 1`,
+		`chrome_events_total{domain="Network"} 2`,
+		`chrome_events_total{domain="Page"} 1`,
+		"chrome_reconnects_total 1",
+		"chrome_websocket_errors_total 1",
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Expected '%s' in:\n%s", line, output)
+		}
+	}
+	if strings.Index(output, `domain="Network"`) > strings.Index(output, `domain="Page"`) {
+		t.Errorf("Expected labels to be sorted")
+	}
+
+	recorder := httptest.NewRecorder()
+	NewPrometheus("browser").ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Expected a text response, got %s", recorder.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(recorder.Body.String(), "browser_reconnects_total 0\n") {
+		t.Errorf("Expected the namespace to be used, got:\n%s", recorder.Body.String())
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if `"a\\b\"c\nd"` != quote("a\\b\"c\nd") {
+		t.Errorf("Expected the label value to be escaped, got %s", quote("a\\b\"c\nd"))
+	}
+}
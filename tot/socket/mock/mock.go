@@ -0,0 +1,68 @@
+/*
+Package mock provides a Socketer for unit testing code built on the protocol
+wrappers without a browser or a websocket server. Responses are scripted per
+method, events are injected synchronously and the commands sent are recorded
+for assertions:
+
+	sock := mock.New()
+	sock.Respond("Runtime.evaluate", map[string]interface{}{
+		"result": map[string]interface{}{"type": "string", "value": "Example Domain"},
+	})
+
+	title, err := pageTitle(sock)
+	...
+	sock.AssertCalledWith(t, "Runtime.evaluate", &runtime.EvaluateParams{Expression: "document.title"})
+
+Commands without a scripted response receive an empty result. For tests that
+need the websocket protocol itself, see the cdptest package.
+*/
+package mock
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Call is a command sent to the mock socket.
+*/
+type Call struct {
+	ID     int
+	Method string
+	Params json.RawMessage
+}
+
+/*
+HandlerFunc generates the result for a command. Returning a non-nil error sends
+a protocol error response instead of a result.
+*/
+type HandlerFunc func(params json.RawMessage) (result interface{}, err *socket.Error)
+
+/*
+TestingT is the part of testing.TB used by the assertions.
+*/
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Helper()
+}
+
+/*
+equalJSON returns whether two JSON values are equal, treating a missing value
+as null.
+*/
+func equalJSON(a, b []byte) bool {
+	var valueA, valueB interface{}
+	if 0 < len(a) {
+		if err := json.Unmarshal(a, &valueA); nil != err {
+			return false
+		}
+	}
+	if 0 < len(b) {
+		if err := json.Unmarshal(b, &valueB); nil != err {
+			return false
+		}
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"testing"
+)
+
+func TestEqualJSON(t *testing.T) {
+	for _, test := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{`{"a":1,"b":2}`, `{"b":2,"a":1}`, true},
+		{``, `null`, true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`{"a":1}`, `not json`, false},
+	} {
+		if test.equal != equalJSON([]byte(test.a), []byte(test.b)) {
+			t.Errorf("Expected equalJSON(%s, %s) to be %v", test.a, test.b, test.equal)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Socket is a mock Socketer. The protocol interfaces are embedded, so it can be
+passed wherever a socket.Socketer or socket.Protocoller is expected.
+*/
+type Socket struct {
+	calls     []*Call
+	commandID int64
+	errCh     chan error
+	handlers  map[string]HandlerFunc
+	listeners socket.EventHandlerMapper
+	mux       *sync.Mutex
+	once      map[string][]HandlerFunc
+	url       *url.URL
+
+	// Protocol interfaces for the API.
+	*socket.Protocols
+}
+
+/*
+New returns a mock socket.
+*/
+func New() *Socket {
+	sock := &Socket{
+		calls:     make([]*Call, 0),
+		errCh:     make(chan error, 1),
+		handlers:  make(map[string]HandlerFunc),
+		listeners: socket.NewEventHandlerMap(),
+		mux:       &sync.Mutex{},
+		once:      make(map[string][]HandlerFunc),
+		url:       &url.URL{Scheme: "mock"},
+	}
+	sock.Protocols = socket.NewProtocols(sock)
+	return sock
+}
+
+/*
+Handle registers a handler that generates responses for the specified command.
+*/
+func (sock *Socket) Handle(method string, handler HandlerFunc) {
+	sock.mux.Lock()
+	sock.handlers[method] = handler
+	sock.mux.Unlock()
+}
+
+/*
+Respond registers a canned result for the specified command.
+*/
+func (sock *Socket) Respond(method string, result interface{}) {
+	sock.Handle(method, func(params json.RawMessage) (interface{}, *socket.Error) {
+		return result, nil
+	})
+}
+
+/*
+RespondOnce queues a result for the next call of the specified command. Queued
+results are used in order before the handler registered with Handle, Respond
+or Fail, for scripting a sequence of responses.
+*/
+func (sock *Socket) RespondOnce(method string, result interface{}) {
+	sock.mux.Lock()
+	sock.once[method] = append(sock.once[method], func(params json.RawMessage) (interface{}, *socket.Error) {
+		return result, nil
+	})
+	sock.mux.Unlock()
+}
+
+/*
+Fail registers a protocol error response for the specified command.
+*/
+func (sock *Socket) Fail(method string, code int, message string) {
+	sock.Handle(method, func(params json.RawMessage) (interface{}, *socket.Error) {
+		return nil, &socket.Error{
+			Code:    code,
+			Data:    []byte(fmt.Sprintf("%q", message)),
+			Message: message,
+		}
+	})
+}
+
+/*
+Emit passes an event to the event handlers. Emit returns after the handlers
+have returned, so their effects can be asserted right away.
+*/
+func (sock *Socket) Emit(method string, params interface{}) error {
+	data, err := json.Marshal(params)
+	if nil != err {
+		return err
+	}
+	response := &socket.Response{
+		Method: method,
+		Params: data,
+	}
+
+	handlers, err := sock.listeners.Get(method)
+	if nil != err {
+		return nil
+	}
+	for _, handler := range handlers {
+		handler.Handle(response)
+	}
+	return nil
+}
+
+/*
+Calls returns the calls of a command in the order they were sent, or every call
+if the method is empty.
+*/
+func (sock *Socket) Calls(method string) []*Call {
+	sock.mux.Lock()
+	defer sock.mux.Unlock()
+	calls := make([]*Call, 0)
+	for _, call := range sock.calls {
+		if "" == method || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+/*
+Reset removes the recorded calls and the queued results. Handlers registered
+with Handle, Respond or Fail and event handlers are kept.
+*/
+func (sock *Socket) Reset() {
+	sock.mux.Lock()
+	sock.calls = make([]*Call, 0)
+	sock.once = make(map[string][]HandlerFunc)
+	sock.mux.Unlock()
+}
+
+/*
+AssertCalled fails the test if the command wasn't sent and returns its latest
+call.
+*/
+func (sock *Socket) AssertCalled(t TestingT, method string) *Call {
+	t.Helper()
+	calls := sock.Calls(method)
+	if 0 == len(calls) {
+		t.Errorf("Expected '%s' to be called", method)
+		return nil
+	}
+	return calls[len(calls)-1]
+}
+
+/*
+AssertCalledWith fails the test if the command wasn't sent with parameters that
+encode to the same JSON as params.
+*/
+func (sock *Socket) AssertCalledWith(t TestingT, method string, params interface{}) {
+	t.Helper()
+	expected, err := json.Marshal(params)
+	if nil != err {
+		t.Errorf("Could not encode the expected parameters of '%s': %v", method, err)
+		return
+	}
+	calls := sock.Calls(method)
+	for _, call := range calls {
+		if equalJSON(expected, call.Params) {
+			return
+		}
+	}
+	if 0 == len(calls) {
+		t.Errorf("Expected '%s' to be called with %s, it wasn't called", method, expected)
+		return
+	}
+	t.Errorf("Expected '%s' to be called with %s, it was called with %s", method, expected, calls[len(calls)-1].Params)
+}
+
+/*
+AssertCallCount fails the test if the command wasn't sent exactly count times.
+*/
+func (sock *Socket) AssertCallCount(t TestingT, method string, count int) {
+	t.Helper()
+	if calls := sock.Calls(method); count != len(calls) {
+		t.Errorf("Expected '%s' to be called %d time(s), it was called %d time(s)", method, count, len(calls))
+	}
+}
+
+/*
+AssertNotCalled fails the test if the command was sent.
+*/
+func (sock *Socket) AssertNotCalled(t TestingT, method string) {
+	t.Helper()
+	if calls := sock.Calls(method); 0 != len(calls) {
+		t.Errorf("Expected '%s' not to be called, it was called %d time(s)", method, len(calls))
+	}
+}
+
+/*
+AddEventHandler adds an event handler to the stack of listeners for an event.
+
+AddEventHandler is a Socketer implementation.
+*/
+func (sock *Socket) AddEventHandler(handler socket.EventHandler) *socket.Subscription {
+	sock.listeners.Add(handler)
+	return socket.NewSubscription(handler, func() error {
+		return sock.RemoveEventHandler(handler)
+	})
+}
+
+/*
+CurCommandID returns the latest command ID.
+
+CurCommandID is a Socketer implementation.
+*/
+func (sock *Socket) CurCommandID() int {
+	return int(atomic.LoadInt64(&sock.commandID))
+}
+
+/*
+Errors returns the error channel. The mock socket doesn't report errors on it.
+
+Errors is a Socketer implementation.
+*/
+func (sock *Socket) Errors() chan error {
+	return sock.errCh
+}
+
+/*
+Listen is a no-op.
+
+Listen is a Socketer implementation.
+*/
+func (sock *Socket) Listen() {
+}
+
+/*
+NextCommandID generates and returns the next command ID.
+
+NextCommandID is a Socketer implementation.
+*/
+func (sock *Socket) NextCommandID() int {
+	return int(atomic.AddInt64(&sock.commandID, 1))
+}
+
+/*
+RemoveEventHandler removes a handler from the stack of listeners for an event.
+
+RemoveEventHandler is a Socketer implementation.
+*/
+func (sock *Socket) RemoveEventHandler(handler socket.EventHandler) error {
+	if nil != sock.listeners.Remove(handler) {
+		return fmt.Errorf("handler for '%s' not found", handler.Name())
+	}
+	return nil
+}
+
+/*
+SendCommand records a command and responds to it with its scripted response.
+
+SendCommand is a Socketer implementation.
+*/
+func (sock *Socket) SendCommand(command socket.Commander) chan *socket.Response {
+	params, _ := json.Marshal(command.Params())
+
+	sock.mux.Lock()
+	sock.calls = append(sock.calls, &Call{
+		ID:     command.ID(),
+		Method: command.Method(),
+		Params: params,
+	})
+	handler := sock.handlers[command.Method()]
+	if queued := sock.once[command.Method()]; 0 < len(queued) {
+		handler = queued[0]
+		sock.once[command.Method()] = queued[1:]
+	}
+	sock.mux.Unlock()
+
+	response := &socket.Response{
+		ID:     command.ID(),
+		Result: []byte("{}"),
+	}
+	if nil != handler {
+		result, err := handler(params)
+		if nil != err {
+			response.Error = err
+			response.Result = nil
+		} else if nil != result {
+			data, e := json.Marshal(result)
+			if nil != e {
+				response.Error = &socket.Error{
+					Code:    -32603,
+					Data:    []byte(fmt.Sprintf("%q", e.Error())),
+					Message: fmt.Sprintf("could not encode the result of '%s': %s", command.Method(), e.Error()),
+				}
+				response.Result = nil
+			} else {
+				response.Result = data
+			}
+		}
+	}
+	go command.Respond(response)
+	return command.Response()
+}
+
+/*
+SendCommandContext records a command and responds to it like SendCommand,
+unless the context is already done.
+
+SendCommandContext is a Socketer implementation.
+*/
+func (sock *Socket) SendCommandContext(ctx context.Context, command socket.Commander) chan *socket.Response {
+	if err := ctx.Err(); nil != err {
+		go command.Respond(&socket.Response{
+			Error: &socket.Error{
+				Code:    -32000,
+				Data:    []byte(fmt.Sprintf("%q", err.Error())),
+				Message: err.Error(),
+			},
+			ID: command.ID(),
+		})
+		return command.Response()
+	}
+	return sock.SendCommand(command)
+}
+
+/*
+Stop is a no-op.
+
+Stop is a Socketer implementation.
+*/
+func (sock *Socket) Stop() {
+}
+
+/*
+URL returns a placeholder mock:// URL.
+
+URL is a Socketer implementation.
+*/
+func (sock *Socket) URL() *url.URL {
+	return sock.url
+}
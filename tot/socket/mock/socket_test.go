@@ -0,0 +1,159 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+recorder is a TestingT that records failures.
+*/
+type recorder struct {
+	errors []string
+}
+
+func (rec *recorder) Errorf(format string, args ...interface{}) {
+	rec.errors = append(rec.errors, fmt.Sprintf(format, args...))
+}
+
+func (rec *recorder) Helper() {}
+
+func TestSocketImplementsInterfaces(t *testing.T) {
+	var _ socket.Socketer = New()
+	var _ socket.Protocoller = New()
+}
+
+func TestSocketRespond(t *testing.T) {
+	sock := New()
+	sock.Respond("Page.navigate", map[string]string{"frameId": "frame-1"})
+
+	result := <-sock.Page().Navigate(&page.NavigateParams{URL: "https://example.com"})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if "frame-1" != result.FrameID {
+		t.Errorf("Expected frame-1, got '%s'", result.FrameID)
+	}
+	if result := <-sock.Page().Enable(); nil != result.Err {
+		t.Errorf("Expected an empty result for an unscripted command, got error: %v", result.Err)
+	}
+
+	sock.AssertCalled(t, "Page.navigate")
+	sock.AssertCalledWith(t, "Page.navigate", &page.NavigateParams{URL: "https://example.com"})
+	sock.AssertCallCount(t, "Page.navigate", 1)
+	sock.AssertNotCalled(t, "Page.reload")
+	if 2 != len(sock.Calls("")) {
+		t.Errorf("Expected 2 calls, got %d", len(sock.Calls("")))
+	}
+}
+
+func TestSocketRespondOnce(t *testing.T) {
+	sock := New()
+	sock.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "default"}})
+	sock.RespondOnce("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "first"}})
+	sock.RespondOnce("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "second"}})
+
+	for _, expected := range []string{"first", "second", "default", "default"} {
+		result := <-sock.Runtime().Evaluate(&runtime.EvaluateParams{Expression: "x"})
+		if nil != result.Err {
+			t.Fatalf("Expected nil, got error: %v", result.Err)
+		}
+		if expected != result.Result.Value {
+			t.Errorf("Expected '%s', got '%v'", expected, result.Result.Value)
+		}
+	}
+}
+
+func TestSocketHandle(t *testing.T) {
+	sock := New()
+	sock.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *socket.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		return map[string]interface{}{"result": map[string]string{"type": "string", "value": evaluate.Expression}}, nil
+	})
+	result := <-sock.Runtime().Evaluate(&runtime.EvaluateParams{Expression: "echo"})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if "echo" != result.Result.Value {
+		t.Errorf("Expected 'echo', got '%v'", result.Result.Value)
+	}
+}
+
+func TestSocketFail(t *testing.T) {
+	sock := New()
+	sock.Fail("Page.navigate", -32000, "Cannot navigate to invalid URL")
+
+	result := <-sock.Page().Navigate(&page.NavigateParams{URL: "invalid"})
+	if nil == result.Err {
+		t.Fatalf("Expected an error, got nil")
+	}
+
+	response := <-sock.SendCommand(socket.NewCommand(sock, "Page.navigate", nil))
+	if nil == response.Error || -32000 != response.Error.Code {
+		t.Errorf("Expected error code -32000, got %v", response.Error)
+	}
+}
+
+func TestSocketSendCommandContext(t *testing.T) {
+	sock := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	response := <-sock.SendCommandContext(ctx, socket.NewCommand(sock, "Page.enable", nil))
+	if nil == response.Error {
+		t.Errorf("Expected an error for a canceled context")
+	}
+	sock.AssertNotCalled(t, "Page.enable")
+}
+
+func TestSocketEmit(t *testing.T) {
+	sock := New()
+	var timestamp page.MonotonicTime
+	subscription := sock.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		timestamp = event.Timestamp
+	})
+	if err := sock.Emit("Page.loadEventFired", map[string]int{"timestamp": 3}); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 3 != timestamp {
+		t.Errorf("Expected the handler to be called with timestamp 3, got %v", timestamp)
+	}
+
+	subscription.Remove()
+	sock.Emit("Page.loadEventFired", map[string]int{"timestamp": 4})
+	if 3 != timestamp {
+		t.Errorf("Expected the removed handler not to be called, got timestamp %v", timestamp)
+	}
+	if err := sock.Emit("Page.loadEventFired", func() {}); nil == err {
+		t.Errorf("Expected an error for unencodable params")
+	}
+}
+
+func TestSocketAssertions(t *testing.T) {
+	sock := New()
+	<-sock.Page().Navigate(&page.NavigateParams{URL: "https://example.com"})
+
+	rec := &recorder{}
+	if nil != sock.AssertCalled(rec, "Page.reload") {
+		t.Errorf("Expected no call to be returned")
+	}
+	sock.AssertNotCalled(rec, "Page.navigate")
+	sock.AssertCalledWith(rec, "Page.navigate", &page.NavigateParams{URL: "https://example.org"})
+	sock.AssertCalledWith(rec, "Page.reload", nil)
+	sock.AssertCallCount(rec, "Page.navigate", 2)
+	if 5 != len(rec.errors) {
+		t.Errorf("Expected 5 failures, got %d: %v", len(rec.errors), rec.errors)
+	}
+
+	sock.Reset()
+	sock.AssertNotCalled(t, "Page.navigate")
+	if 1 != sock.CurCommandID() {
+		t.Errorf("Expected command IDs to be kept, got %d", sock.CurCommandID())
+	}
+}
@@ -0,0 +1,127 @@
+package socket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+Batch queues commands and sends them together, for driving a remote browser
+over a high latency link where waiting for each response before sending the
+next command makes the round trips dominate:
+
+	batch := socket.NewBatch(sock)
+	for _, selector := range selectors {
+		batch.Add(socket.NewCommand(sock, "Runtime.evaluate", &runtime.EvaluateParams{
+			Expression: fmt.Sprintf("document.querySelector(%q).textContent", selector),
+		}))
+	}
+	for _, response := range batch.Flush() {
+		result := &runtime.EvaluateResult{}
+		if err := response.Err(); nil != err {
+			...
+		}
+		json.Unmarshal(response.Result, result)
+		...
+	}
+
+The commands of a Socket or Session batch are written back to back in the
+order they were added, by one goroutine, so they are pipelined in a single
+round trip. Other Socketers send them with SendCommand. A batch can be reused
+after it's flushed.
+*/
+type Batch struct {
+	commands []Commander
+	mux      *sync.Mutex
+	socket   Socketer
+}
+
+/*
+NewBatch returns an empty batch of commands for a socket.
+*/
+func NewBatch(socket Socketer) *Batch {
+	return &Batch{
+		commands: make([]Commander, 0),
+		mux:      &sync.Mutex{},
+		socket:   socket,
+	}
+}
+
+/*
+Add queues a command. Its response is returned by Flush at the index Add
+returns.
+*/
+func (batch *Batch) Add(command Commander) int {
+	batch.mux.Lock()
+	defer batch.mux.Unlock()
+	batch.commands = append(batch.commands, command)
+	return len(batch.commands) - 1
+}
+
+/*
+Len returns the number of queued commands.
+*/
+func (batch *Batch) Len() int {
+	batch.mux.Lock()
+	defer batch.mux.Unlock()
+	return len(batch.commands)
+}
+
+/*
+Flush sends the queued commands and waits for their responses, which are
+returned in the order the commands were added.
+*/
+func (batch *Batch) Flush() []*Response {
+	futures := batch.FlushAsync()
+	responses := make([]*Response, len(futures))
+	for a, future := range futures {
+		responses[a] = future.Response()
+	}
+	return responses
+}
+
+/*
+FlushAsync sends the queued commands without waiting for their responses and
+returns their futures in the order the commands were added.
+*/
+func (batch *Batch) FlushAsync() []*Future {
+	batch.mux.Lock()
+	commands := batch.commands
+	batch.commands = make([]Commander, 0)
+	batch.mux.Unlock()
+
+	var socket *Socket
+	var session *Session
+	var sessionID target.SessionID
+	switch socketer := batch.socket.(type) {
+	case *Socket:
+		socket = socketer
+	case *Session:
+		socket = socketer.socket
+		session = socketer
+		sessionID = socketer.ID()
+	}
+
+	futures := make([]*Future, len(commands))
+	if nil == socket {
+		for a, command := range commands {
+			futures[a] = SendCommandAsync(batch.socket, command)
+		}
+		return futures
+	}
+
+	queued := make([]Commander, 0, len(commands))
+	for a, command := range commands {
+		futures[a] = newFuture(command, command.Response())
+		if nil != session && !session.accept(command) {
+			continue
+		}
+		if socket.queueCommand(context.Background(), command, sessionID) && !socket.holdCommand(command, sessionID) {
+			queued = append(queued, command)
+		}
+	}
+	socket.writeCommands(sessionID, queued...)
+	return futures
+}
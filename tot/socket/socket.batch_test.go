@@ -0,0 +1,93 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestBatch(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		return map[string]interface{}{"result": map[string]string{"type": "string", "value": evaluate.Expression}}, nil
+	})
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	batch := NewBatch(socket)
+	for a := 0; a < 5; a++ {
+		if index := batch.Add(NewCommand(socket, "Runtime.evaluate", &runtime.EvaluateParams{Expression: fmt.Sprintf("%d", a)})); a != index {
+			t.Errorf("Expected index %d, got %d", a, index)
+		}
+	}
+	if 5 != batch.Len() {
+		t.Errorf("Expected 5 queued commands, got %d", batch.Len())
+	}
+	responses := batch.Flush()
+	if 0 != batch.Len() {
+		t.Errorf("Expected the batch to be empty after flushing, got %d", batch.Len())
+	}
+	for a, response := range responses {
+		result := &runtime.EvaluateResult{}
+		if err := response.Err(); nil != err {
+			t.Fatalf("Expected nil, got error: %v", err)
+		}
+		json.Unmarshal(response.Result, result)
+		if fmt.Sprintf("%d", a) != result.Result.Value {
+			t.Errorf("Expected response %d in order, got %v", a, result.Result.Value)
+		}
+	}
+	for a, command := range server.Commands() {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(command.Params, evaluate)
+		if fmt.Sprintf("%d", a) != evaluate.Expression {
+			t.Errorf("Expected command %d to be written in order, got %s", a, evaluate.Expression)
+		}
+	}
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	batch = NewBatch(session)
+	batch.Add(NewCommand(session, "Runtime.evaluate", &runtime.EvaluateParams{Expression: "session"}))
+	futures := batch.FlushAsync()
+	if err := Await(futures...); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	commands := server.Commands()
+	if "session-1" != commands[len(commands)-1].SessionID {
+		t.Errorf("Expected the batch to be sent to session-1, got '%s'", commands[len(commands)-1].SessionID)
+	}
+}
+
+func TestBatchSocketer(t *testing.T) {
+	replay, err := NewReplay(strings.NewReader(`
+{"direction":"out","data":{"id":1,"method":"Runtime.evaluate","params":{"expression":"a"}}}
+{"direction":"in","data":{"id":1,"result":{"result":{"type":"string","value":"A"}}}}
+`))
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	replay.Listen()
+	defer replay.Stop()
+
+	batch := NewBatch(replay)
+	batch.Add(NewCommand(replay, "Runtime.evaluate", &runtime.EvaluateParams{Expression: "a"}))
+	batch.Add(NewCommand(replay, "Page.reload", nil))
+	responses := batch.Flush()
+	if nil != responses[0].Err() {
+		t.Errorf("Expected nil, got error: %v", responses[0].Err())
+	}
+	if nil == responses[1].Err() {
+		t.Errorf("Expected an error for the unrecorded command")
+	}
+}
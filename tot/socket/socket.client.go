@@ -0,0 +1,98 @@
+package socket
+
+import (
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+BrowserClient is a connection to the browser target. It only provides the
+browser level protocol domains, so page commands such as Page.navigate can't be
+sent to the browser target by mistake. Pages are driven through the sessions
+returned by AttachPage:
+
+	client := socket.NewBrowserClient(socket.New(browserURL))
+	defer client.Stop()
+	created := <-client.Target().CreateTarget(&target.CreateTargetParams{URL: "about:blank"})
+	...
+	session, err := client.AttachPage(created.ID)
+	if nil != err {
+		...
+	}
+	<-session.Page().Navigate(&page.NavigateParams{URL: "https://example.com"})
+*/
+type BrowserClient struct {
+	BrowserProtocoller
+	socket *Socket
+}
+
+/*
+NewBrowserClient returns a client for a connection to the browser target, the
+webSocketDebuggerUrl reported by /json/version.
+*/
+func NewBrowserClient(socket *Socket) *BrowserClient {
+	return &BrowserClient{
+		BrowserProtocoller: socket,
+		socket:             socket,
+	}
+}
+
+/*
+AttachPage attaches to a page target in flat mode and returns its session.
+*/
+func (client *BrowserClient) AttachPage(targetID target.ID) (*PageSession, error) {
+	session, err := client.socket.AttachToTarget(targetID)
+	if nil != err {
+		return nil, err
+	}
+	return NewPageSession(session), nil
+}
+
+/*
+Socket returns the underlying connection, for sending raw commands.
+*/
+func (client *BrowserClient) Socket() *Socket {
+	return client.socket
+}
+
+/*
+Stop closes the connection.
+*/
+func (client *BrowserClient) Stop() {
+	client.socket.Stop()
+}
+
+/*
+PageSession is a connection to a page target. It only provides the protocol
+domains available on pages, so browser commands such as Browser.close can't be
+sent to a page by mistake.
+*/
+type PageSession struct {
+	PageProtocoller
+	socket Socketer
+}
+
+/*
+NewPageSession returns a session for a connection to a page target, either a
+Session attached by a BrowserClient or a Socket connected to the page's own
+webSocketDebuggerUrl.
+*/
+func NewPageSession(socket Socketer) *PageSession {
+	return &PageSession{
+		PageProtocoller: NewProtocols(socket),
+		socket:          socket,
+	}
+}
+
+/*
+Socket returns the underlying session or connection, for sending raw commands.
+*/
+func (session *PageSession) Socket() Socketer {
+	return session.socket
+}
+
+/*
+Stop detaches the session, or closes the connection to the page.
+*/
+func (session *PageSession) Stop() {
+	session.socket.Stop()
+}
@@ -0,0 +1,66 @@
+package socket
+
+import (
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+func TestProtocolsImplementTargetProtocollers(t *testing.T) {
+	var _ BrowserProtocoller = &Protocols{}
+	var _ PageProtocoller = &Protocols{}
+}
+
+func TestBrowserClient(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.createTarget", map[string]string{"targetId": "target-1"})
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	client := NewBrowserClient(New(server.URL()))
+	defer client.Stop()
+
+	created := <-client.Target().CreateTarget(&target.CreateTargetParams{URL: "about:blank"})
+	if nil != created.Err {
+		t.Fatalf("Expected nil, got error: %v", created.Err)
+	}
+	session, err := client.AttachPage(created.ID)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if result := <-session.Page().Navigate(&page.NavigateParams{URL: "https://example.com"}); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if result := <-client.Browser().GetVersion(); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+
+	commands := server.Commands()
+	if 4 != len(commands) {
+		t.Fatalf("Expected 4 commands, got %d", len(commands))
+	}
+	if "Page.navigate" != commands[2].Method || "session-1" != commands[2].SessionID {
+		t.Errorf("Expected Page.navigate for session-1, got %s '%s'", commands[2].Method, commands[2].SessionID)
+	}
+	if "Browser.getVersion" != commands[3].Method || "" != commands[3].SessionID {
+		t.Errorf("Expected Browser.getVersion for the browser target, got %s '%s'", commands[3].Method, commands[3].SessionID)
+	}
+	if session.Socket() != client.Socket().Session("session-1") {
+		t.Errorf("Expected the page session to use the attached session")
+	}
+}
+
+func TestPageSessionSocket(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	session := NewPageSession(New(server.URL()))
+	defer session.Stop()
+
+	if result := <-session.Page().Reload(&page.ReloadParams{}); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if commands := server.Commands(); 1 != len(commands) || "Page.reload" != commands[0].Method {
+		t.Errorf("Expected Page.reload to be sent, got %v", commands)
+	}
+}
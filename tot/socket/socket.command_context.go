@@ -0,0 +1,152 @@
+package socket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+SendCommandContext sends a command to a connected socket like SendCommand, but
+stops waiting for the response when the context is canceled or its deadline
+passes. The command is then responded to with a SocketCommandCanceled or
+SocketCommandTimeout error. A response that arrives later is treated like the
+late response to a timed out command.
+
+SendCommandContext is a Socketer implementation.
+*/
+func (socket *Socket) SendCommandContext(ctx context.Context, command Commander) chan *Response {
+	return socket.sendCommandContext(ctx, command, "")
+}
+
+/*
+sendCommandContext delivers a command payload like sendCommand and stops
+waiting for the response when the context is done.
+*/
+func (socket *Socket) sendCommandContext(ctx context.Context, command Commander, sessionID target.SessionID) chan *Response {
+	if err := ctx.Err(); nil != err {
+		go command.Respond(contextResponse(command, err))
+		return command.Response()
+	}
+
+	response := socket.sendCommand(ctx, command, sessionID)
+	if nil == ctx.Done() {
+		return response
+	}
+
+	result := make(chan *Response, 1)
+	go func() {
+		select {
+		case res := <-response:
+			result <- res
+		case <-ctx.Done():
+			go socket.cancelCommand(command.ID(), ctx.Err())
+			result <- <-response
+		}
+	}()
+	return result
+}
+
+/*
+cancelCommand removes a pending command from the stack and responds to it with
+the context error. Commands that have already been responded to are ignored.
+*/
+func (socket *Socket) cancelCommand(id int, err error) {
+	command, popErr := socket.commands.Pop(id)
+	if nil != popErr {
+		return
+	}
+	socket.expired.add(command, time.Now())
+
+	response := contextResponse(command, err)
+	socket.logger.Warn(response.Error.Message, logger.Fields{"commandID": command.ID(), "error": err, "method": command.Method(), "socketID": socket.socketID})
+	socket.completed(command, response)
+	command.Respond(response)
+}
+
+/*
+contextResponse returns the error response for a command whose context is
+done.
+*/
+func contextResponse(command Commander, err error) *Response {
+	code := codes.SocketCommandCanceled
+	if context.DeadlineExceeded == err {
+		code = codes.SocketCommandTimeout
+	}
+	err = errs.Wrap(err, code, fmt.Sprintf("command #%d '%s' was abandoned", command.ID(), command.Method()))
+	response := &Response{
+		Error: &Error{
+			Code:    int(code),
+			Data:    []byte(fmt.Sprintf("%q", err.Error())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+	}
+	if codes.SocketCommandTimeout == code {
+		response.timeout = &TimeoutError{
+			CommandID: command.ID(),
+			Method:    command.Method(),
+			message:   err.Error(),
+		}
+	}
+	return response
+}
+
+/*
+ContextSocket is a view of a Socketer that sends every command with a context.
+Its protocol interfaces propagate the context to every command, so a group of
+calls can share a deadline:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := <-sock.WithContext(ctx).Page().Navigate(params)
+
+Other Socketer methods are passed through to the underlying Socketer.
+*/
+type ContextSocket struct {
+	Socketer
+	*Protocols
+	ctx context.Context
+}
+
+/*
+NewContextSocket returns a view of a Socketer that sends every command with the
+specified context.
+*/
+func NewContextSocket(ctx context.Context, socket Socketer) *ContextSocket {
+	contextSocket := &ContextSocket{
+		Socketer: socket,
+		ctx:      ctx,
+	}
+	contextSocket.Protocols = NewProtocols(contextSocket)
+	return contextSocket
+}
+
+/*
+Context returns the context commands are sent with.
+*/
+func (socket *ContextSocket) Context() context.Context {
+	return socket.ctx
+}
+
+/*
+SendCommand sends a command with the view's context.
+
+SendCommand is a Socketer implementation.
+*/
+func (socket *ContextSocket) SendCommand(command Commander) chan *Response {
+	return socket.Socketer.SendCommandContext(socket.ctx, command)
+}
+
+/*
+WithContext returns a view of the socket that sends every command, including
+commands sent through its protocol interfaces, with the specified context.
+*/
+func (socket *Socket) WithContext(ctx context.Context) *ContextSocket {
+	return NewContextSocket(ctx, socket)
+}
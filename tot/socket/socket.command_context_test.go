@@ -0,0 +1,111 @@
+package socket
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+func TestSendCommandContextCancel(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSendCommandContextCancel")
+	mockSocket := NewMock(socketURL)
+	lateCh := make(chan time.Duration, 1)
+	WithLateResponseHandler(func(command Commander, response *Response, late time.Duration) {
+		lateCh <- late
+	})(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	command := NewCommand(mockSocket, "Some.method", nil)
+	resultCh := mockSocket.SendCommandContext(ctx, command)
+	cancel()
+	response := <-resultCh
+	if nil == response.Error || int(codes.SocketCommandCanceled) != response.Error.Code {
+		t.Fatalf("Expected a canceled error, got %v", response.Error)
+	}
+
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     command.ID(),
+		Result: []byte(`"late result"`),
+	})
+	select {
+	case <-lateCh:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the late response to be delivered")
+	}
+}
+
+func TestSendCommandContextDeadline(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSendCommandContextDeadline")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	response := <-mockSocket.SendCommandContext(ctx, NewCommand(mockSocket, "Some.method", nil))
+	if nil == response.Error || int(codes.SocketCommandTimeout) != response.Error.Code {
+		t.Errorf("Expected a timeout error, got %v", response.Error)
+	}
+
+	// A context that is already done doesn't send the command.
+	command := NewCommand(mockSocket, "Some.method", nil)
+	response = <-mockSocket.SendCommandContext(ctx, command)
+	if nil == response.Error || int(codes.SocketCommandTimeout) != response.Error.Code {
+		t.Errorf("Expected a timeout error, got %v", response.Error)
+	}
+	if _, err := mockSocket.commands.Get(command.ID()); nil == err {
+		t.Errorf("Expected the command not to be sent")
+	}
+}
+
+func TestSendCommandContextResponse(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSendCommandContextResponse")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	command := NewCommand(mockSocket, "Some.method", nil)
+	resultCh := mockSocket.SendCommandContext(ctx, command)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     command.ID(),
+		Error:  &Error{},
+		Result: []byte(`"result"`),
+	})
+	response := <-resultCh
+	if nil != response.Error && 0 != response.Error.Code {
+		t.Errorf("Expected nil, got error: '%s'", response.Error.Error())
+	}
+	if `"result"` != string(response.Result) {
+		t.Errorf("Expected the result, got %s", response.Result)
+	}
+}
+
+func TestContextSocket(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestContextSocket")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	contextSocket := mockSocket.WithContext(ctx)
+	if ctx != contextSocket.Context() {
+		t.Errorf("Expected the view's context")
+	}
+	if mockSocket.URL() != contextSocket.URL() {
+		t.Errorf("Expected the socket URL, got %s", contextSocket.URL())
+	}
+
+	result := <-contextSocket.Page().Navigate(&page.NavigateParams{URL: "https://www.example.com"})
+	if nil == result.Err {
+		t.Errorf("Expected a timeout error, got nil")
+	}
+}
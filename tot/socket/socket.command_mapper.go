@@ -7,24 +7,56 @@ import (
 	errs "github.com/bdlm/errors"
 )
 
+/*
+commandMapShards is the number of shards pending commands are spread across.
+Command IDs are sequential, so consecutive commands land in different shards.
+*/
+const commandMapShards = 64
+
 /*
 NewCommandMap creates and returns a pointer to a struct that implements the
 CommandMapper interface.
 */
 func NewCommandMap() *CommandMap {
-	return &CommandMap{
-		stack: make(map[int]Commander),
-		mux:   &sync.Mutex{},
+	stack := &CommandMap{}
+	for a := range stack.shards {
+		stack.shards[a].stack = make(map[int]Commander)
 	}
+	return stack
 }
 
 /*
 CommandMap provides a CommandMapper interface implementation for managing the
 command stack.
+
+Commands are spread across shards by ID, each with its own lock, so goroutines
+sending commands and the goroutine routing responses rarely contend for the
+same lock when thousands of commands are in flight.
 */
 type CommandMap struct {
-	mux   *sync.Mutex
+	shards [commandMapShards]commandShard
+}
+
+/*
+commandShard is a shard of the command stack.
+*/
+type commandShard struct {
+	mux   sync.Mutex
 	stack map[int]Commander
+
+	// pad keeps shards on separate cache lines.
+	_ [48]byte
+}
+
+/*
+shard returns the shard a command ID belongs to.
+*/
+func (stack *CommandMap) shard(id int) *commandShard {
+	index := id % commandMapShards
+	if index < 0 {
+		index = -index
+	}
+	return &stack.shards[index]
 }
 
 /*
@@ -33,9 +65,10 @@ Delete removes a command from the stack.
 Delete is a CommandMapper implementation.
 */
 func (stack *CommandMap) Delete(id int) {
-	stack.mux.Lock()
-	delete(stack.stack, id)
-	stack.mux.Unlock()
+	shard := stack.shard(id)
+	shard.mux.Lock()
+	delete(shard.stack, id)
+	shard.mux.Unlock()
 }
 
 /*
@@ -44,22 +77,77 @@ Get retrieves a command from the stack.
 Get is a CommandMapper implementation.
 */
 func (stack *CommandMap) Get(id int) (Commander, error) {
-	stack.mux.Lock()
-	command, ok := stack.stack[id]
-	stack.mux.Unlock()
+	shard := stack.shard(id)
+	shard.mux.Lock()
+	command, ok := shard.stack[id]
+	shard.mux.Unlock()
 	if !ok {
 		return nil, errs.New(0, fmt.Sprintf("Command %d not found", id))
 	}
 	return command, nil
 }
 
+/*
+Len returns the number of commands in the stack.
+
+Len is a CommandMapper implementation.
+*/
+func (stack *CommandMap) Len() int {
+	count := 0
+	for a := range stack.shards {
+		shard := &stack.shards[a]
+		shard.mux.Lock()
+		count += len(shard.stack)
+		shard.mux.Unlock()
+	}
+	return count
+}
+
+/*
+Pop retrieves and removes a command from the stack.
+
+Pop is a CommandMapper implementation.
+*/
+func (stack *CommandMap) Pop(id int) (Commander, error) {
+	shard := stack.shard(id)
+	shard.mux.Lock()
+	command, ok := shard.stack[id]
+	delete(shard.stack, id)
+	shard.mux.Unlock()
+	if !ok {
+		return nil, errs.New(0, fmt.Sprintf("Command %d not found", id))
+	}
+	return command, nil
+}
+
+/*
+PopAll retrieves and removes all commands from the stack. Shards are emptied
+one at a time, so commands set while PopAll runs may or may not be returned.
+
+PopAll is a CommandMapper implementation.
+*/
+func (stack *CommandMap) PopAll() []Commander {
+	commands := make([]Commander, 0)
+	for a := range stack.shards {
+		shard := &stack.shards[a]
+		shard.mux.Lock()
+		for id, command := range shard.stack {
+			commands = append(commands, command)
+			delete(shard.stack, id)
+		}
+		shard.mux.Unlock()
+	}
+	return commands
+}
+
 /*
 Set sets a command in the stack.
 
 Set is a CommandMapper implementation.
 */
 func (stack *CommandMap) Set(cmd Commander) {
-	stack.mux.Lock()
-	stack.stack[cmd.ID()] = cmd
-	stack.mux.Unlock()
+	shard := stack.shard(cmd.ID())
+	shard.mux.Lock()
+	shard.stack[cmd.ID()] = cmd
+	shard.mux.Unlock()
 }
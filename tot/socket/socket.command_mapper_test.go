@@ -1,7 +1,12 @@
 package socket
 
 import (
+	"fmt"
+	"net/url"
+	"sync"
 	"testing"
+
+	errs "github.com/bdlm/errors"
 )
 
 func TestSocketCommandMapperError(t *testing.T) {
@@ -11,3 +16,109 @@ func TestSocketCommandMapperError(t *testing.T) {
 		t.Errorf("Expected nil, got error: '%s'", err.Error())
 	}
 }
+
+func TestSocketCommandMapperPop(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSocketCommandMapperPop")
+	commandMap := NewCommandMap()
+	command := NewCommand(NewMock(socketURL), "Some.method", nil)
+	commandMap.Set(command)
+	if cmd, err := commandMap.Pop(command.ID()); nil != err || cmd != command {
+		t.Errorf("Expected the command, got %v %v", cmd, err)
+	}
+	if _, err := commandMap.Pop(command.ID()); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestSocketCommandMapperPopAll(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSocketCommandMapperPopAll")
+	mockSocket := NewMock(socketURL)
+	commandMap := NewCommandMap()
+	commandMap.Set(NewCommand(mockSocket, "Some.method", nil))
+	commandMap.Set(NewCommand(mockSocket, "Other.method", nil))
+	if 2 != commandMap.Len() {
+		t.Errorf("Expected 2 commands, got %d", commandMap.Len())
+	}
+	if commands := commandMap.PopAll(); 2 != len(commands) {
+		t.Errorf("Expected 2 commands, got %d", len(commands))
+	}
+	if commands := commandMap.PopAll(); 0 != len(commands) || 0 != commandMap.Len() {
+		t.Errorf("Expected no commands, got %d", len(commands))
+	}
+}
+
+func TestSocketCommandMapperShards(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSocketCommandMapperShards")
+	mockSocket := NewMock(socketURL)
+	commandMap := NewCommandMap()
+	commands := make([]Commander, 0, 3*commandMapShards)
+	for a := 0; a < 3*commandMapShards; a++ {
+		command := NewCommand(mockSocket, "Some.method", nil)
+		commands = append(commands, command)
+		commandMap.Set(command)
+	}
+	for _, command := range commands[:commandMapShards] {
+		if cmd, err := commandMap.Get(command.ID()); nil != err || cmd != command {
+			t.Errorf("Expected command %d, got %v %v", command.ID(), cmd, err)
+		}
+		commandMap.Delete(command.ID())
+	}
+	if commands := commandMap.PopAll(); 2*commandMapShards != len(commands) {
+		t.Errorf("Expected %d commands, got %d", 2*commandMapShards, len(commands))
+	}
+}
+
+/*
+lockedCommandMap is a command stack behind a single lock, the layout CommandMap
+used before it was sharded, for comparison in the benchmarks.
+*/
+type lockedCommandMap struct {
+	mux   *sync.Mutex
+	stack map[int]Commander
+}
+
+func (stack *lockedCommandMap) Set(cmd Commander) {
+	stack.mux.Lock()
+	stack.stack[cmd.ID()] = cmd
+	stack.mux.Unlock()
+}
+
+func (stack *lockedCommandMap) Pop(id int) (Commander, error) {
+	stack.mux.Lock()
+	command, ok := stack.stack[id]
+	delete(stack.stack, id)
+	stack.mux.Unlock()
+	if !ok {
+		return nil, errs.New(0, fmt.Sprintf("Command %d not found", id))
+	}
+	return command, nil
+}
+
+/*
+benchmarkCommandMap sets and pops commands from many goroutines, the way
+command senders and the response router use the stack.
+*/
+func benchmarkCommandMap(b *testing.B, stack interface {
+	Set(Commander)
+	Pop(int) (Commander, error)
+}) {
+	socketURL, _ := url.Parse("https://test:9222/benchmarkCommandMap")
+	mockSocket := NewMock(socketURL)
+	b.SetParallelism(16)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			command := &Command{id: mockSocket.NextCommandID()}
+			stack.Set(command)
+			stack.Pop(command.ID())
+		}
+	})
+}
+
+func BenchmarkCommandMap(b *testing.B) {
+	benchmarkCommandMap(b, NewCommandMap())
+}
+
+func BenchmarkCommandMapSingleLock(b *testing.B) {
+	benchmarkCommandMap(b, &lockedCommandMap{mux: &sync.Mutex{}, stack: make(map[int]Commander)})
+}
@@ -0,0 +1,158 @@
+package socket
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+CommandOption configures a command created with NewCommand.
+*/
+type CommandOption func(command *Command)
+
+/*
+WithTimeout overrides the socket's default command timeout for a command. A
+negative timeout waits indefinitely:
+
+	command := socket.NewCommand(sock, "Page.captureScreenshot", params, socket.WithTimeout(time.Minute))
+*/
+func WithTimeout(timeout time.Duration) CommandOption {
+	return func(command *Command) {
+		command.timeout = timeout
+	}
+}
+
+/*
+TimeoutError is returned by Response.Err, and as the error of protocol
+results, when a command times out. The response's Error carries the
+SocketCommandTimeout code.
+*/
+type TimeoutError struct {
+	// The ID of the command that timed out.
+	CommandID int
+
+	// The method of the command that timed out.
+	Method string
+
+	// The timeout that expired. Zero if the command's context deadline was
+	// exceeded.
+	Duration time.Duration
+
+	message string
+}
+
+/*
+Error implements the error interface.
+*/
+func (err *TimeoutError) Error() string {
+	return err.message
+}
+
+/*
+Timeout reports that the error is a timeout, like net.Error.
+*/
+func (err *TimeoutError) Timeout() bool {
+	return true
+}
+
+/*
+expiredRetention is how long a timed out command is remembered so that a late
+response can be matched to it.
+*/
+var expiredRetention = 5 * time.Minute
+
+/*
+expiredCommand is a command that timed out before its response arrived.
+*/
+type expiredCommand struct {
+	command  Commander
+	deadline time.Time
+}
+
+/*
+expiredCommands tracks timed out commands by ID.
+*/
+type expiredCommands struct {
+	mux   *sync.Mutex
+	stack map[int]*expiredCommand
+}
+
+func newExpiredCommands() *expiredCommands {
+	return &expiredCommands{
+		mux:   &sync.Mutex{},
+		stack: make(map[int]*expiredCommand),
+	}
+}
+
+func (expired *expiredCommands) add(command Commander, deadline time.Time) {
+	expired.mux.Lock()
+	expired.stack[command.ID()] = &expiredCommand{command: command, deadline: deadline}
+	expired.mux.Unlock()
+	time.AfterFunc(expiredRetention, func() {
+		expired.pop(command.ID())
+	})
+}
+
+func (expired *expiredCommands) pop(id int) (*expiredCommand, bool) {
+	expired.mux.Lock()
+	defer expired.mux.Unlock()
+	cmd, ok := expired.stack[id]
+	delete(expired.stack, id)
+	return cmd, ok
+}
+
+/*
+expireCommand removes a pending command from the stack and responds to it with
+a timeout error. Commands that have already been responded to are ignored.
+*/
+func (socket *Socket) expireCommand(id int, timeout time.Duration) {
+	command, err := socket.commands.Pop(id)
+	if nil != err {
+		return
+	}
+	socket.expired.add(command, time.Now())
+
+	err = errs.New(codes.SocketCommandTimeout, fmt.Sprintf("command #%d '%s' timed out after %s", command.ID(), command.Method(), timeout))
+	socket.logger.Warn(err.Error(), logger.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID, "timeout": timeout.String()})
+	response := &Response{
+		Error: &Error{
+			Code:    int(codes.SocketCommandTimeout),
+			Data:    []byte(fmt.Sprintf("%q", timeout.String())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+		timeout: &TimeoutError{
+			CommandID: command.ID(),
+			Method:    command.Method(),
+			Duration:  timeout,
+			message:   err.Error(),
+		},
+	}
+	socket.completed(command, response)
+	command.Respond(response)
+}
+
+/*
+handleLateResponse delivers a response for a command that has already timed out
+to the late response handler. It returns false if the response doesn't belong
+to an expired command.
+*/
+func (socket *Socket) handleLateResponse(response *Response) bool {
+	expired, ok := socket.expired.pop(response.ID)
+	if !ok {
+		return false
+	}
+	late := time.Since(expired.deadline)
+	socket.logger.Warn("late response received for expired command", logger.Fields{"commandID": expired.command.ID(), "late": late.String(), "method": expired.command.Method(), "socketID": socket.socketID})
+	if nil != socket.lateResponseHandler {
+		socket.guard("late response handler", expired.command.Method(), func() {
+			socket.lateResponseHandler(expired.command, response, late)
+		})
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package socket
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+func TestCommandTimeout(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCommandTimeout")
+	mockSocket := NewMock(socketURL)
+	lateCh := make(chan time.Duration, 1)
+	WithCommandTimeout(100 * time.Millisecond)(mockSocket)
+	WithLateResponseHandler(func(command Commander, response *Response, late time.Duration) {
+		if "Some.method" != command.Method() {
+			t.Errorf("Expected Some.method, got %s", command.Method())
+		}
+		if `"late result"` != string(response.Result) {
+			t.Errorf("Expected the late result, got %s", response.Result)
+		}
+		lateCh <- late
+	})(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	command := NewCommand(mockSocket, "Some.method", nil)
+	response := <-mockSocket.SendCommand(command)
+	if nil == response.Error || int(codes.SocketCommandTimeout) != response.Error.Code {
+		t.Fatalf("Expected a timeout error, got %v", response.Error)
+	}
+
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     command.ID(),
+		Result: []byte(`"late result"`),
+	})
+	select {
+	case late := <-lateCh:
+		if late <= 0 {
+			t.Errorf("Expected a positive delay, got %s", late)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the late response to be delivered")
+	}
+}
+
+func TestCommandNoTimeout(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCommandNoTimeout")
+	mockSocket := NewMock(socketURL)
+	WithCommandTimeout(5 * time.Second)(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	command := NewCommand(mockSocket, "Some.method", nil)
+	resultCh := mockSocket.SendCommand(command)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     command.ID(),
+		Result: []byte(`"result"`),
+	})
+	response := <-resultCh
+	if nil != response.Error && 0 != response.Error.Code {
+		t.Errorf("Expected nil, got error: '%s'", response.Error.Error())
+	}
+	// The timer firing after the response must be a no-op.
+	mockSocket.expireCommand(command.ID(), time.Second)
+	if _, ok := mockSocket.expired.pop(command.ID()); ok {
+		t.Errorf("Expected a completed command not to expire")
+	}
+}
+
+func TestCommandTimeoutOverride(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCommandTimeoutOverride")
+	mockSocket := NewMock(socketURL)
+	WithCommandTimeout(5 * time.Second)(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	command := NewCommand(mockSocket, "Some.method", nil, WithTimeout(50*time.Millisecond))
+	if 50*time.Millisecond != command.Timeout() {
+		t.Errorf("Expected 50ms, got %s", command.Timeout())
+	}
+	select {
+	case response := <-mockSocket.SendCommand(command):
+		err, ok := response.Err().(*TimeoutError)
+		if !ok {
+			t.Fatalf("Expected a *TimeoutError, got %v", response.Err())
+		}
+		if command.ID() != err.CommandID || "Some.method" != err.Method || 50*time.Millisecond != err.Duration || !err.Timeout() {
+			t.Errorf("Expected the command timeout, got %v", err)
+		}
+		if int(codes.SocketCommandTimeout) != response.Error.Code {
+			t.Errorf("Expected the timeout code, got %d", response.Error.Code)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the command override to time out")
+	}
+
+	// A negative timeout disables the default.
+	WithCommandTimeout(50 * time.Millisecond)(mockSocket)
+	command = NewCommand(mockSocket, "Some.method", nil, WithTimeout(-1))
+	resultCh := mockSocket.SendCommand(command)
+	select {
+	case response := <-resultCh:
+		t.Fatalf("Expected no timeout, got %v", response.Err())
+	case <-time.After(100 * time.Millisecond):
+	}
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     command.ID(),
+		Result: []byte(`"result"`),
+	})
+	if response := <-resultCh; nil != response.Err() {
+		t.Errorf("Expected nil, got error: %v", response.Err())
+	}
+}
+
+func TestCommandTimeoutResult(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestCommandTimeoutResult")
+	mockSocket := NewMock(socketURL)
+	WithCommandTimeout(50 * time.Millisecond)(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	result := <-mockSocket.Page().Navigate(&page.NavigateParams{URL: "about:blank"})
+	if err, ok := result.Err.(*TimeoutError); !ok || "Page.navigate" != err.Method {
+		t.Errorf("Expected a *TimeoutError, got %v", result.Err)
+	}
+}
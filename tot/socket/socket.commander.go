@@ -1,17 +1,25 @@
 package socket
 
+import (
+	"time"
+)
+
 /*
 NewCommand creates and returns a pointer to a struct that implements the
 Commander interface.
 */
-func NewCommand(socket Socketer, method string, params interface{}) *Command {
-	return &Command{
+func NewCommand(socket Socketer, method string, params interface{}, options ...CommandOption) *Command {
+	command := &Command{
 		id:       socket.NextCommandID(),
 		method:   method,
 		params:   params,
 		response: make(chan *Response),
 		socket:   socket,
 	}
+	for _, option := range options {
+		option(command)
+	}
+	return command
 }
 
 /*
@@ -37,6 +45,13 @@ type Command struct {
 
 	// socket contains the Socketer instance
 	socket Socketer
+
+	// Optional. streamResult streams a result larger than the socket's
+	// maximum message size.
+	streamResult bool
+
+	// Optional. timeout overrides the socket's default command timeout.
+	timeout time.Duration
 }
 
 /*
@@ -93,6 +108,16 @@ func (cmd *Command) Response() chan *Response {
 	return cmd.response
 }
 
+/*
+Timeout returns the command timeout. Zero uses the socket's default command
+timeout.
+
+Timeout is a Commander implementation.
+*/
+func (cmd *Command) Timeout() time.Duration {
+	return cmd.timeout
+}
+
 /*
 SetError sets the error value
 
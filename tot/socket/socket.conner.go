@@ -1,11 +1,12 @@
 package socket
 
 import (
+	"encoding/json"
 	"fmt"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
 )
 
 /*
@@ -31,21 +32,20 @@ func (socket *Socket) Connect() error {
 		return nil
 	}
 
-	log.WithFields(log.Fields{"socketID": socket.socketID, "url": socket.url.String()}).
-		Debug("connecting")
+	socket.logger.Debug("connecting", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
 	websocket, err := socket.newSocket(socket.url)
 	if nil != err {
-		log.WithFields(log.Fields{"error": err.Error(), "socketID": socket.socketID}).
-			Debug("received error")
+		socket.logger.Debug("received error", logger.Fields{"error": err.Error(), "socketID": socket.socketID})
 		socket.connected = false
 		return errs.Wrap(err, codes.SocketEventHandlerNotFound, "Connect() failed while creating socket")
 	}
 
+	socket.limitReads(websocket)
 	socket.conn = websocket
 	socket.connected = true
+	socket.discoverVersion()
 
-	log.WithFields(log.Fields{"socketID": socket.socketID, "url": socket.url.String()}).
-		Debug("connection established")
+	socket.logger.Debug("connection established", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
 	return nil
 }
 
@@ -88,8 +88,22 @@ func (socket *Socket) ReadJSON(v interface{}) error {
 		return errs.Wrap(err, codes.SocketNotConnected, "not connected")
 	}
 
-	err = socket.conn.ReadJSON(&v)
+	if !socket.tapping() {
+		err = socket.conn.ReadJSON(&v)
+		if nil != err {
+			return socket.readError(err, "socket read failed")
+		}
+		return nil
+	}
+
+	// Read the raw frame for the taps, then decode it.
+	raw := json.RawMessage{}
+	err = socket.conn.ReadJSON(&raw)
 	if nil != err {
+		return socket.readError(err, "socket read failed")
+	}
+	socket.tap(Inbound, raw)
+	if err = json.Unmarshal(raw, v); nil != err {
 		return errs.Wrap(err, codes.SocketReadFailed, "socket read failed")
 	}
 
@@ -97,7 +111,8 @@ func (socket *Socket) ReadJSON(v interface{}) error {
 }
 
 /*
-WriteJSON writes data to a websocket connection.
+WriteJSON writes data to a websocket connection. Writes are serialized, the
+websocket connection supports only one concurrent writer.
 
 WriteJSON is a Conner implementation.
 */
@@ -107,8 +122,21 @@ func (socket *Socket) WriteJSON(v interface{}) error {
 		return errs.Wrap(err, codes.SocketNotConnected, "not connected")
 	}
 
+	socket.writeMux.Lock()
+	defer socket.writeMux.Unlock()
+
+	if socket.tapping() {
+		raw, err := json.Marshal(v)
+		if nil != err {
+			return errs.Wrap(err, codes.SocketWriteFailed, "socket write failed")
+		}
+		socket.tap(Outbound, raw)
+		v = json.RawMessage(raw)
+	}
+
 	err = socket.conn.WriteJSON(v)
 	if nil != err {
+		socket.metrics.websocketError(err)
 		return errs.Wrap(err, codes.SocketWriteFailed, "socket write failed")
 	}
 
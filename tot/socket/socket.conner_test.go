@@ -2,7 +2,10 @@ package socket
 
 import (
 	"net/url"
+	"sync"
 	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
 )
 
 func TestConner(t *testing.T) {
@@ -24,3 +27,27 @@ func TestConner(t *testing.T) {
 		t.Errorf("Expected nil, got error: '%s'", err.Error())
 	}
 }
+
+func TestConcurrentWrites(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Browser.getVersion", map[string]string{"product": "HeadlessChrome"})
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	// Commands sent from many goroutines are written concurrently.
+	wg := &sync.WaitGroup{}
+	for a := 0; a < 50; a++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result := <-socket.Browser().GetVersion(); nil != result.Err {
+				t.Errorf("Expected nil, got error: %v", result.Err)
+			}
+		}()
+	}
+	wg.Wait()
+	if commands := server.Commands(); 50 != len(commands) {
+		t.Errorf("Expected 50 commands, got %d", len(commands))
+	}
+}
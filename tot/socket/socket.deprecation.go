@@ -0,0 +1,177 @@
+package socket
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+methodNotFound is the protocol error code for an unknown method.
+*/
+const methodNotFound = -32601
+
+/*
+MethodSuccessors maps protocol methods that were moved to another domain or
+renamed in Chrome releases to their successors. The successors accept the same
+parameters.
+*/
+var MethodSuccessors = map[string]string{
+	"Page.clearDeviceMetricsOverride":     "Emulation.clearDeviceMetricsOverride",
+	"Page.clearDeviceOrientationOverride": "DeviceOrientation.clearDeviceOrientationOverride",
+	"Page.clearGeolocationOverride":       "Emulation.clearGeolocationOverride",
+	"Page.getCookies":                     "Network.getCookies",
+	"Page.removeScriptToEvaluateOnLoad":   "Page.removeScriptToEvaluateOnNewDocument",
+	"Page.setDeviceMetricsOverride":       "Emulation.setDeviceMetricsOverride",
+	"Page.setDeviceOrientationOverride":   "DeviceOrientation.setDeviceOrientationOverride",
+	"Page.setDownloadBehavior":            "Browser.setDownloadBehavior",
+	"Page.setGeolocationOverride":         "Emulation.setGeolocationOverride",
+	"Page.setTouchEmulationEnabled":       "Emulation.setTouchEmulationEnabled",
+}
+
+/*
+WithDeprecationShims retries commands that the browser rejects as unknown
+methods with their successors, so code written against an older protocol keeps
+working across Chrome releases. Successors are looked up in the specified
+mapping, or in MethodSuccessors if it's nil:
+
+	sock := socket.New(socketURL, socket.WithDeprecationShims(map[string]string{
+		"Page.setDownloadBehavior": "Browser.setDownloadBehavior",
+	}))
+
+Each translation is logged once as a warning. After a method is found missing
+the later commands calling it are sent to its successor directly.
+*/
+func WithDeprecationShims(successors map[string]string) Option {
+	return func(socket *Socket) {
+		if nil == successors {
+			successors = MethodSuccessors
+		}
+		socket.shims = &methodShims{
+			missing:    make(map[string]bool),
+			mux:        &sync.Mutex{},
+			pending:    make(map[int]*shimAttempt),
+			successors: successors,
+		}
+	}
+}
+
+/*
+shimAttempt is the method a pending command was last sent as.
+*/
+type shimAttempt struct {
+	method    string
+	sessionID target.SessionID
+}
+
+/*
+methodShims translates commands calling removed methods. A nil value doesn't
+translate.
+*/
+type methodShims struct {
+	missing    map[string]bool
+	mux        *sync.Mutex
+	pending    map[int]*shimAttempt
+	successors map[string]string
+}
+
+/*
+resolve returns the method a command calling the specified method is sent as,
+skipping methods already found missing.
+*/
+func (shims *methodShims) resolve(method string) string {
+	for seen := 0; shims.missing[method] && seen < len(shims.successors); seen++ {
+		successor, ok := shims.successors[method]
+		if !ok {
+			break
+		}
+		method = successor
+	}
+	return method
+}
+
+/*
+sent records a command calling a method that has a successor.
+*/
+func (shims *methodShims) sent(command Commander, sessionID target.SessionID) {
+	if nil == shims {
+		return
+	}
+	shims.mux.Lock()
+	defer shims.mux.Unlock()
+	if _, ok := shims.successors[command.Method()]; ok {
+		shims.pending[command.ID()] = &shimAttempt{
+			method:    shims.resolve(command.Method()),
+			sessionID: sessionID,
+		}
+	}
+}
+
+/*
+completed forgets a command that has been responded to.
+*/
+func (shims *methodShims) completed(command Commander) {
+	if nil == shims {
+		return
+	}
+	shims.mux.Lock()
+	delete(shims.pending, command.ID())
+	shims.mux.Unlock()
+}
+
+/*
+method returns the method a command is sent as.
+*/
+func (shims *methodShims) method(command Commander) string {
+	if nil == shims {
+		return command.Method()
+	}
+	shims.mux.Lock()
+	defer shims.mux.Unlock()
+	if attempt, ok := shims.pending[command.ID()]; ok {
+		return attempt.method
+	}
+	return command.Method()
+}
+
+/*
+next marks the method a command was sent as missing and returns its successor,
+and false if there's none.
+*/
+func (shims *methodShims) next(command Commander, response *Response) (string, string, target.SessionID, bool) {
+	if nil == shims || nil == response.Error || methodNotFound != response.Error.Code {
+		return "", "", "", false
+	}
+	shims.mux.Lock()
+	defer shims.mux.Unlock()
+	attempt, ok := shims.pending[command.ID()]
+	if !ok {
+		return "", "", "", false
+	}
+	removed := attempt.method
+	shims.missing[removed] = true
+	successor := shims.resolve(removed)
+	if shims.missing[successor] {
+		return "", "", "", false
+	}
+	attempt.method = successor
+	return removed, successor, attempt.sessionID, true
+}
+
+/*
+shimCommand sends a command rejected as an unknown method again as its
+successor. It returns false if the command has no successor and should be
+responded to.
+*/
+func (socket *Socket) shimCommand(command Commander, response *Response) bool {
+	removed, successor, sessionID, ok := socket.shims.next(command, response)
+	if !ok {
+		return false
+	}
+	socket.logger.Warn(fmt.Sprintf("'%s' isn't supported by the browser, sending '%s' instead", removed, successor), logger.Fields{"commandID": command.ID(), "method": removed, "socketID": socket.socketID, "successor": successor})
+	socket.commands.Set(command)
+	go socket.writeCommand(command, sessionID)
+	return true
+}
@@ -0,0 +1,75 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestDeprecationShims(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	var removed int32
+	server.Handle("Page.setDownloadBehavior", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		atomic.AddInt32(&removed, 1)
+		return nil, &cdptest.Error{Code: methodNotFound, Message: "'Page.setDownloadBehavior' wasn't found"}
+	})
+	server.Handle("Browser.setDownloadBehavior", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		behavior := map[string]string{}
+		json.Unmarshal(params, &behavior)
+		if "deny" != behavior["behavior"] {
+			return nil, &cdptest.Error{Code: -32602, Message: "Invalid parameters"}
+		}
+		return map[string]interface{}{}, nil
+	})
+	socket := New(server.URL(), WithDeprecationShims(nil))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	for a := 0; a < 2; a++ {
+		response := <-socket.SendCommand(NewCommand(socket, "Page.setDownloadBehavior", map[string]string{"behavior": "deny"}))
+		if nil != response.Error {
+			t.Errorf("Expected nil, got error: %v", response.Error)
+		}
+	}
+	if 1 != atomic.LoadInt32(&removed) {
+		t.Errorf("Expected the removed method to be called once, got %d calls", removed)
+	}
+	if 3 != len(server.Commands()) {
+		t.Errorf("Expected 3 commands to be sent, got %d", len(server.Commands()))
+	}
+}
+
+func TestDeprecationShimsUnknown(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	notFound := func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: methodNotFound, Message: "method wasn't found"}
+	}
+	server.Handle("Some.removed", notFound)
+	server.Handle("Some.renamed", notFound)
+	server.Handle("Some.unmapped", notFound)
+	socket := New(server.URL(), WithDeprecationShims(map[string]string{
+		"Some.removed": "Some.renamed",
+		"Some.renamed": "Some.removed",
+	}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.unmapped", nil)); nil == response.Error || methodNotFound != response.Error.Code {
+		t.Errorf("Expected a method not found error, got %v", response.Error)
+	}
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.removed", nil)); nil == response.Error || methodNotFound != response.Error.Code {
+		t.Errorf("Expected a method not found error, got %v", response.Error)
+	}
+	if 3 != len(server.Commands()) {
+		t.Errorf("Expected the cycle to stop after both methods were tried, got %d commands", len(server.Commands()))
+	}
+}
@@ -0,0 +1,296 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/inspector"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+Detachment describes a connection or flattened session that the browser
+detached, for example because a user opened DevTools for the page or another
+client took over the target.
+*/
+type Detachment struct {
+	// The detached session, empty if the socket's own connection was
+	// detached.
+	SessionID target.SessionID
+
+	// The target of the detached session, if it's known.
+	TargetID target.ID
+
+	// The reason reported by the Inspector.detached event, empty if the
+	// browser didn't report one. See DetachReason.
+	Reason string
+}
+
+/*
+DetachReason returns the typed detach reason. Reasons that aren't known to
+inspector.DetachReasonEnum return the zero value.
+*/
+func (detachment *Detachment) DetachReason() inspector.DetachReasonEnum {
+	return (&inspector.DetachedEvent{Reason: detachment.Reason}).DetachReason()
+}
+
+/*
+Error implements the error interface.
+*/
+func (detachment *Detachment) Error() string {
+	subject := "the connection"
+	if "" != detachment.SessionID {
+		subject = fmt.Sprintf("session '%s'", detachment.SessionID)
+	}
+	if "" == detachment.Reason {
+		return fmt.Sprintf("the browser detached %s", subject)
+	}
+	return fmt.Sprintf("the browser detached %s: %s", subject, detachment.Reason)
+}
+
+/*
+reattachable returns whether attaching again can succeed: the target wasn't
+closed, didn't crash and the user didn't cancel debugging.
+*/
+func (detachment *Detachment) reattachable() bool {
+	switch detachment.DetachReason() {
+	case inspector.DetachReason.TargetClosed,
+		inspector.DetachReason.CanceledByUser,
+		inspector.DetachReason.RenderProcessGone:
+		return false
+	}
+	return true
+}
+
+/*
+WithDetachHook registers a function that is called when the browser detaches
+the socket's connection or one of its flattened sessions, for example because
+a user opened DevTools for the page:
+
+	sock := socket.New(socketURL, socket.WithDetachHook(func(detachment *socket.Detachment) {
+		if inspector.DetachReason.ReplacedWithDevtools == detachment.DetachReason() {
+			...
+		}
+	}))
+
+Sessions detached with Session.Stop aren't reported.
+*/
+func WithDetachHook(hook func(detachment *Detachment)) Option {
+	return func(socket *Socket) {
+		socket.detachHook = hook
+	}
+}
+
+/*
+WithReattach attaches again when the browser detaches the socket's connection
+or one of its flattened sessions for a reason other than the target closing or
+crashing or the user canceling debugging. Sessions keep their event handlers
+and the domains that were enabled are enabled again. The connection is
+re-established with the reconnect policy, or with up to 3 attempts if there is
+none.
+
+Reattaching to a target that DevTools is attached to only succeeds if the
+browser allows multiple clients.
+*/
+func WithReattach() Option {
+	return func(socket *Socket) {
+		socket.reattach = true
+		if nil == socket.enabled {
+			socket.enabled = newEnabledDomains()
+		}
+	}
+}
+
+/*
+Detached returns the detachment of the socket's connection, or nil if the
+browser hasn't detached it.
+*/
+func (socket *Socket) Detached() *Detachment {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	return socket.detachment
+}
+
+/*
+Detached returns the detachment of the session, or nil if the browser hasn't
+detached it.
+*/
+func (session *Session) Detached() *Detachment {
+	session.mux.Lock()
+	defer session.mux.Unlock()
+	return session.detachment
+}
+
+/*
+Reattach attaches to the session's target again after the browser detached it.
+The session gets a new ID and keeps its event handlers, and the domains that
+were enabled through the session are enabled again. Reattach does nothing if
+the session is attached.
+*/
+func (session *Session) Reattach() error {
+	session.mux.Lock()
+	detachment := session.detachment
+	targetID := session.targetID
+	session.mux.Unlock()
+	if nil == detachment {
+		return nil
+	}
+	if "" == targetID {
+		return errs.New(codes.SocketDetached, fmt.Sprintf("could not reattach session '%s': its target is unknown", detachment.SessionID))
+	}
+
+	result := <-session.socket.Target().AttachToTarget(&target.AttachToTargetParams{
+		ID:      targetID,
+		Flatten: true,
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.SocketDetached, fmt.Sprintf("could not reattach to target '%s'", targetID))
+	}
+
+	session.mux.Lock()
+	session.id = result.SessionID
+	session.detachReason = ""
+	session.detachment = nil
+	session.mux.Unlock()
+	session.socket.sessions.set(session)
+	session.socket.logger.Info("session reattached", logger.Fields{"sessionID": result.SessionID, "socketID": session.socket.socketID, "targetID": targetID})
+
+	domains, params := session.enabled.list()
+	for _, domain := range domains {
+		response := <-session.SendCommand(NewCommand(session, domain+".enable", params[domain]))
+		if nil != response.Error && 0 != response.Error.Code {
+			session.socket.logger.Warn("could not enable domain after reattaching", logger.Fields{"domain": domain, "error": response.Error, "sessionID": result.SessionID, "socketID": session.socket.socketID})
+		}
+	}
+	return nil
+}
+
+/*
+inspectorDetached records the reason of an Inspector.detached event. The
+connection of the socket is detached right away, a session is detached when
+the Target.detachedFromTarget event follows.
+*/
+func (socket *Socket) inspectorDetached(response *Response, session *Session) {
+	event := &inspector.DetachedEvent{}
+	json.Unmarshal(response.Params, event)
+
+	if nil != session {
+		session.mux.Lock()
+		session.detachReason = event.Reason
+		session.mux.Unlock()
+		return
+	}
+
+	detachment := &Detachment{Reason: event.Reason}
+	socket.mux.Lock()
+	socket.detachment = detachment
+	socket.mux.Unlock()
+	socket.logger.Warn(detachment.Error(), logger.Fields{"reason": event.Reason, "socketID": socket.socketID})
+	socket.detached(detachment)
+}
+
+/*
+sessionDetached removes the session of a Target.detachedFromTarget event and,
+unless the session was stopped, marks it detached and reattaches it if that's
+enabled.
+*/
+func (socket *Socket) sessionDetached(response *Response) {
+	event := &target.DetachedFromTargetEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err {
+		return
+	}
+	session, ok := socket.sessions.get(event.SessionID)
+	socket.sessions.remove(event.SessionID)
+	if !ok {
+		return
+	}
+
+	session.mux.Lock()
+	if session.stopped || event.SessionID != session.id {
+		session.mux.Unlock()
+		return
+	}
+	if "" == session.targetID {
+		session.targetID = event.ID
+	}
+	detachment := &Detachment{
+		SessionID: event.SessionID,
+		TargetID:  session.targetID,
+		Reason:    session.detachReason,
+	}
+	session.detachment = detachment
+	session.mux.Unlock()
+	socket.logger.Warn(detachment.Error(), logger.Fields{"reason": detachment.Reason, "sessionID": event.SessionID, "socketID": socket.socketID, "targetID": detachment.TargetID})
+	socket.detached(detachment)
+
+	if socket.reattach && detachment.reattachable() {
+		go func() {
+			if err := session.Reattach(); nil != err {
+				socket.logger.Warn(err.Error(), logger.Fields{"error": err, "sessionID": event.SessionID, "socketID": socket.socketID})
+				if nil != socket.errorHook {
+					socket.errorHook(err)
+				}
+			}
+		}()
+	}
+}
+
+/*
+detached passes a detachment to the detach hook.
+*/
+func (socket *Socket) detached(detachment *Detachment) {
+	if nil == socket.detachHook {
+		return
+	}
+	socket.readLoopCallback(func() {
+		socket.guard("detach hook", "Inspector.detached", func() {
+			socket.detachHook(detachment)
+		})
+	})
+}
+
+/*
+reattachPolicy returns the policy used to re-establish a connection that the
+browser detached, or nil if it shouldn't be re-established.
+*/
+func (socket *Socket) reattachPolicy() *ReconnectPolicy {
+	if nil != socket.reconnectPolicy {
+		return socket.reconnectPolicy
+	}
+	detachment := socket.Detached()
+	if !socket.reattach || nil == detachment || !detachment.reattachable() {
+		return nil
+	}
+	return &ReconnectPolicy{MaxAttempts: 3}
+}
+
+/*
+detachedError wraps a read error with the detachment of the connection, if the
+browser detached it.
+*/
+func (socket *Socket) detachedError(err error) error {
+	detachment := socket.Detached()
+	if nil == detachment {
+		return err
+	}
+	return errs.Wrap(err, codes.SocketDetached, detachment.Error())
+}
+
+/*
+detachedResponse returns the error response to a command sent to a detached
+session.
+*/
+func detachedResponse(command Commander, detachment *Detachment) *Response {
+	err := errs.New(codes.SocketDetached, fmt.Sprintf("command #%d '%s' was not sent: %s", command.ID(), command.Method(), detachment.Error()))
+	return &Response{
+		Error: &Error{
+			Code:    int(codes.SocketDetached),
+			Data:    []byte(fmt.Sprintf("%q", err.Error())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+	}
+}
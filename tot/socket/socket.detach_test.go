@@ -0,0 +1,271 @@
+package socket
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/inspector"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestDetachment(t *testing.T) {
+	detachment := &Detachment{Reason: "replaced_with_devtools"}
+	if "the browser detached the connection: replaced_with_devtools" != detachment.Error() {
+		t.Errorf("Unexpected error message '%s'", detachment.Error())
+	}
+	if inspector.DetachReason.ReplacedWithDevtools != detachment.DetachReason() {
+		t.Errorf("Expected %s, got %s", inspector.DetachReason.ReplacedWithDevtools, detachment.DetachReason())
+	}
+	if !detachment.reattachable() {
+		t.Errorf("Expected a session replaced by DevTools to be reattachable")
+	}
+
+	detachment = &Detachment{SessionID: "session-1"}
+	if "the browser detached session 'session-1'" != detachment.Error() {
+		t.Errorf("Unexpected error message '%s'", detachment.Error())
+	}
+	if !detachment.reattachable() {
+		t.Errorf("Expected a session detached without a reason to be reattachable")
+	}
+
+	for _, reason := range []string{"target_closed", "canceled_by_user", "Render process gone."} {
+		if (&Detachment{Reason: reason}).reattachable() {
+			t.Errorf("Expected a session detached with '%s' not to be reattachable", reason)
+		}
+	}
+}
+
+func TestSocketSessionDetached(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	detachments := make(chan *Detachment, 2)
+	socket := New(server.URL(), WithDetachHook(func(detachment *Detachment) {
+		detachments <- detachment
+	}))
+	defer socket.Stop()
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	events := make(chan *inspector.DetachedEvent, 1)
+	session.Inspector().OnDetached(func(event *inspector.DetachedEvent) {
+		events <- event
+	})
+
+	server.EmitSession("session-1", "Inspector.detached", map[string]string{"reason": "replaced_with_devtools"})
+	server.Emit("Target.detachedFromTarget", map[string]string{"sessionId": "session-1", "targetId": "target-1"})
+	select {
+	case event := <-events:
+		if inspector.DetachReason.ReplacedWithDevtools != event.DetachReason() {
+			t.Errorf("Expected %s, got %s", inspector.DetachReason.ReplacedWithDevtools, event.DetachReason())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the Inspector.detached event to be delivered to the session")
+	}
+	var detachment *Detachment
+	select {
+	case detachment = <-detachments:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the detach hook to be called")
+	}
+	if "session-1" != detachment.SessionID || "target-1" != detachment.TargetID || "replaced_with_devtools" != detachment.Reason {
+		t.Errorf("Unexpected detachment %+v", detachment)
+	}
+	if detachment != session.Detached() {
+		t.Errorf("Expected the session to be detached")
+	}
+
+	sent := len(server.Commands())
+	response := <-session.SendCommand(NewCommand(session, "Page.reload", nil))
+	if nil == response.Error || int(codes.SocketDetached) != response.Error.Code {
+		t.Fatalf("Expected a detached error, got %v", response.Error)
+	}
+	if !strings.Contains(response.Error.Message, "replaced_with_devtools") {
+		t.Errorf("Expected the error to include the detach reason, got '%s'", response.Error.Message)
+	}
+	if sent != len(server.Commands()) {
+		t.Errorf("Expected the command not to be sent")
+	}
+	if nil != socket.Detached() {
+		t.Errorf("Expected the socket's connection not to be detached")
+	}
+}
+
+func TestSocketSessionStopNotDetached(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	detachments := make(chan *Detachment, 1)
+	socket := New(server.URL(), WithDetachHook(func(detachment *Detachment) {
+		detachments <- detachment
+	}))
+	defer socket.Stop()
+
+	session := socket.Session("session-1")
+	server.Handle("Target.detachFromTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		server.Emit("Target.detachedFromTarget", map[string]string{"sessionId": "session-1"})
+		return map[string]string{}, nil
+	})
+	session.Stop()
+	select {
+	case detachment := <-detachments:
+		t.Errorf("Expected a stopped session not to be reported, got %+v", detachment)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSocketSessionReattach(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	var attached int32
+	server.Handle("Target.attachToTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		if 1 == atomic.AddInt32(&attached, 1) {
+			return map[string]string{"sessionId": "session-1"}, nil
+		}
+		return map[string]string{"sessionId": "session-2"}, nil
+	})
+	socket := New(server.URL(), WithReattach())
+	defer socket.Stop()
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if result := <-session.Page().Enable(); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	events := make(chan bool, 1)
+	session.AddEventHandler(NewEventHandler("Some.event", func(response *Response) {
+		events <- true
+	}))
+
+	server.EmitSession("session-1", "Inspector.detached", map[string]string{"reason": "replaced_with_devtools"})
+	server.Emit("Target.detachedFromTarget", map[string]string{"sessionId": "session-1", "targetId": "target-1"})
+	deadline := time.Now().Add(5 * time.Second)
+	for "session-2" != session.ID() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the session to be reattached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if nil != session.Detached() {
+		t.Errorf("Expected the reattached session not to be detached")
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		enabled := false
+		for _, command := range server.Commands() {
+			if "Page.enable" == command.Method && "session-2" == command.SessionID {
+				enabled = true
+			}
+		}
+		if enabled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected Page.enable to be sent to the reattached session")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	server.EmitSession("session-2", "Some.event", map[string]string{})
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the event handler to be kept after reattaching")
+	}
+}
+
+func TestSocketSessionTargetClosed(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	detachments := make(chan *Detachment, 1)
+	socket := New(server.URL(), WithReattach(), WithDetachHook(func(detachment *Detachment) {
+		detachments <- detachment
+	}))
+	defer socket.Stop()
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	server.EmitSession("session-1", "Inspector.detached", map[string]string{"reason": "target_closed"})
+	server.Emit("Target.detachedFromTarget", map[string]string{"sessionId": "session-1", "targetId": "target-1"})
+	select {
+	case <-detachments:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the detach hook to be called")
+	}
+	time.Sleep(50 * time.Millisecond)
+	attaches := 0
+	for _, command := range server.Commands() {
+		if "Target.attachToTarget" == command.Method {
+			attaches++
+		}
+	}
+	if 1 != attaches {
+		t.Errorf("Expected a closed target not to be reattached, attached %d times", attaches)
+	}
+	if nil == session.Detached() {
+		t.Errorf("Expected the session to stay detached")
+	}
+}
+
+func TestSocketConnectionReattach(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	detachments := make(chan *Detachment, 1)
+	socket := New(server.URL(), WithReattach(), WithDetachHook(func(detachment *Detachment) {
+		detachments <- detachment
+	}))
+	defer socket.Stop()
+
+	if result := <-socket.Page().Enable(); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	server.Emit("Inspector.detached", map[string]string{"reason": "replaced_with_devtools"})
+	select {
+	case detachment := <-detachments:
+		if "" != detachment.SessionID || "replaced_with_devtools" != detachment.Reason {
+			t.Errorf("Unexpected detachment %+v", detachment)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the detach hook to be called")
+	}
+	if nil == socket.Detached() {
+		t.Fatalf("Expected the connection to be detached")
+	}
+
+	server.SetChaos(cdptest.Chaos{DisconnectAfter: 1})
+	response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	if nil == response.Error || int(codes.SocketConnectionLost) != response.Error.Code {
+		t.Fatalf("Expected a connection lost error, got %v", response.Error)
+	}
+	server.SetChaos(cdptest.Chaos{})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for nil != socket.Detached() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the connection to be reattached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		commands := server.Commands()
+		if "Page.enable" == commands[len(commands)-1].Method {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected Page.enable to be sent again")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,105 @@
+package socket
+
+import (
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestWithDialer(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	var dials int32
+	socket := New(server.URL(), WithDialer(func(network, addr string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		return net.Dial(network, addr)
+	}))
+	defer socket.Stop()
+
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)); nil != response.Err() {
+		t.Fatalf("Expected nil, got error: %v", response.Err())
+	}
+	if 1 != atomic.LoadInt32(&dials) {
+		t.Errorf("Expected 1 dial, got %d", dials)
+	}
+}
+
+/*
+newConnectProxy starts an HTTP proxy that tunnels CONNECT requests and records
+the Proxy-Authorization header of the last request.
+*/
+func newConnectProxy(auth *atomic.Value) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if http.MethodConnect != r.Method {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		auth.Store(r.Header.Get("Proxy-Authorization"))
+		upstream, err := net.Dial("tcp", r.Host)
+		if nil != err {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if nil != err {
+			upstream.Close()
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			io.Copy(upstream, buf)
+			upstream.Close()
+		}()
+		io.Copy(conn, upstream)
+		conn.Close()
+	}))
+}
+
+func TestWithProxy(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	auth := &atomic.Value{}
+	proxy := newConnectProxy(auth)
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("user", "secret")
+	socket := New(server.URL(), WithProxy(proxyURL))
+	defer socket.Stop()
+
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)); nil != response.Err() {
+		t.Fatalf("Expected nil, got error: %v", response.Err())
+	}
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	if value, _ := auth.Load().(string); expected != value {
+		t.Errorf("Expected the connection to be tunneled with credentials, got '%s'", value)
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	server := cdptest.NewTLSServer(nil)
+	defer server.Close()
+	if "wss" != server.URL().Scheme {
+		t.Fatalf("Expected a wss:// URL, got %s", server.URL())
+	}
+
+	socket := New(server.URL(), WithTLSConfig(server.TLSConfig()))
+	defer socket.Stop()
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)); nil != response.Err() {
+		t.Fatalf("Expected nil, got error: %v", response.Err())
+	}
+
+	untrusted := New(server.URL())
+	defer untrusted.Stop()
+	if err := untrusted.Connect(); nil == err {
+		t.Errorf("Expected an untrusted certificate to be rejected")
+	}
+}
@@ -0,0 +1,95 @@
+package socket
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+enableWarning is how long an event handler can wait for its domain to be
+enabled before a warning is logged.
+*/
+var enableWarning = 5 * time.Second
+
+/*
+enableFree are the domains whose events are delivered without a
+<Domain>.enable command, because other commands start them.
+*/
+var enableFree = map[string]bool{
+	"Browser":      true,
+	"HeapProfiler": true,
+	"IO":           true,
+	"Storage":      true,
+	"Target":       true,
+	"Tracing":      true,
+}
+
+/*
+EnabledDomains returns the domains enabled on the socket's connection with a
+<Domain>.enable command that hasn't been followed by <Domain>.disable, in the
+order they were enabled. Domains enabled through sessions are tracked by the
+sessions.
+*/
+func (socket *Socket) EnabledDomains() []string {
+	if nil == socket.enabled {
+		return []string{}
+	}
+	domains, _ := socket.enabled.list()
+	return domains
+}
+
+/*
+EnabledDomains returns the domains enabled on the session's target, in the
+order they were enabled.
+*/
+func (session *Session) EnabledDomains() []string {
+	domains, _ := session.enabled.list()
+	return domains
+}
+
+/*
+has returns whether a domain is enabled.
+*/
+func (enabled *enabledDomains) has(domain string) bool {
+	enabled.mux.Lock()
+	defer enabled.mux.Unlock()
+	_, ok := enabled.params[domain]
+	return ok
+}
+
+/*
+warn returns true the first time it's called for a domain.
+*/
+func (enabled *enabledDomains) warn(domain string) bool {
+	enabled.mux.Lock()
+	defer enabled.mux.Unlock()
+	if enabled.warned[domain] {
+		return false
+	}
+	enabled.warned[domain] = true
+	return true
+}
+
+/*
+checkEnabled logs a warning if the domain of an event handler still isn't
+enabled a while after the handler was added, which usually means its events
+will never be delivered. Handlers are often added just before the domain is
+enabled, so the check is delayed. Each domain is only reported once.
+*/
+func (socket *Socket) checkEnabled(enabled *enabledDomains, handler EventHandler, fields logger.Fields) {
+	domain := eventDomain(handler.Name())
+	if nil == enabled || enableFree[domain] || enabled.has(domain) {
+		return
+	}
+	time.AfterFunc(enableWarning, func() {
+		if 0 != atomic.LoadInt32(&socket.shutdown) || enabled.has(domain) || !enabled.warn(domain) {
+			return
+		}
+		fields["domain"] = domain
+		fields["event"] = handler.Name()
+		socket.logger.Warn(fmt.Sprintf("an event handler was added for '%s' but the %s domain isn't enabled, its events aren't delivered until %s.enable is sent", handler.Name(), domain, domain), fields)
+	})
+}
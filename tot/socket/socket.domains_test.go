@@ -0,0 +1,73 @@
+package socket
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestEnabledDomains(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	<-socket.Page().Enable()
+	<-socket.Network().Enable(nil)
+	<-socket.Page().Disable()
+	if domains := socket.EnabledDomains(); 1 != len(domains) || "Network" != domains[0] {
+		t.Errorf("Expected [Network], got %v", domains)
+	}
+
+	session := socket.Session("session-1")
+	<-session.Runtime().Enable()
+	if domains := session.EnabledDomains(); 1 != len(domains) || "Runtime" != domains[0] {
+		t.Errorf("Expected [Runtime], got %v", domains)
+	}
+	if domains := socket.EnabledDomains(); 1 != len(domains) {
+		t.Errorf("Expected the session's domains to be tracked separately, got %v", domains)
+	}
+}
+
+func TestEnableWarning(t *testing.T) {
+	warning := enableWarning
+	enableWarning = 50 * time.Millisecond
+	defer func() { enableWarning = warning }()
+
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	mux := &sync.Mutex{}
+	warnings := []string{}
+	socket := New(server.URL(), WithLogger(logger.Func(func(level logger.Level, msg string, fields logger.Fields) {
+		if logger.WarnLevel == level && strings.Contains(msg, "isn't enabled") {
+			mux.Lock()
+			warnings = append(warnings, fields["domain"].(string))
+			mux.Unlock()
+		}
+	})))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	noop := func(response *Response) {}
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", noop))
+	socket.AddEventHandler(NewEventHandler("Page.frameNavigated", noop))
+	socket.AddEventHandler(NewEventHandler("Target.targetCreated", noop))
+	socket.AddEventHandler(NewEventHandler("Network.requestWillBeSent", noop))
+	<-socket.Network().Enable(nil)
+	time.Sleep(200 * time.Millisecond)
+
+	mux.Lock()
+	defer mux.Unlock()
+	if 1 != len(warnings) || "Page" != warnings[0] {
+		t.Errorf("Expected one warning for the Page domain, got %v", warnings)
+	}
+}
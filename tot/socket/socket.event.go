@@ -0,0 +1,133 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+NewEvent returns a pointer to an Event envelope for the specified socket
+response.
+*/
+func NewEvent(response *Response) *Event {
+	return &Event{
+		mux:      &sync.Mutex{},
+		response: response,
+	}
+}
+
+/*
+Event is a lightweight envelope for an event read from the websocket. The event
+parameters are kept in their raw form and are only decoded when the consumer
+asks for them, so subscribers that only need the method name or a single field
+don't pay the cost of unmarshalling the full event struct.
+*/
+type Event struct {
+	// fields caches the top-level parameter values once a single field has
+	// been requested.
+	fields map[string]json.RawMessage
+
+	// mux guards the fields cache.
+	mux *sync.Mutex
+
+	// response is the socket response the event was read from.
+	response *Response
+}
+
+/*
+Decode unmarshals the event parameters into the provided typed event struct,
+e.g. a *network.RequestWillBeSentEvent.
+*/
+func (event *Event) Decode(v interface{}) error {
+	if err := json.Unmarshal(event.response.Params, v); nil != err {
+		return errs.Wrap(err, codes.SocketEventDecodeFailed, fmt.Sprintf("could not decode %s event", event.Method()))
+	}
+	return nil
+}
+
+/*
+Domain returns the protocol domain the event belongs to, e.g. 'Network' for
+the 'Network.requestWillBeSent' event.
+*/
+func (event *Event) Domain() string {
+	if idx := strings.Index(event.response.Method, "."); idx > -1 {
+		return event.response.Method[:idx]
+	}
+	return event.response.Method
+}
+
+/*
+Err returns the error delivered with the event, if any.
+*/
+func (event *Event) Err() error {
+	if nil != event.response.Error && 0 != event.response.Error.Code {
+		return event.response.Error
+	}
+	return nil
+}
+
+/*
+Field unmarshals a single top-level event parameter into the provided variable.
+The parameter object is only split into its fields once, subsequent calls reuse
+the result.
+*/
+func (event *Event) Field(name string, v interface{}) error {
+	event.mux.Lock()
+	if nil == event.fields {
+		fields := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(event.response.Params, &fields); nil != err {
+			event.mux.Unlock()
+			return errs.Wrap(err, codes.SocketEventDecodeFailed, fmt.Sprintf("could not decode %s event", event.Method()))
+		}
+		event.fields = fields
+	}
+	raw, ok := event.fields[name]
+	event.mux.Unlock()
+
+	if !ok {
+		return errs.New(codes.SocketEventFieldNotFound, fmt.Sprintf("field '%s' not found in %s event", name, event.Method()))
+	}
+	if err := json.Unmarshal(raw, v); nil != err {
+		return errs.Wrap(err, codes.SocketEventDecodeFailed, fmt.Sprintf("could not decode field '%s' of %s event", name, event.Method()))
+	}
+	return nil
+}
+
+/*
+Method returns the name of the event, e.g. 'Network.requestWillBeSent'.
+*/
+func (event *Event) Method() string {
+	return event.response.Method
+}
+
+/*
+Params returns the raw, undecoded event parameters.
+*/
+func (event *Event) Params() json.RawMessage {
+	return event.response.Params
+}
+
+/*
+Response returns the socket response the event was read from.
+*/
+func (event *Event) Response() *Response {
+	return event.response
+}
+
+/*
+NewEnvelopeHandler returns a pointer to an event handler that delivers events
+as lazily decoded Event envelopes instead of typed event structs.
+*/
+func NewEnvelopeHandler(
+	name string,
+	callback func(event *Event),
+) *Handler {
+	return NewEventHandler(name, func(response *Response) {
+		callback(NewEvent(response))
+	})
+}
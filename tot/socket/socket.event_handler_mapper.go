@@ -5,7 +5,6 @@ import (
 	"sync"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/mkenney/go-chrome/codes"
 )
 
@@ -39,20 +38,14 @@ func (stack *EventHandlerMap) Add(
 	stack.Lock()
 	defer stack.Unlock()
 
-	handlers, err := stack.Get(handler.Name())
-	if nil != err {
-		handlers = make([]EventHandler, 0)
-	}
+	handlers := stack.stack[handler.Name()]
 	for _, hndl := range handlers {
 		if hndl == handler {
 			return errs.New(codes.SocketDuplicateEventHandler, fmt.Sprintf("Attempted to add a duplicate handler for event '%s'", handler.Name()))
 		}
 	}
 
-	log.WithFields(log.Fields{"event": handler.Name()}).
-		Debug("Adding event handler")
-	handlers = append(handlers, handler)
-	stack.Set(handler.Name(), handlers)
+	stack.Set(handler.Name(), append(handlers, handler))
 	return nil
 }
 
@@ -68,15 +61,19 @@ func (stack *EventHandlerMap) Delete(
 }
 
 /*
-Get retrieves the entire stack of handlers for an event.
+Get retrieves a copy of the entire stack of handlers for an event, which is
+safe to iterate while handlers are added and removed. Get locks the mutex, so
+it must not be called with the mutex locked.
 
 Get is an EventHandlerMapper implementation.
 */
 func (stack *EventHandlerMap) Get(
 	name string,
 ) ([]EventHandler, error) {
+	stack.Lock()
+	defer stack.Unlock()
 	if handlers, ok := stack.stack[name]; ok {
-		return handlers, nil
+		return append([]EventHandler{}, handlers...), nil
 	}
 	return nil, errs.New(codes.SocketDuplicateEventHandler, fmt.Sprintf("No event listeners found for %s", name))
 }
@@ -101,9 +98,14 @@ func (stack *EventHandlerMap) Remove(
 	stack.Lock()
 	defer stack.Unlock()
 
-	for k, hndl := range stack.stack[handler.Name()] {
+	handlers := stack.stack[handler.Name()]
+	for k, hndl := range handlers {
 		if hndl == handler {
-			stack.stack[handler.Name()] = append(stack.stack[handler.Name()][:k], stack.stack[handler.Name()][k+1:]...)
+			// A new stack is built so that a stack being iterated isn't
+			// rewritten.
+			remaining := make([]EventHandler, 0, len(handlers)-1)
+			remaining = append(remaining, handlers[:k]...)
+			stack.stack[handler.Name()] = append(remaining, handlers[k+1:]...)
 			return nil
 		}
 	}
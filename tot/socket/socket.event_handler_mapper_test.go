@@ -36,3 +36,27 @@ func TestEventHandlerMapper(t *testing.T) {
 	// no-op
 	handlerMap.Delete("eventName")
 }
+
+func TestEventHandlerMapperGetCopy(t *testing.T) {
+	handlerMap := NewEventHandlerMap()
+	first := NewEventHandler("eventName", func(response *Response) {})
+	second := NewEventHandler("eventName", func(response *Response) {})
+	handlerMap.Add(first)
+	handlerMap.Add(second)
+
+	handlers, err := handlerMap.Get("eventName")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if err := handlerMap.Remove(first); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if 2 != len(handlers) || first != handlers[0] || second != handlers[1] {
+		t.Errorf("Expected Get to return a copy unchanged by Remove, got %v", handlers)
+	}
+
+	remaining, _ := handlerMap.Get("eventName")
+	if 1 != len(remaining) || second != remaining[0] {
+		t.Errorf("Expected only the second handler, got %v", remaining)
+	}
+}
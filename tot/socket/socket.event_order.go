@@ -0,0 +1,96 @@
+package socket
+
+import (
+	"strings"
+	"sync"
+)
+
+/*
+WithOrderedEvents delivers the events of each domain to their handlers strictly
+in the order they were received, for example so that Network.responseReceived
+is always handled after the Network.requestWillBeSent of the same request.
+Handlers of different domains, and of the same domain in different flattened
+sessions, still run concurrently.
+
+The handlers of an event run one at a time and the next event of the domain
+waits for them to return, so a slow handler delays its domain. A handler must
+not wait for a later event of its own domain, which would never be delivered.
+Without the option every handler runs in its own goroutine and events can be
+handled out of order.
+*/
+func WithOrderedEvents() Option {
+	return func(socket *Socket) {
+		socket.ordered = newOrderedDispatch()
+	}
+}
+
+/*
+orderedDispatch runs event handlers serially per domain and session.
+*/
+type orderedDispatch struct {
+	mux    *sync.Mutex
+	queues map[string]*dispatchQueue
+}
+
+/*
+dispatchQueue is the pending handler calls of a domain.
+*/
+type dispatchQueue struct {
+	calls   []func()
+	running bool
+}
+
+func newOrderedDispatch() *orderedDispatch {
+	return &orderedDispatch{
+		mux:    &sync.Mutex{},
+		queues: make(map[string]*dispatchQueue),
+	}
+}
+
+/*
+enqueue adds a handler call for an event to the queue of its domain and starts
+running the queue if it isn't already.
+*/
+func (ordered *orderedDispatch) enqueue(response *Response, call func()) {
+	key := response.SessionID + " " + eventDomain(response.Method)
+	ordered.mux.Lock()
+	defer ordered.mux.Unlock()
+	queue, ok := ordered.queues[key]
+	if !ok {
+		queue = &dispatchQueue{}
+		ordered.queues[key] = queue
+	}
+	queue.calls = append(queue.calls, call)
+	if !queue.running {
+		queue.running = true
+		go ordered.run(key, queue)
+	}
+}
+
+/*
+run runs the calls of a queue in order until it's empty, then removes it.
+*/
+func (ordered *orderedDispatch) run(key string, queue *dispatchQueue) {
+	for {
+		ordered.mux.Lock()
+		if 0 == len(queue.calls) {
+			delete(ordered.queues, key)
+			ordered.mux.Unlock()
+			return
+		}
+		call := queue.calls[0]
+		queue.calls = queue.calls[1:]
+		ordered.mux.Unlock()
+		call()
+	}
+}
+
+/*
+eventDomain returns the domain of an event name.
+*/
+func eventDomain(method string) string {
+	if dot := strings.Index(method, "."); dot >= 0 {
+		return method[:dot]
+	}
+	return method
+}
@@ -0,0 +1,86 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSocketOrderedEvents(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithOrderedEvents())
+	socket.Listen()
+	defer socket.Stop()
+
+	const count = 50
+	mux := &sync.Mutex{}
+	received := make([]int, 0, count)
+	done := make(chan bool)
+	networkHandled := make(chan bool)
+	socket.AddEventHandler(NewEventHandler("Network.dataReceived", func(response *Response) {
+		params := struct {
+			Index int `json:"index"`
+		}{}
+		json.Unmarshal(response.Params, &params)
+		if 0 == params.Index {
+			close(networkHandled)
+		}
+		// Later events would overtake earlier ones if they weren't ordered.
+		time.Sleep(time.Duration(count-params.Index) * 100 * time.Microsecond)
+		mux.Lock()
+		received = append(received, params.Index)
+		if count == len(received) {
+			close(done)
+		}
+		mux.Unlock()
+	}))
+	pageHandled := make(chan bool)
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {
+		// Blocks until a Network event is handled, which would never happen
+		// if the domains shared a queue.
+		<-networkHandled
+		close(pageHandled)
+	}))
+
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+	for a := 0; a < count; a++ {
+		server.Emit("Network.dataReceived", map[string]int{"index": a})
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected %d events to be handled", count)
+	}
+	select {
+	case <-pageHandled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the domains to be dispatched concurrently")
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	for a, index := range received {
+		if a != index {
+			t.Fatalf("Expected the events in receive order, got %v", received)
+		}
+	}
+}
+
+func TestEventDomain(t *testing.T) {
+	for method, domain := range map[string]string{
+		"Network.requestWillBeSent": "Network",
+		"Page.loadEventFired":       "Page",
+		"custom":                    "custom",
+	} {
+		if domain != eventDomain(method) {
+			t.Errorf("Expected '%s' for '%s', got '%s'", domain, method, eventDomain(method))
+		}
+	}
+}
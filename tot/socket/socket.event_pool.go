@@ -0,0 +1,230 @@
+package socket
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/layer/tree"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+eventDecoder is a reusable JSON decoder. Decoding through a pooled json.Decoder
+reuses the decoder state and read buffer between events instead of allocating
+new ones for every message.
+*/
+type eventDecoder struct {
+	decoder *json.Decoder
+	reader  *bytes.Reader
+}
+
+var eventDecoderPool = sync.Pool{
+	New: func() interface{} {
+		reader := bytes.NewReader(nil)
+		return &eventDecoder{
+			decoder: json.NewDecoder(reader),
+			reader:  reader,
+		}
+	},
+}
+
+/*
+decodeEvent unmarshals event parameters into the provided struct using a pooled
+decoder. A decoder that returns an error is discarded rather than returned to
+the pool because json.Decoder errors are sticky.
+*/
+func decodeEvent(params json.RawMessage, event interface{}) error {
+	dec := eventDecoderPool.Get().(*eventDecoder)
+	dec.reader.Reset(params)
+	if err := dec.decoder.Decode(event); nil != err {
+		return err
+	}
+	dec.reader.Reset(nil)
+	eventDecoderPool.Put(dec)
+	return nil
+}
+
+/*
+eventPooler is implemented by sockets that can report whether event pooling is
+enabled.
+*/
+type eventPooler interface {
+	EventPooling() bool
+}
+
+/*
+eventPooling returns whether event structs should be pooled for the specified
+socket.
+*/
+func eventPooling(socket Socketer) bool {
+	if pooler, ok := socket.(eventPooler); ok {
+		return pooler.EventPooling()
+	}
+	return false
+}
+
+var (
+	layerPaintedEventPool = sync.Pool{New: func() interface{} {
+		return &tree.LayerPaintedEvent{}
+	}}
+	layerTreeDidChangeEventPool = sync.Pool{New: func() interface{} {
+		return &tree.DidChangeEvent{}
+	}}
+	dataReceivedEventPool = sync.Pool{New: func() interface{} {
+		return &network.DataReceivedEvent{}
+	}}
+	loadingFinishedEventPool = sync.Pool{New: func() interface{} {
+		return &network.LoadingFinishedEvent{}
+	}}
+	requestWillBeSentEventPool = sync.Pool{New: func() interface{} {
+		return &network.RequestWillBeSentEvent{}
+	}}
+	responseReceivedEventPool = sync.Pool{New: func() interface{} {
+		return &network.ResponseReceivedEvent{}
+	}}
+	screencastFrameEventPool = sync.Pool{New: func() interface{} {
+		return &page.ScreencastFrameEvent{}
+	}}
+)
+
+/*
+acquireLayerPaintedEvent returns a LayerTree.layerPainted event struct and
+whether it was taken from the pool.
+*/
+func acquireLayerPaintedEvent(socket Socketer) (*tree.LayerPaintedEvent, bool) {
+	if !eventPooling(socket) {
+		return &tree.LayerPaintedEvent{}, false
+	}
+	return layerPaintedEventPool.Get().(*tree.LayerPaintedEvent), true
+}
+
+/*
+releaseLayerPaintedEvent resets a pooled LayerTree.layerPainted event struct and
+returns it to the pool.
+*/
+func releaseLayerPaintedEvent(event *tree.LayerPaintedEvent) {
+	*event = tree.LayerPaintedEvent{}
+	layerPaintedEventPool.Put(event)
+}
+
+/*
+acquireLayerTreeDidChangeEvent returns a LayerTree.layerTreeDidChange event
+struct and whether it was taken from the pool.
+*/
+func acquireLayerTreeDidChangeEvent(socket Socketer) (*tree.DidChangeEvent, bool) {
+	if !eventPooling(socket) {
+		return &tree.DidChangeEvent{}, false
+	}
+	return layerTreeDidChangeEventPool.Get().(*tree.DidChangeEvent), true
+}
+
+/*
+releaseLayerTreeDidChangeEvent resets a pooled LayerTree.layerTreeDidChange
+event struct and returns it to the pool.
+*/
+func releaseLayerTreeDidChangeEvent(event *tree.DidChangeEvent) {
+	*event = tree.DidChangeEvent{}
+	layerTreeDidChangeEventPool.Put(event)
+}
+
+/*
+acquireDataReceivedEvent returns a Network.dataReceived event struct and whether
+it was taken from the pool.
+*/
+func acquireDataReceivedEvent(socket Socketer) (*network.DataReceivedEvent, bool) {
+	if !eventPooling(socket) {
+		return &network.DataReceivedEvent{}, false
+	}
+	return dataReceivedEventPool.Get().(*network.DataReceivedEvent), true
+}
+
+/*
+releaseDataReceivedEvent resets a pooled Network.dataReceived event struct and
+returns it to the pool.
+*/
+func releaseDataReceivedEvent(event *network.DataReceivedEvent) {
+	*event = network.DataReceivedEvent{}
+	dataReceivedEventPool.Put(event)
+}
+
+/*
+acquireLoadingFinishedEvent returns a Network.loadingFinished event struct and
+whether it was taken from the pool.
+*/
+func acquireLoadingFinishedEvent(socket Socketer) (*network.LoadingFinishedEvent, bool) {
+	if !eventPooling(socket) {
+		return &network.LoadingFinishedEvent{}, false
+	}
+	return loadingFinishedEventPool.Get().(*network.LoadingFinishedEvent), true
+}
+
+/*
+releaseLoadingFinishedEvent resets a pooled Network.loadingFinished event struct
+and returns it to the pool.
+*/
+func releaseLoadingFinishedEvent(event *network.LoadingFinishedEvent) {
+	*event = network.LoadingFinishedEvent{}
+	loadingFinishedEventPool.Put(event)
+}
+
+/*
+acquireRequestWillBeSentEvent returns a Network.requestWillBeSent event struct
+and whether it was taken from the pool.
+*/
+func acquireRequestWillBeSentEvent(socket Socketer) (*network.RequestWillBeSentEvent, bool) {
+	if !eventPooling(socket) {
+		return &network.RequestWillBeSentEvent{}, false
+	}
+	return requestWillBeSentEventPool.Get().(*network.RequestWillBeSentEvent), true
+}
+
+/*
+releaseRequestWillBeSentEvent resets a pooled Network.requestWillBeSent event
+struct and returns it to the pool.
+*/
+func releaseRequestWillBeSentEvent(event *network.RequestWillBeSentEvent) {
+	*event = network.RequestWillBeSentEvent{}
+	requestWillBeSentEventPool.Put(event)
+}
+
+/*
+acquireResponseReceivedEvent returns a Network.responseReceived event struct and
+whether it was taken from the pool.
+*/
+func acquireResponseReceivedEvent(socket Socketer) (*network.ResponseReceivedEvent, bool) {
+	if !eventPooling(socket) {
+		return &network.ResponseReceivedEvent{}, false
+	}
+	return responseReceivedEventPool.Get().(*network.ResponseReceivedEvent), true
+}
+
+/*
+releaseResponseReceivedEvent resets a pooled Network.responseReceived event
+struct and returns it to the pool.
+*/
+func releaseResponseReceivedEvent(event *network.ResponseReceivedEvent) {
+	*event = network.ResponseReceivedEvent{}
+	responseReceivedEventPool.Put(event)
+}
+
+/*
+acquireScreencastFrameEvent returns a Page.screencastFrame event struct and
+whether it was taken from the pool.
+*/
+func acquireScreencastFrameEvent(socket Socketer) (*page.ScreencastFrameEvent, bool) {
+	if !eventPooling(socket) {
+		return &page.ScreencastFrameEvent{}, false
+	}
+	return screencastFrameEventPool.Get().(*page.ScreencastFrameEvent), true
+}
+
+/*
+releaseScreencastFrameEvent resets a pooled Page.screencastFrame event struct
+and returns it to the pool.
+*/
+func releaseScreencastFrameEvent(event *page.ScreencastFrameEvent) {
+	*event = page.ScreencastFrameEvent{}
+	screencastFrameEventPool.Put(event)
+}
@@ -0,0 +1,125 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+var benchmarkDataReceivedParams = []byte(`{"requestId":"1000.1","timestamp":12345,"dataLength":65536,"encodedDataLength":1024}`)
+
+var benchmarkResponseReceivedParams = []byte(`{"requestId":"1000.1","loaderId":"loader-id","timestamp":12345,"type":"XHR","response":{"url":"https://example.com/api","status":200,"statusText":"OK","headers":{"Content-Type":"application/json","Cache-Control":"no-cache"},"mimeType":"application/json","connectionReused":true,"connectionId":1,"encodedDataLength":1024,"protocol":"h2"}}`)
+
+func TestDecodeEvent(t *testing.T) {
+	expected := &network.DataReceivedEvent{}
+	json.Unmarshal(benchmarkDataReceivedParams, expected)
+
+	for a := 0; a < 3; a++ {
+		event := &network.DataReceivedEvent{}
+		if err := decodeEvent(benchmarkDataReceivedParams, event); nil != err {
+			t.Errorf("Expected nil, got error: '%s'", err.Error())
+		}
+		if *expected != *event {
+			t.Errorf("Expected %v, got %v", expected, event)
+		}
+	}
+
+	if err := decodeEvent([]byte(`{"requestId":`), &network.DataReceivedEvent{}); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+	event := &network.DataReceivedEvent{}
+	if err := decodeEvent(benchmarkDataReceivedParams, event); nil != err {
+		t.Errorf("Expected nil after a failed decode, got error: '%s'", err.Error())
+	}
+}
+
+func TestEventPooling(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestEventPooling")
+	mockSocket := NewMock(socketURL)
+	if mockSocket.EventPooling() {
+		t.Errorf("Expected event pooling to be disabled by default")
+	}
+	if _, pooled := acquireDataReceivedEvent(mockSocket); pooled {
+		t.Errorf("Expected an unpooled event")
+	}
+
+	WithEventPooling()(mockSocket)
+	if !mockSocket.EventPooling() {
+		t.Errorf("Expected event pooling to be enabled")
+	}
+	event, pooled := acquireDataReceivedEvent(mockSocket)
+	if !pooled {
+		t.Errorf("Expected a pooled event")
+	}
+	event.RequestID = "request-id"
+	releaseDataReceivedEvent(event)
+	if "" != event.RequestID {
+		t.Errorf("Expected a released event to be reset, got %v", event)
+	}
+
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+	resultChan := make(chan network.DataReceivedEvent)
+	mockSocket.Network().OnDataReceived(func(eventData *network.DataReceivedEvent) {
+		resultChan <- *eventData
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Network.dataReceived",
+		Params: benchmarkDataReceivedParams,
+	})
+	result := <-resultChan
+	if "1000.1" != result.RequestID {
+		t.Errorf("Expected 1000.1, got %s", result.RequestID)
+	}
+}
+
+func BenchmarkEventUnmarshal(b *testing.B) {
+	b.ReportAllocs()
+	for a := 0; a < b.N; a++ {
+		event := &network.ResponseReceivedEvent{}
+		json.Unmarshal(benchmarkResponseReceivedParams, event)
+	}
+}
+
+func BenchmarkEventDecodePooled(b *testing.B) {
+	b.ReportAllocs()
+	for a := 0; a < b.N; a++ {
+		event := responseReceivedEventPool.Get().(*network.ResponseReceivedEvent)
+		decodeEvent(benchmarkResponseReceivedParams, event)
+		releaseResponseReceivedEvent(event)
+	}
+}
+
+func BenchmarkDataReceivedHandler(b *testing.B) {
+	socketURL, _ := url.Parse("https://test:9222/BenchmarkDataReceivedHandler")
+	benchmarkEventHandler(b, NewMock(socketURL))
+}
+
+func BenchmarkDataReceivedHandlerPooled(b *testing.B) {
+	socketURL, _ := url.Parse("https://test:9222/BenchmarkDataReceivedHandlerPooled")
+	mockSocket := NewMock(socketURL)
+	WithEventPooling()(mockSocket)
+	benchmarkEventHandler(b, mockSocket)
+}
+
+func benchmarkEventHandler(b *testing.B, mockSocket *Socket) {
+	var total int
+	mockSocket.Network().OnDataReceived(func(event *network.DataReceivedEvent) {
+		total += event.DataLength
+	})
+	handlers, _ := mockSocket.handlers.Get("Network.dataReceived")
+	response := &Response{
+		Method: "Network.dataReceived",
+		Params: benchmarkDataReceivedParams,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for a := 0; a < b.N; a++ {
+		handlers[0].Handle(response)
+	}
+}
@@ -0,0 +1,55 @@
+package socket
+
+import (
+	"sync"
+)
+
+/*
+eventStream delivers events to a typed channel until it is canceled. Sends and
+the closing of the channel are synchronized so that a channel is never written
+to after it's closed.
+*/
+type eventStream struct {
+	closed bool
+	done   chan struct{}
+	mux    *sync.RWMutex
+	once   *sync.Once
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{
+		done: make(chan struct{}),
+		mux:  &sync.RWMutex{},
+		once: &sync.Once{},
+	}
+}
+
+/*
+send executes a function that writes an event to the channel. The function
+must stop waiting when done is closed.
+*/
+func (stream *eventStream) send(fn func(done <-chan struct{})) {
+	stream.mux.RLock()
+	defer stream.mux.RUnlock()
+	if stream.closed {
+		return
+	}
+	fn(stream.done)
+}
+
+/*
+cancel returns a function that removes the event handler subscription, stops
+pending sends and closes the channel.
+*/
+func (stream *eventStream) cancel(sub *Subscription, closeCh func()) func() {
+	return func() {
+		stream.once.Do(func() {
+			sub.Remove()
+			close(stream.done)
+			stream.mux.Lock()
+			stream.closed = true
+			closeCh()
+			stream.mux.Unlock()
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+func TestEventStream(t *testing.T) {
+	stream := newEventStream()
+	eventCh := make(chan int)
+	sub := NewSubscription(NewEventHandler("Some.event", func(response *Response) {}), func() error { return nil })
+	cancel := stream.cancel(sub, func() { close(eventCh) })
+
+	// A pending send is stopped by cancel.
+	sent := make(chan bool)
+	go func() {
+		stream.send(func(done <-chan struct{}) {
+			select {
+			case eventCh <- 1:
+			case <-done:
+			}
+		})
+		sent <- true
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the pending send to be stopped")
+	}
+	if _, ok := <-eventCh; ok {
+		t.Errorf("Expected the channel to be closed")
+	}
+
+	// Sends after cancel are dropped.
+	stream.send(func(done <-chan struct{}) {
+		t.Errorf("Expected the send to be dropped")
+	})
+	cancel()
+}
+
+func TestPageLoadEventFiredChan(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestPageLoadEventFiredChan")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	eventCh, cancel := mockSocket.Page().LoadEventFiredChan(1)
+	mockResult := &page.LoadEventFiredEvent{
+		Timestamp: page.MonotonicTime(time.Now().Unix()),
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Page.loadEventFired",
+		Params: mockResultBytes,
+	})
+	select {
+	case result := <-eventCh:
+		if mockResult.Timestamp != result.Timestamp {
+			t.Errorf("Expected %d, got %d", mockResult.Timestamp, result.Timestamp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected an event")
+	}
+
+	cancel()
+	if _, ok := <-eventCh; ok {
+		t.Errorf("Expected the channel to be closed")
+	}
+	if handlers, _ := mockSocket.handlers.Get("Page.loadEventFired"); 0 != len(handlers) {
+		t.Errorf("Expected the handler to be removed, got %d handlers", len(handlers))
+	}
+}
+
+func TestDataReceivedChanPooled(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestDataReceivedChanPooled")
+	mockSocket := NewMock(socketURL)
+	WithEventPooling()(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	eventCh, cancel := mockSocket.Network().DataReceivedChan(2)
+	defer cancel()
+	for _, requestID := range []string{"1", "2"} {
+		mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+			ID:     0,
+			Error:  &Error{},
+			Method: "Network.dataReceived",
+			Params: []byte(`{"requestId":"` + requestID + `","dataLength":1}`),
+		})
+	}
+	// Both events are read after their handlers returned the pooled structs.
+	timeout := time.After(5 * time.Second)
+	for 2 != len(eventCh) {
+		select {
+		case <-timeout:
+			t.Fatalf("Expected 2 events, got %d", len(eventCh))
+		case <-time.After(time.Millisecond):
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	for _, requestID := range []network.RequestID{"1", "2"} {
+		if result := <-eventCh; requestID != result.RequestID || 1 != result.DataLength {
+			t.Errorf("Expected request %s, got %+v", requestID, result)
+		}
+	}
+}
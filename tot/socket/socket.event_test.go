@@ -0,0 +1,94 @@
+package socket
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+func TestEvent(t *testing.T) {
+	event := NewEvent(&Response{
+		Error:  &Error{},
+		Method: "Network.dataReceived",
+		Params: []byte(`{"requestId":"request-id","timestamp":1,"dataLength":10,"encodedDataLength":5}`),
+	})
+
+	if "Network.dataReceived" != event.Method() {
+		t.Errorf("Expected Network.dataReceived, got %s", event.Method())
+	}
+	if "Network" != event.Domain() {
+		t.Errorf("Expected Network, got %s", event.Domain())
+	}
+	if nil != event.Err() {
+		t.Errorf("Expected nil, got error: '%s'", event.Err().Error())
+	}
+
+	var requestID string
+	if err := event.Field("requestId", &requestID); nil != err {
+		t.Errorf("Expected nil, got error: '%s'", err.Error())
+	}
+	if "request-id" != requestID {
+		t.Errorf("Expected request-id, got %s", requestID)
+	}
+	if err := event.Field("missing", &requestID); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+	if err := event.Field("requestId", new(int)); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+
+	data := &network.DataReceivedEvent{}
+	if err := event.Decode(data); nil != err {
+		t.Errorf("Expected nil, got error: '%s'", err.Error())
+	}
+	if 10 != data.DataLength {
+		t.Errorf("Expected 10, got %d", data.DataLength)
+	}
+
+	event = NewEvent(&Response{
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+		Method: "Network.dataReceived",
+		Params: []byte(`{"requestId":`),
+	})
+	if nil == event.Err() {
+		t.Errorf("Expected error, got success")
+	}
+	if err := event.Field("requestId", &requestID); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+	if err := event.Decode(data); nil == err {
+		t.Errorf("Expected error, got success")
+	}
+}
+
+func TestEnvelopeHandler(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestEnvelopeHandler")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultChan := make(chan *Event)
+	mockSocket.AddEventHandler(NewEnvelopeHandler("Some.event", func(event *Event) {
+		resultChan <- event
+	}))
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Some.event",
+		Params: []byte(`{"value":"mock value"}`),
+	})
+
+	event := <-resultChan
+	var value string
+	if err := event.Field("value", &value); nil != err {
+		t.Errorf("Expected nil, got error: '%s'", err.Error())
+	}
+	if "mock value" != value {
+		t.Errorf("Expected 'mock value', got '%s'", value)
+	}
+}
@@ -0,0 +1,54 @@
+package socket
+
+import (
+	"fmt"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+WithProtocolExposureGuard checks every Target.exposeDevToolsProtocol command sent
+by the socket or its sessions before it's sent, after the explicit
+target.GrantProtocolAccess opt-in. Returning an error refuses the command with
+a SocketProtocolExposureDenied error. Use it to restrict exposure to the
+targets and binding names the application expects:
+
+	sock := socket.New(socketURL, socket.WithProtocolExposureGuard(func(params *target.ExposeDevToolsProtocolParams) error {
+		if toolingTarget != params.ID {
+			return fmt.Errorf("target '%s' is not the tooling page", params.ID)
+		}
+		return nil
+	}))
+*/
+func WithProtocolExposureGuard(guard func(params *target.ExposeDevToolsProtocolParams) error) Option {
+	return func(socket *Socket) {
+		socket.exposureGuard = guard
+	}
+}
+
+/*
+guardExposure returns a SocketProtocolExposureDenied error if the params don't
+grant protocol access or the socket's exposure guard refuses them.
+*/
+func guardExposure(socketer Socketer, params *target.ExposeDevToolsProtocolParams) error {
+	if nil == params || !params.Access.Granted() {
+		return errs.New(codes.SocketProtocolExposureDenied, "protocol access must be granted with target.GrantProtocolAccess")
+	}
+
+	var guard func(params *target.ExposeDevToolsProtocolParams) error
+	switch socket := socketer.(type) {
+	case *Socket:
+		guard = socket.exposureGuard
+	case *Session:
+		guard = socket.socket.exposureGuard
+	}
+	if nil == guard {
+		return nil
+	}
+	if err := guard(params); nil != err {
+		return errs.Wrap(err, codes.SocketProtocolExposureDenied, fmt.Sprintf("exposing the protocol to target '%s' was refused", params.ID))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package socket
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+func TestSocketProtocolExposure(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	socket := New(server.URL(), WithProtocolExposureGuard(func(params *target.ExposeDevToolsProtocolParams) error {
+		if "tooling" != params.ID {
+			return errors.New("not the tooling page")
+		}
+		return nil
+	}))
+	defer socket.Stop()
+
+	result := <-socket.Target().ExposeDevToolsProtocol(&target.ExposeDevToolsProtocolParams{ID: "tooling"})
+	if nil == result.Err || !strings.Contains(result.Err.Error(), "GrantProtocolAccess") {
+		t.Errorf("Expected an error without an explicit grant, got %v", result.Err)
+	}
+	result = <-socket.Target().ExposeDevToolsProtocol(&target.ExposeDevToolsProtocolParams{
+		ID:     "other",
+		Access: target.GrantProtocolAccess(),
+	})
+	if nil == result.Err || !strings.Contains(result.Err.Error(), "refused") {
+		t.Errorf("Expected the guard to refuse the target, got %v", result.Err)
+	}
+	if 0 != len(server.Commands()) {
+		t.Fatalf("Expected refused commands not to be sent, got %d", len(server.Commands()))
+	}
+
+	result = <-socket.Target().ExposeDevToolsProtocol(&target.ExposeDevToolsProtocolParams{
+		ID:          "tooling",
+		BindingName: "devtools",
+		Access:      target.GrantProtocolAccess(),
+	})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	commands := server.Commands()
+	if 1 != len(commands) || "Target.exposeDevToolsProtocol" != commands[0].Method {
+		t.Fatalf("Expected Target.exposeDevToolsProtocol to be sent, got %v", commands)
+	}
+	params := map[string]interface{}{}
+	json.Unmarshal(commands[0].Params, &params)
+	if "tooling" != params["targetId"] || "devtools" != params["bindingName"] || 2 != len(params) {
+		t.Errorf("Expected only the target ID and binding name to be sent, got %s", commands[0].Params)
+	}
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	result = <-session.Target().ExposeDevToolsProtocol(&target.ExposeDevToolsProtocolParams{
+		ID:     "other",
+		Access: target.GrantProtocolAccess(),
+	})
+	if nil == result.Err {
+		t.Errorf("Expected the socket's guard to apply to its sessions")
+	}
+}
+
+func TestProtocolAccess(t *testing.T) {
+	if (target.ProtocolAccess{}).Granted() {
+		t.Errorf("Expected the zero value not to grant access")
+	}
+	access := target.ProtocolAccess{}
+	json.Unmarshal([]byte(`{"granted":true}`), &access)
+	if access.Granted() {
+		t.Errorf("Expected access not to be granted by decoded input")
+	}
+	if !target.GrantProtocolAccess().Granted() {
+		t.Errorf("Expected GrantProtocolAccess to grant access")
+	}
+}
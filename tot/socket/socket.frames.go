@@ -0,0 +1,92 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+/*
+Frame is a websocket frame sent or received by a socket.
+*/
+type Frame struct {
+	// The time the frame was sent or received.
+	Time time.Time `json:"time"`
+
+	// Whether the frame was sent. Frames that weren't sent were received.
+	Sent bool `json:"sent"`
+
+	// The JSON payload of the frame.
+	Data json.RawMessage `json:"data"`
+}
+
+/*
+WithFrameHistory keeps the most recent frames sent and received by the socket,
+up to size, for diagnosing failures after the fact. Frames returns them.
+*/
+func WithFrameHistory(size int) Option {
+	return func(socket *Socket) {
+		if size <= 0 {
+			socket.frames = nil
+			return
+		}
+		socket.frames = &frameHistory{
+			frames: make([]*Frame, size),
+			mux:    &sync.Mutex{},
+		}
+	}
+}
+
+/*
+Frames returns the frames kept by WithFrameHistory, oldest first. If frame
+history isn't enabled Frames returns nil.
+*/
+func (socket *Socket) Frames() []*Frame {
+	return socket.frames.list()
+}
+
+/*
+frameHistory is a ring buffer of frames. A nil frameHistory keeps nothing.
+*/
+type frameHistory struct {
+	frames []*Frame
+	full   bool
+	mux    *sync.Mutex
+	next   int
+}
+
+/*
+record adds a frame, replacing the oldest frame if the history is full.
+*/
+func (history *frameHistory) record(sent bool, data []byte) {
+	if nil == history {
+		return
+	}
+	frame := &Frame{Time: time.Now(), Sent: sent, Data: data}
+
+	history.mux.Lock()
+	history.frames[history.next] = frame
+	history.next++
+	if history.next == len(history.frames) {
+		history.next = 0
+		history.full = true
+	}
+	history.mux.Unlock()
+}
+
+/*
+list returns the frames, oldest first.
+*/
+func (history *frameHistory) list() []*Frame {
+	if nil == history {
+		return nil
+	}
+	history.mux.Lock()
+	defer history.mux.Unlock()
+	if !history.full {
+		return append([]*Frame{}, history.frames[:history.next]...)
+	}
+	frames := make([]*Frame, 0, len(history.frames))
+	frames = append(frames, history.frames[history.next:]...)
+	return append(frames, history.frames[:history.next]...)
+}
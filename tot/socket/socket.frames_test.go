@@ -0,0 +1,46 @@
+package socket
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestFrameHistory(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithFrameHistory(3))
+	defer socket.Stop()
+
+	<-socket.SendCommand(NewCommand(socket, "Some.first", nil))
+	<-socket.SendCommand(NewCommand(socket, "Some.second", nil))
+
+	frames := socket.Frames()
+	if 3 != len(frames) {
+		t.Fatalf("Expected 3 frames, got %d", len(frames))
+	}
+	if frames[0].Sent || !frames[1].Sent || frames[2].Sent {
+		t.Errorf("Expected received, sent and received frames, got %v %v %v", frames[0].Sent, frames[1].Sent, frames[2].Sent)
+	}
+	if !strings.Contains(string(frames[1].Data), "Some.second") {
+		t.Errorf("Expected the second command, got %s", frames[1].Data)
+	}
+	for a := 1; a < len(frames); a++ {
+		if frames[a].Time.Before(frames[a-1].Time) {
+			t.Errorf("Expected the frames oldest first")
+		}
+	}
+}
+
+func TestFrameHistoryDisabled(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	<-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	if frames := socket.Frames(); nil != frames {
+		t.Errorf("Expected no frames, got %d", len(frames))
+	}
+}
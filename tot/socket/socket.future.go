@@ -0,0 +1,133 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+)
+
+/*
+Future is the pending response to a command sent with SendCommandAsync. Done is
+closed when the response arrives. Response, Result, Err and Decode wait for it.
+*/
+type Future struct {
+	command  Commander
+	done     chan struct{}
+	response *Response
+}
+
+/*
+SendCommandAsync sends a command and returns its Future without waiting for the
+response, so many commands can be sent at once and awaited together:
+
+	futures := make([]*socket.Future, 0, len(frames))
+	for _, frame := range frames {
+		futures = append(futures, socket.SendCommandAsync(sock, socket.NewCommand(
+			sock,
+			"Runtime.evaluate",
+			&runtime.EvaluateParams{Expression: "document.title", ContextID: frame.ContextID},
+		)))
+	}
+	if err := socket.Await(futures...); nil != err {
+		...
+	}
+	for _, future := range futures {
+		result := &runtime.EvaluateResult{}
+		future.Decode(result)
+		...
+	}
+
+The socket may be a Socket, a Session or any other Socketer.
+*/
+func SendCommandAsync(socketer Socketer, command Commander) *Future {
+	return newFuture(command, socketer.SendCommand(command))
+}
+
+/*
+SendCommandAsyncContext sends a command like SendCommandAsync, but stops waiting
+for the response when the context is done, see Socket.SendCommandContext.
+*/
+func SendCommandAsyncContext(ctx context.Context, socketer Socketer, command Commander) *Future {
+	return newFuture(command, socketer.SendCommandContext(ctx, command))
+}
+
+/*
+Await waits for every future and returns the first error in argument order, or
+nil if every command succeeded.
+*/
+func Await(futures ...*Future) error {
+	var err error
+	for _, future := range futures {
+		if e := future.Err(); nil != e && nil == err {
+			err = e
+		}
+	}
+	return err
+}
+
+/*
+newFuture returns a future resolved by the first response on the channel.
+*/
+func newFuture(command Commander, responses chan *Response) *Future {
+	future := &Future{
+		command: command,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		future.response = <-responses
+		close(future.done)
+	}()
+	return future
+}
+
+/*
+Command returns the command the future is the response to.
+*/
+func (future *Future) Command() Commander {
+	return future.command
+}
+
+/*
+Decode waits for the response and decodes its result into v. Streamed results
+are decoded from the stream. The command error is returned if it failed.
+*/
+func (future *Future) Decode(v interface{}) error {
+	response := future.Response()
+	if err := response.Err(); nil != err {
+		return err
+	}
+	if stream := response.Stream(); nil != stream {
+		defer stream.Close()
+		return json.NewDecoder(stream).Decode(v)
+	}
+	return json.Unmarshal(response.Result, v)
+}
+
+/*
+Done returns a channel that's closed when the response arrives.
+*/
+func (future *Future) Done() <-chan struct{} {
+	return future.done
+}
+
+/*
+Err waits for the response and returns its error.
+*/
+func (future *Future) Err() error {
+	return future.Response().Err()
+}
+
+/*
+Response waits for the response and returns it.
+*/
+func (future *Future) Response() *Response {
+	<-future.done
+	return future.response
+}
+
+/*
+Result waits for the response and returns its raw result, which is empty if the
+command failed or the result was streamed.
+*/
+func (future *Future) Result() json.RawMessage {
+	return future.Response().Result
+}
@@ -0,0 +1,87 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSendCommandAsync(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		if "fail" == evaluate.Expression {
+			return nil, &cdptest.Error{Code: -32000, Message: "evaluation failed"}
+		}
+		return map[string]interface{}{"result": map[string]string{"type": "string", "value": evaluate.Expression}}, nil
+	})
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	futures := make([]*Future, 0)
+	for a := 0; a < 10; a++ {
+		futures = append(futures, SendCommandAsync(socket, NewCommand(
+			socket,
+			"Runtime.evaluate",
+			&runtime.EvaluateParams{Expression: fmt.Sprintf("frame-%d", a)},
+		)))
+	}
+	if err := Await(futures...); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	for a, future := range futures {
+		select {
+		case <-future.Done():
+		default:
+			t.Errorf("Expected future %d to be done", a)
+		}
+		result := &runtime.EvaluateResult{}
+		if err := future.Decode(result); nil != err {
+			t.Fatalf("Expected nil, got error: %v", err)
+		}
+		if fmt.Sprintf("frame-%d", a) != result.Result.Value {
+			t.Errorf("Expected frame-%d, got %v", a, result.Result.Value)
+		}
+		if 0 == len(future.Result()) || "Runtime.evaluate" != future.Command().Method() {
+			t.Errorf("Expected the raw result of the command, got %s", future.Result())
+		}
+	}
+
+	failed := SendCommandAsync(socket, NewCommand(socket, "Runtime.evaluate", &runtime.EvaluateParams{Expression: "fail"}))
+	if err := Await(futures[0], failed); nil == err {
+		t.Errorf("Expected the failed command's error")
+	}
+	if err := failed.Decode(&runtime.EvaluateResult{}); nil == err {
+		t.Errorf("Expected Decode to return the command error")
+	}
+}
+
+func TestSendCommandAsyncContext(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		time.Sleep(time.Second)
+		return map[string]interface{}{}, nil
+	})
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	future := SendCommandAsyncContext(ctx, socket, NewCommand(socket, "Runtime.evaluate", &runtime.EvaluateParams{Expression: "slow"}))
+	select {
+	case <-future.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the future to be resolved when the context is done")
+	}
+	if nil == future.Err() {
+		t.Errorf("Expected a timeout error")
+	}
+}
@@ -29,22 +29,42 @@ func (err Error) Error() string {
 }
 
 /*
-Response represents a socket message.
+Response represents a socket message. Messages from a flattened target session
+carry the session ID.
 */
 type Response struct {
-	Error  *Error          `json:"error"`
-	ID     int             `json:"id"`
-	Method string          `json:"method"`
-	Params json.RawMessage `json:"params"`
-	Result json.RawMessage `json:"result"`
+	Error     *Error          `json:"error"`
+	ID        int             `json:"id"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params"`
+	Result    json.RawMessage `json:"result"`
+	SessionID string          `json:"sessionId,omitempty"`
+
+	stream  *spooledValue
+	timeout *TimeoutError
+}
+
+/*
+Err returns the response error. Commands that timed out return a
+*TimeoutError.
+*/
+func (response *Response) Err() error {
+	if nil != response.timeout {
+		return response.timeout
+	}
+	if nil == response.Error || 0 == response.Error.Code {
+		return nil
+	}
+	return response.Error
 }
 
 /*
 Payload represents a WebSocket JSON payload for a sending a command to the
-websocket.
+websocket. Commands for a flattened target session carry the session ID.
 */
 type Payload struct {
-	ID     int         `json:"id"`
-	Method string      `json:"method"`
-	Params interface{} `json:"params"`
+	ID        int         `json:"id"`
+	Method    string      `json:"method"`
+	Params    interface{} `json:"params"`
+	SessionID string      `json:"sessionId,omitempty"`
 }
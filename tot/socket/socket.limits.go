@@ -0,0 +1,142 @@
+package socket
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	errs "github.com/bdlm/errors"
+	"github.com/gorilla/websocket"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+defaultWriteLimit is the largest command payload written by default. It's the
+largest payload older Chrome versions accept.
+*/
+const defaultWriteLimit = 1 * 1024 * 1024
+
+/*
+WithReadLimit sets the maximum size of an inbound message, in bytes. A larger
+message fails the read with a SocketMessageTooLarge error and the connection is
+closed, so the limit protects the process from a runaway message rather than
+shaping normal traffic; use WithMaxMessageSize to stream large messages. Zero,
+the default, accepts messages of any size.
+*/
+func WithReadLimit(size int64) Option {
+	return func(socket *Socket) {
+		socket.readLimit = size
+	}
+}
+
+/*
+WithWriteLimit sets the maximum size of a command payload, in bytes. Chrome
+doesn't reassemble fragmented websocket frames, so the write buffer of the
+connection is sized to the limit and every payload is written as a single
+frame. A larger payload isn't sent and the command is responded to with an
+error. Defaults to 1MB, recent Chrome versions accept payloads of up to 100MB.
+*/
+func WithWriteLimit(size int) Option {
+	return func(socket *Socket) {
+		socket.websocketDialer().WriteBufferSize = size
+	}
+}
+
+/*
+WithBufferSizes sets the sizes of the read and write buffers of the websocket
+connection, in bytes. Zero keeps a default: 4KB for reads and 1MB for writes.
+A larger read buffer reduces reallocation while reading streams of large
+messages, such as heap snapshot chunks, and smaller buffers reduce the memory
+used by each connection:
+
+	sock := socket.New(socketURL, socket.WithBufferSizes(1024*1024, 0))
+
+The write buffer holds a whole payload, so its size is also the write limit,
+see WithWriteLimit.
+*/
+func WithBufferSizes(read, write int) Option {
+	return func(socket *Socket) {
+		dialer := socket.websocketDialer()
+		if read > 0 {
+			dialer.ReadBufferSize = read
+		}
+		if write > 0 {
+			dialer.WriteBufferSize = write
+		}
+	}
+}
+
+/*
+WithLargeEvents delivers the specified events when they exceed the maximum
+message size set with WithMaxMessageSize instead of dropping them, for example
+HeapProfiler.addHeapSnapshotChunk while taking a heap snapshot. The message is
+scanned and spooled like other oversized messages, then its params are read
+back into memory for the event handlers.
+*/
+func WithLargeEvents(methods ...string) Option {
+	return func(socket *Socket) {
+		if nil == socket.largeEvents {
+			socket.largeEvents = make(map[string]bool)
+		}
+		for _, method := range methods {
+			socket.largeEvents[method] = true
+		}
+	}
+}
+
+/*
+readLimiter is implemented by websocket connections that can limit the size of
+inbound messages.
+*/
+type readLimiter interface {
+	SetReadLimit(limit int64)
+}
+
+/*
+SetReadLimit sets the maximum size of an inbound message.
+*/
+func (socket *ChromeWebSocket) SetReadLimit(limit int64) {
+	if nil != socket.conn {
+		socket.conn.SetReadLimit(limit)
+	}
+}
+
+/*
+limitReads applies the socket's read limit to a new connection.
+*/
+func (socket *Socket) limitReads(conn WebSocketer) {
+	if socket.readLimit <= 0 {
+		return
+	}
+	if limiter, ok := conn.(readLimiter); ok {
+		limiter.SetReadLimit(socket.readLimit)
+	}
+}
+
+/*
+readError wraps an error reading a message, reporting a message that exceeded
+the read limit as a SocketMessageTooLarge error.
+*/
+func (socket *Socket) readError(err error, msg string) error {
+	socket.metrics.websocketError(err)
+	if websocket.ErrReadLimit == err {
+		return errs.Wrap(err, codes.SocketMessageTooLarge, fmt.Sprintf("%s: a message exceeded the read limit of %d bytes", msg, socket.readLimit))
+	}
+	return errs.Wrap(err, codes.SocketReadFailed, msg)
+}
+
+/*
+handleLargeEvent reads the spooled params of an oversized event back into
+memory and delivers the event.
+*/
+func (socket *Socket) handleLargeEvent(response *Response) {
+	params, err := ioutil.ReadAll(response.stream)
+	response.stream.Close()
+	response.stream = nil
+	if nil != err {
+		socket.logger.Warn("could not read an oversized event", logger.Fields{"error": err, "event": response.Method, "socketID": socket.socketID})
+		return
+	}
+	response.Params = params
+	socket.handleEvent(response)
+}
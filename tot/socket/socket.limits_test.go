@@ -0,0 +1,104 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/gorilla/websocket"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestWriteLimit(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithWriteLimit(1024))
+	defer socket.Stop()
+	if 1024 != socket.dialer.WriteBufferSize {
+		t.Errorf("Expected the write buffer to be sized to the limit, got %d", socket.dialer.WriteBufferSize)
+	}
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	response := <-socket.SendCommand(NewCommand(socket, "Some.method", map[string]string{"data": strings.Repeat("x", 512)}))
+	if nil != response.Error {
+		t.Errorf("Expected nil, got error: %v", response.Error)
+	}
+	response = <-socket.SendCommand(NewCommand(socket, "Some.method", map[string]string{"data": strings.Repeat("x", 2048)}))
+	if nil == response.Error {
+		t.Errorf("Expected a write error")
+	}
+	if 1 != len(server.Commands()) {
+		t.Errorf("Expected the large payload not to be sent, got %d commands", len(server.Commands()))
+	}
+}
+
+func TestBufferSizes(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithBufferSizes(64*1024, 0))
+	defer socket.Stop()
+	if 64*1024 != socket.dialer.ReadBufferSize || defaultWriteLimit != socket.dialer.WriteBufferSize {
+		t.Errorf("Expected a 64KB read buffer and the default write buffer, got %d and %d", socket.dialer.ReadBufferSize, socket.dialer.WriteBufferSize)
+	}
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)); nil != response.Error {
+		t.Errorf("Expected nil, got error: %v", response.Error)
+	}
+
+	socket = New(server.URL(), WithBufferSizes(0, 2048))
+	defer socket.Stop()
+	if 0 != socket.dialer.ReadBufferSize || 2048 != socket.dialer.WriteBufferSize {
+		t.Errorf("Expected the default read buffer and a 2KB write buffer, got %d and %d", socket.dialer.ReadBufferSize, socket.dialer.WriteBufferSize)
+	}
+}
+
+func TestReadError(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithReadLimit(1024))
+	defer socket.Stop()
+
+	err := socket.readError(websocket.ErrReadLimit, "socket read failed")
+	if e, ok := err.(errs.Err); !ok || codes.SocketMessageTooLarge != e.Code() {
+		t.Errorf("Expected a SocketMessageTooLarge error, got %v", err)
+	}
+	err = socket.readError(fmt.Errorf("closed"), "socket read failed")
+	if e, ok := err.(errs.Err); !ok || codes.SocketReadFailed != e.Code() {
+		t.Errorf("Expected a SocketReadFailed error, got %v", err)
+	}
+}
+
+func TestLargeEvents(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithMaxMessageSize(1024), WithLargeEvents("HeapProfiler.addHeapSnapshotChunk"))
+	defer socket.Stop()
+
+	chunks := make(chan string, 1)
+	socket.AddEventHandler(NewEventHandler("HeapProfiler.addHeapSnapshotChunk", func(response *Response) {
+		params := map[string]string{}
+		json.Unmarshal(response.Params, &params)
+		chunks <- params["chunk"]
+	}))
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	server.Emit("HeapProfiler.addHeapSnapshotChunk", map[string]string{"chunk": strings.Repeat("x", 4096)})
+	select {
+	case chunk := <-chunks:
+		if 4096 != len(chunk) {
+			t.Errorf("Expected the whole chunk, got %d bytes", len(chunk))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the oversized event to be delivered")
+	}
+}
@@ -0,0 +1,99 @@
+package socket
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+WithMetrics reports socket metrics to a collector. The metrics package provides
+a collector that exposes them to Prometheus:
+
+	collector := metrics.NewPrometheus("chrome")
+	http.Handle("/metrics", collector)
+	sock := socket.New(socketURL, socket.WithMetrics(collector))
+*/
+func WithMetrics(collector MetricsCollector) Option {
+	return func(socket *Socket) {
+		socket.metrics = &socketMetrics{
+			collector: collector,
+			mux:       &sync.Mutex{},
+			started:   make(map[int]time.Time),
+		}
+	}
+}
+
+/*
+socketMetrics tracks the commands in flight for a collector. A nil
+socketMetrics collects nothing.
+*/
+type socketMetrics struct {
+	collector MetricsCollector
+	mux       *sync.Mutex
+	started   map[int]time.Time
+}
+
+/*
+sent records a command being sent.
+*/
+func (metrics *socketMetrics) sent(command Commander) {
+	if nil == metrics {
+		return
+	}
+	metrics.mux.Lock()
+	metrics.started[command.ID()] = time.Now()
+	metrics.mux.Unlock()
+	metrics.collector.CommandSent(command.Method())
+}
+
+/*
+completed records the response to a command. Commands that weren't recorded as
+sent, or were already completed, are ignored.
+*/
+func (metrics *socketMetrics) completed(command Commander, response *Response) {
+	if nil == metrics {
+		return
+	}
+	metrics.mux.Lock()
+	started, ok := metrics.started[command.ID()]
+	delete(metrics.started, command.ID())
+	metrics.mux.Unlock()
+	if !ok {
+		return
+	}
+	var err error
+	if nil != response {
+		err = response.Err()
+	}
+	metrics.collector.CommandCompleted(command.Method(), time.Since(started), err)
+}
+
+/*
+event records a received event.
+*/
+func (metrics *socketMetrics) event(method string) {
+	if nil == metrics {
+		return
+	}
+	metrics.collector.EventReceived(method)
+}
+
+/*
+reconnected records a reconnect.
+*/
+func (metrics *socketMetrics) reconnected() {
+	if nil == metrics {
+		return
+	}
+	metrics.collector.Reconnected()
+}
+
+/*
+websocketError records a websocket read or write error.
+*/
+func (metrics *socketMetrics) websocketError(err error) {
+	if nil == metrics {
+		return
+	}
+	metrics.collector.WebsocketError(err)
+}
@@ -0,0 +1,142 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+type testCollector struct {
+	completed       map[string]int
+	errors          map[string]int
+	events          []string
+	mux             *sync.Mutex
+	reconnects      int
+	sent            map[string]int
+	websocketErrors int
+}
+
+func newTestCollector() *testCollector {
+	return &testCollector{
+		completed: make(map[string]int),
+		errors:    make(map[string]int),
+		events:    make([]string, 0),
+		mux:       &sync.Mutex{},
+		sent:      make(map[string]int),
+	}
+}
+
+func (collector *testCollector) CommandSent(method string) {
+	collector.mux.Lock()
+	collector.sent[method]++
+	collector.mux.Unlock()
+}
+
+func (collector *testCollector) CommandCompleted(method string, duration time.Duration, err error) {
+	collector.mux.Lock()
+	collector.completed[method]++
+	if nil != err {
+		collector.errors[method]++
+	}
+	collector.mux.Unlock()
+}
+
+func (collector *testCollector) EventReceived(method string) {
+	collector.mux.Lock()
+	collector.events = append(collector.events, method)
+	collector.mux.Unlock()
+}
+
+func (collector *testCollector) Reconnected() {
+	collector.mux.Lock()
+	collector.reconnects++
+	collector.mux.Unlock()
+}
+
+func (collector *testCollector) WebsocketError(err error) {
+	collector.mux.Lock()
+	collector.websocketErrors++
+	collector.mux.Unlock()
+}
+
+func TestMetrics(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Some.failure", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: -32000, Message: "failed"}
+	})
+	server.Handle("Some.slow", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		time.Sleep(200 * time.Millisecond)
+		return nil, nil
+	})
+
+	collector := newTestCollector()
+	reconnected := make(chan bool, 1)
+	socket := New(server.URL(),
+		WithMetrics(collector),
+		WithReconnect(&ReconnectPolicy{
+			InitialBackoff: 50 * time.Millisecond,
+			OnReconnect: func(attempts int) {
+				reconnected <- true
+			},
+		}),
+	)
+	defer socket.Stop()
+
+	<-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	<-socket.SendCommand(NewCommand(socket, "Some.failure", nil))
+	<-socket.SendCommand(NewCommand(socket, "Some.slow", nil, WithTimeout(10*time.Millisecond)))
+
+	events := make(chan bool, 1)
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {
+		events <- true
+	}))
+	server.Emit("Page.loadEventFired", map[string]interface{}{"timestamp": 1})
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the event to be handled")
+	}
+
+	collector.mux.Lock()
+	if 1 != collector.sent["Some.method"] || 1 != collector.completed["Some.method"] || 0 != collector.errors["Some.method"] {
+		t.Errorf("Expected a successful command, got %v %v", collector.completed, collector.errors)
+	}
+	if 1 != collector.errors["Some.failure"] {
+		t.Errorf("Expected a failed command, got %v", collector.errors)
+	}
+	if 1 != collector.completed["Some.slow"] || 1 != collector.errors["Some.slow"] {
+		t.Errorf("Expected a timed out command, got %v %v", collector.completed, collector.errors)
+	}
+	if 1 != len(collector.events) || "Page.loadEventFired" != collector.events[0] {
+		t.Errorf("Expected the event to be counted, got %v", collector.events)
+	}
+	collector.mux.Unlock()
+
+	// The late response to the timed out command is not counted again.
+	time.Sleep(300 * time.Millisecond)
+
+	server.SetChaos(cdptest.Chaos{DisconnectAfter: 1})
+	<-socket.SendCommand(NewCommand(socket, "Some.lost", nil))
+	server.SetChaos(cdptest.Chaos{})
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the socket to reconnect")
+	}
+
+	collector.mux.Lock()
+	defer collector.mux.Unlock()
+	if 1 != collector.completed["Some.slow"] {
+		t.Errorf("Expected the late response to be ignored, got %d", collector.completed["Some.slow"])
+	}
+	if 1 != collector.errors["Some.lost"] {
+		t.Errorf("Expected the lost command to fail, got %v", collector.errors)
+	}
+	if 1 != collector.reconnects || collector.websocketErrors < 1 {
+		t.Errorf("Expected a reconnect and a websocket error, got %d and %d", collector.reconnects, collector.websocketErrors)
+	}
+}
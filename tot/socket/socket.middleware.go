@@ -0,0 +1,125 @@
+package socket
+
+import (
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+Middleware sees the traffic of a socket, for logging, metrics, redaction of
+secrets or chaos testing. Command is called with every outbound command payload
+before it's written to the websocket and Message is called with every inbound
+command response and event before it's handled. Either function may be nil.
+
+Middleware may modify the payload or message. Returning an error vetoes it: a
+vetoed command is not sent and is responded to with a SocketCommandVetoed
+error, a vetoed message is dropped. A command whose response is dropped waits
+for its timeout, if one is set.
+*/
+type Middleware struct {
+	Command func(payload *Payload) error
+	Message func(response *Response) error
+}
+
+/*
+WithMiddleware adds middleware to the socket. Middleware is called in the order
+it was added and the first error stops the chain:
+
+	sock := socket.New(socketURL, socket.WithMiddleware(&socket.Middleware{
+		Command: func(payload *socket.Payload) error {
+			log.WithFields(log.Fields{"method": payload.Method}).Debug("sending command")
+			return nil
+		},
+	}))
+*/
+func WithMiddleware(middleware *Middleware) Option {
+	return func(socket *Socket) {
+		if nil == socket.middleware {
+			socket.middleware = &middlewareChain{mux: &sync.RWMutex{}}
+		}
+		socket.middleware.add(middleware)
+	}
+}
+
+/*
+middlewareChain is the ordered middleware of a socket. A nil chain passes all
+traffic through.
+*/
+type middlewareChain struct {
+	mux   *sync.RWMutex
+	stack []*Middleware
+}
+
+func (chain *middlewareChain) add(middleware *Middleware) {
+	chain.mux.Lock()
+	chain.stack = append(chain.stack, middleware)
+	chain.mux.Unlock()
+}
+
+/*
+command passes an outbound payload through the chain.
+*/
+func (chain *middlewareChain) command(payload *Payload) error {
+	if nil == chain {
+		return nil
+	}
+	chain.mux.RLock()
+	defer chain.mux.RUnlock()
+	for _, middleware := range chain.stack {
+		if nil == middleware.Command {
+			continue
+		}
+		if err := middleware.Command(payload); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+message passes an inbound message through the chain.
+*/
+func (chain *middlewareChain) message(response *Response) error {
+	if nil == chain {
+		return nil
+	}
+	chain.mux.RLock()
+	defer chain.mux.RUnlock()
+	for _, middleware := range chain.stack {
+		if nil == middleware.Message {
+			continue
+		}
+		if err := middleware.Message(response); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+commandMiddleware passes an outbound payload through the middleware of the
+socket. A panicking middleware vetoes the command.
+*/
+func (socket *Socket) commandMiddleware(payload *Payload) (err error) {
+	if socket.guard("command middleware", payload.Method, func() {
+		err = socket.middleware.command(payload)
+	}) {
+		err = errs.New(codes.SocketEventHandlerPanic, "command middleware panicked")
+	}
+	return err
+}
+
+/*
+messageMiddleware passes an inbound message through the middleware of the
+socket. A panicking middleware vetoes the message.
+*/
+func (socket *Socket) messageMiddleware(response *Response) (err error) {
+	if socket.guard("message middleware", response.Method, func() {
+		err = socket.middleware.message(response)
+	}) {
+		err = errs.New(codes.SocketEventHandlerPanic, "message middleware panicked")
+	}
+	return err
+}
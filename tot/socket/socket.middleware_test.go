@@ -0,0 +1,100 @@
+package socket
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	mux := &sync.Mutex{}
+	seen := make([]string, 0)
+	socket := New(server.URL(),
+		WithMiddleware(&Middleware{
+			Command: func(payload *Payload) error {
+				mux.Lock()
+				seen = append(seen, payload.Method)
+				mux.Unlock()
+				if "Browser.close" == payload.Method {
+					return errors.New("not allowed")
+				}
+				if params, ok := payload.Params.(*network.SetExtraHTTPHeadersParams); ok {
+					params.Headers["Authorization"] = "[redacted]"
+				}
+				return nil
+			},
+		}),
+		WithMiddleware(&Middleware{
+			Message: func(response *Response) error {
+				if "Page.frameNavigated" == response.Method {
+					return errors.New("dropped")
+				}
+				if "Page.loadEventFired" == response.Method {
+					response.Params = []byte(`{"timestamp": 42}`)
+				}
+				return nil
+			},
+		}),
+	)
+	defer socket.Stop()
+
+	result := <-socket.Network().SetExtraHTTPHeaders(&network.SetExtraHTTPHeadersParams{
+		Headers: network.Headers{"Authorization": "secret"},
+	})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	commands := server.Commands()
+	params := &network.SetExtraHTTPHeadersParams{}
+	json.Unmarshal(commands[0].Params, params)
+	if "[redacted]" != params.Headers["Authorization"] {
+		t.Errorf("Expected the header to be redacted, got %v", params.Headers)
+	}
+
+	closeResult := <-socket.Browser().Close()
+	if err, ok := closeResult.Err.(*Error); !ok || int(codes.SocketCommandVetoed) != err.Code {
+		t.Errorf("Expected a vetoed command, got %v", closeResult.Err)
+	}
+	if 1 != len(server.Commands()) {
+		t.Errorf("Expected the vetoed command not to be sent, got %d commands", len(server.Commands()))
+	}
+	mux.Lock()
+	if 2 != len(seen) || "Browser.close" != seen[1] {
+		t.Errorf("Expected the middleware to see both commands, got %v", seen)
+	}
+	mux.Unlock()
+
+	navigated := make(chan bool, 1)
+	socket.Page().OnFrameNavigated(func(event *page.FrameNavigatedEvent) {
+		navigated <- true
+	})
+	loaded := make(chan page.MonotonicTime, 1)
+	socket.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		loaded <- event.Timestamp
+	})
+	server.Emit("Page.frameNavigated", map[string]interface{}{"frame": map[string]string{"id": "main"}})
+	server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+	select {
+	case timestamp := <-loaded:
+		if 42 != timestamp {
+			t.Errorf("Expected the modified event, got %v", timestamp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the load event")
+	}
+	select {
+	case <-navigated:
+		t.Errorf("Expected the vetoed event to be dropped")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,237 @@
+package socket
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+deadlockWarning is how long the read loop waits for a response to be received
+before reporting a possible deadlock.
+*/
+var deadlockWarning = 10 * time.Second
+
+/*
+WithNonBlockingCommands makes sending commands from event handlers safe. By
+default the read loop hands each response to its command and waits until it's
+received, so a handler that sends a command and then waits for an event before
+reading the response stops the socket: the event is never read. With the option
+the read loop never waits for a response to be received, and commands are
+queued and written in the order they were sent by a separate writer goroutine.
+
+Callbacks that run on the read loop itself, such as middleware, taps and the
+detach hook, must not send commands in either mode because the read loop can't
+read the response while it waits for the callback. Commands sent while such a
+callback runs are written once it returns, and a command that isn't written
+within 10 seconds is responded to with a SocketDeadlock error; start a
+goroutine to send them instead. Without the option a response that isn't
+received within 10 seconds is reported to the logger and the error hook as a
+possible deadlock.
+*/
+func WithNonBlockingCommands() Option {
+	return func(socket *Socket) {
+		socket.writer = &commandWriter{
+			mux:    &sync.Mutex{},
+			socket: socket,
+		}
+	}
+}
+
+/*
+commandWriter writes queued commands to the websocket connection in order.
+*/
+type commandWriter struct {
+	mux     *sync.Mutex
+	queue   []queuedCommand
+	running bool
+	socket  *Socket
+}
+
+/*
+queuedCommand is a command waiting to be written.
+*/
+type queuedCommand struct {
+	command   Commander
+	sessionID target.SessionID
+}
+
+/*
+enqueue adds commands to the queue and starts the writer goroutine if it isn't
+running.
+*/
+func (writer *commandWriter) enqueue(sessionID target.SessionID, commands ...Commander) {
+	writer.mux.Lock()
+	defer writer.mux.Unlock()
+	for _, command := range commands {
+		writer.queue = append(writer.queue, queuedCommand{command: command, sessionID: sessionID})
+	}
+	if !writer.running {
+		writer.running = true
+		go writer.run()
+	}
+}
+
+/*
+run writes the queued commands until the queue is empty.
+*/
+func (writer *commandWriter) run() {
+	for {
+		writer.mux.Lock()
+		if 0 == len(writer.queue) {
+			writer.running = false
+			writer.mux.Unlock()
+			return
+		}
+		queued := writer.queue[0]
+		writer.queue = writer.queue[1:]
+		writer.mux.Unlock()
+		writer.socket.writeCommand(queued.command, queued.sessionID)
+	}
+}
+
+/*
+writeCommands writes stored commands to the websocket connection in order,
+without waiting for the writes.
+*/
+func (socket *Socket) writeCommands(sessionID target.SessionID, commands ...Commander) {
+	if nil != socket.writer {
+		socket.writer.enqueue(sessionID, commands...)
+		return
+	}
+	go func() {
+		for _, command := range commands {
+			socket.writeCommand(command, sessionID)
+		}
+	}()
+}
+
+/*
+respond hands a response to its command. Without non-blocking commands it
+waits until the response is received and reports a possible deadlock if that
+takes too long.
+*/
+func (socket *Socket) respond(command Commander, response *Response) {
+	if nil != socket.writer {
+		go command.Respond(response)
+		return
+	}
+
+	warning := deadlockWarning
+	timer := time.AfterFunc(warning, func() {
+		err := errs.New(codes.SocketDeadlock, fmt.Sprintf("the read loop has waited %s for the response to command #%d '%s' to be received, an event handler may be waiting for an event before reading it", warning, command.ID(), command.Method()))
+		socket.logger.Warn(err.Error(), logger.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID})
+		if nil != socket.errorHook {
+			socket.errorHook(err)
+		}
+	})
+	command.Respond(response)
+	timer.Stop()
+}
+
+/*
+readLoopCallbacks holds the commands sent while a callback runs on the read
+loop, such as middleware, a tap or the detach hook. The read loop can't read
+their responses until the callback returns, so they're written afterwards.
+*/
+type readLoopCallbacks struct {
+	held    []queuedCommand
+	mux     *sync.Mutex
+	running int
+}
+
+func newReadLoopCallbacks() *readLoopCallbacks {
+	return &readLoopCallbacks{mux: &sync.Mutex{}}
+}
+
+/*
+readLoopCallback runs a callback on the read loop and writes the commands sent
+while it ran.
+*/
+func (socket *Socket) readLoopCallback(fn func()) {
+	callbacks := socket.callbacks
+	if nil == callbacks {
+		fn()
+		return
+	}
+	callbacks.mux.Lock()
+	callbacks.running++
+	callbacks.mux.Unlock()
+	defer func() {
+		callbacks.mux.Lock()
+		callbacks.running--
+		var held []queuedCommand
+		if 0 == callbacks.running {
+			held = callbacks.held
+			callbacks.held = nil
+		}
+		callbacks.mux.Unlock()
+		for _, queued := range held {
+			socket.writeCommands(queued.sessionID, queued.command)
+		}
+	}()
+	fn()
+}
+
+/*
+holdCommand holds a command until the running read loop callbacks return, and
+returns false if none are running. A command that is still held after the
+deadlock warning delay was most likely sent by a callback waiting for its
+response, it's dropped and responded to with a SocketDeadlock error.
+*/
+func (socket *Socket) holdCommand(command Commander, sessionID target.SessionID) bool {
+	callbacks := socket.callbacks
+	if nil == callbacks {
+		return false
+	}
+	callbacks.mux.Lock()
+	defer callbacks.mux.Unlock()
+	if 0 == callbacks.running {
+		return false
+	}
+	callbacks.held = append(callbacks.held, queuedCommand{command: command, sessionID: sessionID})
+	time.AfterFunc(deadlockWarning, func() {
+		callbacks.mux.Lock()
+		held := false
+		for a, queued := range callbacks.held {
+			if command == queued.command {
+				callbacks.held = append(callbacks.held[:a], callbacks.held[a+1:]...)
+				held = true
+				break
+			}
+		}
+		callbacks.mux.Unlock()
+		if !held {
+			return
+		}
+		if _, err := socket.commands.Pop(command.ID()); nil != err {
+			return
+		}
+		socket.logger.Warn("command sent from the read loop", logger.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID})
+		response := deadlockResponse(command)
+		socket.completed(command, response)
+		command.Respond(response)
+	})
+	return true
+}
+
+/*
+deadlockResponse returns the error response to a command sent from the read
+loop.
+*/
+func deadlockResponse(command Commander) *Response {
+	err := errs.New(codes.SocketDeadlock, fmt.Sprintf("command #%d '%s' was not sent: it was sent from the socket's read loop and its response could never be read", command.ID(), command.Method()))
+	return &Response{
+		Error: &Error{
+			Code:    int(codes.SocketDeadlock),
+			Data:    []byte(fmt.Sprintf("%q", err.Error())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+	}
+}
@@ -0,0 +1,159 @@
+package socket
+
+import (
+	"testing"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestNonBlockingCommands(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithNonBlockingCommands())
+	defer socket.Stop()
+
+	navigated := make(chan bool)
+	socket.AddEventHandler(NewEventHandler("Page.frameNavigated", func(response *Response) {
+		close(navigated)
+	}))
+	handled := make(chan *Response)
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {
+		// The response isn't read until a later event is handled, which
+		// stalls a blocking read loop.
+		responses := socket.SendCommand(NewCommand(socket, "Some.method", nil))
+		<-navigated
+		handled <- <-responses
+	}))
+
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+	for 0 == len(server.Commands()) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	server.Emit("Page.frameNavigated", map[string]string{})
+
+	select {
+	case response := <-handled:
+		if nil != response.Error {
+			t.Errorf("Expected nil, got error: %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the handler to receive its response")
+	}
+}
+
+func TestReadLoopCommand(t *testing.T) {
+	warning := deadlockWarning
+	deadlockWarning = 50 * time.Millisecond
+	defer func() { deadlockWarning = warning }()
+
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	var socket *Socket
+	responses := make(chan *Response, 1)
+	socket = New(server.URL(), WithMiddleware(&Middleware{
+		Message: func(response *Response) error {
+			if "Page.loadEventFired" == response.Method {
+				responses <- <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+			}
+			return nil
+		},
+	}))
+	defer socket.Stop()
+
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+
+	select {
+	case response := <-responses:
+		if nil == response.Error || int(codes.SocketDeadlock) != response.Error.Code {
+			t.Errorf("Expected a SocketDeadlock error, got %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the command to be responded to")
+	}
+	if 0 != len(server.Commands()) {
+		t.Errorf("Expected the command not to be sent")
+	}
+}
+
+func TestReadLoopCallbackHold(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Some.method", map[string]string{})
+	var socket *Socket
+	sent := make(chan chan *Response, 1)
+	release := make(chan bool)
+	socket = New(server.URL(), WithMiddleware(&Middleware{
+		Message: func(response *Response) error {
+			if "Page.loadEventFired" == response.Method {
+				// A command sent by another goroutine while the middleware
+				// runs is written once it returns.
+				go func() {
+					sent <- socket.SendCommand(NewCommand(socket, "Some.method", nil))
+				}()
+				<-release
+			}
+			return nil
+		},
+	}))
+	defer socket.Stop()
+
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+	responses := <-sent
+	time.Sleep(50 * time.Millisecond)
+	if 0 != len(server.Commands()) {
+		t.Errorf("Expected the command to be held while the middleware runs")
+	}
+	close(release)
+
+	select {
+	case response := <-responses:
+		if nil != response.Error {
+			t.Errorf("Expected nil, got error: %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the command to be responded to")
+	}
+}
+
+func TestDeadlockWarning(t *testing.T) {
+	warning := deadlockWarning
+	deadlockWarning = 50 * time.Millisecond
+	defer func() { deadlockWarning = warning }()
+
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	reported := make(chan error, 1)
+	socket := New(server.URL(), WithErrorHook(func(err error) {
+		reported <- err
+	}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	responses := socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	select {
+	case err := <-reported:
+		if e, ok := err.(errs.Err); !ok || codes.SocketDeadlock != e.Code() {
+			t.Errorf("Expected a SocketDeadlock error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a possible deadlock to be reported")
+	}
+	if response := <-responses; nil != response.Error {
+		t.Errorf("Expected nil, got error: %v", response.Error)
+	}
+}
@@ -0,0 +1,98 @@
+package socket
+
+import (
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+Option defines a functional option for configuring a Socket. Options are
+applied by New before the socket starts listening.
+*/
+type Option func(socket *Socket)
+
+/*
+WithEventPooling enables reuse of the event structs delivered to callbacks for
+high-frequency events (Network.*, LayerTree.* and Page.screencastFrame). When
+enabled, an event passed to a callback is only valid until the callback
+returns and must be copied if it is needed afterwards.
+*/
+func WithEventPooling() Option {
+	return func(socket *Socket) {
+		socket.eventPooling = true
+	}
+}
+
+/*
+WithErrorHook registers a function that is called with errors that occur
+outside of a command response, such as panics recovered from event handler
+callbacks. The hook is called from the goroutine the error occurred in.
+*/
+func WithErrorHook(hook func(err error)) Option {
+	return func(socket *Socket) {
+		socket.errorHook = hook
+	}
+}
+
+/*
+WithHandlerPanics disables panic isolation for event handlers and the other
+callbacks guarded by the socket. A panicking callback is still reported to the
+error and panic hooks but the panic is then re-raised, which is useful while
+debugging handlers.
+*/
+func WithHandlerPanics() Option {
+	return func(socket *Socket) {
+		socket.handlerPanics = true
+	}
+}
+
+/*
+WithCommandTimeout sets the maximum time to wait for a command response. When
+a command times out its response channel receives an error response with the
+SocketCommandTimeout code. A zero duration, the default, waits indefinitely.
+*/
+func WithCommandTimeout(timeout time.Duration) Option {
+	return func(socket *Socket) {
+		socket.commandTimeout = timeout
+	}
+}
+
+/*
+WithLateResponseHandler registers a function that receives responses that
+arrive after their command has timed out, along with how late they were. Late
+responses are otherwise logged and dropped. Comparing the delay against the
+timeout helps tell a slow browser from a lost message.
+*/
+func WithLateResponseHandler(handler func(command Commander, response *Response, late time.Duration)) Option {
+	return func(socket *Socket) {
+		socket.lateResponseHandler = handler
+	}
+}
+
+/*
+WithLogger sets the logger used by the socket and its sessions. Defaults to
+logger.Default(), which writes to the bdlm/log standard logger unless it's
+replaced with logger.SetDefault.
+*/
+func WithLogger(log logger.Logger) Option {
+	return func(socket *Socket) {
+		socket.logger = log
+	}
+}
+
+/*
+WithReconnect enables automatic reconnection when the websocket connection
+drops. See ReconnectPolicy for details. A nil policy uses the defaults.
+*/
+func WithReconnect(policy *ReconnectPolicy) Option {
+	return func(socket *Socket) {
+		if nil == policy {
+			policy = &ReconnectPolicy{}
+		}
+		if nil == socket.enabled {
+			socket.enabled = newEnabledDomains()
+		}
+		socket.reconnectPolicy = policy
+	}
+}
@@ -0,0 +1,39 @@
+package socket
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	mux := &sync.Mutex{}
+	messages := make(map[string]logger.Fields)
+	socket := New(server.URL(), WithLogger(logger.Func(func(level logger.Level, msg string, fields logger.Fields) {
+		mux.Lock()
+		messages[msg] = fields
+		mux.Unlock()
+	})))
+	defer socket.Stop()
+
+	<-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	handler := NewEventHandler("Some.event", func(response *Response) {})
+	socket.AddEventHandler(handler)
+	socket.RemoveEventHandler(handler)
+
+	mux.Lock()
+	defer mux.Unlock()
+	for _, msg := range []string{"New socket connection listening", "sending command payload to socket", "Adding event handler", "Removed event handler"} {
+		fields, ok := messages[msg]
+		if !ok {
+			t.Errorf("Expected '%s' to be logged, got %v", msg, messages)
+		} else if socket.socketID != fields["socketID"] {
+			t.Errorf("Expected the socket ID to be logged with '%s', got %v", msg, fields)
+		}
+	}
+}
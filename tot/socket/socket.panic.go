@@ -0,0 +1,100 @@
+package socket
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+HandlerPanic describes a panic recovered from a callback invoked by the socket.
+*/
+type HandlerPanic struct {
+	// The callback that panicked, for example "'Page.loadEventFired' event
+	// handler" or "message middleware".
+	Callback string
+
+	// The event or command method the callback was handling, if any.
+	Method string
+
+	// The value passed to panic.
+	Value interface{}
+
+	// The stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+/*
+Error implements the error interface.
+*/
+func (p *HandlerPanic) Error() string {
+	if "" == p.Method {
+		return fmt.Sprintf("recovered from panic in %s: %v", p.Callback, p.Value)
+	}
+	return fmt.Sprintf("recovered from panic in %s handling '%s': %v", p.Callback, p.Method, p.Value)
+}
+
+/*
+WithHandlerPanicHook registers a function that is called with the panics
+recovered from callbacks: event handlers, middleware, taps, the late response
+handler and the reconnect hook. A panicking callback doesn't stop the socket,
+the event or message it was handling is dropped and the socket keeps reading:
+
+	sock := socket.New(socketURL, socket.WithHandlerPanicHook(func(p *socket.HandlerPanic) {
+		log.WithFields(log.Fields{"stack": string(p.Stack)}).Error(p.Error())
+	}))
+
+The hook is called from the goroutine the panic occurred in, after the error
+hook.
+*/
+func WithHandlerPanicHook(hook func(p *HandlerPanic)) Option {
+	return func(socket *Socket) {
+		socket.panicHook = hook
+	}
+}
+
+/*
+guard calls a callback, recovering from any panic so that a single misbehaving
+callback can't take down the read loop or the process. It returns whether the
+callback panicked.
+*/
+func (socket *Socket) guard(callback, method string, fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		if nil == r {
+			return
+		}
+		panicked = true
+		socket.recovered(&HandlerPanic{
+			Callback: callback,
+			Method:   method,
+			Value:    r,
+			Stack:    debug.Stack(),
+		})
+		if socket.handlerPanics {
+			panic(r)
+		}
+	}()
+	fn()
+	return false
+}
+
+/*
+recovered logs a recovered panic and passes it to the error and panic hooks.
+*/
+func (socket *Socket) recovered(p *HandlerPanic) {
+	err := errs.New(codes.SocketEventHandlerPanic, p.Error())
+	if e, ok := p.Value.(error); ok {
+		err = errs.Wrap(e, codes.SocketEventHandlerPanic, fmt.Sprintf("recovered from panic in %s", p.Callback))
+	}
+	socket.logger.Error(err.Error(), logger.Fields{"callback": p.Callback, "error": err, "method": p.Method, "socketID": socket.socketID, "stack": string(p.Stack)})
+	if nil != socket.errorHook {
+		socket.errorHook(err)
+	}
+	if nil != socket.panicHook {
+		socket.panicHook(p)
+	}
+}
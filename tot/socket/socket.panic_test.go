@@ -0,0 +1,95 @@
+package socket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestHandlerPanicHook(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	panics := make(chan *HandlerPanic, 10)
+	socket := New(server.URL(),
+		WithHandlerPanicHook(func(p *HandlerPanic) {
+			panics <- p
+		}),
+		WithMiddleware(&Middleware{Message: func(response *Response) error {
+			if "Panic.event" == response.Method {
+				panic("middleware panic")
+			}
+			return nil
+		}}),
+	)
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	// A panicking tap and message middleware don't stop the read loop.
+	socket.Tap(func(direction Direction, raw []byte) {
+		if Inbound == direction {
+			panic("tap panic")
+		}
+	})
+	events := make(chan string, 1)
+	socket.AddEventHandler(NewEventHandler("Some.event", func(response *Response) {
+		panic("handler panic")
+	}))
+	socket.AddEventHandler(NewEventHandler("Other.event", func(response *Response) {
+		events <- response.Method
+	}))
+
+	server.Emit("Panic.event", map[string]interface{}{})
+	server.Emit("Some.event", map[string]interface{}{})
+	server.Emit("Other.event", map[string]interface{}{})
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the socket to keep reading after the panics")
+	}
+
+	callbacks := map[string]bool{}
+	for len(callbacks) < 3 {
+		select {
+		case p := <-panics:
+			callbacks[p.Callback] = true
+			if 0 == len(p.Stack) {
+				t.Errorf("Expected a stack trace for %s", p.Callback)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected panics from the tap, middleware and handler, got %v", callbacks)
+		}
+	}
+	for _, callback := range []string{"tap", "message middleware", "'Some.event' event handler"} {
+		if !callbacks[callback] {
+			t.Errorf("Expected a panic from the %s, got %v", callback, callbacks)
+		}
+	}
+
+	// The read loop still delivers command responses.
+	server.Handle("Some.method", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return map[string]interface{}{}, nil
+	})
+	select {
+	case response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)):
+		if nil != response.Error && 0 != response.Error.Code {
+			t.Errorf("Expected nil, got error: %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected a response after the panics")
+	}
+}
+
+func TestHandlerPanicError(t *testing.T) {
+	p := &HandlerPanic{Callback: "tap", Value: "boom"}
+	if "recovered from panic in tap: boom" != p.Error() {
+		t.Errorf("Unexpected error message '%s'", p.Error())
+	}
+	p.Method = "Page.loadEventFired"
+	if "recovered from panic in tap handling 'Page.loadEventFired': boom" != p.Error() {
+		t.Errorf("Unexpected error message '%s'", p.Error())
+	}
+}
@@ -1,12 +1,122 @@
 package socket
 
+/*
+Protocols provides the protocol interfaces for a Socketer. It implements
+Protocoller and can be embedded by Socketer implementations to provide the
+protocol API.
+*/
+type Protocols struct {
+	accessibility        *AccessibilityProtocol
+	animation            *AnimationProtocol
+	applicationCache     *ApplicationCacheProtocol
+	audits               *AuditsProtocol
+	browser              *BrowserProtocol
+	cacheStorage         *CacheStorageProtocol
+	cast                 *CastProtocol
+	console              *ConsoleProtocol
+	css                  *CSSProtocol
+	database             *DatabaseProtocol
+	debugger             *DebuggerProtocol
+	deviceAccess         *DeviceAccessProtocol
+	deviceOrientation    *DeviceOrientationProtocol
+	domDebugger          *DOMDebuggerProtocol
+	domSnapshot          *DOMSnapshotProtocol
+	domStorage           *DOMStorageProtocol
+	dom                  *DOMProtocol
+	emulation            *EmulationProtocol
+	fedCM                *FedCMProtocol
+	fetch                *FetchProtocol
+	headlessExperimental *HeadlessExperimentalProtocol
+	heapProfiler         *HeapProfilerProtocol
+	indexedDB            *IndexedDBProtocol
+	input                *InputProtocol
+	inspector            *InspectorProtocol
+	io                   *IOProtocol
+	layerTree            *LayerTreeProtocol
+	log                  *LogProtocol
+	memory               *MemoryProtocol
+	network              *NetworkProtocol
+	overlay              *OverlayProtocol
+	page                 *PageProtocol
+	performance          *PerformanceProtocol
+	performanceTimeline  *PerformanceTimelineProtocol
+	preload              *PreloadProtocol
+	profiler             *ProfilerProtocol
+	runtime              *RuntimeProtocol
+	schema               *SchemaProtocol
+	security             *SecurityProtocol
+	serviceWorker        *ServiceWorkerProtocol
+	storage              *StorageProtocol
+	systemInfo           *SystemInfoProtocol
+	target               *TargetProtocol
+	tethering            *TetheringProtocol
+	tracing              *TracingProtocol
+	webAuthn             *WebAuthnProtocol
+}
+
+/*
+NewProtocols returns the protocol interfaces for a Socketer. Commands sent
+through the protocol interfaces are sent with the Socketer's SendCommand
+method.
+*/
+func NewProtocols(socket Socketer) *Protocols {
+	return &Protocols{
+		accessibility:        &AccessibilityProtocol{Socket: socket},
+		animation:            &AnimationProtocol{Socket: socket},
+		applicationCache:     &ApplicationCacheProtocol{Socket: socket},
+		audits:               &AuditsProtocol{Socket: socket},
+		browser:              &BrowserProtocol{Socket: socket},
+		cacheStorage:         &CacheStorageProtocol{Socket: socket},
+		cast:                 &CastProtocol{Socket: socket},
+		console:              &ConsoleProtocol{Socket: socket},
+		css:                  &CSSProtocol{Socket: socket},
+		database:             &DatabaseProtocol{Socket: socket},
+		debugger:             &DebuggerProtocol{Socket: socket},
+		deviceAccess:         &DeviceAccessProtocol{Socket: socket},
+		deviceOrientation:    &DeviceOrientationProtocol{Socket: socket},
+		domDebugger:          &DOMDebuggerProtocol{Socket: socket},
+		domSnapshot:          &DOMSnapshotProtocol{Socket: socket},
+		domStorage:           &DOMStorageProtocol{Socket: socket},
+		dom:                  &DOMProtocol{Socket: socket},
+		emulation:            &EmulationProtocol{Socket: socket},
+		fedCM:                &FedCMProtocol{Socket: socket},
+		fetch:                &FetchProtocol{Socket: socket},
+		headlessExperimental: &HeadlessExperimentalProtocol{Socket: socket},
+		heapProfiler:         &HeapProfilerProtocol{Socket: socket},
+		indexedDB:            &IndexedDBProtocol{Socket: socket},
+		input:                &InputProtocol{Socket: socket},
+		inspector:            &InspectorProtocol{Socket: socket},
+		io:                   &IOProtocol{Socket: socket},
+		layerTree:            &LayerTreeProtocol{Socket: socket},
+		log:                  &LogProtocol{Socket: socket},
+		memory:               &MemoryProtocol{Socket: socket},
+		network:              &NetworkProtocol{Socket: socket},
+		overlay:              &OverlayProtocol{Socket: socket},
+		page:                 &PageProtocol{Socket: socket},
+		performance:          &PerformanceProtocol{Socket: socket},
+		performanceTimeline:  &PerformanceTimelineProtocol{Socket: socket},
+		preload:              &PreloadProtocol{Socket: socket},
+		profiler:             &ProfilerProtocol{Socket: socket},
+		runtime:              &RuntimeProtocol{Socket: socket},
+		schema:               &SchemaProtocol{Socket: socket},
+		security:             &SecurityProtocol{Socket: socket},
+		serviceWorker:        &ServiceWorkerProtocol{Socket: socket},
+		storage:              &StorageProtocol{Socket: socket},
+		systemInfo:           &SystemInfoProtocol{Socket: socket},
+		target:               &TargetProtocol{Socket: socket},
+		tethering:            &TetheringProtocol{Socket: socket},
+		tracing:              &TracingProtocol{Socket: socket},
+		webAuthn:             &WebAuthnProtocol{Socket: socket},
+	}
+}
+
 /*
 Accessibility returns the AccessibilityProtocol instance.
 
 Accessibility is a Protocoller implementation.
 */
-func (socket *Socket) Accessibility() *AccessibilityProtocol {
-	return socket.accessibility
+func (protocols *Protocols) Accessibility() *AccessibilityProtocol {
+	return protocols.accessibility
 }
 
 /*
@@ -14,8 +124,8 @@ Animation returns the AnimationProtocol instance.
 
 Animation is a Protocoller implementation.
 */
-func (socket *Socket) Animation() *AnimationProtocol {
-	return socket.animation
+func (protocols *Protocols) Animation() *AnimationProtocol {
+	return protocols.animation
 }
 
 /*
@@ -23,8 +133,8 @@ ApplicationCache returns the ApplicationCacheProtocol instance.
 
 ApplicationCache is a Protocoller implementation.
 */
-func (socket *Socket) ApplicationCache() *ApplicationCacheProtocol {
-	return socket.applicationCache
+func (protocols *Protocols) ApplicationCache() *ApplicationCacheProtocol {
+	return protocols.applicationCache
 }
 
 /*
@@ -32,8 +142,8 @@ Audits returns the AuditsProtocol instance.
 
 Audits is a Protocoller implementation.
 */
-func (socket *Socket) Audits() *AuditsProtocol {
-	return socket.audits
+func (protocols *Protocols) Audits() *AuditsProtocol {
+	return protocols.audits
 }
 
 /*
@@ -41,8 +151,8 @@ Browser returns the BrowserProtocol instance.
 
 Browser is a Protocoller implementation.
 */
-func (socket *Socket) Browser() *BrowserProtocol {
-	return socket.browser
+func (protocols *Protocols) Browser() *BrowserProtocol {
+	return protocols.browser
 }
 
 /*
@@ -50,8 +160,17 @@ CacheStorage returns the CacheStorageProtocol instance.
 
 CacheStorage is a Protocoller implementation.
 */
-func (socket *Socket) CacheStorage() *CacheStorageProtocol {
-	return socket.cacheStorage
+func (protocols *Protocols) CacheStorage() *CacheStorageProtocol {
+	return protocols.cacheStorage
+}
+
+/*
+Cast returns the CastProtocol instance.
+
+Cast is a Protocoller implementation.
+*/
+func (protocols *Protocols) Cast() *CastProtocol {
+	return protocols.cast
 }
 
 /*
@@ -59,8 +178,8 @@ Console returns the ConsoleProtocol instance.
 
 Console is a Protocoller implementation.
 */
-func (socket *Socket) Console() *ConsoleProtocol {
-	return socket.console
+func (protocols *Protocols) Console() *ConsoleProtocol {
+	return protocols.console
 }
 
 /*
@@ -68,8 +187,8 @@ CSS returns the CSSProtocol instance.
 
 CSS is a Protocoller implementation.
 */
-func (socket *Socket) CSS() *CSSProtocol {
-	return socket.css
+func (protocols *Protocols) CSS() *CSSProtocol {
+	return protocols.css
 }
 
 /*
@@ -77,8 +196,8 @@ Database returns the DatabaseProtocol instance.
 
 Database is a Protocoller implementation.
 */
-func (socket *Socket) Database() *DatabaseProtocol {
-	return socket.database
+func (protocols *Protocols) Database() *DatabaseProtocol {
+	return protocols.database
 }
 
 /*
@@ -86,8 +205,17 @@ Debugger returns the DebuggerProtocol instance.
 
 Debugger is a Protocoller implementation.
 */
-func (socket *Socket) Debugger() *DebuggerProtocol {
-	return socket.debugger
+func (protocols *Protocols) Debugger() *DebuggerProtocol {
+	return protocols.debugger
+}
+
+/*
+DeviceAccess returns the DeviceAccessProtocol instance.
+
+DeviceAccess is a Protocoller implementation.
+*/
+func (protocols *Protocols) DeviceAccess() *DeviceAccessProtocol {
+	return protocols.deviceAccess
 }
 
 /*
@@ -95,8 +223,8 @@ DeviceOrientation returns the DeviceOrientationProtocol instance.
 
 DeviceOrientation is a Protocoller implementation.
 */
-func (socket *Socket) DeviceOrientation() *DeviceOrientationProtocol {
-	return socket.deviceOrientation
+func (protocols *Protocols) DeviceOrientation() *DeviceOrientationProtocol {
+	return protocols.deviceOrientation
 }
 
 /*
@@ -104,8 +232,8 @@ DOMDebugger returns the DOMDebuggerProtocol instance.
 
 DOMDebugger is a Protocoller implementation.
 */
-func (socket *Socket) DOMDebugger() *DOMDebuggerProtocol {
-	return socket.domDebugger
+func (protocols *Protocols) DOMDebugger() *DOMDebuggerProtocol {
+	return protocols.domDebugger
 }
 
 /*
@@ -113,8 +241,8 @@ DOMSnapshot returns the DOMSnapshotProtocol instance.
 
 DOMSnapshot is a Protocoller implementation.
 */
-func (socket *Socket) DOMSnapshot() *DOMSnapshotProtocol {
-	return socket.domSnapshot
+func (protocols *Protocols) DOMSnapshot() *DOMSnapshotProtocol {
+	return protocols.domSnapshot
 }
 
 /*
@@ -122,8 +250,8 @@ DOMStorage returns the DOMStorageProtocol instance.
 
 DOMStorage is a Protocoller implementation.
 */
-func (socket *Socket) DOMStorage() *DOMStorageProtocol {
-	return socket.domStorage
+func (protocols *Protocols) DOMStorage() *DOMStorageProtocol {
+	return protocols.domStorage
 }
 
 /*
@@ -131,8 +259,8 @@ DOM returns the DOMProtocol instance.
 
 DOM is a Protocoller implementation.
 */
-func (socket *Socket) DOM() *DOMProtocol {
-	return socket.dom
+func (protocols *Protocols) DOM() *DOMProtocol {
+	return protocols.dom
 }
 
 /*
@@ -140,8 +268,26 @@ Emulation returns the EmulationProtocol instance.
 
 Emulation is a Protocoller implementation.
 */
-func (socket *Socket) Emulation() *EmulationProtocol {
-	return socket.emulation
+func (protocols *Protocols) Emulation() *EmulationProtocol {
+	return protocols.emulation
+}
+
+/*
+FedCM returns the FedCMProtocol instance.
+
+FedCM is a Protocoller implementation.
+*/
+func (protocols *Protocols) FedCM() *FedCMProtocol {
+	return protocols.fedCM
+}
+
+/*
+Fetch returns the FetchProtocol instance.
+
+Fetch is a Protocoller implementation.
+*/
+func (protocols *Protocols) Fetch() *FetchProtocol {
+	return protocols.fetch
 }
 
 /*
@@ -149,8 +295,8 @@ HeadlessExperimental returns the HeadlessExperimentalProtocol instance.
 
 HeadlessExperimental is a Protocoller implementation.
 */
-func (socket *Socket) HeadlessExperimental() *HeadlessExperimentalProtocol {
-	return socket.headlessExperimental
+func (protocols *Protocols) HeadlessExperimental() *HeadlessExperimentalProtocol {
+	return protocols.headlessExperimental
 }
 
 /*
@@ -158,8 +304,8 @@ HeapProfiler returns the HeapProfilerProtocol instance.
 
 HeapProfiler is a Protocoller implementation.
 */
-func (socket *Socket) HeapProfiler() *HeapProfilerProtocol {
-	return socket.heapProfiler
+func (protocols *Protocols) HeapProfiler() *HeapProfilerProtocol {
+	return protocols.heapProfiler
 }
 
 /*
@@ -167,8 +313,8 @@ IndexedDB returns the IndexedDBProtocol instance.
 
 IndexedDB is a Protocoller implementation.
 */
-func (socket *Socket) IndexedDB() *IndexedDBProtocol {
-	return socket.indexedDB
+func (protocols *Protocols) IndexedDB() *IndexedDBProtocol {
+	return protocols.indexedDB
 }
 
 /*
@@ -176,8 +322,17 @@ Input returns the InputProtocol instance.
 
 Input is a Protocoller implementation.
 */
-func (socket *Socket) Input() *InputProtocol {
-	return socket.input
+func (protocols *Protocols) Input() *InputProtocol {
+	return protocols.input
+}
+
+/*
+Inspector returns the InspectorProtocol instance.
+
+Inspector is a Protocoller implementation.
+*/
+func (protocols *Protocols) Inspector() *InspectorProtocol {
+	return protocols.inspector
 }
 
 /*
@@ -185,8 +340,8 @@ IO returns the IOProtocol instance.
 
 IO is a Protocoller implementation.
 */
-func (socket *Socket) IO() *IOProtocol {
-	return socket.io
+func (protocols *Protocols) IO() *IOProtocol {
+	return protocols.io
 }
 
 /*
@@ -194,8 +349,8 @@ LayerTree returns the LayerTreeProtocol instance.
 
 LayerTree is a Protocoller implementation.
 */
-func (socket *Socket) LayerTree() *LayerTreeProtocol {
-	return socket.layerTree
+func (protocols *Protocols) LayerTree() *LayerTreeProtocol {
+	return protocols.layerTree
 }
 
 /*
@@ -203,8 +358,8 @@ Log returns the LogProtocol instance.
 
 Log is a Protocoller implementation.
 */
-func (socket *Socket) Log() *LogProtocol {
-	return socket.log
+func (protocols *Protocols) Log() *LogProtocol {
+	return protocols.log
 }
 
 /*
@@ -212,8 +367,8 @@ Memory returns the MemoryProtocol instance.
 
 Memory is a Protocoller implementation.
 */
-func (socket *Socket) Memory() *MemoryProtocol {
-	return socket.memory
+func (protocols *Protocols) Memory() *MemoryProtocol {
+	return protocols.memory
 }
 
 /*
@@ -221,8 +376,8 @@ Network returns the NetworkProtocol instance.
 
 Network is a Protocoller implementation.
 */
-func (socket *Socket) Network() *NetworkProtocol {
-	return socket.network
+func (protocols *Protocols) Network() *NetworkProtocol {
+	return protocols.network
 }
 
 /*
@@ -230,8 +385,8 @@ Overlay returns the OverlayProtocol instance.
 
 Overlay is a Protocoller implementation.
 */
-func (socket *Socket) Overlay() *OverlayProtocol {
-	return socket.overlay
+func (protocols *Protocols) Overlay() *OverlayProtocol {
+	return protocols.overlay
 }
 
 /*
@@ -239,8 +394,8 @@ Page returns the PageProtocol instance.
 
 Page is a Protocoller implementation.
 */
-func (socket *Socket) Page() *PageProtocol {
-	return socket.page
+func (protocols *Protocols) Page() *PageProtocol {
+	return protocols.page
 }
 
 /*
@@ -248,8 +403,26 @@ Performance returns the PerformanceProtocol instance.
 
 Performance is a Protocoller implementation.
 */
-func (socket *Socket) Performance() *PerformanceProtocol {
-	return socket.performance
+func (protocols *Protocols) Performance() *PerformanceProtocol {
+	return protocols.performance
+}
+
+/*
+PerformanceTimeline returns the PerformanceTimelineProtocol instance.
+
+PerformanceTimeline is a Protocoller implementation.
+*/
+func (protocols *Protocols) PerformanceTimeline() *PerformanceTimelineProtocol {
+	return protocols.performanceTimeline
+}
+
+/*
+Preload returns the PreloadProtocol instance.
+
+Preload is a Protocoller implementation.
+*/
+func (protocols *Protocols) Preload() *PreloadProtocol {
+	return protocols.preload
 }
 
 /*
@@ -257,8 +430,8 @@ Profiler returns the ProfilerProtocol instance.
 
 Profiler is a Protocoller implementation.
 */
-func (socket *Socket) Profiler() *ProfilerProtocol {
-	return socket.profiler
+func (protocols *Protocols) Profiler() *ProfilerProtocol {
+	return protocols.profiler
 }
 
 /*
@@ -266,8 +439,8 @@ Runtime returns the RuntimeProtocol instance.
 
 Runtime is a Protocoller implementation.
 */
-func (socket *Socket) Runtime() *RuntimeProtocol {
-	return socket.runtime
+func (protocols *Protocols) Runtime() *RuntimeProtocol {
+	return protocols.runtime
 }
 
 /*
@@ -275,8 +448,8 @@ Schema returns the SchemaProtocol instance.
 
 Schema is a Protocoller implementation.
 */
-func (socket *Socket) Schema() *SchemaProtocol {
-	return socket.schema
+func (protocols *Protocols) Schema() *SchemaProtocol {
+	return protocols.schema
 }
 
 /*
@@ -284,8 +457,8 @@ Security returns the SecurityProtocol instance.
 
 Security is a Protocoller implementation.
 */
-func (socket *Socket) Security() *SecurityProtocol {
-	return socket.security
+func (protocols *Protocols) Security() *SecurityProtocol {
+	return protocols.security
 }
 
 /*
@@ -293,8 +466,8 @@ ServiceWorker returns the ServiceWorkerProtocol instance.
 
 ServiceWorker is a Protocoller implementation.
 */
-func (socket *Socket) ServiceWorker() *ServiceWorkerProtocol {
-	return socket.serviceWorker
+func (protocols *Protocols) ServiceWorker() *ServiceWorkerProtocol {
+	return protocols.serviceWorker
 }
 
 /*
@@ -302,8 +475,8 @@ Storage returns the StorageProtocol instance.
 
 Storage is a Protocoller implementation.
 */
-func (socket *Socket) Storage() *StorageProtocol {
-	return socket.storage
+func (protocols *Protocols) Storage() *StorageProtocol {
+	return protocols.storage
 }
 
 /*
@@ -311,8 +484,8 @@ SystemInfo returns the SystemInfoProtocol instance.
 
 SystemInfo is a Protocoller implementation.
 */
-func (socket *Socket) SystemInfo() *SystemInfoProtocol {
-	return socket.systemInfo
+func (protocols *Protocols) SystemInfo() *SystemInfoProtocol {
+	return protocols.systemInfo
 }
 
 /*
@@ -320,8 +493,8 @@ Target returns the TargetProtocol instance.
 
 Target is a Protocoller implementation.
 */
-func (socket *Socket) Target() *TargetProtocol {
-	return socket.target
+func (protocols *Protocols) Target() *TargetProtocol {
+	return protocols.target
 }
 
 /*
@@ -329,8 +502,8 @@ Tethering returns the TetheringProtocol instance.
 
 Tethering is a Protocoller implementation.
 */
-func (socket *Socket) Tethering() *TetheringProtocol {
-	return socket.tethering
+func (protocols *Protocols) Tethering() *TetheringProtocol {
+	return protocols.tethering
 }
 
 /*
@@ -338,6 +511,15 @@ Tracing returns the TracingProtocol instance.
 
 Tracing is a Protocoller implementation.
 */
-func (socket *Socket) Tracing() *TracingProtocol {
-	return socket.tracing
+func (protocols *Protocols) Tracing() *TracingProtocol {
+	return protocols.tracing
+}
+
+/*
+WebAuthn returns the WebAuthnProtocol instance.
+
+WebAuthn is a Protocoller implementation.
+*/
+func (protocols *Protocols) WebAuthn() *WebAuthnProtocol {
+	return protocols.webAuthn
 }
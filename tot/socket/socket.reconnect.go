@@ -0,0 +1,212 @@
+package socket
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+ReconnectPolicy configures automatic reconnection after the websocket
+connection drops, for example when Chrome restarts. Event handlers stay
+registered across reconnects and the domains that were enabled with a
+<Domain>.enable command are enabled again on the new connection. Commands that
+were waiting for a response when the connection dropped receive a
+SocketConnectionLost error.
+
+The delay between attempts starts at InitialBackoff and is multiplied by
+Multiplier after each failed attempt, up to MaxBackoff.
+*/
+type ReconnectPolicy struct {
+	// Optional. Maximum number of consecutive reconnection attempts. Zero
+	// retries until the socket is stopped.
+	MaxAttempts int
+
+	// Optional. Delay before the first attempt. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// Optional. Maximum delay between attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Optional. Backoff multiplier. Defaults to 2.
+	Multiplier float64
+
+	// Optional. Returns the URL to reconnect to. A restarted browser assigns
+	// new websocket URLs, this can be used to look up the new URL. Defaults to
+	// the current URL.
+	Resolve func() (*url.URL, error)
+
+	// Optional. Called after the connection is re-established and the
+	// enabled domains have been enabled again, with the number of attempts
+	// it took.
+	OnReconnect func(attempts int)
+}
+
+/*
+backoff returns the delay before the specified attempt, starting at 1.
+*/
+func (policy *ReconnectPolicy) backoff(attempt int) time.Duration {
+	max := policy.MaxBackoff
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	return exponentialBackoff(policy.InitialBackoff, max, policy.Multiplier, attempt)
+}
+
+/*
+enabledDomains tracks the parameters of the enable commands sent for each
+domain, in the order the domains were first enabled.
+*/
+type enabledDomains struct {
+	domains []string
+	mux     *sync.Mutex
+	params  map[string]interface{}
+	warned  map[string]bool
+}
+
+func newEnabledDomains() *enabledDomains {
+	return &enabledDomains{
+		domains: make([]string, 0),
+		mux:     &sync.Mutex{},
+		params:  make(map[string]interface{}),
+		warned:  make(map[string]bool),
+	}
+}
+
+/*
+track records <Domain>.enable and <Domain>.disable commands.
+*/
+func (enabled *enabledDomains) track(command Commander) {
+	parts := strings.SplitN(command.Method(), ".", 2)
+	if 2 != len(parts) || ("enable" != parts[1] && "disable" != parts[1]) {
+		return
+	}
+	domain := parts[0]
+
+	enabled.mux.Lock()
+	defer enabled.mux.Unlock()
+	_, ok := enabled.params[domain]
+	if "disable" == parts[1] {
+		if ok {
+			delete(enabled.params, domain)
+			for a, name := range enabled.domains {
+				if name == domain {
+					enabled.domains = append(enabled.domains[:a], enabled.domains[a+1:]...)
+					break
+				}
+			}
+		}
+		return
+	}
+	if !ok {
+		enabled.domains = append(enabled.domains, domain)
+	}
+	enabled.params[domain] = command.Params()
+}
+
+/*
+list returns the enabled domains and their enable parameters.
+*/
+func (enabled *enabledDomains) list() ([]string, map[string]interface{}) {
+	enabled.mux.Lock()
+	defer enabled.mux.Unlock()
+	domains := append([]string{}, enabled.domains...)
+	params := make(map[string]interface{}, len(enabled.params))
+	for domain, p := range enabled.params {
+		params[domain] = p
+	}
+	return domains, params
+}
+
+/*
+reconnect closes the dropped connection, fails the pending commands and
+reconnects according to the reconnect policy. The enabled domains are restored
+in the background because their responses are read by the caller.
+*/
+func (socket *Socket) reconnect(policy *ReconnectPolicy, cause error) error {
+	socket.mux.Lock()
+	if nil != socket.conn {
+		socket.conn.Close()
+	}
+	socket.conn = nil
+	socket.connected = false
+	socket.mux.Unlock()
+	socket.failPending(cause)
+
+	for attempt := 1; 0 == policy.MaxAttempts || attempt <= policy.MaxAttempts; attempt++ {
+		time.Sleep(policy.backoff(attempt))
+		if !socket.listening {
+			return errs.New(codes.SocketReconnectFailed, "socket stopped while reconnecting")
+		}
+
+		if nil != policy.Resolve {
+			socketURL, err := policy.Resolve()
+			if nil != err {
+				socket.logger.Warn("could not resolve the reconnect URL", logger.Fields{"attempt": attempt, "error": err, "socketID": socket.socketID})
+				continue
+			}
+			socket.mux.Lock()
+			socket.url = socketURL
+			socket.mux.Unlock()
+		}
+
+		if err := socket.Connect(); nil != err {
+			socket.logger.Warn("reconnect failed", logger.Fields{"attempt": attempt, "error": err, "socketID": socket.socketID})
+			continue
+		}
+		socket.logger.Info("socket reconnected", logger.Fields{"attempt": attempt, "socketID": socket.socketID, "url": socket.url.String()})
+		socket.metrics.reconnected()
+		socket.mux.Lock()
+		socket.detachment = nil
+		socket.mux.Unlock()
+		go socket.restore(policy, attempt)
+		return nil
+	}
+
+	return errs.Wrap(cause, codes.SocketReconnectFailed, fmt.Sprintf("could not reconnect after %d attempts", policy.MaxAttempts))
+}
+
+/*
+failPending responds to every pending command with a SocketConnectionLost
+error.
+*/
+func (socket *Socket) failPending(cause error) {
+	for _, command := range socket.commands.PopAll() {
+		err := errs.Wrap(cause, codes.SocketConnectionLost, fmt.Sprintf("command #%d '%s' lost its connection", command.ID(), command.Method()))
+		response := &Response{
+			Error: &Error{
+				Code:    int(codes.SocketConnectionLost),
+				Data:    []byte(fmt.Sprintf("%q", err.Error())),
+				Message: err.Error(),
+			},
+			ID: command.ID(),
+		}
+		socket.completed(command, response)
+		go command.Respond(response)
+	}
+}
+
+/*
+restore enables the previously enabled domains on a new connection and calls
+the OnReconnect hook.
+*/
+func (socket *Socket) restore(policy *ReconnectPolicy, attempts int) {
+	domains, params := socket.enabled.list()
+	for _, domain := range domains {
+		response := <-socket.SendCommand(NewCommand(socket, domain+".enable", params[domain]))
+		if nil != response.Error && 0 != response.Error.Code {
+			socket.logger.Warn("could not enable domain after reconnecting", logger.Fields{"domain": domain, "error": response.Error, "socketID": socket.socketID})
+		}
+	}
+	if nil != policy.OnReconnect {
+		socket.guard("reconnect hook", "", func() {
+			policy.OnReconnect(attempts)
+		})
+	}
+}
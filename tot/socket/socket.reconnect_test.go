@@ -0,0 +1,140 @@
+package socket
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	policy := &ReconnectPolicy{}
+	if 100*time.Millisecond != policy.backoff(1) {
+		t.Errorf("Expected 100ms, got %s", policy.backoff(1))
+	}
+	if 400*time.Millisecond != policy.backoff(3) {
+		t.Errorf("Expected 400ms, got %s", policy.backoff(3))
+	}
+	if 30*time.Second != policy.backoff(100) {
+		t.Errorf("Expected 30s, got %s", policy.backoff(100))
+	}
+
+	policy = &ReconnectPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 3}
+	if 3*time.Second != policy.backoff(2) {
+		t.Errorf("Expected 3s, got %s", policy.backoff(2))
+	}
+	if 5*time.Second != policy.backoff(3) {
+		t.Errorf("Expected 5s, got %s", policy.backoff(3))
+	}
+}
+
+func TestEnabledDomainsTrack(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestEnabledDomainsTrack")
+	mockSocket := NewMock(socketURL)
+	enabled := newEnabledDomains()
+	enabled.track(NewCommand(mockSocket, "Network.enable", nil))
+	enabled.track(NewCommand(mockSocket, "Page.enable", nil))
+	enabled.track(NewCommand(mockSocket, "Fetch.enable", "first"))
+	enabled.track(NewCommand(mockSocket, "Page.navigate", nil))
+	enabled.track(NewCommand(mockSocket, "Page.disable", nil))
+	enabled.track(NewCommand(mockSocket, "Fetch.enable", "second"))
+
+	domains, params := enabled.list()
+	if 2 != len(domains) || "Network" != domains[0] || "Fetch" != domains[1] {
+		t.Errorf("Expected [Network Fetch], got %v", domains)
+	}
+	if "second" != params["Fetch"] {
+		t.Errorf("Expected the latest enable params, got %v", params["Fetch"])
+	}
+}
+
+func TestSocketReconnect(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	reconnected := make(chan int, 1)
+	socket := New(server.URL(), WithReconnect(&ReconnectPolicy{
+		InitialBackoff: 200 * time.Millisecond,
+		OnReconnect: func(attempts int) {
+			reconnected <- attempts
+		},
+	}))
+	defer socket.Stop()
+
+	events := make(chan bool, 1)
+	socket.AddEventHandler(NewEventHandler("Some.event", func(response *Response) {
+		events <- true
+	}))
+	for _, method := range []string{"Network.enable", "Page.enable", "Page.disable"} {
+		if response := <-socket.SendCommand(NewCommand(socket, method, nil)); nil != response.Error && 0 != response.Error.Code {
+			t.Fatalf("Expected success, got error: %v", response.Error)
+		}
+	}
+
+	server.SetChaos(cdptest.Chaos{DisconnectAfter: 1})
+	response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	if nil == response.Error || int(codes.SocketConnectionLost) != response.Error.Code {
+		t.Fatalf("Expected a connection lost error, got %v", response.Error)
+	}
+	server.SetChaos(cdptest.Chaos{})
+
+	select {
+	case attempts := <-reconnected:
+		if 1 != attempts {
+			t.Errorf("Expected 1 attempt, got %d", attempts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the socket to reconnect")
+	}
+
+	commands := server.Commands()
+	if last := commands[len(commands)-1]; "Network.enable" != last.Method {
+		t.Errorf("Expected Network.enable to be sent again, got %s", last.Method)
+	}
+	for _, command := range commands[4:] {
+		if "Page.enable" == command.Method {
+			t.Errorf("Expected the disabled Page domain to stay disabled")
+		}
+	}
+
+	if err := server.Emit("Some.event", map[string]string{}); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the event handler to be attached after reconnecting")
+	}
+}
+
+func TestSocketReconnectFailed(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+
+	socket := New(server.URL(), WithReconnect(&ReconnectPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: 10 * time.Millisecond,
+		Resolve: func() (*url.URL, error) {
+			return nil, errors.New("browser is gone")
+		},
+	}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	server.SetChaos(cdptest.Chaos{DisconnectAfter: 1})
+	<-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+
+	select {
+	case err := <-socket.Errors():
+		if nil == err {
+			t.Errorf("Expected error, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the socket to give up")
+	}
+}
@@ -0,0 +1,448 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+Record writes every frame sent and received by the socket to a writer, one line
+of JSON per frame in the NewTapWriter format, so the conversation can be
+replayed with NewReplay. The returned function stops recording:
+
+	file, _ := os.Create("testdata/login.jsonl")
+	defer file.Close()
+	stop := sock.Record(file)
+	defer stop()
+
+Frames sent and received before Record is called aren't recorded.
+*/
+func (socket *Socket) Record(w io.Writer) func() {
+	return socket.Tap(NewTapWriter(w))
+}
+
+/*
+Replay is a Socketer that serves a recording made with Socket.Record instead of
+connecting to Chrome, for fast, hermetic tests of automation logic:
+
+	file, _ := os.Open("testdata/login.jsonl")
+	defer file.Close()
+	replay, err := socket.NewReplay(file)
+	if nil != err {
+		...
+	}
+	replay.Listen()
+	defer replay.Stop()
+	err = login(replay)
+
+Each command is matched to the first recorded command that hasn't been replayed
+yet with the same method and parameters, or failing that with the same method.
+Its recorded response is returned with the command's ID, then the messages that
+were received after the recorded command and before the next one are replayed
+in order: events are passed to the event handlers and responses to the
+commands they belong to. The events received before the first command are
+replayed by Listen. Handlers are called one at a time, so a handler that blocks
+delays the replay.
+
+A command that doesn't match a recorded command, or whose response wasn't
+recorded, receives a SocketReplayFailed error. Sessions aren't distinguished:
+commands and events of every session are replayed as if they belonged to the
+replay.
+*/
+type Replay struct {
+	commandID int64
+	commands  []*replayCommand
+	errCh     chan error
+	handlers  EventHandlerMapper
+	initial   []*Response
+	listening bool
+	logger    logger.Logger
+	matched   map[int]Commander
+	mux       *sync.Mutex
+	pending   map[int]*Response
+	queue     []func()
+	running   bool
+	stopped   bool
+	url       *url.URL
+
+	// Protocol interfaces for the API.
+	*Protocols
+}
+
+/*
+replayCommand is a recorded command and the messages received after it.
+*/
+type replayCommand struct {
+	id        int
+	method    string
+	params    json.RawMessage
+	messages  []*Response
+	responded bool
+	replayed  bool
+}
+
+/*
+NewReplay reads a recording made with Socket.Record or a tap created with
+NewTapWriter.
+*/
+func NewReplay(reader io.Reader) (*Replay, error) {
+	replay := &Replay{
+		commands: make([]*replayCommand, 0),
+		errCh:    make(chan error, 1),
+		handlers: NewEventHandlerMap(),
+		initial:  make([]*Response, 0),
+		logger:   logger.Default(),
+		matched:  make(map[int]Commander),
+		mux:      &sync.Mutex{},
+		pending:  make(map[int]*Response),
+		url:      &url.URL{Scheme: "replay"},
+	}
+	replay.Protocols = NewProtocols(replay)
+
+	responded := make(map[int]bool)
+	buffered := bufio.NewReader(reader)
+	for line := 1; ; line++ {
+		data, err := buffered.ReadBytes('\n')
+		if nil != err && io.EOF != err {
+			return nil, errs.Wrap(err, codes.SocketReplayFailed, "could not read the recording")
+		}
+		if data = bytes.TrimSpace(data); 0 < len(data) {
+			frame := struct {
+				Direction string          `json:"direction"`
+				Data      json.RawMessage `json:"data"`
+			}{}
+			message := &Response{}
+			if e := json.Unmarshal(data, &frame); nil != e {
+				return nil, errs.Wrap(e, codes.SocketReplayFailed, fmt.Sprintf("invalid frame on line %d of the recording", line))
+			}
+			if e := json.Unmarshal(frame.Data, message); nil != e {
+				return nil, errs.Wrap(e, codes.SocketReplayFailed, fmt.Sprintf("invalid message on line %d of the recording", line))
+			}
+
+			switch frame.Direction {
+			case Outbound.String():
+				replay.commands = append(replay.commands, &replayCommand{
+					id:       message.ID,
+					method:   message.Method,
+					params:   message.Params,
+					messages: make([]*Response, 0),
+				})
+			case Inbound.String():
+				if message.ID > 0 {
+					responded[message.ID] = true
+				}
+				if 0 == len(replay.commands) {
+					replay.initial = append(replay.initial, message)
+				} else {
+					last := replay.commands[len(replay.commands)-1]
+					last.messages = append(last.messages, message)
+				}
+			default:
+				return nil, errs.New(codes.SocketReplayFailed, fmt.Sprintf("invalid direction '%s' on line %d of the recording", frame.Direction, line))
+			}
+		}
+		if io.EOF == err {
+			break
+		}
+	}
+	for _, command := range replay.commands {
+		command.responded = responded[command.id]
+	}
+	return replay, nil
+}
+
+/*
+AddEventHandler adds an event handler to the stack of listeners for an event.
+
+AddEventHandler is a Socketer implementation.
+*/
+func (replay *Replay) AddEventHandler(handler EventHandler) *Subscription {
+	replay.handlers.Add(handler)
+	return NewSubscription(handler, func() error {
+		return replay.RemoveEventHandler(handler)
+	})
+}
+
+/*
+CurCommandID returns the latest command ID.
+
+CurCommandID is a Socketer implementation.
+*/
+func (replay *Replay) CurCommandID() int {
+	return int(atomic.LoadInt64(&replay.commandID))
+}
+
+/*
+Errors returns the error channel. A replay doesn't report errors on it.
+
+Errors is a Socketer implementation.
+*/
+func (replay *Replay) Errors() chan error {
+	return replay.errCh
+}
+
+/*
+Listen replays the events that were received before the first recorded
+command.
+
+Listen is a Socketer implementation.
+*/
+func (replay *Replay) Listen() {
+	replay.mux.Lock()
+	defer replay.mux.Unlock()
+	if replay.listening {
+		return
+	}
+	replay.listening = true
+	replay.enqueue(replay.initial)
+}
+
+/*
+NextCommandID generates and returns the next command ID.
+
+NextCommandID is a Socketer implementation.
+*/
+func (replay *Replay) NextCommandID() int {
+	return int(atomic.AddInt64(&replay.commandID, 1))
+}
+
+/*
+Remaining returns the number of recorded commands that haven't been replayed,
+for asserting that the automation under test sent everything it was recorded
+sending.
+*/
+func (replay *Replay) Remaining() int {
+	replay.mux.Lock()
+	defer replay.mux.Unlock()
+	remaining := 0
+	for _, recorded := range replay.commands {
+		if !recorded.replayed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+/*
+RemoveEventHandler removes a handler from the stack of listeners for an event.
+
+RemoveEventHandler is a Socketer implementation.
+*/
+func (replay *Replay) RemoveEventHandler(handler EventHandler) error {
+	return removeEventHandler(replay.handlers, handler, replay.logger, logger.Fields{"url": replay.url.String()})
+}
+
+/*
+SendCommand responds to a command with its recorded response and replays the
+messages received after it.
+
+SendCommand is a Socketer implementation.
+*/
+func (replay *Replay) SendCommand(command Commander) chan *Response {
+	replay.mux.Lock()
+	defer replay.mux.Unlock()
+	if replay.stopped {
+		go command.Respond(shutdownResponse(command, "was sent after the replay was stopped"))
+		return command.Response()
+	}
+
+	recorded := replay.match(command)
+	if nil == recorded {
+		go command.Respond(replayResponse(command, "does not match a recorded command"))
+		return command.Response()
+	}
+	recorded.replayed = true
+	replay.matched[recorded.id] = command
+
+	if response, ok := replay.pending[recorded.id]; ok {
+		delete(replay.pending, recorded.id)
+		replay.enqueue([]*Response{response})
+	}
+	replay.enqueue(recorded.messages)
+	if !recorded.responded {
+		delete(replay.matched, recorded.id)
+		replay.queue = append(replay.queue, func() {
+			command.Respond(replayResponse(command, "has no recorded response"))
+		})
+		replay.start()
+	}
+	return command.Response()
+}
+
+/*
+SendCommandContext responds to a command like SendCommand, unless the context
+is already done.
+
+SendCommandContext is a Socketer implementation.
+*/
+func (replay *Replay) SendCommandContext(ctx context.Context, command Commander) chan *Response {
+	if err := ctx.Err(); nil != err {
+		go command.Respond(contextResponse(command, err))
+		return command.Response()
+	}
+	return replay.SendCommand(command)
+}
+
+/*
+Stop stops the replay. Messages that haven't been replayed yet are dropped.
+
+Stop is a Socketer implementation.
+*/
+func (replay *Replay) Stop() {
+	replay.mux.Lock()
+	replay.stopped = true
+	replay.queue = nil
+	replay.mux.Unlock()
+}
+
+/*
+URL returns a placeholder replay:// URL.
+
+URL is a Socketer implementation.
+*/
+func (replay *Replay) URL() *url.URL {
+	return replay.url
+}
+
+/*
+match returns the first recorded command that hasn't been replayed with the
+command's method and parameters, or with its method if none has the same
+parameters.
+*/
+func (replay *Replay) match(command Commander) *replayCommand {
+	params, _ := json.Marshal(command.Params())
+	var sameMethod *replayCommand
+	for _, recorded := range replay.commands {
+		if recorded.replayed || recorded.method != command.Method() {
+			continue
+		}
+		if equalJSON(recorded.params, params) {
+			return recorded
+		}
+		if nil == sameMethod {
+			sameMethod = recorded
+		}
+	}
+	return sameMethod
+}
+
+/*
+enqueue adds messages to the replay queue and starts replaying the queue if it
+isn't already. The replay mutex must be held.
+*/
+func (replay *Replay) enqueue(messages []*Response) {
+	for _, message := range messages {
+		message := message
+		replay.queue = append(replay.queue, func() {
+			replay.deliver(message)
+		})
+	}
+	replay.start()
+}
+
+/*
+start starts replaying the queue if it isn't already. The replay mutex must be
+held.
+*/
+func (replay *Replay) start() {
+	if !replay.running && 0 < len(replay.queue) {
+		replay.running = true
+		go replay.run()
+	}
+}
+
+/*
+run replays the queue one message at a time until it's empty.
+*/
+func (replay *Replay) run() {
+	for {
+		replay.mux.Lock()
+		if 0 == len(replay.queue) {
+			replay.running = false
+			replay.mux.Unlock()
+			return
+		}
+		step := replay.queue[0]
+		replay.queue = replay.queue[1:]
+		replay.mux.Unlock()
+		step()
+	}
+}
+
+/*
+deliver passes a recorded event to the event handlers, or a recorded response to
+the command it belongs to. Responses to commands that haven't been replayed yet
+are kept until they are.
+*/
+func (replay *Replay) deliver(message *Response) {
+	if message.ID > 0 {
+		replay.mux.Lock()
+		command, ok := replay.matched[message.ID]
+		if !ok {
+			replay.pending[message.ID] = message
+			replay.mux.Unlock()
+			return
+		}
+		delete(replay.matched, message.ID)
+		replay.mux.Unlock()
+
+		response := *message
+		response.ID = command.ID()
+		command.Respond(&response)
+		return
+	}
+
+	handlers, err := replay.handlers.Get(message.Method)
+	if nil != err {
+		return
+	}
+	for _, handler := range handlers {
+		handler.Handle(message)
+	}
+}
+
+/*
+equalJSON returns whether two JSON values are equal, treating a missing value
+as null.
+*/
+func equalJSON(a, b []byte) bool {
+	var valueA, valueB interface{}
+	if 0 < len(a) {
+		if err := json.Unmarshal(a, &valueA); nil != err {
+			return false
+		}
+	}
+	if 0 < len(b) {
+		if err := json.Unmarshal(b, &valueB); nil != err {
+			return false
+		}
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+/*
+replayResponse returns a SocketReplayFailed error response to a command.
+*/
+func replayResponse(command Commander, reason string) *Response {
+	err := errs.New(codes.SocketReplayFailed, fmt.Sprintf("command #%d '%s' %s", command.ID(), command.Method(), reason))
+	return &Response{
+		Error: &Error{
+			Code:    int(codes.SocketReplayFailed),
+			Data:    []byte(fmt.Sprintf("%q", err.Error())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+	}
+}
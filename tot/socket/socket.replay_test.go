@@ -0,0 +1,161 @@
+package socket
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSocketRecordReplay(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Handle("Page.navigate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		server.Emit("Page.loadEventFired", map[string]int{"timestamp": 1})
+		return map[string]string{"frameId": "frame-1"}, nil
+	})
+
+	recording := &bytes.Buffer{}
+	socket := New(server.URL())
+	stop := socket.Record(recording)
+	loaded := make(chan bool, 1)
+	socket.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		loaded <- true
+	})
+	if result := <-socket.Page().Enable(); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if result := <-socket.Page().Navigate(&page.NavigateParams{URL: "https://example.com"}); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the load event to be recorded")
+	}
+	stop()
+	socket.Stop()
+
+	replay, err := NewReplay(recording)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	var _ Socketer = replay
+	replay.Listen()
+	defer replay.Stop()
+
+	events := make(chan *page.LoadEventFiredEvent, 1)
+	replay.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		events <- event
+	})
+	if result := <-replay.Page().Enable(); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	result := <-replay.Page().Navigate(&page.NavigateParams{URL: "https://example.com"})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	if "frame-1" != result.FrameID {
+		t.Errorf("Expected frame-1, got '%s'", result.FrameID)
+	}
+	select {
+	case event := <-events:
+		if 1 != event.Timestamp {
+			t.Errorf("Expected timestamp 1, got %v", event.Timestamp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the load event to be replayed")
+	}
+	if 0 != replay.Remaining() {
+		t.Errorf("Expected every recorded command to be replayed, %d remaining", replay.Remaining())
+	}
+}
+
+func TestReplayMatchesParams(t *testing.T) {
+	replay, err := NewReplay(strings.NewReader(`
+{"direction":"out","data":{"id":1,"method":"Runtime.evaluate","params":{"expression":"a"}}}
+{"direction":"out","data":{"id":2,"method":"Runtime.evaluate","params":{"expression":"b"}}}
+{"direction":"in","data":{"id":2,"result":{"result":{"type":"string","value":"B"}}}}
+{"direction":"in","data":{"id":1,"result":{"result":{"type":"string","value":"A"}}}}
+`))
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	replay.Listen()
+	defer replay.Stop()
+
+	for _, expression := range []string{"b", "a"} {
+		result := <-replay.Runtime().Evaluate(&runtime.EvaluateParams{Expression: expression})
+		if nil != result.Err {
+			t.Fatalf("Expected nil, got error: %v", result.Err)
+		}
+		if strings.ToUpper(expression) != result.Result.Value {
+			t.Errorf("Expected '%s', got '%v'", strings.ToUpper(expression), result.Result.Value)
+		}
+	}
+	if 0 != replay.Remaining() {
+		t.Errorf("Expected every recorded command to be replayed, %d remaining", replay.Remaining())
+	}
+}
+
+func TestReplayInitialEvents(t *testing.T) {
+	replay, err := NewReplay(strings.NewReader(`{"direction":"in","data":{"method":"Page.loadEventFired","params":{"timestamp":2}}}`))
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer replay.Stop()
+	events := make(chan *page.LoadEventFiredEvent, 1)
+	replay.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		events <- event
+	})
+	replay.Listen()
+	select {
+	case event := <-events:
+		if 2 != event.Timestamp {
+			t.Errorf("Expected timestamp 2, got %v", event.Timestamp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the event to be replayed by Listen")
+	}
+}
+
+func TestReplayFailed(t *testing.T) {
+	replay, err := NewReplay(strings.NewReader(`{"direction":"out","data":{"id":1,"method":"Page.enable","params":null}}`))
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	replay.Listen()
+
+	response := <-replay.SendCommand(NewCommand(replay, "Page.reload", nil))
+	if nil == response.Error || int(codes.SocketReplayFailed) != response.Error.Code {
+		t.Errorf("Expected a replay error for an unrecorded command, got %v", response.Error)
+	}
+	response = <-replay.SendCommand(NewCommand(replay, "Page.enable", nil))
+	if nil == response.Error || int(codes.SocketReplayFailed) != response.Error.Code {
+		t.Errorf("Expected a replay error for a command without a recorded response, got %v", response.Error)
+	}
+
+	replay.Stop()
+	response = <-replay.SendCommand(NewCommand(replay, "Page.enable", nil))
+	if nil == response.Error || int(codes.SocketShutdown) != response.Error.Code {
+		t.Errorf("Expected a shutdown error after stopping, got %v", response.Error)
+	}
+}
+
+func TestNewReplayInvalid(t *testing.T) {
+	for _, recording := range []string{
+		`not json`,
+		`{"direction":"out","data":"not a message"}`,
+		`{"direction":"sideways","data":{}}`,
+	} {
+		if _, err := NewReplay(strings.NewReader(recording)); nil == err {
+			t.Errorf("Expected an error for '%s'", recording)
+		}
+	}
+}
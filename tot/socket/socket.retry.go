@@ -0,0 +1,250 @@
+package socket
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+transientErrors are protocol error messages for failures that usually succeed
+when the command is sent again: the execution context or target went away
+while the page navigated or crashed.
+*/
+var transientErrors = []string{
+	"Cannot find context with specified id",
+	"Cannot find default execution context",
+	"Execution context was destroyed",
+	"Inspected target navigated or closed",
+	"Target crashed",
+}
+
+/*
+RetryPolicy configures retrying commands that fail with a transient error, for
+example a Runtime.evaluate sent while the page navigates fails with 'Cannot
+find context with specified id'. Retried commands keep their ID and stay
+pending between attempts, so the command timeout covers all attempts and the
+caller only receives the final response.
+
+The delay before a retry starts at InitialBackoff and is multiplied by
+Multiplier after each retry, up to MaxBackoff, and is randomized by Jitter so
+that commands failing together aren't retried together.
+
+Only retry commands that are safe to send twice. Input commands, for example,
+are not, which Retryable can be used to exclude.
+*/
+type RetryPolicy struct {
+	// Optional. Maximum number of retries for a command. Defaults to 3.
+	MaxRetries int
+
+	// Optional. Delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// Optional. Maximum delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// Optional. Backoff multiplier. Defaults to 2.
+	Multiplier float64
+
+	// Optional. Fraction of the delay that's randomized, between 0 and 1.
+	// Defaults to 0.2, a negative value disables jitter.
+	Jitter float64
+
+	// Optional. Returns whether a failed command is retried. Defaults to
+	// IsTransient.
+	Retryable func(method string, err *Error) bool
+}
+
+/*
+IsTransient returns whether a protocol error is a transient failure that
+usually succeeds when the command is sent again.
+*/
+func IsTransient(err *Error) bool {
+	if nil == err {
+		return false
+	}
+	for _, message := range transientErrors {
+		if strings.Contains(err.Message, message) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+backoff returns the delay before the specified retry, starting at 1.
+*/
+func (policy *RetryPolicy) backoff(retry int) time.Duration {
+	max := policy.MaxBackoff
+	if max <= 0 {
+		max = 5 * time.Second
+	}
+	delay := exponentialBackoff(policy.InitialBackoff, max, policy.Multiplier, retry)
+	jitter := policy.Jitter
+	if 0 == jitter {
+		jitter = 0.2
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	if jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 - jitter + 2*jitter*rand.Float64()))
+	}
+	return delay
+}
+
+/*
+retries returns the maximum number of retries.
+*/
+func (policy *RetryPolicy) retries() int {
+	if policy.MaxRetries <= 0 {
+		return 3
+	}
+	return policy.MaxRetries
+}
+
+/*
+retryable returns whether a failed command is retried.
+*/
+func (policy *RetryPolicy) retryable(method string, err *Error) bool {
+	if nil == err {
+		return false
+	}
+	if nil != policy.Retryable {
+		return policy.Retryable(method, err)
+	}
+	return IsTransient(err)
+}
+
+/*
+exponentialBackoff returns the delay before the specified attempt, starting at
+1, growing from initial by multiplier up to max. Zero values use a 100ms
+initial delay and a multiplier of 2.
+*/
+func exponentialBackoff(initial, max time.Duration, multiplier float64, attempt int) time.Duration {
+	delay := initial
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	for a := 1; a < attempt && delay < max; a++ {
+		delay = time.Duration(float64(delay) * multiplier)
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+/*
+WithRetry retries commands that fail with a transient error. See RetryPolicy
+for details. A nil policy uses the defaults:
+
+	sock := socket.New(socketURL, socket.WithRetry(&socket.RetryPolicy{
+		MaxRetries: 5,
+		Retryable: func(method string, err *socket.Error) bool {
+			return !strings.HasPrefix(method, "Input.") && socket.IsTransient(err)
+		},
+	}))
+*/
+func WithRetry(policy *RetryPolicy) Option {
+	return func(socket *Socket) {
+		if nil == policy {
+			policy = &RetryPolicy{}
+		}
+		socket.retry = &commandRetries{
+			attempts: make(map[int]*retryAttempt),
+			mux:      &sync.Mutex{},
+			policy:   policy,
+		}
+	}
+}
+
+/*
+retryAttempt is the retry state of a pending command.
+*/
+type retryAttempt struct {
+	retries   int
+	sessionID target.SessionID
+}
+
+/*
+commandRetries tracks the retries of the pending commands. A nil value doesn't
+retry.
+*/
+type commandRetries struct {
+	attempts map[int]*retryAttempt
+	mux      *sync.Mutex
+	policy   *RetryPolicy
+}
+
+/*
+sent records a command sent for the first time.
+*/
+func (retries *commandRetries) sent(command Commander, sessionID target.SessionID) {
+	if nil == retries {
+		return
+	}
+	retries.mux.Lock()
+	retries.attempts[command.ID()] = &retryAttempt{sessionID: sessionID}
+	retries.mux.Unlock()
+}
+
+/*
+completed forgets a command that has been responded to.
+*/
+func (retries *commandRetries) completed(command Commander) {
+	if nil == retries {
+		return
+	}
+	retries.mux.Lock()
+	delete(retries.attempts, command.ID())
+	retries.mux.Unlock()
+}
+
+/*
+next returns the delay before the next retry of a failed command, and false if
+the command isn't retried.
+*/
+func (retries *commandRetries) next(command Commander, response *Response) (time.Duration, target.SessionID, bool) {
+	if nil == retries || !retries.policy.retryable(command.Method(), response.Error) {
+		return 0, "", false
+	}
+	retries.mux.Lock()
+	defer retries.mux.Unlock()
+	attempt, ok := retries.attempts[command.ID()]
+	if !ok || attempt.retries >= retries.policy.retries() {
+		return 0, "", false
+	}
+	attempt.retries++
+	return retries.policy.backoff(attempt.retries), attempt.sessionID, true
+}
+
+/*
+retryCommand sends a failed command again after the retry policy's backoff. It
+returns false if the command isn't retried and should be responded to.
+*/
+func (socket *Socket) retryCommand(command Commander, response *Response) bool {
+	delay, sessionID, ok := socket.retry.next(command, response)
+	if !ok {
+		return false
+	}
+	socket.logger.Debug("retrying command after transient error", logger.Fields{"commandID": command.ID(), "delay": delay.String(), "error": response.Error.Message, "method": command.Method(), "socketID": socket.socketID})
+
+	// The command stays pending so that a timeout, shutdown or lost
+	// connection responds to it while it waits.
+	socket.commands.Set(command)
+	time.AfterFunc(delay, func() {
+		if _, err := socket.commands.Get(command.ID()); nil != err {
+			return
+		}
+		socket.writeCommand(command, sessionID)
+	})
+	return true
+}
@@ -0,0 +1,108 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond, Jitter: -1}
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond}
+	for a, delay := range expected {
+		if backoff := policy.backoff(a + 1); delay != backoff {
+			t.Errorf("Expected %s for retry %d, got %s", delay, a+1, backoff)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for a := 0; a < 100; a++ {
+		if backoff := policy.backoff(1); backoff < 5*time.Millisecond || backoff > 15*time.Millisecond {
+			t.Fatalf("Expected a jittered delay between 5ms and 15ms, got %s", backoff)
+		}
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	if !IsTransient(&Error{Message: "Cannot find context with specified id"}) {
+		t.Errorf("Expected a missing context to be transient")
+	}
+	if IsTransient(&Error{Message: "Invalid parameters"}) {
+		t.Errorf("Expected invalid parameters not to be transient")
+	}
+	if IsTransient(nil) {
+		t.Errorf("Expected nil not to be transient")
+	}
+}
+
+func TestSocketRetry(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	var calls int32
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return nil, &cdptest.Error{Code: -32000, Message: "Cannot find context with specified id"}
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "number", "value": 2}}, nil
+	})
+	server.Handle("Input.dispatchKeyEvent", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &cdptest.Error{Code: -32000, Message: "Cannot find context with specified id"}
+	})
+	socket := New(server.URL(), WithRetry(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		Retryable: func(method string, err *Error) bool {
+			return "Input.dispatchKeyEvent" != method && IsTransient(err)
+		},
+	}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	response := <-socket.SendCommand(NewCommand(socket, "Runtime.evaluate", nil))
+	if nil != response.Error {
+		t.Errorf("Expected the command to succeed after retrying, got %v", response.Error)
+	}
+	if 3 != atomic.LoadInt32(&calls) {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	response = <-socket.SendCommand(NewCommand(socket, "Input.dispatchKeyEvent", nil))
+	if nil == response.Error {
+		t.Errorf("Expected an error, got nil")
+	}
+	if 1 != atomic.LoadInt32(&calls) {
+		t.Errorf("Expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestSocketRetryExhausted(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	var calls int32
+	server.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &cdptest.Error{Code: -32000, Message: "Execution context was destroyed."}
+	})
+	socket := New(server.URL(), WithRetry(&RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	response := <-socket.SendCommand(NewCommand(socket, "Runtime.evaluate", nil))
+	if nil == response.Error || !IsTransient(response.Error) {
+		t.Errorf("Expected the last transient error, got %v", response.Error)
+	}
+	if 3 != atomic.LoadInt32(&calls) {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+	if 0 != socket.commands.Len() {
+		t.Errorf("Expected no pending commands, got %d", socket.commands.Len())
+	}
+}
@@ -0,0 +1,264 @@
+package socket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+Session is a flattened target session. Commands sent through a session are
+addressed to its target and events from the target are delivered to the
+session's own event handlers, all over the websocket connection of the socket
+that attached to the target. Session is a Socketer and a Protocoller, so the
+protocol interfaces work per session:
+
+	session, err := socket.AttachToTarget(targetID)
+	if nil != err {
+		...
+	}
+	defer session.Stop()
+	<-session.Page().Navigate(&page.NavigateParams{URL: "https://example.com"})
+*/
+type Session struct {
+	detachReason string
+	detachment   *Detachment
+	enabled      *enabledDomains
+	handlers     EventHandlerMapper
+	id           target.SessionID
+	mux          *sync.Mutex
+	socket       *Socket
+	stopped      bool
+	targetID     target.ID
+
+	// Protocol interfaces for the API.
+	*Protocols
+}
+
+/*
+AttachToTarget attaches to a target in flat mode and returns the session.
+*/
+func (socket *Socket) AttachToTarget(targetID target.ID) (*Session, error) {
+	result := <-socket.Target().AttachToTarget(&target.AttachToTargetParams{
+		ID:      targetID,
+		Flatten: true,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, 0, fmt.Sprintf("could not attach to target '%s'", targetID))
+	}
+	session := socket.Session(result.SessionID)
+	session.mux.Lock()
+	session.targetID = targetID
+	session.mux.Unlock()
+	return session, nil
+}
+
+/*
+Session returns the flattened session with the specified ID, for example a
+session that was attached automatically with Target.setAutoAttach. Sessions are
+removed when the target detaches.
+*/
+func (socket *Socket) Session(sessionID target.SessionID) *Session {
+	return socket.sessions.add(socket, sessionID)
+}
+
+/*
+ID returns the session ID.
+*/
+func (session *Session) ID() target.SessionID {
+	session.mux.Lock()
+	defer session.mux.Unlock()
+	return session.id
+}
+
+/*
+AddEventHandler adds an event handler to the stack of listeners for an event
+from the session's target. The returned subscription removes the handler.
+
+AddEventHandler is a Socketer implementation.
+*/
+func (session *Session) AddEventHandler(handler EventHandler) *Subscription {
+	session.socket.logger.Debug("Adding event handler", logger.Fields{"event": handler.Name(), "sessionID": session.ID(), "socketID": session.socket.socketID})
+	if session.socket.gated(handler) {
+		return NewSubscription(handler, func() error { return nil })
+	}
+	session.handlers.Add(handler)
+	session.socket.checkEnabled(session.enabled, handler, logger.Fields{"sessionID": session.ID(), "socketID": session.socket.socketID})
+	return NewSubscription(handler, func() error {
+		return session.RemoveEventHandler(handler)
+	})
+}
+
+/*
+CurCommandID returns the latest command ID of the parent socket.
+
+CurCommandID is a Socketer implementation.
+*/
+func (session *Session) CurCommandID() int {
+	return session.socket.CurCommandID()
+}
+
+/*
+Errors returns the error channel of the parent socket.
+
+Errors is a Socketer implementation.
+*/
+func (session *Session) Errors() chan error {
+	return session.socket.Errors()
+}
+
+/*
+Listen is a no-op, messages are read by the parent socket.
+
+Listen is a Socketer implementation.
+*/
+func (session *Session) Listen() {
+}
+
+/*
+NextCommandID generates and returns the next command ID. Command IDs are shared
+with the parent socket.
+
+NextCommandID is a Socketer implementation.
+*/
+func (session *Session) NextCommandID() int {
+	return session.socket.NextCommandID()
+}
+
+/*
+RemoveEventHandler removes a handler from the stack of listeners for an event.
+
+RemoveEventHandler is a Socketer implementation.
+*/
+func (session *Session) RemoveEventHandler(handler EventHandler) error {
+	return removeEventHandler(session.handlers, handler, session.socket.logger, logger.Fields{"sessionID": session.ID(), "socketID": session.socket.socketID})
+}
+
+/*
+SendCommand delivers a command payload to the session's target. Commands sent
+after the browser detached the session receive a SocketDetached error.
+
+SendCommand is a Socketer implementation.
+*/
+func (session *Session) SendCommand(command Commander) chan *Response {
+	return session.SendCommandContext(context.Background(), command)
+}
+
+/*
+SendCommandContext delivers a command payload to the session's target and
+stops waiting for the response when the context is done.
+
+SendCommandContext is a Socketer implementation.
+*/
+func (session *Session) SendCommandContext(ctx context.Context, command Commander) chan *Response {
+	if !session.accept(command) {
+		return command.Response()
+	}
+	return session.socket.sendCommandContext(ctx, command, session.ID())
+}
+
+/*
+accept responds to a command with a SocketDetached error if the browser
+detached the session, otherwise it tracks the domains the command enables. It
+returns false if the command was responded to.
+*/
+func (session *Session) accept(command Commander) bool {
+	if detachment := session.Detached(); nil != detachment {
+		go command.Respond(detachedResponse(command, detachment))
+		return false
+	}
+	session.enabled.track(command)
+	return true
+}
+
+/*
+Stop detaches from the target. The parent socket keeps listening.
+
+Stop is a Socketer implementation.
+*/
+func (session *Session) Stop() {
+	session.mux.Lock()
+	session.stopped = true
+	sessionID := session.id
+	session.mux.Unlock()
+
+	result := <-session.socket.Target().DetachFromTarget(&target.DetachFromTargetParams{
+		SessionID: sessionID,
+	})
+	if nil != result.Err {
+		session.socket.logger.Warn("could not detach from target", logger.Fields{"error": result.Err, "sessionID": sessionID, "socketID": session.socket.socketID})
+	}
+	session.socket.sessions.remove(sessionID)
+}
+
+/*
+URL returns the URL of the parent socket's websocket connection.
+
+URL is a Socketer implementation.
+*/
+func (session *Session) URL() *url.URL {
+	return session.socket.URL()
+}
+
+/*
+sessionMap tracks the flattened sessions of a socket.
+*/
+type sessionMap struct {
+	mux      *sync.Mutex
+	sessions map[target.SessionID]*Session
+}
+
+func newSessionMap() *sessionMap {
+	return &sessionMap{
+		mux:      &sync.Mutex{},
+		sessions: make(map[target.SessionID]*Session),
+	}
+}
+
+/*
+add returns the session with the specified ID, creating it if necessary.
+*/
+func (sessions *sessionMap) add(socket *Socket, sessionID target.SessionID) *Session {
+	sessions.mux.Lock()
+	defer sessions.mux.Unlock()
+	if session, ok := sessions.sessions[sessionID]; ok {
+		return session
+	}
+	session := &Session{
+		enabled:  newEnabledDomains(),
+		handlers: NewEventHandlerMap(),
+		id:       sessionID,
+		mux:      &sync.Mutex{},
+		socket:   socket,
+	}
+	session.Protocols = NewProtocols(session)
+	sessions.sessions[sessionID] = session
+	return session
+}
+
+func (sessions *sessionMap) get(sessionID target.SessionID) (*Session, bool) {
+	sessions.mux.Lock()
+	defer sessions.mux.Unlock()
+	session, ok := sessions.sessions[sessionID]
+	return session, ok
+}
+
+/*
+set adds a session under its current ID.
+*/
+func (sessions *sessionMap) set(session *Session) {
+	sessions.mux.Lock()
+	sessions.sessions[session.ID()] = session
+	sessions.mux.Unlock()
+}
+
+func (sessions *sessionMap) remove(sessionID target.SessionID) {
+	sessions.mux.Lock()
+	delete(sessions.sessions, sessionID)
+	sessions.mux.Unlock()
+}
@@ -0,0 +1,93 @@
+package socket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSocketSession(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "session-1" != session.ID() {
+		t.Errorf("Expected session-1, got %s", session.ID())
+	}
+	if session != socket.Session("session-1") {
+		t.Errorf("Expected the attached session to be returned")
+	}
+	var _ Socketer = session
+
+	result := <-session.Page().Navigate(&page.NavigateParams{URL: "about:blank"})
+	if nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	commands := server.Commands()
+	attach := map[string]interface{}{}
+	json.Unmarshal(commands[0].Params, &attach)
+	if true != attach["flatten"] || "" != commands[0].SessionID {
+		t.Errorf("Expected a flat browser level attach, got %s '%s'", commands[0].Params, commands[0].SessionID)
+	}
+	if "Page.navigate" != commands[1].Method || "session-1" != commands[1].SessionID {
+		t.Errorf("Expected Page.navigate for session-1, got %s '%s'", commands[1].Method, commands[1].SessionID)
+	}
+
+	sessionEvents := make(chan bool, 1)
+	session.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		sessionEvents <- true
+	})
+	socketEvents := make(chan bool, 1)
+	socket.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		socketEvents <- true
+	})
+	server.EmitSession("session-1", "Page.loadEventFired", map[string]int{"timestamp": 1})
+	select {
+	case <-sessionEvents:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the session event to be delivered")
+	}
+	select {
+	case <-socketEvents:
+		t.Errorf("Expected the session event not to be delivered to the socket")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	server.Emit("Target.detachedFromTarget", map[string]string{"sessionId": "session-1"})
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := socket.sessions.get("session-1"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the detached session to be removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSocketSessionStop(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	session := socket.Session("session-1")
+	session.Stop()
+	if _, ok := socket.sessions.get("session-1"); ok {
+		t.Errorf("Expected the session to be removed")
+	}
+	commands := server.Commands()
+	if 1 != len(commands) || "Target.detachFromTarget" != commands[0].Method || "" != commands[0].SessionID {
+		t.Errorf("Expected a browser level Target.detachFromTarget, got %v", commands)
+	}
+}
@@ -0,0 +1,136 @@
+package socket
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+shutdownPollInterval is how often Shutdown checks whether the pending commands
+and event handlers have finished.
+*/
+const shutdownPollInterval = 10 * time.Millisecond
+
+/*
+Shutdown gracefully stops the socket. Unlike Stop, which closes the connection
+regardless of what's in flight, Shutdown:
+
+ 1. Stops accepting new commands, which are responded to with a
+    SocketShutdown error.
+ 2. Waits for the responses to the pending commands.
+ 3. Waits for the running event handlers to return. Events received after
+    the pending commands have been responded to aren't delivered.
+ 4. Closes the websocket connection.
+
+If the context is done before the pending commands are responded to, they're
+responded to with a SocketShutdown error and Shutdown stops waiting for the
+event handlers. The returned error aggregates everything that didn't shut
+down cleanly:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sock.Shutdown(ctx); nil != err {
+		...
+	}
+
+A socket can only be shut down once.
+*/
+func (socket *Socket) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&socket.shutdown, 0, 1) {
+		return errs.New(codes.SocketShutdown, fmt.Sprintf("socket #%d is already shut down", socket.socketID))
+	}
+	socket.logger.Info("Socket shutting down gracefully", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
+
+	var err error
+	fail := func(e error, msg string) {
+		if nil == err {
+			err = errs.Wrap(e, codes.SocketShutdown, msg)
+		} else {
+			err = err.(errs.Err).With(e, msg)
+		}
+	}
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	// Wait for the responses to the pending commands.
+	drained := true
+	for drained && 0 < socket.commands.Len() {
+		select {
+		case <-ctx.Done():
+			drained = false
+			if abandoned := socket.abandonPending(); abandoned > 0 {
+				fail(ctx.Err(), fmt.Sprintf("%d pending commands did not receive a response", abandoned))
+			}
+		case <-ticker.C:
+		}
+	}
+
+	// Stop delivering events and wait for the running handlers.
+	atomic.StoreInt32(&socket.shutdown, 2)
+	for drained && 0 < atomic.LoadInt32(&socket.dispatching) {
+		select {
+		case <-ctx.Done():
+			drained = false
+			fail(ctx.Err(), fmt.Sprintf("%d event handlers did not return", atomic.LoadInt32(&socket.dispatching)))
+		case <-ticker.C:
+		}
+	}
+
+	// Close the connection, which ends the read loop.
+	listening := socket.listening
+	socket.listening = false
+	socket.mux.Lock()
+	conn := socket.conn
+	socket.mux.Unlock()
+	if nil != conn {
+		if e := conn.Close(); nil != e {
+			fail(e, "could not close socket connection")
+		}
+	}
+	if listening {
+		select {
+		case <-socket.listenCh:
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+
+	socket.logger.Debug("socket shut down", logger.Fields{"socketID": socket.socketID})
+	return err
+}
+
+/*
+abandonPending responds to every pending command with a SocketShutdown error
+and returns the number of commands.
+*/
+func (socket *Socket) abandonPending() int {
+	commands := socket.commands.PopAll()
+	for _, command := range commands {
+		response := shutdownResponse(command, "was abandoned when the socket was shut down")
+		socket.completed(command, response)
+		go command.Respond(response)
+	}
+	return len(commands)
+}
+
+/*
+shutdownResponse returns a SocketShutdown error response to a command.
+*/
+func shutdownResponse(command Commander, reason string) *Response {
+	err := errs.New(codes.SocketShutdown, fmt.Sprintf("command #%d '%s' %s", command.ID(), command.Method(), reason))
+	return &Response{
+		Error: &Error{
+			Code:    int(codes.SocketShutdown),
+			Data:    []byte(fmt.Sprintf("%q", err.Error())),
+			Message: err.Error(),
+		},
+		ID: command.ID(),
+	}
+}
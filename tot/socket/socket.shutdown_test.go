@@ -0,0 +1,142 @@
+package socket
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestShutdown(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.SetChaos(cdptest.Chaos{Latency: 200 * time.Millisecond})
+	socket := New(server.URL())
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	pending := make(chan *Response, 1)
+	go func() {
+		pending <- <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	}()
+	for 0 == socket.commands.Len() {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := socket.Shutdown(ctx); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if response := <-pending; nil != response.Err() {
+		t.Errorf("Expected the pending command to be responded to, got error: %v", response.Err())
+	}
+
+	response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	if nil == response.Error || int(codes.SocketShutdown) != response.Error.Code {
+		t.Errorf("Expected a SocketShutdown error, got %v", response.Error)
+	}
+	if err := socket.Shutdown(ctx); nil == err {
+		t.Errorf("Expected an error shutting down twice")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.SetChaos(cdptest.Chaos{DropRate: 1})
+	socket := New(server.URL())
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	pending := socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := socket.Shutdown(ctx); nil == err {
+		t.Errorf("Expected an error for the abandoned command")
+	}
+	select {
+	case response := <-pending:
+		if nil == response.Error || int(codes.SocketShutdown) != response.Error.Code {
+			t.Errorf("Expected a SocketShutdown error, got %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the abandoned command to be responded to")
+	}
+}
+
+func TestShutdownWaitsForHandlers(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	started := make(chan bool, 1)
+	var handled int32
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {
+		started <- true
+		time.Sleep(200 * time.Millisecond)
+		atomic.StoreInt32(&handled, 1)
+	}))
+	server.Emit("Page.loadEventFired", map[string]interface{}{"timestamp": 1})
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the event to be handled")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := socket.Shutdown(ctx); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 1 != atomic.LoadInt32(&handled) {
+		t.Errorf("Expected Shutdown to wait for the event handler")
+	}
+}
+
+/*
+shutdownOnSet is a command map that starts a shutdown while a command is stored,
+after the socket checked that it isn't shut down.
+*/
+type shutdownOnSet struct {
+	CommandMapper
+	socket *Socket
+}
+
+func (commands shutdownOnSet) Set(command Commander) {
+	atomic.StoreInt32(&commands.socket.shutdown, 1)
+	commands.CommandMapper.Set(command)
+}
+
+func TestShutdownWhileSending(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	socket.commands = shutdownOnSet{CommandMapper: socket.commands, socket: socket}
+
+	select {
+	case response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil)):
+		if nil == response.Error || int(codes.SocketShutdown) != response.Error.Code {
+			t.Errorf("Expected a SocketShutdown error, got %v", response.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the command to be responded to")
+	}
+	if 0 != socket.commands.Len() {
+		t.Errorf("Expected the command not to be pending, got %d", socket.commands.Len())
+	}
+	if 0 != len(server.Commands()) {
+		t.Errorf("Expected the command not to be sent")
+	}
+}
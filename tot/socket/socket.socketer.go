@@ -1,77 +1,55 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
+	"github.com/gorilla/websocket"
 	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/target"
 )
 
 /*
 New returns a pointer to a websocket struct that implements Socketer interface
-listening to the specified URL.
+listening to the specified URL. Any options are applied before the socket
+starts listening.
 */
-func New(url *url.URL) *Socket {
+func New(url *url.URL, options ...Option) *Socket {
 	socket := &Socket{
-		commandIDMux: &sync.Mutex{},
-		commands:     NewCommandMap(),
-		errCh:        make(chan error, 3),
-		handlers:     NewEventHandlerMap(),
-		mux:          &sync.Mutex{},
-		newSocket:    NewWebsocket,
-		socketID:     NextSocketID(),
-		url:          url,
+		callbacks: newReadLoopCallbacks(),
+		commands:  NewCommandMap(),
+		enabled:   newEnabledDomains(),
+		errCh:     make(chan error, 3),
+		expired:   newExpiredCommands(),
+		handlers:  NewEventHandlerMap(),
+		logger:    logger.Default(),
+		mux:       &sync.Mutex{},
+		newSocket: NewWebsocket,
+		sessions:  newSessionMap(),
+		socketID:  NextSocketID(),
+		url:       url,
+		taps:      newTapList(),
+		versions:  newVersionDiscovery(),
+		writeMux:  &sync.Mutex{},
 	}
 
 	// Init the protocol interfaces for the API.
-	socket.accessibility = &AccessibilityProtocol{Socket: socket}
-	socket.animation = &AnimationProtocol{Socket: socket}
-	socket.applicationCache = &ApplicationCacheProtocol{Socket: socket}
-	socket.audits = &AuditsProtocol{Socket: socket}
-	socket.browser = &BrowserProtocol{Socket: socket}
-	socket.cacheStorage = &CacheStorageProtocol{Socket: socket}
-	socket.console = &ConsoleProtocol{Socket: socket}
-	socket.css = &CSSProtocol{Socket: socket}
-	socket.database = &DatabaseProtocol{Socket: socket}
-	socket.debugger = &DebuggerProtocol{Socket: socket}
-	socket.deviceOrientation = &DeviceOrientationProtocol{Socket: socket}
-	socket.domDebugger = &DOMDebuggerProtocol{Socket: socket}
-	socket.domSnapshot = &DOMSnapshotProtocol{Socket: socket}
-	socket.domStorage = &DOMStorageProtocol{Socket: socket}
-	socket.dom = &DOMProtocol{Socket: socket}
-	socket.emulation = &EmulationProtocol{Socket: socket}
-	socket.headlessExperimental = &HeadlessExperimentalProtocol{Socket: socket}
-	socket.heapProfiler = &HeapProfilerProtocol{Socket: socket}
-	socket.indexedDB = &IndexedDBProtocol{Socket: socket}
-	socket.input = &InputProtocol{Socket: socket}
-	socket.io = &IOProtocol{Socket: socket}
-	socket.layerTree = &LayerTreeProtocol{Socket: socket}
-	socket.log = &LogProtocol{Socket: socket}
-	socket.memory = &MemoryProtocol{Socket: socket}
-	socket.network = &NetworkProtocol{Socket: socket}
-	socket.overlay = &OverlayProtocol{Socket: socket}
-	socket.page = &PageProtocol{Socket: socket}
-	socket.performance = &PerformanceProtocol{Socket: socket}
-	socket.profiler = &ProfilerProtocol{Socket: socket}
-	socket.runtime = &RuntimeProtocol{Socket: socket}
-	socket.schema = &SchemaProtocol{Socket: socket}
-	socket.security = &SecurityProtocol{Socket: socket}
-	socket.serviceWorker = &ServiceWorkerProtocol{Socket: socket}
-	socket.storage = &StorageProtocol{Socket: socket}
-	socket.systemInfo = &SystemInfoProtocol{Socket: socket}
-	socket.target = &TargetProtocol{Socket: socket}
-	socket.tethering = &TetheringProtocol{Socket: socket}
-	socket.tracing = &TracingProtocol{Socket: socket}
+	socket.Protocols = NewProtocols(socket)
+
+	for _, option := range options {
+		option(socket)
+	}
 
 	socket.Listen()
 
-	log.WithFields(log.Fields{"socketID": socket.socketID, "url": socket.url.String()}).
-		Info("New socket connection listening")
+	socket.logger.Info("New socket connection listening", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
 
 	return socket
 }
@@ -95,70 +73,87 @@ func NextSocketID() int {
 Socket is a Socketer implementation.
 */
 type Socket struct {
-	commandID    int
-	commandIDMux *sync.Mutex
-	commands     CommandMapper
-	conn         WebSocketer
-	connected    bool
-	errCh        chan error
-	handlers     EventHandlerMapper
-	listenCh     chan bool
-	listening    bool
-	mux          *sync.Mutex
-	newSocket    func(socketURL *url.URL) (WebSocketer, error)
-	socketID     int
-	url          *url.URL
+	// commandID is updated atomically and must be the first field to be
+	// 64-bit aligned on 32-bit platforms.
+	commandID           int64
+	callbacks           *readLoopCallbacks
+	commandTimeout      time.Duration
+	commands            CommandMapper
+	conn                WebSocketer
+	connected           bool
+	dialer              *websocket.Dialer
+	dispatching         int32
+	errCh               chan error
+	detachHook          func(detachment *Detachment)
+	detachment          *Detachment
+	enabled             *enabledDomains
+	errorHook           func(err error)
+	eventPooling        bool
+	expired             *expiredCommands
+	exposureGuard       func(params *target.ExposeDevToolsProtocolParams) error
+	frames              *frameHistory
+	handlerPanics       bool
+	handlers            EventHandlerMapper
+	largeEvents         map[string]bool
+	lateResponseHandler func(command Commander, response *Response, late time.Duration)
+	listenCh            chan bool
+	listening           bool
+	logger              logger.Logger
+	maxMessageSize      int
+	metrics             *socketMetrics
+	middleware          *middlewareChain
+	mux                 *sync.Mutex
+	newSocket           func(socketURL *url.URL) (WebSocketer, error)
+	ordered             *orderedDispatch
+	panicHook           func(p *HandlerPanic)
+	readLimit           int64
+	reattach            bool
+	reconnectPolicy     *ReconnectPolicy
+	retry               *commandRetries
+	sessions            *sessionMap
+	shims               *methodShims
+	shutdown            int32
+	socketID            int
+	taps                *tapList
+	tracing             *commandTracing
+	url                 *url.URL
+	versions            *versionDiscovery
+	writeMux            *sync.Mutex
+	writer              *commandWriter
 
 	// Protocol interfaces for the API.
-	accessibility        *AccessibilityProtocol
-	animation            *AnimationProtocol
-	applicationCache     *ApplicationCacheProtocol
-	audits               *AuditsProtocol
-	browser              *BrowserProtocol
-	cacheStorage         *CacheStorageProtocol
-	console              *ConsoleProtocol
-	css                  *CSSProtocol
-	database             *DatabaseProtocol
-	debugger             *DebuggerProtocol
-	deviceOrientation    *DeviceOrientationProtocol
-	domDebugger          *DOMDebuggerProtocol
-	domSnapshot          *DOMSnapshotProtocol
-	domStorage           *DOMStorageProtocol
-	dom                  *DOMProtocol
-	emulation            *EmulationProtocol
-	headlessExperimental *HeadlessExperimentalProtocol
-	heapProfiler         *HeapProfilerProtocol
-	indexedDB            *IndexedDBProtocol
-	input                *InputProtocol
-	io                   *IOProtocol
-	layerTree            *LayerTreeProtocol
-	log                  *LogProtocol
-	memory               *MemoryProtocol
-	network              *NetworkProtocol
-	overlay              *OverlayProtocol
-	page                 *PageProtocol
-	performance          *PerformanceProtocol
-	profiler             *ProfilerProtocol
-	runtime              *RuntimeProtocol
-	schema               *SchemaProtocol
-	security             *SecurityProtocol
-	serviceWorker        *ServiceWorkerProtocol
-	storage              *StorageProtocol
-	systemInfo           *SystemInfoProtocol
-	target               *TargetProtocol
-	tethering            *TetheringProtocol
-	tracing              *TracingProtocol
+	*Protocols
 }
 
 /*
 AddEventHandler adds an event handler to the stack of listeners for an event.
+The returned subscription removes the handler.
 
 AddEventHandler is a Socketer implementation.
 */
 func (socket *Socket) AddEventHandler(
 	handler EventHandler,
-) {
+) *Subscription {
+	socket.logger.Debug("Adding event handler", logger.Fields{"event": handler.Name(), "socketID": socket.socketID})
+	if socket.gated(handler) {
+		return NewSubscription(handler, func() error { return nil })
+	}
 	socket.handlers.Add(handler)
+	socket.checkEnabled(socket.enabled, handler, logger.Fields{"socketID": socket.socketID})
+	return NewSubscription(handler, func() error {
+		return socket.RemoveEventHandler(handler)
+	})
+}
+
+/*
+completed reports the response to a command to the metrics collector and
+tracer. It's called once for each command, before the command is responded to.
+*/
+func (socket *Socket) completed(command Commander, response *Response) {
+	socket.metrics.completed(command, response)
+	socket.tracing.end(command, response)
+	socket.retry.completed(command)
+	socket.shims.completed(command)
 }
 
 /*
@@ -167,10 +162,26 @@ CurCommandID returns the latest command ID.
 CurCommandID is a Socketer implementation.
 */
 func (socket *Socket) CurCommandID() int {
-	socket.commandIDMux.Lock()
-	id := socket.commandID
-	socket.commandIDMux.Unlock()
-	return id
+	return int(atomic.LoadInt64(&socket.commandID))
+}
+
+/*
+dispatch executes an event handler, recovering from any panic in the callback
+so that a single misbehaving handler can't take down the process. Recovered
+panics are logged with a stack trace and passed to the error and panic hooks.
+*/
+func (socket *Socket) dispatch(handler EventHandler, response *Response) {
+	socket.guard(fmt.Sprintf("'%s' event handler", handler.Name()), response.Method, func() {
+		handler.Handle(response)
+	})
+}
+
+/*
+EventPooling returns whether event structs delivered to high-frequency event
+callbacks are pooled and reused.
+*/
+func (socket *Socket) EventPooling() bool {
+	return socket.eventPooling
 }
 
 /*
@@ -179,18 +190,18 @@ connection.
 */
 func (socket *Socket) handleResponse(response *Response) {
 	// Log a message on error
-	if command, err := socket.commands.Get(response.ID); nil != err {
+	if command, err := socket.commands.Pop(response.ID); nil != err {
+		if socket.handleLateResponse(response) {
+			return
+		}
 		err = errs.Wrap(err, codes.SocketCmdHandlerNotFound, fmt.Sprintf("command #%d not found", response.ID))
-		log.WithFields(log.Fields{"error": err, "result": response.Result, "socketID": socket.socketID}).
-			Debug(response.Error)
+		socket.logger.Debug(err.Error(), logger.Fields{"error": response.Error, "result": response.Result, "socketID": socket.socketID})
 
-	} else {
-		log.WithFields(log.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID}).
-			Debug("executing handler")
-		command.Respond(response)
-		socket.commands.Delete(command.ID())
-		log.WithFields(log.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID, "url": socket.url.String()}).
-			Debug("Command complete")
+	} else if !socket.shimCommand(command, response) && !socket.retryCommand(command, response) {
+		socket.logger.Debug("executing handler", logger.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID})
+		socket.completed(command, response)
+		socket.respond(command, response)
+		socket.logger.Debug("Command complete", logger.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID, "url": socket.url.String()})
 	}
 }
 
@@ -201,22 +212,51 @@ connection.
 func (socket *Socket) handleEvent(
 	response *Response,
 ) {
-	log.WithFields(log.Fields{"event": response.Method, "socketID": socket.socketID, "url": socket.url.String()}).
-		Debug("handling event")
+	socket.logger.Debug("handling event", logger.Fields{"event": response.Method, "socketID": socket.socketID, "url": socket.url.String()})
+	socket.metrics.event(response.Method)
 
 	if response.Method == "Inspector.targetCrashed" {
-		log.WithFields(log.Fields{"socketID": socket.socketID}).
-			Error("Chrome has crashed!")
+		socket.logger.Error("Chrome has crashed!", logger.Fields{"socketID": socket.socketID})
+	} else if response.Method == "Inspector.targetReloadedAfterCrash" {
+		socket.logger.Info("Chrome has recovered from a crash", logger.Fields{"socketID": socket.socketID})
 	}
 
-	if handlers, err := socket.handlers.Get(response.Method); nil != err {
-		log.WithFields(log.Fields{"error": err, "socketID": socket.socketID}).
-			Debug(err)
+	eventHandlers := socket.handlers
+	if "" != response.SessionID {
+		session, ok := socket.sessions.get(target.SessionID(response.SessionID))
+		if !ok {
+			socket.logger.Debug("event for unknown session", logger.Fields{"event": response.Method, "sessionID": response.SessionID, "socketID": socket.socketID})
+			return
+		}
+		eventHandlers = session.handlers
+		if "Inspector.detached" == response.Method {
+			socket.inspectorDetached(response, session)
+		}
+	} else if "Target.detachedFromTarget" == response.Method {
+		socket.sessionDetached(response)
+	} else if "Inspector.detached" == response.Method {
+		socket.inspectorDetached(response, nil)
+	}
+
+	if 2 == atomic.LoadInt32(&socket.shutdown) {
+		socket.logger.Debug("event received after shutdown", logger.Fields{"event": response.Method, "socketID": socket.socketID})
+	} else if handlers, err := eventHandlers.Get(response.Method); nil != err {
+		socket.logger.Debug(err.Error(), logger.Fields{"error": err, "socketID": socket.socketID})
 	} else {
 		for a, event := range handlers {
-			log.WithFields(log.Fields{"event": response.Method, "handler#": a, "socketID": socket.socketID}).
-				Info("Executing handler")
-			go event.Handle(response)
+			socket.logger.Info("Executing handler", logger.Fields{"event": response.Method, "handler#": a, "socketID": socket.socketID})
+			atomic.AddInt32(&socket.dispatching, 1)
+			call := func(event EventHandler) func() {
+				return func() {
+					defer atomic.AddInt32(&socket.dispatching, -1)
+					socket.dispatch(event, response)
+				}
+			}(event)
+			if nil != socket.ordered {
+				socket.ordered.enqueue(response, call)
+			} else {
+				go call()
+			}
 		}
 	}
 }
@@ -227,8 +267,7 @@ handleUnknown receives all other socket responses.
 func (socket *Socket) handleUnknown(
 	response *Response,
 ) {
-	log.WithFields(log.Fields{"socketID": socket.socketID, "url": socket.url.String()}).
-		Debug("handling unexpected data")
+	socket.logger.Debug("handling unexpected data", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
 	var command Commander
 	var err error
 
@@ -238,14 +277,12 @@ func (socket *Socket) handleUnknown(
 		if nil != response.Error && 0 != response.Error.Code {
 			err = err.(errs.Err).With(response.Error, err.Error())
 		}
-		log.WithFields(log.Fields{"error": err, "result": response.Result, "socketID": socket.socketID}).
-			Debug(err)
+		socket.logger.Debug(err.Error(), logger.Fields{"error": err, "result": response.Result, "socketID": socket.socketID})
 		return
 	}
 
 	command.Respond(response)
-	log.WithFields(log.Fields{"commandID": command.ID(), "error": response.Error, "method": command.Method(), "socketID": socket.socketID}).
-		Debug("Unrecognised socket message")
+	socket.logger.Debug("Unrecognised socket message", logger.Fields{"commandID": command.ID(), "error": response.Error, "method": command.Method(), "socketID": socket.socketID})
 }
 
 /*
@@ -278,8 +315,7 @@ func (socket *Socket) listen(errCh chan error) {
 			if e, ok := r.(error); ok {
 				err = errs.Wrap(e, codes.SocketPanic, "recovered from panic in Socket.listen()")
 			}
-			log.WithFields(log.Fields{"error": err}).
-				Error(err)
+			socket.logger.Error(err.Error(), logger.Fields{"error": err})
 		}
 		errCh <- err
 	}()
@@ -293,35 +329,55 @@ func (socket *Socket) listen(errCh chan error) {
 
 	for {
 		response := &Response{}
-		err = socket.ReadJSON(&response)
+		read := false
+		if socket.maxMessageSize > 0 {
+			read, err = socket.readMessage(response)
+		}
+		if !read {
+			err = socket.ReadJSON(&response)
+		}
 		if nil != err {
-			err = errs.Wrap(err, codes.SocketReadFailed, fmt.Sprintf("socket #%d - socket read failed", socket.socketID))
-			log.WithFields(log.Fields{
+			err = socket.detachedError(errs.Wrap(err, codes.SocketReadFailed, fmt.Sprintf("socket #%d - socket read failed", socket.socketID)))
+			socket.logger.Error(err.Error(), logger.Fields{
 				"socketID": socket.socketID,
-			}).Error(err)
+			})
+
+			if policy := socket.reattachPolicy(); nil != policy && socket.listening {
+				if err = socket.reconnect(policy, err); nil == err {
+					continue
+				}
+				socket.logger.Error(err.Error(), logger.Fields{"error": err, "socketID": socket.socketID})
+				break
+			}
 		}
 		if 0 == response.ID &&
 			"" == response.Method &&
 			0 == len(response.Params) &&
 			0 == len(response.Result) {
-			log.WithFields(log.Fields{"socketID": socket.socketID}).
-				Error("nil response from socket")
+			socket.logger.Error("nil response from socket", logger.Fields{"socketID": socket.socketID})
 		}
 
-		if response.ID > 0 {
-			log.WithFields(log.Fields{"responseID": response.ID, "socketID": socket.socketID}).
-				Debug("sending to command handler")
+		var vetoErr error
+		socket.readLoopCallback(func() {
+			vetoErr = socket.messageMiddleware(response)
+		})
+		if nil != vetoErr {
+			socket.logger.Debug("message vetoed by middleware", logger.Fields{"error": vetoErr, "method": response.Method, "responseID": response.ID, "socketID": socket.socketID})
+
+		} else if nil != response.stream {
+			socket.handleOversized(response)
+
+		} else if response.ID > 0 {
+			socket.logger.Debug("sending to command handler", logger.Fields{"responseID": response.ID, "socketID": socket.socketID})
 			socket.handleResponse(response)
 
 		} else if "" != response.Method {
-			log.WithFields(log.Fields{"method": response.Method, "socketID": socket.socketID}).
-				Debug("sending to event handler")
+			socket.logger.Debug("sending to event handler", logger.Fields{"method": response.Method, "socketID": socket.socketID})
 			socket.handleEvent(response)
 
 		} else {
 			tmp, _ := json.Marshal(response)
-			log.WithFields(log.Fields{"data": string(tmp), "method": response.Method, "responseID": response.ID, "socketID": socket.socketID}).
-				Error("Unknown response from web socket")
+			socket.logger.Error("Unknown response from web socket", logger.Fields{"data": string(tmp), "method": response.Method, "responseID": response.ID, "socketID": socket.socketID})
 
 			if nil == response.Error {
 				response.Error = &Error{
@@ -332,8 +388,7 @@ func (socket *Socket) listen(errCh chan error) {
 		}
 
 		if !socket.listening {
-			log.WithFields(log.Fields{"socketID": socket.socketID, "url": socket.url.String()}).
-				Info("Socket shutting down")
+			socket.logger.Info("Socket shutting down", logger.Fields{"socketID": socket.socketID, "url": socket.url.String()})
 			go func() {
 				select {
 				case socket.listenCh <- true:
@@ -358,11 +413,7 @@ NextCommandID generates and returns the next command ID.
 NextCommandID is a Socketer implementation.
 */
 func (socket *Socket) NextCommandID() int {
-	socket.commandIDMux.Lock()
-	socket.commandID++
-	id := socket.commandID
-	socket.commandIDMux.Unlock()
-	return id
+	return int(atomic.AddInt64(&socket.commandID, 1))
 }
 
 /*
@@ -373,28 +424,35 @@ RemoveEventHandler is a Socketer implementation.
 func (socket *Socket) RemoveEventHandler(
 	handler EventHandler,
 ) error {
-	socket.handlers.Lock()
-	defer socket.handlers.Unlock()
+	return removeEventHandler(socket.handlers, handler, socket.logger, logger.Fields{"socketID": socket.socketID})
+}
 
-	handlers, err := socket.handlers.Get(handler.Name())
+/*
+removeEventHandler removes a handler from an event handler map. The fields
+identify the socket or session in log messages.
+*/
+func removeEventHandler(eventHandlers EventHandlerMapper, handler EventHandler, log logger.Logger, fields logger.Fields) error {
+	handlers, err := eventHandlers.Get(handler.Name())
 	if nil != err {
-		log.WithFields(log.Fields{"error": err, "socketID": socket.socketID}).
-			Warn("Could not remove handler")
+		fields["error"] = err
+		log.Warn("Could not remove handler", fields)
 		return errs.Wrap(err, 0, fmt.Sprintf("failed to remove event handler '%s'", handler.Name()))
 	}
 
 	for i, hndlr := range handlers {
 		if hndlr == handler {
-			handlers = append(handlers[:i], handlers[i+1:]...)
-			socket.handlers.Set(handler.Name(), handlers)
-			log.WithFields(log.Fields{"handler": handler.Name(), "handlerID": i, "socketID": socket.socketID}).
-				Info("Removed event handler")
+			if nil != eventHandlers.Remove(handler) {
+				// Removed concurrently.
+				break
+			}
+			fields["handler"] = handler.Name()
+			fields["handlerID"] = i
+			log.Info("Removed event handler", fields)
 			return nil
 		}
 	}
 
-	log.WithFields(log.Fields{"socketID": socket.socketID}).
-		Warn("handler not found")
+	log.Warn("handler not found", fields)
 	return nil
 }
 
@@ -406,36 +464,117 @@ SendCommand is a Socketer implementation.
 Workflow:
 	1. The socket's command mutex is locked.
 	2. The command counter is incremented.
-	3. The payload is sent to the socket connection and the mutex is unlocked.
-	4. The command is stored using the generated ID.
+	3. The command is stored using the generated ID.
+	4. The payload is sent to the socket connection and the mutex is unlocked.
 	5. When the command has been executed and the socket responds,
 	socket.HandleCmd() is triggered from the command instance to generate the
 	response and the command unlocks itself.
 */
 func (socket *Socket) SendCommand(command Commander) chan *Response {
-	log.WithFields(log.Fields{"commandID": command.ID(), "method": command.Method(), "socketID": socket.socketID}).
-		Debug("sending command payload to socket")
-	go func() {
-		payload := &Payload{
-			ID:     command.ID(),
-			Method: command.Method(),
-			Params: command.Params(),
+	return socket.sendCommand(context.Background(), command, "")
+}
+
+/*
+sendCommand delivers a command payload to the websocket connection, addressed
+to a flattened target session if a session ID is specified. The context is
+traced with the command, and sendCommandContext cancels the command when the
+context is done or its deadline passes. The command's timeout, or the socket's
+command timeout, expires the command independently of the context.
+*/
+func (socket *Socket) sendCommand(ctx context.Context, command Commander, sessionID target.SessionID) chan *Response {
+	if socket.queueCommand(ctx, command, sessionID) && !socket.holdCommand(command, sessionID) {
+		socket.writeCommands(sessionID, command)
+	}
+	return command.Response()
+}
+
+/*
+queueCommand stores a command to wait for its response, starting its timeout
+and tracing. It returns false if the command can't be sent, after responding
+to it.
+*/
+func (socket *Socket) queueCommand(ctx context.Context, command Commander, sessionID target.SessionID) bool {
+	socket.logger.Debug("sending command payload to socket", logger.Fields{"commandID": command.ID(), "method": command.Method(), "sessionID": sessionID, "socketID": socket.socketID})
+
+	if 0 != atomic.LoadInt32(&socket.shutdown) {
+		go command.Respond(shutdownResponse(command, "was sent after the socket was shut down"))
+		return false
+	}
+	// The command is stored before it's sent so that a fast response, a
+	// timeout or a cancellation always finds it.
+	socket.commands.Set(command)
+	// A shutdown that started after the check above may have found no
+	// pending commands, it wouldn't wait for this one.
+	if 0 != atomic.LoadInt32(&socket.shutdown) {
+		if _, err := socket.commands.Pop(command.ID()); nil == err {
+			go command.Respond(shutdownResponse(command, "was sent after the socket was shut down"))
 		}
+		return false
+	}
+	socket.metrics.sent(command)
+	socket.tracing.start(ctx, command, sessionID)
+	socket.retry.sent(command, sessionID)
+	socket.shims.sent(command, sessionID)
+	if nil != socket.enabled && "" == sessionID {
+		socket.enabled.track(command)
+	}
+	timeout := socket.commandTimeout
+	if 0 != command.Timeout() {
+		timeout = command.Timeout()
+	}
+	if timeout > 0 {
+		time.AfterFunc(timeout, func() {
+			socket.expireCommand(command.ID(), timeout)
+		})
+	}
+	return true
+}
 
-		if err := socket.WriteJSON(payload); err != nil {
-			err = errs.Wrap(err, 0, "write failed: could not write data to websocket")
-			command.Respond(&Response{Error: &Error{
-				Code:    1,
-				Data:    []byte(fmt.Sprintf(`"%#v"`, err)),
-				Message: "Failed to send command payload to socket connection",
-			}})
+/*
+writeCommand passes a command payload through the middleware and writes it to
+the websocket connection, responding to the command if it's vetoed or can't be
+written.
+*/
+func (socket *Socket) writeCommand(command Commander, sessionID target.SessionID) {
+	payload := &Payload{
+		ID:        command.ID(),
+		Method:    socket.shims.method(command),
+		Params:    command.Params(),
+		SessionID: string(sessionID),
+	}
+
+	if err := socket.commandMiddleware(payload); nil != err {
+		if _, popErr := socket.commands.Pop(command.ID()); nil != popErr {
 			return
 		}
+		err = errs.Wrap(err, codes.SocketCommandVetoed, fmt.Sprintf("command #%d '%s' was vetoed", command.ID(), command.Method()))
+		response := &Response{
+			Error: &Error{
+				Code:    int(codes.SocketCommandVetoed),
+				Data:    []byte(fmt.Sprintf("%q", err.Error())),
+				Message: err.Error(),
+			},
+			ID: command.ID(),
+		}
+		socket.completed(command, response)
+		command.Respond(response)
+		return
+	}
 
-		socket.commands.Set(command)
-	}()
-
-	return command.Response()
+	if err := socket.WriteJSON(payload); err != nil {
+		err = errs.Wrap(err, 0, "write failed: could not write data to websocket")
+		if _, popErr := socket.commands.Pop(command.ID()); nil != popErr {
+			// The command has already been responded to.
+			return
+		}
+		response := &Response{Error: &Error{
+			Code:    1,
+			Data:    []byte(fmt.Sprintf(`"%#v"`, err)),
+			Message: "Failed to send command payload to socket connection",
+		}}
+		socket.completed(command, response)
+		command.Respond(response)
+	}
 }
 
 /*
@@ -450,10 +589,11 @@ func (socket *Socket) Stop() {
 		select {
 		case <-socket.listenCh:
 		case <-time.After(1 * time.Second):
-			socket.conn.Close()
+			if nil != socket.conn {
+				socket.conn.Close()
+			}
 		}
-		log.WithFields(log.Fields{"socketID": socket.socketID}).
-			Debug("socket stopped")
+		socket.logger.Debug("socket stopped", logger.Fields{"socketID": socket.socketID})
 	}
 }
 
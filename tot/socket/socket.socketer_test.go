@@ -179,6 +179,53 @@ func TestRemoveEventHandler(t *testing.T) {
 //	}
 //}
 
+func TestEventHandlerPanic(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestEventHandlerPanic")
+	mockSocket := NewMock(socketURL)
+	errCh := make(chan error, 1)
+	WithErrorHook(func(err error) {
+		errCh <- err
+	})(mockSocket)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultCh := make(chan bool)
+	mockSocket.AddEventHandler(NewEventHandler("Some.event", func(response *Response) {
+		panic("handler panic")
+	}))
+	mockSocket.AddEventHandler(NewEventHandler("Some.event", func(response *Response) {
+		resultCh <- true
+	}))
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Some.event",
+		Params: []byte(`{}`),
+	})
+
+	select {
+	case err := <-errCh:
+		if nil == err {
+			t.Errorf("Expected error, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the panic to be reported to the error hook")
+	}
+	select {
+	case <-resultCh:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the second handler to be executed")
+	}
+
+	WithHandlerPanics()(mockSocket)
+	defer func() {
+		if r := recover(); "handler panic" != r {
+			t.Errorf("Expected the panic to be re-raised, got %v", r)
+		}
+	}()
+	mockSocket.dispatch(NewEventHandler("Some.event", func(response *Response) {
+		panic("handler panic")
+	}), &Response{Method: "Some.event"})
+}
+
 func TestURL(t *testing.T) {
 	socketURL, _ := url.Parse("https://test:9222/TestURL")
 	mockSocket := NewMock(socketURL)
@@ -0,0 +1,334 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+messageReader is implemented by websocket connections that can read a message
+as a stream.
+*/
+type messageReader interface {
+	NextReader() (io.Reader, error)
+}
+
+/*
+NextReader returns a reader for the next websocket message.
+*/
+func (socket *ChromeWebSocket) NextReader() (io.Reader, error) {
+	if nil == socket.conn {
+		return nil, errs.New(codes.WebsocketNotConnected, "not connected")
+	}
+	_, reader, err := socket.conn.NextReader()
+	return reader, err
+}
+
+/*
+WithMaxMessageSize limits the size of the messages read into memory, in bytes.
+A larger message, such as a giant Runtime.evaluate result or a screenshot, is
+streamed instead: its result or params are spooled to a temporary file rather
+than buffered. A command created with WithStreamedResult receives the spooled
+result as a stream, any other command receives a SocketMessageTooLarge error
+and an oversized event is dropped and reported to the error hook, unless it's
+delivered with WithLargeEvents. Zero, the default, reads every message into
+memory.
+
+Messages that are streamed aren't passed to taps or the frame history.
+*/
+func WithMaxMessageSize(size int) Option {
+	return func(socket *Socket) {
+		socket.maxMessageSize = size
+	}
+}
+
+/*
+WithStreamedResult streams the result of a command if it's larger than the
+socket's maximum message size. The response's Result is then empty and the
+result JSON is read from Stream, which must be closed:
+
+	command := socket.NewCommand(sock, "Page.captureScreenshot", params, socket.WithStreamedResult())
+	response := <-sock.SendCommand(command)
+	if stream := response.Stream(); nil != stream {
+		defer stream.Close()
+		decoder := json.NewDecoder(stream)
+		...
+	}
+*/
+func WithStreamedResult() CommandOption {
+	return func(command *Command) {
+		command.streamResult = true
+	}
+}
+
+/*
+StreamsResult returns whether the command's result is streamed if it's too
+large to buffer.
+*/
+func (cmd *Command) StreamsResult() bool {
+	return cmd.streamResult
+}
+
+/*
+Stream returns the result or params of a message that was too large to buffer,
+as JSON. It's nil for messages that were buffered. The stream must be closed,
+which removes the spooled data.
+*/
+func (response *Response) Stream() io.ReadCloser {
+	if nil == response.stream {
+		return nil
+	}
+	return response.stream
+}
+
+/*
+spooledValue is a JSON value spooled to a temporary file.
+*/
+type spooledValue struct {
+	*os.File
+}
+
+/*
+Close closes and removes the file.
+*/
+func (value *spooledValue) Close() error {
+	err := value.File.Close()
+	os.Remove(value.File.Name())
+	return err
+}
+
+/*
+readMessage reads the next message into the response, streaming it if it's
+larger than the maximum message size. It returns false if the connection
+doesn't support streaming, in which case nothing was read.
+*/
+func (socket *Socket) readMessage(response *Response) (bool, error) {
+	if err := socket.Connect(); nil != err {
+		return true, errs.Wrap(err, codes.SocketNotConnected, "not connected")
+	}
+	conn, ok := socket.conn.(messageReader)
+	if !ok {
+		return false, nil
+	}
+	reader, err := conn.NextReader()
+	if nil != err {
+		return true, socket.readError(err, "socket read failed")
+	}
+
+	buffered := bufio.NewReader(reader)
+	head, err := ioutil.ReadAll(io.LimitReader(buffered, int64(socket.maxMessageSize)+1))
+	if nil != err {
+		return true, socket.readError(err, "socket read failed")
+	}
+	if len(head) <= socket.maxMessageSize {
+		if socket.tapping() {
+			socket.tap(Inbound, head)
+		}
+		if err = json.Unmarshal(head, response); nil != err {
+			return true, errs.Wrap(err, codes.SocketReadFailed, "socket read failed")
+		}
+		return true, nil
+	}
+
+	socket.logger.Debug("streaming oversized message", logger.Fields{"maxMessageSize": socket.maxMessageSize, "socketID": socket.socketID})
+	message := bufio.NewReader(io.MultiReader(bytes.NewReader(head), buffered))
+	if err = spoolMessage(message, response); nil != err {
+		return true, socket.readError(err, "could not stream an oversized message")
+	}
+	// Discard anything following the message object.
+	io.Copy(ioutil.Discard, message)
+	return true, nil
+}
+
+/*
+handleOversized routes a message that was streamed: the response to a command
+that streams its result is delivered, any other command is responded to with a
+SocketMessageTooLarge error and events are dropped.
+*/
+func (socket *Socket) handleOversized(response *Response) {
+	if response.ID <= 0 && socket.largeEvents[response.Method] {
+		socket.handleLargeEvent(response)
+		return
+	}
+	if response.ID <= 0 {
+		response.stream.Close()
+		err := errs.New(codes.SocketMessageTooLarge, fmt.Sprintf("event '%s' exceeded the maximum message size of %d bytes and was dropped", response.Method, socket.maxMessageSize))
+		socket.logger.Warn(err.Error(), logger.Fields{"event": response.Method, "socketID": socket.socketID})
+		if nil != socket.errorHook {
+			socket.errorHook(err)
+		}
+		return
+	}
+
+	command, err := socket.commands.Get(response.ID)
+	if nil != err {
+		response.stream.Close()
+		socket.handleResponse(response)
+		return
+	}
+	if streamer, ok := command.(interface{ StreamsResult() bool }); ok && streamer.StreamsResult() {
+		socket.handleResponse(response)
+		return
+	}
+
+	response.stream.Close()
+	response.stream = nil
+	err = errs.New(codes.SocketMessageTooLarge, fmt.Sprintf("the result of command #%d '%s' exceeded the maximum message size of %d bytes", command.ID(), command.Method(), socket.maxMessageSize))
+	response.Error = &Error{
+		Code:    int(codes.SocketMessageTooLarge),
+		Data:    []byte(fmt.Sprintf("%q", err.Error())),
+		Message: err.Error(),
+	}
+	socket.handleResponse(response)
+}
+
+/*
+spoolMessage parses a message object, spooling its result or params to a
+temporary file and decoding the other, small, fields into the response.
+*/
+func spoolMessage(reader *bufio.Reader, response *Response) (err error) {
+	fields := map[string]json.RawMessage{}
+	defer func() {
+		if nil != err && nil != response.stream {
+			response.stream.Close()
+			response.stream = nil
+		}
+	}()
+
+	if err = expectByte(reader, '{'); nil != err {
+		return err
+	}
+	for {
+		b, err := nextByte(reader)
+		if nil != err {
+			return err
+		}
+		if '}' == b {
+			break
+		}
+		if ',' == b {
+			continue
+		}
+		reader.UnreadByte()
+
+		key := bytes.Buffer{}
+		if err = copyValue(&key, reader); nil != err {
+			return err
+		}
+		var name string
+		if err = json.Unmarshal(key.Bytes(), &name); nil != err {
+			return err
+		}
+		if err = expectByte(reader, ':'); nil != err {
+			return err
+		}
+		if _, err = nextByte(reader); nil != err {
+			return err
+		}
+		reader.UnreadByte()
+
+		if ("result" == name || "params" == name) && nil == response.stream {
+			file, err := ioutil.TempFile("", "go-chrome-message-")
+			if nil != err {
+				return err
+			}
+			response.stream = &spooledValue{file}
+			if err = copyValue(file, reader); nil != err {
+				return err
+			}
+			if _, err = file.Seek(0, io.SeekStart); nil != err {
+				return err
+			}
+			continue
+		}
+		value := bytes.Buffer{}
+		if err = copyValue(&value, reader); nil != err {
+			return err
+		}
+		fields[name] = json.RawMessage(value.Bytes())
+	}
+
+	envelope, _ := json.Marshal(fields)
+	return json.Unmarshal(envelope, response)
+}
+
+/*
+nextByte returns the next byte that isn't whitespace.
+*/
+func nextByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if nil != err {
+			return 0, err
+		}
+		if ' ' != b && '\t' != b && '\r' != b && '\n' != b {
+			return b, nil
+		}
+	}
+}
+
+/*
+expectByte reads the next byte that isn't whitespace and fails if it isn't the
+expected byte.
+*/
+func expectByte(reader *bufio.Reader, expected byte) error {
+	b, err := nextByte(reader)
+	if nil != err {
+		return err
+	}
+	if expected != b {
+		return fmt.Errorf("invalid message: expected '%c', found '%c'", expected, b)
+	}
+	return nil
+}
+
+/*
+copyValue copies one JSON value from the reader to the writer without
+buffering it, tracking nesting and strings to find its end.
+*/
+func copyValue(w io.Writer, reader *bufio.Reader) error {
+	out := bufio.NewWriter(w)
+	depth := 0
+	inString := false
+	escaped := false
+	for {
+		b, err := reader.ReadByte()
+		if nil != err {
+			return err
+		}
+		if !inString && 0 == depth && (',' == b || '}' == b || ']' == b || ' ' == b || '\t' == b || '\r' == b || '\n' == b) {
+			// The end of a scalar.
+			reader.UnreadByte()
+			return out.Flush()
+		}
+		out.WriteByte(b)
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && '\\' == b:
+			escaped = true
+		case '"' == b:
+			inString = !inString
+			if !inString && 0 == depth {
+				return out.Flush()
+			}
+		case inString:
+		case '{' == b || '[' == b:
+			depth++
+		case '}' == b || ']' == b:
+			depth--
+			if 0 == depth {
+				return out.Flush()
+			}
+		}
+	}
+}
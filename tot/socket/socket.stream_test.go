@@ -0,0 +1,105 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestSpoolMessage(t *testing.T) {
+	message := `{"id": 7, "result": {"data": "a \"quoted\" } ] value\\", "list": [1, {"x": [2]}]}, "sessionId": "s1"}`
+	response := &Response{}
+	if err := spoolMessage(bufio.NewReader(strings.NewReader(message)), response); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if 7 != response.ID || "s1" != response.SessionID || nil != response.Result {
+		t.Errorf("Unexpected response %+v", response)
+	}
+	stream := response.Stream()
+	if nil == stream {
+		t.Fatalf("Expected a stream, got nil")
+	}
+	name := response.stream.Name()
+	result, _ := ioutil.ReadAll(stream)
+	stream.Close()
+	if `{"data": "a \"quoted\" } ] value\\", "list": [1, {"x": [2]}]}` != string(result) {
+		t.Errorf("Unexpected result %s", result)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("Expected the spooled result to be removed")
+	}
+
+	response = &Response{}
+	if err := spoolMessage(bufio.NewReader(strings.NewReader(`{"method": "Some.event", "params": 12.5e3}`)), response); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	result, _ = ioutil.ReadAll(response.Stream())
+	response.Stream().Close()
+	if "Some.event" != response.Method || "12.5e3" != string(result) {
+		t.Errorf("Unexpected event %+v %s", response, result)
+	}
+
+	if err := spoolMessage(bufio.NewReader(strings.NewReader(`{"id": 1, "result": {"truncated`)), &Response{}); nil == err {
+		t.Errorf("Expected an error for a truncated message")
+	}
+}
+
+func TestMaxMessageSize(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	large := map[string]interface{}{"data": strings.Repeat("x", 4096)}
+	server.Respond("Page.captureScreenshot", large)
+	server.Respond("Some.method", map[string]interface{}{"ok": true})
+	errCh := make(chan error, 1)
+	socket := New(server.URL(), WithMaxMessageSize(1024), WithErrorHook(func(err error) {
+		errCh <- err
+	}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+
+	response := <-socket.SendCommand(NewCommand(socket, "Page.captureScreenshot", nil, WithStreamedResult()))
+	if nil != response.Err() {
+		t.Fatalf("Expected nil, got error: %v", response.Err())
+	}
+	stream := response.Stream()
+	if nil == stream {
+		t.Fatalf("Expected a stream, got nil")
+	}
+	result := map[string]string{}
+	if err := json.NewDecoder(stream).Decode(&result); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	stream.Close()
+	if 4096 != len(result["data"]) {
+		t.Errorf("Expected the streamed result, got %d bytes", len(result["data"]))
+	}
+
+	response = <-socket.SendCommand(NewCommand(socket, "Page.captureScreenshot", nil))
+	if nil == response.Error || int(codes.SocketMessageTooLarge) != response.Error.Code {
+		t.Errorf("Expected a SocketMessageTooLarge error, got %v", response.Error)
+	}
+
+	response = <-socket.SendCommand(NewCommand(socket, "Some.method", nil, WithStreamedResult()))
+	if nil != response.Stream() || `{"ok":true}` != string(response.Result) {
+		t.Errorf("Expected a buffered result, got %s", response.Result)
+	}
+
+	server.Emit("Some.event", large)
+	select {
+	case err := <-errCh:
+		if !strings.Contains(err.Error(), "Some.event") {
+			t.Errorf("Expected the oversized event to be reported, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the oversized event to be reported")
+	}
+}
@@ -0,0 +1,107 @@
+package socket
+
+import (
+	"sync"
+)
+
+/*
+NewSubscription returns a subscription for an event handler that was added
+with AddEventHandler. The remove function removes the handler.
+*/
+func NewSubscription(handler EventHandler, remove func() error) *Subscription {
+	return &Subscription{
+		handler: handler,
+		once:    &sync.Once{},
+		remove:  remove,
+	}
+}
+
+/*
+Subscription is an added event handler. Subscriptions are returned by
+AddEventHandler and the protocol On* functions so that short-lived handlers can
+be removed:
+
+	sub := tab.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		...
+	})
+	defer sub.Remove()
+*/
+type Subscription struct {
+	err     error
+	handler EventHandler
+	once    *sync.Once
+	remove  func() error
+}
+
+/*
+Handler returns the subscribed event handler.
+*/
+func (sub *Subscription) Handler() EventHandler {
+	return sub.handler
+}
+
+/*
+Remove removes the event handler. Only the first call removes the handler, later
+calls return the same result.
+*/
+func (sub *Subscription) Remove() error {
+	sub.once.Do(func() {
+		sub.err = sub.remove()
+	})
+	return sub.err
+}
+
+/*
+Once adds an event handler that is removed after it handles the first event,
+for short-lived waits:
+
+	socket.Once(tab, socket.NewEventHandler("Page.loadEventFired", func(response *socket.Response) {
+		...
+	}))
+
+The returned subscription can be used to remove the handler before an event is
+received.
+*/
+func Once(subscriber EventSubscriber, handler EventHandler) *Subscription {
+	once := &onceHandler{
+		handler: handler,
+		once:    &sync.Once{},
+		ready:   make(chan struct{}),
+	}
+	once.sub = subscriber.AddEventHandler(once)
+	close(once.ready)
+	return once.sub
+}
+
+/*
+onceHandler removes itself before passing the first event to its handler.
+*/
+type onceHandler struct {
+	handler EventHandler
+	once    *sync.Once
+	ready   chan struct{}
+	sub     *Subscription
+}
+
+/*
+Handle removes the handler and executes the wrapped handler, only the first
+event is handled.
+
+Handle is an EventHandler implementation.
+*/
+func (handler *onceHandler) Handle(response *Response) {
+	handler.once.Do(func() {
+		<-handler.ready
+		handler.sub.Remove()
+		handler.handler.Handle(response)
+	})
+}
+
+/*
+Name returns the name of the event the handler is assigned to.
+
+Name is an EventHandler implementation.
+*/
+func (handler *onceHandler) Name() string {
+	return handler.handler.Name()
+}
@@ -0,0 +1,75 @@
+package socket
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionRemove(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestSubscriptionRemove")
+	mockSocket := NewMock(socketURL)
+
+	handler := NewEventHandler("Some.event", func(response *Response) {})
+	sub := mockSocket.AddEventHandler(handler)
+	if handler != sub.Handler() {
+		t.Errorf("Expected the subscribed handler")
+	}
+	if handlers, _ := mockSocket.handlers.Get("Some.event"); 1 != len(handlers) {
+		t.Fatalf("Expected 1 handler, got %d", len(handlers))
+	}
+	if err := sub.Remove(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if handlers, _ := mockSocket.handlers.Get("Some.event"); 0 != len(handlers) {
+		t.Errorf("Expected the handler to be removed, got %d handlers", len(handlers))
+	}
+	if err := sub.Remove(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	socketURL, _ := url.Parse("https://test:9222/TestOnce")
+	mockSocket := NewMock(socketURL)
+	mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	resultCh := make(chan string, 2)
+	Once(mockSocket, NewEventHandler("Some.event", func(response *Response) {
+		resultCh <- string(response.Params)
+	}))
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Some.event",
+		Params: []byte(`{"a":1}`),
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Some.event",
+		Params: []byte(`{"a":2}`),
+	})
+
+	select {
+	case params := <-resultCh:
+		if `{"a":1}` != params {
+			t.Errorf("Expected the first event, got %s", params)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the event to be handled")
+	}
+	select {
+	case params := <-resultCh:
+		t.Errorf("Expected a single event, got %s", params)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if handlers, _ := mockSocket.handlers.Get("Some.event"); 0 != len(handlers) {
+		t.Errorf("Expected the handler to be removed, got %d handlers", len(handlers))
+	}
+
+	sub := Once(mockSocket, NewEventHandler("Other.event", func(response *Response) {}))
+	if err := sub.Remove(); nil != err {
+		t.Errorf("Expected nil, got error: %v", err)
+	}
+	if handlers, _ := mockSocket.handlers.Get("Other.event"); 0 != len(handlers) {
+		t.Errorf("Expected the handler to be removed, got %d handlers", len(handlers))
+	}
+}
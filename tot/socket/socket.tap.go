@@ -0,0 +1,160 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+/*
+Direction is the direction of a websocket frame.
+*/
+type Direction int
+
+const (
+	// Inbound frames are received from Chrome.
+	Inbound Direction = iota
+	// Outbound frames are sent to Chrome.
+	Outbound
+)
+
+/*
+String implements Stringer.
+*/
+func (direction Direction) String() string {
+	switch direction {
+	case Inbound:
+		return "in"
+	case Outbound:
+		return "out"
+	}
+	return fmt.Sprintf("direction(%d)", int(direction))
+}
+
+/*
+Tap adds a function that receives every frame sent and received by the socket,
+for dumping the entire CDP conversation without registering handlers for each
+event. The returned function removes the tap:
+
+	file, _ := os.Create("cdp.jsonl")
+	defer file.Close()
+	remove := sock.Tap(socket.NewTapWriter(file))
+	defer remove()
+
+Taps are called synchronously from the goroutines reading and writing the
+websocket, so they must be fast and must not send commands.
+*/
+func (socket *Socket) Tap(tap func(direction Direction, raw []byte)) func() {
+	taps := socket.taps
+	taps.mux.Lock()
+	taps.next++
+	id := taps.next
+	taps.taps[id] = tap
+	taps.mux.Unlock()
+
+	once := &sync.Once{}
+	return func() {
+		once.Do(func() {
+			taps.mux.Lock()
+			delete(taps.taps, id)
+			taps.mux.Unlock()
+		})
+	}
+}
+
+/*
+newTapList returns an empty tap list.
+*/
+func newTapList() *tapList {
+	return &tapList{
+		mux:  &sync.RWMutex{},
+		taps: make(map[int]func(direction Direction, raw []byte)),
+	}
+}
+
+/*
+tapList is the taps added to a socket. A nil tapList has no taps.
+*/
+type tapList struct {
+	mux  *sync.RWMutex
+	next int
+	taps map[int]func(direction Direction, raw []byte)
+}
+
+/*
+call passes a frame to each tap, guarding against panics.
+*/
+func (taps *tapList) call(direction Direction, raw []byte, guard func(callback, method string, fn func()) bool) {
+	if nil == taps {
+		return
+	}
+	taps.mux.RLock()
+	defer taps.mux.RUnlock()
+	for _, tap := range taps.taps {
+		guard("tap", "", func() {
+			tap(direction, raw)
+		})
+	}
+}
+
+/*
+active returns whether there are any taps.
+*/
+func (taps *tapList) active() bool {
+	if nil == taps {
+		return false
+	}
+	taps.mux.RLock()
+	defer taps.mux.RUnlock()
+	return len(taps.taps) > 0
+}
+
+/*
+tapping returns whether frames need to be encoded for the frame history or the
+taps.
+*/
+func (socket *Socket) tapping() bool {
+	return nil != socket.frames || socket.taps.active()
+}
+
+/*
+tap passes a frame to the frame history and the taps.
+*/
+func (socket *Socket) tap(direction Direction, raw []byte) {
+	socket.frames.record(Outbound == direction, raw)
+	if Outbound == direction {
+		socket.taps.call(direction, raw, socket.guard)
+		return
+	}
+	socket.readLoopCallback(func() {
+		socket.taps.call(direction, raw, socket.guard)
+	})
+}
+
+/*
+NewTapWriter returns a tap that writes each frame to a writer as a line of
+JSON:
+
+	{"time":"2018-06-01T12:00:00.000000001Z","direction":"out","data":{"id":1,"method":"Page.enable"}}
+
+Writes are serialized, so the writer can be shared by the taps of several
+sockets.
+*/
+func NewTapWriter(w io.Writer) func(direction Direction, raw []byte) {
+	mux := &sync.Mutex{}
+	return func(direction Direction, raw []byte) {
+		line, err := json.Marshal(struct {
+			Time      time.Time       `json:"time"`
+			Direction string          `json:"direction"`
+			Data      json.RawMessage `json:"data"`
+		}{time.Now(), direction.String(), raw})
+		if nil != err {
+			return
+		}
+		mux.Lock()
+		w.Write(append(line, '\n'))
+		mux.Unlock()
+	}
+}
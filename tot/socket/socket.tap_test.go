@@ -0,0 +1,84 @@
+package socket
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTap(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL())
+	defer socket.Stop()
+
+	mux := &sync.Mutex{}
+	frames := map[Direction][]string{}
+	remove := socket.Tap(func(direction Direction, raw []byte) {
+		mux.Lock()
+		frames[direction] = append(frames[direction], string(raw))
+		mux.Unlock()
+	})
+
+	if response := <-socket.SendCommand(NewCommand(socket, "Some.method", map[string]string{"key": "value"})); nil != response.Err() {
+		t.Fatalf("Expected nil, got error: %v", response.Err())
+	}
+	events := make(chan bool, 1)
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {
+		events <- true
+	}))
+	server.Emit("Page.loadEventFired", map[string]interface{}{"timestamp": 1})
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the event to be handled")
+	}
+
+	mux.Lock()
+	if 1 != len(frames[Outbound]) || !strings.Contains(frames[Outbound][0], `"method":"Some.method"`) {
+		t.Errorf("Expected the command frame, got %v", frames[Outbound])
+	}
+	if 2 != len(frames[Inbound]) || !strings.Contains(frames[Inbound][1], "Page.loadEventFired") {
+		t.Errorf("Expected the response and event frames, got %v", frames[Inbound])
+	}
+	mux.Unlock()
+
+	remove()
+	remove()
+	<-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	mux.Lock()
+	if 1 != len(frames[Outbound]) {
+		t.Errorf("Expected the tap to be removed, got %d frames", len(frames[Outbound]))
+	}
+	mux.Unlock()
+}
+
+func TestNewTapWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tap := NewTapWriter(buf)
+	tap(Outbound, []byte(`{"id":1,"method":"Page.enable"}`))
+	tap(Inbound, []byte(`{"id":1,"result":{}}`))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if 2 != len(lines) {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	line := struct {
+		Direction string          `json:"direction"`
+		Data      json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(lines[0]), &line); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "out" != line.Direction || `{"id":1,"method":"Page.enable"}` != string(line.Data) {
+		t.Errorf("Unexpected line %s", lines[0])
+	}
+	if "in" != Inbound.String() || "direction(5)" != Direction(5).String() {
+		t.Errorf("Unexpected direction names")
+	}
+}
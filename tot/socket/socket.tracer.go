@@ -0,0 +1,100 @@
+package socket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+WithTracer traces every command sent through the socket, including commands
+sent to flattened target sessions. Commands sent with SendCommandContext or
+through a ContextSocket are traced with their context, so their spans are
+children of the caller's span.
+
+An OpenTelemetry tracer can be adapted with a few lines:
+
+	type otelTracer struct {
+		tracer trace.Tracer
+	}
+
+	func (t otelTracer) StartCommand(ctx context.Context, command socket.Commander, sessionID string) socket.CommandSpan {
+		_, span := t.tracer.Start(ctx, command.Method(), trace.WithSpanKind(trace.SpanKindClient))
+		span.SetAttributes(
+			attribute.String("cdp.method", command.Method()),
+			attribute.Int("cdp.command_id", command.ID()),
+			attribute.String("cdp.session_id", sessionID),
+		)
+		return otelSpan{span}
+	}
+
+	type otelSpan struct {
+		span trace.Span
+	}
+
+	func (s otelSpan) End(response *socket.Response) {
+		if err := response.Err(); nil != err {
+			if nil != response.Error {
+				s.span.SetAttributes(attribute.Int("cdp.error_code", response.Error.Code))
+			}
+			s.span.RecordError(err)
+			s.span.SetStatus(codes.Error, err.Error())
+		}
+		s.span.End()
+	}
+
+	sock := socket.New(socketURL, socket.WithTracer(otelTracer{otel.Tracer("go-chrome")}))
+*/
+func WithTracer(tracer Tracer) Option {
+	return func(socket *Socket) {
+		socket.tracing = &commandTracing{
+			mux:    &sync.Mutex{},
+			spans:  make(map[int]CommandSpan),
+			tracer: tracer,
+		}
+	}
+}
+
+/*
+commandTracing tracks the spans of the commands in flight for a tracer. A nil
+commandTracing traces nothing.
+*/
+type commandTracing struct {
+	mux    *sync.Mutex
+	spans  map[int]CommandSpan
+	tracer Tracer
+}
+
+/*
+start starts the span of a command.
+*/
+func (tracing *commandTracing) start(ctx context.Context, command Commander, sessionID target.SessionID) {
+	if nil == tracing {
+		return
+	}
+	span := tracing.tracer.StartCommand(ctx, command, string(sessionID))
+	if nil == span {
+		return
+	}
+	tracing.mux.Lock()
+	tracing.spans[command.ID()] = span
+	tracing.mux.Unlock()
+}
+
+/*
+end ends the span of a command. Commands without a span, or whose span has
+already ended, are ignored.
+*/
+func (tracing *commandTracing) end(command Commander, response *Response) {
+	if nil == tracing {
+		return
+	}
+	tracing.mux.Lock()
+	span, ok := tracing.spans[command.ID()]
+	delete(tracing.spans, command.ID())
+	tracing.mux.Unlock()
+	if ok {
+		span.End(response)
+	}
+}
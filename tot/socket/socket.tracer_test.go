@@ -0,0 +1,100 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+type traceKey struct{}
+
+type testSpan struct {
+	ctx       context.Context
+	errCode   int
+	ended     int
+	method    string
+	sessionID string
+}
+
+func (span *testSpan) End(response *Response) {
+	span.ended++
+	if nil != response.Error {
+		span.errCode = response.Error.Code
+	}
+}
+
+type testTracer struct {
+	mux   *sync.Mutex
+	spans []*testSpan
+}
+
+func (tracer *testTracer) StartCommand(ctx context.Context, command Commander, sessionID string) CommandSpan {
+	span := &testSpan{ctx: ctx, method: command.Method(), sessionID: sessionID}
+	tracer.mux.Lock()
+	tracer.spans = append(tracer.spans, span)
+	tracer.mux.Unlock()
+	return span
+}
+
+func TestTracer(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Target.attachToTarget", map[string]string{"sessionId": "session-1"})
+	server.Handle("Some.failure", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: -32000, Message: "failed"}
+	})
+
+	tracer := &testTracer{mux: &sync.Mutex{}}
+	socket := New(server.URL(),
+		WithTracer(tracer),
+		WithMiddleware(&Middleware{
+			Command: func(payload *Payload) error {
+				if "Browser.close" == payload.Method {
+					return context.Canceled
+				}
+				return nil
+			},
+		}),
+	)
+	defer socket.Stop()
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "parent")
+	if result := <-socket.WithContext(ctx).Page().Navigate(&page.NavigateParams{URL: "about:blank"}); nil != result.Err {
+		t.Fatalf("Expected nil, got error: %v", result.Err)
+	}
+	<-socket.SendCommand(NewCommand(socket, "Some.failure", nil))
+	<-socket.Browser().Close()
+	session, err := socket.AttachToTarget("target-1")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	<-NewContextSocket(ctx, session).Page().Reload(&page.ReloadParams{})
+
+	tracer.mux.Lock()
+	defer tracer.mux.Unlock()
+	if 5 != len(tracer.spans) {
+		t.Fatalf("Expected 5 spans, got %d", len(tracer.spans))
+	}
+	for _, span := range tracer.spans {
+		if 1 != span.ended {
+			t.Errorf("Expected the %s span to end once, ended %d times", span.method, span.ended)
+		}
+	}
+	if navigate := tracer.spans[0]; "Page.navigate" != navigate.method || "parent" != navigate.ctx.Value(traceKey{}) || 0 != navigate.errCode {
+		t.Errorf("Expected a successful Page.navigate span with the caller's context, got %v", navigate)
+	}
+	if -32000 != tracer.spans[1].errCode {
+		t.Errorf("Expected the error code, got %d", tracer.spans[1].errCode)
+	}
+	if int(codes.SocketCommandVetoed) != tracer.spans[2].errCode {
+		t.Errorf("Expected the vetoed command to end with an error, got %d", tracer.spans[2].errCode)
+	}
+	if reload := tracer.spans[4]; "session-1" != reload.sessionID || "parent" != reload.ctx.Value(traceKey{}) {
+		t.Errorf("Expected a session span with the caller's context, got %v", reload)
+	}
+}
@@ -0,0 +1,37 @@
+package socket
+
+import (
+	"net"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+)
+
+/*
+unixDialer returns a copy of a dialer that connects to the DevTools endpoint
+exposed on a unix domain socket, for URLs in the form
+
+	unix:///path/to/devtools.sock#/devtools/page/<id>
+
+where the path is the socket file and the fragment is the websocket path on the
+endpoint. Unix sockets are useful in sandboxes that forbid TCP ports. Proxies
+don't apply to unix sockets and a custom dial function set with WithDialer is
+replaced.
+*/
+func unixDialer(dialer *websocket.Dialer, socketURL *url.URL) (*websocket.Dialer, string) {
+	unix := *dialer
+	path := socketURL.Path
+	unix.NetDial = func(network, addr string) (net.Conn, error) {
+		return net.Dial("unix", path)
+	}
+	unix.Proxy = nil
+
+	endpoint := &url.URL{Scheme: "ws", Host: "localhost", Path: "/"}
+	if "" != socketURL.Fragment {
+		if fragment, err := url.Parse(socketURL.Fragment); nil == err {
+			endpoint.Path = fragment.Path
+			endpoint.RawQuery = fragment.RawQuery
+		}
+	}
+	return &unix, endpoint.String()
+}
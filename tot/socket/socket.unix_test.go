@@ -0,0 +1,71 @@
+package socket
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestUnixSocket(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	server.Respond("Some.method", map[string]interface{}{"ok": true})
+
+	dir, err := ioutil.TempDir("", "go-chrome-unix-")
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "devtools.sock")
+	listener, err := net.Listen("unix", path)
+	if nil != err {
+		t.Skipf("unix sockets are unavailable: %v", err)
+	}
+	defer listener.Close()
+	// Forward the unix socket to the test server.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			upstream, err := net.Dial("tcp", server.URL().Host)
+			if nil != err {
+				conn.Close()
+				return
+			}
+			go io.Copy(upstream, conn)
+			go io.Copy(conn, upstream)
+		}
+	}()
+
+	socketURL := &url.URL{Scheme: "unix", Path: path, Fragment: server.URL().Path}
+	socket := New(socketURL, WithProxy(&url.URL{Scheme: "http", Host: "127.0.0.1:1"}))
+	defer socket.Stop()
+	if err := server.WaitForConnection(5 * time.Second); nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	response := <-socket.SendCommand(NewCommand(socket, "Some.method", nil))
+	if nil != response.Error || `{"ok":true}` != string(response.Result) {
+		t.Errorf("Expected the response through the unix socket, got %s %v", response.Result, response.Error)
+	}
+}
+
+func TestUnixDialer(t *testing.T) {
+	socketURL, _ := url.Parse("unix:///run/chrome/devtools.sock#/devtools/page/1?x=y")
+	_, endpoint := unixDialer(newDialer(), socketURL)
+	if "ws://localhost/devtools/page/1?x=y" != endpoint {
+		t.Errorf("Expected 'ws://localhost/devtools/page/1?x=y', got '%s'", endpoint)
+	}
+	socketURL, _ = url.Parse("unix:///run/chrome/devtools.sock")
+	if _, endpoint = unixDialer(newDialer(), socketURL); "ws://localhost/" != endpoint {
+		t.Errorf("Expected 'ws://localhost/', got '%s'", endpoint)
+	}
+}
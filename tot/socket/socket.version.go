@@ -0,0 +1,209 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+)
+
+/*
+EventSince maps events to the Chrome release that first emitted them, for
+WithEventGating. The releases are approximate.
+*/
+var EventSince = map[string]int{
+	"Audits.issueAdded":                  84,
+	"Fetch.authRequired":                 74,
+	"Fetch.requestPaused":                74,
+	"Network.requestWillBeSentExtraInfo": 77,
+	"Network.responseReceivedExtraInfo":  77,
+	"Page.backForwardCacheNotUsed":       98,
+}
+
+/*
+BrowserVersion is the version information the browser reports on its
+/json/version endpoint.
+*/
+type BrowserVersion struct {
+	Browser              string `json:"Browser"`
+	ProtocolVersion      string `json:"Protocol-Version"`
+	UserAgent            string `json:"User-Agent"`
+	V8Version            string `json:"V8-Version"`
+	WebKitVersion        string `json:"WebKit-Version"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+/*
+Major returns the major version of the browser, for example 120 for
+'HeadlessChrome/120.0.6099.109', or 0 if it can't be parsed.
+*/
+func (version *BrowserVersion) Major() int {
+	parts := strings.SplitN(version.Browser, "/", 2)
+	if 2 != len(parts) {
+		return 0
+	}
+	major, _ := strconv.Atoi(strings.SplitN(parts[1], ".", 2)[0])
+	return major
+}
+
+/*
+WithVersionDiscovery queries the browser version from the /json/version
+endpoint as soon as the socket connects, so Version doesn't wait for it.
+*/
+func WithVersionDiscovery() Option {
+	return func(socket *Socket) {
+		socket.versions.eager = true
+	}
+}
+
+/*
+WithEventGating skips event handlers for events the browser doesn't emit
+because it's older than the release that added them. The releases are looked
+up in the specified map, or in EventSince if it's nil. The browser version is
+discovered on connect, see WithVersionDiscovery; handlers added before it's
+known wait for it. Skipped handlers are logged and their subscriptions are
+inert. Handlers are added when the version can't be discovered.
+*/
+func WithEventGating(since map[string]int) Option {
+	return func(socket *Socket) {
+		if nil == since {
+			since = EventSince
+		}
+		socket.versions.eager = true
+		socket.versions.since = since
+	}
+}
+
+/*
+versionDiscovery holds the discovered browser version.
+*/
+type versionDiscovery struct {
+	eager   bool
+	mux     *sync.Mutex
+	since   map[string]int
+	version *BrowserVersion
+}
+
+func newVersionDiscovery() *versionDiscovery {
+	return &versionDiscovery{mux: &sync.Mutex{}}
+}
+
+/*
+Version returns the version of the browser the socket is connected to, queried
+from its /json/version endpoint. The version is cached once it's discovered.
+*/
+func (socket *Socket) Version() (*BrowserVersion, error) {
+	socket.versions.mux.Lock()
+	defer socket.versions.mux.Unlock()
+	if nil != socket.versions.version {
+		return socket.versions.version, nil
+	}
+
+	client, uri := socket.versionClient()
+	resp, err := client.Get(uri)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.SocketVersionUnavailable, fmt.Sprintf("could not query '%s'", uri))
+	}
+	defer resp.Body.Close()
+	if http.StatusOK != resp.StatusCode {
+		return nil, errs.New(codes.SocketVersionUnavailable, fmt.Sprintf("could not query '%s': %s", uri, resp.Status))
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.SocketVersionUnavailable, fmt.Sprintf("could not read '%s'", uri))
+	}
+	version := &BrowserVersion{}
+	if err := json.Unmarshal(content, version); nil != err {
+		return nil, errs.Wrap(err, codes.SocketVersionUnavailable, fmt.Sprintf("invalid '%s' response", uri))
+	}
+	socket.logger.Debug("discovered browser version", logger.Fields{"browser": version.Browser, "protocolVersion": version.ProtocolVersion, "socketID": socket.socketID})
+	socket.versions.version = version
+	return version, nil
+}
+
+/*
+AtLeast returns whether the browser's major version is at least the specified
+release. It returns false if the version can't be discovered.
+*/
+func (socket *Socket) AtLeast(major int) bool {
+	version, err := socket.Version()
+	return nil == err && version.Major() >= major
+}
+
+/*
+discoverVersion starts the version query of a socket that discovers it on
+connect.
+*/
+func (socket *Socket) discoverVersion() {
+	if nil == socket.versions || !socket.versions.eager {
+		return
+	}
+	go func() {
+		if _, err := socket.Version(); nil != err {
+			socket.logger.Debug("could not discover the browser version", logger.Fields{"error": err.Error(), "socketID": socket.socketID})
+		}
+	}()
+}
+
+/*
+gated returns whether an event handler is skipped because the browser doesn't
+emit its event.
+*/
+func (socket *Socket) gated(handler EventHandler) bool {
+	if nil == socket.versions {
+		return false
+	}
+	since, ok := socket.versions.since[handler.Name()]
+	if !ok {
+		return false
+	}
+	version, err := socket.Version()
+	if nil != err || 0 == version.Major() || version.Major() >= since {
+		return false
+	}
+	socket.logger.Info(fmt.Sprintf("skipping the '%s' event handler, the event was added in Chrome %d", handler.Name(), since), logger.Fields{"browser": version.Browser, "event": handler.Name(), "socketID": socket.socketID})
+	return true
+}
+
+/*
+versionClient returns an HTTP client that reaches the socket's browser the way
+the websocket does, and the URL of its /json/version endpoint.
+*/
+func (socket *Socket) versionClient() (*http.Client, string) {
+	transport := &http.Transport{}
+	if nil != socket.dialer {
+		transport.TLSClientConfig = socket.dialer.TLSClientConfig
+		transport.Proxy = socket.dialer.Proxy
+		if dial := socket.dialer.NetDial; nil != dial {
+			transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dial(network, addr)
+			}
+		}
+	}
+
+	endpoint := &url.URL{Scheme: "http", Host: socket.url.Host, Path: "/json/version"}
+	switch socket.url.Scheme {
+	case "wss", "https":
+		endpoint.Scheme = "https"
+	case "unix":
+		path := socket.url.Path
+		endpoint.Host = "localhost"
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, "unix", path)
+		}
+	}
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}, endpoint.String()
+}
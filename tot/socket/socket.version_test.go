@@ -0,0 +1,110 @@
+package socket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+newVersionServer serves /json/version and accepts websocket connections.
+*/
+func newVersionServer(browser string) (*url.URL, *int32, func()) {
+	queries := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if "/json/version" == r.URL.Path {
+			atomic.AddInt32(&queries, 1)
+			w.Write([]byte(`{"Browser": "` + browser + `", "Protocol-Version": "1.3"}`))
+			return
+		}
+		upgrader := websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if nil != err {
+			return
+		}
+		defer ws.Close()
+		for {
+			if _, _, err := ws.ReadMessage(); nil != err {
+				return
+			}
+		}
+	}))
+	socketURL, _ := url.Parse(strings.Replace(server.URL, "http://", "ws://", 1) + "/devtools/page/1")
+	return socketURL, &queries, server.Close
+}
+
+func TestBrowserVersionMajor(t *testing.T) {
+	tests := map[string]int{
+		"HeadlessChrome/120.0.6099.109": 120,
+		"Chrome/74.0.3729.169":          74,
+		"Edg/99":                        99,
+		"unknown":                       0,
+	}
+	for browser, expected := range tests {
+		if major := (&BrowserVersion{Browser: browser}).Major(); expected != major {
+			t.Errorf("Expected %d for '%s', got %d", expected, browser, major)
+		}
+	}
+}
+
+func TestSocketVersion(t *testing.T) {
+	socketURL, queries, stop := newVersionServer("HeadlessChrome/120.0.6099.109")
+	defer stop()
+	socket := New(socketURL, WithVersionDiscovery())
+	defer socket.Stop()
+
+	for start := time.Now(); 0 == atomic.LoadInt32(queries) && time.Since(start) < 5*time.Second; {
+		time.Sleep(10 * time.Millisecond)
+	}
+	version, err := socket.Version()
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "1.3" != version.ProtocolVersion || 120 != version.Major() {
+		t.Errorf("Expected protocol 1.3 and Chrome 120, got %+v", version)
+	}
+	if !socket.AtLeast(100) || socket.AtLeast(121) {
+		t.Errorf("Expected Chrome 120 to be at least 100 and less than 121")
+	}
+	if 1 != atomic.LoadInt32(queries) {
+		t.Errorf("Expected the version to be queried once, got %d queries", *queries)
+	}
+}
+
+func TestEventGating(t *testing.T) {
+	socketURL, _, stop := newVersionServer("Chrome/73.0.3683.103")
+	defer stop()
+	socket := New(socketURL, WithEventGating(nil))
+	defer socket.Stop()
+
+	socket.AddEventHandler(NewEventHandler("Fetch.requestPaused", func(response *Response) {}))
+	socket.AddEventHandler(NewEventHandler("Page.loadEventFired", func(response *Response) {}))
+	if _, err := socket.handlers.Get("Fetch.requestPaused"); nil == err {
+		t.Errorf("Expected the Fetch.requestPaused handler to be skipped")
+	}
+	if _, err := socket.handlers.Get("Page.loadEventFired"); nil != err {
+		t.Errorf("Expected the Page.loadEventFired handler to be added, got error: %v", err)
+	}
+}
+
+func TestVersionUnavailable(t *testing.T) {
+	server := cdptest.NewServer(nil)
+	defer server.Close()
+	socket := New(server.URL(), WithEventGating(nil))
+	defer socket.Stop()
+
+	if _, err := socket.Version(); nil == err {
+		t.Errorf("Expected an error")
+	}
+	socket.AddEventHandler(NewEventHandler("Fetch.requestPaused", func(response *Response) {}))
+	if _, err := socket.handlers.Get("Fetch.requestPaused"); nil != err {
+		t.Errorf("Expected the handler to be added when the version is unknown, got error: %v", err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"net/url"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/gorilla/websocket"
 	"github.com/mkenney/go-chrome/codes"
 )
@@ -17,26 +16,42 @@ NewWebsocket returns a connected socket connection that implements the
 WebSocketer interface.
 */
 func NewWebsocket(socketURL *url.URL) (WebSocketer, error) {
-	dialer := &websocket.Dialer{
+	return dialWebsocket(newDialer(), socketURL)
+}
+
+/*
+newDialer returns a websocket dialer configured for Chrome.
+*/
+func newDialer() *websocket.Dialer {
+	return &websocket.Dialer{
 		EnableCompression: true,
 		// See: https://github.com/gorilla/websocket/issues/245
 		// Chrome does not support socket fragmentation: https://chromium.googlesource.com/chromium/src/+/master/net/server/web_socket_encoder.cc#85
 		// Chrome does not support payloads larger than 1MB: https://chromium.googlesource.com/chromium/src/+/master/net/server/http_connection.h#33
-		WriteBufferSize: 1 * 1024 * 1024,
+		WriteBufferSize: defaultWriteLimit,
 	}
+}
+
+/*
+dialWebsocket connects to a websocket URL with a dialer. unix:// URLs connect
+to a unix domain socket, see unixDialer.
+*/
+func dialWebsocket(dialer *websocket.Dialer, socketURL *url.URL) (WebSocketer, error) {
 	header := http.Header{"Origin": []string{}}
 
-	websocket, response, err := dialer.Dial(socketURL.String(), header)
+	endpoint := socketURL.String()
+	if "unix" == socketURL.Scheme {
+		dialer, endpoint = unixDialer(dialer, socketURL)
+	}
+	websocket, _, err := dialer.Dial(endpoint, header)
 	if err != nil {
 		return nil, errs.Wrap(err, codes.WebsocketConnectFailed, fmt.Sprintf(
 			"%s websocket connection failed",
 			socketURL.String(),
 		))
 	}
-	log.WithFields(log.Fields{"status": response.Status, "url": socketURL.String()}).
-		Info("Websocket connection established")
 
-	return &ChromeWebSocket{conn: websocket}, nil
+	return &ChromeWebSocket{conn: websocket, writeLimit: dialer.WriteBufferSize}, nil
 }
 
 /*
@@ -48,6 +63,7 @@ ChromeWebSocket represents a WebSocketer interface
 type ChromeWebSocket struct {
 	conn          *websocket.Conn
 	mockResponses []*Response
+	writeLimit    int
 }
 
 /*
@@ -89,9 +105,13 @@ func (socket *ChromeWebSocket) WriteJSON(v interface{}) error {
 	if nil == socket.conn {
 		return errs.New(codes.WebsocketNotConnected, "not connected")
 	}
+	limit := socket.writeLimit
+	if limit <= 0 {
+		limit = defaultWriteLimit
+	}
 	tmp, _ := json.Marshal(v)
-	if len(tmp) > 1*1024*1024 {
-		return fmt.Errorf("payload too large. chrome supports a maximum payload size of 1MB. See https://github.com/gorilla/websocket/issues/245")
+	if len(tmp) > limit {
+		return errs.New(codes.SocketMessageTooLarge, fmt.Sprintf("payload too large: the payload of %d bytes exceeds the write limit of %d bytes. See https://github.com/gorilla/websocket/issues/245", len(tmp), limit))
 	}
 	return socket.conn.WriteJSON(v)
 }
@@ -0,0 +1,175 @@
+package sourcemap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+Resolver loads the source maps of scripts by URL and resolves generated
+positions with them. The map of each script is loaded once, scripts without a
+sourceMappingURL comment aren't mapped. The sources of loaded maps are resolved
+to URLs relative to the map.
+*/
+type Resolver struct {
+	fetch func(url string) ([]byte, error)
+	maps  map[string]*loaded
+	mux   *sync.Mutex
+}
+
+/*
+loaded is the result of loading the source map of a script.
+*/
+type loaded struct {
+	err       error
+	once      *sync.Once
+	sourceMap *Map
+}
+
+/*
+sourceMappingURL matches the sourceMappingURL comment of a script.
+*/
+var sourceMappingURL = regexp.MustCompile(`(?m)^\s*//[#@]\s*sourceMappingURL=(\S+)\s*$`)
+
+/*
+NewResolver returns a resolver that loads scripts and source maps with the fetch
+function, or with HTTP GET requests if it's nil. Inline data: URLs are decoded
+without fetching.
+*/
+func NewResolver(fetch func(url string) ([]byte, error)) *Resolver {
+	if nil == fetch {
+		fetch = httpFetch
+	}
+	return &Resolver{
+		fetch: fetch,
+		maps:  make(map[string]*loaded),
+		mux:   &sync.Mutex{},
+	}
+}
+
+/*
+Load returns the source map of a script, or nil if the script doesn't have one.
+*/
+func (resolver *Resolver) Load(scriptURL string) (*Map, error) {
+	resolver.mux.Lock()
+	result, ok := resolver.maps[scriptURL]
+	if !ok {
+		result = &loaded{once: &sync.Once{}}
+		resolver.maps[scriptURL] = result
+	}
+	resolver.mux.Unlock()
+
+	result.once.Do(func() {
+		result.sourceMap, result.err = resolver.load(scriptURL)
+	})
+	return result.sourceMap, result.err
+}
+
+/*
+Resolve returns the original position of a generated position in a script, or
+nil if the script doesn't have a source map or the position isn't mapped.
+*/
+func (resolver *Resolver) Resolve(scriptURL string, line, column int) (*Position, error) {
+	sourceMap, err := resolver.Load(scriptURL)
+	if nil != err || nil == sourceMap {
+		return nil, err
+	}
+	return sourceMap.Lookup(line, column), nil
+}
+
+/*
+load fetches a script and its source map.
+*/
+func (resolver *Resolver) load(scriptURL string) (*Map, error) {
+	script, err := resolver.fetch(scriptURL)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.SourceMapLoadFailed, fmt.Sprintf("could not fetch script '%s'", scriptURL))
+	}
+	matches := sourceMappingURL.FindAllSubmatch(script, -1)
+	if 0 == len(matches) {
+		return nil, nil
+	}
+	mapURL := string(matches[len(matches)-1][1])
+
+	// Sources are relative to the source map, or to the script if the map
+	// is inline.
+	base, err := url.Parse(scriptURL)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.SourceMapLoadFailed, fmt.Sprintf("invalid script URL '%s'", scriptURL))
+	}
+	var data []byte
+	if strings.HasPrefix(mapURL, "data:") {
+		data, err = decodeDataURL(mapURL)
+		if nil != err {
+			return nil, errs.Wrap(err, codes.SourceMapLoadFailed, fmt.Sprintf("could not decode the inline source map of '%s'", scriptURL))
+		}
+	} else {
+		reference, err := url.Parse(mapURL)
+		if nil != err {
+			return nil, errs.Wrap(err, codes.SourceMapLoadFailed, fmt.Sprintf("invalid source map URL '%s' in '%s'", mapURL, scriptURL))
+		}
+		base = base.ResolveReference(reference)
+		data, err = resolver.fetch(base.String())
+		if nil != err {
+			return nil, errs.Wrap(err, codes.SourceMapLoadFailed, fmt.Sprintf("could not fetch source map '%s'", base))
+		}
+	}
+
+	sourceMap, err := Parse(data)
+	if nil != err {
+		return nil, err
+	}
+	for a, source := range sourceMap.Sources {
+		if reference, err := url.Parse(source); nil == err {
+			sourceMap.Sources[a] = base.ResolveReference(reference).String()
+		}
+	}
+	return sourceMap, nil
+}
+
+/*
+decodeDataURL returns the data of a data: URL.
+*/
+func decodeDataURL(dataURL string) ([]byte, error) {
+	comma := strings.Index(dataURL, ",")
+	if comma < 0 {
+		return nil, fmt.Errorf("invalid data URL")
+	}
+	meta, data := dataURL[len("data:"):comma], dataURL[comma+1:]
+	if strings.HasSuffix(meta, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	unescaped, err := url.PathUnescape(data)
+	return []byte(unescaped), err
+}
+
+/*
+httpClient fetches scripts and source maps for resolvers without a fetch
+function.
+*/
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+/*
+httpFetch fetches a URL with a GET request.
+*/
+func httpFetch(url string) ([]byte, error) {
+	response, err := httpClient.Get(url)
+	if nil != err {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if http.StatusOK != response.StatusCode {
+		return nil, fmt.Errorf("%s returned %s", url, response.Status)
+	}
+	return ioutil.ReadAll(response.Body)
+}
@@ -0,0 +1,78 @@
+package sourcemap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testMap = `{"version": 3, "sources": ["app.ts"], "names": [], "mappings": "AAAA,QAAQ"}`
+
+func TestResolver(t *testing.T) {
+	fetched := make(map[string]int)
+	files := map[string]string{
+		"https://example.com/js/app.min.js":          "function a(){}\n//# sourceMappingURL=maps/app.min.js.map\n",
+		"https://example.com/js/maps/app.min.js.map": testMap,
+		"https://example.com/js/inline.js":           "function a(){}\n//# sourceMappingURL=data:application/json;charset=utf-8;base64," + base64.StdEncoding.EncodeToString([]byte(testMap)) + "\n",
+		"https://example.com/js/plain.js":            "function a(){}\n",
+	}
+	resolver := NewResolver(func(url string) ([]byte, error) {
+		fetched[url]++
+		if file, ok := files[url]; ok {
+			return []byte(file), nil
+		}
+		return nil, fmt.Errorf("%s not found", url)
+	})
+
+	for script, source := range map[string]string{
+		"https://example.com/js/app.min.js": "https://example.com/js/maps/app.ts",
+		"https://example.com/js/inline.js":  "https://example.com/js/app.ts",
+	} {
+		position, err := resolver.Resolve(script, 0, 9)
+		if nil != err {
+			t.Fatalf("Expected nil, got error: %v", err)
+		}
+		if nil == position || source != position.Source || 8 != position.Column {
+			t.Errorf("Expected %s 0:9 to map to %s 0:8, got %+v", script, source, position)
+		}
+	}
+	resolver.Resolve("https://example.com/js/app.min.js", 0, 0)
+	if 1 != fetched["https://example.com/js/app.min.js"] || 1 != fetched["https://example.com/js/maps/app.min.js.map"] {
+		t.Errorf("Expected the script and map to be fetched once, got %v", fetched)
+	}
+
+	if position, err := resolver.Resolve("https://example.com/js/plain.js", 0, 0); nil != err || nil != position {
+		t.Errorf("Expected a script without a source map not to be mapped, got %+v %v", position, err)
+	}
+	if _, err := resolver.Resolve("https://example.com/js/missing.js", 0, 0); nil == err {
+		t.Errorf("Expected an error for a script that can't be fetched")
+	}
+}
+
+func TestResolverHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.js":
+			w.Write([]byte("//# sourceMappingURL=/app.js.map"))
+		case "/app.js.map":
+			w.Write([]byte(testMap))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(nil)
+	position, err := resolver.Resolve(server.URL+"/app.js", 0, 0)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if nil == position || server.URL+"/app.ts" != position.Source {
+		t.Errorf("Expected the position to be mapped to %s/app.ts, got %+v", server.URL, position)
+	}
+	if _, err := resolver.Load(server.URL + "/missing.js"); nil == err {
+		t.Errorf("Expected an error for a missing script")
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Package sourcemap maps positions in generated JavaScript back to the original
+sources with version 3 source maps, so stack traces of bundled or transpiled
+scripts point at the code that was written:
+
+	resolver := sourcemap.NewResolver(nil)
+	position, err := resolver.Resolve("https://example.com/app.min.js", 0, 1832)
+	if nil != err {
+		...
+	}
+	if nil != position {
+		fmt.Printf("%s:%d:%d\n", position.Source, position.Line+1, position.Column+1)
+	}
+
+Lines and columns are 0-based, like the positions the protocol reports. Index
+maps with sections aren't supported.
+
+https://sourcemaps.info/spec.html
+*/
+package sourcemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+Map is a decoded source map.
+*/
+type Map struct {
+	File    string
+	Names   []string
+	Sources []string
+
+	lines [][]*segment
+}
+
+/*
+Position is a position in an original source.
+*/
+type Position struct {
+	// The original source, prefixed with the source root.
+	Source string
+
+	// The 0-based line in the original source.
+	Line int
+
+	// The 0-based column in the original source.
+	Column int
+
+	// Optional. The original name of the symbol at the position.
+	Name string
+}
+
+/*
+segment is a mapping from a generated column to an original position. Segments
+without a source don't map the generated column.
+*/
+type segment struct {
+	column       int
+	hasSource    bool
+	source       int
+	sourceLine   int
+	sourceColumn int
+	name         int
+}
+
+/*
+Parse decodes a version 3 source map.
+*/
+func Parse(data []byte) (*Map, error) {
+	raw := struct {
+		Version    int             `json:"version"`
+		File       string          `json:"file"`
+		SourceRoot string          `json:"sourceRoot"`
+		Sources    []string        `json:"sources"`
+		Names      []string        `json:"names"`
+		Mappings   string          `json:"mappings"`
+		Sections   json.RawMessage `json:"sections"`
+	}{}
+	// Maps may be prefixed with )]}' to prevent them from being run as a
+	// script.
+	text := strings.TrimPrefix(string(data), ")]}'")
+	if err := json.Unmarshal([]byte(text), &raw); nil != err {
+		return nil, errs.Wrap(err, codes.SourceMapInvalid, "could not decode the source map")
+	}
+	if 3 != raw.Version {
+		return nil, errs.New(codes.SourceMapInvalid, fmt.Sprintf("unsupported source map version %d", raw.Version))
+	}
+	if 0 < len(raw.Sections) {
+		return nil, errs.New(codes.SourceMapInvalid, "index maps with sections are not supported")
+	}
+
+	sourceMap := &Map{
+		File:    raw.File,
+		Names:   raw.Names,
+		Sources: make([]string, len(raw.Sources)),
+	}
+	for a, source := range raw.Sources {
+		if "" != raw.SourceRoot && !strings.HasSuffix(raw.SourceRoot, "/") {
+			source = raw.SourceRoot + "/" + source
+		} else {
+			source = raw.SourceRoot + source
+		}
+		sourceMap.Sources[a] = source
+	}
+
+	lines, err := decodeMappings(raw.Mappings, len(raw.Sources), len(raw.Names))
+	if nil != err {
+		return nil, err
+	}
+	sourceMap.lines = lines
+	return sourceMap, nil
+}
+
+/*
+Lookup returns the original position of a generated position, or nil if the
+position isn't mapped.
+*/
+func (sourceMap *Map) Lookup(line, column int) *Position {
+	if line < 0 || line >= len(sourceMap.lines) {
+		return nil
+	}
+	segments := sourceMap.lines[line]
+	// The segment that starts at or before the column.
+	index := sort.Search(len(segments), func(a int) bool {
+		return segments[a].column > column
+	}) - 1
+	if index < 0 || !segments[index].hasSource {
+		return nil
+	}
+
+	seg := segments[index]
+	position := &Position{
+		Source: sourceMap.Sources[seg.source],
+		Line:   seg.sourceLine,
+		Column: seg.sourceColumn,
+	}
+	if seg.name >= 0 {
+		position.Name = sourceMap.Names[seg.name]
+	}
+	return position
+}
+
+/*
+decodeMappings decodes the mappings field into segments per generated line,
+sorted by column.
+*/
+func decodeMappings(mappings string, sources, names int) ([][]*segment, error) {
+	lines := make([][]*segment, 0)
+	source, sourceLine, sourceColumn, name := 0, 0, 0, 0
+
+	for lineNumber, line := range strings.Split(mappings, ";") {
+		segments := make([]*segment, 0)
+		column := 0
+		for _, field := range strings.Split(line, ",") {
+			if "" == field {
+				continue
+			}
+			values, err := decodeVLQ(field)
+			if nil != err {
+				return nil, errs.Wrap(err, codes.SourceMapInvalid, fmt.Sprintf("invalid mapping '%s' on generated line %d", field, lineNumber))
+			}
+			if 1 != len(values) && 4 != len(values) && 5 != len(values) {
+				return nil, errs.New(codes.SourceMapInvalid, fmt.Sprintf("invalid mapping '%s' on generated line %d: %d fields", field, lineNumber, len(values)))
+			}
+
+			column += values[0]
+			seg := &segment{column: column, name: -1}
+			if 4 <= len(values) {
+				source += values[1]
+				sourceLine += values[2]
+				sourceColumn += values[3]
+				if source < 0 || source >= sources {
+					return nil, errs.New(codes.SourceMapInvalid, fmt.Sprintf("invalid source index %d on generated line %d", source, lineNumber))
+				}
+				seg.hasSource = true
+				seg.source = source
+				seg.sourceLine = sourceLine
+				seg.sourceColumn = sourceColumn
+			}
+			if 5 == len(values) {
+				name += values[4]
+				if name < 0 || name >= names {
+					return nil, errs.New(codes.SourceMapInvalid, fmt.Sprintf("invalid name index %d on generated line %d", name, lineNumber))
+				}
+				seg.name = name
+			}
+			segments = append(segments, seg)
+		}
+		sort.SliceStable(segments, func(a, b int) bool {
+			return segments[a].column < segments[b].column
+		})
+		lines = append(lines, segments)
+	}
+	return lines, nil
+}
+
+/*
+base64Digits are the digits of the base64 VLQ encoding.
+*/
+const base64Digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+/*
+decodeVLQ decodes the base64 VLQ values of a mapping segment.
+*/
+func decodeVLQ(field string) ([]int, error) {
+	values := make([]int, 0, 5)
+	value, shift := 0, uint(0)
+	continued := false
+	for _, char := range field {
+		digit := strings.IndexRune(base64Digits, char)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base64 digit '%c'", char)
+		}
+		continued = 0 != digit&32
+		value += (digit & 31) << shift
+		if continued {
+			shift += 5
+			continue
+		}
+		// The lowest bit is the sign.
+		if 0 != value&1 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if continued {
+		return nil, fmt.Errorf("truncated value")
+	}
+	return values, nil
+}
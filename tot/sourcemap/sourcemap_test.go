@@ -0,0 +1,101 @@
+package sourcemap
+
+import (
+	"testing"
+)
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		field  string
+		values []int
+	}{
+		{"AAAA", []int{0, 0, 0, 0}},
+		{"IAAI", []int{4, 0, 0, 4}},
+		{"D", []int{-1}},
+		{"gB", []int{16}},
+		{"hB", []int{-16}},
+		{"AACAA", []int{0, 0, 1, 0, 0}},
+	}
+	for _, test := range tests {
+		values, err := decodeVLQ(test.field)
+		if nil != err {
+			t.Errorf("Expected nil, got error for '%s': %v", test.field, err)
+			continue
+		}
+		if len(test.values) != len(values) {
+			t.Errorf("Expected %v for '%s', got %v", test.values, test.field, values)
+			continue
+		}
+		for a := range values {
+			if test.values[a] != values[a] {
+				t.Errorf("Expected %v for '%s', got %v", test.values, test.field, values)
+				break
+			}
+		}
+	}
+	for _, field := range []string{"g", "A!"} {
+		if _, err := decodeVLQ(field); nil == err {
+			t.Errorf("Expected an error for '%s'", field)
+		}
+	}
+}
+
+func TestParseLookup(t *testing.T) {
+	sourceMap, err := Parse([]byte(`)]}'
+{
+	"version": 3,
+	"file": "app.min.js",
+	"sourceRoot": "/assets",
+	"sources": ["src/app.ts", "src/util.ts"],
+	"names": ["start", "fail"],
+	"mappings": "AAAAA,QAAQ;AACRC,UCAQ,E"
+}`))
+	if nil != err {
+		t.Fatalf("Expected nil, got error: %v", err)
+	}
+	if "app.min.js" != sourceMap.File || "/assets/src/app.ts" != sourceMap.Sources[0] {
+		t.Errorf("Expected the file and prefixed sources, got '%s' %v", sourceMap.File, sourceMap.Sources)
+	}
+
+	tests := []struct {
+		line, column int
+		expected     *Position
+	}{
+		{0, 0, &Position{Source: "/assets/src/app.ts", Line: 0, Column: 0, Name: "start"}},
+		{0, 7, &Position{Source: "/assets/src/app.ts", Line: 0, Column: 0, Name: "start"}},
+		{0, 8, &Position{Source: "/assets/src/app.ts", Line: 0, Column: 8}},
+		{1, 3, &Position{Source: "/assets/src/app.ts", Line: 1, Column: 0, Name: "fail"}},
+		{1, 11, &Position{Source: "/assets/src/util.ts", Line: 1, Column: 8}},
+		{1, 12, nil},
+		{1, 14, nil},
+		{2, 0, nil},
+		{-1, 0, nil},
+	}
+	for _, test := range tests {
+		position := sourceMap.Lookup(test.line, test.column)
+		if nil == test.expected {
+			if nil != position {
+				t.Errorf("Expected %d:%d not to be mapped, got %+v", test.line, test.column, position)
+			}
+			continue
+		}
+		if nil == position || *test.expected != *position {
+			t.Errorf("Expected %d:%d to map to %+v, got %+v", test.line, test.column, test.expected, position)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"version": 2, "sources": [], "mappings": ""}`,
+		`{"version": 3, "sections": [{"offset": {"line": 0, "column": 0}}]}`,
+		`{"version": 3, "sources": ["a.js"], "mappings": "AAAA,AAA"}`,
+		`{"version": 3, "sources": ["a.js"], "mappings": "ACAA"}`,
+		`{"version": 3, "sources": ["a.js"], "names": [], "mappings": "AAAAA"}`,
+	} {
+		if _, err := Parse([]byte(data)); nil == err {
+			t.Errorf("Expected an error for '%s'", data)
+		}
+	}
+}
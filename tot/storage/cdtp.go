@@ -17,3 +17,50 @@ type UsageForType struct {
 	// Storage usage (bytes).
 	Usage int `json:"usage"`
 }
+
+/*
+SharedStorageEntry is a key-value pair in an origin's shared storage.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#type-SharedStorageEntry
+*/
+type SharedStorageEntry struct {
+	// The key.
+	Key string `json:"key"`
+
+	// The value.
+	Value string `json:"value"`
+}
+
+/*
+SharedStorageMetadata is the details of an origin's shared storage.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#type-SharedStorageMetadata
+*/
+type SharedStorageMetadata struct {
+	// Time the origin's shared storage was last created, in seconds since the
+	// epoch.
+	CreationTime float64 `json:"creationTime"`
+
+	// Number of key-value pairs stored.
+	Length int `json:"length"`
+
+	// Bits of entropy remaining in the navigation budget.
+	RemainingBudget float64 `json:"remainingBudget"`
+
+	// Total number of bytes stored as key-value pairs.
+	BytesUsed int `json:"bytesUsed"`
+}
+
+/*
+TrustTokens is the number of available (signed, but not used) Trust Tokens
+from an issuer. EXPERIMENTAL.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#type-TrustTokens
+*/
+type TrustTokens struct {
+	// The issuer origin.
+	IssuerOrigin string `json:"issuerOrigin"`
+
+	// The number of tokens.
+	Count float64 `json:"count"`
+}
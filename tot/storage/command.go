@@ -23,6 +23,170 @@ type ClearDataForOriginResult struct {
 	Err error `json:"-"`
 }
 
+/*
+ClearSharedStorageEntriesParams represents Storage.clearSharedStorageEntries
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearSharedStorageEntries
+*/
+type ClearSharedStorageEntriesParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+}
+
+/*
+ClearSharedStorageEntriesResult represents the result of calls to
+Storage.clearSharedStorageEntries.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearSharedStorageEntries
+*/
+type ClearSharedStorageEntriesResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+ClearTrustTokensParams represents Storage.clearTrustTokens parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearTrustTokens
+*/
+type ClearTrustTokensParams struct {
+	// The issuer origin.
+	IssuerOrigin string `json:"issuerOrigin"`
+}
+
+/*
+ClearTrustTokensResult represents the result of calls to
+Storage.clearTrustTokens.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearTrustTokens
+*/
+type ClearTrustTokensResult struct {
+	// True if any tokens were deleted, false otherwise.
+	DidDeleteTokens bool `json:"didDeleteTokens"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DeleteSharedStorageEntryParams represents Storage.deleteSharedStorageEntry
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-deleteSharedStorageEntry
+*/
+type DeleteSharedStorageEntryParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+
+	// The key of the entry.
+	Key string `json:"key"`
+}
+
+/*
+DeleteSharedStorageEntryResult represents the result of calls to
+Storage.deleteSharedStorageEntry.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-deleteSharedStorageEntry
+*/
+type DeleteSharedStorageEntryResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetInterestGroupDetailsParams represents Storage.getInterestGroupDetails
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getInterestGroupDetails
+*/
+type GetInterestGroupDetailsParams struct {
+	// The owner origin of the interest group.
+	OwnerOrigin string `json:"ownerOrigin"`
+
+	// The name of the interest group.
+	Name string `json:"name"`
+}
+
+/*
+GetInterestGroupDetailsResult represents the result of calls to
+Storage.getInterestGroupDetails.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getInterestGroupDetails
+*/
+type GetInterestGroupDetailsResult struct {
+	// The interest group, largely a generateBid interest group with an absolute
+	// expirationTime and the joiningOrigin.
+	// https://wicg.github.io/turtledove/#dictdef-generatebidinterestgroup
+	Details map[string]interface{} `json:"details"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetSharedStorageEntriesParams represents Storage.getSharedStorageEntries
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageEntries
+*/
+type GetSharedStorageEntriesParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+}
+
+/*
+GetSharedStorageEntriesResult represents the result of calls to
+Storage.getSharedStorageEntries.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageEntries
+*/
+type GetSharedStorageEntriesResult struct {
+	// The entries.
+	Entries []*SharedStorageEntry `json:"entries"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetSharedStorageMetadataParams represents Storage.getSharedStorageMetadata
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageMetadata
+*/
+type GetSharedStorageMetadataParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+}
+
+/*
+GetSharedStorageMetadataResult represents the result of calls to
+Storage.getSharedStorageMetadata.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getSharedStorageMetadata
+*/
+type GetSharedStorageMetadataResult struct {
+	// The metadata.
+	Metadata *SharedStorageMetadata `json:"metadata"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetTrustTokensResult represents the result of calls to Storage.getTrustTokens.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getTrustTokens
+*/
+type GetTrustTokensResult struct {
+	// The number of tokens per issuer.
+	Tokens []*TrustTokens `json:"tokens"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 GetUsageAndQuotaParams represents Storage.getUsageAndQuota parameters.
 
@@ -52,6 +216,160 @@ type GetUsageAndQuotaResult struct {
 	Err error `json:"-"`
 }
 
+/*
+ResetSharedStorageBudgetParams represents Storage.resetSharedStorageBudget
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-resetSharedStorageBudget
+*/
+type ResetSharedStorageBudgetParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+}
+
+/*
+ResetSharedStorageBudgetResult represents the result of calls to
+Storage.resetSharedStorageBudget.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-resetSharedStorageBudget
+*/
+type ResetSharedStorageBudgetResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SendPendingAttributionReportsResult represents the result of calls to
+Storage.sendPendingAttributionReports.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-sendPendingAttributionReports
+*/
+type SendPendingAttributionReportsResult struct {
+	// The number of reports that were sent.
+	NumSent int `json:"numSent"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetAttributionReportingLocalTestingModeParams represents
+Storage.setAttributionReportingLocalTestingMode parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingLocalTestingMode
+*/
+type SetAttributionReportingLocalTestingModeParams struct {
+	// If enabled, noise is suppressed and reports are sent immediately.
+	Enabled bool `json:"enabled"`
+}
+
+/*
+SetAttributionReportingLocalTestingModeResult represents the result of calls to
+Storage.setAttributionReportingLocalTestingMode.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingLocalTestingMode
+*/
+type SetAttributionReportingLocalTestingModeResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetAttributionReportingTrackingParams represents
+Storage.setAttributionReportingTracking parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingTracking
+*/
+type SetAttributionReportingTrackingParams struct {
+	// Whether Attribution Reporting events are issued.
+	Enable bool `json:"enable"`
+}
+
+/*
+SetAttributionReportingTrackingResult represents the result of calls to
+Storage.setAttributionReportingTracking.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setAttributionReportingTracking
+*/
+type SetAttributionReportingTrackingResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetInterestGroupTrackingParams represents Storage.setInterestGroupTracking
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setInterestGroupTracking
+*/
+type SetInterestGroupTrackingParams struct {
+	// Whether Storage.interestGroupAccessed events are issued.
+	Enable bool `json:"enable"`
+}
+
+/*
+SetInterestGroupTrackingResult represents the result of calls to
+Storage.setInterestGroupTracking.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setInterestGroupTracking
+*/
+type SetInterestGroupTrackingResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetSharedStorageEntryParams represents Storage.setSharedStorageEntry parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageEntry
+*/
+type SetSharedStorageEntryParams struct {
+	// The origin owning the shared storage.
+	OwnerOrigin string `json:"ownerOrigin"`
+
+	// The key of the entry.
+	Key string `json:"key"`
+
+	// The value of the entry.
+	Value string `json:"value"`
+
+	// Optional. Only sets the entry if the key doesn't exist.
+	IgnoreIfPresent bool `json:"ignoreIfPresent,omitempty"`
+}
+
+/*
+SetSharedStorageEntryResult represents the result of calls to
+Storage.setSharedStorageEntry.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageEntry
+*/
+type SetSharedStorageEntryResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetSharedStorageTrackingParams represents Storage.setSharedStorageTracking
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageTracking
+*/
+type SetSharedStorageTrackingParams struct {
+	// Whether Storage.sharedStorageAccessed events are issued.
+	Enable bool `json:"enable"`
+}
+
+/*
+SetSharedStorageTrackingResult represents the result of calls to
+Storage.setSharedStorageTracking.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setSharedStorageTracking
+*/
+type SetSharedStorageTrackingResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 TrackCacheStorageForOriginParams represents Storage.trackCacheStorageForOrigin parameters.
 
@@ -9,6 +9,12 @@ type CacheStorageContentUpdatedEvent struct {
 	// Origin to update.
 	Origin string `json:"origin"`
 
+	// Storage key to update.
+	StorageKey string `json:"storageKey"`
+
+	// Storage bucket to update.
+	BucketID string `json:"bucketId"`
+
 	// Name of cache in origin.
 	CacheName string `json:"cacheName"`
 
@@ -25,6 +31,12 @@ type CacheStorageListUpdatedEvent struct {
 	// Origin to update.
 	Origin string `json:"origin"`
 
+	// Storage key to update.
+	StorageKey string `json:"storageKey"`
+
+	// Storage bucket to update.
+	BucketID string `json:"bucketId"`
+
 	// Error information related to this event
 	Err error `json:"-"`
 }
@@ -38,6 +50,12 @@ type IndexedDBContentUpdatedEvent struct {
 	// Origin to update.
 	Origin string `json:"origin"`
 
+	// Storage key to update.
+	StorageKey string `json:"storageKey"`
+
+	// Storage bucket to update.
+	BucketID string `json:"bucketId"`
+
 	// Database to update.
 	DatabaseName string `json:"databaseName"`
 
@@ -57,6 +75,12 @@ type IndexedDBListUpdatedEvent struct {
 	// Origin to update.
 	Origin string `json:"origin"`
 
+	// Storage key to update.
+	StorageKey string `json:"storageKey"`
+
+	// Storage bucket to update.
+	BucketID string `json:"bucketId"`
+
 	// Error information related to this event
 	Err error `json:"-"`
 }
@@ -0,0 +1,194 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+)
+
+/*
+CanvasCapture is the content of a canvas element.
+*/
+type CanvasCapture struct {
+	// The size of the canvas drawing buffer in pixels, which may differ from
+	// its size on the page.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// The rendering context of the canvas: '2d', 'webgl', 'webgl2' or
+	// 'bitmaprenderer'.
+	Context string `json:"context"`
+
+	// Whether the WebGL drawing buffer is preserved between frames. If it
+	// isn't, the content was captured in an animation frame callback and may
+	// be blank if the page doesn't draw every frame, see
+	// PreserveDrawingBuffers.
+	Preserved bool `json:"preserved"`
+
+	// Whether every pixel of the canvas is transparent black.
+	Blank bool `json:"blank"`
+
+	// The PNG encoded content.
+	PNG []byte `json:"-"`
+}
+
+/*
+canvasCapture captures the canvas matching a selector as a PNG data URL. The
+context type is found by requesting each type in turn: getContext returns null
+for a type other than the one the canvas already has. A WebGL drawing buffer
+that isn't preserved is cleared once the frame is composited, so it's read in
+an animation frame callback after the page has drawn the frame.
+*/
+const canvasCapture = `(function (selector) {
+	return new Promise(function (resolve) {
+		var canvas = document.querySelector(selector);
+		if (!canvas) {
+			return resolve(JSON.stringify({error: 'no element matches the selector'}));
+		}
+		if ('CANVAS' !== canvas.tagName) {
+			return resolve(JSON.stringify({error: 'the element is a ' + canvas.tagName.toLowerCase() + ', not a canvas'}));
+		}
+		var report = {width: canvas.width, height: canvas.height, context: '', preserved: true};
+		var types = ['2d', 'webgl2', 'webgl', 'bitmaprenderer'];
+		for (var a = 0; a < types.length; a++) {
+			var ctx = canvas.getContext(types[a]);
+			if (ctx) {
+				report.context = types[a];
+				if (ctx.getContextAttributes && 'bitmaprenderer' !== types[a] && '2d' !== types[a]) {
+					report.preserved = !!ctx.getContextAttributes().preserveDrawingBuffer;
+				}
+				break;
+			}
+		}
+		var capture = function () {
+			try {
+				report.data = canvas.toDataURL('image/png');
+			} catch (e) {
+				report.tainted = 'SecurityError' === e.name;
+				report.error = String(e);
+				return resolve(JSON.stringify(report));
+			}
+			var blank = document.createElement('canvas');
+			blank.width = canvas.width;
+			blank.height = canvas.height;
+			report.blank = blank.toDataURL('image/png') === report.data;
+			resolve(JSON.stringify(report));
+		};
+		if (report.preserved) {
+			capture();
+		} else {
+			requestAnimationFrame(capture);
+		}
+	});
+})(%s)`
+
+/*
+preserveDrawingBuffers makes WebGL contexts preserve their drawing buffer
+unless the page explicitly asks not to.
+*/
+const preserveDrawingBuffers = `(function () {
+	var getContext = HTMLCanvasElement.prototype.getContext;
+	HTMLCanvasElement.prototype.getContext = function (type, attributes) {
+		if (/^(experimental-)?webgl2?$/.test(type)) {
+			attributes = Object.assign({preserveDrawingBuffer: true}, attributes);
+		}
+		return getContext.call(this, type, attributes);
+	};
+})();`
+
+/*
+CaptureCanvas captures the content of the canvas element matching a CSS
+selector as a PNG, for graphics regression tests that compare rendered output
+without the rest of the page:
+
+	capture, err := tab.CaptureCanvas("#chart")
+	if nil != err {
+		...
+	}
+	ioutil.WriteFile("chart.png", capture.PNG, 0644)
+
+Unlike a screenshot the capture is the canvas drawing buffer at its own
+resolution, unaffected by CSS scaling, overlapping elements or the viewport.
+
+A canvas that has drawn cross-origin images without CORS is tainted and can't
+be read, which fails with a TabCanvasTainted error.
+*/
+func (tab *Tab) CaptureCanvas(selector string) (*CanvasCapture, error) {
+	quoted, _ := json.Marshal(selector)
+	result := <-tab.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:    fmt.Sprintf(canvasCapture, quoted),
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s'", selector))
+	}
+	if nil != result.ExceptionDetails {
+		return nil, errs.New(codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s': %s", selector, tab.evalError(result.ExceptionDetails).Error()))
+	}
+	if nil == result.Result {
+		return nil, errs.New(codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s': no result", selector))
+	}
+	value, ok := result.Result.Value.(string)
+	if !ok {
+		return nil, errs.New(codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s': unexpected result", selector))
+	}
+
+	report := struct {
+		CanvasCapture
+		Data    string `json:"data"`
+		Error   string `json:"error"`
+		Tainted bool   `json:"tainted"`
+	}{}
+	if err := json.Unmarshal([]byte(value), &report); nil != err {
+		return nil, errs.Wrap(err, codes.TabCanvasCaptureFailed, fmt.Sprintf("could not decode the capture of canvas '%s'", selector))
+	}
+	if report.Tainted {
+		return nil, errs.New(codes.TabCanvasTainted, fmt.Sprintf("canvas '%s' is tainted by cross-origin content: %s", selector, report.Error))
+	}
+	if "" != report.Error {
+		return nil, errs.New(codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s': %s", selector, report.Error))
+	}
+
+	const prefix = "data:image/png;base64,"
+	if !strings.HasPrefix(report.Data, prefix) {
+		return nil, errs.New(codes.TabCanvasCaptureFailed, fmt.Sprintf("could not capture canvas '%s': not a PNG data URL", selector))
+	}
+	png, err := base64.StdEncoding.DecodeString(report.Data[len(prefix):])
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabCanvasCaptureFailed, fmt.Sprintf("could not decode the capture of canvas '%s'", selector))
+	}
+	capture := report.CanvasCapture
+	capture.PNG = png
+	return &capture, nil
+}
+
+/*
+PreserveDrawingBuffers makes WebGL contexts created by documents loaded in the
+tab after the call preserve their drawing buffer, so CaptureCanvas reads the
+last frame drawn even if the page doesn't draw continuously. Contexts that
+explicitly disable preserveDrawingBuffer are left as they are. Preserving the
+buffer can slow down rendering, so it's only meant for tests. The script is
+removed when the tab is closed.
+*/
+func (tab *Tab) PreserveDrawingBuffers() error {
+	result := <-tab.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+		Source: preserveDrawingBuffers,
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabCanvasCaptureFailed, "could not add the drawing buffer script")
+	}
+	identifier := result.Identifier
+	tab.OnClose(func() error {
+		return (<-tab.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+			Identifier: identifier,
+		})).Err
+	})
+	return nil
+}
@@ -0,0 +1,54 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestTabCaptureCanvas(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\ncontent")
+	browser, stop := newEvaluateBrowser(t, `{"width": 300, "height": 150, "context": "webgl", "preserved": false, "blank": false, "data": "data:image/png;base64,`+base64.StdEncoding.EncodeToString(png)+`"}`)
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	capture, err := tab.CaptureCanvas("#chart")
+	tab.Socket().Stop()
+	stop()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 300 != capture.Width || 150 != capture.Height || "webgl" != capture.Context || capture.Preserved || capture.Blank {
+		t.Errorf("Unexpected capture %+v", capture)
+	}
+	if string(png) != string(capture.PNG) {
+		t.Errorf("Expected the decoded PNG, received %q", capture.PNG)
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{`{"error": "no element matches the selector"}`, "no element matches"},
+		{`{"context": "2d", "tainted": true, "error": "SecurityError: Tainted canvases may not be exported."}`, "is tainted by cross-origin content"},
+		{`{"context": "2d", "data": "data:image/jpeg;base64,"}`, "not a PNG data URL"},
+	}
+	for _, test := range tests {
+		browser, stop := newEvaluateBrowser(t, test.value)
+		tab, err := browser.NewTab("about:blank")
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		_, err = tab.CaptureCanvas("#chart")
+		tab.Socket().Stop()
+		stop()
+		if nil == err {
+			t.Errorf("Expected error for %s, received nil", test.value)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Expected '%s' for %s, received %v", test.expected, test.value, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+ChallengeRule identifies a CAPTCHA or anti-bot interstitial by the URL of the
+page and the elements in it. A rule matches if every condition it sets is met.
+*/
+type ChallengeRule struct {
+	// The name reported in Challenge.Rule, like "recaptcha".
+	Name string
+
+	// Optional. A URL pattern of the page. Wildcards ('*' -> zero or more,
+	// '?' -> exactly one) are allowed.
+	URL string
+
+	// Optional. A CSS selector that matches an element of the challenge.
+	Selector string
+}
+
+/*
+ChallengeRules are the rules OnChallenge uses by default, they detect the
+widgets of common CAPTCHA services and the interstitials of common anti-bot
+services.
+*/
+var ChallengeRules = []*ChallengeRule{
+	{Name: "recaptcha", Selector: `iframe[src*="google.com/recaptcha"], iframe[src*="recaptcha.net/recaptcha"]`},
+	{Name: "hcaptcha", Selector: `iframe[src*="hcaptcha.com"]`},
+	{Name: "turnstile", Selector: `iframe[src*="challenges.cloudflare.com"]`},
+	{Name: "cloudflare", Selector: `#challenge-form, #challenge-running, #challenge-stage`},
+	{Name: "arkose", Selector: `iframe[src*="arkoselabs.com"], iframe[src*="funcaptcha.com"]`},
+	{Name: "datadome", Selector: `iframe[src*="captcha-delivery.com"]`},
+	{Name: "perimeterx", Selector: `#px-captcha`},
+	{Name: "google", URL: "https://www.google.com/sorry/*"},
+}
+
+/*
+Challenge is a CAPTCHA or anti-bot interstitial detected in a tab.
+*/
+type Challenge struct {
+	// The name of the rule that matched.
+	Rule string
+
+	// The URL of the page.
+	URL string
+}
+
+/*
+challengeMatch returns the index of the first rule whose selector matches an
+element of the page, or -1.
+*/
+const challengeMatch = `(function (rules) {
+	for (var a = 0; a < rules.length; a++) {
+		try {
+			if (document.querySelector(rules[a].selector)) {
+				return rules[a].index;
+			}
+		} catch (e) {
+		}
+	}
+	return -1;
+})(%s)`
+
+/*
+OnChallenge enables the Page domain and calls the callback when a page loaded
+in the tab shows a CAPTCHA or an anti-bot interstitial, so a pipeline can hand
+the tab over for manual solving or give up on the page instead of waiting for
+content that never loads:
+
+	ctx, cancel := context.WithCancel(ctx)
+	err := tab.OnChallenge(func(challenge *chrome.Challenge) {
+		log.Warnf("%s challenge at %s", challenge.Rule, challenge.URL)
+		cancel()
+	})
+
+ChallengeRules are used if no rules are specified. URL rules are checked when
+the main frame navigates, selector rules when the document is parsed and again
+when it has loaded, so widgets added by scripts later on aren't detected. The
+callback is called at most once per document, with the first matching rule.
+The handlers are removed when the tab is cleaned up.
+*/
+func (tab *Tab) OnChallenge(callback func(challenge *Challenge), rules ...*ChallengeRule) error {
+	if 0 == len(rules) {
+		rules = ChallengeRules
+	}
+	detector := &challengeDetector{
+		callback: callback,
+		mux:      &sync.Mutex{},
+		rules:    rules,
+		tab:      tab,
+		urls:     make([]*regexp.Regexp, len(rules)),
+	}
+	for a, rule := range rules {
+		if "" == rule.URL && "" == rule.Selector {
+			return errs.New(codes.TabChallengeFailed, fmt.Sprintf("challenge rule '%s' has neither a URL nor a selector", rule.Name))
+		}
+		if "" != rule.URL {
+			detector.urls[a] = wildcardPattern(rule.URL)
+		}
+	}
+
+	tab.AddEventHandler(socket.NewEventHandler("Page.frameNavigated", detector.navigated))
+	tab.AddEventHandler(socket.NewEventHandler("Page.domContentEventFired", detector.loaded))
+	tab.AddEventHandler(socket.NewEventHandler("Page.loadEventFired", detector.loaded))
+	if result := <-tab.Page().Enable(); nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabChallengeFailed, "could not enable the Page domain")
+	}
+	return nil
+}
+
+/*
+challengeDetector checks the documents loaded in a tab against challenge
+rules.
+*/
+type challengeDetector struct {
+	callback func(challenge *Challenge)
+	document int
+	mux      *sync.Mutex
+	reported bool
+	rules    []*ChallengeRule
+	tab      *Tab
+	url      string
+	urls     []*regexp.Regexp
+}
+
+/*
+navigated starts tracking a new document and checks the rules that only have
+a URL condition.
+*/
+func (detector *challengeDetector) navigated(response *socket.Response) {
+	event := &page.FrameNavigatedEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err || nil == event.Frame || "" != event.Frame.ParentID {
+		return
+	}
+	detector.mux.Lock()
+	detector.document++
+	detector.reported = false
+	detector.url = event.Frame.URL
+	document := detector.document
+	detector.mux.Unlock()
+
+	for a, rule := range detector.rules {
+		if "" == rule.Selector && detector.urls[a].MatchString(event.Frame.URL) {
+			detector.report(document, a)
+			return
+		}
+	}
+}
+
+/*
+loaded checks the rules with a selector condition. The page is evaluated in a
+goroutine so the event isn't held up by the evaluation.
+*/
+func (detector *challengeDetector) loaded(response *socket.Response) {
+	detector.mux.Lock()
+	document, url, reported := detector.document, detector.url, detector.reported
+	detector.mux.Unlock()
+	if reported {
+		return
+	}
+
+	type selectorRule struct {
+		Index    int    `json:"index"`
+		Selector string `json:"selector"`
+	}
+	candidates := make([]*selectorRule, 0, len(detector.rules))
+	for a, rule := range detector.rules {
+		if "" == rule.Selector || (nil != detector.urls[a] && !detector.urls[a].MatchString(url)) {
+			continue
+		}
+		candidates = append(candidates, &selectorRule{Index: a, Selector: rule.Selector})
+	}
+	if 0 == len(candidates) {
+		return
+	}
+	data, _ := json.Marshal(candidates)
+
+	go func() {
+		index := -1
+		if err := detector.tab.Eval(fmt.Sprintf(challengeMatch, data), &index); nil != err || index < 0 || index >= len(detector.rules) {
+			return
+		}
+		detector.report(document, index)
+	}()
+}
+
+/*
+report calls the callback once per document. Reports for a document that has
+been replaced by a navigation are dropped.
+*/
+func (detector *challengeDetector) report(document, index int) {
+	detector.mux.Lock()
+	if document != detector.document || detector.reported {
+		detector.mux.Unlock()
+		return
+	}
+	detector.reported = true
+	url := detector.url
+	detector.mux.Unlock()
+	detector.callback(&Challenge{Rule: detector.rules[index].Name, URL: url})
+}
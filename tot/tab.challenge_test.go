@@ -0,0 +1,83 @@
+package chrome
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTabOnChallenge(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	expressions := make(chan string, 10)
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		expression := struct{ Expression string }{}
+		json.Unmarshal(params, &expression)
+		expressions <- expression.Expression
+		return map[string]interface{}{"result": map[string]interface{}{"type": "number", "value": 0}}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	challenges := make(chan *Challenge, 10)
+	if err := tab.OnChallenge(func(challenge *Challenge) { challenges <- challenge }); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	enabled := false
+	for _, command := range cdp.Commands() {
+		if "Page.enable" == command.Method {
+			enabled = true
+		}
+	}
+	if !enabled {
+		t.Errorf("Expected the Page domain to be enabled")
+	}
+
+	cdp.Emit("Page.frameNavigated", map[string]interface{}{
+		"frame": map[string]interface{}{"id": "main", "url": "https://www.google.com/sorry/index?continue=1"},
+	})
+	select {
+	case challenge := <-challenges:
+		if "google" != challenge.Rule || "https://www.google.com/sorry/index?continue=1" != challenge.URL {
+			t.Errorf("Expected the google challenge, got %v", challenge)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the challenge to be reported")
+	}
+
+	cdp.Emit("Page.frameNavigated", map[string]interface{}{
+		"frame": map[string]interface{}{"id": "main", "url": "https://example.com/"},
+	})
+	cdp.Emit("Page.frameNavigated", map[string]interface{}{
+		"frame": map[string]interface{}{"id": "child", "parentId": "main", "url": "https://www.google.com/sorry/index"},
+	})
+	time.Sleep(50 * time.Millisecond)
+	cdp.Emit("Page.domContentEventFired", map[string]interface{}{"timestamp": 1})
+	select {
+	case challenge := <-challenges:
+		if "recaptcha" != challenge.Rule || "https://example.com/" != challenge.URL {
+			t.Errorf("Expected the recaptcha challenge, got %v", challenge)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the challenge to be reported")
+	}
+	if expression := <-expressions; !strings.Contains(expression, `"selector":"#px-captcha"`) || strings.Contains(expression, "google.com/sorry") {
+		t.Errorf("Expected the selector rules, got %q", expression)
+	}
+	cdp.Emit("Page.loadEventFired", map[string]interface{}{"timestamp": 2})
+	select {
+	case challenge := <-challenges:
+		t.Errorf("Expected one report per document, got %v", challenge)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := tab.OnChallenge(func(*Challenge) {}, &ChallengeRule{Name: "empty"}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
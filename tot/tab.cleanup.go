@@ -0,0 +1,119 @@
+package chrome
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+cleanupStack is a LIFO stack of functions that restore a tab to its original
+state. Event handlers are tracked separately so that handlers removed by the
+caller aren't removed a second time.
+*/
+type cleanupStack struct {
+	funcs    []*cleanupFunc
+	handlers map[socket.EventHandler]*cleanupFunc
+	mux      *sync.Mutex
+}
+
+/*
+cleanupFunc is a registered cleanup function. Disabled functions are skipped.
+*/
+type cleanupFunc struct {
+	disabled bool
+	fn       func() error
+}
+
+func newCleanupStack() *cleanupStack {
+	return &cleanupStack{
+		funcs:    make([]*cleanupFunc, 0),
+		handlers: make(map[socket.EventHandler]*cleanupFunc),
+		mux:      &sync.Mutex{},
+	}
+}
+
+func (stack *cleanupStack) add(fn func() error) *cleanupFunc {
+	cleanup := &cleanupFunc{fn: fn}
+	stack.mux.Lock()
+	stack.funcs = append(stack.funcs, cleanup)
+	stack.mux.Unlock()
+	return cleanup
+}
+
+func (stack *cleanupStack) addHandler(handler socket.EventHandler, fn func() error) {
+	cleanup := stack.add(fn)
+	stack.mux.Lock()
+	stack.handlers[handler] = cleanup
+	stack.mux.Unlock()
+}
+
+func (stack *cleanupStack) removeHandler(handler socket.EventHandler) {
+	stack.mux.Lock()
+	if cleanup, ok := stack.handlers[handler]; ok {
+		cleanup.disabled = true
+		delete(stack.handlers, handler)
+	}
+	stack.mux.Unlock()
+}
+
+/*
+run executes and clears all registered cleanup functions in reverse order of
+registration. Every function is executed even if an earlier one fails.
+*/
+func (stack *cleanupStack) run() []error {
+	stack.mux.Lock()
+	funcs := stack.funcs
+	stack.funcs = make([]*cleanupFunc, 0)
+	stack.handlers = make(map[socket.EventHandler]*cleanupFunc)
+	stack.mux.Unlock()
+
+	errors := make([]error, 0)
+	for a := len(funcs) - 1; a >= 0; a-- {
+		if funcs[a].disabled {
+			continue
+		}
+		if err := funcs[a].fn(); nil != err {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
+
+/*
+OnClose registers a function to be executed when the tab is cleaned up or
+closed. Cleanup functions run in reverse order of registration so that state
+is unwound in the order it was built up.
+
+Helpers that change the state of a tab register the matching cleanup here so
+that a cleaned up tab can be safely reused.
+*/
+func (tab *Tab) OnClose(fn func() error) {
+	tab.cleanup.add(fn)
+}
+
+/*
+Cleanup executes all registered cleanup functions and removes any event
+handlers that were added through the tab, restoring it to a pristine state
+without closing it. All cleanup functions are executed even if some of them
+fail, and the failures are combined into the returned error.
+*/
+func (tab *Tab) Cleanup() error {
+	errors := tab.cleanup.run()
+	if 0 == len(errors) {
+		return nil
+	}
+
+	messages := make([]string, len(errors))
+	for a, err := range errors {
+		messages[a] = err.Error()
+	}
+	err := errs.Wrap(errors[0], codes.TabCleanupFailed, fmt.Sprintf("%d cleanup function(s) failed: %s", len(errors), strings.Join(messages, "; ")))
+	tab.log().Debug(err.Error(), logger.Fields{"error": err, "tabID": tab.Data().ID})
+	return err
+}
@@ -0,0 +1,66 @@
+package chrome
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+func TestTabCleanup(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, err := browser.NewTab("https://TestTabCleanup")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+
+	order := make([]int, 0)
+	tab.OnClose(func() error {
+		order = append(order, 1)
+		return nil
+	})
+	tab.OnClose(func() error {
+		order = append(order, 2)
+		return errors.New("cleanup failed")
+	})
+	tab.OnClose(func() error {
+		order = append(order, 3)
+		return nil
+	})
+
+	if err := tab.Cleanup(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if 3 != len(order) || 3 != order[0] || 2 != order[1] || 1 != order[2] {
+		t.Errorf("Expected cleanup in reverse order, received %v", order)
+	}
+	if err := tab.Cleanup(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if 3 != len(order) {
+		t.Errorf("Expected cleanup functions to run once, received %v", order)
+	}
+}
+
+func TestTabCleanupHandlers(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabCleanupHandlers")
+
+	handler1 := socket.NewEventHandler("Page.loadEventFired", func(response *socket.Response) {})
+	handler2 := socket.NewEventHandler("Page.loadEventFired", func(response *socket.Response) {})
+	tab.AddEventHandler(handler1)
+	tab.AddEventHandler(handler2)
+	if 2 != len(tab.cleanup.handlers) {
+		t.Errorf("Expected 2 tracked handlers, received %d", len(tab.cleanup.handlers))
+	}
+	tab.RemoveEventHandler(handler1)
+	if 1 != len(tab.cleanup.handlers) {
+		t.Errorf("Expected 1 tracked handler, received %d", len(tab.cleanup.handlers))
+	}
+	if err := tab.Cleanup(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if 0 != len(tab.cleanup.handlers) || 0 != len(tab.cleanup.funcs) {
+		t.Errorf("Expected an empty cleanup stack")
+	}
+}
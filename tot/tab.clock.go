@@ -0,0 +1,291 @@
+package chrome
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+clockShim replaces Date, performance.now and the timer functions with a fake
+clock that only moves when it's told to. Timers are scheduled on a monotonic
+tick count, so changing the system time doesn't fire or delay them, the same
+way it doesn't in a real browser. Animation frames are scheduled every 16ms.
+Errors thrown by callbacks don't stop the clock, the first one is rethrown
+when advancing finishes so it's reported to the caller.
+*/
+const clockShim = `(function (now) {
+	if (window.__goChromeClock) {
+		return;
+	}
+	var RealDate = Date;
+	var slice = Array.prototype.slice;
+	var perfStart = performance.now();
+	var clock = {ticks: 0, offset: now, id: 0, timers: {}};
+
+	var FakeDate = function Date() {
+		if (!(this instanceof FakeDate)) {
+			return new RealDate(clock.offset + clock.ticks).toString();
+		}
+		if (0 === arguments.length) {
+			return new RealDate(clock.offset + clock.ticks);
+		}
+		return new (Function.prototype.bind.apply(RealDate, [null].concat(slice.call(arguments))))();
+	};
+	FakeDate.prototype = RealDate.prototype;
+	FakeDate.prototype.constructor = FakeDate;
+	FakeDate.now = function () {
+		return clock.offset + clock.ticks;
+	};
+	FakeDate.parse = RealDate.parse;
+	FakeDate.UTC = RealDate.UTC;
+	window.Date = FakeDate;
+	performance.now = function () {
+		return perfStart + clock.ticks;
+	};
+
+	var schedule = function (fn, delay, args, interval, frame) {
+		if ('function' !== typeof fn) {
+			fn = new Function(String(fn));
+		}
+		delay = Math.max(0, Number(delay) || 0);
+		var id = ++clock.id;
+		clock.timers[id] = {
+			id: id,
+			fn: fn,
+			args: args,
+			at: clock.ticks + (frame ? 16 - clock.ticks %% 16 : delay),
+			interval: interval ? Math.max(1, delay) : 0,
+			frame: frame
+		};
+		return id;
+	};
+	var cancel = function (id) {
+		delete clock.timers[id];
+	};
+	window.setTimeout = function (fn, delay) {
+		return schedule(fn, delay, slice.call(arguments, 2), false, false);
+	};
+	window.setInterval = function (fn, delay) {
+		return schedule(fn, delay, slice.call(arguments, 2), true, false);
+	};
+	window.requestAnimationFrame = function (fn) {
+		return schedule(fn, 0, [], false, true);
+	};
+	window.clearTimeout = cancel;
+	window.clearInterval = cancel;
+	window.cancelAnimationFrame = cancel;
+
+	clock.advance = function (ms) {
+		var end = clock.ticks + Math.max(0, ms);
+		var error = null;
+		for (;;) {
+			var next = null;
+			for (var id in clock.timers) {
+				var timer = clock.timers[id];
+				if (timer.at <= end && (!next || timer.at < next.at || (timer.at === next.at && timer.id < next.id))) {
+					next = timer;
+				}
+			}
+			if (!next) {
+				break;
+			}
+			clock.ticks = Math.max(clock.ticks, next.at);
+			if (next.interval) {
+				next.at += next.interval;
+			} else {
+				delete clock.timers[next.id];
+			}
+			try {
+				next.fn.apply(window, next.frame ? [performance.now()] : next.args);
+			} catch (e) {
+				error = error || e;
+			}
+		}
+		clock.ticks = end;
+		if (error) {
+			throw error;
+		}
+		return clock.offset + clock.ticks;
+	};
+	clock.setSystemTime = function (ms) {
+		clock.offset = ms - clock.ticks;
+		return clock.offset + clock.ticks;
+	};
+	clock.pending = function () {
+		return Object.keys(clock.timers).length;
+	};
+	Object.defineProperty(window, '__goChromeClock', {value: clock});
+})(%d);`
+
+/*
+Clock is a fake clock installed in the pages of a tab, see InstallClock.
+*/
+type Clock struct {
+	tab    *Tab
+	mux    sync.Mutex
+	now    time.Time
+	script page.ScriptIdentifier
+}
+
+/*
+InstallClock replaces the clock of the tab's pages with a fake one set to now,
+for testing timeouts, polling and animations without waiting for them:
+
+	clock, err := tab.InstallClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	if nil != err {
+		...
+	}
+	// the session expires after 30 minutes of inactivity
+	clock.Advance(30 * time.Minute)
+
+Date, performance.now, setTimeout, setInterval and requestAnimationFrame, and
+the functions that clear them, are replaced in the current page and in
+documents loaded after the call. Time only moves when Advance is called, which
+fires the timers that are due in order. Unlike virtual time, which moves the
+whole renderer, only the page's view of the clock is changed: network requests
+and rendering still happen in real time.
+
+Documents loaded after the call start at the time of the clock when they load.
+Timers don't survive a navigation, like in a real browser. The fake clock is
+removed when the tab is closed.
+*/
+func (tab *Tab) InstallClock(now time.Time) (*Clock, error) {
+	clock := &Clock{tab: tab, now: now}
+	if err := clock.register(); nil != err {
+		return nil, err
+	}
+	tab.OnClose(func() error {
+		clock.mux.Lock()
+		defer clock.mux.Unlock()
+		return clock.unregister()
+	})
+	if err := tab.Eval(clock.shim(), nil); nil != err {
+		return nil, errs.Wrap(err, codes.TabClockFailed, "could not install the clock in the current page")
+	}
+	return clock, nil
+}
+
+/*
+Advance moves the clock forward by d, firing the timers and animation frames
+that are due in order. Timers scheduled by the callbacks fire too if they're
+due before the end. The page clock has millisecond precision, d is rounded down
+to a whole number of milliseconds. An error thrown by a callback doesn't stop the clock, the
+first one is returned as an *EvalError once the clock has been advanced.
+*/
+func (clock *Clock) Advance(d time.Duration) error {
+	clock.mux.Lock()
+	defer clock.mux.Unlock()
+	if d < 0 {
+		return errs.New(codes.TabClockFailed, fmt.Sprintf("can't advance the clock by a negative duration %s", d))
+	}
+	d = d.Truncate(time.Millisecond)
+	clock.now = clock.now.Add(d)
+	err := clock.tab.Eval(fmt.Sprintf("window.__goChromeClock.advance(%d)", d/time.Millisecond), nil)
+	if regErr := clock.reregister(); nil != regErr {
+		return regErr
+	}
+	if _, ok := err.(*EvalError); ok {
+		return err
+	}
+	if nil != err {
+		return errs.Wrap(err, codes.TabClockFailed, "could not advance the clock")
+	}
+	return nil
+}
+
+/*
+Now returns the current time of the clock.
+*/
+func (clock *Clock) Now() time.Time {
+	clock.mux.Lock()
+	defer clock.mux.Unlock()
+	return clock.now
+}
+
+/*
+Pending returns the number of timers and animation frames waiting to fire in
+the current page.
+*/
+func (clock *Clock) Pending() (int, error) {
+	var pending int
+	if err := clock.tab.Eval("window.__goChromeClock.pending()", &pending); nil != err {
+		return 0, errs.Wrap(err, codes.TabClockFailed, "could not count the pending timers")
+	}
+	return pending, nil
+}
+
+/*
+SetSystemTime changes the time of the clock without firing any timers, like
+the system clock being changed. Timers stay due after the same amount of time
+since they're measured on a monotonic clock, as is performance.now.
+*/
+func (clock *Clock) SetSystemTime(now time.Time) error {
+	clock.mux.Lock()
+	defer clock.mux.Unlock()
+	clock.now = now
+	if err := clock.tab.Eval(fmt.Sprintf("window.__goChromeClock.setSystemTime(%d)", clock.millis()), nil); nil != err {
+		return errs.Wrap(err, codes.TabClockFailed, "could not set the system time")
+	}
+	return clock.reregister()
+}
+
+/*
+millis returns the time of the clock in milliseconds since the epoch.
+*/
+func (clock *Clock) millis() int64 {
+	return clock.now.UnixNano() / int64(time.Millisecond)
+}
+
+/*
+shim returns the script installing the clock at its current time.
+*/
+func (clock *Clock) shim() string {
+	return fmt.Sprintf(clockShim, clock.millis())
+}
+
+/*
+register adds the script installing the clock in new documents.
+*/
+func (clock *Clock) register() error {
+	result := <-clock.tab.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+		Source: clock.shim(),
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabClockFailed, "could not add the clock script")
+	}
+	clock.script = result.Identifier
+	return nil
+}
+
+/*
+unregister removes the script installing the clock in new documents.
+*/
+func (clock *Clock) unregister() error {
+	if "" == clock.script {
+		return nil
+	}
+	result := <-clock.tab.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+		Identifier: clock.script,
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabClockFailed, "could not remove the clock script")
+	}
+	clock.script = ""
+	return nil
+}
+
+/*
+reregister replaces the script installing the clock so new documents start at
+its current time.
+*/
+func (clock *Clock) reregister() error {
+	if err := clock.unregister(); nil != err {
+		return err
+	}
+	return clock.register()
+}
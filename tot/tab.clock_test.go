@@ -0,0 +1,98 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTabClock(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	mux := sync.Mutex{}
+	scripts := []string{}
+	expressions := []string{}
+	cdp.Handle("Page.addScriptToEvaluateOnNewDocument", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		source := struct{ Source string }{}
+		json.Unmarshal(params, &source)
+		scripts = append(scripts, source.Source)
+		return map[string]interface{}{"identifier": fmt.Sprintf("%d", len(scripts))}, nil
+	})
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		expression := struct{ Expression string }{}
+		json.Unmarshal(params, &expression)
+		expressions = append(expressions, expression.Expression)
+		if strings.Contains(expression.Expression, "advance(2000)") {
+			return map[string]interface{}{
+				"result": map[string]interface{}{"type": "object"},
+				"exceptionDetails": map[string]interface{}{
+					"exceptionId": 1,
+					"text":        "Uncaught",
+					"exception":   map[string]interface{}{"type": "object", "className": "Error", "description": "Error: boom"},
+				},
+			}, nil
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "number", "value": 2}}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock, err := tab.InstallClock(start)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if err := clock.Advance(1500*time.Millisecond + 300*time.Microsecond); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if !start.Add(1500 * time.Millisecond).Equal(clock.Now()) {
+		t.Errorf("Expected the clock to be advanced, got %s", clock.Now())
+	}
+	err = clock.Advance(2 * time.Second)
+	if evalErr, ok := err.(*EvalError); !ok || "Error" != evalErr.ClassName {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+	if err := clock.Advance(-time.Second); nil == err {
+		t.Errorf("Expected an error for a negative duration")
+	}
+	later := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := clock.SetSystemTime(later); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if pending, err := clock.Pending(); nil != err || 2 != pending {
+		t.Errorf("Expected 2 pending timers, got %d, %v", pending, err)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	expected := []string{
+		"})(1704099600000);",
+		"window.__goChromeClock.advance(1500)",
+		"window.__goChromeClock.advance(2000)",
+		"window.__goChromeClock.setSystemTime(1893456000000)",
+		"window.__goChromeClock.pending()",
+	}
+	if len(expected) != len(expressions) {
+		t.Fatalf("Expected %d evaluations, got %q", len(expected), expressions)
+	}
+	for a, expression := range expressions {
+		if !strings.HasSuffix(expression, expected[a]) {
+			t.Errorf("Expected evaluation %d to end with %q, got %q", a, expected[a], expression)
+		}
+	}
+	if 4 != len(scripts) || !strings.HasSuffix(scripts[2], "})(1704099603500);") || !strings.HasSuffix(scripts[3], "})(1893456000000);") {
+		t.Errorf("Expected the new document script to follow the clock, got %d scripts", len(scripts))
+	}
+}
@@ -0,0 +1,246 @@
+package chrome
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/sourcemap"
+)
+
+/*
+WithSourceMaps maps the stack frames of evaluation errors to the original
+sources with the source maps of the scripts, see EvalError. Scripts and source
+maps are loaded with the fetch function, or with HTTP GET requests if it's nil,
+once per script URL.
+*/
+func WithSourceMaps(fetch func(url string) ([]byte, error)) Option {
+	return func(chrome *Chrome) {
+		chrome.sourceMaps = sourcemap.NewResolver(fetch)
+	}
+}
+
+/*
+EvalError is an exception thrown by an evaluated expression, or the reason the
+promise it returned was rejected.
+*/
+type EvalError struct {
+	// The class of the thrown error, for example 'TypeError'. Empty if the
+	// value thrown isn't an object.
+	ClassName string
+
+	// The error message, or the value thrown if it isn't an error.
+	Message string
+
+	// The stack trace, innermost frame first. Frames are mapped to the
+	// original sources if the browser was created with WithSourceMaps.
+	Stack []*StackFrame
+
+	// The exception details reported by the browser.
+	Details *runtime.ExceptionDetails
+}
+
+/*
+Error implements error. The message is formatted like a JavaScript stack trace.
+*/
+func (err *EvalError) Error() string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(err.ClassName)
+	if "" != err.ClassName && "" != err.Message {
+		buffer.WriteString(": ")
+	}
+	buffer.WriteString(err.Message)
+	for _, frame := range err.Stack {
+		buffer.WriteString("\n    at ")
+		buffer.WriteString(frame.String())
+	}
+	return buffer.String()
+}
+
+/*
+StackFrame is a frame of an evaluation error stack trace. Lines and columns are
+1-based, like in JavaScript stack traces.
+*/
+type StackFrame struct {
+	FunctionName string
+	URL          string
+	Line         int
+	Column       int
+
+	// Whether the location was mapped to the original source.
+	Mapped bool
+}
+
+/*
+String formats the frame like a JavaScript stack trace frame.
+*/
+func (frame *StackFrame) String() string {
+	location := fmt.Sprintf("%s:%d:%d", frame.URL, frame.Line, frame.Column)
+	if "" == frame.FunctionName {
+		return location
+	}
+	return fmt.Sprintf("%s (%s)", frame.FunctionName, location)
+}
+
+/*
+Evaluation is the pending result of an expression evaluated with EvalAsync.
+Done is closed when the expression has been evaluated and the promise it
+returned, if any, has settled. Err and Decode wait for it.
+*/
+type Evaluation struct {
+	done  chan struct{}
+	err   error
+	value json.RawMessage
+}
+
+/*
+Done returns a channel that's closed when the evaluation has completed.
+*/
+func (evaluation *Evaluation) Done() <-chan struct{} {
+	return evaluation.done
+}
+
+/*
+Err waits for the evaluation and returns its error. Exceptions thrown by the
+expression and rejected promises are returned as an *EvalError.
+*/
+func (evaluation *Evaluation) Err() error {
+	<-evaluation.done
+	return evaluation.err
+}
+
+/*
+Decode waits for the evaluation and decodes the value into v, which is left
+unchanged if the value is undefined or null. The evaluation error is returned
+if it failed.
+*/
+func (evaluation *Evaluation) Decode(v interface{}) error {
+	if err := evaluation.Err(); nil != err {
+		return err
+	}
+	if nil == v || 0 == len(evaluation.value) {
+		return nil
+	}
+	if err := json.Unmarshal(evaluation.value, v); nil != err {
+		return errs.Wrap(err, codes.TabEvalFailed, fmt.Sprintf("could not decode the value into %T", v))
+	}
+	return nil
+}
+
+/*
+Eval evaluates a JavaScript expression in the tab's page and decodes its value
+into v, which may be nil to discard it. A promise returned by the expression is
+awaited, its resolved value is decoded:
+
+	var title string
+	if err := tab.Eval(`fetch("/api/title").then(r => r.text())`, &title); nil != err {
+		if evalErr, ok := err.(*chrome.EvalError); ok {
+			log.Error(evalErr.ClassName, evalErr.Message)
+		}
+		...
+	}
+
+Exceptions thrown by the expression and rejected promises are returned as an
+*EvalError.
+*/
+func (tab *Tab) Eval(expression string, v interface{}) error {
+	return tab.EvalAsync(expression).Decode(v)
+}
+
+/*
+EvalAsync evaluates a JavaScript expression like Eval without waiting for it,
+so many evaluations can be in progress at once. The evaluation completes when
+the promise the expression returns settles.
+*/
+func (tab *Tab) EvalAsync(expression string) *Evaluation {
+	evaluation := &Evaluation{done: make(chan struct{})}
+	resultChan := tab.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:    expression,
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	})
+	go func() {
+		defer close(evaluation.done)
+		result := <-resultChan
+		switch {
+		case nil != result.Err:
+			evaluation.err = errs.Wrap(result.Err, codes.TabEvalFailed, "could not evaluate the expression")
+		case nil != result.ExceptionDetails:
+			evaluation.err = tab.evalError(result.ExceptionDetails)
+		case nil != result.Result && nil != result.Result.Value:
+			evaluation.value, evaluation.err = json.Marshal(result.Result.Value)
+			if nil != evaluation.err {
+				evaluation.err = errs.Wrap(evaluation.err, codes.TabEvalFailed, "could not encode the value")
+			}
+		}
+	}()
+	return evaluation
+}
+
+/*
+evalError converts exception details into an EvalError, mapping the stack
+frames to the original sources if source maps are enabled.
+*/
+func (tab *Tab) evalError(details *runtime.ExceptionDetails) *EvalError {
+	err := &EvalError{
+		Details: details,
+		Message: details.Text,
+	}
+	if exception := details.Exception; nil != exception {
+		switch {
+		case "" != exception.ClassName:
+			err.ClassName = exception.ClassName
+			if "" != exception.Description {
+				// The description of an error is its stack, the message
+				// is the first line without the class name.
+				message := strings.SplitN(exception.Description, "\n", 2)[0]
+				err.Message = strings.TrimPrefix(strings.TrimPrefix(message, exception.ClassName), ": ")
+			}
+		case nil != exception.Value:
+			err.Message = fmt.Sprintf("%v", exception.Value)
+		case "" != exception.Description:
+			err.Message = exception.Description
+		}
+	}
+
+	if nil != details.StackTrace {
+		for _, callFrame := range details.StackTrace.CallFrames {
+			err.Stack = append(err.Stack, tab.stackFrame(callFrame))
+		}
+	}
+	return err
+}
+
+/*
+stackFrame converts a protocol call frame, mapping it to the original source if
+source maps are enabled and the script has one.
+*/
+func (tab *Tab) stackFrame(callFrame *runtime.CallFrame) *StackFrame {
+	frame := &StackFrame{
+		FunctionName: callFrame.FunctionName,
+		URL:          callFrame.URL,
+		Line:         callFrame.LineNumber + 1,
+		Column:       callFrame.ColumnNumber + 1,
+	}
+	if nil == tab.sourceMaps || "" == callFrame.URL {
+		return frame
+	}
+	position, err := tab.sourceMaps.Resolve(callFrame.URL, callFrame.LineNumber, callFrame.ColumnNumber)
+	if nil != err {
+		tab.log().Debug("could not map a stack frame", logger.Fields{"error": err, "url": callFrame.URL})
+		return frame
+	}
+	if nil == position {
+		return frame
+	}
+	frame.URL = position.Source
+	frame.Line = position.Line + 1
+	frame.Column = position.Column + 1
+	frame.Mapped = true
+	return frame
+}
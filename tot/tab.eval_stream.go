@@ -0,0 +1,150 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	cdpio "github.com/mkenney/go-chrome/tot/io"
+	"github.com/mkenney/go-chrome/tot/runtime"
+)
+
+/*
+evalStreamChunk is the number of bytes requested by each IO.read call of an
+evaluation stream.
+*/
+const evalStreamChunk = 1 << 20
+
+/*
+evalStreamScript serializes the value of an expression to a JSON Blob in the
+page. An undefined value is serialized as null.
+*/
+const evalStreamScript = `(async () => {
+	const json = JSON.stringify(await (%s));
+	return new Blob([undefined === json ? "null" : json], {type: "application/json"});
+})()`
+
+/*
+EvalStream evaluates a JavaScript expression in the tab's page and returns its
+value as a JSON stream. The value is serialized in the page to a Blob that's
+read in chunks with IO.read, so it isn't limited by the maximum size of a
+protocol message like the value returned by Eval:
+
+	stream, err := tab.EvalStream(`fetch("/api/export").then(r => r.json())`)
+	if nil != err {
+		...
+	}
+	defer stream.Close()
+	decoder := json.NewDecoder(stream)
+	...
+
+A promise returned by the expression is awaited. Exceptions thrown by the
+expression and rejected promises are returned as an *EvalError. The stream must
+be closed, which releases the Blob.
+*/
+func (tab *Tab) EvalStream(expression string) (io.ReadCloser, error) {
+	result := <-tab.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:   fmt.Sprintf(evalStreamScript, expression),
+		AwaitPromise: true,
+	})
+	switch {
+	case nil != result.Err:
+		return nil, errs.Wrap(result.Err, codes.TabEvalFailed, "could not evaluate the expression")
+	case nil != result.ExceptionDetails:
+		return nil, tab.evalError(result.ExceptionDetails)
+	case nil == result.Result || "" == result.Result.ObjectID:
+		return nil, errs.New(codes.TabEvalFailed, "the evaluation did not return a Blob")
+	}
+
+	stream := &evalStream{objectID: result.Result.ObjectID, tab: tab}
+	blob := <-tab.IO().ResolveBlob(&cdpio.ResolveBlobParams{ObjectID: stream.objectID})
+	if nil != blob.Err {
+		stream.release()
+		return nil, errs.Wrap(blob.Err, codes.TabEvalFailed, "could not resolve the Blob of the value")
+	}
+	stream.handle = cdpio.StreamHandle("blob:" + blob.UUID)
+	return stream, nil
+}
+
+/*
+EvalLarge evaluates a JavaScript expression like Eval and decodes its value
+into v from a stream, see EvalStream. It's meant for values too large to be
+returned in one protocol message, such as big JSON extractions.
+*/
+func (tab *Tab) EvalLarge(expression string, v interface{}) error {
+	stream, err := tab.EvalStream(expression)
+	if nil != err {
+		return err
+	}
+	defer stream.Close()
+	if nil == v {
+		return nil
+	}
+	if err := json.NewDecoder(stream).Decode(v); nil != err {
+		return errs.Wrap(err, codes.TabEvalFailed, fmt.Sprintf("could not decode the value into %T", v))
+	}
+	return nil
+}
+
+/*
+evalStream reads the JSON Blob of an evaluated value.
+*/
+type evalStream struct {
+	buf      []byte
+	eof      bool
+	handle   cdpio.StreamHandle
+	objectID runtime.RemoteObjectID
+	tab      *Tab
+}
+
+/*
+Read implements io.Reader.
+*/
+func (stream *evalStream) Read(p []byte) (int, error) {
+	for 0 == len(stream.buf) {
+		if stream.eof {
+			return 0, io.EOF
+		}
+		result := <-stream.tab.IO().Read(&cdpio.ReadParams{
+			Handle: stream.handle,
+			Size:   evalStreamChunk,
+		})
+		if nil != result.Err {
+			return 0, errs.Wrap(result.Err, codes.TabEvalFailed, "could not read the value")
+		}
+		stream.buf = []byte(result.Data)
+		if result.Base64Encoded {
+			data, err := base64.StdEncoding.DecodeString(result.Data)
+			if nil != err {
+				return 0, errs.Wrap(err, codes.TabEvalFailed, "could not decode a chunk of the value")
+			}
+			stream.buf = data
+		}
+		stream.eof = result.EOF
+	}
+	n := copy(p, stream.buf)
+	stream.buf = stream.buf[n:]
+	return n, nil
+}
+
+/*
+Close implements io.Closer. It closes the stream and releases the Blob.
+*/
+func (stream *evalStream) Close() error {
+	result := <-stream.tab.IO().Close(&cdpio.CloseParams{Handle: stream.handle})
+	stream.release()
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabEvalFailed, "could not close the stream of the value")
+	}
+	return nil
+}
+
+/*
+release releases the Blob object.
+*/
+func (stream *evalStream) release() {
+	<-stream.tab.Runtime().ReleaseObject(&runtime.ReleaseObjectParams{ObjectID: stream.objectID})
+}
@@ -0,0 +1,116 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	cdpio "github.com/mkenney/go-chrome/tot/io"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func newEvalStreamTab(t *testing.T) (*Tab, *cdptest.Server, func() []string) {
+	browser, cdp, stop := newCDPBrowser(t)
+	mux := &sync.Mutex{}
+	released := []string{}
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		if !evaluate.AwaitPromise || evaluate.ReturnByValue || !strings.Contains(evaluate.Expression, "new Blob(") {
+			return nil, &cdptest.Error{Code: -32602, Message: "expected a Blob returned by reference"}
+		}
+		if strings.Contains(evaluate.Expression, "(thrown)") {
+			return evalResponses["thrown"], nil
+		}
+		return map[string]interface{}{
+			"result": map[string]interface{}{"type": "object", "className": "Blob", "objectId": "blob-1"},
+		}, nil
+	})
+	cdp.Handle("IO.resolveBlob", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return map[string]interface{}{"uuid": "6e8a1c6a"}, nil
+	})
+	reads := 0
+	cdp.Handle("IO.read", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		read := &cdpio.ReadParams{}
+		json.Unmarshal(params, read)
+		if "blob:6e8a1c6a" != read.Handle {
+			return nil, &cdptest.Error{Code: -32602, Message: "unknown handle"}
+		}
+		reads++
+		if 1 == reads {
+			return map[string]interface{}{"base64Encoded": true, "data": base64.StdEncoding.EncodeToString([]byte(`{"rows": [1, 2`)), "eof": false}, nil
+		}
+		return map[string]interface{}{"data": `, 3]}`, "eof": true}, nil
+	})
+	cdp.Handle("IO.close", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		released = append(released, "IO.close")
+		mux.Unlock()
+		return map[string]interface{}{}, nil
+	})
+	cdp.Handle("Runtime.releaseObject", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		released = append(released, "Runtime.releaseObject")
+		mux.Unlock()
+		return map[string]interface{}{}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		stop()
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	return tab, cdp, func() []string {
+		tab.Socket().Stop()
+		stop()
+		mux.Lock()
+		defer mux.Unlock()
+		return released
+	}
+}
+
+func TestTabEvalStream(t *testing.T) {
+	tab, _, stop := newEvalStreamTab(t)
+
+	stream, err := tab.EvalStream("rows")
+	if nil != err {
+		stop()
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	data, err := ioutil.ReadAll(stream)
+	if nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if `{"rows": [1, 2, 3]}` != string(data) {
+		t.Errorf("Expected the chunks to be joined, got '%s'", data)
+	}
+	if err := stream.Close(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if released := stop(); 2 != len(released) {
+		t.Errorf("Expected the stream to be closed and the Blob released, got %v", released)
+	}
+}
+
+func TestTabEvalLarge(t *testing.T) {
+	tab, _, stop := newEvalStreamTab(t)
+	defer stop()
+
+	value := struct {
+		Rows []int `json:"rows"`
+	}{}
+	if err := tab.EvalLarge("rows", &value); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 3 != len(value.Rows) || 3 != value.Rows[2] {
+		t.Errorf("Expected the value to be decoded, got %+v", value)
+	}
+	if err := tab.EvalLarge("thrown", nil); nil == err {
+		t.Errorf("Expected an error")
+	} else if evalErr, ok := err.(*EvalError); !ok || "nope" != evalErr.Message {
+		t.Errorf("Expected an *EvalError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,174 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+evalResponses are the Runtime.evaluate results returned for test expressions.
+*/
+var evalResponses = map[string]interface{}{
+	"title": map[string]interface{}{
+		"result": map[string]interface{}{"type": "object", "value": map[string]interface{}{"title": "Example Domain", "links": 1}},
+	},
+	"undefined": map[string]interface{}{
+		"result": map[string]interface{}{"type": "undefined"},
+	},
+	"rejected": map[string]interface{}{
+		"result": map[string]interface{}{"type": "object", "subtype": "error", "className": "TypeError"},
+		"exceptionDetails": map[string]interface{}{
+			"exceptionId": 1,
+			"text":        "Uncaught (in promise)",
+			"exception": map[string]interface{}{
+				"type":        "object",
+				"subtype":     "error",
+				"className":   "TypeError",
+				"description": "TypeError: Cannot read properties of undefined (reading 'title')\n    at load (https://example.com/app.min.js:1:10)",
+			},
+			"stackTrace": map[string]interface{}{
+				"callFrames": []map[string]interface{}{
+					{"functionName": "load", "scriptId": "1", "url": "https://example.com/app.min.js", "lineNumber": 0, "columnNumber": 9},
+					{"functionName": "", "scriptId": "2", "url": "https://example.com/plain.js", "lineNumber": 4, "columnNumber": 2},
+				},
+			},
+		},
+	},
+	"thrown": map[string]interface{}{
+		"result": map[string]interface{}{"type": "string", "value": "nope"},
+		"exceptionDetails": map[string]interface{}{
+			"exceptionId": 2,
+			"text":        "Uncaught",
+			"exception":   map[string]interface{}{"type": "string", "value": "nope"},
+		},
+	},
+}
+
+func newEvalTab(t *testing.T) (*Tab, *cdptest.Server, func()) {
+	browser, cdp, stop := newCDPBrowser(t)
+	WithSourceMaps(func(url string) ([]byte, error) {
+		switch url {
+		case "https://example.com/app.min.js":
+			return []byte("function load(){}\n//# sourceMappingURL=app.min.js.map"), nil
+		case "https://example.com/app.min.js.map":
+			return []byte(`{"version": 3, "sources": ["src/app.ts"], "names": [], "mappings": "AAAA,QAEE"}`), nil
+		case "https://example.com/plain.js":
+			return []byte("function plain(){}"), nil
+		}
+		return nil, fmt.Errorf("%s not found", url)
+	})(browser)
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		if !evaluate.AwaitPromise || !evaluate.ReturnByValue {
+			return nil, &cdptest.Error{Code: -32602, Message: "expected awaitPromise and returnByValue"}
+		}
+		if response, ok := evalResponses[evaluate.Expression]; ok {
+			return response, nil
+		}
+		return nil, &cdptest.Error{Code: -32000, Message: "unexpected expression"}
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		stop()
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	return tab, cdp, func() {
+		tab.Socket().Stop()
+		stop()
+	}
+}
+
+func TestTabEval(t *testing.T) {
+	tab, _, stop := newEvalTab(t)
+	defer stop()
+
+	page := struct {
+		Title string `json:"title"`
+		Links int    `json:"links"`
+	}{}
+	if err := tab.Eval("title", &page); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if "Example Domain" != page.Title || 1 != page.Links {
+		t.Errorf("Expected the value to be decoded, got %+v", page)
+	}
+	value := "unchanged"
+	if err := tab.Eval("undefined", &value); nil != err || "unchanged" != value {
+		t.Errorf("Expected undefined to leave the value unchanged, got '%s' %v", value, err)
+	}
+	if err := tab.Eval("title", nil); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	var wrongType int
+	if err := tab.Eval("title", &wrongType); nil == err {
+		t.Errorf("Expected a decoding error")
+	}
+	if err := tab.Eval("unknown", nil); nil == err {
+		t.Errorf("Expected a protocol error")
+	} else if _, ok := err.(*EvalError); ok {
+		t.Errorf("Expected a protocol error not to be an EvalError")
+	}
+}
+
+func TestTabEvalError(t *testing.T) {
+	tab, _, stop := newEvalTab(t)
+	defer stop()
+
+	err := tab.Eval("rejected", nil)
+	evalErr, ok := err.(*EvalError)
+	if !ok {
+		t.Fatalf("Expected an *EvalError, got %T: %v", err, err)
+	}
+	if "TypeError" != evalErr.ClassName || "Cannot read properties of undefined (reading 'title')" != evalErr.Message {
+		t.Errorf("Expected the class name and message, got '%s' '%s'", evalErr.ClassName, evalErr.Message)
+	}
+	if 2 != len(evalErr.Stack) {
+		t.Fatalf("Expected 2 stack frames, got %d", len(evalErr.Stack))
+	}
+	mapped := evalErr.Stack[0]
+	if !mapped.Mapped || "https://example.com/src/app.ts" != mapped.URL || 3 != mapped.Line || 3 != mapped.Column || "load" != mapped.FunctionName {
+		t.Errorf("Expected the frame to be mapped to https://example.com/src/app.ts:3:3, got %+v", mapped)
+	}
+	if unmapped := evalErr.Stack[1]; unmapped.Mapped || "https://example.com/plain.js" != unmapped.URL || 5 != unmapped.Line || 3 != unmapped.Column {
+		t.Errorf("Expected the frame not to be mapped, got %+v", unmapped)
+	}
+	expected := "TypeError: Cannot read properties of undefined (reading 'title')\n" +
+		"    at load (https://example.com/src/app.ts:3:3)\n" +
+		"    at https://example.com/plain.js:5:3"
+	if expected != evalErr.Error() {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, evalErr.Error())
+	}
+
+	err = tab.Eval("thrown", nil)
+	if evalErr, ok := err.(*EvalError); !ok || "" != evalErr.ClassName || "nope" != evalErr.Message || "nope" != evalErr.Error() {
+		t.Errorf("Expected the thrown value as the message, got %#v", err)
+	}
+}
+
+func TestTabEvalAsync(t *testing.T) {
+	tab, cdp, stop := newEvalTab(t)
+	defer stop()
+	cdp.SetChaos(cdptest.Chaos{Latency: 50 * time.Millisecond})
+
+	evaluations := []*Evaluation{tab.EvalAsync("title"), tab.EvalAsync("rejected"), tab.EvalAsync("undefined")}
+	for _, evaluation := range evaluations {
+		select {
+		case <-evaluation.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the evaluation to complete")
+		}
+	}
+	if nil != evaluations[0].Err() || nil != evaluations[2].Err() {
+		t.Errorf("Expected nil, received errors: %v, %v", evaluations[0].Err(), evaluations[2].Err())
+	}
+	if err := evaluations[1].Err(); nil == err || !strings.HasPrefix(err.Error(), "TypeError: ") {
+		t.Errorf("Expected the rejection, got %v", err)
+	}
+}
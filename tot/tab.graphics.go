@@ -0,0 +1,145 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/runtime"
+)
+
+/*
+GraphicsMode is how the browser renders WebGL.
+*/
+type GraphicsMode string
+
+/*
+Graphics modes.
+*/
+const (
+	// GraphicsGPU renders on the GPU.
+	GraphicsGPU GraphicsMode = "gpu"
+
+	// GraphicsSoftware renders with a software renderer such as SwiftShader.
+	GraphicsSoftware GraphicsMode = "software"
+
+	// GraphicsUnavailable can't render WebGL, canvases stay blank.
+	GraphicsUnavailable GraphicsMode = "unavailable"
+)
+
+/*
+GraphicsReport is the result of a graphics probe.
+*/
+type GraphicsReport struct {
+	// How the browser renders WebGL.
+	Mode GraphicsMode `json:"mode"`
+
+	// Whether a WebGL context could be created and drawn to.
+	WebGL bool `json:"webgl"`
+
+	// Whether a WebGL 2 context could be created.
+	WebGL2 bool `json:"webgl2"`
+
+	// The unmasked WebGL vendor and renderer, for example 'Google Inc.
+	// (Google)' and 'ANGLE (Google, Vulkan 1.3.0 (SwiftShader Device
+	// (Subzero)), SwiftShader driver)'.
+	Vendor   string `json:"vendor"`
+	Renderer string `json:"renderer"`
+
+	// Why WebGL isn't available, if it isn't.
+	Error string `json:"error,omitempty"`
+
+	// Whether the browser was relaunched with SwiftShader because the GPU
+	// didn't work. Set by WithGraphicsFallback.
+	Fallback bool `json:"fallback"`
+}
+
+/*
+softwareRenderer matches the names of software WebGL renderers.
+*/
+var softwareRenderer = regexp.MustCompile(`(?i)swiftshader|llvmpipe|softpipe|software|basic render`)
+
+/*
+graphicsProbe creates a WebGL context, clears it to green and reads a pixel
+back, so a context that's created but can't draw is detected as well.
+*/
+const graphicsProbe = `(function () {
+	var report = {webgl: false, webgl2: false, vendor: '', renderer: ''};
+	try {
+		var canvas = document.createElement('canvas');
+		canvas.width = canvas.height = 4;
+		var gl = canvas.getContext('webgl', {preserveDrawingBuffer: true});
+		if (!gl) {
+			report.error = 'could not create a WebGL context';
+			return JSON.stringify(report);
+		}
+		var info = gl.getExtension('WEBGL_debug_renderer_info');
+		report.vendor = String(gl.getParameter(info ? info.UNMASKED_VENDOR_WEBGL : gl.VENDOR));
+		report.renderer = String(gl.getParameter(info ? info.UNMASKED_RENDERER_WEBGL : gl.RENDERER));
+		gl.clearColor(0, 1, 0, 1);
+		gl.clear(gl.COLOR_BUFFER_BIT);
+		var pixel = new Uint8Array(4);
+		gl.readPixels(0, 0, 1, 1, gl.RGBA, gl.UNSIGNED_BYTE, pixel);
+		report.webgl = 255 === pixel[1] && 0 === pixel[0];
+		if (!report.webgl) {
+			report.error = 'WebGL drew nothing';
+		}
+		report.webgl2 = !!document.createElement('canvas').getContext('webgl2');
+	} catch (e) {
+		report.error = String(e);
+	}
+	return JSON.stringify(report);
+})()`
+
+/*
+ProbeGraphics checks whether WebGL works in the tab and whether it's rendered
+on the GPU or in software. A browser without a working GPU or software
+renderer produces blank canvases in screenshots, which the probe detects
+before any are taken:
+
+	report, err := tab.ProbeGraphics()
+	if nil != err {
+		...
+	}
+	if chrome.GraphicsUnavailable == report.Mode {
+		...
+	}
+
+The probe runs in the current document of the tab and doesn't navigate.
+*/
+func (tab *Tab) ProbeGraphics() (*GraphicsReport, error) {
+	result := <-tab.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:    graphicsProbe,
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.ChromeGraphicsProbeFailed, "could not run the graphics probe")
+	}
+	if nil != result.ExceptionDetails {
+		return nil, errs.New(codes.ChromeGraphicsProbeFailed, fmt.Sprintf("the graphics probe threw an exception: %s", tab.evalError(result.ExceptionDetails).Error()))
+	}
+	if nil == result.Result {
+		return nil, errs.New(codes.ChromeGraphicsProbeFailed, "the graphics probe returned nothing")
+	}
+	value, ok := result.Result.Value.(string)
+	if !ok {
+		return nil, errs.New(codes.ChromeGraphicsProbeFailed, "the graphics probe returned an unexpected value")
+	}
+
+	report := &GraphicsReport{}
+	if err := json.Unmarshal([]byte(value), report); nil != err {
+		return nil, errs.Wrap(err, codes.ChromeGraphicsProbeFailed, "could not decode the graphics probe")
+	}
+	switch {
+	case !report.WebGL:
+		report.Mode = GraphicsUnavailable
+	case softwareRenderer.MatchString(report.Renderer):
+		report.Mode = GraphicsSoftware
+	default:
+		report.Mode = GraphicsGPU
+	}
+	return report, nil
+}
@@ -0,0 +1,88 @@
+package chrome
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+newCDPBrowser returns a browser whose tabs connect to a cdptest server.
+*/
+func newCDPBrowser(t *testing.T) (*Chrome, *cdptest.Server, func()) {
+	cdp := cdptest.NewServer(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/json/new") {
+			w.Write([]byte(`{"id": "1", "webSocketDebuggerUrl": "ws://` + cdp.URL().Host + `/devtools/page/cdptest"}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/json/version") {
+			w.Write([]byte(`{"webSocketDebuggerUrl": "` + cdp.BrowserURL().String() + `"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	return New(WithAddress(serverURL.Hostname()), WithPort(port)), cdp, func() {
+		server.Close()
+		cdp.Close()
+	}
+}
+
+/*
+newEvaluateBrowser returns a browser whose tabs connect to a cdptest server
+that returns the value for every Runtime.evaluate command.
+*/
+func newEvaluateBrowser(t *testing.T, value string) (*Chrome, func()) {
+	browser, cdp, stop := newCDPBrowser(t)
+	cdp.Respond("Runtime.evaluate", map[string]interface{}{
+		"result": map[string]interface{}{"type": "string", "value": value},
+	})
+	return browser, stop
+}
+
+func TestTabProbeGraphics(t *testing.T) {
+	tests := []struct {
+		probe string
+		mode  GraphicsMode
+	}{
+		{`{"webgl": true, "webgl2": true, "vendor": "Google Inc. (NVIDIA)", "renderer": "ANGLE (NVIDIA, GeForce RTX 3060)"}`, GraphicsGPU},
+		{`{"webgl": true, "renderer": "ANGLE (Google, Vulkan 1.3.0 (SwiftShader Device (Subzero)), SwiftShader driver)"}`, GraphicsSoftware},
+		{`{"webgl": true, "renderer": "llvmpipe (LLVM 15.0.7, 256 bits)"}`, GraphicsSoftware},
+		{`{"webgl": false, "error": "could not create a WebGL context"}`, GraphicsUnavailable},
+	}
+	for _, test := range tests {
+		browser, stop := newEvaluateBrowser(t, test.probe)
+		tab, err := browser.NewTab("about:blank")
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		report, err := tab.ProbeGraphics()
+		tab.Socket().Stop()
+		stop()
+		if nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+			continue
+		}
+		if test.mode != report.Mode {
+			t.Errorf("Expected %s for %s, received %s", test.mode, test.probe, report.Mode)
+		}
+	}
+
+	browser, stop := newEvaluateBrowser(t, `not json`)
+	defer stop()
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+	if _, err := tab.ProbeGraphics(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
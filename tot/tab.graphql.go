@@ -0,0 +1,389 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+GraphQLOperation is a GraphQL operation sent by a tab, and its response.
+*/
+type GraphQLOperation struct {
+	// The name of the operation, from the operationName field of the request
+	// or the operation definition in the query.
+	Name string
+
+	// The operation type, "query", "mutation" or "subscription".
+	Type string
+
+	// The query document. Empty for persisted queries.
+	Query string
+
+	// The variables of the operation.
+	Variables map[string]interface{}
+
+	// The position of the operation in a batched request, 0 if the request
+	// isn't batched.
+	Index int
+
+	// Whether the operation was sent in a batched request.
+	Batched bool
+
+	// The request that sent the operation.
+	Request *Request
+
+	// The response to the operation.
+	Response *Response
+}
+
+/*
+GraphQLError is an error returned by a GraphQL server.
+*/
+type GraphQLError struct {
+	// The error message.
+	Message string `json:"message"`
+
+	// Optional. The path of the response field that failed.
+	Path []interface{} `json:"path,omitempty"`
+
+	// Optional. Server-specific error details.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+/*
+graphQLPayload is an operation in a GraphQL request body.
+*/
+type graphQLPayload struct {
+	OperationName string                 `json:"operationName"`
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+/*
+graphQLDefinition matches the first operation definition of a query document.
+*/
+var graphQLDefinition = regexp.MustCompile(`^\s*(query|mutation|subscription)\b\s*([_A-Za-z][_0-9A-Za-z]*)?`)
+
+/*
+ParseGraphQLRequest returns the operations in a GraphQL request body, a JSON
+object or a batch of objects with a query or an operationName. An error is
+returned if the body isn't a GraphQL request.
+*/
+func ParseGraphQLRequest(body []byte) ([]*GraphQLOperation, error) {
+	var payloads []*graphQLPayload
+	batched := strings.HasPrefix(strings.TrimSpace(string(body)), "[")
+	if batched {
+		if err := json.Unmarshal(body, &payloads); nil != err {
+			return nil, errs.Wrap(err, codes.TabGraphQLFailed, "the body isn't a GraphQL request")
+		}
+	} else {
+		payload := &graphQLPayload{}
+		if err := json.Unmarshal(body, payload); nil != err {
+			return nil, errs.Wrap(err, codes.TabGraphQLFailed, "the body isn't a GraphQL request")
+		}
+		payloads = append(payloads, payload)
+	}
+
+	operations := make([]*GraphQLOperation, 0, len(payloads))
+	for a, payload := range payloads {
+		if nil == payload || ("" == payload.Query && "" == payload.OperationName) {
+			return nil, errs.New(codes.TabGraphQLFailed, "the body isn't a GraphQL request")
+		}
+		operation := &GraphQLOperation{
+			Name:      payload.OperationName,
+			Type:      "query",
+			Query:     payload.Query,
+			Variables: payload.Variables,
+			Index:     a,
+			Batched:   batched,
+		}
+		if match := graphQLDefinition.FindStringSubmatch(payload.Query); nil != match {
+			operation.Type = match[1]
+			if "" == operation.Name {
+				operation.Name = match[2]
+			}
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+/*
+WaitForOperation blocks until the tab receives the response to a GraphQL
+operation, or the context is done. Operations are matched by name in POST
+requests to the endpoints, URL patterns that work like they do for
+WaitForRequest. Without endpoints every request with a GraphQL body is
+matched:
+
+	operation, err := tab.WaitForOperation(ctx, "SearchProducts", "https://*.example.com/graphql")
+	if nil != err {
+		...
+	}
+	if err := operation.AssertNoErrors(); nil != err {
+		...
+	}
+
+The Network domain must be enabled. Only operations sent after
+WaitForOperation is called are matched, so it's usually called in a goroutine
+before triggering the operation.
+*/
+func (tab *Tab) WaitForOperation(ctx context.Context, name string, endpoints ...string) (*GraphQLOperation, error) {
+	waiter := &graphQLWaiter{
+		done:     make(chan struct{}),
+		name:     name,
+		mux:      &sync.Mutex{},
+		requests: make(map[network.RequestID]*graphQLRequest),
+		tab:      tab,
+	}
+	for _, endpoint := range endpoints {
+		waiter.endpoints = append(waiter.endpoints, urlPattern(endpoint))
+	}
+	defer close(waiter.done)
+	handler := socket.NewEventHandler("Network.requestWillBeSent", waiter.sent)
+	tab.AddEventHandler(handler)
+	defer tab.RemoveEventHandler(handler)
+
+	response, err := tab.WaitForResponse(ctx, "", waiter.matches)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabWaitFailed, fmt.Sprintf("no GraphQL operation '%s'", name))
+	}
+	request, _ := waiter.request(response.RequestID)
+	operation := request.operation
+	operation.Response = response
+	return operation, nil
+}
+
+/*
+graphQLRequest is a request classified by a graphQLWaiter. ready is closed
+once the request is classified.
+*/
+type graphQLRequest struct {
+	operation *GraphQLOperation
+	ready     chan struct{}
+}
+
+/*
+graphQLWaiter matches GraphQL operations to their responses. Event handlers
+run concurrently, so a response can be handled before the request it belongs
+to has been classified. done is closed when WaitForOperation returns.
+*/
+type graphQLWaiter struct {
+	done      chan struct{}
+	endpoints []*regexp.Regexp
+	mux       *sync.Mutex
+	name      string
+	requests  map[network.RequestID]*graphQLRequest
+	tab       *Tab
+}
+
+/*
+sent classifies a request sent to an endpoint.
+*/
+func (waiter *graphQLWaiter) sent(response *socket.Response) {
+	event := &network.RequestWillBeSentEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err || nil == event.Request {
+		return
+	}
+	if !waiter.endpoint(event.Request.URL) {
+		return
+	}
+	// Redirects send the request again with the same ID, it's classified
+	// once.
+	waiter.mux.Lock()
+	request, ok := waiter.requests[event.RequestID]
+	if !ok {
+		request = &graphQLRequest{ready: make(chan struct{})}
+		waiter.requests[event.RequestID] = request
+	}
+	waiter.mux.Unlock()
+	if ok {
+		return
+	}
+	defer close(request.ready)
+	if "POST" != event.Request.Method {
+		return
+	}
+	sent := &Request{RequestWillBeSentEvent: event, tab: waiter.tab}
+	body, err := sent.Body()
+	if nil != err {
+		return
+	}
+	operations, err := ParseGraphQLRequest(body)
+	if nil != err {
+		return
+	}
+	for _, operation := range operations {
+		if waiter.name == operation.Name {
+			operation.Request = sent
+			request.operation = operation
+			return
+		}
+	}
+}
+
+/*
+matches returns whether a response belongs to a matching operation, waiting
+for its request to be classified. Responses to requests that weren't sent to
+an endpoint while waiting, such as requests sent before WaitForOperation was
+called, never match.
+*/
+func (waiter *graphQLWaiter) matches(response *Response) bool {
+	if !waiter.endpoint(response.Response.URL) {
+		return false
+	}
+	request, ok := waiter.request(response.RequestID)
+	if !ok {
+		return false
+	}
+	select {
+	case <-request.ready:
+		return nil != request.operation
+	case <-waiter.done:
+		return false
+	}
+}
+
+/*
+endpoint returns whether a URL is a GraphQL endpoint.
+*/
+func (waiter *graphQLWaiter) endpoint(url string) bool {
+	if 0 == len(waiter.endpoints) {
+		return true
+	}
+	for _, endpoint := range waiter.endpoints {
+		if endpoint.MatchString(url) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+request returns the classification of a request, and whether the request was
+sent to an endpoint.
+*/
+func (waiter *graphQLWaiter) request(requestID network.RequestID) (*graphQLRequest, bool) {
+	waiter.mux.Lock()
+	defer waiter.mux.Unlock()
+	request, ok := waiter.requests[requestID]
+	return request, ok
+}
+
+/*
+result returns the decoded result of the operation, the element of a batched
+response that belongs to it.
+*/
+func (operation *GraphQLOperation) result() (map[string]interface{}, error) {
+	if nil == operation.Response {
+		return nil, errs.New(codes.TabGraphQLFailed, fmt.Sprintf("operation '%s' has no response", operation.Name))
+	}
+	var doc interface{}
+	if err := operation.Response.BodyJSON(&doc); nil != err {
+		return nil, err
+	}
+	if operation.Batched {
+		batch, ok := doc.([]interface{})
+		if !ok || operation.Index >= len(batch) {
+			return nil, errs.New(codes.TabGraphQLFailed, fmt.Sprintf("the response has no result for operation '%s'", operation.Name))
+		}
+		doc = batch[operation.Index]
+	}
+	result, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, errs.New(codes.TabGraphQLFailed, fmt.Sprintf("the result of operation '%s' isn't an object", operation.Name))
+	}
+	return result, nil
+}
+
+/*
+Data decodes the data of the operation's response into v.
+*/
+func (operation *GraphQLOperation) Data(v interface{}) error {
+	result, err := operation.result()
+	if nil != err {
+		return err
+	}
+	data, _ := json.Marshal(result["data"])
+	if err := json.Unmarshal(data, v); nil != err {
+		return errs.Wrap(err, codes.TabGraphQLFailed, fmt.Sprintf("could not decode the data of operation '%s'", operation.Name))
+	}
+	return nil
+}
+
+/*
+Errors returns the errors in the operation's response.
+*/
+func (operation *GraphQLOperation) Errors() ([]*GraphQLError, error) {
+	result, err := operation.result()
+	if nil != err {
+		return nil, err
+	}
+	var graphQLErrors []*GraphQLError
+	data, _ := json.Marshal(result["errors"])
+	if err := json.Unmarshal(data, &graphQLErrors); nil != err {
+		return nil, errs.Wrap(err, codes.TabGraphQLFailed, fmt.Sprintf("could not decode the errors of operation '%s'", operation.Name))
+	}
+	return graphQLErrors, nil
+}
+
+/*
+AssertNoErrors returns an error listing the errors in the operation's
+response, or nil if there are none.
+*/
+func (operation *GraphQLOperation) AssertNoErrors() error {
+	graphQLErrors, err := operation.Errors()
+	if nil != err {
+		return err
+	}
+	if 0 == len(graphQLErrors) {
+		return nil
+	}
+	messages := make([]string, len(graphQLErrors))
+	for a, graphQLError := range graphQLErrors {
+		messages[a] = graphQLError.Message
+	}
+	return errs.New(codes.TabGraphQLFailed, fmt.Sprintf("operation '%s' returned %d error(s): %s", operation.Name, len(graphQLErrors), strings.Join(messages, "; ")))
+}
+
+/*
+AssertField returns an error unless a field of the operation's result has the
+expected value. Paths work like they do for Response.Field and are relative to
+the result, so they usually start with "data":
+
+	err := operation.AssertField("data.product.price", 9.99)
+
+The expected value is compared after a JSON round trip, so any numeric type
+can be used for numbers.
+*/
+func (operation *GraphQLOperation) AssertField(path string, expected interface{}) error {
+	result, err := operation.result()
+	if nil != err {
+		return err
+	}
+	actual, err := jsonPath(result, path)
+	if nil != err {
+		return errs.Wrap(err, codes.TabFieldNotFound, fmt.Sprintf("could not extract '%s' from the result of operation '%s'", path, operation.Name))
+	}
+	var want interface{}
+	data, err := json.Marshal(expected)
+	if nil == err {
+		err = json.Unmarshal(data, &want)
+	}
+	if nil != err {
+		return errs.Wrap(err, codes.TabGraphQLFailed, fmt.Sprintf("could not encode the expected value of '%s'", path))
+	}
+	if !reflect.DeepEqual(want, actual) {
+		return errs.New(codes.TabGraphQLFailed, fmt.Sprintf("operation '%s': expected '%s' to be %v, got %v", operation.Name, path, want, actual))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package chrome
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseGraphQLRequest(t *testing.T) {
+	operations, err := ParseGraphQLRequest([]byte(`{"operationName": "Search", "query": "query Search($q: String) { search(q: $q) { id } }", "variables": {"q": "go"}}`))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 1 != len(operations) || "Search" != operations[0].Name || "query" != operations[0].Type || "go" != operations[0].Variables["q"] || operations[0].Batched {
+		t.Errorf("Expected the Search query, got %+v", operations[0])
+	}
+
+	operations, err = ParseGraphQLRequest([]byte(` [{"query": "{ me { id } }"}, {"query": "mutation AddToCart { add { id } }"}, {"operationName": "Persisted"}]`))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 3 != len(operations) {
+		t.Fatalf("Expected 3 operations, got %d", len(operations))
+	}
+	if "" != operations[0].Name || "query" != operations[0].Type {
+		t.Errorf("Expected an anonymous query, got %+v", operations[0])
+	}
+	if "AddToCart" != operations[1].Name || "mutation" != operations[1].Type || 1 != operations[1].Index || !operations[1].Batched {
+		t.Errorf("Expected the AddToCart mutation, got %+v", operations[1])
+	}
+	if "Persisted" != operations[2].Name || "" != operations[2].Query {
+		t.Errorf("Expected a persisted query, got %+v", operations[2])
+	}
+
+	for _, body := range []string{`q=go`, `{"data": {}}`, `[null]`} {
+		if _, err := ParseGraphQLRequest([]byte(body)); nil == err {
+			t.Errorf("Expected error for '%s', got nil", body)
+		}
+	}
+}
+
+func TestTabWaitForOperation(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabWaitForOperation")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+	rec.results["Network.getResponseBody"] = `{"body": "[{\"data\": null}, {\"data\": {\"product\": {\"name\": \"pen\", \"price\": 2.5}}, \"errors\": [{\"message\": \"stock unavailable\", \"path\": [\"product\", \"stock\"]}]}]"}`
+
+	type result struct {
+		operation *GraphQLOperation
+		err       error
+	}
+	resultCh := make(chan result)
+	go func() {
+		operation, err := tab.WaitForOperation(context.Background(), "Product", "*/graphql")
+		resultCh <- result{operation, err}
+	}()
+	waitForHandlers(t, rec, 4)
+	// The response to a request sent before the wait never matches.
+	rec.emit("Network.responseReceived", `{"requestId": "0", "type": "Fetch", "response": {"url": "https://example.com/graphql", "status": 200}}`)
+	rec.emit("Network.loadingFinished", `{"requestId": "0"}`)
+	rec.emit("Network.requestWillBeSent", `{"requestId": "1", "request": {"url": "https://example.com/graphql", "method": "POST", "headers": {}, "postData": "{\"query\": \"query Other { other }\"}"}}`)
+	rec.emit("Network.responseReceived", `{"requestId": "1", "type": "Fetch", "response": {"url": "https://example.com/graphql", "status": 200}}`)
+	rec.emit("Network.loadingFinished", `{"requestId": "1"}`)
+	// A redirect sends the request again.
+	rec.emit("Network.requestWillBeSent", `{"requestId": "1", "request": {"url": "https://example.com/graphql", "method": "POST", "headers": {}, "postData": "{\"query\": \"query Other { other }\"}"}}`)
+	rec.emit("Network.requestWillBeSent", `{"requestId": "2", "request": {"url": "https://example.com/api", "method": "POST", "headers": {}, "postData": "{\"query\": \"query Product { product }\"}"}}`)
+	rec.emit("Network.responseReceived", `{"requestId": "2", "type": "Fetch", "response": {"url": "https://example.com/api", "status": 200}}`)
+	rec.emit("Network.loadingFinished", `{"requestId": "2"}`)
+	rec.emit("Network.requestWillBeSent", `{"requestId": "3", "request": {"url": "https://example.com/graphql", "method": "POST", "headers": {}, "postData": "[{\"query\": \"query Other { other }\"}, {\"operationName\": \"Product\", \"query\": \"query Product($id: ID) { product(id: $id) { name price } }\", \"variables\": {\"id\": \"7\"}}]"}}`)
+	rec.emit("Network.loadingFinished", `{"requestId": "3"}`)
+	rec.emit("Network.responseReceived", `{"requestId": "3", "type": "Fetch", "response": {"url": "https://example.com/graphql", "status": 200}}`)
+
+	var res result
+	select {
+	case res = <-resultCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected an operation")
+	}
+	if nil != res.err {
+		t.Fatalf("Expected nil, received error: %v", res.err)
+	}
+	operation := res.operation
+	if "3" != operation.Request.RequestID || "3" != operation.Response.RequestID || 1 != operation.Index || "7" != operation.Variables["id"] {
+		t.Fatalf("Expected the Product operation of request 3, got %+v", operation)
+	}
+
+	data := struct {
+		Product struct {
+			Name string `json:"name"`
+		} `json:"product"`
+	}{}
+	if err := operation.Data(&data); nil != err || "pen" != data.Product.Name {
+		t.Errorf("Expected the decoded data, received %v %v", data, err)
+	}
+	graphQLErrors, err := operation.Errors()
+	if nil != err || 1 != len(graphQLErrors) || "stock unavailable" != graphQLErrors[0].Message || 2 != len(graphQLErrors[0].Path) {
+		t.Errorf("Expected 1 error, received %+v %v", graphQLErrors, err)
+	}
+	if err := operation.AssertNoErrors(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if err := operation.AssertField("data.product.price", 2.5); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if err := operation.AssertField("data.product.name", "pencil"); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if err := operation.AssertField("data.product.stock", 1); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := tab.WaitForOperation(ctx, "Product"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
@@ -0,0 +1,206 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+HAR is an HTTP Archive. Only the fields needed to replay responses are decoded.
+*/
+type HAR struct {
+	Log *HARLog `json:"log"`
+}
+
+/*
+HARLog is the root of a HAR archive.
+*/
+type HARLog struct {
+	Entries []*HAREntry `json:"entries"`
+}
+
+/*
+HAREntry is a recorded request and its response.
+*/
+type HAREntry struct {
+	Request  *HARRequest  `json:"request"`
+	Response *HARResponse `json:"response"`
+}
+
+/*
+HARRequest is a recorded request.
+*/
+type HARRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+/*
+HARResponse is a recorded response.
+*/
+type HARResponse struct {
+	Status     int          `json:"status"`
+	StatusText string       `json:"statusText"`
+	Headers    []*HARHeader `json:"headers"`
+	Content    *HARContent  `json:"content"`
+}
+
+/*
+HARHeader is a recorded header.
+*/
+type HARHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+/*
+HARContent is a recorded response body. Text is base64 encoded if Encoding is
+"base64".
+*/
+type HARContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+/*
+LoadHAR reads a HAR archive from a file.
+*/
+func LoadHAR(file string) (*HAR, error) {
+	fh, err := os.Open(file)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabHARInvalid, fmt.Sprintf("could not open HAR archive '%s'", file))
+	}
+	defer fh.Close()
+	return ReadHAR(fh)
+}
+
+/*
+ReadHAR reads a HAR archive from a JSON document.
+*/
+func ReadHAR(reader io.Reader) (*HAR, error) {
+	har := &HAR{}
+	if err := json.NewDecoder(reader).Decode(har); nil != err {
+		return nil, errs.Wrap(err, codes.TabHARInvalid, "could not decode HAR archive")
+	}
+	if nil == har.Log {
+		return nil, errs.New(codes.TabHARInvalid, "HAR archive has no log")
+	}
+	return har, nil
+}
+
+/*
+ReplayHAR serves every request the tab makes from a HAR archive using Fetch
+interception, for offline and deterministic reruns:
+
+	har, err := chrome.LoadHAR("session.har")
+	if nil != err {
+		...
+	}
+	if err := tab.ReplayHAR(har); nil != err {
+		...
+	}
+
+Requests are matched by method and URL. If the archive recorded the same
+request more than once the responses are served in recorded order, and the last
+response is repeated once they run out. Requests that were not recorded fail as
+if the network was disconnected.
+
+Replay is registered as an Interceptor handler that matches every URL, so
+handlers added to the tab's Interceptor afterwards are never used.
+*/
+func (tab *Tab) ReplayHAR(har *HAR) error {
+	replay := &harReplay{
+		entries: make(map[string][]*HARResponse),
+		mux:     &sync.Mutex{},
+		tab:     tab,
+	}
+	for _, entry := range har.Log.Entries {
+		if nil == entry.Request || nil == entry.Response {
+			continue
+		}
+		key := harKey(entry.Request.Method, entry.Request.URL)
+		replay.entries[key] = append(replay.entries[key], entry.Response)
+	}
+	return tab.Interceptor().Handle(&fetch.RequestPattern{URLPattern: "*"}, replay.handle)
+}
+
+/*
+harReplay serves recorded responses.
+*/
+type harReplay struct {
+	entries map[string][]*HARResponse
+	mux     *sync.Mutex
+	tab     *Tab
+}
+
+/*
+handle fulfills a paused request with its recorded response.
+*/
+func (replay *harReplay) handle(event *fetch.RequestPausedEvent) error {
+	response := replay.next(harKey(event.Request.Method, event.Request.URL))
+	if nil == response {
+		return (<-replay.tab.Fetch().FailRequest(&fetch.FailRequestParams{
+			RequestID:   event.RequestID,
+			ErrorReason: network.ErrorReason.InternetDisconnected,
+		})).Err
+	}
+
+	headers := make([]*fetch.HeaderEntry, 0, len(response.Headers))
+	for _, header := range response.Headers {
+		// The recorded body is already decoded.
+		switch strings.ToLower(header.Name) {
+		case "content-encoding", "content-length":
+			continue
+		}
+		headers = append(headers, &fetch.HeaderEntry{Name: header.Name, Value: header.Value})
+	}
+	body := ""
+	if nil != response.Content {
+		body = response.Content.Text
+		if "base64" != response.Content.Encoding {
+			body = base64.StdEncoding.EncodeToString([]byte(body))
+		}
+	}
+	return (<-replay.tab.Fetch().FulfillRequest(&fetch.FulfillRequestParams{
+		RequestID:       event.RequestID,
+		ResponseCode:    response.Status,
+		ResponseHeaders: headers,
+		Body:            body,
+		ResponsePhrase:  response.StatusText,
+	})).Err
+}
+
+/*
+next returns the next recorded response for a request, or nil if the request
+was not recorded.
+*/
+func (replay *harReplay) next(key string) *HARResponse {
+	replay.mux.Lock()
+	defer replay.mux.Unlock()
+	responses := replay.entries[key]
+	if 0 == len(responses) {
+		return nil
+	}
+	if len(responses) > 1 {
+		replay.entries[key] = responses[1:]
+	}
+	return responses[0]
+}
+
+/*
+harKey returns the key a request is matched by.
+*/
+func harKey(method, url string) string {
+	return strings.ToUpper(method) + " " + url
+}
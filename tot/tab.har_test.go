@@ -0,0 +1,116 @@
+package chrome
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+var testHAR = `{
+	"log": {
+		"entries": [
+			{
+				"request": {"method": "GET", "url": "https://example.com/"},
+				"response": {
+					"status": 200,
+					"statusText": "OK",
+					"headers": [
+						{"name": "Content-Type", "value": "text/html"},
+						{"name": "Content-Encoding", "value": "gzip"}
+					],
+					"content": {"mimeType": "text/html", "text": "<html></html>"}
+				}
+			},
+			{
+				"request": {"method": "GET", "url": "https://example.com/logo.png"},
+				"response": {"status": 200, "headers": [], "content": {"mimeType": "image/png", "text": "iVBORw0K", "encoding": "base64"}}
+			},
+			{
+				"request": {"method": "POST", "url": "https://example.com/api"},
+				"response": {"status": 201, "headers": [], "content": {"text": "first"}}
+			},
+			{
+				"request": {"method": "POST", "url": "https://example.com/api"},
+				"response": {"status": 200, "headers": [], "content": {"text": "second"}}
+			}
+		]
+	}
+}`
+
+func TestReadHAR(t *testing.T) {
+	har, err := ReadHAR(strings.NewReader(testHAR))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 4 != len(har.Log.Entries) || "https://example.com/logo.png" != har.Log.Entries[1].Request.URL {
+		t.Errorf("Expected 4 entries, received %v", har.Log.Entries)
+	}
+
+	if _, err := ReadHAR(strings.NewReader(`{}`)); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if _, err := ReadHAR(strings.NewReader(`{"log":`)); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if _, err := LoadHAR("/does/not/exist.har"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestTabReplayHAR(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabReplayHAR")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+
+	har, _ := ReadHAR(strings.NewReader(testHAR))
+	if err := tab.ReplayHAR(har); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if methods := rec.reset(); "Fetch.enable" != strings.Join(methods, ",") {
+		t.Errorf("Expected Fetch.enable, received %v", methods)
+	}
+
+	fulfill := func(method, url string) *fetch.FulfillRequestParams {
+		rec.emit("Fetch.requestPaused", `{"requestId": "1", "request": {"url": "`+url+`", "method": "`+method+`", "headers": {}}, "resourceType": "Other"}`)
+		defer rec.reset()
+		if 1 != len(rec.commands) || "Fetch.fulfillRequest" != rec.commands[0].Method() {
+			t.Fatalf("Expected Fetch.fulfillRequest for %s %s, received %v", method, url, rec.methods)
+		}
+		return rec.commands[0].Params().(*fetch.FulfillRequestParams)
+	}
+
+	params := fulfill("GET", "https://example.com/")
+	if 200 != params.ResponseCode || "OK" != params.ResponsePhrase {
+		t.Errorf("Expected 200 OK, received %d %s", params.ResponseCode, params.ResponsePhrase)
+	}
+	if 1 != len(params.ResponseHeaders) || "Content-Type" != params.ResponseHeaders[0].Name {
+		t.Errorf("Expected the content encoding to be dropped, received %v", params.ResponseHeaders)
+	}
+	if body, _ := base64.StdEncoding.DecodeString(params.Body); "<html></html>" != string(body) {
+		t.Errorf("Expected the recorded body, received '%s'", body)
+	}
+
+	if params := fulfill("GET", "https://example.com/logo.png"); "iVBORw0K" != params.Body {
+		t.Errorf("Expected the recorded base64 body, received '%s'", params.Body)
+	}
+
+	for _, expect := range []string{"first", "second", "second"} {
+		params := fulfill("post", "https://example.com/api")
+		if body, _ := base64.StdEncoding.DecodeString(params.Body); expect != string(body) {
+			t.Errorf("Expected '%s', received '%s'", expect, body)
+		}
+	}
+
+	rec.emit("Fetch.requestPaused", `{"requestId": "2", "request": {"url": "https://example.com/missing", "method": "GET", "headers": {}}, "resourceType": "Other"}`)
+	if 1 != len(rec.commands) || "Fetch.failRequest" != rec.commands[0].Method() {
+		t.Fatalf("Expected Fetch.failRequest, received %v", rec.methods)
+	}
+	if network.ErrorReason.InternetDisconnected != rec.commands[0].Params().(*fetch.FailRequestParams).ErrorReason {
+		t.Errorf("Expected the request to fail as disconnected")
+	}
+}
@@ -0,0 +1,42 @@
+package chrome
+
+import (
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+OnTargetCrash enables the Inspector domain and calls crashed when the renderer
+of the tab's page crashes and recovered when the page is reloaded after the
+crash, so work on the tab can be paused instead of waiting for commands that
+won't be answered:
+
+	err := tab.OnTargetCrash(
+		func() { log.Warn("tab crashed") },
+		func() { log.Info("tab recovered") },
+	)
+
+Either function may be nil. The handlers are removed when the tab is cleaned
+up.
+*/
+func (tab *Tab) OnTargetCrash(crashed, recovered func()) error {
+	// The handlers are added through the tab so that they're removed by
+	// Cleanup. The events have no parameters.
+	if nil != crashed {
+		tab.AddEventHandler(socket.NewEventHandler("Inspector.targetCrashed", func(response *socket.Response) {
+			crashed()
+		}))
+	}
+	if nil != recovered {
+		tab.AddEventHandler(socket.NewEventHandler("Inspector.targetReloadedAfterCrash", func(response *socket.Response) {
+			recovered()
+		}))
+	}
+
+	result := <-tab.Inspector().Enable()
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabInspectorFailed, "could not enable the Inspector domain")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package chrome
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTabOnTargetCrash(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	tab, err := browser.NewTab("https://example.com")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Close()
+
+	crashed := make(chan bool, 1)
+	recovered := make(chan bool, 1)
+	err = tab.OnTargetCrash(
+		func() { crashed <- true },
+		func() { recovered <- true },
+	)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	enabled := false
+	for _, command := range cdp.Commands() {
+		if "Inspector.enable" == command.Method {
+			enabled = true
+		}
+	}
+	if !enabled {
+		t.Errorf("Expected the Inspector domain to be enabled")
+	}
+
+	cdp.Emit("Inspector.targetCrashed", map[string]string{})
+	select {
+	case <-crashed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the crash to be reported")
+	}
+	cdp.Emit("Inspector.targetReloadedAfterCrash", map[string]string{})
+	select {
+	case <-recovered:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the recovery to be reported")
+	}
+
+	if err := tab.Cleanup(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	cdp.Emit("Inspector.targetCrashed", map[string]string{})
+	select {
+	case <-crashed:
+		t.Errorf("Expected the handlers to be removed by Cleanup")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,232 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+RequestHandler handles a request paused by the Fetch domain. The handler must
+resume the request with one of the Fetch ContinueRequest, FailRequest or
+FulfillRequest commands. If the handler returns an error without resuming the
+request, the request is failed.
+*/
+type RequestHandler func(event *fetch.RequestPausedEvent) error
+
+/*
+HeaderHook computes headers to add to an intercepted request, for example HMAC
+signatures or per-request auth tokens. The returned headers are merged into the
+request headers, replacing headers with the same name.
+*/
+type HeaderHook func(request *network.Request) (network.Headers, error)
+
+/*
+Interceptor routes requests paused by the Fetch domain to handlers registered
+for matching URL patterns. Fetch interception is enabled when the first handler
+is added and is disabled when the tab is cleaned up:
+
+	tab.Interceptor().AddHeaderHook("https://api.example.com/*", func(request *network.Request) (network.Headers, error) {
+		return network.Headers{"X-Signature": sign(request)}, nil
+	})
+
+Handlers are matched in the order they were added and the first matching
+handler is used. Fetch interception replaces the deprecated Network request
+interception used by Profile rules, the two should not be used on the same tab.
+*/
+type Interceptor struct {
+	mux        *sync.Mutex
+	registered bool
+	rules      []*interceptorRule
+	tab        *Tab
+}
+
+/*
+interceptorRule is a request pattern and its handler.
+*/
+type interceptorRule struct {
+	handler RequestHandler
+	pattern *fetch.RequestPattern
+	url     *regexp.Regexp
+}
+
+/*
+match returns whether a paused request matches the rule.
+*/
+func (rule *interceptorRule) match(event *fetch.RequestPausedEvent) bool {
+	if nil == event.Request || !rule.url.MatchString(event.Request.URL) {
+		return false
+	}
+	if 0 != rule.pattern.ResourceType && rule.pattern.ResourceType != event.ResourceType {
+		return false
+	}
+	// Requests paused at the response stage carry a response status or error.
+	responseStage := 0 != event.ResponseStatusCode || 0 != event.ResponseErrorReason
+	if fetch.RequestStage.Response == rule.pattern.RequestStage {
+		return responseStage
+	}
+	return !responseStage
+}
+
+/*
+Interceptor returns the Fetch request interceptor for the tab.
+*/
+func (tab *Tab) Interceptor() *Interceptor {
+	tab.interceptorOnce.Do(func() {
+		tab.interceptor = &Interceptor{
+			mux:   &sync.Mutex{},
+			rules: make([]*interceptorRule, 0),
+			tab:   tab,
+		}
+	})
+	return tab.interceptor
+}
+
+/*
+Handle adds a handler for requests matching a Fetch request pattern and
+re-enables Fetch interception with the patterns of all registered handlers.
+*/
+func (interceptor *Interceptor) Handle(pattern *fetch.RequestPattern, handler RequestHandler) error {
+	rule := &interceptorRule{
+		handler: handler,
+		pattern: pattern,
+		url:     urlPattern(pattern.URLPattern),
+	}
+
+	interceptor.mux.Lock()
+	defer interceptor.mux.Unlock()
+	interceptor.register()
+	interceptor.rules = append(interceptor.rules, rule)
+	if err := interceptor.enable(); nil != err {
+		interceptor.rules = interceptor.rules[:len(interceptor.rules)-1]
+		return errs.Wrap(err, codes.TabInterceptFailed, fmt.Sprintf("could not intercept requests matching '%s'", pattern.URLPattern))
+	}
+	return nil
+}
+
+/*
+AddHeaderHook adds a hook that computes headers for each request whose URL
+matches a pattern before the request continues. Wildcards ('*' -> zero or more,
+'?' -> exactly one) are allowed. If the hook returns an error the request is
+failed. Header names are matched case-insensitively.
+*/
+func (interceptor *Interceptor) AddHeaderHook(pattern string, hook HeaderHook) error {
+	return interceptor.Handle(&fetch.RequestPattern{URLPattern: pattern}, func(event *fetch.RequestPausedEvent) error {
+		headers, err := hook(event.Request)
+		if nil != err {
+			return err
+		}
+		merged := network.Headers{}
+		for k, v := range event.Request.Headers {
+			merged[k] = v
+		}
+		// Header names are case-insensitive, a hook's header replaces any
+		// case variant of it.
+		for k, v := range headers {
+			for name := range merged {
+				if strings.EqualFold(k, name) {
+					delete(merged, name)
+				}
+			}
+			merged[k] = v
+		}
+		return (<-interceptor.tab.Fetch().ContinueRequest(&fetch.ContinueRequestParams{
+			RequestID: event.RequestID,
+			Headers:   headerEntries(merged),
+		})).Err
+	})
+}
+
+/*
+enable sends the patterns of all registered handlers to Fetch.enable. Must be
+called with the mutex locked.
+*/
+func (interceptor *Interceptor) enable() error {
+	patterns := make([]*fetch.RequestPattern, len(interceptor.rules))
+	for a, rule := range interceptor.rules {
+		patterns[a] = rule.pattern
+	}
+	return (<-interceptor.tab.Fetch().Enable(&fetch.EnableParams{Patterns: patterns})).Err
+}
+
+/*
+register adds the Fetch.requestPaused handler and registers a cleanup function
+with the tab the first time a handler is added. Must be called with the mutex
+locked.
+*/
+func (interceptor *Interceptor) register() {
+	if interceptor.registered {
+		return
+	}
+	interceptor.registered = true
+	interceptor.tab.AddEventHandler(socket.NewEventHandler("Fetch.requestPaused", interceptor.handle))
+	interceptor.tab.OnClose(func() error {
+		interceptor.mux.Lock()
+		interceptor.registered = false
+		interceptor.rules = make([]*interceptorRule, 0)
+		interceptor.mux.Unlock()
+		return (<-interceptor.tab.Fetch().Disable()).Err
+	})
+}
+
+/*
+handle passes a paused request to the first matching handler. Requests without
+a matching handler continue unchanged.
+*/
+func (interceptor *Interceptor) handle(response *socket.Response) {
+	event := &fetch.RequestPausedEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err {
+		interceptor.tab.log().Warn("could not decode paused request", logger.Fields{"error": err})
+		return
+	}
+
+	interceptor.mux.Lock()
+	var rule *interceptorRule
+	for _, r := range interceptor.rules {
+		if r.match(event) {
+			rule = r
+			break
+		}
+	}
+	interceptor.mux.Unlock()
+
+	var err error
+	if nil == rule {
+		err = (<-interceptor.tab.Fetch().ContinueRequest(&fetch.ContinueRequestParams{RequestID: event.RequestID})).Err
+	} else if err = rule.handler(event); nil != err {
+		interceptor.tab.log().Warn("request handler failed, failing request", logger.Fields{"error": err, "requestID": event.RequestID})
+		err = (<-interceptor.tab.Fetch().FailRequest(&fetch.FailRequestParams{
+			RequestID:   event.RequestID,
+			ErrorReason: network.ErrorReason.Failed,
+		})).Err
+	}
+	if nil != err {
+		interceptor.tab.log().Warn("could not resume paused request", logger.Fields{"error": err, "requestID": event.RequestID})
+	}
+}
+
+/*
+headerEntries converts headers to Fetch header entries, sorted by name.
+*/
+func headerEntries(headers network.Headers) []*fetch.HeaderEntry {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	entries := make([]*fetch.HeaderEntry, len(names))
+	for a, name := range names {
+		entries[a] = &fetch.HeaderEntry{Name: name, Value: headers[name]}
+	}
+	return entries
+}
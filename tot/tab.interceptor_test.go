@@ -0,0 +1,120 @@
+package chrome
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+func TestInterceptorAddHeaderHook(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestInterceptorAddHeaderHook")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+
+	err := tab.Interceptor().AddHeaderHook("https://api.example.com/*", func(request *network.Request) (network.Headers, error) {
+		return network.Headers{"X-Signature": request.Method + " " + request.URL}, nil
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	err = tab.Interceptor().AddHeaderHook("https://fail.example.com/*", func(request *network.Request) (network.Headers, error) {
+		return nil, errors.New("no token")
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if methods := rec.reset(); "Fetch.enable,Fetch.enable" != strings.Join(methods, ",") {
+		t.Errorf("Expected Fetch.enable twice, received %v", methods)
+	}
+
+	rec.emit("Fetch.requestPaused", `{
+		"requestId": "1",
+		"request": {"url": "https://api.example.com/v1", "method": "GET", "headers": {"Accept": "*/*", "x-signature": "old"}},
+		"resourceType": "XHR"
+	}`)
+	if 1 != len(rec.commands) || "Fetch.continueRequest" != rec.commands[0].Method() {
+		t.Fatalf("Expected Fetch.continueRequest, received %v", rec.methods)
+	}
+	params := rec.commands[0].Params().(*fetch.ContinueRequestParams)
+	if "1" != params.RequestID {
+		t.Errorf("Expected request 1, received %s", params.RequestID)
+	}
+	if 2 != len(params.Headers) ||
+		"Accept" != params.Headers[0].Name ||
+		"X-Signature" != params.Headers[1].Name ||
+		"GET https://api.example.com/v1" != params.Headers[1].Value {
+		t.Errorf("Expected merged headers, received %v", params.Headers)
+	}
+	rec.reset()
+
+	rec.emit("Fetch.requestPaused", `{
+		"requestId": "2",
+		"request": {"url": "https://fail.example.com/", "method": "GET", "headers": {}},
+		"resourceType": "XHR"
+	}`)
+	if 1 != len(rec.commands) || "Fetch.failRequest" != rec.commands[0].Method() {
+		t.Fatalf("Expected Fetch.failRequest, received %v", rec.methods)
+	}
+	if network.ErrorReason.Failed != rec.commands[0].Params().(*fetch.FailRequestParams).ErrorReason {
+		t.Errorf("Expected the request to fail")
+	}
+	rec.reset()
+
+	rec.emit("Fetch.requestPaused", `{
+		"requestId": "3",
+		"request": {"url": "https://other.example.com/", "method": "GET", "headers": {}},
+		"resourceType": "Document"
+	}`)
+	if 1 != len(rec.commands) || nil != rec.commands[0].Params().(*fetch.ContinueRequestParams).Headers {
+		t.Errorf("Expected the request to continue unchanged, received %v", rec.methods)
+	}
+	rec.reset()
+
+	if err := tab.Cleanup(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if methods := rec.reset(); "Fetch.disable" != strings.Join(methods, ",") {
+		t.Errorf("Expected Fetch.disable, received %v", methods)
+	}
+}
+
+func TestInterceptorRuleMatch(t *testing.T) {
+	tests := []struct {
+		pattern *fetch.RequestPattern
+		event   *fetch.RequestPausedEvent
+		match   bool
+	}{
+		{
+			&fetch.RequestPattern{URLPattern: "*.png"},
+			&fetch.RequestPausedEvent{Request: &network.Request{URL: "https://example.com/a.png"}},
+			true,
+		},
+		{
+			&fetch.RequestPattern{URLPattern: "*.png", ResourceType: page.ResourceType.Image},
+			&fetch.RequestPausedEvent{Request: &network.Request{URL: "https://example.com/a.png"}, ResourceType: page.ResourceType.XHR},
+			false,
+		},
+		{
+			&fetch.RequestPattern{},
+			&fetch.RequestPausedEvent{Request: &network.Request{URL: "https://example.com/"}, ResponseStatusCode: 200},
+			false,
+		},
+		{
+			&fetch.RequestPattern{RequestStage: fetch.RequestStage.Response},
+			&fetch.RequestPausedEvent{Request: &network.Request{URL: "https://example.com/"}, ResponseStatusCode: 200},
+			true,
+		},
+	}
+	for a, test := range tests {
+		rule := &interceptorRule{pattern: test.pattern, url: urlPattern(test.pattern.URLPattern)}
+		if test.match != rule.match(test.event) {
+			t.Errorf("Expected test %d match to be %v", a, test.match)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+)
+
+/*
+freezeProbe counts timer ticks and records the document freeze and resume
+events. Installing it again restarts it.
+*/
+const freezeProbe = `(function () {
+	var previous = window.__goChromeFreeze;
+	if (previous) {
+		clearInterval(previous.timer);
+	}
+	var probe = window.__goChromeFreeze = {ticks: [], frozenAt: 0, resumedAt: 0, listening: !!previous};
+	if (!probe.listening) {
+		document.addEventListener('freeze', function () { window.__goChromeFreeze.frozenAt = Date.now(); });
+		document.addEventListener('resume', function () { window.__goChromeFreeze.resumedAt = Date.now(); });
+	}
+	probe.timer = setInterval(function () { probe.ticks.push(Date.now()); }, 10);
+	return '';
+})()`
+
+/*
+freezeReport stops the freeze probe and reports the ticks counted while the
+page was frozen and after it resumed.
+*/
+const freezeReport = `(function () {
+	var probe = window.__goChromeFreeze;
+	if (!probe) {
+		return JSON.stringify({error: 'the freeze probe is not installed'});
+	}
+	clearInterval(probe.timer);
+	var frozen = 0, resumed = 0;
+	probe.ticks.forEach(function (tick) {
+		if (probe.frozenAt && tick > probe.frozenAt && (!probe.resumedAt || tick < probe.resumedAt)) {
+			frozen++;
+		}
+		if (probe.resumedAt && tick >= probe.resumedAt) {
+			resumed++;
+		}
+	});
+	return JSON.stringify({freeze: probe.frozenAt > 0, resume: probe.resumedAt > 0, frozen: frozen, resumed: resumed});
+})()`
+
+/*
+FreezeCheck configures VerifyFreezing.
+*/
+type FreezeCheck struct {
+	// Optional. How long the page is kept frozen. Defaults to 1s.
+	Duration time.Duration
+
+	// Optional. A JavaScript expression for the application state that must
+	// survive freezing, for example 'localStorage.getItem("draft")' or
+	// 'window.app.store.getState()'. Its value is compared as JSON before
+	// freezing and after resuming.
+	State string
+}
+
+/*
+FreezeReport is the result of VerifyFreezing.
+*/
+type FreezeReport struct {
+	// Whether the document received the freeze and resume events.
+	FreezeEvent bool
+	ResumeEvent bool
+
+	// The number of timer ticks while the page was frozen, which should be
+	// zero, and after it resumed.
+	FrozenTicks  int
+	ResumedTicks int
+
+	// The JSON encoded state before freezing and after resuming, and whether
+	// they're equal. Empty and true if no state expression was checked.
+	StateBefore    string
+	StateAfter     string
+	StatePersisted bool
+}
+
+/*
+OK returns whether the page handled freezing correctly: it received the
+lifecycle events, its timers stopped while frozen and ran again after resuming,
+and its state persisted.
+*/
+func (report *FreezeReport) OK() bool {
+	return report.FreezeEvent &&
+		report.ResumeEvent &&
+		0 == report.FrozenTicks &&
+		report.ResumedTicks > 0 &&
+		report.StatePersisted
+}
+
+/*
+Freeze freezes the page in the tab as a browser does to save battery in a
+background tab: timers, tasks and network loading are suspended until the page
+is resumed. The document receives a freeze event.
+*/
+func (tab *Tab) Freeze() error {
+	return tab.setWebLifecycleState(page.WebLifecycleState.Frozen)
+}
+
+/*
+Resume resumes a frozen page. The document receives a resume event.
+*/
+func (tab *Tab) Resume() error {
+	return tab.setWebLifecycleState(page.WebLifecycleState.Active)
+}
+
+/*
+VerifyFreezing freezes the page, resumes it and reports whether it handled
+freezing correctly, for battery-friendly app validation:
+
+	report, err := tab.VerifyFreezing(&chrome.FreezeCheck{
+		Duration: 2 * time.Second,
+		State:    "window.app.store.getState()",
+	})
+	if nil != err {
+		...
+	}
+	if !report.OK() {
+		...
+	}
+
+The timers checked are a probe interval installed in the page, which shows
+whether the browser suspended the page. Pages that keep working while frozen,
+for example in a worker, aren't detected. A nil check uses the defaults.
+*/
+func (tab *Tab) VerifyFreezing(check *FreezeCheck) (*FreezeReport, error) {
+	if nil == check {
+		check = &FreezeCheck{}
+	}
+	duration := check.Duration
+	if duration <= 0 {
+		duration = time.Second
+	}
+
+	report := &FreezeReport{StatePersisted: true}
+	var err error
+	if "" != check.State {
+		if report.StateBefore, err = tab.lifecycleEvaluate(fmt.Sprintf("JSON.stringify(%s)", check.State)); nil != err {
+			return nil, err
+		}
+	}
+	if _, err = tab.lifecycleEvaluate(freezeProbe); nil != err {
+		return nil, err
+	}
+
+	if err = tab.Freeze(); nil != err {
+		return nil, err
+	}
+	time.Sleep(duration)
+	if err = tab.Resume(); nil != err {
+		return nil, err
+	}
+	// Give the timers a chance to run after resuming.
+	time.Sleep(100 * time.Millisecond)
+
+	value, err := tab.lifecycleEvaluate(freezeReport)
+	if nil != err {
+		return nil, err
+	}
+	probe := struct {
+		Error   string `json:"error"`
+		Freeze  bool   `json:"freeze"`
+		Frozen  int    `json:"frozen"`
+		Resume  bool   `json:"resume"`
+		Resumed int    `json:"resumed"`
+	}{}
+	if err = json.Unmarshal([]byte(value), &probe); nil != err {
+		return nil, errs.Wrap(err, codes.TabLifecycleFailed, "could not decode the freeze probe")
+	}
+	if "" != probe.Error {
+		return nil, errs.New(codes.TabLifecycleFailed, probe.Error)
+	}
+	report.FreezeEvent = probe.Freeze
+	report.ResumeEvent = probe.Resume
+	report.FrozenTicks = probe.Frozen
+	report.ResumedTicks = probe.Resumed
+
+	if "" != check.State {
+		if report.StateAfter, err = tab.lifecycleEvaluate(fmt.Sprintf("JSON.stringify(%s)", check.State)); nil != err {
+			return nil, err
+		}
+		report.StatePersisted = report.StateBefore == report.StateAfter
+	}
+	return report, nil
+}
+
+/*
+setWebLifecycleState sets the web lifecycle state of the page.
+*/
+func (tab *Tab) setWebLifecycleState(state page.WebLifecycleStateEnum) error {
+	result := <-tab.Page().SetWebLifecycleState(&page.SetWebLifecycleStateParams{State: state})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabLifecycleFailed, fmt.Sprintf("could not set the web lifecycle state to '%s'", state))
+	}
+	return nil
+}
+
+/*
+lifecycleEvaluate evaluates an expression for VerifyFreezing, returning its
+value as a string. Undefined and null are returned as empty strings.
+*/
+func (tab *Tab) lifecycleEvaluate(expression string) (string, error) {
+	result := <-tab.Runtime().Evaluate(&runtime.EvaluateParams{
+		Expression:    expression,
+		AwaitPromise:  true,
+		ReturnByValue: true,
+	})
+	if nil != result.Err {
+		return "", errs.Wrap(result.Err, codes.TabLifecycleFailed, "could not evaluate the expression")
+	}
+	if nil != result.ExceptionDetails {
+		return "", errs.New(codes.TabLifecycleFailed, fmt.Sprintf("the expression threw an exception: %s", tab.evalError(result.ExceptionDetails).Error()))
+	}
+	if nil == result.Result || nil == result.Result.Value {
+		return "", nil
+	}
+	value, ok := result.Result.Value.(string)
+	if !ok {
+		return "", errs.New(codes.TabLifecycleFailed, fmt.Sprintf("the expression returned a %T, not a string", result.Result.Value))
+	}
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package chrome
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTabVerifyFreezing(t *testing.T) {
+	tests := []struct {
+		probe string
+		state []string
+		ok    bool
+	}{
+		{`{"freeze": true, "resume": true, "frozen": 0, "resumed": 8}`, []string{`{"draft":"hello"}`, `{"draft":"hello"}`}, true},
+		{`{"freeze": true, "resume": true, "frozen": 12, "resumed": 8}`, []string{`{"draft":"hello"}`, `{"draft":"hello"}`}, false},
+		{`{"freeze": true, "resume": true, "frozen": 0, "resumed": 8}`, []string{`{"draft":"hello"}`, `null`}, false},
+		{`{"freeze": false, "resume": false, "frozen": 0, "resumed": 8}`, []string{`1`, `1`}, false},
+	}
+	for _, test := range tests {
+		browser, cdp, stop := newCDPBrowser(t)
+		states := append([]string{}, test.state...)
+		cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+			p := struct {
+				Expression string `json:"expression"`
+			}{}
+			json.Unmarshal(params, &p)
+			value := ""
+			switch {
+			case strings.HasPrefix(p.Expression, "JSON.stringify(window.app.state)"):
+				value, states = states[0], states[1:]
+			case strings.Contains(p.Expression, "clearInterval(probe.timer)"):
+				value = test.probe
+			}
+			return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": value}}, nil
+		})
+		tab, err := browser.NewTab("about:blank")
+		if nil != err {
+			t.Fatalf("Expected nil, received error: %v", err)
+		}
+		report, err := tab.VerifyFreezing(&FreezeCheck{Duration: time.Millisecond, State: "window.app.state"})
+		commands := cdp.Commands()
+		tab.Socket().Stop()
+		stop()
+		if nil != err {
+			t.Errorf("Expected nil, received error: %v", err)
+			continue
+		}
+		if test.ok != report.OK() {
+			t.Errorf("Expected OK to be %v for %s, received %+v", test.ok, test.probe, report)
+		}
+
+		lifecycle := make([]string, 0)
+		for _, command := range commands {
+			if "Page.setWebLifecycleState" == command.Method {
+				lifecycle = append(lifecycle, string(command.Params))
+			}
+		}
+		if 2 != len(lifecycle) || !strings.Contains(lifecycle[0], "frozen") || !strings.Contains(lifecycle[1], "active") {
+			t.Errorf("Expected the page to be frozen and resumed, received %v", lifecycle)
+		}
+	}
+}
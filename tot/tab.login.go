@@ -0,0 +1,624 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/dom/storage"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+loginPollInterval is how often the conditions of a login flow are checked.
+*/
+const loginPollInterval = 100 * time.Millisecond
+
+/*
+Login describes a login flow run by Tab.Login. The same struct covers a plain
+form login, where the fields are filled and submitted in the tab, and an OAuth
+popup login, where a button in the tab opens the identity provider in a new
+window and the fields are filled and submitted there.
+*/
+type Login struct {
+	// Optional. The URL of the login page. The flow starts on the page that is
+	// loaded in the tab if it's empty.
+	URL string `json:"url"`
+
+	// Optional. The selector of the element that opens the OAuth popup. The
+	// fields are filled in the popup window if it's set.
+	Popup string `json:"popup"`
+
+	// The form fields, filled in order. Login waits for each field to exist,
+	// so fields of multi-step forms are filled once they're shown.
+	Fields []*LoginField `json:"fields"`
+
+	// The selector of the element that submits the form.
+	Submit string `json:"submit"`
+
+	// Optional. A URL pattern the popup, or the tab if there's no popup, must
+	// navigate to after the form is submitted, like the redirect URI of an
+	// OAuth client. Wildcards ('*' -> zero or more, '?' -> exactly one) are
+	// allowed.
+	Redirect string `json:"redirect"`
+
+	// Optional. A JavaScript expression that is truthy in the tab once the
+	// user is logged in, like "null !== document.querySelector('#account')".
+	Success string `json:"success"`
+
+	// Optional. The tokens captured from network responses of the tab.
+	Tokens []*TokenCapture `json:"tokens"`
+
+	// Optional. The origins whose localStorage is saved in the storage state.
+	// The origin of the page loaded in the tab at the end of the flow is used
+	// if it's empty.
+	Origins []string `json:"origins"`
+}
+
+/*
+LoginField is a form field filled by a login flow.
+*/
+type LoginField struct {
+	// The selector of the input element.
+	Selector string `json:"selector"`
+
+	// The value entered in the field.
+	Value string `json:"value"`
+}
+
+/*
+TokenCapture captures a token from a network response received during a login
+flow, like the access token returned by an OAuth token endpoint.
+*/
+type TokenCapture struct {
+	// The name of the token in StorageState.Tokens.
+	Name string `json:"name"`
+
+	// The URL pattern of the response, see WaitForResponse.
+	URL string `json:"url"`
+
+	// Optional. The response header that holds the token.
+	Header string `json:"header"`
+
+	// Optional. The path of the token in a JSON response body, see
+	// Response.Field. The whole body is the token if neither a header nor a
+	// field is set.
+	Field string `json:"field"`
+}
+
+/*
+StorageState is the authenticated state of a browser, the cookies and the
+localStorage values of a set of origins plus the tokens captured by a login
+flow. It's JSON encodable, so a login can be run once and its state reused by
+later tabs and test runs with LoadStorageState and Tab.UseStorageState.
+*/
+type StorageState struct {
+	// The cookies of the browser.
+	Cookies []*network.Cookie `json:"cookies"`
+
+	// The localStorage values of the saved origins.
+	Origins []*OriginStorage `json:"origins"`
+
+	// The captured tokens, by name.
+	Tokens map[string]string `json:"tokens"`
+}
+
+/*
+OriginStorage holds the localStorage values of an origin.
+*/
+type OriginStorage struct {
+	// The origin, like "https://app.example.com".
+	Origin string `json:"origin"`
+
+	// The localStorage values.
+	Local map[string]string `json:"local"`
+}
+
+/*
+LoadStorageState reads a StorageState from a JSON file.
+*/
+func LoadStorageState(file string) (*StorageState, error) {
+	fh, err := os.Open(file)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabLoginFailed, fmt.Sprintf("could not open storage state '%s'", file))
+	}
+	defer fh.Close()
+	return ReadStorageState(fh)
+}
+
+/*
+ReadStorageState reads a StorageState from a JSON document.
+*/
+func ReadStorageState(reader io.Reader) (*StorageState, error) {
+	state := &StorageState{}
+	if err := json.NewDecoder(reader).Decode(state); nil != err {
+		return nil, errs.Wrap(err, codes.TabLoginFailed, "could not decode storage state")
+	}
+	return state, nil
+}
+
+/*
+loginFill sets the value of an input element the way typing does, through the
+native value setter so frameworks that track the value notice the change, and
+reports whether the element exists.
+*/
+const loginFill = `(function (selector, value) {
+	var element = document.querySelector(selector);
+	if (!element) {
+		return false;
+	}
+	element.focus();
+	var descriptor = Object.getOwnPropertyDescriptor(Object.getPrototypeOf(element), 'value');
+	if (descriptor && descriptor.set) {
+		descriptor.set.call(element, value);
+	} else {
+		element.value = value;
+	}
+	element.dispatchEvent(new Event('input', {bubbles: true}));
+	element.dispatchEvent(new Event('change', {bubbles: true}));
+	return true;
+})(%s, %s)`
+
+/*
+loginClick clicks an element and reports whether it exists.
+*/
+const loginClick = `(function (selector) {
+	var element = document.querySelector(selector);
+	if (!element) {
+		return false;
+	}
+	element.click();
+	return true;
+})(%s)`
+
+/*
+Login runs a login flow in the tab and returns the resulting storage state. A
+form login fills the fields, submits the form and waits for the success
+condition:
+
+	state, err := tab.Login(ctx, &chrome.Login{
+		URL: "https://app.example.com/login",
+		Fields: []*chrome.LoginField{
+			{Selector: "#email", Value: "user@example.com"},
+			{Selector: "#password", Value: password},
+		},
+		Submit:  "button[type=submit]",
+		Success: "null !== document.querySelector('#account')",
+	})
+
+An OAuth popup login clicks the element that opens the identity provider's
+window, fills and submits the form in that window, waits for it to redirect
+back to the application and captures the tokens the application receives:
+
+	state, err := tab.Login(ctx, &chrome.Login{
+		URL:   "https://app.example.com",
+		Popup: "#sign-in-with-example",
+		Fields: []*chrome.LoginField{
+			{Selector: "input[name=username]", Value: "user"},
+			{Selector: "input[name=password]", Value: password},
+		},
+		Submit:   "#approve",
+		Redirect: "https://app.example.com/oauth/callback*",
+		Success:  "null !== localStorage.getItem('session')",
+		Tokens: []*chrome.TokenCapture{
+			{Name: "access", URL: "https://app.example.com/oauth/token", Field: "access_token"},
+		},
+	})
+
+Login returns once every step is done, or an error once the context is done.
+The popup is closed if it's still open at the end of the flow. The state is
+saved with Tab.StorageState and can be encoded as JSON and restored in other
+tabs with Tab.UseStorageState, so the login doesn't have to be repeated.
+
+The Network domain is enabled if tokens are captured and target discovery is
+enabled if there's a popup or a redirect, neither is disabled afterwards.
+*/
+func (tab *Tab) Login(ctx context.Context, login *Login) (*StorageState, error) {
+	if len(login.Fields) > 0 && "" == login.Submit {
+		return nil, errs.New(codes.TabLoginFailed, "a login with fields needs a submit selector")
+	}
+
+	captures := make([]*responseWaiter, 0, len(login.Tokens))
+	if len(login.Tokens) > 0 {
+		if result := <-tab.Network().Enable(&network.EnableParams{}); nil != result.Err {
+			return nil, errs.Wrap(result.Err, codes.TabLoginFailed, "could not enable the network domain")
+		}
+		for _, capture := range login.Tokens {
+			waiter := tab.watchResponses(capture.URL, nil)
+			defer waiter.stop()
+			captures = append(captures, waiter)
+		}
+	}
+
+	var targets *loginTargets
+	if "" != login.Popup || "" != login.Redirect {
+		var err error
+		if targets, err = tab.watchLoginTargets(); nil != err {
+			return nil, err
+		}
+		defer targets.stop()
+	}
+
+	if "" != login.URL {
+		result := <-tab.Page().Navigate(&page.NavigateParams{URL: login.URL})
+		if nil != result.Err {
+			return nil, errs.Wrap(result.Err, codes.TabLoginFailed, fmt.Sprintf("could not navigate to '%s'", login.URL))
+		}
+	}
+
+	form := tab
+	redirectID := target.ID(tab.Data().ID)
+	if "" != login.Popup {
+		if err := tab.loginUntil(ctx, loginExpression(loginClick, login.Popup), fmt.Sprintf("could not click '%s'", login.Popup)); nil != err {
+			return nil, err
+		}
+		popup, err := tab.attachPopup(ctx, targets)
+		if nil != err {
+			return nil, err
+		}
+		defer func() {
+			popup.Socket().Stop()
+			if !targets.closed(target.ID(popup.Data().ID)) {
+				<-tab.Target().CloseTarget(&target.CloseTargetParams{ID: target.ID(popup.Data().ID)})
+			}
+		}()
+		form = popup
+		redirectID = target.ID(popup.Data().ID)
+	}
+
+	for _, field := range login.Fields {
+		if err := form.loginUntil(ctx, loginExpression(loginFill, field.Selector, field.Value), fmt.Sprintf("could not fill '%s'", field.Selector)); nil != err {
+			return nil, err
+		}
+	}
+	if "" != login.Submit {
+		if err := form.loginUntil(ctx, loginExpression(loginClick, login.Submit), fmt.Sprintf("could not click '%s'", login.Submit)); nil != err {
+			return nil, err
+		}
+	}
+
+	if "" != login.Redirect {
+		if err := targets.waitForURL(ctx, redirectID, login.Redirect); nil != err {
+			return nil, err
+		}
+	}
+	if "" != login.Success {
+		if err := tab.loginUntil(ctx, login.Success, "the success condition was not met"); nil != err {
+			return nil, err
+		}
+	}
+
+	tokens := make(map[string]string, len(login.Tokens))
+	for a, capture := range login.Tokens {
+		token, err := captureToken(ctx, captures[a], capture)
+		if nil != err {
+			return nil, err
+		}
+		tokens[capture.Name] = token
+	}
+
+	state, err := tab.StorageState(login.Origins...)
+	if nil != err {
+		return nil, err
+	}
+	state.Tokens = tokens
+	return state, nil
+}
+
+/*
+StorageState returns the cookies of the browser and the localStorage values of
+the origins. The origin of the page loaded in the tab is used if no origins
+are specified. sessionStorage isn't saved, it's scoped to a single tab.
+*/
+func (tab *Tab) StorageState(origins ...string) (*StorageState, error) {
+	cookies := <-tab.Network().GetAllCookies()
+	if nil != cookies.Err {
+		return nil, errs.Wrap(cookies.Err, codes.TabLoginFailed, "could not read the cookies")
+	}
+	state := &StorageState{
+		Cookies: cookies.Cookies,
+		Origins: make([]*OriginStorage, 0, len(origins)),
+		Tokens:  make(map[string]string),
+	}
+
+	if 0 == len(origins) {
+		var origin string
+		if err := tab.Eval("location.origin", &origin); nil != err {
+			return nil, errs.Wrap(err, codes.TabLoginFailed, "could not read the origin of the page")
+		}
+		// Opaque origins like about:blank have no storage.
+		if "null" != origin && "" != origin {
+			origins = []string{origin}
+		}
+	}
+	for _, origin := range origins {
+		result := <-tab.DOMStorage().GetItems(&storage.GetItemsParams{
+			StorageID: &storage.ID{SecurityOrigin: origin, IsLocalStorage: true},
+		})
+		if nil != result.Err {
+			return nil, errs.Wrap(result.Err, codes.TabLoginFailed, fmt.Sprintf("could not read the localStorage of '%s'", origin))
+		}
+		local := make(map[string]string, len(result.Entries))
+		for _, entry := range result.Entries {
+			if 2 != len(entry) {
+				continue
+			}
+			key, _ := entry[0].(string)
+			value, _ := entry[1].(string)
+			local[key] = value
+		}
+		state.Origins = append(state.Origins, &OriginStorage{Origin: origin, Local: local})
+	}
+	return state, nil
+}
+
+/*
+UseStorageState restores a storage state in the tab, the cookies are set in the
+browser and the localStorage values are stored in documents of their origins
+loaded after the call, see AddStorageFixtures:
+
+	state, err := chrome.LoadStorageState("testdata/login.json")
+	if nil != err {
+		...
+	}
+	if err := tab.UseStorageState(state); nil != err {
+		...
+	}
+	<-tab.Page().Navigate(&page.NavigateParams{URL: "https://app.example.com"})
+
+The tokens aren't restored, they're meant to be used by the caller, for example
+as an Authorization header.
+*/
+func (tab *Tab) UseStorageState(state *StorageState) error {
+	if len(state.Cookies) > 0 {
+		cookies := make([]*network.SetCookieParams, 0, len(state.Cookies))
+		for _, cookie := range state.Cookies {
+			param := &network.SetCookieParams{
+				Name:         cookie.Name,
+				Value:        cookie.Value,
+				Domain:       cookie.Domain,
+				Path:         cookie.Path,
+				Secure:       cookie.Secure,
+				HTTPOnly:     cookie.HTTPOnly,
+				SameSite:     cookie.SameSite,
+				PartitionKey: cookie.PartitionKey,
+			}
+			if !cookie.Session {
+				param.Expires = network.TimeSinceEpoch(cookie.Expires)
+			}
+			cookies = append(cookies, param)
+		}
+		if result := <-tab.Network().SetCookies(&network.SetCookiesParams{Cookies: cookies}); nil != result.Err {
+			return errs.Wrap(result.Err, codes.TabLoginFailed, "could not set the cookies")
+		}
+	}
+
+	fixtures := make([]*StorageFixture, 0, len(state.Origins))
+	for _, origin := range state.Origins {
+		fixtures = append(fixtures, &StorageFixture{
+			Origins: []string{origin.Origin},
+			Local:   origin.Local,
+		})
+	}
+	if len(fixtures) > 0 {
+		if err := tab.AddStorageFixtures(fixtures...); nil != err {
+			return errs.Wrap(err, codes.TabLoginFailed, "could not restore the localStorage values")
+		}
+	}
+	return nil
+}
+
+/*
+loginExpression formats a login script with JSON encoded string arguments.
+*/
+func loginExpression(script string, args ...string) string {
+	encoded := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		data, _ := json.Marshal(arg)
+		encoded = append(encoded, string(data))
+	}
+	return fmt.Sprintf(script, encoded...)
+}
+
+/*
+loginUntil evaluates an expression until it's truthy or the context is done.
+The expression is evaluated with a user gesture so clicks can open popups.
+Evaluation errors are ignored, the page may be navigating.
+*/
+func (tab *Tab) loginUntil(ctx context.Context, expression, message string) error {
+	ticker := time.NewTicker(loginPollInterval)
+	defer ticker.Stop()
+	for {
+		result := <-tab.Runtime().Evaluate(&runtime.EvaluateParams{
+			Expression:    fmt.Sprintf("!!(%s)", expression),
+			ReturnByValue: true,
+			UserGesture:   true,
+		})
+		if nil == result.Err && nil == result.ExceptionDetails && nil != result.Result && true == result.Result.Value {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errs.Wrap(ctx.Err(), codes.TabLoginFailed, message)
+		}
+	}
+}
+
+/*
+captureToken waits for the response of a token capture and extracts the token.
+*/
+func captureToken(ctx context.Context, waiter *responseWaiter, capture *TokenCapture) (string, error) {
+	var response *Response
+	select {
+	case response = <-waiter.found:
+	case <-ctx.Done():
+		return "", errs.Wrap(ctx.Err(), codes.TabLoginFailed, fmt.Sprintf("no response matching '%s' for token '%s'", capture.URL, capture.Name))
+	}
+
+	switch {
+	case "" != capture.Header:
+		header := http.CanonicalHeaderKey(capture.Header)
+		for name, value := range response.Response.Headers {
+			if http.CanonicalHeaderKey(name) == header {
+				return value, nil
+			}
+		}
+		return "", errs.New(codes.TabLoginFailed, fmt.Sprintf("response %s has no '%s' header for token '%s'", response.RequestID, capture.Header, capture.Name))
+
+	case "" != capture.Field:
+		value, err := response.Field(capture.Field)
+		if nil != err {
+			return "", errs.Wrap(err, codes.TabLoginFailed, fmt.Sprintf("could not capture token '%s'", capture.Name))
+		}
+		if token, ok := value.(string); ok {
+			return token, nil
+		}
+		data, _ := json.Marshal(value)
+		return string(data), nil
+	}
+
+	body, err := response.Body()
+	if nil != err {
+		return "", errs.Wrap(err, codes.TabLoginFailed, fmt.Sprintf("could not capture token '%s'", capture.Name))
+	}
+	return string(body), nil
+}
+
+/*
+loginTargets tracks the targets the browser reports during a login flow, the
+popups opened by the tab and the URLs targets navigate to.
+*/
+type loginTargets struct {
+	changed   chan struct{}
+	destroyed map[target.ID]bool
+	mux       *sync.Mutex
+	opener    target.ID
+	popups    chan *target.Info
+	subs      []*socket.Subscription
+	urls      map[target.ID]string
+}
+
+/*
+watchLoginTargets enables target discovery and starts tracking targets.
+*/
+func (tab *Tab) watchLoginTargets() (*loginTargets, error) {
+	targets := &loginTargets{
+		changed:   make(chan struct{}, 1),
+		destroyed: make(map[target.ID]bool),
+		mux:       &sync.Mutex{},
+		opener:    target.ID(tab.Data().ID),
+		popups:    make(chan *target.Info, 1),
+		urls:      make(map[target.ID]string),
+	}
+	targets.subs = []*socket.Subscription{
+		tab.Target().OnTargetCreated(func(event *target.CreatedEvent) {
+			if nil == event.Info {
+				return
+			}
+			targets.update(event.Info)
+			if "page" == event.Info.Type && targets.opener == event.Info.OpenerID {
+				select {
+				case targets.popups <- event.Info:
+				default:
+				}
+			}
+		}),
+		tab.Target().OnTargetInfoChanged(func(event *target.InfoChangedEvent) {
+			if nil != event.Info {
+				targets.update(event.Info)
+			}
+		}),
+		tab.Target().OnTargetDestroyed(func(event *target.DestroyedEvent) {
+			targets.mux.Lock()
+			targets.destroyed[event.ID] = true
+			targets.mux.Unlock()
+			targets.notify()
+		}),
+	}
+	if result := <-tab.Target().SetDiscoverTargets(&target.SetDiscoverTargetsParams{Discover: true}); nil != result.Err {
+		targets.stop()
+		return nil, errs.Wrap(result.Err, codes.TabLoginFailed, "could not enable target discovery")
+	}
+	return targets, nil
+}
+
+func (targets *loginTargets) update(info *target.Info) {
+	targets.mux.Lock()
+	targets.urls[info.ID] = info.URL
+	targets.mux.Unlock()
+	targets.notify()
+}
+
+func (targets *loginTargets) notify() {
+	select {
+	case targets.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (targets *loginTargets) closed(targetID target.ID) bool {
+	targets.mux.Lock()
+	defer targets.mux.Unlock()
+	return targets.destroyed[targetID]
+}
+
+func (targets *loginTargets) stop() {
+	for _, sub := range targets.subs {
+		sub.Remove()
+	}
+}
+
+/*
+waitForURL blocks until a target navigates to a URL matching a pattern. A
+popup that closes itself right after the redirect still reports the URL before
+it's destroyed.
+*/
+func (targets *loginTargets) waitForURL(ctx context.Context, targetID target.ID, pattern string) error {
+	url := urlPattern(pattern)
+	for {
+		targets.mux.Lock()
+		current, destroyed := targets.urls[targetID], targets.destroyed[targetID]
+		targets.mux.Unlock()
+		if url.MatchString(current) {
+			return nil
+		}
+		if destroyed {
+			return errs.New(codes.TabLoginFailed, fmt.Sprintf("the window was closed at '%s' before redirecting to '%s'", current, pattern))
+		}
+		select {
+		case <-targets.changed:
+		case <-ctx.Done():
+			return errs.Wrap(ctx.Err(), codes.TabLoginFailed, fmt.Sprintf("no redirect to '%s', the window is at '%s'", pattern, current))
+		}
+	}
+}
+
+/*
+attachPopup waits for the tab to open a popup and attaches to it.
+*/
+func (tab *Tab) attachPopup(ctx context.Context, targets *loginTargets) (*Tab, error) {
+	var info *target.Info
+	select {
+	case info = <-targets.popups:
+	case <-ctx.Done():
+		return nil, errs.Wrap(ctx.Err(), codes.TabLoginFailed, "no popup was opened")
+	}
+
+	popup, err := tab.attachTarget(info)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabLoginFailed, fmt.Sprintf("could not attach to popup '%s'", info.ID))
+	}
+	return popup, nil
+}
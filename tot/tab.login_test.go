@@ -0,0 +1,216 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+handleLoginEvaluate answers Runtime.evaluate commands of a login flow, the
+origin is reported for location.origin and every other expression is truthy.
+The submitted callback is run in a goroutine when the submit element is
+clicked.
+*/
+func handleLoginEvaluate(cdp *cdptest.Server, submit string, submitted func()) chan string {
+	expressions := make(chan string, 20)
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		expression := struct{ Expression string }{}
+		json.Unmarshal(params, &expression)
+		expressions <- expression.Expression
+		if "location.origin" == expression.Expression {
+			return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": "https://app.example.com"}}, nil
+		}
+		if strings.Contains(expression.Expression, `element.click();`) && strings.Contains(expression.Expression, submit) {
+			go submitted()
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "boolean", "value": true}}, nil
+	})
+	return expressions
+}
+
+func TestTabLogin(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	expressions := handleLoginEvaluate(cdp, `"#submit"`, func() {
+		time.Sleep(10 * time.Millisecond)
+		cdp.Emit("Network.responseReceived", map[string]interface{}{
+			"requestId": "7",
+			"response":  map[string]interface{}{"url": "https://app.example.com/api/session", "status": 200, "headers": map[string]interface{}{"x-csrf-token": "csrf"}},
+		})
+		cdp.Emit("Network.loadingFinished", map[string]interface{}{"requestId": "7"})
+	})
+	cdp.Respond("Network.getResponseBody", map[string]interface{}{"body": `{"data": {"token": "secret"}}`})
+	cdp.Respond("Network.getAllCookies", map[string]interface{}{
+		"cookies": []interface{}{map[string]interface{}{"name": "sid", "value": "abc", "domain": "app.example.com", "path": "/"}},
+	})
+	cdp.Respond("DOMStorage.getDOMStorageItems", map[string]interface{}{
+		"entries": [][]string{{"user", "1"}},
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	state, err := tab.Login(ctx, &Login{
+		URL: "https://app.example.com/login",
+		Fields: []*LoginField{
+			{Selector: "#email", Value: "user@example.com"},
+			{Selector: "#password", Value: `p"ss`},
+		},
+		Submit:  "#submit",
+		Success: "window.loggedIn",
+		Tokens: []*TokenCapture{
+			{Name: "api", URL: "https://app.example.com/api/*", Field: "data.token"},
+			{Name: "csrf", URL: "https://app.example.com/api/*", Header: "X-CSRF-Token"},
+		},
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+
+	expected := []string{`"#email", "user@example.com"`, `"#password", "p\"ss"`, `"#submit"`, `!!(window.loggedIn)`, `location.origin`}
+	for _, fragment := range expected {
+		expression := <-expressions
+		if !strings.Contains(expression, fragment) {
+			t.Errorf("Expected an expression with %s, got %q", fragment, expression)
+		}
+	}
+	if 1 != len(state.Cookies) || "sid" != state.Cookies[0].Name {
+		t.Errorf("Expected the sid cookie, got %v", state.Cookies)
+	}
+	if 1 != len(state.Origins) || "https://app.example.com" != state.Origins[0].Origin || "1" != state.Origins[0].Local["user"] {
+		t.Errorf("Expected the localStorage of the origin, got %v", state.Origins)
+	}
+	if "secret" != state.Tokens["api"] || "csrf" != state.Tokens["csrf"] {
+		t.Errorf("Expected the captured tokens, got %v", state.Tokens)
+	}
+
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return map[string]interface{}{"result": map[string]interface{}{"type": "boolean", "value": false}}, nil
+	})
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := tab.Login(ctx, &Login{Success: "window.loggedIn"}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if _, err := tab.Login(context.Background(), &Login{Fields: []*LoginField{{Selector: "#email"}}}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestTabLoginPopup(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	handleLoginEvaluate(cdp, `"#sign-in"`, func() {
+		cdp.Emit("Target.targetCreated", map[string]interface{}{
+			"targetInfo": map[string]interface{}{"targetId": "popup", "type": "page", "url": "https://id.example.com/authorize", "openerId": "1"},
+		})
+	})
+	cdp.Respond("Target.attachToTarget", map[string]interface{}{"sessionId": "popup-session"})
+	closed := make(chan string, 1)
+	cdp.Handle("Target.closeTarget", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		closed <- string(params)
+		return map[string]interface{}{"success": true}, nil
+	})
+	cdp.Respond("DOMStorage.getDOMStorageItems", map[string]interface{}{"entries": []interface{}{}})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	go func() {
+		for {
+			for _, command := range cdp.Commands() {
+				if "Runtime.evaluate" == command.Method && "popup-session" == command.SessionID && strings.Contains(string(command.Params), `#approve`) {
+					cdp.Emit("Target.targetInfoChanged", map[string]interface{}{
+						"targetInfo": map[string]interface{}{"targetId": "popup", "type": "page", "url": "https://app.example.com/oauth/callback?code=1", "openerId": "1"},
+					})
+					return
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = tab.Login(ctx, &Login{
+		Popup:    "#sign-in",
+		Fields:   []*LoginField{{Selector: "#username", Value: "user"}},
+		Submit:   "#approve",
+		Redirect: "https://app.example.com/oauth/callback*",
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	select {
+	case params := <-closed:
+		if !strings.Contains(params, `"popup"`) {
+			t.Errorf("Expected the popup to be closed, got %s", params)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected the popup to be closed")
+	}
+	for _, command := range cdp.Commands() {
+		if "Runtime.evaluate" == command.Method && strings.Contains(string(command.Params), `#username`) && "popup-session" != command.SessionID {
+			t.Errorf("Expected the fields to be filled in the popup, got session '%s'", command.SessionID)
+		}
+	}
+}
+
+func TestTabUseStorageState(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	cookies := make(chan string, 1)
+	cdp.Handle("Network.setCookies", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		cookies <- string(params)
+		return map[string]interface{}{}, nil
+	})
+	sources := make(chan string, 1)
+	cdp.Handle("Page.addScriptToEvaluateOnNewDocument", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		source := struct{ Source string }{}
+		json.Unmarshal(params, &source)
+		sources <- source.Source
+		return map[string]interface{}{"identifier": "1"}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	state, err := ReadStorageState(strings.NewReader(`{
+		"cookies": [
+			{"name": "sid", "value": "abc", "domain": "app.example.com", "path": "/", "expires": -1, "session": true},
+			{"name": "remember", "value": "1", "domain": "app.example.com", "path": "/", "expires": 1900000000}
+		],
+		"origins": [{"origin": "https://app.example.com", "local": {"user": "1"}}],
+		"tokens": {"api": "secret"}
+	}`))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if err := tab.UseStorageState(state); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected := `{"cookies":[{"name":"sid","value":"abc","domain":"app.example.com","path":"/"},{"name":"remember","value":"1","domain":"app.example.com","path":"/","expires":1900000000}]}`
+	if params := <-cookies; expected != params {
+		t.Errorf("Expected %s, got %s", expected, params)
+	}
+	if source := <-sources; !strings.HasSuffix(source, `})([{"origins":["https://app.example.com"],"local":{"user":"1"},"session":null}]);`) {
+		t.Errorf("Expected the localStorage fixture, got %q", source)
+	}
+
+	if _, err := ReadStorageState(strings.NewReader(`[`)); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
@@ -0,0 +1,371 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/target"
+)
+
+/*
+NavigationPolicy configures NavigateWithRetry. The zero value retries a failed
+navigation twice.
+
+The delay before a retry starts at InitialBackoff and is multiplied by
+Multiplier after each retry, up to MaxBackoff, and is randomized by Jitter so
+that tabs failing together aren't retried together.
+*/
+type NavigationPolicy struct {
+	// Optional. Maximum number of attempts, including the first one. Defaults
+	// to 3.
+	MaxAttempts int
+
+	// Optional. Time an attempt may take to load the page. Defaults to 30s.
+	Timeout time.Duration
+
+	// Optional. Delay before the first retry. Defaults to 1s.
+	InitialBackoff time.Duration
+
+	// Optional. Maximum delay between retries. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Optional. Backoff multiplier. Defaults to 2.
+	Multiplier float64
+
+	// Optional. Fraction of the delay that's randomized, between 0 and 1.
+	// Defaults to 0.2, a negative value disables jitter.
+	Jitter float64
+
+	// Optional. Selectors of elements that only appear on error pages, like
+	// "#error-page" or ".rate-limit-notice". A page with a matching element
+	// is a failed attempt.
+	ErrorSelectors []string
+
+	// Optional. Pages with fewer characters of visible text are failed
+	// attempts. Defaults to 1, so only empty pages fail, a negative value
+	// disables the check.
+	MinBodyLength int
+
+	// Optional. Returns whether a failed attempt is retried. Defaults to
+	// retrying every failed attempt.
+	Retryable func(attempt *NavigationAttempt) bool
+
+	// Optional. Rotates proxies between attempts. Every attempt is made in a
+	// new tab, in a browser context that uses the next proxy of the rotator,
+	// and the health of the proxy is updated with the outcome.
+	Proxies *ProxyRotator
+
+	// Optional. The region of the rotated proxies.
+	Region string
+}
+
+/*
+NavigationAttempt is an attempt to load a page made by NavigateWithRetry.
+*/
+type NavigationAttempt struct {
+	// The number of the attempt, starting at 1.
+	Number int
+
+	// The proxy the attempt used, if proxies are rotated.
+	Proxy *Proxy
+
+	// The URL of the loaded page, after redirects.
+	URL string
+
+	// The HTTP status of the page, 0 if it's not known.
+	Status int
+
+	// The time the attempt took.
+	Duration time.Duration
+
+	// The reason the attempt failed, nil if it succeeded.
+	Err error
+}
+
+/*
+Navigation is the outcome of NavigateWithRetry.
+*/
+type Navigation struct {
+	// The tab the page was loaded in. It's the tab NavigateWithRetry was
+	// called on unless proxies are rotated.
+	Tab *Tab
+
+	// The attempts, in order.
+	Attempts []*NavigationAttempt
+}
+
+/*
+navigationCheck waits for the page to load and reports its status, the length
+of its text and the first error selector that matches.
+*/
+const navigationCheck = `new Promise(function (resolve) {
+	var check = function () {
+		var entry = performance.getEntriesByType('navigation')[0];
+		var selector = %s.filter(function (selector) {
+			try {
+				return null !== document.querySelector(selector);
+			} catch (e) {
+				return false;
+			}
+		})[0];
+		resolve({
+			status: entry && entry.responseStatus || 0,
+			length: document.body ? document.body.innerText.trim().length : 0,
+			selector: selector || '',
+			url: location.href
+		});
+	};
+	if ('complete' === document.readyState) {
+		check();
+	} else {
+		window.addEventListener('load', check);
+	}
+})`
+
+/*
+NavigateWithRetry loads a page and retries with backoff when the attempt fails
+softly: the navigation fails with a network error like net::ERR_TIMED_OUT, the
+page doesn't load in time, responds with a 5xx or 429 status, has no text or
+contains an error selector:
+
+	navigation, err := tab.NavigateWithRetry(ctx, "https://example.com/products", &chrome.NavigationPolicy{
+		ErrorSelectors: []string{"#captcha", ".service-unavailable"},
+		Proxies:        rotator,
+	})
+	for _, attempt := range navigation.Attempts {
+		log.Infof("attempt %d: %d %v", attempt.Number, attempt.Status, attempt.Err)
+	}
+	if nil != err {
+		...
+	}
+	html, err := navigation.Tab.DOMSnapshotText()
+
+The attempts are reported even if every attempt fails, the error is the reason
+the last attempt failed. A nil policy uses the defaults.
+
+With proxy rotation every attempt is made in a new tab. The tabs of failed
+attempts are closed and the browser contexts of the others are disposed when
+the tab NavigateWithRetry was called on is cleaned up.
+*/
+func (tab *Tab) NavigateWithRetry(ctx context.Context, url string, policy *NavigationPolicy) (*Navigation, error) {
+	if nil == policy {
+		policy = &NavigationPolicy{}
+	}
+	navigation := &Navigation{Attempts: make([]*NavigationAttempt, 0, policy.attempts())}
+	for number := 1; ; number++ {
+		attempt := &NavigationAttempt{Number: number}
+		navigation.Attempts = append(navigation.Attempts, attempt)
+
+		attemptTab := tab
+		if nil != policy.Proxies {
+			var err error
+			if attemptTab, attempt.Proxy, err = tab.proxiedTab(policy.Proxies, policy.Region); nil != err {
+				attempt.Err = err
+				return navigation, errs.Wrap(err, codes.TabNavigationFailed, fmt.Sprintf("could not open a tab for attempt %d", number))
+			}
+		}
+
+		start := time.Now()
+		attemptTab.navigateAttempt(ctx, url, policy, attempt)
+		attempt.Duration = time.Since(start)
+
+		if nil != policy.Proxies {
+			if nil == attempt.Err {
+				policy.Proxies.Succeeded(attempt.Proxy)
+			} else {
+				policy.Proxies.Failed(attempt.Proxy, attempt.Err)
+				attemptTab.Cleanup()
+				attemptTab.Socket().Stop()
+			}
+		}
+		if nil == attempt.Err {
+			navigation.Tab = attemptTab
+			return navigation, nil
+		}
+
+		if number >= policy.attempts() || (nil != policy.Retryable && !policy.Retryable(attempt)) {
+			return navigation, errs.Wrap(attempt.Err, codes.TabNavigationFailed, fmt.Sprintf("could not load '%s' in %d attempts", url, number))
+		}
+		select {
+		case <-time.After(policy.backoff(number)):
+		case <-ctx.Done():
+			return navigation, errs.Wrap(ctx.Err(), codes.TabNavigationFailed, fmt.Sprintf("could not load '%s' in %d attempts", url, number))
+		}
+	}
+}
+
+/*
+navigateAttempt loads the page and records the outcome in the attempt.
+*/
+func (tab *Tab) navigateAttempt(ctx context.Context, url string, policy *NavigationPolicy, attempt *NavigationAttempt) {
+	ctx, cancel := context.WithTimeout(ctx, policy.timeout())
+	defer cancel()
+
+	result := <-tab.WithContext(ctx).Page().Navigate(&page.NavigateParams{URL: url})
+	switch {
+	case nil != ctx.Err():
+		attempt.Err = errs.Wrap(ctx.Err(), codes.TabNavigationFailed, fmt.Sprintf("'%s' did not load in %s", url, policy.timeout()))
+		<-tab.Page().StopLoading()
+		return
+	case nil != result.Err:
+		attempt.Err = errs.Wrap(result.Err, codes.TabNavigationFailed, fmt.Sprintf("could not navigate to '%s'", url))
+		return
+	case "" != result.ErrorText:
+		attempt.Err = errs.New(codes.TabNavigationFailed, fmt.Sprintf("navigation to '%s' failed: %s", url, result.ErrorText))
+		return
+	}
+
+	selectors := policy.ErrorSelectors
+	if nil == selectors {
+		selectors = []string{}
+	}
+	data, _ := json.Marshal(selectors)
+	evaluation := tab.EvalAsync(fmt.Sprintf(navigationCheck, data))
+	select {
+	case <-evaluation.Done():
+	case <-ctx.Done():
+		attempt.Err = errs.Wrap(ctx.Err(), codes.TabNavigationFailed, fmt.Sprintf("'%s' did not load in %s", url, policy.timeout()))
+		<-tab.Page().StopLoading()
+		return
+	}
+	check := struct {
+		Length   int    `json:"length"`
+		Selector string `json:"selector"`
+		Status   int    `json:"status"`
+		URL      string `json:"url"`
+	}{}
+	if err := evaluation.Decode(&check); nil != err {
+		attempt.Err = errs.Wrap(err, codes.TabNavigationFailed, fmt.Sprintf("could not check the page loaded from '%s'", url))
+		return
+	}
+	attempt.Status = check.Status
+	attempt.URL = check.URL
+
+	switch {
+	case check.Status >= 500 || 429 == check.Status:
+		attempt.Err = errs.New(codes.TabNavigationFailed, fmt.Sprintf("'%s' responded with status %d", check.URL, check.Status))
+	case "" != check.Selector:
+		attempt.Err = errs.New(codes.TabNavigationFailed, fmt.Sprintf("'%s' is an error page, it matches '%s'", check.URL, check.Selector))
+	case check.Length < policy.minBodyLength():
+		attempt.Err = errs.New(codes.TabNavigationFailed, fmt.Sprintf("'%s' has %d characters of text, expected at least %d", check.URL, check.Length, policy.minBodyLength()))
+	}
+}
+
+/*
+proxiedTab opens a tab in a new browser context that uses the next proxy of a
+rotator. Browser contexts can only be managed through the browser target, so
+they're created and disposed through the browser connection the tab shares, or
+one opened for the context. The context is disposed when the tab or the tab
+that opened it is cleaned up.
+*/
+func (tab *Tab) proxiedTab(rotator *ProxyRotator, region string) (*Tab, *Proxy, error) {
+	conn, release, err := tab.browserConnection()
+	if nil != err {
+		return nil, nil, err
+	}
+	browser := conn.Client()
+	contextID, proxy, err := rotator.NewContext(browser.Target(), region)
+	if nil != err {
+		release()
+		return nil, nil, err
+	}
+	var disposed bool
+	dispose := func() error {
+		if disposed {
+			return nil
+		}
+		disposed = true
+		defer release()
+		return (<-browser.Target().DisposeBrowserContext(&target.DisposeBrowserContextParams{
+			BrowserContextID: contextID,
+		})).Err
+	}
+
+	result := <-tab.Target().CreateTarget(&target.CreateTargetParams{
+		URL:              "about:blank",
+		BrowserContextID: contextID,
+	})
+	if nil != result.Err {
+		dispose()
+		return nil, nil, result.Err
+	}
+	proxied, err := tab.attachTarget(&target.Info{ID: result.ID, Type: "page", URL: "about:blank"})
+	if nil != err {
+		dispose()
+		return nil, nil, err
+	}
+	proxied.OnClose(dispose)
+	tab.OnClose(dispose)
+	return proxied, proxy, nil
+}
+
+/*
+attempts returns the maximum number of attempts.
+*/
+func (policy *NavigationPolicy) attempts() int {
+	if policy.MaxAttempts <= 0 {
+		return 3
+	}
+	return policy.MaxAttempts
+}
+
+/*
+timeout returns the time an attempt may take.
+*/
+func (policy *NavigationPolicy) timeout() time.Duration {
+	if policy.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return policy.Timeout
+}
+
+/*
+minBodyLength returns the minimum length of the text of a page.
+*/
+func (policy *NavigationPolicy) minBodyLength() int {
+	if 0 == policy.MinBodyLength {
+		return 1
+	}
+	return policy.MinBodyLength
+}
+
+/*
+backoff returns the delay before the specified retry, starting at 1.
+*/
+func (policy *NavigationPolicy) backoff(retry int) time.Duration {
+	delay := policy.InitialBackoff
+	if delay <= 0 {
+		delay = time.Second
+	}
+	max := policy.MaxBackoff
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	for a := 1; a < retry && delay < max; a++ {
+		delay = time.Duration(float64(delay) * multiplier)
+	}
+	if delay > max {
+		delay = max
+	}
+	jitter := policy.Jitter
+	if 0 == jitter {
+		jitter = 0.2
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	if jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 - jitter + 2*jitter*rand.Float64()))
+	}
+	return delay
+}
@@ -0,0 +1,181 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+/*
+handleNavigationChecks answers the page checks of NavigateWithRetry with the
+specified results in order, repeating the last one.
+*/
+func handleNavigationChecks(cdp *cdptest.Server, checks ...map[string]interface{}) {
+	mux := &sync.Mutex{}
+	cdp.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		check := checks[0]
+		if len(checks) > 1 {
+			checks = checks[1:]
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "object", "value": check}}, nil
+	})
+}
+
+func TestTabNavigateWithRetry(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	handleNavigationChecks(cdp,
+		map[string]interface{}{"status": 503, "length": 20, "selector": "", "url": "https://example.com/"},
+		map[string]interface{}{"status": 200, "length": 0, "selector": "", "url": "https://example.com/"},
+		map[string]interface{}{"status": 200, "length": 20, "selector": "#captcha", "url": "https://example.com/"},
+		map[string]interface{}{"status": 200, "length": 20, "selector": "", "url": "https://example.com/home"},
+	)
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	navigation, err := tab.NavigateWithRetry(context.Background(), "https://example.com/", &NavigationPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		ErrorSelectors: []string{"#captcha"},
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if tab != navigation.Tab {
+		t.Errorf("Expected the page to be loaded in the tab")
+	}
+	if 4 != len(navigation.Attempts) {
+		t.Fatalf("Expected 4 attempts, got %d", len(navigation.Attempts))
+	}
+	for a, expected := range []string{"status 503", "0 characters", "#captcha"} {
+		if attempt := navigation.Attempts[a]; nil == attempt.Err || !strings.Contains(attempt.Err.Error(), expected) {
+			t.Errorf("Expected attempt %d to fail with '%s', got %v", attempt.Number, expected, attempt.Err)
+		}
+	}
+	if last := navigation.Attempts[3]; nil != last.Err || 200 != last.Status || "https://example.com/home" != last.URL {
+		t.Errorf("Expected the last attempt to succeed, got %+v", last)
+	}
+
+	var navigations int
+	for _, command := range cdp.Commands() {
+		if "Page.navigate" == command.Method {
+			navigations++
+		}
+	}
+	if 4 != navigations {
+		t.Errorf("Expected 4 navigations, got %d", navigations)
+	}
+}
+
+func TestTabNavigateWithRetryFailure(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	cdp.Respond("Page.navigate", map[string]interface{}{"frameId": "1", "errorText": "net::ERR_TIMED_OUT"})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	navigation, err := tab.NavigateWithRetry(context.Background(), "https://example.com/", &NavigationPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+	})
+	if nil == err {
+		t.Fatalf("Expected error, got nil")
+	}
+	if nil != navigation.Tab {
+		t.Errorf("Expected no tab, got %v", navigation.Tab)
+	}
+	if 2 != len(navigation.Attempts) {
+		t.Fatalf("Expected 2 attempts, got %d", len(navigation.Attempts))
+	}
+	if last := navigation.Attempts[1]; nil == last.Err || !strings.Contains(last.Err.Error(), "net::ERR_TIMED_OUT") {
+		t.Errorf("Expected a timed out error, got %v", last.Err)
+	}
+
+	navigation, err = tab.NavigateWithRetry(context.Background(), "https://example.com/", &NavigationPolicy{
+		InitialBackoff: time.Millisecond,
+		Retryable: func(attempt *NavigationAttempt) bool {
+			return false
+		},
+	})
+	if nil == err || 1 != len(navigation.Attempts) {
+		t.Errorf("Expected 1 failed attempt, got %d %v", len(navigation.Attempts), err)
+	}
+}
+
+func TestTabNavigateWithRetryProxies(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	handleNavigationChecks(cdp,
+		map[string]interface{}{"status": 502, "length": 20, "selector": "", "url": "https://example.com/"},
+		map[string]interface{}{"status": 200, "length": 20, "selector": "", "url": "https://example.com/"},
+	)
+	var contexts int
+	cdp.Handle("Target.createBrowserContext", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		contexts++
+		return map[string]interface{}{"browserContextId": []string{"", "ctx1", "ctx2"}[contexts]}, nil
+	})
+	cdp.Respond("Target.createTarget", map[string]interface{}{"targetId": "2"})
+	cdp.Respond("Target.attachToTarget", map[string]interface{}{"sessionId": "S"})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	first := &Proxy{Server: "http://first:3128"}
+	second := &Proxy{Server: "http://second:3128"}
+	rotator := NewProxyRotator(ProxyList{first, second}, 1, time.Hour)
+	navigation, err := tab.NavigateWithRetry(context.Background(), "https://example.com/", &NavigationPolicy{
+		InitialBackoff: time.Millisecond,
+		Proxies:        rotator,
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if 2 != len(navigation.Attempts) || first != navigation.Attempts[0].Proxy || second != navigation.Attempts[1].Proxy {
+		t.Fatalf("Expected an attempt with each proxy, got %+v", navigation.Attempts)
+	}
+	if nil == navigation.Tab || tab == navigation.Tab {
+		t.Errorf("Expected the page to be loaded in a new tab")
+	}
+	for _, health := range rotator.Health() {
+		if first == health.Proxy && 0 == health.Failures {
+			t.Errorf("Expected the first proxy to have failed")
+		}
+	}
+
+	if err := tab.Cleanup(); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	disposed := map[string]bool{}
+	for _, command := range cdp.Commands() {
+		switch command.Method {
+		case "Page.navigate":
+			if "S" != command.SessionID {
+				t.Errorf("Expected the navigation to be sent to the session, got '%s'", command.SessionID)
+			}
+		case "Target.disposeBrowserContext":
+			params := struct {
+				BrowserContextID string `json:"browserContextId"`
+			}{}
+			json.Unmarshal(command.Params, &params)
+			disposed[params.BrowserContextID] = true
+		}
+	}
+	if !disposed["ctx1"] || !disposed["ctx2"] {
+		t.Errorf("Expected both browser contexts to be disposed, got %v", disposed)
+	}
+}
@@ -0,0 +1,107 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+)
+
+/*
+BodyJSON decodes the response body into v.
+*/
+func (response *Response) BodyJSON(v interface{}) error {
+	body, err := response.Body()
+	if nil != err {
+		return err
+	}
+	if err := json.Unmarshal(body, v); nil != err {
+		return errs.Wrap(err, codes.TabBodyUnavailable, fmt.Sprintf("could not decode the body of response %s", response.RequestID))
+	}
+	return nil
+}
+
+/*
+Field extracts a field from a JSON response body using a JSONPath-style
+expression. Object keys are separated by '.' and array elements are selected
+by index, a leading '$' is optional:
+
+	name, err := response.Field("$.data.items[0].name")
+
+Values are decoded the way encoding/json decodes into an interface{}, so
+numbers are float64, objects are map[string]interface{} and arrays are
+[]interface{}.
+*/
+func (response *Response) Field(path string) (interface{}, error) {
+	var doc interface{}
+	if err := response.BodyJSON(&doc); nil != err {
+		return nil, err
+	}
+	value, err := jsonPath(doc, path)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabFieldNotFound, fmt.Sprintf("could not extract '%s' from the body of response %s", path, response.RequestID))
+	}
+	return value, nil
+}
+
+/*
+jsonPath returns the value at a path in a decoded JSON document.
+*/
+func jsonPath(doc interface{}, path string) (interface{}, error) {
+	segments, err := jsonPathSegments(path)
+	if nil != err {
+		return nil, err
+	}
+	value := doc
+	for _, segment := range segments {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = node[segment]; !ok {
+				return nil, errs.New(0, fmt.Sprintf("key '%s' not found", segment))
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if nil != err {
+				return nil, errs.New(0, fmt.Sprintf("invalid index '%s' for an array", segment))
+			}
+			if index < 0 || index >= len(node) {
+				return nil, errs.New(0, fmt.Sprintf("index %d out of range", index))
+			}
+			value = node[index]
+		default:
+			return nil, errs.New(0, fmt.Sprintf("cannot select '%s' from a %T", segment, value))
+		}
+	}
+	return value, nil
+}
+
+/*
+jsonPathSegments splits a path into object keys and array indexes.
+*/
+func jsonPathSegments(path string) ([]string, error) {
+	path = strings.TrimPrefix(path, "$")
+	segments := make([]string, 0)
+	for _, part := range strings.Split(path, ".") {
+		for "" != part {
+			open := strings.Index(part, "[")
+			if -1 == open {
+				segments = append(segments, part)
+				break
+			}
+			if open > 0 {
+				segments = append(segments, part[:open])
+			}
+			end := strings.Index(part, "]")
+			if end < open {
+				return nil, errs.New(0, fmt.Sprintf("invalid path '%s'", path))
+			}
+			segments = append(segments, part[open+1:end])
+			part = part[end+1:]
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,66 @@
+package chrome
+
+import (
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+func TestResponseBodyJSON(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestResponseBodyJSON")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+	rec.results["Network.getResponseBody"] = `{"body": "{\"data\": {\"items\": [{\"name\": \"a\"}, {\"name\": \"b\", \"tags\": [1, 2]}]}}"}`
+	response := &Response{
+		ResponseReceivedEvent: &network.ResponseReceivedEvent{RequestID: "1"},
+		tab:                   tab,
+	}
+
+	data := struct {
+		Data struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+		} `json:"data"`
+	}{}
+	if err := response.BodyJSON(&data); nil != err || 2 != len(data.Data.Items) {
+		t.Errorf("Expected the decoded body, received %v %v", data, err)
+	}
+
+	for path, expect := range map[string]interface{}{
+		"$.data.items[1].name":    "b",
+		"data.items[0].name":      "a",
+		"$.data.items[1].tags[1]": float64(2),
+	} {
+		value, err := response.Field(path)
+		if nil != err {
+			t.Errorf("Expected nil, received error for '%s': %v", path, err)
+		} else if expect != value {
+			t.Errorf("Expected %v for '%s', received %v", expect, path, value)
+		}
+	}
+	if value, err := response.Field("$"); nil != err {
+		t.Errorf("Expected the document, received error: %v", err)
+	} else if _, ok := value.(map[string]interface{}); !ok {
+		t.Errorf("Expected the document, received %v", value)
+	}
+
+	for _, path := range []string{
+		"$.data.missing",
+		"$.data.items[2]",
+		"$.data.items.name",
+		"$.data.items[0].name.first",
+		"$.data.items[0",
+	} {
+		if _, err := response.Field(path); nil == err {
+			t.Errorf("Expected error for '%s', received nil", path)
+		}
+	}
+
+	rec.results["Network.getResponseBody"] = `{"body": "not json"}`
+	if err := response.BodyJSON(&data); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
@@ -0,0 +1,255 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Request is a network request sent by a tab.
+*/
+type Request struct {
+	*network.RequestWillBeSentEvent
+	tab *Tab
+}
+
+/*
+Body returns the data sent with the request. An error is returned if the
+request has no body.
+*/
+func (request *Request) Body() ([]byte, error) {
+	if "" != request.Request.PostData {
+		return []byte(request.Request.PostData), nil
+	}
+	result := <-request.tab.Network().GetRequestPostData(&network.GetRequestPostDataParams{
+		RequestID: request.RequestID,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.TabBodyUnavailable, fmt.Sprintf("could not read the body of request %s", request.RequestID))
+	}
+	return []byte(result.PostData), nil
+}
+
+/*
+Response is a network response received by a tab.
+*/
+type Response struct {
+	*network.ResponseReceivedEvent
+	tab *Tab
+}
+
+/*
+Body returns the response body.
+*/
+func (response *Response) Body() ([]byte, error) {
+	result := <-response.tab.Network().GetResponseBody(&network.GetResponseBodyParams{
+		RequestID: response.RequestID,
+	})
+	if nil != result.Err {
+		return nil, errs.Wrap(result.Err, codes.TabBodyUnavailable, fmt.Sprintf("could not read the body of response %s", response.RequestID))
+	}
+	if !result.Base64Encoded {
+		return []byte(result.Body), nil
+	}
+	body, err := base64.StdEncoding.DecodeString(result.Body)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabBodyUnavailable, fmt.Sprintf("could not decode the body of response %s", response.RequestID))
+	}
+	return body, nil
+}
+
+/*
+WaitForRequest blocks until the tab sends a request whose URL matches a
+pattern, or the context is done. Wildcards ('*' -> zero or more, '?' -> exactly
+one) are allowed and an empty pattern matches every request.
+
+The Network domain must be enabled. Only requests sent after WaitForRequest is
+called are matched, so it's usually called in a goroutine before triggering the
+request:
+
+	requestCh := make(chan *chrome.Request)
+	go func() {
+		request, _ := tab.WaitForRequest(ctx, "https://example.com/api/search*")
+		requestCh <- request
+	}()
+*/
+func (tab *Tab) WaitForRequest(ctx context.Context, pattern string) (*Request, error) {
+	url := urlPattern(pattern)
+	found := make(chan *network.RequestWillBeSentEvent, 1)
+	handler := socket.NewEventHandler("Network.requestWillBeSent", func(response *socket.Response) {
+		event := &network.RequestWillBeSentEvent{}
+		if err := json.Unmarshal(response.Params, event); nil != err || nil == event.Request {
+			return
+		}
+		if url.MatchString(event.Request.URL) {
+			select {
+			case found <- event:
+			default:
+			}
+		}
+	})
+	tab.AddEventHandler(handler)
+	defer tab.RemoveEventHandler(handler)
+
+	select {
+	case event := <-found:
+		return &Request{RequestWillBeSentEvent: event, tab: tab}, nil
+	case <-ctx.Done():
+		return nil, errs.Wrap(ctx.Err(), codes.TabWaitFailed, fmt.Sprintf("no request matching '%s'", pattern))
+	}
+}
+
+/*
+WaitForResponse blocks until the tab receives a response whose URL matches a
+pattern and that satisfies the predicate, or the context is done. Patterns
+work like they do for WaitForRequest. A nil predicate accepts every matching
+response. The predicate only has access to the response metadata, such as the
+status and headers.
+
+WaitForResponse returns once the response body has finished loading, so the
+body is available through Response.Body. The Network domain must be enabled.
+*/
+func (tab *Tab) WaitForResponse(ctx context.Context, pattern string, predicate func(response *Response) bool) (*Response, error) {
+	waiter := tab.watchResponses(pattern, predicate)
+	defer waiter.stop()
+
+	select {
+	case response := <-waiter.found:
+		return response, nil
+	case <-ctx.Done():
+		return nil, errs.Wrap(ctx.Err(), codes.TabWaitFailed, fmt.Sprintf("no response matching '%s'", pattern))
+	}
+}
+
+/*
+responseWaiter matches responses to the loading events of their requests.
+Event handlers run concurrently, so a loading event can be handled before the
+response it belongs to.
+*/
+type responseWaiter struct {
+	done     map[network.RequestID]bool
+	found    chan *Response
+	handlers []socket.EventHandler
+	matched  map[network.RequestID]*Response
+	mux      *sync.Mutex
+	tab      *Tab
+	url      *regexp.Regexp
+	validate func(response *Response) bool
+}
+
+/*
+watchResponses starts matching responses, the first matching response is sent
+to the found channel of the returned waiter. The handlers are registered before
+watchResponses returns, so responses to requests triggered afterwards are never
+missed.
+*/
+func (tab *Tab) watchResponses(pattern string, predicate func(response *Response) bool) *responseWaiter {
+	waiter := &responseWaiter{
+		done:     make(map[network.RequestID]bool),
+		found:    make(chan *Response, 1),
+		matched:  make(map[network.RequestID]*Response),
+		mux:      &sync.Mutex{},
+		url:      urlPattern(pattern),
+		tab:      tab,
+		validate: predicate,
+	}
+	waiter.handlers = []socket.EventHandler{
+		socket.NewEventHandler("Network.responseReceived", waiter.received),
+		socket.NewEventHandler("Network.loadingFinished", waiter.finished),
+		socket.NewEventHandler("Network.loadingFailed", waiter.failed),
+	}
+	for _, handler := range waiter.handlers {
+		tab.AddEventHandler(handler)
+	}
+	return waiter
+}
+
+/*
+stop removes the event handlers of the waiter.
+*/
+func (waiter *responseWaiter) stop() {
+	for _, handler := range waiter.handlers {
+		waiter.tab.RemoveEventHandler(handler)
+	}
+}
+
+func (waiter *responseWaiter) received(response *socket.Response) {
+	event := &network.ResponseReceivedEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err || nil == event.Response {
+		return
+	}
+	if !waiter.url.MatchString(event.Response.URL) {
+		return
+	}
+	res := &Response{ResponseReceivedEvent: event, tab: waiter.tab}
+	if nil != waiter.validate && !waiter.validate(res) {
+		return
+	}
+
+	waiter.mux.Lock()
+	defer waiter.mux.Unlock()
+	if waiter.done[event.RequestID] {
+		waiter.deliver(res)
+		return
+	}
+	waiter.matched[event.RequestID] = res
+}
+
+func (waiter *responseWaiter) finished(response *socket.Response) {
+	event := &network.LoadingFinishedEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err {
+		return
+	}
+	waiter.loaded(event.RequestID)
+}
+
+func (waiter *responseWaiter) failed(response *socket.Response) {
+	event := &network.LoadingFailedEvent{}
+	if err := json.Unmarshal(response.Params, event); nil != err {
+		return
+	}
+	waiter.loaded(event.RequestID)
+}
+
+/*
+loaded delivers the matched response for a request that finished loading.
+*/
+func (waiter *responseWaiter) loaded(requestID network.RequestID) {
+	waiter.mux.Lock()
+	defer waiter.mux.Unlock()
+	if res, ok := waiter.matched[requestID]; ok {
+		waiter.deliver(res)
+		return
+	}
+	waiter.done[requestID] = true
+}
+
+/*
+deliver sends the first matching response. Must be called with the mutex
+locked.
+*/
+func (waiter *responseWaiter) deliver(res *Response) {
+	select {
+	case waiter.found <- res:
+	default:
+	}
+}
+
+/*
+urlPattern compiles a URL pattern, an empty pattern matches every URL.
+*/
+func urlPattern(pattern string) *regexp.Regexp {
+	if "" == pattern {
+		return wildcardPattern("*")
+	}
+	return wildcardPattern(pattern)
+}
@@ -0,0 +1,114 @@
+package chrome
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+/*
+waitForHandlers blocks until the specified number of event handlers have been
+added to the recording socket.
+*/
+func waitForHandlers(t *testing.T, rec *recordingSocket, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rec.mux.Lock()
+		added := len(rec.handlers)
+		rec.mux.Unlock()
+		if added >= count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Expected %d event handlers", count)
+}
+
+func TestTabWaitForRequest(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabWaitForRequest")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+	rec.results["Network.getRequestPostData"] = `{"postData": "large body"}`
+
+	type result struct {
+		request *Request
+		err     error
+	}
+	resultCh := make(chan result)
+	go func() {
+		request, err := tab.WaitForRequest(context.Background(), "*/api/*")
+		resultCh <- result{request, err}
+	}()
+	waitForHandlers(t, rec, 1)
+	rec.emit("Network.requestWillBeSent", `{"requestId": "1", "request": {"url": "https://example.com/index.html", "method": "GET", "headers": {}}}`)
+	rec.emit("Network.requestWillBeSent", `{"requestId": "2", "request": {"url": "https://example.com/api/search", "method": "POST", "headers": {}, "postData": "q=go"}}`)
+
+	res := <-resultCh
+	if nil != res.err {
+		t.Fatalf("Expected nil, received error: %v", res.err)
+	}
+	if "2" != res.request.RequestID {
+		t.Errorf("Expected request 2, received %s", res.request.RequestID)
+	}
+	if body, err := res.request.Body(); nil != err || "q=go" != string(body) {
+		t.Errorf("Expected the post data, received '%s' %v", body, err)
+	}
+	res.request.Request.PostData = ""
+	if body, err := res.request.Body(); nil != err || "large body" != string(body) {
+		t.Errorf("Expected the requested post data, received '%s' %v", body, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := tab.WaitForRequest(ctx, "*/api/*"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestTabWaitForResponse(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabWaitForResponse")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+	rec.results["Network.getResponseBody"] = `{"body": "eyJvayI6IHRydWV9", "base64Encoded": true}`
+
+	type result struct {
+		response *Response
+		err      error
+	}
+	resultCh := make(chan result)
+	go func() {
+		response, err := tab.WaitForResponse(context.Background(), "*/api/*", func(response *Response) bool {
+			return 200 == response.Response.Status
+		})
+		resultCh <- result{response, err}
+	}()
+	waitForHandlers(t, rec, 3)
+	rec.emit("Network.responseReceived", `{"requestId": "1", "type": "XHR", "response": {"url": "https://example.com/api/1", "status": 500}}`)
+	rec.emit("Network.loadingFinished", `{"requestId": "1"}`)
+	// The loading event can be handled before the response.
+	rec.emit("Network.loadingFinished", `{"requestId": "2"}`)
+	rec.emit("Network.responseReceived", `{"requestId": "2", "type": "XHR", "response": {"url": "https://example.com/api/2", "status": 200}}`)
+
+	var res result
+	select {
+	case res = <-resultCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a response")
+	}
+	if nil != res.err {
+		t.Fatalf("Expected nil, received error: %v", res.err)
+	}
+	if "2" != res.response.RequestID {
+		t.Errorf("Expected response 2, received %s", res.response.RequestID)
+	}
+	data := struct {
+		OK bool `json:"ok"`
+	}{}
+	if err := res.response.BodyJSON(&data); nil != err || !data.OK {
+		t.Errorf("Expected the decoded body, received %v %v", data, err)
+	}
+}
@@ -0,0 +1,257 @@
+package chrome
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/emulation"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+OverrideState is a snapshot of the emulation overrides applied to a tab. A nil
+field means the override is not active.
+*/
+type OverrideState struct {
+	CPUThrottlingRate *emulation.SetCPUThrottlingRateParams     `json:"cpuThrottlingRate,omitempty"`
+	DeviceMetrics     *emulation.SetDeviceMetricsOverrideParams `json:"deviceMetrics,omitempty"`
+	Geolocation       *emulation.SetGeolocationOverrideParams   `json:"geolocation,omitempty"`
+	NetworkConditions *network.EmulateConditionsParams          `json:"networkConditions,omitempty"`
+	UserAgent         *network.SetUserAgentOverrideParams       `json:"userAgent,omitempty"`
+}
+
+/*
+copy returns a copy of the state that doesn't share any params structs.
+*/
+func (state OverrideState) copy() OverrideState {
+	cp := OverrideState{}
+	if nil != state.CPUThrottlingRate {
+		tmp := *state.CPUThrottlingRate
+		cp.CPUThrottlingRate = &tmp
+	}
+	if nil != state.DeviceMetrics {
+		tmp := *state.DeviceMetrics
+		cp.DeviceMetrics = &tmp
+	}
+	if nil != state.Geolocation {
+		tmp := *state.Geolocation
+		cp.Geolocation = &tmp
+	}
+	if nil != state.NetworkConditions {
+		tmp := *state.NetworkConditions
+		cp.NetworkConditions = &tmp
+	}
+	if nil != state.UserAgent {
+		tmp := *state.UserAgent
+		cp.UserAgent = &tmp
+	}
+	return cp
+}
+
+/*
+empty returns whether no overrides are active.
+*/
+func (state OverrideState) empty() bool {
+	return nil == state.CPUThrottlingRate &&
+		nil == state.DeviceMetrics &&
+		nil == state.Geolocation &&
+		nil == state.NetworkConditions &&
+		nil == state.UserAgent
+}
+
+/*
+Overrides tracks the emulation overrides applied to a tab so they can be
+snapshotted and restored. Overrides should be applied through this struct
+rather than directly through the protocol interfaces, otherwise they can't be
+tracked.
+
+Snapshot and Restore can be nested to apply temporary emulation:
+
+	snapshot := tab.Overrides().Snapshot()
+	defer tab.Overrides().Restore(snapshot)
+	tab.Overrides().SetUserAgent(&network.SetUserAgentOverrideParams{...})
+
+All overrides are cleared when the tab is cleaned up.
+*/
+type Overrides struct {
+	mux        *sync.Mutex
+	registered bool
+	state      OverrideState
+	tab        *Tab
+}
+
+/*
+Overrides returns the emulation override state for the tab.
+*/
+func (tab *Tab) Overrides() *Overrides {
+	tab.overridesOnce.Do(func() {
+		tab.overrides = &Overrides{
+			mux: &sync.Mutex{},
+			tab: tab,
+		}
+	})
+	return tab.overrides
+}
+
+/*
+register registers a cleanup function with the tab the first time an override
+is applied. Must be called with the mutex locked.
+*/
+func (overrides *Overrides) register() {
+	if overrides.registered {
+		return
+	}
+	overrides.registered = true
+	overrides.tab.OnClose(func() error {
+		overrides.mux.Lock()
+		overrides.registered = false
+		overrides.mux.Unlock()
+		return overrides.Restore(OverrideState{})
+	})
+}
+
+/*
+Restore applies a snapshot, changing or clearing only the overrides that differ
+from the current state.
+*/
+func (overrides *Overrides) Restore(state OverrideState) error {
+	state = state.copy()
+	overrides.mux.Lock()
+	current := overrides.state
+	overrides.mux.Unlock()
+
+	if !reflect.DeepEqual(current.CPUThrottlingRate, state.CPUThrottlingRate) {
+		params := state.CPUThrottlingRate
+		if nil == params {
+			params = &emulation.SetCPUThrottlingRateParams{Rate: 1}
+		}
+		if err := (<-overrides.tab.Emulation().SetCPUThrottlingRate(params)).Err; nil != err {
+			return err
+		}
+		overrides.set(func(s *OverrideState) { s.CPUThrottlingRate = state.CPUThrottlingRate })
+	}
+
+	if !reflect.DeepEqual(current.DeviceMetrics, state.DeviceMetrics) {
+		var err error
+		if nil == state.DeviceMetrics {
+			err = (<-overrides.tab.Emulation().ClearDeviceMetricsOverride()).Err
+		} else {
+			err = (<-overrides.tab.Emulation().SetDeviceMetricsOverride(state.DeviceMetrics)).Err
+		}
+		if nil != err {
+			return err
+		}
+		overrides.set(func(s *OverrideState) { s.DeviceMetrics = state.DeviceMetrics })
+	}
+
+	if !reflect.DeepEqual(current.Geolocation, state.Geolocation) {
+		var err error
+		if nil == state.Geolocation {
+			err = (<-overrides.tab.Emulation().ClearGeolocationOverride()).Err
+		} else {
+			err = (<-overrides.tab.Emulation().SetGeolocationOverride(state.Geolocation)).Err
+		}
+		if nil != err {
+			return err
+		}
+		overrides.set(func(s *OverrideState) { s.Geolocation = state.Geolocation })
+	}
+
+	if !reflect.DeepEqual(current.NetworkConditions, state.NetworkConditions) {
+		params := state.NetworkConditions
+		if nil == params {
+			params = &network.EmulateConditionsParams{
+				DownloadThroughput: -1,
+				UploadThroughput:   -1,
+			}
+		}
+		if err := (<-overrides.tab.Network().EmulateConditions(params)).Err; nil != err {
+			return err
+		}
+		overrides.set(func(s *OverrideState) { s.NetworkConditions = state.NetworkConditions })
+	}
+
+	if !reflect.DeepEqual(current.UserAgent, state.UserAgent) {
+		// An empty user agent disables the override.
+		params := state.UserAgent
+		if nil == params {
+			params = &network.SetUserAgentOverrideParams{}
+		}
+		if err := (<-overrides.tab.Network().SetUserAgentOverride(params)).Err; nil != err {
+			return err
+		}
+		overrides.set(func(s *OverrideState) { s.UserAgent = state.UserAgent })
+	}
+
+	return nil
+}
+
+/*
+set updates the tracked state.
+*/
+func (overrides *Overrides) set(fn func(state *OverrideState)) {
+	overrides.mux.Lock()
+	fn(&overrides.state)
+	if !overrides.state.empty() {
+		overrides.register()
+	}
+	overrides.mux.Unlock()
+}
+
+/*
+SetCPUThrottlingRate enables CPU throttling to emulate slow CPUs and tracks the
+override.
+*/
+func (overrides *Overrides) SetCPUThrottlingRate(params *emulation.SetCPUThrottlingRateParams) error {
+	state := overrides.Snapshot()
+	state.CPUThrottlingRate = params
+	return overrides.Restore(state)
+}
+
+/*
+SetDeviceMetrics overrides the values of device screen dimensions and tracks
+the override.
+*/
+func (overrides *Overrides) SetDeviceMetrics(params *emulation.SetDeviceMetricsOverrideParams) error {
+	state := overrides.Snapshot()
+	state.DeviceMetrics = params
+	return overrides.Restore(state)
+}
+
+/*
+SetGeolocation overrides the geolocation position or error and tracks the
+override.
+*/
+func (overrides *Overrides) SetGeolocation(params *emulation.SetGeolocationOverrideParams) error {
+	state := overrides.Snapshot()
+	state.Geolocation = params
+	return overrides.Restore(state)
+}
+
+/*
+SetNetworkConditions activates emulation of network conditions and tracks the
+override.
+*/
+func (overrides *Overrides) SetNetworkConditions(params *network.EmulateConditionsParams) error {
+	state := overrides.Snapshot()
+	state.NetworkConditions = params
+	return overrides.Restore(state)
+}
+
+/*
+SetUserAgent overrides the user agent string and tracks the override.
+*/
+func (overrides *Overrides) SetUserAgent(params *network.SetUserAgentOverrideParams) error {
+	state := overrides.Snapshot()
+	state.UserAgent = params
+	return overrides.Restore(state)
+}
+
+/*
+Snapshot returns a copy of the currently active overrides.
+*/
+func (overrides *Overrides) Snapshot() OverrideState {
+	overrides.mux.Lock()
+	defer overrides.mux.Unlock()
+	return overrides.state.copy()
+}
@@ -0,0 +1,138 @@
+package chrome
+
+import (
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/emulation"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+recordingSocket is a MockSocket that responds to every command with the result
+set for its method, or an empty result, and records the commands that were
+sent and the event handlers that were added.
+*/
+type recordingSocket struct {
+	*MockSocket
+	commands []socket.Commander
+	handlers []socket.EventHandler
+	methods  []string
+	mux      *sync.Mutex
+	results  map[string]string
+}
+
+func newRecordingSocket(url *url.URL) *recordingSocket {
+	rec := &recordingSocket{
+		MockSocket: NewMockSocket(url),
+		methods:    make([]string, 0),
+		mux:        &sync.Mutex{},
+		results:    make(map[string]string),
+	}
+	rec.browser = &socket.BrowserProtocol{Socket: rec}
+	rec.emulation = &socket.EmulationProtocol{Socket: rec}
+	rec.fetch = &socket.FetchProtocol{Socket: rec}
+	rec.network = &socket.NetworkProtocol{Socket: rec}
+	return rec
+}
+
+func (rec *recordingSocket) SendCommand(command socket.Commander) chan *socket.Response {
+	rec.mux.Lock()
+	rec.commands = append(rec.commands, command)
+	rec.methods = append(rec.methods, command.Method())
+	result, ok := rec.results[command.Method()]
+	rec.mux.Unlock()
+	if !ok {
+		result = `{}`
+	}
+	go command.Respond(&socket.Response{ID: command.ID(), Result: []byte(result)})
+	return command.Response()
+}
+
+func (rec *recordingSocket) AddEventHandler(handler socket.EventHandler) *socket.Subscription {
+	rec.mux.Lock()
+	rec.handlers = append(rec.handlers, handler)
+	rec.mux.Unlock()
+	return socket.NewSubscription(handler, func() error { return nil })
+}
+
+/*
+emit passes an event to the added handlers for that event.
+*/
+func (rec *recordingSocket) emit(method string, params string) {
+	rec.mux.Lock()
+	handlers := append([]socket.EventHandler{}, rec.handlers...)
+	rec.mux.Unlock()
+	for _, handler := range handlers {
+		if method == handler.Name() {
+			handler.Handle(&socket.Response{Method: method, Params: []byte(params)})
+		}
+	}
+}
+
+func (rec *recordingSocket) reset() []string {
+	rec.mux.Lock()
+	defer rec.mux.Unlock()
+	methods := rec.methods
+	rec.commands = nil
+	rec.methods = make([]string, 0)
+	return methods
+}
+
+func TestTabOverrides(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabOverrides")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+
+	if err := tab.Overrides().SetUserAgent(&network.SetUserAgentOverrideParams{UserAgent: "outer"}); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	outer := tab.Overrides().Snapshot()
+	if nil == outer.UserAgent || "outer" != outer.UserAgent.UserAgent {
+		t.Errorf("Expected the outer user agent, received %v", outer.UserAgent)
+	}
+
+	tab.Overrides().SetUserAgent(&network.SetUserAgentOverrideParams{UserAgent: "inner"})
+	tab.Overrides().SetCPUThrottlingRate(&emulation.SetCPUThrottlingRateParams{Rate: 4})
+	tab.Overrides().SetGeolocation(&emulation.SetGeolocationOverrideParams{Latitude: 1, Longitude: 2})
+	rec.reset()
+
+	if err := tab.Overrides().Restore(outer); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	methods := rec.reset()
+	expected := []string{"Emulation.setCPUThrottlingRate", "Emulation.clearGeolocationOverride", "Network.setUserAgentOverride"}
+	if len(expected) != len(methods) {
+		t.Fatalf("Expected %v, received %v", expected, methods)
+	}
+	for a := range expected {
+		if expected[a] != methods[a] {
+			t.Errorf("Expected %v, received %v", expected, methods)
+		}
+	}
+	restored := tab.Overrides().Snapshot()
+	if nil == restored.UserAgent || "outer" != restored.UserAgent.UserAgent || nil != restored.CPUThrottlingRate || nil != restored.Geolocation {
+		t.Errorf("Expected the outer state, received %v", restored)
+	}
+
+	if err := tab.Cleanup(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if methods := rec.reset(); 1 != len(methods) || "Network.setUserAgentOverride" != methods[0] {
+		t.Errorf("Expected the user agent to be cleared, received %v", methods)
+	}
+	if !tab.Overrides().Snapshot().empty() {
+		t.Errorf("Expected no active overrides after cleanup")
+	}
+
+	// Overrides applied after a cleanup are cleaned up again.
+	tab.Overrides().SetNetworkConditions(&network.EmulateConditionsParams{Offline: true})
+	tab.Cleanup()
+	if !tab.Overrides().Snapshot().empty() {
+		t.Errorf("Expected no active overrides after cleanup")
+	}
+}
@@ -0,0 +1,348 @@
+package chrome
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
+	"github.com/mkenney/go-chrome/tot/browser"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/socket"
+	yaml "gopkg.in/yaml.v2"
+)
+
+/*
+Profile is a declarative tab configuration. Profiles are JSON or YAML documents
+so that rendering behavior can be tuned without code changes, for example:
+
+	{
+		"emulation": {
+			"userAgent": {"userAgent": "Mozilla/5.0 ..."},
+			"deviceMetrics": {"width": 375, "height": 667, "deviceScaleFactor": 2, "mobile": true}
+		},
+		"headers": {"Accept-Language": "de-DE"},
+		"blockedURLs": ["*.doubleclick.net"],
+		"permissions": [{"origin": "https://example.com", "permissions": ["geolocation"]}],
+		"intercept": [{"urlPattern": "*.png", "errorReason": "Aborted"}]
+	}
+
+YAML documents use the same field names:
+
+	emulation:
+	  userAgent:
+	    userAgent: Mozilla/5.0 ...
+	headers:
+	  Accept-Language: de-DE
+	blockedURLs:
+	  - "*.doubleclick.net"
+
+Everything a profile applies is registered with the tab cleanup registry and is
+undone when the tab is cleaned up or closed.
+*/
+type Profile struct {
+	// Optional. Emulation overrides, applied through Tab.Overrides().
+	Emulation *OverrideState `json:"emulation,omitempty"`
+
+	// Optional. Extra HTTP headers sent with every request.
+	Headers network.Headers `json:"headers,omitempty"`
+
+	// Optional. URL patterns to block. Wildcards ('*') are allowed.
+	BlockedURLs []string `json:"blockedURLs,omitempty"`
+
+	// Optional. Permissions to grant. All other permissions are denied for
+	// the listed origins.
+	Permissions []*browser.GrantPermissionsParams `json:"permissions,omitempty"`
+
+	// Optional. Request interception rules. The first matching rule is
+	// applied to an intercepted request.
+	Intercept []*InterceptRule `json:"intercept,omitempty"`
+}
+
+/*
+InterceptRule describes how matching requests are handled. A rule with an
+ErrorReason fails the request, otherwise the request continues with any
+configured headers merged into the request headers.
+*/
+type InterceptRule struct {
+	// Optional. Wildcards ('*' -> zero or more, '?' -> exactly one) are
+	// allowed. Omitting is equivalent to "*".
+	URLPattern string `json:"urlPattern,omitempty"`
+
+	// Optional. If set, only requests for matching resource types are
+	// intercepted.
+	ResourceType page.ResourceTypeEnum `json:"resourceType,omitempty"`
+
+	// Optional. If set, matching requests fail with the given reason.
+	ErrorReason network.ErrorReasonEnum `json:"errorReason,omitempty"`
+
+	// Optional. Headers to add to or replace in matching requests.
+	Headers network.Headers `json:"headers,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+/*
+match returns whether an intercepted request matches the rule.
+*/
+func (rule *InterceptRule) match(event *network.RequestInterceptedEvent) bool {
+	if page.ResourceTypeEnum(0) != rule.ResourceType && rule.ResourceType != event.ResourceType {
+		return false
+	}
+	return nil != event.Request && rule.pattern.MatchString(event.Request.URL)
+}
+
+/*
+wildcardPattern compiles a CDP URL pattern into a regular expression. '*'
+matches zero or more characters, '?' matches exactly one and a backslash
+escapes the next character.
+*/
+func wildcardPattern(pattern string) *regexp.Regexp {
+	expr := &bytes.Buffer{}
+	expr.WriteString("^")
+	for a := 0; a < len(pattern); a++ {
+		switch pattern[a] {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		case '\\':
+			if a+1 < len(pattern) {
+				a++
+			}
+			expr.WriteString(regexp.QuoteMeta(string(pattern[a])))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(pattern[a])))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+/*
+LoadProfile reads a Profile from a file. Files with a '.yaml' or '.yml'
+extension are read as YAML documents, other files as JSON documents.
+*/
+func LoadProfile(file string) (*Profile, error) {
+	fh, err := os.Open(file)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabProfileInvalid, fmt.Sprintf("could not open profile '%s'", file))
+	}
+	defer fh.Close()
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return ReadYAMLProfile(fh)
+	}
+	return ReadProfile(fh)
+}
+
+/*
+ReadProfile reads a Profile from a JSON document.
+*/
+func ReadProfile(reader io.Reader) (*Profile, error) {
+	profile := &Profile{}
+	if err := json.NewDecoder(reader).Decode(profile); nil != err {
+		return nil, errs.Wrap(err, codes.TabProfileInvalid, "could not decode profile")
+	}
+	return profile, nil
+}
+
+/*
+ReadYAMLProfile reads a Profile from a YAML document. The document is decoded
+like the equivalent JSON document, so the field names and values are the same.
+*/
+func ReadYAMLProfile(reader io.Reader) (*Profile, error) {
+	data, err := ioutil.ReadAll(reader)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabProfileInvalid, "could not read profile")
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); nil != err {
+		return nil, errs.Wrap(err, codes.TabProfileInvalid, "could not decode profile")
+	}
+	doc, err = yamlToJSON(doc)
+	if nil != err {
+		return nil, err
+	}
+	if data, err = json.Marshal(doc); nil != err {
+		return nil, errs.Wrap(err, codes.TabProfileInvalid, "could not decode profile")
+	}
+	return ReadProfile(bytes.NewReader(data))
+}
+
+/*
+yamlToJSON converts a decoded YAML value to a value that can be encoded as
+JSON. YAML mappings are decoded with interface{} keys, JSON objects need string
+keys.
+*/
+func yamlToJSON(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			key, ok := k.(string)
+			if !ok {
+				return nil, errs.New(codes.TabProfileInvalid, fmt.Sprintf("could not decode profile: key '%v' isn't a string", k))
+			}
+			v, err := yamlToJSON(v)
+			if nil != err {
+				return nil, err
+			}
+			object[key] = v
+		}
+		return object, nil
+	case []interface{}:
+		for a, v := range value {
+			v, err := yamlToJSON(v)
+			if nil != err {
+				return nil, err
+			}
+			value[a] = v
+		}
+	}
+	return value, nil
+}
+
+/*
+Apply applies a Profile to the tab. Settings are applied in the order
+emulation, headers, blocked URLs, permissions and interception rules, and Apply
+stops at the first failure. Settings that were applied successfully are still
+undone when the tab is cleaned up.
+*/
+func (tab *Tab) Apply(profile *Profile) error {
+	if nil != profile.Emulation {
+		if err := tab.applyEmulation(profile.Emulation); nil != err {
+			return errs.Wrap(err, codes.TabProfileApplyFailed, "could not apply emulation overrides")
+		}
+	}
+
+	if len(profile.Headers) > 0 {
+		result := <-tab.Network().SetExtraHTTPHeaders(&network.SetExtraHTTPHeadersParams{Headers: profile.Headers})
+		if nil != result.Err {
+			return errs.Wrap(result.Err, codes.TabProfileApplyFailed, "could not set extra HTTP headers")
+		}
+		tab.OnClose(func() error {
+			return (<-tab.Network().SetExtraHTTPHeaders(&network.SetExtraHTTPHeadersParams{Headers: network.Headers{}})).Err
+		})
+	}
+
+	if len(profile.BlockedURLs) > 0 {
+		result := <-tab.Network().SetBlockedURLs(&network.SetBlockedURLsParams{URLs: profile.BlockedURLs})
+		if nil != result.Err {
+			return errs.Wrap(result.Err, codes.TabProfileApplyFailed, "could not set blocked URLs")
+		}
+		tab.OnClose(func() error {
+			return (<-tab.Network().SetBlockedURLs(&network.SetBlockedURLsParams{URLs: []string{}})).Err
+		})
+	}
+
+	if len(profile.Permissions) > 0 {
+		for _, params := range profile.Permissions {
+			result := <-tab.Browser().GrantPermissions(params)
+			if nil != result.Err {
+				return errs.Wrap(result.Err, codes.TabProfileApplyFailed, fmt.Sprintf("could not grant permissions to '%s'", params.Origin))
+			}
+		}
+		tab.OnClose(func() error {
+			return (<-tab.Browser().ResetPermissions(&browser.ResetPermissionsParams{})).Err
+		})
+	}
+
+	if len(profile.Intercept) > 0 {
+		if err := tab.applyIntercept(profile.Intercept); nil != err {
+			return errs.Wrap(err, codes.TabProfileApplyFailed, "could not enable request interception")
+		}
+	}
+
+	return nil
+}
+
+/*
+applyEmulation applies the overrides set in a profile, leaving any other
+active overrides unchanged.
+*/
+func (tab *Tab) applyEmulation(emulation *OverrideState) error {
+	state := tab.Overrides().Snapshot()
+	if nil != emulation.CPUThrottlingRate {
+		state.CPUThrottlingRate = emulation.CPUThrottlingRate
+	}
+	if nil != emulation.DeviceMetrics {
+		state.DeviceMetrics = emulation.DeviceMetrics
+	}
+	if nil != emulation.Geolocation {
+		state.Geolocation = emulation.Geolocation
+	}
+	if nil != emulation.NetworkConditions {
+		state.NetworkConditions = emulation.NetworkConditions
+	}
+	if nil != emulation.UserAgent {
+		state.UserAgent = emulation.UserAgent
+	}
+	return tab.Overrides().Restore(state)
+}
+
+/*
+applyIntercept enables request interception for the rule patterns and adds a
+handler that applies the first matching rule to each intercepted request.
+*/
+func (tab *Tab) applyIntercept(rules []*InterceptRule) error {
+	patterns := make([]*network.RequestPattern, len(rules))
+	for a, rule := range rules {
+		rule.pattern = wildcardPattern("*")
+		if "" != rule.URLPattern {
+			rule.pattern = wildcardPattern(rule.URLPattern)
+		}
+		patterns[a] = &network.RequestPattern{
+			URLPattern:   rule.URLPattern,
+			ResourceType: rule.ResourceType,
+		}
+	}
+
+	handler := socket.NewEventHandler("Network.requestIntercepted", func(response *socket.Response) {
+		event := &network.RequestInterceptedEvent{}
+		if err := json.Unmarshal(response.Params, event); nil != err {
+			tab.log().Warn("could not decode intercepted request", logger.Fields{"error": err})
+			return
+		}
+		params := &network.ContinueInterceptedRequestParams{InterceptionID: event.InterceptionID}
+		for _, rule := range rules {
+			if !rule.match(event) {
+				continue
+			}
+			if network.ErrorReasonEnum(0) != rule.ErrorReason {
+				params.ErrorReason = rule.ErrorReason
+			} else if len(rule.Headers) > 0 {
+				params.Headers = network.Headers{}
+				for k, v := range event.Request.Headers {
+					params.Headers[k] = v
+				}
+				for k, v := range rule.Headers {
+					params.Headers[k] = v
+				}
+			}
+			break
+		}
+		if result := <-tab.Network().ContinueInterceptedRequest(params); nil != result.Err {
+			tab.log().Warn("could not continue intercepted request", logger.Fields{"error": result.Err, "interceptionID": event.InterceptionID})
+		}
+	})
+	tab.AddEventHandler(handler)
+
+	result := <-tab.Network().SetRequestInterception(&network.SetRequestInterceptionParams{Patterns: patterns})
+	if nil != result.Err {
+		return result.Err
+	}
+	tab.OnClose(func() error {
+		return (<-tab.Network().SetRequestInterception(&network.SetRequestInterceptionParams{Patterns: []*network.RequestPattern{}})).Err
+	})
+	return nil
+}
@@ -0,0 +1,171 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+var testProfile = `{
+	"emulation": {
+		"userAgent": {"userAgent": "test-agent"},
+		"cpuThrottlingRate": {"rate": 2}
+	},
+	"headers": {"Accept-Language": "de-DE"},
+	"blockedURLs": ["*.doubleclick.net"],
+	"permissions": [{"origin": "https://example.com", "permissions": ["geolocation"]}],
+	"intercept": [
+		{"urlPattern": "*.png", "errorReason": "Aborted"},
+		{"urlPattern": "https://example.com/api/?", "resourceType": "XHR", "headers": {"X-Test": "1"}}
+	]
+}`
+
+func TestLoadProfile(t *testing.T) {
+	fh, _ := ioutil.TempFile("", "TestLoadProfile")
+	defer os.Remove(fh.Name())
+	fh.WriteString(testProfile)
+	fh.Close()
+
+	profile, err := LoadProfile(fh.Name())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if "test-agent" != profile.Emulation.UserAgent.UserAgent {
+		t.Errorf("Expected test-agent, received %s", profile.Emulation.UserAgent.UserAgent)
+	}
+	if 2 != len(profile.Intercept) || network.ErrorReason.Aborted != profile.Intercept[0].ErrorReason {
+		t.Errorf("Expected 2 intercept rules, received %v", profile.Intercept)
+	}
+
+	if _, err := LoadProfile(fh.Name() + ".missing"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if _, err := ReadProfile(strings.NewReader(`{"intercept": [{"errorReason": "invalid"}]}`)); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+var testYAMLProfile = `
+emulation:
+  userAgent:
+    userAgent: test-agent
+  cpuThrottlingRate:
+    rate: 2
+headers:
+  Accept-Language: de-DE
+blockedURLs:
+  - "*.doubleclick.net"
+permissions:
+  - origin: https://example.com
+    permissions: [geolocation]
+intercept:
+  - urlPattern: "*.png"
+    errorReason: Aborted
+  - urlPattern: https://example.com/api/?
+    resourceType: XHR
+    headers:
+      X-Test: "1"
+`
+
+func TestLoadYAMLProfile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "TestLoadYAMLProfile")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "profile.yml")
+	ioutil.WriteFile(file, []byte(testYAMLProfile), 0644)
+
+	profile, err := LoadProfile(file)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected, _ := ReadProfile(strings.NewReader(testProfile))
+	if !reflect.DeepEqual(expected, profile) {
+		t.Errorf("Expected %+v, received %+v", expected, profile)
+	}
+
+	for _, doc := range []string{"intercept: [{errorReason: invalid}]", "headers: [", "1: one"} {
+		if _, err := ReadYAMLProfile(strings.NewReader(doc)); nil == err {
+			t.Errorf("Expected error for '%s', received nil", doc)
+		}
+	}
+}
+
+func TestTabApply(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabApply")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+
+	profile, _ := ReadProfile(strings.NewReader(testProfile))
+	if err := tab.Apply(profile); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected := []string{
+		"Emulation.setCPUThrottlingRate",
+		"Network.setUserAgentOverride",
+		"Network.setExtraHTTPHeaders",
+		"Network.setBlockedURLs",
+		"Browser.grantPermissions",
+		"Network.setRequestInterception",
+	}
+	if methods := rec.reset(); strings.Join(expected, ",") != strings.Join(methods, ",") {
+		t.Errorf("Expected %v, received %v", expected, methods)
+	}
+
+	if err := tab.Cleanup(); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected = []string{
+		"Network.setRequestInterception",
+		"Browser.resetPermissions",
+		"Network.setBlockedURLs",
+		"Network.setExtraHTTPHeaders",
+		"Emulation.setCPUThrottlingRate",
+		"Network.setUserAgentOverride",
+	}
+	if methods := rec.reset(); strings.Join(expected, ",") != strings.Join(methods, ",") {
+		t.Errorf("Expected %v, received %v", expected, methods)
+	}
+}
+
+func TestInterceptRuleMatch(t *testing.T) {
+	profile, _ := ReadProfile(strings.NewReader(testProfile))
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestInterceptRuleMatch")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+	tab.applyIntercept(profile.Intercept)
+
+	tests := []struct {
+		rule    int
+		url     string
+		resType page.ResourceTypeEnum
+		match   bool
+	}{
+		{0, "https://example.com/image.png", page.ResourceType.Image, true},
+		{0, "https://example.com/image.png?v=1", page.ResourceType.Image, false},
+		{1, "https://example.com/api/1", page.ResourceType.XHR, true},
+		{1, "https://example.com/api/12", page.ResourceType.XHR, false},
+		{1, "https://example.com/api/1", page.ResourceType.Script, false},
+	}
+	for _, test := range tests {
+		event := &network.RequestInterceptedEvent{
+			Request:      &network.Request{URL: test.url},
+			ResourceType: test.resType,
+		}
+		if test.match != profile.Intercept[test.rule].match(event) {
+			t.Errorf("Expected rule %d match %s to be %v", test.rule, test.url, test.match)
+		}
+	}
+
+	if !wildcardPattern(`a\*b`).MatchString("a*b") || wildcardPattern(`a\*b`).MatchString("axb") {
+		t.Errorf("Expected escaped wildcards to match literally")
+	}
+}
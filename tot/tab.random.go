@@ -0,0 +1,87 @@
+package chrome
+
+import (
+	"fmt"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+seedRandom replaces Math.random, crypto.getRandomValues and crypto.randomUUID
+with a mulberry32 generator started from the seed, so every document draws the
+same sequence of numbers.
+*/
+const seedRandom = `(function (seed) {
+	var state = seed >>> 0;
+	var next = function () {
+		state = (state + 0x6D2B79F5) >>> 0;
+		var t = state;
+		t = Math.imul(t ^ (t >>> 15), t | 1);
+		t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+		return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+	};
+	Math.random = next;
+	if (!window.crypto) {
+		return;
+	}
+	var getRandomValues = crypto.getRandomValues;
+	crypto.getRandomValues = function (array) {
+		getRandomValues.call(crypto, array);
+		var bytes = new Uint8Array(array.buffer, array.byteOffset, array.byteLength);
+		for (var a = 0; a < bytes.length; a++) {
+			bytes[a] = Math.floor(next() * 256);
+		}
+		return array;
+	};
+	if (crypto.randomUUID) {
+		crypto.randomUUID = function () {
+			var bytes = crypto.getRandomValues(new Uint8Array(16));
+			bytes[6] = (bytes[6] & 0x0f) | 0x40;
+			bytes[8] = (bytes[8] & 0x3f) | 0x80;
+			var hex = '';
+			for (var a = 0; a < bytes.length; a++) {
+				hex += (bytes[a] + 0x100).toString(16).slice(1);
+				if (3 === a || 5 === a || 7 === a || 9 === a) {
+					hex += '-';
+				}
+			}
+			return hex;
+		};
+	}
+})(%d);`
+
+/*
+SeedRandom makes Math.random, crypto.getRandomValues and crypto.randomUUID in
+documents loaded in the tab after the call return the same sequence of values
+for the same seed, so pages that render random content, like generated avatars
+or shuffled lists, produce identical output across runs for screenshot
+comparisons:
+
+	if err := tab.SeedRandom(42); nil != err {
+		...
+	}
+
+Each document starts the sequence over, so a page renders the same way every
+time it's loaded, as long as it draws the values in the same order. Web workers
+aren't seeded. The values aren't random at all, so the pages must not rely on
+them for security. Arguments are still validated by the browser, so
+getRandomValues throws for arrays that aren't integer typed arrays or are
+larger than 65536 bytes. The script is removed when the tab is closed.
+*/
+func (tab *Tab) SeedRandom(seed uint32) error {
+	result := <-tab.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+		Source: fmt.Sprintf(seedRandom, seed),
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabRandomSeedFailed, "could not add the random seed script")
+	}
+	identifier := result.Identifier
+	tab.OnClose(func() error {
+		return (<-tab.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+			Identifier: identifier,
+		})).Err
+	})
+	return nil
+}
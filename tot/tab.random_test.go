@@ -0,0 +1,41 @@
+package chrome
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTabSeedRandom(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	sources := make(chan string, 1)
+	cdp.Handle("Page.addScriptToEvaluateOnNewDocument", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		source := struct{ Source string }{}
+		json.Unmarshal(params, &source)
+		sources <- source.Source
+		return map[string]interface{}{"identifier": "1"}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	if err := tab.SeedRandom(42); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	source := <-sources
+	if !strings.Contains(source, "Math.random = next") || !strings.HasSuffix(source, "})(42);") {
+		t.Errorf("Expected the seeded script, got %q", source)
+	}
+
+	cdp.Handle("Page.addScriptToEvaluateOnNewDocument", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		return nil, &cdptest.Error{Code: -32000, Message: "Not attached to an active page"}
+	})
+	if err := tab.SeedRandom(42); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
@@ -0,0 +1,370 @@
+package chrome
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/accessibility"
+	"github.com/mkenney/go-chrome/tot/dom"
+)
+
+/*
+snapshotProperties are the accessibility properties included in an ARIA
+snapshot, in order.
+*/
+var snapshotProperties = []string{
+	"checked",
+	"disabled",
+	"expanded",
+	"level",
+	"pressed",
+	"required",
+	"selected",
+}
+
+/*
+snapshotTransparentRoles are roles whose unnamed nodes are left out of an ARIA
+snapshot, their children take their place.
+*/
+var snapshotTransparentRoles = map[string]bool{
+	"generic":       true,
+	"InlineTextBox": true,
+	"none":          true,
+}
+
+/*
+AriaSnapshot returns a textual snapshot of the accessibility tree of the page,
+one node per line indented by depth, for golden-file tests of what assistive
+technology perceives:
+
+	snapshot, err := tab.AriaSnapshot()
+	if nil != err {
+		...
+	}
+	// - heading "Example Domain" [level=1]
+	// - paragraph
+	//   - text "This domain is for use in illustrative examples."
+	//   - link "More information..."
+
+Ignored nodes and unnamed generic containers are left out and whitespace in
+names is collapsed, so the snapshot is stable across runs and unaffected by
+node IDs or markup that doesn't change the semantics. Compare snapshots with
+DiffSnapshots.
+*/
+func (tab *Tab) AriaSnapshot() (string, error) {
+	result := <-tab.Accessibility().GetFullAXTree(&accessibility.GetFullAXTreeParams{})
+	if nil != result.Err {
+		return "", errs.Wrap(result.Err, codes.TabSnapshotFailed, "could not read the accessibility tree")
+	}
+	if 0 == len(result.Nodes) {
+		return "", nil
+	}
+
+	nodes := make(map[accessibility.AXNodeID]*accessibility.AXNode, len(result.Nodes))
+	children := make(map[accessibility.AXNodeID]bool)
+	for _, node := range result.Nodes {
+		nodes[node.NodeID] = node
+		for _, childID := range node.ChildIDs {
+			children[childID] = true
+		}
+	}
+	lines := []string{}
+	for _, node := range result.Nodes {
+		if !children[node.NodeID] {
+			lines = ariaLines(lines, nodes, node, 0, 0)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+/*
+ariaLines appends the snapshot lines of a node and its descendants. The root
+document is left out, its children are at the top level.
+*/
+func ariaLines(lines []string, nodes map[accessibility.AXNodeID]*accessibility.AXNode, node *accessibility.AXNode, depth, level int) []string {
+	if depth > 1000 {
+		return lines
+	}
+	role := axString(node.Role)
+	name := snapshotText(axString(node.Name))
+	childLevel := level
+	if !node.Ignored && "RootWebArea" != role && !(snapshotTransparentRoles[role] && "" == name) {
+		line := strings.Repeat("  ", level) + "- "
+		if "StaticText" == role {
+			line += "text " + strconv.Quote(name)
+		} else {
+			line += role
+			if "" != name {
+				line += " " + strconv.Quote(name)
+			}
+			for _, property := range axProperties(node) {
+				line += " [" + property + "]"
+			}
+			if value := snapshotText(axString(node.Value)); "" != value {
+				line += ": " + strconv.Quote(value)
+			}
+		}
+		lines = append(lines, line)
+		childLevel++
+	}
+	if "StaticText" == role {
+		return lines
+	}
+	for _, childID := range node.ChildIDs {
+		if child, ok := nodes[childID]; ok {
+			lines = ariaLines(lines, nodes, child, depth+1, childLevel)
+		}
+	}
+	return lines
+}
+
+/*
+axProperties returns the snapshot properties of a node as name=value pairs.
+Boolean properties that are false are left out.
+*/
+func axProperties(node *accessibility.AXNode) []string {
+	values := map[string]string{}
+	for _, property := range node.Properties {
+		value := axString(property.Value)
+		if "" == value || "false" == value {
+			continue
+		}
+		values[string(property.Name)] = value
+	}
+	properties := []string{}
+	for _, name := range snapshotProperties {
+		if value, ok := values[name]; ok {
+			if "true" == value {
+				properties = append(properties, name)
+			} else {
+				properties = append(properties, name+"="+value)
+			}
+		}
+	}
+	return properties
+}
+
+/*
+axString returns an accessibility value as a string.
+*/
+func axString(value *accessibility.AXValue) string {
+	if nil == value || nil == value.Value {
+		return ""
+	}
+	switch v := value.Value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+/*
+snapshotText collapses the whitespace in a text.
+*/
+func snapshotText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+/*
+DOMSnapshotText returns a textual snapshot of the DOM of the page, including
+frames and shadow roots, one node per line indented by depth:
+
+	html lang="en"
+	  head
+	    title
+	      "Example Domain"
+	  body
+	    div class="content"
+	      h1
+	        "Example Domain"
+
+Attributes are sorted, whitespace in text is collapsed and text that is only
+whitespace, comments and the content of scripts and styles are left out, so
+the snapshot is stable across runs. Compare snapshots with DiffSnapshots.
+*/
+func (tab *Tab) DOMSnapshotText() (string, error) {
+	result := <-tab.DOM().GetDocument(&dom.GetDocumentParams{Depth: -1, Pierce: true})
+	if nil != result.Err {
+		return "", errs.Wrap(result.Err, codes.TabSnapshotFailed, "could not read the document")
+	}
+	if nil == result.Root {
+		return "", nil
+	}
+	lines := []string{}
+	for _, child := range result.Root.Children {
+		lines = domLines(lines, child, 0)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+/*
+domLines appends the snapshot lines of a DOM node and its descendants.
+*/
+func domLines(lines []string, node *dom.Node, depth int) []string {
+	if depth > 1000 {
+		return lines
+	}
+	indent := strings.Repeat("  ", depth)
+	switch node.NodeType {
+	case 1: // element
+		line := indent + strings.ToLower(node.NodeName)
+		attributes := []string{}
+		for a := 0; a+1 < len(node.Attributes); a += 2 {
+			attributes = append(attributes, node.Attributes[a]+"="+strconv.Quote(snapshotText(node.Attributes[a+1])))
+		}
+		sort.Strings(attributes)
+		if len(attributes) > 0 {
+			line += " " + strings.Join(attributes, " ")
+		}
+		lines = append(lines, line)
+		for _, shadowRoot := range node.ShadowRoots {
+			lines = append(lines, indent+"  #shadow-root ("+string(shadowRoot.ShadowRootType)+")")
+			for _, child := range shadowRoot.Children {
+				lines = domLines(lines, child, depth+2)
+			}
+		}
+		if nil != node.TemplateContent {
+			lines = append(lines, indent+"  #template")
+			for _, child := range node.TemplateContent.Children {
+				lines = domLines(lines, child, depth+2)
+			}
+		}
+		if nil != node.ContentDocument {
+			lines = append(lines, indent+"  #document")
+			for _, child := range node.ContentDocument.Children {
+				lines = domLines(lines, child, depth+2)
+			}
+		}
+		if "script" == node.LocalName || "style" == node.LocalName {
+			return lines
+		}
+		for _, child := range node.Children {
+			lines = domLines(lines, child, depth+1)
+		}
+	case 3: // text
+		if text := snapshotText(node.NodeValue); "" != text {
+			lines = append(lines, indent+strconv.Quote(text))
+		}
+	case 10: // document type
+		lines = append(lines, indent+"<!DOCTYPE "+node.NodeName+">")
+	}
+	return lines
+}
+
+/*
+DiffSnapshots compares two snapshots taken with AriaSnapshot or
+DOMSnapshotText and returns the changed lines with two lines of context, or an
+empty string if they're the same. Removed lines are prefixed with '-', added
+lines with '+' and hunks are separated by their line numbers:
+
+	@@ -1,3 +1,3 @@
+	  - heading "Example Domain" [level=1]
+	  - paragraph
+	-   - link "More information..."
+	+   - link "Learn more"
+
+Since every line is a node at its depth, the diff shows nodes that were added,
+removed or moved in the tree rather than changes in the markup.
+*/
+func DiffSnapshots(before, after string) string {
+	a := snapshotSplit(before)
+	b := snapshotSplit(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte
+		text string
+		a, b int
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, diffLine{'+', b[j], i, j})
+			j++
+		default:
+			lines = append(lines, diffLine{'-', a[i], i, j})
+			i++
+		}
+	}
+
+	const context = 2
+	out := []string{}
+	for start := 0; start < len(lines); {
+		if ' ' == lines[start].op {
+			start++
+			continue
+		}
+		// Extend the hunk while changes are within the context of each other.
+		end := start
+		for next := start; next < len(lines); next++ {
+			if ' ' != lines[next].op {
+				end = next
+			} else if next-end > 2*context {
+				break
+			}
+		}
+		from := start - context
+		if from < 0 {
+			from = 0
+		}
+		to := end + context + 1
+		if to > len(lines) {
+			to = len(lines)
+		}
+		removed, added := 0, 0
+		for _, line := range lines[from:to] {
+			if '+' != line.op {
+				removed++
+			}
+			if '-' != line.op {
+				added++
+			}
+		}
+		out = append(out, fmt.Sprintf("@@ -%d,%d +%d,%d @@", lines[from].a+1, removed, lines[from].b+1, added))
+		for _, line := range lines[from:to] {
+			out = append(out, string(line.op)+" "+line.text)
+		}
+		start = to
+	}
+	return strings.Join(out, "\n")
+}
+
+/*
+snapshotSplit splits a snapshot into lines.
+*/
+func snapshotSplit(snapshot string) []string {
+	if "" == snapshot {
+		return []string{}
+	}
+	return strings.Split(strings.TrimRight(snapshot, "\n"), "\n")
+}
@@ -0,0 +1,130 @@
+package chrome
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTabAriaSnapshot(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	role := func(role string) map[string]interface{} {
+		return map[string]interface{}{"type": "role", "value": role}
+	}
+	name := func(name string) map[string]interface{} {
+		return map[string]interface{}{"type": "computedString", "value": name}
+	}
+	cdp.Respond("Accessibility.getFullAXTree", map[string]interface{}{
+		"nodes": []map[string]interface{}{
+			{"nodeId": "1", "role": role("RootWebArea"), "name": name("Example"), "childIds": []string{"2", "6"}},
+			{"nodeId": "2", "role": role("generic"), "childIds": []string{"3", "4"}},
+			{"nodeId": "3", "role": role("heading"), "name": name("Example  Domain"), "properties": []map[string]interface{}{
+				{"name": "level", "value": map[string]interface{}{"type": "integer", "value": 1}},
+				{"name": "disabled", "value": map[string]interface{}{"type": "boolean", "value": false}},
+			}},
+			{"nodeId": "4", "role": role("paragraph"), "childIds": []string{"5", "7"}},
+			{"nodeId": "5", "role": role("StaticText"), "name": name("\n  Some\ttext ")},
+			{"nodeId": "6", "ignored": true, "role": role("none")},
+			{"nodeId": "7", "role": role("checkbox"), "name": name("Agree"), "properties": []map[string]interface{}{
+				{"name": "checked", "value": map[string]interface{}{"type": "tristate", "value": "true"}},
+			}},
+		},
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	snapshot, err := tab.AriaSnapshot()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected := strings.Join([]string{
+		`- heading "Example Domain" [level=1]`,
+		`- paragraph`,
+		`  - text "Some text"`,
+		`  - checkbox "Agree" [checked]`,
+	}, "\n")
+	if expected != snapshot {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, snapshot)
+	}
+}
+
+func TestTabDOMSnapshotText(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	cdp.Respond("DOM.getDocument", map[string]interface{}{
+		"root": map[string]interface{}{
+			"nodeId": 1, "nodeType": 9, "nodeName": "#document",
+			"children": []map[string]interface{}{
+				{"nodeId": 2, "nodeType": 10, "nodeName": "html"},
+				{"nodeId": 3, "nodeType": 1, "nodeName": "HTML", "localName": "html", "attributes": []string{"lang", "en"}, "children": []map[string]interface{}{
+					{"nodeId": 4, "nodeType": 1, "nodeName": "SCRIPT", "localName": "script", "children": []map[string]interface{}{
+						{"nodeId": 5, "nodeType": 3, "nodeName": "#text", "nodeValue": "var now = Date.now()"},
+					}},
+					{"nodeId": 6, "nodeType": 1, "nodeName": "BODY", "localName": "body", "attributes": []string{"id", "main", "class", "a  b"}, "children": []map[string]interface{}{
+						{"nodeId": 7, "nodeType": 3, "nodeName": "#text", "nodeValue": "\n   "},
+						{"nodeId": 8, "nodeType": 8, "nodeName": "#comment", "nodeValue": "build 1234"},
+						{"nodeId": 9, "nodeType": 3, "nodeName": "#text", "nodeValue": " Hello\n world "},
+						{"nodeId": 10, "nodeType": 1, "nodeName": "MY-ELEMENT", "localName": "my-element", "shadowRoots": []map[string]interface{}{
+							{"nodeId": 11, "nodeType": 11, "nodeName": "#document-fragment", "shadowRootType": "open", "children": []map[string]interface{}{
+								{"nodeId": 12, "nodeType": 1, "nodeName": "SLOT", "localName": "slot"},
+							}},
+						}},
+					}},
+				}},
+			},
+		},
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	snapshot, err := tab.DOMSnapshotText()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	expected := strings.Join([]string{
+		`<!DOCTYPE html>`,
+		`html lang="en"`,
+		`  script`,
+		`  body class="a b" id="main"`,
+		`    "Hello world"`,
+		`    my-element`,
+		`      #shadow-root (open)`,
+		`        slot`,
+	}, "\n")
+	if expected != snapshot {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, snapshot)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	before := strings.Join([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, "\n")
+	after := strings.Join([]string{"a", "b", "c", "D", "e", "f", "g", "h", "i", "j", "k"}, "\n")
+	if diff := DiffSnapshots(before, before); "" != diff {
+		t.Errorf("Expected no diff, got:\n%s", diff)
+	}
+	expected := strings.Join([]string{
+		"@@ -2,5 +2,5 @@",
+		"  b",
+		"  c",
+		"- d",
+		"+ D",
+		"  e",
+		"  f",
+		"@@ -9,2 +9,3 @@",
+		"  i",
+		"  j",
+		"+ k",
+	}, "\n")
+	if diff := DiffSnapshots(before, after); expected != diff {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, diff)
+	}
+	if diff := DiffSnapshots("", "a"); "@@ -1,0 +1,1 @@\n+ a" != diff {
+		t.Errorf("Expected an added line, got:\n%s", diff)
+	}
+}
@@ -46,6 +46,13 @@ func (tab *Tab) CacheStorage() *socket.CacheStorageProtocol {
 	return tab.protocol.CacheStorage()
 }
 
+/*
+Cast implements socket.Protocoller
+*/
+func (tab *Tab) Cast() *socket.CastProtocol {
+	return tab.protocol.Cast()
+}
+
 /*
 Console implements socket.Protocoller
 */
@@ -74,6 +81,13 @@ func (tab *Tab) Debugger() *socket.DebuggerProtocol {
 	return tab.protocol.Debugger()
 }
 
+/*
+DeviceAccess implements socket.Protocoller
+*/
+func (tab *Tab) DeviceAccess() *socket.DeviceAccessProtocol {
+	return tab.protocol.DeviceAccess()
+}
+
 /*
 DeviceOrientation implements socket.Protocoller
 */
@@ -116,6 +130,20 @@ func (tab *Tab) Emulation() *socket.EmulationProtocol {
 	return tab.protocol.Emulation()
 }
 
+/*
+FedCM implements socket.Protocoller
+*/
+func (tab *Tab) FedCM() *socket.FedCMProtocol {
+	return tab.protocol.FedCM()
+}
+
+/*
+Fetch implements socket.Protocoller
+*/
+func (tab *Tab) Fetch() *socket.FetchProtocol {
+	return tab.protocol.Fetch()
+}
+
 /*
 HeadlessExperimental implements socket.Protocoller
 */
@@ -144,6 +172,13 @@ func (tab *Tab) Input() *socket.InputProtocol {
 	return tab.protocol.Input()
 }
 
+/*
+Inspector implements socket.Protocoller
+*/
+func (tab *Tab) Inspector() *socket.InspectorProtocol {
+	return tab.protocol.Inspector()
+}
+
 /*
 IO implements socket.Protocoller
 */
@@ -200,6 +235,20 @@ func (tab *Tab) Performance() *socket.PerformanceProtocol {
 	return tab.protocol.Performance()
 }
 
+/*
+PerformanceTimeline implements socket.Protocoller
+*/
+func (tab *Tab) PerformanceTimeline() *socket.PerformanceTimelineProtocol {
+	return tab.protocol.PerformanceTimeline()
+}
+
+/*
+Preload implements socket.Protocoller
+*/
+func (tab *Tab) Preload() *socket.PreloadProtocol {
+	return tab.protocol.Preload()
+}
+
 /*
 Profiler implements socket.Protocoller
 */
@@ -269,3 +318,10 @@ Tracing implements socket.Protocoller
 func (tab *Tab) Tracing() *socket.TracingProtocol {
 	return tab.protocol.Tracing()
 }
+
+/*
+WebAuthn implements socket.Protocoller
+*/
+func (tab *Tab) WebAuthn() *socket.WebAuthnProtocol {
+	return tab.protocol.WebAuthn()
+}
@@ -42,6 +42,10 @@ func TestProtocoller1(t *testing.T) {
 		t.Errorf("Expected struct, received nil")
 	}
 
+	if testVal := tab.Cast(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
 	if testVal := tab.Console(); nil == testVal {
 		t.Errorf("Expected struct, received nil")
 	}
@@ -58,6 +62,10 @@ func TestProtocoller1(t *testing.T) {
 		t.Errorf("Expected struct, received nil")
 	}
 
+	if testVal := tab.DeviceAccess(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
 	if testVal := tab.DeviceOrientation(); nil == testVal {
 		t.Errorf("Expected struct, received nil")
 	}
@@ -92,6 +100,14 @@ func TestProtocoller2(t *testing.T) {
 		t.Errorf("Expected struct, received nil")
 	}
 
+	if testVal := tab.FedCM(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
+	if testVal := tab.Fetch(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
 	if testVal := tab.HeadlessExperimental(); nil == testVal {
 		t.Errorf("Expected struct, received nil")
 	}
@@ -108,6 +124,10 @@ func TestProtocoller2(t *testing.T) {
 		t.Errorf("Expected struct, received nil")
 	}
 
+	if testVal := tab.Inspector(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
 	if testVal := tab.IO(); nil == testVal {
 		t.Errorf("Expected struct, received nil")
 	}
@@ -150,6 +170,14 @@ func TestProtocoller3(t *testing.T) {
 		t.Errorf("Expected struct, received nil")
 	}
 
+	if testVal := tab.PerformanceTimeline(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
+	if testVal := tab.Preload(); nil == testVal {
+		t.Errorf("Expected struct, received nil")
+	}
+
 	if testVal := tab.Profiler(); nil == testVal {
 		t.Errorf("Expected struct, received nil")
 	}
@@ -1,20 +1,33 @@
 package chrome
 
 import (
+	"context"
+
 	"github.com/mkenney/go-chrome/tot/socket"
 )
 
 /*
 AddEventHandler implements Socketer
+
+Handlers added through the tab are removed automatically when the tab is
+cleaned up. The returned subscription removes the handler early.
 */
-func (tab *Tab) AddEventHandler(handler socket.EventHandler) {
+func (tab *Tab) AddEventHandler(handler socket.EventHandler) *socket.Subscription {
 	tab.Socket().AddEventHandler(handler)
+	tab.cleanup.addHandler(handler, func() error {
+		return tab.Socket().RemoveEventHandler(handler)
+	})
+	return socket.NewSubscription(handler, func() error {
+		tab.cleanup.removeHandler(handler)
+		return tab.Socket().RemoveEventHandler(handler)
+	})
 }
 
 /*
 RemoveEventHandler implements Socketer
 */
 func (tab *Tab) RemoveEventHandler(handler socket.EventHandler) {
+	tab.cleanup.removeHandler(handler)
 	tab.Socket().RemoveEventHandler(handler)
 }
 
@@ -24,3 +37,23 @@ SendCommand implements Socketer
 func (tab *Tab) SendCommand(command socket.Commander) chan *socket.Response {
 	return tab.Socket().SendCommand(command)
 }
+
+/*
+SendCommandContext implements Socketer
+*/
+func (tab *Tab) SendCommandContext(ctx context.Context, command socket.Commander) chan *socket.Response {
+	return tab.Socket().SendCommandContext(ctx, command)
+}
+
+/*
+WithContext returns a view of the tab's socket that sends every command,
+including commands sent through its protocol interfaces, with the specified
+context:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := <-tab.WithContext(ctx).Page().Navigate(params)
+*/
+func (tab *Tab) WithContext(ctx context.Context) *socket.ContextSocket {
+	return socket.NewContextSocket(ctx, tab.Socket())
+}
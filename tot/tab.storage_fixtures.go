@@ -0,0 +1,118 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/page"
+)
+
+/*
+StorageFixture is a set of localStorage and sessionStorage values stored in the
+pages of matching origins before their scripts run, see AddStorageFixtures.
+*/
+type StorageFixture struct {
+	// The origins the fixture applies to, like "https://app.example.com". A
+	// '*' matches any part of a host name or port, "https://*.example.com"
+	// matches every subdomain. The fixture applies to every origin if the
+	// list is empty.
+	Origins []string `json:"origins"`
+
+	// The values stored in localStorage.
+	Local map[string]string `json:"local"`
+
+	// The values stored in sessionStorage.
+	Session map[string]string `json:"session"`
+}
+
+/*
+storageFixtures stores the fixtures matching the origin of the document. Opaque
+origins, like about:blank and data: URLs, and origins that aren't allowed to
+use storage throw when the storage is accessed, so they're skipped.
+*/
+const storageFixtures = `(function (fixtures) {
+	var origin = location.origin;
+	var matches = function (pattern) {
+		var expression = pattern.replace(/[.+?^${}()|[\]\\\/]/g, '\\$&').replace(/\*/g, '[^/]*');
+		return new RegExp('^' + expression + '$').test(origin);
+	};
+	var store = function (storage, values) {
+		try {
+			for (var key in values) {
+				storage().setItem(key, values[key]);
+			}
+		} catch (e) {
+		}
+	};
+	fixtures.forEach(function (fixture) {
+		if (fixture.origins && fixture.origins.length && !fixture.origins.some(matches)) {
+			return;
+		}
+		store(function () { return window.localStorage; }, fixture.local);
+		store(function () { return window.sessionStorage; }, fixture.session);
+	});
+})(%s);`
+
+/*
+AddStorageFixtures stores localStorage and sessionStorage values in documents
+loaded in the tab after the call, before any of their scripts run, so feature
+flags and dismissed onboarding states can be preset without going through the
+UI:
+
+	err := tab.AddStorageFixtures(&chrome.StorageFixture{
+		Origins: []string{"https://app.example.com"},
+		Local: map[string]string{
+			"onboarding.dismissed": "true",
+			"flags":                `{"newEditor": true}`,
+		},
+	})
+
+The values are stored every time a matching document loads, in frames too, so
+values the page changes are reset by a navigation or a reload. Fixtures are
+applied in order, a later fixture overwrites the values of an earlier one. The
+fixtures are removed when the tab is closed.
+
+An origin must be a scheme and a host with an optional port, without a path.
+*/
+func (tab *Tab) AddStorageFixtures(fixtures ...*StorageFixture) error {
+	for _, fixture := range fixtures {
+		for _, origin := range fixture.Origins {
+			if err := validateFixtureOrigin(origin); nil != err {
+				return err
+			}
+		}
+	}
+	data, err := json.Marshal(fixtures)
+	if nil != err {
+		return errs.Wrap(err, codes.TabStorageFixturesFailed, "could not encode the storage fixtures")
+	}
+	result := <-tab.Page().AddScriptToEvaluateOnNewDocument(&page.AddScriptToEvaluateOnNewDocumentParams{
+		Source: fmt.Sprintf(storageFixtures, data),
+	})
+	if nil != result.Err {
+		return errs.Wrap(result.Err, codes.TabStorageFixturesFailed, "could not add the storage fixtures script")
+	}
+	identifier := result.Identifier
+	tab.OnClose(func() error {
+		return (<-tab.Page().RemoveScriptToEvaluateOnNewDocument(&page.RemoveScriptToEvaluateOnNewDocumentParams{
+			Identifier: identifier,
+		})).Err
+	})
+	return nil
+}
+
+/*
+validateFixtureOrigin returns an error if an origin pattern isn't a scheme and
+a host with an optional port.
+*/
+func validateFixtureOrigin(origin string) error {
+	parsed, err := url.Parse(strings.Replace(origin, "*", "0", -1))
+	if nil != err || "" == parsed.Scheme || "" == parsed.Host || "" != parsed.Path || "" != parsed.RawQuery || "" != parsed.Fragment || nil != parsed.User {
+		return errs.New(codes.TabStorageFixturesFailed, fmt.Sprintf("'%s' is not an origin, expected a scheme and a host like 'https://example.com'", origin))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package chrome
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+func TestTabAddStorageFixtures(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	sources := make(chan string, 1)
+	cdp.Handle("Page.addScriptToEvaluateOnNewDocument", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		source := struct{ Source string }{}
+		json.Unmarshal(params, &source)
+		sources <- source.Source
+		return map[string]interface{}{"identifier": "1"}, nil
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	err = tab.AddStorageFixtures(&StorageFixture{
+		Origins: []string{"https://*.example.com", "http://localhost:*"},
+		Local:   map[string]string{"onboarding.dismissed": "true"},
+		Session: map[string]string{"tour": "done"},
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	source := <-sources
+	expected := `})([{"origins":["https://*.example.com","http://localhost:*"],"local":{"onboarding.dismissed":"true"},"session":{"tour":"done"}}]);`
+	if !strings.HasSuffix(source, expected) {
+		t.Errorf("Expected the fixtures in the script, got %q", source)
+	}
+
+	for _, origin := range []string{"example.com", "https://example.com/app", "https://example.com?a=1", "https://user@example.com"} {
+		if err := tab.AddStorageFixtures(&StorageFixture{Origins: []string{origin}}); nil == err {
+			t.Errorf("Expected an error for '%s', received nil", origin)
+		}
+	}
+	select {
+	case source := <-sources:
+		t.Errorf("Expected no script for invalid origins, got %q", source)
+	default:
+	}
+}
@@ -3,11 +3,14 @@ package chrome
 import (
 	"fmt"
 	"net/url"
+	"sync"
 
 	errs "github.com/bdlm/errors"
-	"github.com/bdlm/log"
 	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/logger"
 	"github.com/mkenney/go-chrome/tot/socket"
+	"github.com/mkenney/go-chrome/tot/sourcemap"
+	"github.com/mkenney/go-chrome/tot/target"
 )
 
 /*
@@ -25,9 +28,12 @@ func (chrome *Chrome) NewTab(uri string) (*Tab, error) {
 	}
 
 	tab := &Tab{
-		chrome: chrome,
-		data:   &TabData{},
-		url:    targetURL,
+		chrome:     chrome,
+		cleanup:    newCleanupStack(),
+		data:       &TabData{},
+		logger:     chrome.logger,
+		sourceMaps: chrome.sourceMaps,
+		url:        targetURL,
 	}
 
 	_, err = tab.Chromium().Query(
@@ -39,12 +45,12 @@ func (chrome *Chrome) NewTab(uri string) (*Tab, error) {
 		return nil, errs.Wrap(err, codes.TabQueryFailed, fmt.Sprintf("/new?%s query failed", url.QueryEscape(uri)))
 	}
 
-	websocketURL, err := url.Parse(tab.Data().WebSocketDebuggerURL)
+	websocketURL, err := chrome.websocketURL(tab.Data().WebSocketDebuggerURL)
 	if nil != err {
 		return nil, errs.Wrap(err, codes.TabWebsocketURLInvalid, fmt.Sprintf("invalid websocket URL '%s'", tab.Data().WebSocketDebuggerURL))
 	}
 
-	socket := socket.New(websocketURL)
+	socket := socket.New(websocketURL, chrome.socketOptions(websocketURL)...)
 	tab.socket = socket
 	tab.protocol = socket
 	chrome.tabs = append(chrome.tabs, tab)
@@ -52,15 +58,90 @@ func (chrome *Chrome) NewTab(uri string) (*Tab, error) {
 	return tab, nil
 }
 
+/*
+attachTarget returns a tab for another target, attached as a flattened session
+of the connection the tab uses.
+*/
+func (tab *Tab) attachTarget(info *target.Info) (*Tab, error) {
+	parent, ok := tab.socket.(*socket.Socket)
+	if nil != tab.connection {
+		parent, ok = tab.connection.socket, true
+	}
+	if !ok {
+		return nil, errs.New(codes.TabQueryFailed, "targets can only be attached through a websocket connection")
+	}
+	session, err := parent.AttachToTarget(info.ID)
+	if nil != err {
+		return nil, errs.Wrap(err, codes.TabQueryFailed, fmt.Sprintf("could not attach to target '%s'", info.ID))
+	}
+	return &Tab{
+		chrome:  tab.chrome,
+		cleanup: newCleanupStack(),
+		data: &TabData{
+			ID:    string(info.ID),
+			Title: info.Title,
+			Type:  info.Type,
+			URL:   info.URL,
+		},
+		logger:     tab.logger,
+		protocol:   session,
+		socket:     session,
+		sourceMaps: tab.sourceMaps,
+	}, nil
+}
+
+/*
+socketOptions returns the options for a websocket connection to the browser,
+switching the URL to wss:// if the developer tools endpoints are served over
+TLS.
+*/
+func (chrome *Chrome) socketOptions(websocketURL *url.URL) []socket.Option {
+	options := []socket.Option{socket.WithLogger(chrome.logger)}
+	if nil != chrome.tlsConfig {
+		// The endpoint reports the scheme it's served with behind the proxy.
+		if "ws" == websocketURL.Scheme {
+			websocketURL.Scheme = "wss"
+		}
+		options = append(options, socket.WithTLSConfig(chrome.tlsConfig))
+	}
+	if nil != chrome.sshClient {
+		options = append(options, socket.WithDialer(chrome.sshDial))
+	}
+	if nil != chrome.crashHandler {
+		options = append(options, socket.WithFrameHistory(crashFrameHistory))
+	}
+	if nil != chrome.detachHook {
+		options = append(options, socket.WithDetachHook(chrome.detachHook))
+	}
+	if chrome.reattach {
+		options = append(options, socket.WithReattach())
+	}
+	if chrome.nonBlocking {
+		options = append(options, socket.WithNonBlockingCommands())
+	}
+	if chrome.orderedEvents {
+		options = append(options, socket.WithOrderedEvents())
+	}
+	return options
+}
+
 /*
 Tab is a struct representing an individual Chrome tab
 */
 type Tab struct {
-	chrome   Chromium
-	data     *TabData
-	protocol socket.Protocoller
-	socket   socket.Socketer
-	url      *url.URL
+	chrome          Chromium
+	cleanup         *cleanupStack
+	connection      *BrowserConnection
+	data            *TabData
+	interceptor     *Interceptor
+	interceptorOnce sync.Once
+	logger          logger.Logger
+	overrides       *Overrides
+	overridesOnce   sync.Once
+	protocol        socket.Protocoller
+	socket          socket.Socketer
+	sourceMaps      *sourcemap.Resolver
+	url             *url.URL
 }
 
 /*
@@ -72,23 +153,43 @@ func (tab *Tab) Chromium() Chromium {
 
 /*
 Close implements Tabber.
+
+Registered cleanup functions are run before the tab is closed. Cleanup failures
+are logged but don't prevent the tab from closing.
 */
 func (tab *Tab) Close() (interface{}, error) {
 	var err error
 	var result interface{}
+	if err = tab.Cleanup(); nil != err {
+		tab.log().Warn(err.Error(), logger.Fields{"error": err, "tabID": tab.Data().ID})
+	}
 	tab.Socket().Stop()
+	if nil != tab.connection {
+		return nil, tab.connection.closeTarget(tab)
+	}
 	_, err = tab.Chromium().Query(fmt.Sprintf("/json/close/%s", tab.Data().ID), url.Values{}, &result)
 	if nil != err {
-		log.WithFields(log.Fields{
+		tab.log().Warn(err.Error(), logger.Fields{
 			"result": result,
 			"error":  err,
-		}).Warn(err)
+		})
 		return nil, errs.Wrap(err, 0, fmt.Sprintf("close/%s query failed", tab.Data().ID))
 	}
 	tab.Chromium().RemoveTab(tab)
 	return result, nil
 }
 
+/*
+log returns the tab's logger, the bdlm/log standard logger for tabs that weren't
+created by a Chrome instance.
+*/
+func (tab *Tab) log() logger.Logger {
+	if nil == tab.logger {
+		return logger.Default()
+	}
+	return tab.logger
+}
+
 /*
 Data implements Tabber.
 */
@@ -103,6 +204,14 @@ func (tab *Tab) Protocol() socket.Protocoller {
 	return tab.protocol
 }
 
+/*
+PageSession returns the tab's connection as a PageSession, which only provides
+the protocol domains available on pages.
+*/
+func (tab *Tab) PageSession() *socket.PageSession {
+	return socket.NewPageSession(tab.socket)
+}
+
 /*
 Socket implements Tabber.
 */
@@ -0,0 +1,438 @@
+package chrome
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/bdlm/errors"
+	"github.com/mkenney/go-chrome/codes"
+	"github.com/mkenney/go-chrome/tot/network"
+	"github.com/mkenney/go-chrome/tot/page"
+	"github.com/mkenney/go-chrome/tot/runtime"
+	"github.com/mkenney/go-chrome/tot/socket"
+)
+
+/*
+Trace records what happened in a tab step by step for post-mortem debugging:
+a screenshot and a DOM snapshot after each step, and the network requests and
+console messages during it. The trace is written as a zip archive holding the
+data as trace.json, the screenshots and snapshots, and index.html, a viewer
+that works from the extracted files without a server:
+
+	trace, err := tab.StartTrace("checkout")
+	if nil != err {
+		...
+	}
+	defer trace.SaveOnFailure(t, "checkout-trace.zip")
+
+	trace.Step("open the cart", func() error {
+		return (<-tab.Page().Navigate(&page.NavigateParams{URL: "https://shop.example.com/cart"})).Err
+	})
+	trace.Step("check out", func() error {
+		return tab.Eval(`document.querySelector("#checkout").click()`, nil)
+	})
+
+Screenshots and snapshots are best effort, a step whose capture fails is
+recorded with the capture error.
+*/
+type Trace struct {
+	tab     *Tab
+	mux     *sync.Mutex
+	subs    []*socket.Subscription
+	started time.Time
+
+	title    string
+	steps    []*TraceStep
+	requests []*TraceRequest
+	pending  map[network.RequestID]*TraceRequest
+	console  []*TraceMessage
+	files    map[string][]byte
+}
+
+/*
+TraceStep is a recorded step.
+*/
+type TraceStep struct {
+	Title string    `json:"title"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Error is the error returned by the step, if any.
+	Error string `json:"error,omitempty"`
+
+	// URL is the URL of the page after the step.
+	URL string `json:"url,omitempty"`
+
+	// Screenshot and DOM are the paths of the screenshot and the DOM
+	// snapshot in the archive, empty if they couldn't be captured.
+	Screenshot string `json:"screenshot,omitempty"`
+	DOM        string `json:"dom,omitempty"`
+
+	// CaptureError describes why the screenshot or snapshot is missing.
+	CaptureError string `json:"captureError,omitempty"`
+}
+
+/*
+TraceRequest is a network request made during a step.
+*/
+type TraceRequest struct {
+	Step     int       `json:"step"`
+	Time     time.Time `json:"time"`
+	Method   string    `json:"method"`
+	URL      string    `json:"url"`
+	Type     string    `json:"type,omitempty"`
+	Status   int       `json:"status,omitempty"`
+	MimeType string    `json:"mimeType,omitempty"`
+	Failure  string    `json:"failure,omitempty"`
+}
+
+/*
+TraceMessage is a console message or an uncaught exception logged during a
+step.
+*/
+type TraceMessage struct {
+	Step  int       `json:"step"`
+	Time  time.Time `json:"time"`
+	Level string    `json:"level"`
+	Text  string    `json:"text"`
+}
+
+/*
+StartTrace enables the Network and Runtime domains and starts recording a
+trace of the tab. Network requests and console messages are attributed to the
+step in progress when they're received.
+*/
+func (tab *Tab) StartTrace(title string) (*Trace, error) {
+	trace := &Trace{
+		tab:     tab,
+		mux:     &sync.Mutex{},
+		started: time.Now(),
+		title:   title,
+		pending: map[network.RequestID]*TraceRequest{},
+		files:   map[string][]byte{},
+	}
+	trace.subs = append(trace.subs,
+		tab.Network().OnRequestWillBeSent(trace.requestWillBeSent),
+		tab.Network().OnResponseReceived(trace.responseReceived),
+		tab.Network().OnLoadingFailed(trace.loadingFailed),
+		tab.Runtime().OnConsoleAPICalled(trace.consoleAPICalled),
+		tab.Runtime().OnExceptionThrown(trace.exceptionThrown),
+	)
+
+	if result := <-tab.Network().Enable(&network.EnableParams{}); nil != result.Err {
+		trace.Stop()
+		return nil, errs.Wrap(result.Err, codes.TabTraceFailed, "could not enable the Network domain")
+	}
+	if result := <-tab.Runtime().Enable(); nil != result.Err {
+		trace.Stop()
+		return nil, errs.Wrap(result.Err, codes.TabTraceFailed, "could not enable the Runtime domain")
+	}
+	return trace, nil
+}
+
+/*
+Step runs a step of the test and captures the state of the page after it,
+even if it failed. It returns the error returned by the step.
+*/
+func (trace *Trace) Step(title string, step func() error) error {
+	start := time.Now()
+	var err error
+	if nil != step {
+		err = step()
+	}
+	trace.capture(title, start, err)
+	return err
+}
+
+/*
+Capture captures the state of the page as a step without an action, for
+example the initial state or the state when a test fails.
+*/
+func (trace *Trace) Capture(title string) {
+	trace.capture(title, time.Now(), nil)
+}
+
+/*
+Steps returns the recorded steps.
+*/
+func (trace *Trace) Steps() []*TraceStep {
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	return append([]*TraceStep{}, trace.steps...)
+}
+
+/*
+Stop stops recording network requests and console messages. Stopped traces
+can still be written.
+*/
+func (trace *Trace) Stop() {
+	trace.mux.Lock()
+	subs := trace.subs
+	trace.subs = nil
+	trace.mux.Unlock()
+	for _, sub := range subs {
+		sub.Remove()
+	}
+}
+
+/*
+Write writes the trace archive.
+*/
+func (trace *Trace) Write(writer io.Writer) error {
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+
+	data, err := json.Marshal(map[string]interface{}{
+		"title":    trace.title,
+		"started":  trace.started,
+		"steps":    trace.steps,
+		"requests": trace.requests,
+		"console":  trace.console,
+	})
+	if nil != err {
+		return errs.Wrap(err, codes.TabTraceFailed, "could not encode the trace")
+	}
+
+	archive := zip.NewWriter(writer)
+	files := map[string][]byte{
+		"trace.json": data,
+		"index.html": []byte(strings.Replace(traceViewer, "/*TRACE*/null", string(data), 1)),
+	}
+	for name, content := range trace.files {
+		files[name] = content
+	}
+	for _, name := range traceFileNames(files) {
+		fh, err := archive.Create(name)
+		if nil == err {
+			_, err = fh.Write(files[name])
+		}
+		if nil != err {
+			return errs.Wrap(err, codes.TabTraceFailed, fmt.Sprintf("could not write %s to the trace", name))
+		}
+	}
+	if err := archive.Close(); nil != err {
+		return errs.Wrap(err, codes.TabTraceFailed, "could not write the trace")
+	}
+	return nil
+}
+
+/*
+Save stops the trace and writes the archive to a file.
+*/
+func (trace *Trace) Save(file string) error {
+	trace.Stop()
+	buffer := &bytes.Buffer{}
+	if err := trace.Write(buffer); nil != err {
+		return err
+	}
+	if err := ioutil.WriteFile(file, buffer.Bytes(), 0644); nil != err {
+		return errs.Wrap(err, codes.TabTraceFailed, fmt.Sprintf("could not write the trace to %s", file))
+	}
+	return nil
+}
+
+/*
+SaveOnFailure stops the trace and saves it if the test failed, so passing runs
+don't leave artifacts behind. *testing.T and *testing.B satisfy the test
+interface.
+*/
+func (trace *Trace) SaveOnFailure(test interface{ Failed() bool }, file string) error {
+	trace.Stop()
+	if !test.Failed() {
+		return nil
+	}
+	return trace.Save(file)
+}
+
+/*
+capture records a step with a screenshot and a DOM snapshot of the page.
+*/
+func (trace *Trace) capture(title string, start time.Time, stepErr error) {
+	step := &TraceStep{Title: title, Start: start}
+	if nil != stepErr {
+		step.Error = stepErr.Error()
+	}
+
+	trace.mux.Lock()
+	index := len(trace.steps)
+	trace.mux.Unlock()
+	prefix := fmt.Sprintf("steps/%03d", index+1)
+
+	captureErrs := []string{}
+	screenshot := <-trace.tab.Page().CaptureScreenshot(&page.CaptureScreenshotParams{Format: page.Format.Png})
+	if nil != screenshot.Err {
+		captureErrs = append(captureErrs, "screenshot: "+screenshot.Err.Error())
+	} else if data, err := base64.StdEncoding.DecodeString(screenshot.Data); nil != err {
+		captureErrs = append(captureErrs, "screenshot: "+err.Error())
+	} else {
+		step.Screenshot = prefix + ".png"
+		trace.addFile(step.Screenshot, data)
+	}
+	if snapshot, err := trace.tab.DOMSnapshotText(); nil != err {
+		captureErrs = append(captureErrs, "DOM snapshot: "+err.Error())
+	} else {
+		step.DOM = prefix + ".dom.txt"
+		trace.addFile(step.DOM, []byte(snapshot))
+	}
+	var url string
+	if err := trace.tab.Eval("location.href", &url); nil == err {
+		step.URL = url
+	}
+	step.CaptureError = strings.Join(captureErrs, "; ")
+	step.End = time.Now()
+
+	trace.mux.Lock()
+	trace.steps = append(trace.steps, step)
+	trace.mux.Unlock()
+}
+
+/*
+addFile adds a file to the archive.
+*/
+func (trace *Trace) addFile(name string, data []byte) {
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	trace.files[name] = data
+}
+
+/*
+requestWillBeSent records a request.
+*/
+func (trace *Trace) requestWillBeSent(event *network.RequestWillBeSentEvent) {
+	if nil != event.Err || nil == event.Request {
+		return
+	}
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	request := &TraceRequest{
+		Step:   len(trace.steps),
+		Time:   time.Now(),
+		Method: event.Request.Method,
+		URL:    event.Request.URL,
+		Type:   event.Type.String(),
+	}
+	trace.requests = append(trace.requests, request)
+	trace.pending[event.RequestID] = request
+}
+
+/*
+responseReceived records the status of a request.
+*/
+func (trace *Trace) responseReceived(event *network.ResponseReceivedEvent) {
+	if nil != event.Err || nil == event.Response {
+		return
+	}
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	if request, ok := trace.pending[event.RequestID]; ok {
+		request.Status = event.Response.Status
+		request.MimeType = event.Response.MimeType
+		delete(trace.pending, event.RequestID)
+	}
+}
+
+/*
+loadingFailed records the failure of a request.
+*/
+func (trace *Trace) loadingFailed(event *network.LoadingFailedEvent) {
+	if nil != event.Err {
+		return
+	}
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	if request, ok := trace.pending[event.RequestID]; ok {
+		request.Failure = event.ErrorText
+		delete(trace.pending, event.RequestID)
+	}
+}
+
+/*
+consoleAPICalled records a console message.
+*/
+func (trace *Trace) consoleAPICalled(event *runtime.ConsoleAPICalledEvent) {
+	if nil != event.Err {
+		return
+	}
+	args := make([]string, 0, len(event.Args))
+	for _, arg := range event.Args {
+		args = append(args, traceValue(arg))
+	}
+	trace.log(event.Type.String(), strings.Join(args, " "))
+}
+
+/*
+exceptionThrown records an uncaught exception.
+*/
+func (trace *Trace) exceptionThrown(event *runtime.ExceptionThrownEvent) {
+	if nil != event.Err || nil == event.ExceptionDetails {
+		return
+	}
+	text := event.ExceptionDetails.Text
+	if nil != event.ExceptionDetails.Exception && "" != event.ExceptionDetails.Exception.Description {
+		text = event.ExceptionDetails.Exception.Description
+	}
+	trace.log("exception", text)
+}
+
+/*
+log records a console message in the step in progress.
+*/
+func (trace *Trace) log(level, text string) {
+	trace.mux.Lock()
+	defer trace.mux.Unlock()
+	trace.console = append(trace.console, &TraceMessage{
+		Step:  len(trace.steps),
+		Time:  time.Now(),
+		Level: level,
+		Text:  text,
+	})
+}
+
+/*
+traceValue formats a console argument.
+*/
+func traceValue(object *runtime.RemoteObject) string {
+	if nil == object {
+		return ""
+	}
+	switch value := object.Value.(type) {
+	case nil:
+	case string:
+		return value
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+	if "" != object.Description {
+		return object.Description
+	}
+	if "" != object.UnserializableValue.String() {
+		return object.UnserializableValue.String()
+	}
+	return object.Type.String()
+}
+
+/*
+traceFileNames returns the names of the files of an archive, with the viewer
+and the data first.
+*/
+func traceFileNames(files map[string][]byte) []string {
+	names := []string{"index.html", "trace.json"}
+	rest := []string{}
+	for name := range files {
+		if "index.html" != name && "trace.json" != name {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
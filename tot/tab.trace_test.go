@@ -0,0 +1,154 @@
+package chrome
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/socket/cdptest"
+)
+
+type traceTest bool
+
+func (test traceTest) Failed() bool {
+	return bool(test)
+}
+
+func TestTabTrace(t *testing.T) {
+	browser, cdp, stop := newCDPBrowser(t)
+	defer stop()
+	screenshots := 0
+	cdp.Handle("Page.captureScreenshot", func(params json.RawMessage) (interface{}, *cdptest.Error) {
+		screenshots++
+		if 2 == screenshots {
+			return nil, &cdptest.Error{Code: -32000, Message: "Unable to capture screenshot"}
+		}
+		return map[string]interface{}{"data": base64.StdEncoding.EncodeToString([]byte("png"))}, nil
+	})
+	cdp.Respond("DOM.getDocument", map[string]interface{}{
+		"root": map[string]interface{}{"nodeId": 1, "nodeType": 9, "nodeName": "#document", "children": []map[string]interface{}{
+			{"nodeId": 2, "nodeType": 1, "nodeName": "HTML", "localName": "html"},
+		}},
+	})
+	cdp.Respond("Runtime.evaluate", map[string]interface{}{
+		"result": map[string]interface{}{"type": "string", "value": "https://example.com/login"},
+	})
+	tab, err := browser.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	defer tab.Socket().Stop()
+
+	trace, err := tab.StartTrace("login")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	trace.Step("open the login page", func() error {
+		cdp.Emit("Network.requestWillBeSent", map[string]interface{}{
+			"requestId": "1",
+			"type":      "Document",
+			"request":   map[string]interface{}{"method": "GET", "url": "https://example.com/login"},
+		})
+		cdp.Emit("Network.responseReceived", map[string]interface{}{
+			"requestId": "1",
+			"type":      "Document",
+			"response":  map[string]interface{}{"url": "https://example.com/login", "status": 200, "mimeType": "text/html"},
+		})
+		cdp.Emit("Runtime.consoleAPICalled", map[string]interface{}{
+			"type": "log",
+			"args": []map[string]interface{}{{"type": "string", "value": "hello"}, {"type": "number", "value": 42}},
+		})
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			trace.mux.Lock()
+			done := 1 == len(trace.requests) && 200 == trace.requests[0].Status && 1 == len(trace.console)
+			trace.mux.Unlock()
+			if done || time.Now().After(deadline) {
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	if err := trace.Step("submit", func() error {
+		return fmt.Errorf("login failed")
+	}); nil == err || "login failed" != err.Error() {
+		t.Errorf("Expected the step error, got %v", err)
+	}
+
+	steps := trace.Steps()
+	if 2 != len(steps) {
+		t.Fatalf("Expected 2 steps, got %d", len(steps))
+	}
+	if "steps/001.png" != steps[0].Screenshot || "steps/001.dom.txt" != steps[0].DOM || "https://example.com/login" != steps[0].URL {
+		t.Errorf("Expected the first step to be captured, got %+v", steps[0])
+	}
+	if "login failed" != steps[1].Error || "" != steps[1].Screenshot || !strings.Contains(steps[1].CaptureError, "screenshot") {
+		t.Errorf("Expected the second step to record the errors, got %+v", steps[1])
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := trace.Write(buffer); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if nil != err {
+		t.Fatalf("Expected a zip archive, got error: %v", err)
+	}
+	files := map[string]string{}
+	names := []string{}
+	for _, file := range archive.File {
+		fh, _ := file.Open()
+		data, _ := ioutil.ReadAll(fh)
+		fh.Close()
+		files[file.Name] = string(data)
+		names = append(names, file.Name)
+	}
+	if "index.html,trace.json,steps/001.dom.txt,steps/001.png,steps/002.dom.txt" != strings.Join(names, ",") {
+		t.Errorf("Unexpected files: %v", names)
+	}
+	if "png" != files["steps/001.png"] || "html" != strings.TrimSpace(files["steps/001.dom.txt"]) {
+		t.Errorf("Expected the captures, got %q and %q", files["steps/001.png"], files["steps/001.dom.txt"])
+	}
+
+	data := struct {
+		Title    string
+		Requests []*TraceRequest
+		Console  []*TraceMessage
+	}{}
+	if err := json.Unmarshal([]byte(files["trace.json"]), &data); nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if "login" != data.Title || 1 != len(data.Requests) || 0 != data.Requests[0].Step || 200 != data.Requests[0].Status || "Document" != data.Requests[0].Type {
+		t.Errorf("Expected the request to be recorded in the first step, got %+v", data.Requests)
+	}
+	if 1 != len(data.Console) || "log" != data.Console[0].Level || "hello 42" != data.Console[0].Text {
+		t.Errorf("Expected the console message to be recorded, got %+v", data.Console)
+	}
+	if strings.Contains(files["index.html"], "/*TRACE*/") || !strings.Contains(files["index.html"], `"title":"login"`) {
+		t.Errorf("Expected the viewer to embed the trace data")
+	}
+
+	dir, _ := ioutil.TempDir("", "trace")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "trace.zip")
+	if err := trace.SaveOnFailure(traceTest(false), file); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Expected no trace for a passing test")
+	}
+	if err := trace.SaveOnFailure(traceTest(true), file); nil != err {
+		t.Errorf("Expected nil, received error: %v", err)
+	}
+	if _, err := os.Stat(file); nil != err {
+		t.Errorf("Expected the trace to be saved for a failing test: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package chrome
+
+/*
+traceViewer is the index.html page of trace archives. The trace data replaces
+the placeholder so the page works when opened from the extracted files, where
+browsers don't allow it to load trace.json.
+*/
+const traceViewer = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Trace</title>
+<style>
+body { margin: 0; font: 13px sans-serif; display: flex; height: 100vh; }
+nav { width: 260px; overflow-y: auto; border-right: 1px solid #ccc; background: #f7f7f7; }
+nav h1 { font-size: 15px; margin: 12px; }
+nav a { display: block; padding: 6px 12px; color: inherit; text-decoration: none; border-left: 3px solid transparent; }
+nav a.selected { background: #e2ecf8; border-left-color: #3b78c4; }
+nav a.failed { color: #b00020; }
+main { flex: 1; overflow-y: auto; padding: 12px 20px; }
+main img { max-width: 100%; border: 1px solid #ccc; }
+pre { background: #f7f7f7; padding: 8px; overflow: auto; max-height: 400px; }
+table { border-collapse: collapse; width: 100%; }
+td, th { text-align: left; padding: 2px 8px; border-bottom: 1px solid #eee; vertical-align: top; }
+.error, .exception { color: #b00020; }
+.warning { color: #a86400; }
+</style>
+</head>
+<body>
+<nav id="steps"></nav>
+<main id="step"></main>
+<script>
+const trace = /*TRACE*/null;
+
+function el(tag, attrs, ...children) {
+	const node = document.createElement(tag);
+	Object.assign(node, attrs || {});
+	children.forEach(child => node.append(child));
+	return node;
+}
+
+function show(index) {
+	document.querySelectorAll("nav a").forEach((link, i) => link.classList.toggle("selected", i === index));
+	const step = trace.steps[index];
+	const main = document.getElementById("step");
+	main.replaceChildren(el("h2", {}, step.title));
+	const ms = new Date(step.end) - new Date(step.start);
+	main.append(el("p", {}, (step.url || "") + " (" + ms + "ms)"));
+	if (step.error) {
+		main.append(el("pre", {className: "error"}, step.error));
+	}
+	if (step.captureError) {
+		main.append(el("p", {className: "warning"}, step.captureError));
+	}
+	if (step.screenshot) {
+		main.append(el("img", {src: step.screenshot}));
+	}
+
+	const requests = (trace.requests || []).filter(r => r.step === index);
+	main.append(el("h3", {}, "Network (" + requests.length + ")"));
+	const network = el("table");
+	requests.forEach(r => network.append(el("tr", {className: r.failure || r.status >= 400 ? "error" : ""},
+		el("td", {}, r.method), el("td", {}, String(r.failure || r.status || "")), el("td", {}, r.type || ""), el("td", {}, r.url))));
+	main.append(network);
+
+	const messages = (trace.console || []).filter(m => m.step === index);
+	main.append(el("h3", {}, "Console (" + messages.length + ")"));
+	const console = el("table");
+	messages.forEach(m => console.append(el("tr", {className: m.level}, el("td", {}, m.level), el("td", {}, m.text))));
+	main.append(console);
+
+	if (step.dom) {
+		const dom = el("pre", {}, "loading...");
+		main.append(el("h3", {}, "DOM"), dom);
+		fetch(step.dom).then(r => r.text()).then(text => dom.textContent = text)
+			.catch(() => dom.replaceChildren(el("a", {href: step.dom}, step.dom)));
+	}
+}
+
+const nav = document.getElementById("steps");
+nav.append(el("h1", {}, trace.title || "Trace"));
+(trace.steps || []).forEach((step, i) => {
+	const link = el("a", {href: "#", className: step.error ? "failed" : ""}, (i + 1) + ". " + step.title);
+	link.onclick = e => { e.preventDefault(); show(i); };
+	nav.append(link);
+});
+if ((trace.steps || []).length) {
+	show(trace.steps.findIndex(s => s.error) >= 0 ? trace.steps.findIndex(s => s.error) : trace.steps.length - 1);
+}
+</script>
+</body>
+</html>
+`
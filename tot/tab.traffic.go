@@ -0,0 +1,128 @@
+package chrome
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+/*
+TrafficRule shapes the requests sent to matching origins. Network condition
+emulation applies to every request a tab makes, traffic rules only apply to
+the listed origins, for example to slow down third-party scripts or to
+simulate an unavailable CDN.
+*/
+type TrafficRule struct {
+	// Optional. Origin patterns the rule applies to, such as
+	// "https://*.cdn.example.com". Wildcards ('*' -> zero or more, '?' ->
+	// exactly one) are allowed. Omitting is equivalent to "*".
+	Origins []string
+
+	// Optional. Origin patterns the rule doesn't apply to. Listing the
+	// first-party origins with Origins omitted matches all third-party
+	// origins.
+	Exclude []string
+
+	// Optional. A delay added before matching requests are sent.
+	Latency time.Duration
+
+	// Optional. If set, matching requests fail with the given reason after
+	// the delay.
+	ErrorReason network.ErrorReasonEnum
+
+	exclude []*regexp.Regexp
+	origins []*regexp.Regexp
+}
+
+/*
+match returns whether the rule applies to an origin.
+*/
+func (rule *TrafficRule) match(origin string) bool {
+	for _, pattern := range rule.exclude {
+		if pattern.MatchString(origin) {
+			return false
+		}
+	}
+	if 0 == len(rule.origins) {
+		return true
+	}
+	for _, pattern := range rule.origins {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ShapeTraffic applies traffic rules to the requests the tab makes using Fetch
+interception. The first matching rule is applied to a request, requests that
+don't match a rule continue unchanged:
+
+	err := tab.ShapeTraffic(
+		&chrome.TrafficRule{
+			Origins:     []string{"https://cdn.example.com"},
+			ErrorReason: network.ErrorReason.ConnectionRefused,
+		},
+		&chrome.TrafficRule{
+			Exclude: []string{"https://example.com"},
+			Latency: 2 * time.Second,
+		},
+	)
+
+Traffic rules are registered as an Interceptor handler that matches every URL,
+so handlers added to the tab's Interceptor afterwards are never used.
+*/
+func (tab *Tab) ShapeTraffic(rules ...*TrafficRule) error {
+	for _, rule := range rules {
+		rule.origins = make([]*regexp.Regexp, len(rule.Origins))
+		for a, pattern := range rule.Origins {
+			rule.origins[a] = wildcardPattern(pattern)
+		}
+		rule.exclude = make([]*regexp.Regexp, len(rule.Exclude))
+		for a, pattern := range rule.Exclude {
+			rule.exclude[a] = wildcardPattern(pattern)
+		}
+	}
+
+	return tab.Interceptor().Handle(&fetch.RequestPattern{URLPattern: "*"}, func(event *fetch.RequestPausedEvent) error {
+		var rule *TrafficRule
+		if origin, err := requestOrigin(event.Request.URL); nil == err {
+			for _, r := range rules {
+				if r.match(origin) {
+					rule = r
+					break
+				}
+			}
+		}
+		if nil == rule {
+			return (<-tab.Fetch().ContinueRequest(&fetch.ContinueRequestParams{RequestID: event.RequestID})).Err
+		}
+
+		if rule.Latency > 0 {
+			time.Sleep(rule.Latency)
+		}
+		if 0 != rule.ErrorReason {
+			return (<-tab.Fetch().FailRequest(&fetch.FailRequestParams{
+				RequestID:   event.RequestID,
+				ErrorReason: rule.ErrorReason,
+			})).Err
+		}
+		return (<-tab.Fetch().ContinueRequest(&fetch.ContinueRequestParams{RequestID: event.RequestID})).Err
+	})
+}
+
+/*
+requestOrigin returns the origin of a request URL.
+*/
+func requestOrigin(requestURL string) (string, error) {
+	parsed, err := url.Parse(requestURL)
+	if nil != err {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host), nil
+}
@@ -0,0 +1,70 @@
+package chrome
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/fetch"
+	"github.com/mkenney/go-chrome/tot/network"
+)
+
+func TestTabShapeTraffic(t *testing.T) {
+	browser := NewMock(&Flags{}, "", "", "", "")
+	tab, _ := browser.NewTab("https://TestTabShapeTraffic")
+	rec := newRecordingSocket(tab.URL())
+	tab.socket = rec
+	tab.protocol = rec
+
+	err := tab.ShapeTraffic(
+		&TrafficRule{
+			Origins:     []string{"https://cdn.example.com"},
+			ErrorReason: network.ErrorReason.ConnectionRefused,
+		},
+		&TrafficRule{
+			Exclude: []string{"https://example.com", "https://*.example.com"},
+			Latency: 50 * time.Millisecond,
+		},
+	)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: %v", err)
+	}
+	if methods := rec.reset(); "Fetch.enable" != strings.Join(methods, ",") {
+		t.Errorf("Expected Fetch.enable, received %v", methods)
+	}
+
+	pause := func(url string) time.Duration {
+		start := time.Now()
+		rec.emit("Fetch.requestPaused", `{"requestId": "1", "request": {"url": "`+url+`", "method": "GET", "headers": {}}, "resourceType": "Script"}`)
+		return time.Since(start)
+	}
+
+	if elapsed := pause("https://cdn.example.com/lib.js"); elapsed >= 50*time.Millisecond {
+		t.Errorf("Expected no delay, received %v", elapsed)
+	}
+	if 1 != len(rec.commands) || "Fetch.failRequest" != rec.commands[0].Method() {
+		t.Fatalf("Expected Fetch.failRequest, received %v", rec.methods)
+	}
+	if network.ErrorReason.ConnectionRefused != rec.commands[0].Params().(*fetch.FailRequestParams).ErrorReason {
+		t.Errorf("Expected the request to be refused")
+	}
+	rec.reset()
+
+	if elapsed := pause("https://tracker.net/t.js?id=1"); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected a delay, received %v", elapsed)
+	}
+	if 1 != len(rec.commands) || "Fetch.continueRequest" != rec.commands[0].Method() {
+		t.Errorf("Expected Fetch.continueRequest, received %v", rec.methods)
+	}
+	rec.reset()
+
+	for _, url := range []string{"https://example.com/", "https://www.example.com/app.js"} {
+		if elapsed := pause(url); elapsed >= 50*time.Millisecond {
+			t.Errorf("Expected no delay for %s, received %v", url, elapsed)
+		}
+		if 1 != len(rec.commands) || "Fetch.continueRequest" != rec.commands[0].Method() {
+			t.Errorf("Expected Fetch.continueRequest for %s, received %v", url, rec.methods)
+		}
+		rec.reset()
+	}
+}
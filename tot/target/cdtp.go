@@ -63,3 +63,34 @@ type RemoteLocation struct {
 	// desc.
 	Port int `json:"port"`
 }
+
+/*
+ProtocolAccess is the opt-in required to expose the DevTools protocol to a
+target with Target.exposeDevToolsProtocol. The zero value doesn't grant access,
+only GrantProtocolAccess does, so exposing the protocol can't happen by
+accident or through decoded input.
+
+An exposed target's scripts can send any protocol command to the browser
+through the binding. That includes reading cookies and storage of every origin,
+navigating and closing other targets, granting permissions and downloading
+files, and it persists across navigations of the target. Only grant access to
+pages you control, and don't navigate exposed targets to untrusted content.
+*/
+type ProtocolAccess struct {
+	granted bool
+}
+
+/*
+GrantProtocolAccess explicitly grants a target access to the DevTools protocol.
+See ProtocolAccess for the security implications.
+*/
+func GrantProtocolAccess() ProtocolAccess {
+	return ProtocolAccess{granted: true}
+}
+
+/*
+Granted returns whether the access was granted with GrantProtocolAccess.
+*/
+func (access ProtocolAccess) Granted() bool {
+	return access.granted
+}
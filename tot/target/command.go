@@ -28,6 +28,10 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-attachToTa
 type AttachToTargetParams struct {
 	// Target ID.
 	ID ID `json:"targetId"`
+
+	// Optional. Enables "flat" access to the session via specifying sessionId
+	// attribute in the commands.
+	Flatten bool `json:"flatten,omitempty"`
 }
 
 /*
@@ -66,6 +70,24 @@ type CloseTargetResult struct {
 	Err error `json:"-"`
 }
 
+/*
+CreateBrowserContextParams represents Target.createBrowserContext parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createBrowserContext
+*/
+type CreateBrowserContextParams struct {
+	// Optional. If specified, disposes this context when debugging session
+	// disconnects.
+	DisposeOnDetach bool `json:"disposeOnDetach,omitempty"`
+
+	// Optional. Proxy server, similar to the one passed to --proxy-server.
+	ProxyServer string `json:"proxyServer,omitempty"`
+
+	// Optional. Proxy bypass list, similar to the one passed to
+	// --proxy-bypass-list.
+	ProxyBypassList string `json:"proxyBypassList,omitempty"`
+}
+
 /*
 CreateBrowserContextResult represents the result of calls to Target.createBrowserContext.
 
@@ -145,8 +167,8 @@ DisposeBrowserContextParams represents Target.disposeBrowserContext parameters.
 https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-disposeBrowserContext
 */
 type DisposeBrowserContextParams struct {
-	// Target ID.
-	ID ID `json:"targetId"`
+	// The browser context to dispose.
+	BrowserContextID BrowserContextID `json:"browserContextId"`
 }
 
 /*
@@ -161,6 +183,36 @@ type DisposeBrowserContextResult struct {
 	Err error `json:"-"`
 }
 
+/*
+ExposeDevToolsProtocolParams represents Target.exposeDevToolsProtocol parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-exposeDevToolsProtocol
+EXPERIMENTAL.
+*/
+type ExposeDevToolsProtocolParams struct {
+	// Target ID.
+	ID ID `json:"targetId"`
+
+	// Optional. Binding name, 'cdp' if not specified.
+	BindingName string `json:"bindingName,omitempty"`
+
+	// The opt-in to expose the protocol, see ProtocolAccess. The command is
+	// refused without GrantProtocolAccess. Not sent to the browser.
+	Access ProtocolAccess `json:"-"`
+}
+
+/*
+ExposeDevToolsProtocolResult represents the result of calls to
+Target.exposeDevToolsProtocol.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-exposeDevToolsProtocol
+EXPERIMENTAL.
+*/
+type ExposeDevToolsProtocolResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
 /*
 GetTargetInfoParams represents Target.getTargetInfo parameters.
 
@@ -0,0 +1,130 @@
+/*
+Package authn provides type definitions for use with the Chrome WebAuthn protocol
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/
+*/
+package authn
+
+/*
+AuthenticatorID is the unique identifier of a virtual authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-AuthenticatorId
+*/
+type AuthenticatorID string
+
+/*
+VirtualAuthenticatorOptions describes a virtual authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-VirtualAuthenticatorOptions
+*/
+type VirtualAuthenticatorOptions struct {
+	// The protocol the authenticator speaks. Allowed values:
+	//	- AuthenticatorProtocol.U2F
+	//	- AuthenticatorProtocol.Ctap2
+	Protocol AuthenticatorProtocolEnum `json:"protocol"`
+
+	// Optional. The CTAP2 version. Defaults to Ctap2Version.Ctap20. Ignored if
+	// Protocol is AuthenticatorProtocol.U2F. Allowed values:
+	//	- Ctap2Version.Ctap20
+	//	- Ctap2Version.Ctap21
+	Ctap2Version Ctap2VersionEnum `json:"ctap2Version,omitempty"`
+
+	// The transport the authenticator is attached with. Allowed values:
+	//	- AuthenticatorTransport.USB
+	//	- AuthenticatorTransport.NFC
+	//	- AuthenticatorTransport.BLE
+	//	- AuthenticatorTransport.Cable
+	//	- AuthenticatorTransport.Internal
+	Transport AuthenticatorTransportEnum `json:"transport"`
+
+	// Optional. Whether the authenticator supports resident keys (passkeys).
+	// Defaults to false.
+	HasResidentKey bool `json:"hasResidentKey,omitempty"`
+
+	// Optional. Whether the authenticator supports user verification. Defaults
+	// to false.
+	HasUserVerification bool `json:"hasUserVerification,omitempty"`
+
+	// Optional. If set to true, the authenticator will support the largeBlob
+	// extension. https://w3c.github.io/webauthn#largeBlob Defaults to false.
+	HasLargeBlob bool `json:"hasLargeBlob,omitempty"`
+
+	// Optional. If set to true, the authenticator will support the credBlob
+	// extension. Defaults to false.
+	HasCredBlob bool `json:"hasCredBlob,omitempty"`
+
+	// Optional. If set to true, the authenticator will support the
+	// minPinLength extension. Defaults to false.
+	HasMinPinLength bool `json:"hasMinPinLength,omitempty"`
+
+	// Optional. If set to true, the authenticator will support the prf
+	// extension. https://w3c.github.io/webauthn/#prf-extension Defaults to
+	// false.
+	HasPrf bool `json:"hasPrf,omitempty"`
+
+	// Optional. If set to true, tests of user presence will succeed
+	// immediately. Otherwise, they will not be resolved. Chrome defaults this
+	// to true, so a false value is omitted; use
+	// WebAuthn.setAutomaticPresenceSimulation to disable it.
+	AutomaticPresenceSimulation bool `json:"automaticPresenceSimulation,omitempty"`
+
+	// Optional. Sets whether User Verification succeeds or fails for an
+	// authenticator. Defaults to false.
+	IsUserVerified bool `json:"isUserVerified,omitempty"`
+
+	// Optional. Credentials created by this authenticator will have the backup
+	// eligibility (BE) flag set to this value. Defaults to false.
+	DefaultBackupEligibility bool `json:"defaultBackupEligibility,omitempty"`
+
+	// Optional. Credentials created by this authenticator will have the backup
+	// state (BS) flag set to this value. Defaults to false.
+	DefaultBackupState bool `json:"defaultBackupState,omitempty"`
+}
+
+/*
+Credential is a credential stored by a virtual authenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-Credential
+*/
+type Credential struct {
+	// The credential ID, base64 encoded.
+	CredentialID string `json:"credentialId"`
+
+	// Whether the credential is a resident (discoverable) credential.
+	IsResidentCredential bool `json:"isResidentCredential"`
+
+	// Optional. Relying Party ID the credential is scoped to. Must be set when
+	// adding a credential.
+	RpID string `json:"rpId,omitempty"`
+
+	// The ECDSA P-256 private key in PKCS#8 format, base64 encoded.
+	PrivateKey string `json:"privateKey"`
+
+	// Optional. An opaque byte sequence with a maximum size of 64 bytes
+	// mapping the credential to a specific user, base64 encoded.
+	UserHandle string `json:"userHandle,omitempty"`
+
+	// Signature counter. This is incremented by one for each successful
+	// assertion. See https://w3c.github.io/webauthn/#signature-counter.
+	SignCount int `json:"signCount"`
+
+	// Optional. The large blob associated with the credential, base64
+	// encoded.
+	LargeBlob string `json:"largeBlob,omitempty"`
+
+	// Optional. Assertions returned by this credential will have the backup
+	// eligibility (BE) flag set to this value. Defaults to the authenticator's
+	// defaultBackupEligibility value.
+	BackupEligibility bool `json:"backupEligibility,omitempty"`
+
+	// Optional. Assertions returned by this credential will have the backup
+	// state (BS) flag set to this value. Defaults to the authenticator's
+	// defaultBackupState value.
+	BackupState bool `json:"backupState,omitempty"`
+
+	// Optional. The credential's user.name property.
+	UserName string `json:"userName,omitempty"`
+
+	// Optional. The credential's user.displayName property.
+	UserDisplayName string `json:"userDisplayName,omitempty"`
+}
@@ -0,0 +1,311 @@
+package authn
+
+/*
+AddCredentialParams represents WebAuthn.addCredential parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addCredential
+*/
+type AddCredentialParams struct {
+	// The authenticator to add the credential to.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The credential to add.
+	Credential *Credential `json:"credential"`
+}
+
+/*
+AddCredentialResult represents the result of calls to WebAuthn.addCredential.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addCredential
+*/
+type AddCredentialResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+AddVirtualAuthenticatorParams represents WebAuthn.addVirtualAuthenticator
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addVirtualAuthenticator
+*/
+type AddVirtualAuthenticatorParams struct {
+	// The authenticator to create.
+	Options *VirtualAuthenticatorOptions `json:"options"`
+}
+
+/*
+AddVirtualAuthenticatorResult represents the result of calls to
+WebAuthn.addVirtualAuthenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-addVirtualAuthenticator
+*/
+type AddVirtualAuthenticatorResult struct {
+	// The ID of the new authenticator.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+ClearCredentialsParams represents WebAuthn.clearCredentials parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-clearCredentials
+*/
+type ClearCredentialsParams struct {
+	// The authenticator to clear.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+}
+
+/*
+ClearCredentialsResult represents the result of calls to
+WebAuthn.clearCredentials.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-clearCredentials
+*/
+type ClearCredentialsResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+DisableResult represents the result of calls to WebAuthn.disable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-disable
+*/
+type DisableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+EnableParams represents WebAuthn.enable parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-enable
+*/
+type EnableParams struct {
+	// Optional. Whether to enable the WebAuthn user interface. Enabling the UI
+	// is recommended for debugging and demo purposes, disabling it is
+	// recommended for automated testing. Defaults to false.
+	EnableUI bool `json:"enableUI,omitempty"`
+}
+
+/*
+EnableResult represents the result of calls to WebAuthn.enable.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-enable
+*/
+type EnableResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetCredentialParams represents WebAuthn.getCredential parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredential
+*/
+type GetCredentialParams struct {
+	// The authenticator holding the credential.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The base64 encoded credential ID.
+	CredentialID string `json:"credentialId"`
+}
+
+/*
+GetCredentialResult represents the result of calls to WebAuthn.getCredential.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredential
+*/
+type GetCredentialResult struct {
+	// The credential.
+	Credential *Credential `json:"credential"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+GetCredentialsParams represents WebAuthn.getCredentials parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredentials
+*/
+type GetCredentialsParams struct {
+	// The authenticator holding the credentials.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+}
+
+/*
+GetCredentialsResult represents the result of calls to WebAuthn.getCredentials.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-getCredentials
+*/
+type GetCredentialsResult struct {
+	// All credentials registered with the authenticator.
+	Credentials []*Credential `json:"credentials"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+RemoveCredentialParams represents WebAuthn.removeCredential parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeCredential
+*/
+type RemoveCredentialParams struct {
+	// The authenticator holding the credential.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The base64 encoded credential ID.
+	CredentialID string `json:"credentialId"`
+}
+
+/*
+RemoveCredentialResult represents the result of calls to
+WebAuthn.removeCredential.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeCredential
+*/
+type RemoveCredentialResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+RemoveVirtualAuthenticatorParams represents WebAuthn.removeVirtualAuthenticator
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeVirtualAuthenticator
+*/
+type RemoveVirtualAuthenticatorParams struct {
+	// The authenticator to remove.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+}
+
+/*
+RemoveVirtualAuthenticatorResult represents the result of calls to
+WebAuthn.removeVirtualAuthenticator.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-removeVirtualAuthenticator
+*/
+type RemoveVirtualAuthenticatorResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetAutomaticPresenceSimulationParams represents
+WebAuthn.setAutomaticPresenceSimulation parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setAutomaticPresenceSimulation
+*/
+type SetAutomaticPresenceSimulationParams struct {
+	// The authenticator to update.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// Whether tests of user presence succeed immediately.
+	Enabled bool `json:"enabled"`
+}
+
+/*
+SetAutomaticPresenceSimulationResult represents the result of calls to
+WebAuthn.setAutomaticPresenceSimulation.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setAutomaticPresenceSimulation
+*/
+type SetAutomaticPresenceSimulationResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetCredentialPropertiesParams represents WebAuthn.setCredentialProperties
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setCredentialProperties
+*/
+type SetCredentialPropertiesParams struct {
+	// The authenticator holding the credential.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The base64 encoded credential ID.
+	CredentialID string `json:"credentialId"`
+
+	// Optional. The backup eligibility (BE) flag.
+	BackupEligibility bool `json:"backupEligibility,omitempty"`
+
+	// Optional. The backup state (BS) flag.
+	BackupState bool `json:"backupState,omitempty"`
+}
+
+/*
+SetCredentialPropertiesResult represents the result of calls to
+WebAuthn.setCredentialProperties.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setCredentialProperties
+*/
+type SetCredentialPropertiesResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetResponseOverrideBitsParams represents WebAuthn.setResponseOverrideBits
+parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setResponseOverrideBits
+*/
+type SetResponseOverrideBitsParams struct {
+	// The authenticator to update.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// Optional. If set, overrides the signature in the authenticator response
+	// to be zero. Defaults to false.
+	IsBogusSignature bool `json:"isBogusSignature,omitempty"`
+
+	// Optional. If set, overrides the UV bit in the flags in the
+	// authenticator response to be zero. Defaults to false.
+	IsBadUV bool `json:"isBadUV,omitempty"`
+
+	// Optional. If set, overrides the UP bit in the flags in the
+	// authenticator response to be zero. Defaults to false.
+	IsBadUP bool `json:"isBadUP,omitempty"`
+}
+
+/*
+SetResponseOverrideBitsResult represents the result of calls to
+WebAuthn.setResponseOverrideBits.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setResponseOverrideBits
+*/
+type SetResponseOverrideBitsResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
+
+/*
+SetUserVerifiedParams represents WebAuthn.setUserVerified parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setUserVerified
+*/
+type SetUserVerifiedParams struct {
+	// The authenticator to update.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// Whether user verification succeeds.
+	IsUserVerified bool `json:"isUserVerified"`
+}
+
+/*
+SetUserVerifiedResult represents the result of calls to
+WebAuthn.setUserVerified.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#method-setUserVerified
+*/
+type SetUserVerifiedResult struct {
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
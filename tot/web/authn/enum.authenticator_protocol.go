@@ -0,0 +1,77 @@
+package authn
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type authenticatorProtocolEnum struct {
+	U2F   AuthenticatorProtocolEnum
+	Ctap2 AuthenticatorProtocolEnum
+}
+
+/*
+AuthenticatorProtocol provides named access to the AuthenticatorProtocolEnum values.
+*/
+var AuthenticatorProtocol = authenticatorProtocolEnum{
+	U2F:   authenticatorProtocolU2F,
+	Ctap2: authenticatorProtocolCtap2,
+}
+
+/*
+AuthenticatorProtocolEnum represents the protocol a virtual authenticator
+speaks. Allowed Values:
+	- AuthenticatorProtocol.U2F   "u2f"
+	- AuthenticatorProtocol.Ctap2 "ctap2"
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-AuthenticatorProtocol
+*/
+type AuthenticatorProtocolEnum int
+
+/*
+String implements Stringer
+*/
+func (enum AuthenticatorProtocolEnum) String() string {
+	return _authenticatorProtocolEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum AuthenticatorProtocolEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *AuthenticatorProtocolEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _authenticatorProtocolEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// authenticatorProtocolU2F represents the "u2f" value.
+	authenticatorProtocolU2F AuthenticatorProtocolEnum = iota + 1
+	// authenticatorProtocolCtap2 represents the "ctap2" value.
+	authenticatorProtocolCtap2
+)
+
+var _authenticatorProtocolEnums = map[AuthenticatorProtocolEnum]string{
+	authenticatorProtocolU2F:   "u2f",
+	authenticatorProtocolCtap2: "ctap2",
+}
@@ -0,0 +1,51 @@
+package authn
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumAuthenticatorProtocol(t *testing.T) {
+	var enum AuthenticatorProtocolEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = AuthenticatorProtocol.U2F
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"u2f"` != string(result) {
+		t.Errorf("Expected '\"u2f\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"u2f"`), &enum)
+	if AuthenticatorProtocol.U2F != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorProtocol.U2F, enum)
+	}
+
+	enum = AuthenticatorProtocol.Ctap2
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"ctap2"` != string(result) {
+		t.Errorf("Expected '\"ctap2\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"ctap2"`), &enum)
+	if AuthenticatorProtocol.Ctap2 != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorProtocol.Ctap2, enum)
+	}
+}
@@ -0,0 +1,95 @@
+package authn
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type authenticatorTransportEnum struct {
+	USB      AuthenticatorTransportEnum
+	NFC      AuthenticatorTransportEnum
+	BLE      AuthenticatorTransportEnum
+	Cable    AuthenticatorTransportEnum
+	Internal AuthenticatorTransportEnum
+}
+
+/*
+AuthenticatorTransport provides named access to the AuthenticatorTransportEnum values.
+*/
+var AuthenticatorTransport = authenticatorTransportEnum{
+	USB:      authenticatorTransportUSB,
+	NFC:      authenticatorTransportNFC,
+	BLE:      authenticatorTransportBLE,
+	Cable:    authenticatorTransportCable,
+	Internal: authenticatorTransportInternal,
+}
+
+/*
+AuthenticatorTransportEnum represents the transport a virtual authenticator is
+attached with. Allowed Values:
+	- AuthenticatorTransport.USB      "usb"
+	- AuthenticatorTransport.NFC      "nfc"
+	- AuthenticatorTransport.BLE      "ble"
+	- AuthenticatorTransport.Cable    "cable"
+	- AuthenticatorTransport.Internal "internal"
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-AuthenticatorTransport
+*/
+type AuthenticatorTransportEnum int
+
+/*
+String implements Stringer
+*/
+func (enum AuthenticatorTransportEnum) String() string {
+	return _authenticatorTransportEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum AuthenticatorTransportEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *AuthenticatorTransportEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _authenticatorTransportEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// authenticatorTransportUSB represents the "usb" value.
+	authenticatorTransportUSB AuthenticatorTransportEnum = iota + 1
+	// authenticatorTransportNFC represents the "nfc" value.
+	authenticatorTransportNFC
+	// authenticatorTransportBLE represents the "ble" value.
+	authenticatorTransportBLE
+	// authenticatorTransportCable represents the "cable" value.
+	authenticatorTransportCable
+	// authenticatorTransportInternal represents the "internal" value.
+	authenticatorTransportInternal
+)
+
+var _authenticatorTransportEnums = map[AuthenticatorTransportEnum]string{
+	authenticatorTransportUSB:      "usb",
+	authenticatorTransportNFC:      "nfc",
+	authenticatorTransportBLE:      "ble",
+	authenticatorTransportCable:    "cable",
+	authenticatorTransportInternal: "internal",
+}
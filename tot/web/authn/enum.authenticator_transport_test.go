@@ -0,0 +1,90 @@
+package authn
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumAuthenticatorTransport(t *testing.T) {
+	var enum AuthenticatorTransportEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = AuthenticatorTransport.USB
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"usb"` != string(result) {
+		t.Errorf("Expected '\"usb\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"usb"`), &enum)
+	if AuthenticatorTransport.USB != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorTransport.USB, enum)
+	}
+
+	enum = AuthenticatorTransport.NFC
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"nfc"` != string(result) {
+		t.Errorf("Expected '\"nfc\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"nfc"`), &enum)
+	if AuthenticatorTransport.NFC != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorTransport.NFC, enum)
+	}
+
+	enum = AuthenticatorTransport.BLE
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"ble"` != string(result) {
+		t.Errorf("Expected '\"ble\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"ble"`), &enum)
+	if AuthenticatorTransport.BLE != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorTransport.BLE, enum)
+	}
+
+	enum = AuthenticatorTransport.Cable
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"cable"` != string(result) {
+		t.Errorf("Expected '\"cable\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"cable"`), &enum)
+	if AuthenticatorTransport.Cable != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorTransport.Cable, enum)
+	}
+
+	enum = AuthenticatorTransport.Internal
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"internal"` != string(result) {
+		t.Errorf("Expected '\"internal\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"internal"`), &enum)
+	if AuthenticatorTransport.Internal != enum {
+		t.Errorf("Expcected %d, got %d", AuthenticatorTransport.Internal, enum)
+	}
+}
@@ -0,0 +1,77 @@
+package authn
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type ctap2VersionEnum struct {
+	Ctap20 Ctap2VersionEnum
+	Ctap21 Ctap2VersionEnum
+}
+
+/*
+Ctap2Version provides named access to the Ctap2VersionEnum values.
+*/
+var Ctap2Version = ctap2VersionEnum{
+	Ctap20: ctap2VersionCtap20,
+	Ctap21: ctap2VersionCtap21,
+}
+
+/*
+Ctap2VersionEnum represents the CTAP2 version of a virtual authenticator.
+Allowed Values:
+	- Ctap2Version.Ctap20 "ctap2_0"
+	- Ctap2Version.Ctap21 "ctap2_1"
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#type-Ctap2Version
+*/
+type Ctap2VersionEnum int
+
+/*
+String implements Stringer
+*/
+func (enum Ctap2VersionEnum) String() string {
+	return _ctap2VersionEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum Ctap2VersionEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *Ctap2VersionEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _ctap2VersionEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// ctap2VersionCtap20 represents the "ctap2_0" value.
+	ctap2VersionCtap20 Ctap2VersionEnum = iota + 1
+	// ctap2VersionCtap21 represents the "ctap2_1" value.
+	ctap2VersionCtap21
+)
+
+var _ctap2VersionEnums = map[Ctap2VersionEnum]string{
+	ctap2VersionCtap20: "ctap2_0",
+	ctap2VersionCtap21: "ctap2_1",
+}
@@ -0,0 +1,51 @@
+package authn
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumCtap2Version(t *testing.T) {
+	var enum Ctap2VersionEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = Ctap2Version.Ctap20
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"ctap2_0"` != string(result) {
+		t.Errorf("Expected '\"ctap2_0\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"ctap2_0"`), &enum)
+	if Ctap2Version.Ctap20 != enum {
+		t.Errorf("Expcected %d, got %d", Ctap2Version.Ctap20, enum)
+	}
+
+	enum = Ctap2Version.Ctap21
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"ctap2_1"` != string(result) {
+		t.Errorf("Expected '\"ctap2_1\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"ctap2_1"`), &enum)
+	if Ctap2Version.Ctap21 != enum {
+		t.Errorf("Expcected %d, got %d", Ctap2Version.Ctap21, enum)
+	}
+}
@@ -0,0 +1,65 @@
+package authn
+
+/*
+CredentialAddedEvent represents WebAuthn.credentialAdded event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialAdded
+*/
+type CredentialAddedEvent struct {
+	// The authenticator the credential was added to.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The new credential.
+	Credential *Credential `json:"credential"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+CredentialAssertedEvent represents WebAuthn.credentialAsserted event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialAsserted
+*/
+type CredentialAssertedEvent struct {
+	// The authenticator holding the credential.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The asserted credential.
+	Credential *Credential `json:"credential"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+CredentialDeletedEvent represents WebAuthn.credentialDeleted event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialDeleted
+*/
+type CredentialDeletedEvent struct {
+	// The authenticator the credential was deleted from.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The base64 encoded credential ID.
+	CredentialID string `json:"credentialId"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+CredentialUpdatedEvent represents WebAuthn.credentialUpdated event data.
+
+https://chromedevtools.github.io/devtools-protocol/tot/WebAuthn/#event-credentialUpdated
+*/
+type CredentialUpdatedEvent struct {
+	// The authenticator holding the credential.
+	AuthenticatorID AuthenticatorID `json:"authenticatorId"`
+
+	// The updated credential.
+	Credential *Credential `json:"credential"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}